@@ -0,0 +1,390 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package apikey manages named API keys for the RPC and gRPC servers. Each key
+// is restricted to a set of permissions, and optionally to a list of client IP
+// addresses and a list of withdrawal addresses. Every authenticated call is
+// recorded in an audit log. The package also provides the authentication and
+// TLS helpers shared by the API servers.
+package apikey
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/dex/encode"
+)
+
+// Permission is a class of RPC routes that an API key can be granted.
+type Permission string
+
+const (
+	// PermRead allows viewing wallets, orders, books and bot status.
+	PermRead Permission = "read"
+	// PermTrade allows placing and canceling orders and alerts.
+	PermTrade Permission = "trade"
+	// PermBots allows starting, stopping and updating bots.
+	PermBots Permission = "bots"
+	// PermFunds allows sending and withdrawing funds and posting bonds.
+	PermFunds Permission = "funds"
+)
+
+// Permissions are all known permissions.
+var Permissions = []Permission{PermRead, PermTrade, PermBots, PermFunds}
+
+const (
+	keysFilename  = "apikeys.json"
+	auditFilename = "apiaudit.log"
+	secretSize    = 32
+)
+
+// auditMaxSize is the size in bytes at which the audit log is rotated. Only
+// one rotated file is kept, so the audit log uses at most twice this size on
+// disk.
+var auditMaxSize int64 = 4 << 20
+
+var (
+	// ErrUnknownKey is returned when the named key does not exist.
+	ErrUnknownKey = errors.New("unknown api key")
+
+	keyNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+)
+
+// Key is an API key. The secret is not retained, only its hash.
+type Key struct {
+	Name        string       `json:"name"`
+	Permissions []Permission `json:"permissions"`
+	// AllowedIPs are IP addresses or CIDR ranges that may use the key. Empty
+	// allows any address.
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
+	// WithdrawAddrs are the only addresses that funds can be sent to with the
+	// key. Empty allows any address.
+	WithdrawAddrs []string  `json:"withdrawAddrs,omitempty"`
+	Created       time.Time `json:"created"`
+
+	nets []*net.IPNet
+}
+
+// Allows checks whether the key was granted the permission.
+func (k *Key) Allows(p Permission) bool {
+	for _, kp := range k.Permissions {
+		if kp == p {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsIP checks whether the key can be used from the IP address.
+func (k *Key) AllowsIP(ip net.IP) bool {
+	if len(k.nets) == 0 {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	for _, n := range k.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAddress checks whether funds can be sent to the address with the key.
+func (k *Key) AllowsAddress(addr string) bool {
+	if len(k.WithdrawAddrs) == 0 {
+		return true
+	}
+	for _, a := range k.WithdrawAddrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPs parses the IP addresses and CIDR ranges. A single address is
+// treated as a range of one.
+func parseIPs(ips []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(ips))
+	for _, s := range ips {
+		if _, n, err := net.ParseCIDR(s); err == nil {
+			nets = append(nets, n)
+			continue
+		}
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address or CIDR range %q", s)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return nets, nil
+}
+
+// KeyForm is the information needed to create a new key.
+type KeyForm struct {
+	Name          string       `json:"name"`
+	Permissions   []Permission `json:"permissions"`
+	AllowedIPs    []string     `json:"allowedIPs"`
+	WithdrawAddrs []string     `json:"withdrawAddrs"`
+}
+
+// storedKey is a Key with the hash of its secret.
+type storedKey struct {
+	*Key
+	SecretHash string `json:"secretHash"`
+}
+
+// AuditEntry is a record of an authenticated RPC call.
+type AuditEntry struct {
+	Stamp time.Time `json:"stamp"`
+	// Key is the API key name, or empty for the RPC user.
+	Key     string `json:"key,omitempty"`
+	IP      string `json:"ip"`
+	Route   string `json:"route"`
+	Allowed bool   `json:"allowed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Store persists the API keys and the audit log in a directory.
+type Store struct {
+	keysPath       string
+	auditPath      string
+	auditPathPrior string
+
+	mtx  sync.RWMutex
+	keys map[string]*storedKey
+
+	auditMtx sync.Mutex
+}
+
+// NewStore loads the keys stored in the directory.
+func NewStore(dir string) (*Store, error) {
+	s := &Store{
+		keysPath:       filepath.Join(dir, keysFilename),
+		auditPath:      filepath.Join(dir, auditFilename),
+		auditPathPrior: filepath.Join(dir, auditFilename+".1"),
+		keys:           make(map[string]*storedKey),
+	}
+	b, err := os.ReadFile(s.keysPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("error reading api keys file: %w", err)
+	}
+	var keys []*storedKey
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return nil, fmt.Errorf("error parsing api keys file: %w", err)
+	}
+	for _, k := range keys {
+		if k.nets, err = parseIPs(k.AllowedIPs); err != nil {
+			return nil, fmt.Errorf("error parsing allowed IPs for api key %q: %w", k.Name, err)
+		}
+		s.keys[k.Name] = k
+	}
+	return s, nil
+}
+
+// save writes the keys to file. The mtx must be held.
+func (s *Store) save() error {
+	keys := make([]*storedKey, 0, len(s.keys))
+	for _, k := range s.keys {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	b, err := json.MarshalIndent(keys, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error encoding api keys: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.keysPath), 0700); err != nil {
+		return fmt.Errorf("error creating api keys directory: %w", err)
+	}
+	return os.WriteFile(s.keysPath, b, 0600)
+}
+
+func hashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// Create creates a new key, returning its secret. The secret cannot be
+// retrieved again.
+func (s *Store) Create(form *KeyForm) (*Key, string, error) {
+	if !keyNameRegexp.MatchString(form.Name) {
+		return nil, "", fmt.Errorf("invalid key name %q. use 1 to 32 letters, numbers, underscores or hyphens", form.Name)
+	}
+	if len(form.Permissions) == 0 {
+		return nil, "", errors.New("no permissions granted")
+	}
+	perms := make([]Permission, 0, len(form.Permissions))
+	seen := make(map[Permission]bool, len(form.Permissions))
+	for _, p := range form.Permissions {
+		var known bool
+		for _, kp := range Permissions {
+			known = known || p == kp
+		}
+		if !known {
+			return nil, "", fmt.Errorf("unknown permission %q", p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			perms = append(perms, p)
+		}
+	}
+	nets, err := parseIPs(form.AllowedIPs)
+	if err != nil {
+		return nil, "", err
+	}
+	k := &Key{
+		Name:          form.Name,
+		Permissions:   perms,
+		AllowedIPs:    form.AllowedIPs,
+		WithdrawAddrs: form.WithdrawAddrs,
+		Created:       time.Now().Truncate(time.Second),
+		nets:          nets,
+	}
+	secret := hex.EncodeToString(encode.RandomBytes(secretSize))
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, found := s.keys[k.Name]; found {
+		return nil, "", fmt.Errorf("api key %q already exists", k.Name)
+	}
+	s.keys[k.Name] = &storedKey{Key: k, SecretHash: hashSecret(secret)}
+	if err := s.save(); err != nil {
+		delete(s.keys, k.Name)
+		return nil, "", err
+	}
+	return k, secret, nil
+}
+
+// Revoke deletes the key.
+func (s *Store) Revoke(name string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	k, found := s.keys[name]
+	if !found {
+		return ErrUnknownKey
+	}
+	delete(s.keys, name)
+	if err := s.save(); err != nil {
+		s.keys[name] = k
+		return err
+	}
+	return nil
+}
+
+// Keys lists the keys by name.
+func (s *Store) Keys() []*Key {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	keys := make([]*Key, 0, len(s.keys))
+	for _, k := range s.keys {
+		keys = append(keys, k.Key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys
+}
+
+// Authenticate checks the secret for the named key.
+func (s *Store) Authenticate(name, secret string) (*Key, bool) {
+	s.mtx.RLock()
+	k, found := s.keys[name]
+	s.mtx.RUnlock()
+	if !found {
+		return nil, false
+	}
+	if subtle.ConstantTimeCompare([]byte(k.SecretHash), []byte(hashSecret(secret))) != 1 {
+		return nil, false
+	}
+	return k.Key, true
+}
+
+// Audit appends the entry to the audit log. When the log reaches
+// auditMaxSize, it is moved to apiaudit.log.1, replacing any earlier rotated
+// log, and a new log is started.
+func (s *Store) Audit(entry *AuditEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding audit entry: %w", err)
+	}
+	s.auditMtx.Lock()
+	defer s.auditMtx.Unlock()
+	if fi, err := os.Stat(s.auditPath); err == nil && fi.Size()+int64(len(b)+1) > auditMaxSize {
+		if err := os.Rename(s.auditPath, s.auditPathPrior); err != nil {
+			return fmt.Errorf("error rotating audit log: %w", err)
+		}
+	}
+	f, err := os.OpenFile(s.auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening audit log: %w", err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// AuditLog returns up to n of the most recent audit entries, newest first.
+// Entries are read from the current and the rotated audit log.
+func (s *Store) AuditLog(n int) ([]*AuditEntry, error) {
+	s.auditMtx.Lock()
+	defer s.auditMtx.Unlock()
+	var entries []*AuditEntry
+	for _, path := range []string{s.auditPathPrior, s.auditPath} {
+		var err error
+		if entries, err = readAuditLog(path, entries, n); err != nil {
+			return nil, err
+		}
+	}
+	newest := make([]*AuditEntry, len(entries))
+	for i, e := range entries {
+		newest[len(entries)-1-i] = e
+	}
+	return newest, nil
+}
+
+// readAuditLog appends the entries in the audit log file at path to entries,
+// keeping only the last n if n > 0. A missing file is not an error.
+func readAuditLog(path string, entries []*AuditEntry, n int) ([]*AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("error opening audit log: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := new(AuditEntry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("error parsing audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+		if n > 0 && len(entries) > n {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading audit log: %w", err)
+	}
+	return entries, nil
+}
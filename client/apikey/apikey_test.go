@@ -0,0 +1,140 @@
+package apikey
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+
+	for _, form := range []*KeyForm{
+		{Name: "bad name", Permissions: []Permission{PermRead}},
+		{Name: "k", Permissions: nil},
+		{Name: "k", Permissions: []Permission{"root"}},
+		{Name: "k", Permissions: []Permission{PermRead}, AllowedIPs: []string{"localhost"}},
+	} {
+		if _, _, err := s.Create(form); err == nil {
+			t.Fatalf("no error for bad form %+v", form)
+		}
+	}
+
+	k, secret, err := s.Create(&KeyForm{
+		Name:          "trader",
+		Permissions:   []Permission{PermRead, PermFunds, PermRead},
+		AllowedIPs:    []string{"10.0.0.0/8", "127.0.0.1"},
+		WithdrawAddrs: []string{"addr1"},
+	})
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if len(k.Permissions) != 2 || !k.Allows(PermRead) || !k.Allows(PermFunds) || k.Allows(PermTrade) {
+		t.Fatalf("wrong permissions %v", k.Permissions)
+	}
+	if _, _, err := s.Create(&KeyForm{Name: "trader", Permissions: []Permission{PermRead}}); err == nil {
+		t.Fatalf("no error for duplicate name")
+	}
+
+	// Keys are persisted.
+	s, err = NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	if _, ok := s.Authenticate("trader", "wrong"); ok {
+		t.Fatalf("wrong secret authenticated")
+	}
+	if _, ok := s.Authenticate("other", secret); ok {
+		t.Fatalf("wrong name authenticated")
+	}
+	k, ok := s.Authenticate("trader", secret)
+	if !ok {
+		t.Fatalf("not authenticated")
+	}
+	for ip, exp := range map[string]bool{
+		"10.1.2.3":  true,
+		"127.0.0.1": true,
+		"127.0.0.2": false,
+		"::1":       false,
+	} {
+		if k.AllowsIP(net.ParseIP(ip)) != exp {
+			t.Fatalf("wrong AllowsIP result for %s", ip)
+		}
+	}
+	if !k.AllowsAddress("addr1") || k.AllowsAddress("addr2") {
+		t.Fatalf("wrong AllowsAddress result")
+	}
+
+	if err := s.Revoke("trader"); err != nil {
+		t.Fatalf("Revoke error: %v", err)
+	}
+	if err := s.Revoke("trader"); err != ErrUnknownKey {
+		t.Fatalf("wrong error for revoked key: %v", err)
+	}
+	if len(s.Keys()) != 0 {
+		t.Fatalf("key not revoked")
+	}
+	if _, ok := s.Authenticate("trader", secret); ok {
+		t.Fatalf("revoked key authenticated")
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	s, _ := NewStore(t.TempDir())
+	if entries, err := s.AuditLog(10); err != nil || len(entries) != 0 {
+		t.Fatalf("expected empty audit log, got %d entries. err = %v", len(entries), err)
+	}
+	for _, route := range []string{"wallets", "trade", "send"} {
+		if err := s.Audit(&AuditEntry{Key: "k", Route: route, Allowed: route != "send"}); err != nil {
+			t.Fatalf("Audit error: %v", err)
+		}
+	}
+	entries, err := s.AuditLog(2)
+	if err != nil {
+		t.Fatalf("AuditLog error: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Route != "send" || entries[0].Allowed || entries[1].Route != "trade" {
+		t.Fatalf("wrong audit entries")
+	}
+	if entries, _ = s.AuditLog(0); len(entries) != 3 {
+		t.Fatalf("expected all 3 entries, got %d", len(entries))
+	}
+}
+
+func TestAuditLogRotation(t *testing.T) {
+	defer func(size int64) { auditMaxSize = size }(auditMaxSize)
+	auditMaxSize = 300
+	s, _ := NewStore(t.TempDir())
+	const nEntries = 20
+	for i := 0; i < nEntries; i++ {
+		if err := s.Audit(&AuditEntry{Key: "k", Route: strconv.Itoa(i), Allowed: true}); err != nil {
+			t.Fatalf("Audit error: %v", err)
+		}
+	}
+	for _, path := range []string{s.auditPath, s.auditPathPrior} {
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("error reading %s: %v", path, err)
+		}
+		if fi.Size() > auditMaxSize {
+			t.Fatalf("%s is %d bytes, max is %d", path, fi.Size(), auditMaxSize)
+		}
+	}
+	entries, err := s.AuditLog(0)
+	if err != nil {
+		t.Fatalf("AuditLog error: %v", err)
+	}
+	if len(entries) == 0 || len(entries) >= nEntries {
+		t.Fatalf("expected older entries to be dropped, got %d entries", len(entries))
+	}
+	for i, e := range entries {
+		if e.Route != strconv.Itoa(nEntries-1-i) {
+			t.Fatalf("entry %d has route %s", i, e.Route)
+		}
+	}
+}
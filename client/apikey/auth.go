@@ -0,0 +1,95 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package apikey
+
+import (
+	"crypto/elliptic"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"github.com/decred/dcrd/certgen"
+)
+
+// Authorize returns an error if the key is not permitted to call the route.
+// perms is the permission needed for each route. Routes that are not listed
+// are not available to API keys. For routes that need PermFunds,
+// withdrawAddrs is called to get the addresses the request pays, which must
+// be in the key's withdrawal allowlist, if it has one.
+func (k *Key) Authorize(perms map[string]Permission, route string, withdrawAddrs func() ([]string, error)) error {
+	perm, found := perms[route]
+	if !found {
+		return fmt.Errorf("%q is not available to api keys", route)
+	}
+	if !k.Allows(perm) {
+		return fmt.Errorf("api key %q does not have %q permission", k.Name, perm)
+	}
+	if perm != PermFunds || len(k.WithdrawAddrs) == 0 {
+		return nil
+	}
+	addrs, err := withdrawAddrs()
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !k.AllowsAddress(addr) {
+			return fmt.Errorf("address %s is not in the withdrawal allowlist for api key %q", addr, k.Name)
+		}
+	}
+	return nil
+}
+
+// ParseBasicAuth parses the name and secret from the value of an HTTP basic
+// Authorization header. The scheme is case-insensitive, as with
+// (*http.Request).BasicAuth.
+func ParseBasicAuth(auth string) (name, secret string, ok bool) {
+	const prefix = "Basic "
+	if len(auth) < len(prefix) || !strings.EqualFold(auth[:len(prefix)], prefix) {
+		return "", "", false
+	}
+	b, err := base64.StdEncoding.DecodeString(auth[len(prefix):])
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(b), ":")
+}
+
+// ParseIP parses the IP address from a network address, with or without a
+// port.
+func ParseIP(addr string) net.IP {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	return net.ParseIP(host)
+}
+
+// GenCertPair generates a TLS key/cert pair to the paths provided.
+func GenCertPair(certFile, keyFile string, hosts []string, log dex.Logger) error {
+	log.Infof("Generating TLS certificates...")
+
+	org := "dcrdex autogenerated cert"
+	validUntil := time.Now().Add(10 * 365 * 24 * time.Hour)
+	cert, key, err := certgen.NewTLSCertPair(elliptic.P521(), org,
+		validUntil, hosts)
+	if err != nil {
+		return err
+	}
+
+	// Write cert and key files.
+	if err = os.WriteFile(certFile, cert, 0644); err != nil {
+		return err
+	}
+	if err = os.WriteFile(keyFile, key, 0600); err != nil {
+		os.Remove(certFile)
+		return err
+	}
+
+	log.Infof("Done generating TLS certificates")
+	return nil
+}
@@ -0,0 +1,65 @@
+package apikey
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestAuthorize(t *testing.T) {
+	perms := map[string]Permission{"wallets": PermRead, "send": PermFunds, "postbond": PermFunds}
+	k := &Key{Name: "k", Permissions: []Permission{PermFunds}, WithdrawAddrs: []string{"addr1"}}
+	payTo := func(addrs ...string) func() ([]string, error) {
+		return func() ([]string, error) { return addrs, nil }
+	}
+	tests := []struct {
+		name    string
+		route   string
+		addrs   []string
+		wantErr bool
+	}{
+		{"unlisted route", "appseed", nil, true},
+		{"missing permission", "wallets", nil, true},
+		{"allowed address", "send", []string{"addr1"}, false},
+		{"other address", "send", []string{"addr1", "addr2"}, true},
+		{"no address", "postbond", nil, false},
+	}
+	for _, tt := range tests {
+		if err := k.Authorize(perms, tt.route, payTo(tt.addrs...)); (err != nil) != tt.wantErr {
+			t.Fatalf("%s: wanted error = %t, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+	// Without an allowlist, the addresses are not needed.
+	k.WithdrawAddrs = nil
+	err := k.Authorize(perms, "send", func() ([]string, error) {
+		t.Fatalf("addresses requested without an allowlist")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("error without an allowlist: %v", err)
+	}
+}
+
+func TestParseBasicAuth(t *testing.T) {
+	enc := base64.StdEncoding.EncodeToString([]byte("name:sec:ret"))
+	if name, secret, ok := ParseBasicAuth("basic " + enc); !ok || name != "name" || secret != "sec:ret" {
+		t.Fatalf("wrong credentials %q, %q, %t", name, secret, ok)
+	}
+	for _, auth := range []string{"", "Bearer " + enc, "Basic !", "Basic " + base64.StdEncoding.EncodeToString([]byte("name"))} {
+		if _, _, ok := ParseBasicAuth(auth); ok {
+			t.Fatalf("parsed invalid credentials %q", auth)
+		}
+	}
+}
+
+func TestParseIP(t *testing.T) {
+	for addr, want := range map[string]string{
+		"127.0.0.1:1234": "127.0.0.1",
+		"[::1]:1234":     "::1",
+		"10.0.0.1":       "10.0.0.1",
+		"localhost:80":   "<nil>",
+	} {
+		if ip := ParseIP(addr); ip.String() != want {
+			t.Fatalf("%s: expected %s, got %s", addr, want, ip)
+		}
+	}
+}
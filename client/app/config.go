@@ -4,19 +4,26 @@
 package app
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
+	"decred.org/dcrdex/client/bnserver"
 	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/grpcserver"
 	"decred.org/dcrdex/client/mm"
 	"decred.org/dcrdex/client/rpcserver"
 	"decred.org/dcrdex/client/webserver"
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/version"
+	"decred.org/dcrdex/tatanka/client/mesh"
+	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/decred/dcrd/dcrutil/v4"
 	"github.com/jessevdk/go-flags"
 )
@@ -31,6 +38,8 @@ const (
 	walletPairTwoHost  = "127.0.0.7"
 	defaultRPCPort     = "5757"
 	defaultWebPort     = "5758"
+	defaultGRPCPort    = "5759"
+	defaultBNAPIPort   = "5760"
 	defaultLogLevel    = "debug"
 	configFilename     = "dexc.conf"
 )
@@ -47,6 +56,8 @@ type RPCConfig struct {
 	RPCPass string `long:"rpcpass" description:"RPC server password"`
 	RPCCert string `long:"rpccert" description:"RPC server certificate file location"`
 	RPCKey  string `long:"rpckey" description:"RPC server key file location"`
+	// The gRPC server uses the RPC server's credentials and certificate.
+	GRPCAddr string `long:"grpcaddr" description:"gRPC server listen address"`
 	// CertHosts is a list of hosts given to certgen.NewTLSCertPair for the
 	// "Subject Alternate Name" values of the generated TLS certificate. It is
 	// set automatically, not via the config file or cli args.
@@ -92,6 +103,58 @@ func (cfg *RPCConfig) RPC(c *core.Core, marketMaker *mm.MarketMaker, log dex.Log
 	}
 }
 
+// GRPC creates a grpc server configuration.
+func (cfg *RPCConfig) GRPC(c *core.Core, marketMaker *mm.MarketMaker, log dex.Logger) *grpcserver.Config {
+	grpcserver.SetLogger(log)
+	return &grpcserver.Config{
+		Core:        c,
+		MarketMaker: marketMaker,
+		Addr:        cfg.GRPCAddr,
+		User:        cfg.RPCUser,
+		Pass:        cfg.RPCPass,
+		Cert:        cfg.RPCCert,
+		Key:         cfg.RPCKey,
+		Version:     Version,
+		CertHosts: []string{
+			defaultTestnetHost, defaultSimnetHost, defaultMainnetHost,
+			walletPairOneHost, walletPairTwoHost,
+		},
+	}
+}
+
+// BNAPIConfig encapsulates the configuration needed for the Binance-compatible
+// API server.
+type BNAPIConfig struct {
+	BNAPIAddr   string `long:"bnapiaddr" description:"Binance-compatible API server listen address"`
+	BNAPIHost   string `long:"bnapihost" description:"DEX host that orders from the Binance-compatible API are placed on"`
+	BNAPIKey    string `long:"bnapikey" description:"Binance-compatible API key"`
+	BNAPISecret string `long:"bnapisecret" description:"Binance-compatible API secret key for request signatures"`
+	BNAPITLS    bool   `long:"bnapitls" description:"Use HTTPS with the RPC server's certificate for the Binance-compatible API. Required for a bnapiaddr that is not a loopback address."`
+}
+
+// BNAPI creates a Binance-compatible API server configuration. This is a
+// Config method instead of a BNAPIConfig method because it uses the RPC
+// server's certificate and the database directory.
+func (cfg *Config) BNAPI(c *core.Core, log dex.Logger) *bnserver.Config {
+	bnserver.SetLogger(log)
+	bnCfg := &bnserver.Config{
+		Core:          c,
+		Addr:          cfg.BNAPIAddr,
+		Host:          cfg.BNAPIHost,
+		APIKey:        cfg.BNAPIKey,
+		Secret:        cfg.BNAPISecret,
+		ClientIDsFile: filepath.Join(filepath.Dir(cfg.DBPath), "bnapiclientids.json"),
+	}
+	if cfg.BNAPITLS {
+		bnCfg.Cert, bnCfg.Key = cfg.RPCCert, cfg.RPCKey
+		bnCfg.CertHosts = []string{
+			defaultTestnetHost, defaultSimnetHost, defaultMainnetHost,
+			walletPairOneHost, walletPairTwoHost,
+		}
+	}
+	return bnCfg
+}
+
 // CoreConfig encapsulates the settings specific to core.Core.
 type CoreConfig struct {
 	DBPath       string `long:"db" description:"Database filepath. Database will be created if it does not exist."`
@@ -103,11 +166,26 @@ type CoreConfig struct {
 
 	TheOneHost string `long:"onehost" description:"Only connect with this server."`
 
+	Profile string `long:"profile" description:"The user profile to start with. Each profile has its own password, seed, accounts, wallets and bots. Profiles other than the default are stored in the profiles directory of appdata. (default: default)"`
+
 	NoAutoWalletLock   bool `long:"no-wallet-lock" description:"Disable locking of wallets on shutdown or logout. Use this if you want your external wallets to stay unlocked after closing the DEX app."`
 	NoAutoDBBackup     bool `long:"no-db-backup" description:"Disable creation of a database backup on shutdown."`
 	UnlockCoinsOnLogin bool `long:"release-wallet-coins" description:"On login or wallet creation, instruct the wallet to release any coins that it may have locked."`
 
+	UseBoltDB bool   `long:"boltdb" description:"Use the legacy bolt database file instead of the lexi database it is migrated to. This can be used to roll back the migration, but anything recorded after the migration is only in the lexi database."`
+	RestoreDB string `long:"restoredb" description:"Restore the lexi database from a backup file before starting, such as the one written to the backup folder on shutdown. The existing lexi database directory must be moved or removed first."`
+
 	ExtensionModeFile string `long:"extension-mode-file" description:"path to a file that specifies options for running core as an extension."`
+
+	Watchtowers []string `long:"watchtower" description:"URL of a swap watchtower that broadcasts refunds and redeems for active matches while the client is offline. Use multiple times for multiple watchtowers."`
+
+	MeshNode     string   `long:"meshnode" description:"Address (host:port) of a Tatanka Mesh node to enter the mesh through. Setting this enables trading on the Tatanka Mesh."`
+	MeshNodeID   string   `long:"meshnodeid" description:"Hex-encoded peer ID of the meshnode."`
+	MeshNodeCert string   `long:"meshnodecert" description:"Path to the TLS certificate of the meshnode. Required unless meshnotls is set."`
+	MeshNoTLS    bool     `long:"meshnotls" description:"Connect to the meshnode without TLS. For local testing only."`
+	MeshMarkets  []string `long:"meshmarket" description:"A Tatanka Mesh market to trade, as base_quote:lotsize, with the lot size in atoms of the base asset, which must be a power of 2, e.g. dcr_btc:134217728. Use multiple times for multiple markets."`
+	// Mesh is a derivative field set by ResolveConfig.
+	Mesh *core.MeshConfig
 }
 
 // WebConfig encapsulates the configuration needed for the web server.
@@ -141,6 +219,7 @@ type MMConfig struct {
 type Config struct {
 	CoreConfig
 	RPCConfig
+	BNAPIConfig
 	WebConfig
 	LogConfig
 	MMConfig
@@ -154,10 +233,17 @@ type Config struct {
 	Testnet    bool   `long:"testnet" description:"use testnet"`
 	Simnet     bool   `long:"simnet" description:"use simnet"`
 	RPCOn      bool   `long:"rpc" description:"turn on the rpc server"`
+	GRPCOn     bool   `long:"grpc" description:"turn on the grpc server, which uses the rpc server's user, password and certificate"`
+	BNAPIOn    bool   `long:"bnapi" description:"turn on the Binance-compatible API server for trading bots"`
 	NoWeb      bool   `long:"noweb" description:"disable the web server."`
 	CPUProfile string `long:"cpuprofile" description:"File for CPU profiling."`
 	ShowVer    bool   `short:"V" long:"version" description:"Display version information and exit"`
 	Language   string `long:"lang" description:"BCP 47 tag for preferred language, e.g. en-GB, fr, zh-CN"`
+	NotifyCfg  string `long:"notifyconfig" description:"Path to a JSON file configuring notification sinks (webhooks, ntfy, email)."`
+
+	// unresolved is a copy of the Config before ResolveConfig, used to
+	// resolve the Config for another profile.
+	unresolved *Config
 }
 
 // Web creates a configuration for the webserver. This is a Config method
@@ -219,11 +305,68 @@ func (cfg *Config) Core(log dex.Logger) *core.Config {
 		UnlockCoinsOnLogin: cfg.UnlockCoinsOnLogin,
 		NoAutoWalletLock:   cfg.NoAutoWalletLock,
 		NoAutoDBBackup:     cfg.NoAutoDBBackup,
+		UseBoltDB:          cfg.UseBoltDB,
+		RestoreDB:          cfg.RestoreDB,
 		ExtensionModeFile:  cfg.ExtensionModeFile,
 		TheOneHost:         cfg.TheOneHost,
+		Watchtowers:        cfg.Watchtowers,
+		Mesh:               cfg.Mesh,
 	}
 }
 
+// meshConfig parses the Tatanka Mesh settings. A nil *core.MeshConfig is
+// returned if no mesh node is configured.
+func (cfg *CoreConfig) meshConfig() (*core.MeshConfig, error) {
+	if cfg.MeshNode == "" {
+		if len(cfg.MeshMarkets) > 0 {
+			return nil, errors.New("meshmarket set without a meshnode")
+		}
+		return nil, nil
+	}
+	idB, err := hex.DecodeString(cfg.MeshNodeID)
+	if err != nil || len(idB) != tanka.PeerIDLength {
+		return nil, fmt.Errorf("invalid meshnodeid %q", cfg.MeshNodeID)
+	}
+	creds := &mesh.TatankaCredentials{
+		Addr:  cfg.MeshNode,
+		NoTLS: cfg.MeshNoTLS,
+	}
+	copy(creds.PeerID[:], idB)
+	if !cfg.MeshNoTLS {
+		if cfg.MeshNodeCert == "" {
+			return nil, errors.New("meshnodecert is required unless meshnotls is set")
+		}
+		if creds.Cert, err = os.ReadFile(dex.CleanAndExpandPath(cfg.MeshNodeCert)); err != nil {
+			return nil, fmt.Errorf("error reading meshnodecert: %w", err)
+		}
+	}
+	mkts := make([]*core.MeshMarket, 0, len(cfg.MeshMarkets))
+	for _, s := range cfg.MeshMarkets {
+		mktStr, lotStr, found := strings.Cut(s, ":")
+		baseSymbol, quoteSymbol, found2 := strings.Cut(mktStr, "_")
+		if !found || !found2 {
+			return nil, fmt.Errorf("invalid meshmarket %q. expected base_quote:lotsize", s)
+		}
+		baseID, found := dex.BipSymbolID(strings.ToLower(baseSymbol))
+		if !found {
+			return nil, fmt.Errorf("unknown asset %q in meshmarket %q", baseSymbol, s)
+		}
+		quoteID, found := dex.BipSymbolID(strings.ToLower(quoteSymbol))
+		if !found {
+			return nil, fmt.Errorf("unknown asset %q in meshmarket %q", quoteSymbol, s)
+		}
+		lotSize, err := strconv.ParseUint(lotStr, 10, 64)
+		if err != nil || lotSize == 0 || lotSize&(lotSize-1) != 0 {
+			return nil, fmt.Errorf("invalid lot size in meshmarket %q. must be a power of 2", s)
+		}
+		mkts = append(mkts, &core.MeshMarket{BaseID: baseID, QuoteID: quoteID, LotSize: lotSize})
+	}
+	return &core.MeshConfig{
+		EntryNode: creds,
+		Markets:   mkts,
+	}, nil
+}
+
 var DefaultConfig = Config{
 	AppData:    defaultApplicationDirectory,
 	ConfigPath: defaultConfigPath,
@@ -307,28 +450,48 @@ func ResolveConfig(appData string, cfg *Config) error {
 
 	cfg.AppData = appData
 
+	unresolved := *cfg
+	cfg.unresolved = &unresolved
+
+	if cfg.Profile == "" {
+		cfg.Profile = DefaultProfile
+	}
+	if err := ValidateProfileName(cfg.Profile); err != nil {
+		return err
+	}
+	if cfg.Profile != DefaultProfile && (cfg.DBPath != "" || cfg.MMConfig.BotConfigPath != "" || cfg.MMConfig.EventLogDBPath != "") {
+		return fmt.Errorf("custom db, botConfigPath and eventLogDBPath settings cannot be used with profile %q", cfg.Profile)
+	}
+	profileDir := profileDirectory(appData, cfg.Profile)
+
 	var defaultDBPath, defaultLogPath, defaultMMEventLogDBPath, defaultMMConfigPath string
 	switch {
 	case cfg.Testnet:
 		cfg.Net = dex.Testnet
-		defaultDBPath, defaultLogPath, defaultMMEventLogDBPath, defaultMMConfigPath = setNet(appData, "testnet")
+		defaultDBPath, defaultLogPath, defaultMMEventLogDBPath, defaultMMConfigPath = setNet(profileDir, "testnet")
 	case cfg.Simnet:
 		cfg.Net = dex.Simnet
-		defaultDBPath, defaultLogPath, defaultMMEventLogDBPath, defaultMMConfigPath = setNet(appData, "simnet")
+		defaultDBPath, defaultLogPath, defaultMMEventLogDBPath, defaultMMConfigPath = setNet(profileDir, "simnet")
 	default:
 		cfg.Net = dex.Mainnet
-		defaultDBPath, defaultLogPath, defaultMMEventLogDBPath, defaultMMConfigPath = setNet(appData, "mainnet")
+		defaultDBPath, defaultLogPath, defaultMMEventLogDBPath, defaultMMConfigPath = setNet(profileDir, "mainnet")
 	}
 	defaultHost := DefaultHostByNetwork(cfg.Net)
 
-	// If web or RPC server addresses not set, use network specific
-	// defaults
+	// If web, RPC, gRPC or Binance-compatible API server addresses not set,
+	// use network specific defaults
 	if cfg.WebAddr == "" {
 		cfg.WebAddr = net.JoinHostPort(defaultHost, defaultWebPort)
 	}
 	if cfg.RPCAddr == "" {
 		cfg.RPCAddr = net.JoinHostPort(defaultHost, defaultRPCPort)
 	}
+	if cfg.GRPCAddr == "" {
+		cfg.GRPCAddr = net.JoinHostPort(defaultHost, defaultGRPCPort)
+	}
+	if cfg.BNAPIAddr == "" {
+		cfg.BNAPIAddr = net.JoinHostPort(defaultHost, defaultBNAPIPort)
+	}
 
 	if cfg.RPCCert == "" {
 		cfg.RPCCert = filepath.Join(appData, defaultRPCCertFile)
@@ -354,6 +517,12 @@ func ResolveConfig(appData string, cfg *Config) error {
 		cfg.MMConfig.EventLogDBPath = defaultMMEventLogDBPath
 	}
 
+	mesh, err := cfg.meshConfig()
+	if err != nil {
+		return err
+	}
+	cfg.Mesh = mesh
+
 	return nil
 }
 
@@ -0,0 +1,177 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+const (
+	// DefaultProfile is the profile that uses the app data directory itself,
+	// as bisonw did before profiles.
+	DefaultProfile = "default"
+	// profilesDirectory is the app data subdirectory holding the other
+	// profiles, each in a directory with the profile name.
+	profilesDirectory = "profiles"
+)
+
+var profileNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+
+// ValidateProfileName checks that the profile name is 1 to 32 characters of
+// letters, numbers, underscores and hyphens.
+func ValidateProfileName(name string) error {
+	if !profileNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q. use 1 to 32 letters, numbers, underscores or hyphens", name)
+	}
+	return nil
+}
+
+// profileDirectory is the data directory for the profile. Each profile has
+// its own network directories with a database, wallet data, and bot
+// configuration.
+func profileDirectory(appData, profile string) string {
+	if profile == DefaultProfile {
+		return appData
+	}
+	return filepath.Join(appData, profilesDirectory, profile)
+}
+
+// ListProfiles lists the profiles in the app data directory. The default
+// profile is always first.
+func ListProfiles(appData string) ([]string, error) {
+	profiles := []string{DefaultProfile}
+	entries, err := os.ReadDir(filepath.Join(appData, profilesDirectory))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return profiles, nil
+		}
+		return nil, fmt.Errorf("error reading profiles directory: %w", err)
+	}
+	var named []string
+	for _, e := range entries {
+		if e.IsDir() && ValidateProfileName(e.Name()) == nil && e.Name() != DefaultProfile {
+			named = append(named, e.Name())
+		}
+	}
+	sort.Strings(named)
+	return append(profiles, named...), nil
+}
+
+// WithProfile creates a resolved Config for another profile. Settings given
+// on the command line or in the config file are retained.
+func (cfg *Config) WithProfile(profile string) (*Config, error) {
+	if cfg.unresolved == nil {
+		return nil, errors.New("config not resolved")
+	}
+	newCfg := *cfg.unresolved
+	newCfg.Profile = profile
+	return &newCfg, ResolveConfig(cfg.AppData, &newCfg)
+}
+
+// Profiles tracks the active profile and requests to switch to another
+// profile. Switching requires logging out of the active profile, after which
+// the application restarts with the requested profile's Config.
+type Profiles struct {
+	appData string
+	switchC chan string
+
+	mtx    sync.RWMutex
+	active string
+	logout func() error
+}
+
+// NewProfiles is the constructor for Profiles.
+func NewProfiles(appData string) *Profiles {
+	return &Profiles{
+		appData: appData,
+		switchC: make(chan string, 1),
+		active:  DefaultProfile,
+	}
+}
+
+// Activate sets the active profile. logout should log out of the active
+// profile, returning an error if that is not possible, e.g. if there are
+// active orders.
+func (p *Profiles) Activate(profile string, logout func() error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.active = profile
+	p.logout = logout
+}
+
+// Active is the name of the active profile.
+func (p *Profiles) Active() string {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return p.active
+}
+
+// Profiles returns the active profile and all existing profiles.
+func (p *Profiles) Profiles() (active string, all []string, err error) {
+	all, err = ListProfiles(p.appData)
+	return p.Active(), all, err
+}
+
+// SwitchProfile logs out of the active profile and requests a switch to the
+// named profile, which must exist.
+func (p *Profiles) SwitchProfile(profile string) error {
+	return p.switchProfile(profile, false)
+}
+
+// CreateProfile creates the named profile, logs out of the active profile and
+// requests a switch to the new profile.
+func (p *Profiles) CreateProfile(profile string) error {
+	return p.switchProfile(profile, true)
+}
+
+func (p *Profiles) switchProfile(profile string, create bool) error {
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	p.mtx.RLock()
+	active, logout := p.active, p.logout
+	p.mtx.RUnlock()
+	dir := profileDirectory(p.appData, profile)
+	exists := profile == DefaultProfile
+	if !exists {
+		fi, err := os.Stat(dir)
+		exists = err == nil && fi.IsDir()
+	}
+	switch {
+	case create && exists:
+		return fmt.Errorf("profile %q already exists", profile)
+	case !create && !exists:
+		return fmt.Errorf("profile %q does not exist", profile)
+	case profile == active:
+		return nil
+	}
+	if logout != nil {
+		if err := logout(); err != nil {
+			return fmt.Errorf("unable to log out of profile %q: %w", active, err)
+		}
+	}
+	if create {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("error creating profile directory: %w", err)
+		}
+	}
+	select {
+	case p.switchC <- profile:
+		return nil
+	default:
+		return errors.New("a profile switch is already in progress")
+	}
+}
+
+// SwitchRequests is a channel that receives the name of the requested
+// profile.
+func (p *Profiles) SwitchRequests() <-chan string {
+	return p.switchC
+}
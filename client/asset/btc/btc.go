@@ -191,7 +191,7 @@ var (
 		Type:             walletTypeSPV,
 		Tab:              "Native",
 		Description:      "Use the built-in SPV wallet",
-		ConfigOpts:       CommonConfigOpts("BTC", true),
+		ConfigOpts:       append(CommonConfigOpts("BTC", true), WatchOnlyConfigOpts...),
 		Seeded:           true,
 		MultiFundingOpts: MultiFundingOpts,
 	}
@@ -219,6 +219,28 @@ var (
 	}
 )
 
+// WatchOnlyConfigOpts are the wallet creation options for an SPV wallet that
+// holds no private keys.
+var WatchOnlyConfigOpts = []*asset.ConfigOption{
+	{
+		Key:         "watchonlyxpub",
+		DisplayName: "Watch-only account key",
+		Description: "Create a watch-only wallet from a BIP84 account extended " +
+			"public key (m/84'/coin_type'/account'). The wallet holds no " +
+			"private keys, and transactions must be signed by an external " +
+			"signer using PSBTs. Leave empty to create a wallet from the app seed.",
+		DisableWhenActive: true,
+	},
+	{
+		Key:         "watchonlyfingerprint",
+		DisplayName: "Watch-only master key fingerprint",
+		Description: "The 8-character hex master key fingerprint of the " +
+			"external signer, which is included in PSBTs so that the signer " +
+			"can find its keys.",
+		DisableWhenActive: true,
+	},
+}
+
 func apiFallbackOpt(defaultV bool) *asset.ConfigOption {
 	return &asset.ConfigOption{
 		Key:         "apifeefallback",
@@ -577,6 +599,27 @@ func walletExists(dir string, chainParams *chaincfg.Params) (bool, error) {
 type createConfig struct {
 	WalletConfig `ini:",extends"`
 	RecoveryCfg  `ini:",extends"`
+	WatchOnlyCfg `ini:",extends"`
+}
+
+// WatchOnlyCfg is the configuration for creating a watch-only SPV wallet.
+type WatchOnlyCfg struct {
+	XPub        string `ini:"watchonlyxpub"`
+	Fingerprint string `ini:"watchonlyfingerprint"`
+}
+
+// fingerprint parses the master key fingerprint. The fingerprint is the
+// first 4 bytes of the master public key's hash160, and is serialized in
+// PSBTs as a little-endian uint32.
+func (cfg *WatchOnlyCfg) fingerprint() (uint32, error) {
+	if cfg.Fingerprint == "" {
+		return 0, nil
+	}
+	b, err := hex.DecodeString(cfg.Fingerprint)
+	if err != nil || len(b) != 4 {
+		return 0, fmt.Errorf("invalid master key fingerprint %q", cfg.Fingerprint)
+	}
+	return binary.LittleEndian.Uint32(b), nil
 }
 
 // Create creates a new SPV wallet.
@@ -612,6 +655,18 @@ func (d *Driver) Create(params *asset.CreateWalletParams) error {
 	}
 
 	dir := filepath.Join(params.DataDir, chainParams.Name)
+	if cfg.XPub != "" {
+		acctKey, err := parseWatchOnlyKey(cfg.XPub, chainParams)
+		if err != nil {
+			return err
+		}
+		fingerprint, err := cfg.fingerprint()
+		if err != nil {
+			return err
+		}
+		return createWatchOnlySPVWallet(acctKey, fingerprint, bday, dir,
+			params.Logger, cfg.NumExternalAddresses, cfg.NumInternalAddresses, chainParams)
+	}
 	return createSPVWallet(params.Pass, params.Seed, bday, dir,
 		params.Logger, cfg.NumExternalAddresses, cfg.NumInternalAddresses, chainParams)
 }
@@ -843,6 +898,11 @@ type baseWallet struct {
 	pendingTxsMtx sync.RWMutex
 	pendingTxs    map[chainhash.Hash]ExtendedWalletTx
 
+	// psbtTxs are the history entries for unsigned PSBTs that have not been
+	// broadcast. They are added to the tx history by BroadcastPSBT.
+	psbtTxsMtx sync.Mutex
+	psbtTxs    map[chainhash.Hash]*asset.WalletTransaction
+
 	// receiveTxLastQuery stores the last block height at which the wallet
 	// was queried for recieve transactions. This is also stored in the
 	// txHistoryDB.
@@ -1355,6 +1415,7 @@ func newUnconnectedWallet(cfg *BTCCloneCFG, walletCfg *WalletConfig) (*baseWalle
 		txVersion:         txVersion,
 		Network:           cfg.Network,
 		pendingTxs:        make(map[chainhash.Hash]ExtendedWalletTx),
+		psbtTxs:           make(map[chainhash.Hash]*asset.WalletTransaction),
 		walletDir:         walletDir,
 		ar:                addressRecyler,
 	}
@@ -5105,13 +5166,28 @@ func bondPushDataScript(ver uint16, acctID []byte, lockTimeSec int64, pkh []byte
 // current underlying wallet; the bond private key should normally be used to
 // author a new transaction paying to a new address instead.
 func (btc *baseWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time.Time, bondKey *secp256k1.PrivateKey, acctID []byte) (*asset.Bond, func(), error) {
+	bond, _, abandon, err := btc.makeBondTx(ver, amt, feeRate, lockTime, bondKey, acctID, true)
+	return bond, abandon, err
+}
+
+// makeBondTx authors the bond transaction for MakeBondTx. If sign is false,
+// the funding inputs are left unsigned, and the returned Bond has no SignedTx.
+// The unsigned transaction, with any change output, is returned as well.
+func (btc *baseWallet) makeBondTx(ver uint16, amt, feeRate uint64, lockTime time.Time, bondKey *secp256k1.PrivateKey,
+	acctID []byte, sign bool) (*asset.Bond, *wire.MsgTx, func(), error) {
+
 	if ver != 0 {
-		return nil, nil, errors.New("only version 0 bonds supported")
+		return nil, nil, nil, errors.New("only version 0 bonds supported")
+	}
+	// The txid of an unsigned transaction only commits to the final
+	// transaction if all inputs are segwit.
+	if !sign && !btc.segwit {
+		return nil, nil, nil, errors.New("unsigned bond transactions require segwit")
 	}
 	if until := time.Until(lockTime); until >= 365*12*time.Hour /* ~6 months */ {
-		return nil, nil, fmt.Errorf("that lock time is nuts: %v", lockTime)
+		return nil, nil, nil, fmt.Errorf("that lock time is nuts: %v", lockTime)
 	} else if until < 0 {
-		return nil, nil, fmt.Errorf("that lock time is already passed: %v", lockTime)
+		return nil, nil, nil, fmt.Errorf("that lock time is already passed: %v", lockTime)
 	}
 
 	pk := bondKey.PubKey().SerializeCompressed()
@@ -5123,19 +5199,19 @@ func (btc *baseWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time
 	// TL output.
 	lockTimeSec := lockTime.Unix()
 	if lockTimeSec >= dexbtc.MaxCLTVScriptNum || lockTimeSec <= 0 {
-		return nil, nil, fmt.Errorf("invalid lock time %v", lockTime)
+		return nil, nil, nil, fmt.Errorf("invalid lock time %v", lockTime)
 	}
 	bondScript, err := dexbtc.MakeBondScript(ver, uint32(lockTimeSec), pkh)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to build bond output redeem script: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to build bond output redeem script: %w", err)
 	}
 	pkScript, err := btc.scriptHashScript(bondScript)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error constructing p2sh script: %v", err)
+		return nil, nil, nil, fmt.Errorf("error constructing p2sh script: %v", err)
 	}
 	txOut := wire.NewTxOut(int64(amt), pkScript)
 	if btc.IsDust(txOut, feeRate) {
-		return nil, nil, fmt.Errorf("bond output value of %d (fee rate %d) is dust", amt, feeRate)
+		return nil, nil, nil, fmt.Errorf("bond output value of %d (fee rate %d) is dust", amt, feeRate)
 	}
 	baseTx.AddTxOut(txOut)
 
@@ -5144,7 +5220,7 @@ func (btc *baseWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time
 	// for natural visual inspection of the version and lock time.
 	commitPkScript, err := bondPushDataScript(ver, acctID, lockTimeSec, pkh)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to build acct commit output script: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to build acct commit output script: %w", err)
 	}
 	acctOut := wire.NewTxOut(0, commitPkScript) // value zero
 	baseTx.AddTxOut(acctOut)
@@ -5157,9 +5233,9 @@ func (btc *baseWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time
 	}
 
 	const subtract = false
-	coins, _, _, _, _, _, err := btc.cm.Fund(0, 0, true, SendEnough(amt, feeRate, subtract, uint64(baseSize), btc.segwit, true))
+	coins, _, _, _, inputsSize, _, err := btc.cm.Fund(0, 0, true, SendEnough(amt, feeRate, subtract, uint64(baseSize), btc.segwit, true))
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to fund bond tx: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fund bond tx: %w", err)
 	}
 
 	var txIDToRemoveFromHistory *chainhash.Hash // will be non-nil if tx was added to history
@@ -5183,37 +5259,48 @@ func (btc *baseWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time
 
 	totalIn, _, err := btc.addInputsToTx(baseTx, coins)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to add inputs to bond tx: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to add inputs to bond tx: %w", err)
 	}
 
 	changeAddr, err := btc.node.ChangeAddress()
 	if err != nil {
-		return nil, nil, fmt.Errorf("error creating change address: %w", err)
+		return nil, nil, nil, fmt.Errorf("error creating change address: %w", err)
 	}
-	signedTx, _, fee, err := btc.signTxAndAddChange(baseTx, changeAddr, totalIn, amt, feeRate)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to sign bond tx: %w", err)
-	}
-
-	txid := btc.hashTx(signedTx)
 
-	signedTxBytes, err := btc.serializeTx(signedTx)
-	if err != nil {
-		return nil, nil, err
+	var signedTxBytes []byte
+	var txid *chainhash.Hash
+	var fee uint64
+	if sign {
+		signedTx, _, signedFee, err := btc.signTxAndAddChange(baseTx, changeAddr, totalIn, amt, feeRate)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to sign bond tx: %w", err)
+		}
+		txid, fee = btc.hashTx(signedTx), signedFee
+		signedTxBytes, err = btc.serializeTx(signedTx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	} else {
+		fee, err = btc.addChangeUnsigned(baseTx, changeAddr, totalIn, amt, feeRate*(inputsSize+uint64(baseSize)), feeRate)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to add change to bond tx: %w", err)
+		}
+		txid = btc.hashTx(baseTx)
 	}
+
 	unsignedTxBytes, err := btc.serializeTx(baseTx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// Prep the redeem / refund tx.
 	redeemMsgTx, err := btc.makeBondRefundTxV0(txid, 0, amt, bondScript, bondKey, feeRate)
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to create bond redemption tx: %w", err)
+		return nil, nil, nil, fmt.Errorf("unable to create bond redemption tx: %w", err)
 	}
 	redeemTx, err := btc.serializeTx(redeemMsgTx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to serialize bond redemption tx: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to serialize bond redemption tx: %w", err)
 	}
 
 	bond := &asset.Bond{
@@ -5228,7 +5315,7 @@ func (btc *baseWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time
 	}
 	success = true
 
-	btc.addTxToHistory(&asset.WalletTransaction{
+	wt := &asset.WalletTransaction{
 		Type:   asset.CreateBond,
 		ID:     txid.String(),
 		Amount: amt,
@@ -5238,16 +5325,25 @@ func (btc *baseWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time
 			LockTime:  uint64(lockTimeSec),
 			BondID:    pkh,
 		},
-	}, txid, false)
+	}
+	if !sign {
+		// An unsigned bond is recorded when the signed PSBT is broadcast.
+		btc.storePSBTTx(txid, wt)
+		return bond, baseTx, func() {
+			abandon()
+			btc.popPSBTTx(txid)
+		}, nil
+	}
+	btc.addTxToHistory(wt, txid, false)
 
 	txIDToRemoveFromHistory = txid
 
-	return bond, abandon, nil
+	return bond, baseTx, abandon, nil
 }
 
 func (btc *baseWallet) makeBondRefundTxV0(txid *chainhash.Hash, vout uint32, amt uint64,
 	script []byte, priv *secp256k1.PrivateKey, feeRate uint64) (*wire.MsgTx, error) {
-	lockTime, pkhPush, err := dexbtc.ExtractBondDetailsV0(0, script)
+	_, pkhPush, err := dexbtc.ExtractBondDetailsV0(0, script)
 	if err != nil {
 		return nil, err
 	}
@@ -5258,6 +5354,47 @@ func (btc *baseWallet) makeBondRefundTxV0(txid *chainhash.Hash, vout uint32, amt
 		return nil, fmt.Errorf("incorrect private key to spend the bond output")
 	}
 
+	msgTx, err := btc.unsignedBondRefundTxV0(txid, vout, amt, script, feeRate)
+	if err != nil {
+		return nil, err
+	}
+	txIn := msgTx.TxIn[0]
+
+	if btc.segwit {
+		sigHashes := txscript.NewTxSigHashes(msgTx, new(txscript.CannedPrevOutputFetcher))
+		sig, err := txscript.RawTxInWitnessSignature(msgTx, sigHashes, 0, int64(amt),
+			script, txscript.SigHashAll, priv)
+		if err != nil {
+			return nil, err
+		}
+		txIn.Witness = dexbtc.RefundBondScriptSegwit(script, sig, pk)
+	} else {
+		prevPkScript, err := btc.scriptHashScript(script) // P2SH: OP_HASH160 <script hash> OP_EQUAL
+		if err != nil {
+			return nil, fmt.Errorf("error constructing p2sh script: %w", err)
+		}
+		sig, err := btc.signNonSegwit(msgTx, 0, script, txscript.SigHashAll, priv, []int64{int64(amt)}, [][]byte{prevPkScript})
+		if err != nil {
+			return nil, err
+		}
+		txIn.SignatureScript, err = dexbtc.RefundBondScript(script, sig, pk)
+		if err != nil {
+			return nil, fmt.Errorf("RefundBondScript: %w", err)
+		}
+	}
+
+	return msgTx, nil
+}
+
+// unsignedBondRefundTxV0 authors an unsigned transaction spending a v0 bond
+// output to a new wallet address.
+func (btc *baseWallet) unsignedBondRefundTxV0(txid *chainhash.Hash, vout uint32, amt uint64,
+	script []byte, feeRate uint64) (*wire.MsgTx, error) {
+	lockTime, _, err := dexbtc.ExtractBondDetailsV0(0, script)
+	if err != nil {
+		return nil, err
+	}
+
 	msgTx := wire.NewMsgTx(btc.txVersion())
 	// Transaction LockTime must be <= spend time, and >= the CLTV lockTime, so
 	// we use exactly the CLTV's value. This limits the CLTV value to 32-bits.
@@ -5295,29 +5432,6 @@ func (btc *baseWallet) makeBondRefundTxV0(txid *chainhash.Hash, vout uint32, amt
 	}
 	msgTx.AddTxOut(redeemTxOut)
 
-	if btc.segwit {
-		sigHashes := txscript.NewTxSigHashes(msgTx, new(txscript.CannedPrevOutputFetcher))
-		sig, err := txscript.RawTxInWitnessSignature(msgTx, sigHashes, 0, int64(amt),
-			script, txscript.SigHashAll, priv)
-		if err != nil {
-			return nil, err
-		}
-		txIn.Witness = dexbtc.RefundBondScriptSegwit(script, sig, pk)
-	} else {
-		prevPkScript, err := btc.scriptHashScript(script) // P2SH: OP_HASH160 <script hash> OP_EQUAL
-		if err != nil {
-			return nil, fmt.Errorf("error constructing p2sh script: %w", err)
-		}
-		sig, err := btc.signNonSegwit(msgTx, 0, script, txscript.SigHashAll, priv, []int64{int64(amt)}, [][]byte{prevPkScript})
-		if err != nil {
-			return nil, err
-		}
-		txIn.SignatureScript, err = dexbtc.RefundBondScript(script, sig, pk)
-		if err != nil {
-			return nil, fmt.Errorf("RefundBondScript: %w", err)
-		}
-	}
-
 	return msgTx, nil
 }
 
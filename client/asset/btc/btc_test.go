@@ -3686,6 +3686,87 @@ func testRefund(t *testing.T, segwit bool, walletType string) {
 	// TODO test spv spent
 }
 
+func TestWatchtowered(t *testing.T) {
+	runRubric(t, testWatchtowered)
+}
+
+func testWatchtowered(t *testing.T, segwit bool, walletType string) {
+	wallet, node, shutdown := tNewWallet(segwit, walletType)
+	defer shutdown()
+
+	secret, _, pkScript, contract, addr, _, lockTime := makeSwapContract(segwit, time.Hour*12)
+	node.changeAddr = addr.String()
+	node.newAddress = addr.String()
+	privBytes, _ := hex.DecodeString("b07209eec1a8fb6cfe5cb6ace36567406971a75c330db7101fb21bc679bc5330")
+	privKey, _ := btcec.PrivKeyFromBytes(privBytes)
+	wif, _ := btcutil.NewWIF(privKey, &chaincfg.MainNetParams, true)
+	node.privKeyForAddr = wif
+
+	// The refund is signed but not sent.
+	node.txOutRes = newTxOutResult(nil, 1e8, 2)
+	tx := makeRawTx([]dex.Bytes{pkScript}, []*wire.TxIn{dummyInput()})
+	tx.TxOut[0].Value = 1e8
+	txHash := tx.TxHash()
+	blockHash, _ := node.addRawTx(1, tx)
+	node.getCFilterScripts[*blockHash] = [][]byte{pkScript}
+	node.getTransactionErr = WalletTransactionNotFound
+	refundB, err := wallet.SignedRefund(ToCoinID(&txHash, 0), contract, 10)
+	if err != nil {
+		t.Fatalf("SignedRefund error: %v", err)
+	}
+	if node.sentRawTx != nil {
+		t.Fatalf("refund was broadcast")
+	}
+	refundTx, err := wallet.deserializeTx(refundB)
+	if err != nil {
+		t.Fatalf("error decoding refund: %v", err)
+	}
+	if refundTx.LockTime != uint32(lockTime.Unix()) || refundTx.TxIn[0].PreviousOutPoint.Hash != txHash {
+		t.Fatalf("wrong refund tx")
+	}
+
+	// The secret written at the offset is the only unsigned difference.
+	swap := &asset.AuditInfo{
+		Coin:       NewOutput(tTxHash, 0, toSatoshi(5)),
+		Contract:   contract,
+		Recipient:  addr.String(),
+		Expiration: lockTime,
+	}
+	redeemB, offset, err := wallet.RedeemTemplate(swap, 10)
+	if err != nil {
+		t.Fatalf("RedeemTemplate error: %v", err)
+	}
+	if node.sentRawTx != nil {
+		t.Fatalf("redeem was broadcast")
+	}
+	copy(redeemB[offset:], secret)
+	redeemTx, err := wallet.deserializeTx(redeemB)
+	if err != nil {
+		t.Fatalf("error decoding redeem: %v", err)
+	}
+	if redeemTx.TxIn[0].PreviousOutPoint.Hash != *tTxHash {
+		t.Fatalf("wrong redeem outpoint")
+	}
+	var pushes [][]byte
+	if segwit {
+		pushes = redeemTx.TxIn[0].Witness
+	} else {
+		pushes, _ = txscript.PushedData(redeemTx.TxIn[0].SignatureScript)
+	}
+	var found bool
+	for _, push := range pushes {
+		found = found || bytes.Equal(push, secret)
+	}
+	if !found {
+		t.Fatalf("secret not found in redeem tx")
+	}
+
+	swap.Coin = NewOutput(tTxHash, 0, 200)
+	if _, _, err := wallet.RedeemTemplate(swap, 10); err == nil {
+		t.Fatalf("no error for redemption not worth the fees")
+	}
+}
+
 func TestLockUnlock(t *testing.T) {
 	runRubric(t, testLockUnlock)
 }
@@ -0,0 +1,140 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package btc
+
+import (
+	"errors"
+	"fmt"
+
+	"decred.org/dcrdex/client/asset"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// SendMulti pays all of the addresses in a single transaction. The returned
+// coins are the transaction's outputs, in the order of the payments. Part of
+// the asset.MultiSender interface.
+func (btc *intermediaryWallet) SendMulti(payments []*asset.Payment, feeRate uint64) ([]asset.Coin, error) {
+	txHash, values, err := btc.sendMulti(payments, btc.feeRateWithFallback(feeRate))
+	if err != nil {
+		return nil, err
+	}
+	coins := make([]asset.Coin, 0, len(values))
+	for vout, v := range values {
+		coins = append(coins, NewOutput(txHash, uint32(vout), v))
+	}
+	return coins, nil
+}
+
+// EstimateSendMultiTxFee estimates the fees for SendMulti. Part of the
+// asset.MultiSender interface.
+func (btc *intermediaryWallet) EstimateSendMultiTxFee(payments []*asset.Payment, feeRate uint64) (uint64, error) {
+	feeRate = btc.feeRateWithFallback(feeRate)
+	txOuts, _, err := btc.paymentOutputs(payments, feeRate)
+	if err != nil {
+		return 0, err
+	}
+	tx := wire.NewMsgTx(btc.txVersion())
+	for _, txOut := range txOuts {
+		tx.AddTxOut(txOut)
+	}
+	return btc.txFeeEstimator.EstimateSendTxFee(tx, feeRate, false)
+}
+
+// paymentOutputs creates the transaction outputs for the payments, returning
+// the outputs and their total value. An error is returned if any of the
+// addresses are invalid or any of the outputs would be dust.
+func (btc *baseWallet) paymentOutputs(payments []*asset.Payment, feeRate uint64) ([]*wire.TxOut, uint64, error) {
+	if len(payments) == 0 {
+		return nil, 0, errors.New("no payments")
+	}
+	txOuts := make([]*wire.TxOut, 0, len(payments))
+	var total uint64
+	for _, p := range payments {
+		addr, err := btc.decodeAddr(p.Address, btc.chainParams)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid address: %s", p.Address)
+		}
+		var pkScript []byte
+		if scripter, is := addr.(PaymentScripter); is {
+			pkScript, err = scripter.PaymentScript()
+		} else {
+			pkScript, err = txscript.PayToAddrScript(addr)
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("PayToAddrScript error: %w", err)
+		}
+		txOut := wire.NewTxOut(int64(p.Value), pkScript)
+		if btc.IsDust(txOut, feeRate) {
+			return nil, 0, fmt.Errorf("payment of %d to %s is dust", p.Value, p.Address)
+		}
+		txOuts = append(txOuts, txOut)
+		total += p.Value
+	}
+	return txOuts, total, nil
+}
+
+// sendMulti pays all of the addresses in a single transaction with a change
+// output. The fees are in addition to the payment values. feeRate is in units
+// of sats/byte.
+func (btc *baseWallet) sendMulti(payments []*asset.Payment, feeRate uint64) (*chainhash.Hash, []uint64, error) {
+	txOuts, total, err := btc.paymentOutputs(payments, feeRate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	baseSize := uint64(dexbtc.MinimumTxOverhead + wire.VarIntSerializeSize(uint64(len(txOuts)+1)) - 1)
+	for _, txOut := range txOuts {
+		baseSize += uint64(txOut.SerializeSize())
+	}
+	if btc.segwit {
+		baseSize += dexbtc.P2WPKHOutputSize
+	} else {
+		baseSize += dexbtc.P2PKHOutputSize
+	}
+
+	enough := SendEnough(total, feeRate, false, baseSize, btc.segwit, true)
+	coins, _, _, _, _, _, err := btc.cm.Fund(btc.bondReserves.Load(), 0, false, enough)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error funding transaction: %w", err)
+	}
+
+	fundedTx, totalIn, _, err := btc.fundedTx(coins)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error adding inputs to transaction: %w", err)
+	}
+	values := make([]uint64, 0, len(txOuts))
+	for _, txOut := range txOuts {
+		fundedTx.AddTxOut(txOut)
+		values = append(values, uint64(txOut.Value))
+	}
+
+	changeAddr, err := btc.node.ChangeAddress()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating change address: %w", err)
+	}
+
+	msgTx, err := btc.sendWithReturn(fundedTx, changeAddr, totalIn, total, feeRate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txHash := btc.hashTx(msgTx)
+
+	var totalOut uint64
+	for _, txOut := range msgTx.TxOut {
+		totalOut += uint64(txOut.Value)
+	}
+
+	btc.addTxToHistory(&asset.WalletTransaction{
+		Type:   asset.Send,
+		ID:     txHash.String(),
+		Amount: total,
+		Fees:   totalIn - totalOut,
+	}, txHash, true)
+
+	return txHash, values, nil
+}
@@ -0,0 +1,119 @@
+//go:build !spvlive && !harness
+
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package btc
+
+import (
+	"testing"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func TestSendMulti(t *testing.T) {
+	runRubric(t, testSendMulti)
+}
+
+func testSendMulti(t *testing.T, segwit bool, walletType string) {
+	wallet, node, shutdown := tNewWallet(segwit, walletType)
+	defer shutdown()
+
+	node.signFunc = func(tx *wire.MsgTx) {
+		signFunc(tx, 0, wallet.segwit)
+	}
+	node.changeAddr = btcAddr(segwit).String()
+
+	addr := btcAddr(segwit)
+	pkScript, _ := txscript.PayToAddrScript(addr)
+	tx := makeRawTx([]dex.Bytes{pkScript}, []*wire.TxIn{dummyInput()})
+	txHash := tx.TxHash()
+	node.listUnspent = []*ListUnspentResult{{
+		TxID:          txHash.String(),
+		Address:       addr.String(),
+		Amount:        10,
+		Confirmations: 1,
+		ScriptPubKey:  pkScript,
+		SafePtr:       boolPtr(true),
+		Spendable:     true,
+	}}
+	var bals GetBalancesResult
+	bals.Mine.Trusted = 10
+	node.getBalances = &bals
+
+	payments := []*asset.Payment{
+		{Address: btcAddr(segwit).String(), Value: toSatoshi(1)},
+		{Address: btcAddr(segwit).String(), Value: toSatoshi(2)},
+		{Address: btcAddr(segwit).String(), Value: toSatoshi(3)},
+	}
+	const feeRate = 20
+	txSize := dexbtc.MinimumTxOverhead + dexbtc.P2PKHOutputSize*4 + dexbtc.RedeemP2PKHInputSize
+	if segwit {
+		txSize = dexbtc.MinimumTxOverhead + dexbtc.P2WPKHOutputSize*4 + dexbtc.RedeemP2WPKHInputTotalSize
+	}
+	expFees := uint64(txSize * feeRate)
+
+	fee, err := wallet.EstimateSendMultiTxFee(payments, feeRate)
+	if err != nil {
+		t.Fatalf("EstimateSendMultiTxFee error: %v", err)
+	}
+	// The RPC wallet's estimate comes from the node.
+	if walletType == walletTypeSPV && fee != expFees {
+		t.Fatalf("wrong fee estimate. wanted %d, got %d", expFees, fee)
+	}
+
+	coins, err := wallet.SendMulti(payments, feeRate)
+	if err != nil {
+		t.Fatalf("SendMulti error: %v", err)
+	}
+	sentTx := node.sentRawTx
+	if len(sentTx.TxOut) != 4 {
+		t.Fatalf("expected 4 outputs, got %d", len(sentTx.TxOut))
+	}
+	if len(coins) != len(payments) {
+		t.Fatalf("expected %d coins, got %d", len(payments), len(coins))
+	}
+	sentHash := sentTx.TxHash()
+	for i, p := range payments {
+		if uint64(sentTx.TxOut[i].Value) != p.Value {
+			t.Fatalf("wrong value for output %d. wanted %d, got %d", i, p.Value, sentTx.TxOut[i].Value)
+		}
+		op := coins[i].(*Output)
+		if op.Pt.TxHash != sentHash || op.Pt.Vout != uint32(i) || op.Val != p.Value {
+			t.Fatalf("wrong coin for payment %d", i)
+		}
+	}
+	if change := uint64(sentTx.TxOut[3].Value); change != toSatoshi(10)-toSatoshi(6)-expFees {
+		t.Fatalf("wrong change. wanted %d, got %d", toSatoshi(10)-toSatoshi(6)-expFees, change)
+	}
+
+	// Insufficient funds.
+	tooMuch := append(payments, &asset.Payment{Address: btcAddr(segwit).String(), Value: toSatoshi(4)})
+	if _, err := wallet.SendMulti(tooMuch, feeRate); err == nil {
+		t.Fatalf("no error for insufficient funds")
+	}
+	if _, err := wallet.EstimateSendMultiTxFee(tooMuch, feeRate); walletType == walletTypeSPV && err == nil {
+		t.Fatalf("no fee estimate error for insufficient funds")
+	}
+
+	// Dust.
+	dust := []*asset.Payment{{Address: btcAddr(segwit).String(), Value: 1}}
+	if _, err := wallet.SendMulti(dust, feeRate); err == nil {
+		t.Fatalf("no error for dust payment")
+	}
+
+	// Bad address.
+	badAddr := []*asset.Payment{{Address: "notanaddress", Value: toSatoshi(1)}}
+	if _, err := wallet.SendMulti(badAddr, feeRate); err == nil {
+		t.Fatalf("no error for bad address")
+	}
+
+	// No payments.
+	if _, err := wallet.SendMulti(nil, feeRate); err == nil {
+		t.Fatalf("no error for no payments")
+	}
+}
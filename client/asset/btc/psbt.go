@@ -0,0 +1,353 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package btc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// psbtInputFetcher is satisfied by Wallet implementations that can provide the
+// previous outputs and key derivation paths of wallet-owned inputs, which an
+// external signer needs to sign a PSBT.
+type psbtInputFetcher interface {
+	fetchInputInfo(op *wire.OutPoint) (*wire.MsgTx, *wire.TxOut, *psbt.Bip32Derivation, error)
+}
+
+var _ asset.PSBTer = (*ExchangeWalletSPV)(nil)
+
+// SendPSBT authors an unsigned PSBT that sends value to the address. If
+// subtract is true, the fees are subtracted from the value. feeRate is in
+// units of sats/byte. SendPSBT satisfies asset.PSBTer.
+func (btc *ExchangeWalletSPV) SendPSBT(address string, value, feeRate uint64, subtract bool) ([]byte, error) {
+	return btc.sendPSBT(address, value, btc.feeRateWithFallback(feeRate), subtract)
+}
+
+// MakeBondPSBT authors a bond transaction with unsigned funding inputs.
+// MakeBondPSBT satisfies asset.PSBTer.
+func (btc *ExchangeWalletSPV) MakeBondPSBT(ver uint16, amt, feeRate uint64, lockTime time.Time,
+	bondKey *secp256k1.PrivateKey, acctID []byte) (*asset.Bond, []byte, func(), error) {
+
+	bond, unsignedTx, abandon, err := btc.makeBondTx(ver, amt, feeRate, lockTime, bondKey, acctID, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	b, err := btc.walletPSBT(unsignedTx)
+	if err != nil {
+		abandon()
+		return nil, nil, nil, err
+	}
+	return bond, b, abandon, nil
+}
+
+// RefundBondPSBT authors an unsigned PSBT that refunds the bond output to a new
+// wallet address. RefundBondPSBT satisfies asset.PSBTer.
+func (btc *ExchangeWalletSPV) RefundBondPSBT(ver uint16, coinID, script []byte, amt, feeRate uint64) ([]byte, error) {
+	return btc.refundBondPSBT(ver, coinID, script, amt, btc.feeRateWithFallback(feeRate))
+}
+
+// BroadcastPSBT finalizes and broadcasts a signed PSBT. BroadcastPSBT satisfies
+// asset.PSBTer.
+func (btc *ExchangeWalletSPV) BroadcastPSBT(b []byte) (string, error) {
+	return btc.broadcastPSBT(b)
+}
+
+// AbandonPSBT unlocks the wallet inputs of an unsigned PSBT that will not be
+// broadcast. AbandonPSBT satisfies asset.PSBTer.
+func (btc *ExchangeWalletSPV) AbandonPSBT(b []byte) (string, error) {
+	return btc.abandonPSBT(b)
+}
+
+// sendPSBT is like send, but the transaction is returned as an unsigned PSBT
+// instead of being signed and broadcast. The funding coins are locked.
+func (btc *baseWallet) sendPSBT(address string, val, feeRate uint64, subtract bool) ([]byte, error) {
+	if !btc.segwit {
+		return nil, errors.New("PSBTs are only supported for segwit wallets")
+	}
+	addr, err := btc.decodeAddr(address, btc.chainParams)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %s", address)
+	}
+	var pay2script []byte
+	if scripter, is := addr.(PaymentScripter); is {
+		pay2script, err = scripter.PaymentScript()
+	} else {
+		pay2script, err = txscript.PayToAddrScript(addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PayToAddrScript error: %w", err)
+	}
+
+	const baseSize = dexbtc.MinimumTxOverhead + dexbtc.P2WPKHOutputSize*2
+	enough := SendEnough(val, feeRate, subtract, baseSize, true, true)
+	coins, _, _, _, inputsSize, _, err := btc.cm.Fund(btc.bondReserves.Load(), 0, true, enough)
+	if err != nil {
+		return nil, fmt.Errorf("error funding transaction: %w", err)
+	}
+
+	var success bool
+	defer func() {
+		if !success {
+			if err := btc.ReturnCoins(coins); err != nil {
+				btc.log.Errorf("error returning coins for unused PSBT: %v", err)
+			}
+		}
+	}()
+
+	fundedTx, totalIn, _, err := btc.fundedTx(coins)
+	if err != nil {
+		return nil, fmt.Errorf("error adding inputs to transaction: %w", err)
+	}
+
+	fees := feeRate * (inputsSize + baseSize)
+	toSend := val
+	if subtract {
+		if fees >= val {
+			return nil, fmt.Errorf("fees of %d exceed the value %d", fees, val)
+		}
+		toSend = val - fees
+	}
+	fundedTx.AddTxOut(wire.NewTxOut(int64(toSend), pay2script))
+
+	changeAddr, err := btc.node.ChangeAddress()
+	if err != nil {
+		return nil, fmt.Errorf("error creating change address: %w", err)
+	}
+	fee, err := btc.addChangeUnsigned(fundedTx, changeAddr, totalIn, toSend, fees, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := btc.walletPSBT(fundedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	selfSend, err := btc.OwnsDepositAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("error checking address ownership: %w", err)
+	}
+	txType := asset.Send
+	if selfSend {
+		txType = asset.SelfSend
+	}
+
+	// The txid of a transaction with only segwit inputs does not change when
+	// it is signed, so the history entry can be prepared now and recorded when
+	// the signed PSBT is broadcast.
+	txHash := btc.hashTx(fundedTx)
+	btc.storePSBTTx(txHash, &asset.WalletTransaction{
+		Type:      txType,
+		ID:        txHash.String(),
+		Amount:    toSend,
+		Fees:      fee,
+		Recipient: &address,
+	})
+
+	success = true
+	return b, nil
+}
+
+// addChangeUnsigned adds a change output to an unsigned transaction unless the
+// change would be dust. fees should be the fees for the signed transaction
+// with the change output. The actual fees paid are returned.
+func (btc *baseWallet) addChangeUnsigned(tx *wire.MsgTx, addr btcutil.Address, totalIn, totalOut, fees, feeRate uint64) (uint64, error) {
+	if totalOut+fees > totalIn {
+		return 0, fmt.Errorf("not enough funds to cover minimum fee rate. %.8f < %.8f",
+			toBTC(totalIn), toBTC(totalOut+fees))
+	}
+	changeScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return 0, fmt.Errorf("error creating change script: %w", err)
+	}
+	changeOutput := wire.NewTxOut(int64(totalIn-totalOut-fees), changeScript)
+	if btc.IsDust(changeOutput, feeRate) {
+		btc.log.Debugf("Foregoing change worth up to %v in unsigned tx because it is dust", changeOutput.Value)
+		return totalIn - totalOut, nil
+	}
+	tx.AddTxOut(changeOutput)
+	return fees, nil
+}
+
+// walletPSBT creates a serialized PSBT for the unsigned transaction, which
+// must spend only wallet-owned outputs.
+func (btc *baseWallet) walletPSBT(tx *wire.MsgTx) ([]byte, error) {
+	fetcher, is := btc.node.(psbtInputFetcher)
+	if !is {
+		return nil, errors.New("wallet cannot author PSBTs")
+	}
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating PSBT: %w", err)
+	}
+	for i, txIn := range tx.TxIn {
+		prevTx, prevOut, derivation, err := fetcher.fetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching info for input %s: %w", txIn.PreviousOutPoint, err)
+		}
+		pIn := &packet.Inputs[i]
+		// The full previous transaction is included with the witness output
+		// so that signers can verify the input amount.
+		pIn.NonWitnessUtxo = prevTx
+		pIn.WitnessUtxo = prevOut
+		pIn.SighashType = txscript.SigHashAll
+		if derivation != nil {
+			pIn.Bip32Derivation = []*psbt.Bip32Derivation{derivation}
+		}
+	}
+	return serializePSBT(packet)
+}
+
+// refundBondPSBT creates an unsigned PSBT spending a v0 bond output. The
+// witness script is included so that the holder of the bond key can sign it.
+func (btc *baseWallet) refundBondPSBT(ver uint16, coinID, script []byte, amt, feeRate uint64) ([]byte, error) {
+	if ver != 0 {
+		return nil, errors.New("only version 0 bonds supported")
+	}
+	if !btc.segwit {
+		return nil, errors.New("PSBTs are only supported for segwit wallets")
+	}
+	txHash, vout, err := decodeCoinID(coinID)
+	if err != nil {
+		return nil, err
+	}
+	msgTx, err := btc.unsignedBondRefundTxV0(txHash, vout, amt, script, feeRate)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := btc.scriptHashScript(script)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing p2wsh script: %w", err)
+	}
+	packet, err := psbt.NewFromUnsignedTx(msgTx)
+	if err != nil {
+		return nil, fmt.Errorf("error creating PSBT: %w", err)
+	}
+	pIn := &packet.Inputs[0]
+	pIn.WitnessUtxo = wire.NewTxOut(int64(amt), pkScript)
+	pIn.WitnessScript = script
+	pIn.SighashType = txscript.SigHashAll
+	return serializePSBT(packet)
+}
+
+// broadcastPSBT finalizes the signed PSBT and broadcasts the transaction.
+func (btc *baseWallet) broadcastPSBT(b []byte) (string, error) {
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(b), false)
+	if err != nil {
+		return "", fmt.Errorf("error decoding PSBT: %w", err)
+	}
+	for i := range packet.Inputs {
+		if err := finalizePSBTInput(packet, i); err != nil {
+			return "", fmt.Errorf("error finalizing input %d: %w", i, err)
+		}
+	}
+	msgTx, err := psbt.Extract(packet)
+	if err != nil {
+		return "", fmt.Errorf("error extracting transaction: %w", err)
+	}
+	txHash, err := btc.broadcastTx(msgTx)
+	if err != nil {
+		return "", err
+	}
+	// The spent outputs no longer need to be tracked as locked.
+	pts := make([]OutPoint, 0, len(msgTx.TxIn))
+	for _, txIn := range msgTx.TxIn {
+		pts = append(pts, NewOutPoint(&txIn.PreviousOutPoint.Hash, txIn.PreviousOutPoint.Index))
+	}
+	btc.cm.UnlockOutPoints(pts)
+	if wt := btc.popPSBTTx(txHash); wt != nil {
+		btc.addTxToHistory(wt, txHash, true)
+	}
+	return txHash.String(), nil
+}
+
+// abandonPSBT returns the wallet-owned inputs of an unsigned PSBT and forgets
+// its pending history entry. The transaction ID is returned.
+func (btc *baseWallet) abandonPSBT(b []byte) (string, error) {
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(b), false)
+	if err != nil {
+		return "", fmt.Errorf("error decoding PSBT: %w", err)
+	}
+	txHash := btc.hashTx(packet.UnsignedTx)
+	for _, txIn := range packet.UnsignedTx.TxIn {
+		pt := NewOutPoint(&txIn.PreviousOutPoint.Hash, txIn.PreviousOutPoint.Index)
+		if btc.cm.LockedOutput(pt) == nil {
+			continue // e.g. a bond input
+		}
+		if err := btc.cm.ReturnOutPoint(pt); err != nil {
+			return "", fmt.Errorf("error unlocking input %s: %w", pt, err)
+		}
+	}
+	btc.popPSBTTx(txHash)
+	return txHash.String(), nil
+}
+
+// storePSBTTx saves the history entry for an unsigned PSBT until it is
+// broadcast or abandoned.
+func (btc *baseWallet) storePSBTTx(txHash *chainhash.Hash, wt *asset.WalletTransaction) {
+	btc.psbtTxsMtx.Lock()
+	btc.psbtTxs[*txHash] = wt
+	btc.psbtTxsMtx.Unlock()
+}
+
+// popPSBTTx removes and returns the history entry for an unsigned PSBT, or nil
+// if the wallet did not author it.
+func (btc *baseWallet) popPSBTTx(txHash *chainhash.Hash) *asset.WalletTransaction {
+	btc.psbtTxsMtx.Lock()
+	defer btc.psbtTxsMtx.Unlock()
+	wt := btc.psbtTxs[*txHash]
+	delete(btc.psbtTxs, *txHash)
+	return wt
+}
+
+// finalizePSBTInput finalizes the signed PSBT input, unless the signer already
+// did. Bond refund inputs are finalized here, since the bond script is not a
+// standard script that the psbt package knows how to finalize.
+func finalizePSBTInput(packet *psbt.Packet, i int) error {
+	pIn := &packet.Inputs[i]
+	if len(pIn.FinalScriptWitness) > 0 || len(pIn.FinalScriptSig) > 0 {
+		return nil
+	}
+	if len(pIn.WitnessScript) == 0 {
+		return psbt.Finalize(packet, i)
+	}
+	if _, _, err := dexbtc.ExtractBondDetailsV0(0, pIn.WitnessScript); err != nil {
+		return psbt.Finalize(packet, i)
+	}
+	if len(pIn.PartialSigs) != 1 {
+		return fmt.Errorf("bond input requires exactly 1 signature, found %d", len(pIn.PartialSigs))
+	}
+	sig := pIn.PartialSigs[0]
+	var buf bytes.Buffer
+	err := psbt.WriteTxWitness(&buf, dexbtc.RefundBondScriptSegwit(pIn.WitnessScript, sig.Signature, sig.PubKey))
+	if err != nil {
+		return err
+	}
+	pIn.FinalScriptWitness = buf.Bytes()
+	// BIP 174 requires that all other data be cleared from a finalized input.
+	pIn.PartialSigs = nil
+	pIn.SighashType = 0
+	pIn.WitnessScript = nil
+	pIn.Bip32Derivation = nil
+	return nil
+}
+
+func serializePSBT(packet *psbt.Packet) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := packet.Serialize(&buf); err != nil {
+		return nil, fmt.Errorf("error serializing PSBT: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,280 @@
+//go:build !spvlive && !harness
+
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package btc
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+func signPSBTInput(t *testing.T, b []byte, i int, priv *btcec.PrivateKey, subScript []byte) []byte {
+	t.Helper()
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(b), false)
+	if err != nil {
+		t.Fatalf("error decoding PSBT: %v", err)
+	}
+	pIn := &packet.Inputs[i]
+	fetcher := txscript.NewCannedPrevOutputFetcher(pIn.WitnessUtxo.PkScript, pIn.WitnessUtxo.Value)
+	sigHashes := txscript.NewTxSigHashes(packet.UnsignedTx, fetcher)
+	sig, err := txscript.RawTxInWitnessSignature(packet.UnsignedTx, sigHashes, i,
+		pIn.WitnessUtxo.Value, subScript, txscript.SigHashAll, priv)
+	if err != nil {
+		t.Fatalf("error signing input: %v", err)
+	}
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		t.Fatalf("NewUpdater error: %v", err)
+	}
+	if _, err := updater.Sign(i, sig, priv.PubKey().SerializeCompressed(), nil, pIn.WitnessScript); err != nil {
+		t.Fatalf("Sign error: %v", err)
+	}
+	signed, err := serializePSBT(packet)
+	if err != nil {
+		t.Fatalf("serializePSBT error: %v", err)
+	}
+	return signed
+}
+
+func TestSendPSBT(t *testing.T) {
+	wallet, node, shutdown := tNewWallet(true, walletTypeSPV)
+	defer shutdown()
+
+	priv, _ := btcec.NewPrivateKey()
+	inAddr, _ := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(priv.PubKey().SerializeCompressed()), &chaincfg.MainNetParams)
+	inPkScript, _ := txscript.PayToAddrScript(inAddr)
+	prevTx := makeRawTx([]dex.Bytes{inPkScript}, []*wire.TxIn{dummyInput()})
+	prevTx.TxOut[0].Value = 1e8
+	prevHash := prevTx.TxHash()
+	node.fetchInputInfoTx = prevTx
+	node.listUnspent = []*ListUnspentResult{{
+		TxID:          prevHash.String(),
+		Address:       inAddr.String(),
+		Amount:        1,
+		Confirmations: 1,
+		ScriptPubKey:  inPkScript,
+		SafePtr:       boolPtr(true),
+		Spendable:     true,
+	}}
+	node.changeAddr = btcAddr(true).String()
+
+	const feeRate = 10
+	expFees := uint64((dexbtc.MinimumTxOverhead + dexbtc.P2WPKHOutputSize*2 + dexbtc.RedeemP2WPKHInputTotalSize) * feeRate)
+	addr := btcAddr(true).String()
+
+	for _, subtract := range []bool{false, true} {
+		node.lockedCoins = nil
+		b, err := wallet.sendPSBT(addr, 5e7, feeRate, subtract)
+		if err != nil {
+			t.Fatalf("sendPSBT error (subtract = %t): %v", subtract, err)
+		}
+		packet, err := psbt.NewFromRawBytes(bytes.NewReader(b), false)
+		if err != nil {
+			t.Fatalf("error decoding PSBT: %v", err)
+		}
+		tx := packet.UnsignedTx
+		if len(tx.TxIn) != 1 || tx.TxIn[0].PreviousOutPoint.Hash != prevHash {
+			t.Fatalf("wrong inputs")
+		}
+		if len(tx.TxOut) != 2 {
+			t.Fatalf("expected 2 outputs, got %d", len(tx.TxOut))
+		}
+		expSent, expChange := uint64(5e7), 1e8-5e7-expFees
+		if subtract {
+			expSent, expChange = 5e7-expFees, 5e7
+		}
+		if uint64(tx.TxOut[0].Value) != expSent {
+			t.Fatalf("wrong sent value. wanted %d, got %d", expSent, tx.TxOut[0].Value)
+		}
+		if uint64(tx.TxOut[1].Value) != expChange {
+			t.Fatalf("wrong change value. wanted %d, got %d", expChange, tx.TxOut[1].Value)
+		}
+		if packet.Inputs[0].WitnessUtxo == nil || packet.Inputs[0].WitnessUtxo.Value != 1e8 {
+			t.Fatalf("witness utxo not set")
+		}
+		if len(node.lockedCoins) != 1 {
+			t.Fatalf("funding coins not locked")
+		}
+		txHash := tx.TxHash()
+		if wallet.psbtTxs[txHash] == nil {
+			t.Fatalf("history entry not prepared")
+		}
+
+		signed := signPSBTInput(t, b, 0, priv, inPkScript)
+		node.sentRawTx = nil
+		txID, err := wallet.broadcastPSBT(signed)
+		if err != nil {
+			t.Fatalf("broadcastPSBT error: %v", err)
+		}
+		if node.sentRawTx == nil {
+			t.Fatalf("transaction not broadcast")
+		}
+		if txID != tx.TxHash().String() {
+			t.Fatalf("txid changed after signing")
+		}
+		if len(node.sentRawTx.TxIn[0].Witness) != 2 {
+			t.Fatalf("input not finalized")
+		}
+		if wallet.psbtTxs[txHash] != nil {
+			t.Fatalf("history entry still pending after broadcast")
+		}
+	}
+
+	// An unsigned PSBT can't be broadcast.
+	b, err := wallet.sendPSBT(addr, 5e7, feeRate, false)
+	if err != nil {
+		t.Fatalf("sendPSBT error: %v", err)
+	}
+	if _, err := wallet.broadcastPSBT(b); err == nil {
+		t.Fatalf("no error broadcasting unsigned PSBT")
+	}
+
+	// Abandoning the PSBT unlocks the inputs and drops the history entry.
+	pt := NewOutPoint(&prevHash, 0)
+	if wallet.cm.LockedOutput(pt) == nil {
+		t.Fatalf("funding coin not locked")
+	}
+	txID, err := wallet.abandonPSBT(b)
+	if err != nil {
+		t.Fatalf("abandonPSBT error: %v", err)
+	}
+	txHash, _ := chainhash.NewHashFromStr(txID)
+	if wallet.psbtTxs[*txHash] != nil {
+		t.Fatalf("history entry not dropped")
+	}
+	if wallet.cm.LockedOutput(pt) != nil {
+		t.Fatalf("funding coin not unlocked")
+	}
+	if _, err := wallet.abandonPSBT([]byte{0x01}); err == nil {
+		t.Fatalf("no error for invalid PSBT")
+	}
+
+	// Insufficient funds.
+	if _, err := wallet.sendPSBT(addr, 2e8, feeRate, false); err == nil {
+		t.Fatalf("no error for insufficient funds")
+	}
+}
+
+func TestRefundBondPSBT(t *testing.T) {
+	wallet, node, shutdown := tNewWallet(true, walletTypeSPV)
+	defer shutdown()
+	node.newAddress = btcAddr(true).String()
+
+	bondKey, _ := btcec.NewPrivateKey()
+	pkh := btcutil.Hash160(bondKey.PubKey().SerializeCompressed())
+	lockTime := uint32(time.Now().Unix())
+	bondScript, err := dexbtc.MakeBondScript(0, lockTime, pkh)
+	if err != nil {
+		t.Fatalf("MakeBondScript error: %v", err)
+	}
+	const amt = 1e7
+	coinID := ToCoinID(&chainhash.Hash{0x02}, 0)
+
+	b, err := wallet.refundBondPSBT(0, coinID, bondScript, amt, 10)
+	if err != nil {
+		t.Fatalf("refundBondPSBT error: %v", err)
+	}
+
+	if _, err := wallet.refundBondPSBT(1, coinID, bondScript, amt, 10); err == nil {
+		t.Fatalf("no error for unknown bond version")
+	}
+
+	signed := signPSBTInput(t, b, 0, bondKey, bondScript)
+	node.sentRawTx = nil
+	if _, err = wallet.broadcastPSBT(signed); err != nil {
+		t.Fatalf("broadcastPSBT error: %v", err)
+	}
+	if node.sentRawTx == nil {
+		t.Fatalf("refund not broadcast")
+	}
+	refundTx := node.sentRawTx
+	if refundTx.LockTime != lockTime {
+		t.Fatalf("wrong lock time")
+	}
+	witness := refundTx.TxIn[0].Witness
+	if len(witness) != 3 || !bytes.Equal(witness[2], bondScript) {
+		t.Fatalf("bond input not finalized correctly")
+	}
+
+	// Verify the refund actually spends the bond output.
+	pkScript, _ := wallet.scriptHashScript(bondScript)
+	fetcher := txscript.NewCannedPrevOutputFetcher(pkScript, amt)
+	vm, err := txscript.NewEngine(pkScript, refundTx, 0, txscript.StandardVerifyFlags,
+		nil, txscript.NewTxSigHashes(refundTx, fetcher), amt, fetcher)
+	if err != nil {
+		t.Fatalf("NewEngine error: %v", err)
+	}
+	if err := vm.Execute(); err != nil {
+		t.Fatalf("refund script failed: %v", err)
+	}
+}
+
+func TestMakeBondPSBT(t *testing.T) {
+	wallet, node, shutdown := tNewWallet(true, walletTypeSPV)
+	defer shutdown()
+	node.changeAddr = btcAddr(true).String()
+	node.newAddress = btcAddr(true).String()
+
+	addr := btcAddr(true)
+	pkScript, _ := txscript.PayToAddrScript(addr)
+	prevTx := makeRawTx([]dex.Bytes{pkScript}, []*wire.TxIn{dummyInput()})
+	prevTx.TxOut[0].Value = 1e8
+	prevHash := prevTx.TxHash()
+	node.fetchInputInfoTx = prevTx
+	node.listUnspent = []*ListUnspentResult{{
+		TxID:          prevHash.String(),
+		Address:       addr.String(),
+		Amount:        1,
+		Confirmations: 1,
+		ScriptPubKey:  pkScript,
+		SafePtr:       boolPtr(true),
+		Spendable:     true,
+	}}
+
+	bondKey, _ := btcec.NewPrivateKey()
+	const amt = 1e7
+	bond, unsignedTx, abandon, err := wallet.makeBondTx(0, amt, 10, time.Now().Add(time.Hour), bondKey, randBytes(32), false)
+	if err != nil {
+		t.Fatalf("makeBondTx error: %v", err)
+	}
+	if wallet.psbtTxs[unsignedTx.TxHash()] == nil {
+		t.Fatalf("unsigned bond history entry not prepared")
+	}
+	abandon()
+	if wallet.psbtTxs[unsignedTx.TxHash()] != nil {
+		t.Fatalf("unsigned bond history entry not dropped")
+	}
+	if bond.SignedTx != nil {
+		t.Fatalf("unsigned bond has a signed tx")
+	}
+	txHash := unsignedTx.TxHash()
+	if !bytes.Equal(bond.CoinID, ToCoinID(&txHash, 0)) {
+		t.Fatalf("wrong bond coin ID")
+	}
+	if len(bond.RedeemTx) == 0 {
+		t.Fatalf("no backup refund tx")
+	}
+	if _, err := wallet.walletPSBT(unsignedTx); err != nil {
+		t.Fatalf("walletPSBT error: %v", err)
+	}
+
+	// Non-segwit wallets can't author unsigned bonds.
+	legacyWallet, _, legacyShutdown := tNewWallet(false, walletTypeSPV)
+	defer legacyShutdown()
+	if _, _, _, err := legacyWallet.makeBondTx(0, amt, 10, time.Now().Add(time.Hour), bondKey, randBytes(32), false); err == nil {
+		t.Fatalf("no error for unsigned non-segwit bond")
+	}
+}
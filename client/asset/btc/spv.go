@@ -2,6 +2,7 @@ package btc
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"decred.org/dcrdex/dex"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
@@ -55,6 +57,76 @@ var _ BTCWallet = (*btcSPVWallet)(nil)
 
 // createSPVWallet creates a new SPV wallet.
 func createSPVWallet(privPass []byte, seed []byte, bday time.Time, walletDir string, log dex.Logger, extIdx, intIdx uint32, net *chaincfg.Params) error {
+	return initSPVWallet(walletDir, log, extIdx, intIdx, net, func(loader *wallet.Loader, pubPass []byte) (*wallet.Wallet, error) {
+		// CreateWallet adds a -48 hrs buffer on the bday during creation.
+		btcw, err := loader.CreateNewWallet(pubPass, privPass, seed, bday)
+		if err != nil {
+			return nil, fmt.Errorf("CreateNewWallet error: %w", err)
+		}
+		return btcw, nil
+	})
+}
+
+// createWatchOnlySPVWallet creates a new SPV wallet that holds no private
+// keys. The default account is imported from a BIP84 account extended public
+// key, and transactions are signed externally via PSBTs. fingerprint is the
+// master key fingerprint of the external signer, which is included in the
+// PSBT key derivation paths.
+func createWatchOnlySPVWallet(acctKey *hdkeychain.ExtendedKey, fingerprint uint32, bday time.Time, walletDir string,
+	log dex.Logger, extIdx, intIdx uint32, net *chaincfg.Params) error {
+
+	return initSPVWallet(walletDir, log, extIdx, intIdx, net, func(loader *wallet.Loader, pubPass []byte) (*wallet.Wallet, error) {
+		btcw, err := loader.CreateNewWatchingOnlyWallet(pubPass, bday)
+		if err != nil {
+			return nil, fmt.Errorf("CreateNewWatchingOnlyWallet error: %w", err)
+		}
+		// A watching-only wallet has no key scopes. The first account
+		// imported into the new BIP84 scope is the default account.
+		props, err := btcw.ImportAccountWithScope(defaultAcctName, acctKey, fingerprint,
+			waddrmgr.KeyScopeBIP0084, waddrmgr.ScopeAddrMap[waddrmgr.KeyScopeBIP0084])
+		if err != nil {
+			return btcw, fmt.Errorf("error importing account key: %w", err)
+		}
+		if props.AccountNumber != defaultAcctNum {
+			return btcw, fmt.Errorf("imported account number %d, expected %d", props.AccountNumber, defaultAcctNum)
+		}
+		return btcw, nil
+	})
+}
+
+// parseWatchOnlyKey parses the BIP84 account extended public key for a
+// watch-only wallet. SLIP-0132 zpub and vpub encodings are accepted.
+func parseWatchOnlyKey(xpub string, net *chaincfg.Params) (*hdkeychain.ExtendedKey, error) {
+	acctKey, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended public key: %w", err)
+	}
+	if acctKey.IsPrivate() {
+		return nil, errors.New("a watch-only wallet requires an extended public key, not a private key")
+	}
+	var slip132Version waddrmgr.HDVersion = waddrmgr.HDVersionTestNetBIP0084
+	if net.Net == wire.MainNet {
+		slip132Version = waddrmgr.HDVersionMainNetBIP0084
+	}
+	if waddrmgr.HDVersion(binary.BigEndian.Uint32(acctKey.Version())) == slip132Version {
+		if acctKey, err = acctKey.CloneWithVersion(net.HDPublicKeyID[:]); err != nil {
+			return nil, fmt.Errorf("error converting extended public key: %w", err)
+		}
+	}
+	if !acctKey.IsForNet(net) {
+		return nil, fmt.Errorf("extended public key is not for network %s", net.Name)
+	}
+	if acctKey.Depth() != 3 || acctKey.ChildIndex() < hdkeychain.HardenedKeyStart {
+		return nil, errors.New("extended public key must be an account key, m/84'/coin_type'/account'")
+	}
+	return acctKey, nil
+}
+
+// initSPVWallet creates the wallet with the create function, sets the
+// starting address indexes, and creates the chain service database.
+func initSPVWallet(walletDir string, log dex.Logger, extIdx, intIdx uint32, net *chaincfg.Params,
+	create func(loader *wallet.Loader, pubPass []byte) (*wallet.Wallet, error)) error {
+
 	if err := logNeutrino(walletDir); err != nil {
 		return fmt.Errorf("error initializing btcwallet+neutrino logging: %w", err)
 	}
@@ -63,18 +135,20 @@ func createSPVWallet(privPass []byte, seed []byte, bday time.Time, walletDir str
 
 	pubPass := []byte(wallet.InsecurePubPassphrase)
 
-	// CreateWallet adds a -48 hrs buffer on the bday during creation.
-	btcw, err := loader.CreateNewWallet(pubPass, privPass, seed, bday)
-	if err != nil {
-		return fmt.Errorf("CreateNewWallet error: %w", err)
-	}
-
 	bailOnWallet := func() {
 		if err := loader.UnloadWallet(); err != nil {
 			log.Errorf("Error unloading wallet after createSPVWallet error: %v", err)
 		}
 	}
 
+	btcw, err := create(loader, pubPass)
+	if err != nil {
+		if btcw != nil {
+			bailOnWallet()
+		}
+		return err
+	}
+
 	if extIdx > 0 || intIdx > 0 {
 		err = extendAddresses(extIdx, intIdx, btcw)
 		if err != nil {
@@ -118,6 +192,12 @@ func (w *btcSPVWallet) Birthday() time.Time {
 	return w.Manager.Birthday()
 }
 
+// WatchOnly is true if the wallet was created from an extended public key and
+// holds no private keys.
+func (w *btcSPVWallet) WatchOnly() bool {
+	return w.Wallet != nil && w.Manager.WatchOnly()
+}
+
 func (w *btcSPVWallet) updateDBBirthday(bday time.Time) error {
 	btcw, isLoaded := w.loader.LoadedWallet()
 	if !isLoaded {
@@ -20,6 +20,7 @@ import (
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/gcs"
 	"github.com/btcsuite/btcd/btcutil/gcs/builder"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -95,8 +96,12 @@ func (c *tBtcWallet) ListUnspent(minconf, maxconf int32, acctName string) ([]*bt
 	return unspents, nil
 }
 
-func (c *tBtcWallet) FetchInputInfo(prevOut *wire.OutPoint) (*wire.MsgTx, *wire.TxOut, *psbt.Bip32Derivation, int64, error) {
-	return c.fetchInputInfoTx, nil, nil, 0, nil
+func (c *tBtcWallet) FetchInputInfo(op *wire.OutPoint) (*wire.MsgTx, *wire.TxOut, *psbt.Bip32Derivation, int64, error) {
+	var prevOut *wire.TxOut
+	if c.fetchInputInfoTx != nil && int(op.Index) < len(c.fetchInputInfoTx.TxOut) {
+		prevOut = c.fetchInputInfoTx.TxOut[op.Index]
+	}
+	return c.fetchInputInfoTx, prevOut, nil, 0, nil
 }
 
 func (c *tBtcWallet) ResetLockedOutpoints() {}
@@ -913,3 +918,95 @@ func TestGetBlockHeader(t *testing.T) {
 	}
 	node.mainchain = prevMainchain // clean up
 }
+
+func TestCreateWatchOnlySPVWallet(t *testing.T) {
+	net := &chaincfg.RegressionNetParams
+	master, err := hdkeychain.NewMaster(encode.RandomBytes(32), net)
+	if err != nil {
+		t.Fatalf("NewMaster error: %v", err)
+	}
+	acctPriv := master
+	for _, i := range []uint32{84, net.HDCoinType, 0} {
+		if acctPriv, err = acctPriv.Derive(hdkeychain.HardenedKeyStart + i); err != nil {
+			t.Fatalf("Derive error: %v", err)
+		}
+	}
+	acctPub, err := acctPriv.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter error: %v", err)
+	}
+
+	if _, err := parseWatchOnlyKey(acctPriv.String(), net); err == nil {
+		t.Fatalf("no error for extended private key")
+	}
+	masterPub, _ := master.Neuter()
+	if _, err := parseWatchOnlyKey(masterPub.String(), net); err == nil {
+		t.Fatalf("no error for non-account key")
+	}
+	if _, err := parseWatchOnlyKey(acctPub.String(), &chaincfg.MainNetParams); err == nil {
+		t.Fatalf("no error for wrong network")
+	}
+	acctKey, err := parseWatchOnlyKey(acctPub.String(), net)
+	if err != nil {
+		t.Fatalf("parseWatchOnlyKey error: %v", err)
+	}
+
+	const fingerprint = 0x01020304
+	dir := t.TempDir()
+	if err := createWatchOnlySPVWallet(acctKey, fingerprint, time.Now(), dir, tLogger, 0, 0, net); err != nil {
+		t.Fatalf("createWatchOnlySPVWallet error: %v", err)
+	}
+
+	loader := wallet.NewLoader(net, dir, true, dbTimeout, 250)
+	btcw, err := loader.OpenExistingWallet([]byte(wallet.InsecurePubPassphrase), false)
+	if err != nil {
+		t.Fatalf("OpenExistingWallet error: %v", err)
+	}
+	defer loader.UnloadWallet()
+	w := &btcSPVWallet{Wallet: btcw}
+	if !w.WatchOnly() {
+		t.Fatalf("wallet is not watch-only")
+	}
+	props, err := btcw.AccountProperties(waddrmgr.KeyScopeBIP0084, defaultAcctNum)
+	if err != nil {
+		t.Fatalf("AccountProperties error: %v", err)
+	}
+	if props.AccountName != defaultAcctName || props.MasterKeyFingerprint != fingerprint {
+		t.Fatalf("wrong account properties: %+v", props)
+	}
+
+	// The wallet's first address is derived from the account key.
+	extKey, _ := acctPub.Derive(0)
+	addrKey, _ := extKey.Derive(0)
+	addrPK, _ := addrKey.ECPubKey()
+	expAddr, _ := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(addrPK.SerializeCompressed()), net)
+	scopedMgr, err := btcw.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("FetchScopedKeyManager error: %v", err)
+	}
+	var addr btcutil.Address
+	err = walletdb.View(btcw.Database(), func(dbtx walletdb.ReadTx) error {
+		ma, err := scopedMgr.DeriveFromKeyPath(dbtx.ReadBucket(wAddrMgrBkt), waddrmgr.DerivationPath{})
+		if err == nil {
+			addr = ma.Address()
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatalf("DeriveFromKeyPath error: %v", err)
+	}
+	if addr.String() != expAddr.String() {
+		t.Fatalf("wrong address. wanted %s, got %s", expAddr, addr)
+	}
+
+	spvw := &spvWallet{wallet: w}
+	if spvw.Locked() {
+		t.Fatalf("watch-only wallet reported as locked")
+	}
+	if err := spvw.Unlock([]byte("abc")); err != nil {
+		t.Fatalf("Unlock error for watch-only wallet: %v", err)
+	}
+	if _, err := spvw.SignTx(wire.NewMsgTx(wire.TxVersion)); !errors.Is(err, errWatchOnly) {
+		t.Fatalf("wrong SignTx error for watch-only wallet: %v", err)
+	}
+}
@@ -668,14 +668,28 @@ func (w *spvWallet) ExternalAddress() (btcutil.Address, error) {
 
 // signTx attempts to have the wallet sign the transaction inputs.
 func (w *spvWallet) SignTx(tx *wire.MsgTx) (*wire.MsgTx, error) {
+	if w.watchOnly() {
+		return nil, errWatchOnly
+	}
 	// Can't use btcwallet.Wallet.SignTransaction, because it doesn't work for
 	// segwit transactions (for real?).
 	return tx, w.wallet.SignTx(tx)
 }
 
+// fetchInputInfo retrieves the previous transaction, the spent output and the
+// key derivation path for a wallet-owned outpoint. The wallet does not need to
+// be unlocked. fetchInputInfo satisfies psbtInputFetcher.
+func (w *spvWallet) fetchInputInfo(op *wire.OutPoint) (*wire.MsgTx, *wire.TxOut, *psbt.Bip32Derivation, error) {
+	prevTx, prevOut, derivation, _, err := w.wallet.FetchInputInfo(op)
+	return prevTx, prevOut, derivation, err
+}
+
 // PrivKeyForAddress retrieves the private key associated with the specified
 // address.
 func (w *spvWallet) PrivKeyForAddress(addr string) (*btcec.PrivateKey, error) {
+	if w.watchOnly() {
+		return nil, errWatchOnly
+	}
 	a, err := w.decodeAddr(addr, w.chainParams)
 	if err != nil {
 		return nil, err
@@ -683,17 +697,38 @@ func (w *spvWallet) PrivKeyForAddress(addr string) (*btcec.PrivateKey, error) {
 	return w.wallet.PrivKeyForAddress(a)
 }
 
-// Unlock unlocks the wallet.
+// Unlock unlocks the wallet. A watch-only wallet has no keys to unlock.
 func (w *spvWallet) Unlock(pw []byte) error {
+	if w.watchOnly() {
+		return nil
+	}
 	return w.wallet.Unlock(pw, nil)
 }
 
 // Lock locks the wallet.
 func (w *spvWallet) Lock() error {
+	if w.watchOnly() {
+		return nil
+	}
 	w.wallet.Lock()
 	return nil
 }
 
+// watchOnlyWallet is satisfied by BTCWallets that can be created without
+// private keys.
+type watchOnlyWallet interface {
+	WatchOnly() bool
+}
+
+// errWatchOnly is returned when a watch-only wallet is asked to sign.
+var errWatchOnly = errors.New("watch-only wallet cannot sign transactions; use PSBTs with an external signer")
+
+// watchOnly is true if the wallet holds no private keys.
+func (w *spvWallet) watchOnly() bool {
+	wo, is := w.wallet.(watchOnlyWallet)
+	return is && wo.WatchOnly()
+}
+
 // EstimateSendTxFee callers should provide at least one output value.
 func (w *spvWallet) EstimateSendTxFee(tx *wire.MsgTx, feeRate uint64, subtract bool) (fee uint64, err error) {
 	minTxSize := uint64(tx.SerializeSize())
@@ -843,12 +878,14 @@ func (w *spvWallet) SwapConfirmations(txHash *chainhash.Hash, vout uint32, pkScr
 }
 
 func (w *spvWallet) Locked() bool {
+	if w.watchOnly() {
+		return false
+	}
 	return w.wallet.Locked()
 }
 
 func (w *spvWallet) WalletLock() error {
-	w.wallet.Lock()
-	return nil
+	return w.Lock()
 }
 
 func (w *spvWallet) WalletUnlock(pw []byte) error {
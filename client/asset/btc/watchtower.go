@@ -0,0 +1,131 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package btc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var _ asset.Watchtowered = (*baseWallet)(nil)
+
+// SignedRefund creates, but does not broadcast, a signed refund transaction
+// for the swap contract. The transaction is not valid until the contract's
+// lock time has passed. SignedRefund satisfies asset.Watchtowered.
+func (btc *baseWallet) SignedRefund(coinID, contract dex.Bytes, feeRate uint64) (dex.Bytes, error) {
+	txHash, vout, err := decodeCoinID(coinID)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := btc.scriptHashScript(contract)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing pubkey script: %w", err)
+	}
+	if feeRate == 0 {
+		feeRate = btc.targetFeeRateWithFallback(2, 0)
+	}
+	utxo, _, err := btc.node.GetTxOut(txHash, vout, pkScript, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("error finding unspent contract: %w", err)
+	}
+	if utxo == nil {
+		return nil, asset.CoinNotFoundError // spent
+	}
+	msgTx, err := btc.refundTx(txHash, vout, contract, uint64(utxo.Value), nil, feeRate)
+	if err != nil {
+		return nil, fmt.Errorf("error creating refund tx: %w", err)
+	}
+	return btc.serializeTx(msgTx)
+}
+
+// RedeemTemplate creates a signed redeem transaction for the counterparty's
+// swap with a random placeholder in place of the secret. The secret is not
+// covered by the signature, so writing the secret at secretOffset of the
+// serialized transaction produces a valid redemption. RedeemTemplate
+// satisfies asset.Watchtowered.
+func (btc *baseWallet) RedeemTemplate(swap *asset.AuditInfo, feeRate uint64) (dex.Bytes, int, error) {
+	cinfo, err := ConvertAuditInfo(swap, btc.decodeAddr, btc.chainParams)
+	if err != nil {
+		return nil, 0, err
+	}
+	contract := cinfo.contract
+	_, receiver, _, _, err := dexbtc.ExtractSwapDetails(contract, btc.segwit, btc.chainParams)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error extracting swap addresses: %w", err)
+	}
+	prevScript, err := btc.scriptHashScript(contract)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error constructing p2sh script: %w", err)
+	}
+
+	msgTx := wire.NewMsgTx(btc.txVersion())
+	msgTx.AddTxIn(wire.NewTxIn(cinfo.Output.WireOutPoint(), nil, nil))
+	val := cinfo.Output.Val
+
+	size := btc.calcTxSize(msgTx)
+	if btc.segwit {
+		size += (dexbtc.RedeemSwapSigScriptSize+2+3)/4 + dexbtc.P2WPKHOutputSize
+	} else {
+		size += dexbtc.RedeemSwapSigScriptSize + dexbtc.P2PKHOutputSize
+	}
+	if feeRate == 0 {
+		feeRate = btc.targetFeeRateWithFallback(btc.redeemConfTarget(), 0)
+	}
+	fee := feeRate * size
+	if fee > val {
+		return nil, 0, fmt.Errorf("redeem tx not worth the fees")
+	}
+	redeemAddr, err := btc.node.ExternalAddress()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error getting new address from the wallet: %w", err)
+	}
+	pkScript, err := txscript.PayToAddrScript(redeemAddr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error creating change script: %w", err)
+	}
+	txOut := wire.NewTxOut(int64(val-fee), pkScript)
+	if btc.IsDust(txOut, feeRate) {
+		return nil, 0, fmt.Errorf("swap redeem output is dust")
+	}
+	msgTx.AddTxOut(txOut)
+
+	placeholder := make([]byte, 32)
+	if _, err := rand.Read(placeholder); err != nil {
+		return nil, 0, err
+	}
+	if btc.segwit {
+		sigHashes := txscript.NewTxSigHashes(msgTx, new(txscript.CannedPrevOutputFetcher))
+		sig, pubKey, err := btc.createWitnessSig(msgTx, 0, contract, receiver, int64(val), sigHashes)
+		if err != nil {
+			return nil, 0, err
+		}
+		msgTx.TxIn[0].Witness = dexbtc.RedeemP2WSHContract(contract, sig, pubKey, placeholder)
+	} else {
+		sig, pubKey, err := btc.createSig(msgTx, 0, contract, receiver, []int64{int64(val)}, [][]byte{prevScript})
+		if err != nil {
+			return nil, 0, err
+		}
+		msgTx.TxIn[0].SignatureScript, err = dexbtc.RedeemP2SHContract(contract, sig, pubKey, placeholder)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	txB, err := btc.serializeTx(msgTx)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset := bytes.Index(txB, placeholder)
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("secret placeholder not found in serialized redeem tx")
+	}
+	return txB, offset, nil
+}
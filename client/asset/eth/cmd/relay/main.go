@@ -0,0 +1,273 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package main
+
+/*
+ * relay is a simple relayer for gas-sponsored redemptions. It accepts
+ * redemptions that a swap participant has authorized with a signature, and
+ * submits them to the version 2 swap contract's redeemWithSignature method,
+ * paying the gas in exchange for the fee that the participant signed over.
+ *
+ * Redemptions are POSTed to the root path. A GET request to /fee returns the
+ * relayer's current fee quote.
+ */
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	dexeth "decred.org/dcrdex/dex/networks/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// minDeadline is the minimum time remaining before a redemption's
+	// authorization expires for the relayer to accept it.
+	minDeadline = 30 * time.Second
+	// maxRequestSize is the maximum size of a relay request body.
+	maxRequestSize = 1 << 16
+)
+
+var log = dex.StdOutLogger("RELAY", dex.LevelInfo)
+
+func main() {
+	if err := mainErr(); err != nil {
+		fmt.Fprint(os.Stderr, err, "\n")
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainErr() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var rpcAddr, keyPath, contractStr, listen, minTokenFeeStr string
+	flag.StringVar(&rpcAddr, "rpc", "http://127.0.0.1:38556", "RPC address of the node to submit transactions to. The default is the simnet harness alpha node")
+	flag.StringVar(&keyPath, "key", "", "path to a file with the hex-encoded private key of the relayer's funded account")
+	flag.StringVar(&contractStr, "contract", "", "address of the version 2 swap contract")
+	flag.StringVar(&listen, "listen", "127.0.0.1:7250", "address to listen for relay requests on")
+	flag.StringVar(&minTokenFeeStr, "mintokenfee", "0", "minimum relayer fee, in the token's smallest unit, accepted for token redemptions")
+	flag.Parse()
+
+	if keyPath == "" {
+		return errors.New("no private key file specified")
+	}
+	if !common.IsHexAddress(contractStr) {
+		return fmt.Errorf("invalid contract address %q", contractStr)
+	}
+	minTokenFee, ok := new(big.Int).SetString(minTokenFeeStr, 10)
+	if !ok {
+		return fmt.Errorf("invalid minimum token fee %q", minTokenFeeStr)
+	}
+	keyB, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("error reading private key file: %w", err)
+	}
+	privKey, err := crypto.HexToECDSA(strings.TrimSpace(string(keyB)))
+	if err != nil {
+		return fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	client, err := ethclient.DialContext(ctx, rpcAddr)
+	if err != nil {
+		return fmt.Errorf("error connecting to %s: %w", rpcAddr, err)
+	}
+	defer client.Close()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting chain ID: %w", err)
+	}
+
+	r := &relayer{
+		client:       client,
+		privKey:      privKey,
+		addr:         crypto.PubkeyToAddress(privKey.PublicKey),
+		chainID:      chainID,
+		contractAddr: common.HexToAddress(contractStr),
+		minTokenFee:  minTokenFee,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", r.handleRelay)
+	mux.HandleFunc("/fee", r.handleFee)
+	srv := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+	log.Infof("Relaying redemptions for contract %s on chain %s from %s. Listening on %s",
+		r.contractAddr, chainID, r.addr, listen)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type relayer struct {
+	client       *ethclient.Client
+	privKey      *ecdsa.PrivateKey
+	addr         common.Address
+	chainID      *big.Int
+	contractAddr common.Address
+	minTokenFee  *big.Int
+
+	// sendMtx serializes transaction submission so that nonces don't collide.
+	sendMtx sync.Mutex
+}
+
+func (r *relayer) handleRelay(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("POST required"))
+		return
+	}
+	var rr dexeth.RelayedRedemption
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestSize)).Decode(&rr); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("error decoding request: %w", err))
+		return
+	}
+	txHash, err := r.relay(req.Context(), &rr)
+	if err != nil {
+		log.Errorf("Rejected relay request: %v", err)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	log.Infof("Relayed redemption of %d swaps in tx %s", len(rr.Redemptions), txHash)
+	writeJSON(w, http.StatusOK, &dexeth.RelayResponse{TxHash: txHash})
+}
+
+func (r *relayer) handleFee(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("GET required"))
+		return
+	}
+	_, feeCap, err := r.fees(req.Context())
+	if err != nil {
+		log.Errorf("Error getting fees: %v", err)
+		writeError(w, http.StatusInternalServerError, errors.New("error getting fees"))
+		return
+	}
+	writeJSON(w, http.StatusOK, &dexeth.RelayFeeQuote{
+		GasFeeCap:   feeCap,
+		MinTokenFee: r.minTokenFee,
+	})
+}
+
+// fees returns the tip cap and fee cap that the relayer submits transactions
+// with.
+func (r *relayer) fees(ctx context.Context) (tipCap, feeCap *big.Int, err error) {
+	hdr, err := r.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting best header: %w", err)
+	}
+	tipCap, err = r.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting tip cap: %w", err)
+	}
+	feeCap = new(big.Int).Add(new(big.Int).Mul(hdr.BaseFee, big.NewInt(2)), tipCap)
+	return tipCap, feeCap, nil
+}
+
+// relay validates the relayed redemption, checks that the fee covers the cost
+// of gas, and submits the redemption.
+func (r *relayer) relay(ctx context.Context, rr *dexeth.RelayedRedemption) (common.Hash, error) {
+	if rr.ContractAddr != r.contractAddr {
+		return common.Hash{}, fmt.Errorf("unsupported contract %s", rr.ContractAddr)
+	}
+	if rr.ChainID != r.chainID.Int64() {
+		return common.Hash{}, fmt.Errorf("wrong chain ID %d", rr.ChainID)
+	}
+	if time.Until(time.Unix(int64(rr.Deadline), 0)) < minDeadline {
+		return common.Hash{}, errors.New("authorization expires too soon")
+	}
+	if err := rr.Validate(); err != nil {
+		return common.Hash{}, err
+	}
+	calldata, err := dexeth.PackRelayedRedeem(rr)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	r.sendMtx.Lock()
+	defer r.sendMtx.Unlock()
+
+	// Gas estimation executes the call, so a redemption that would revert
+	// errors here.
+	gas, err := r.client.EstimateGas(ctx, ethereum.CallMsg{
+		From: r.addr,
+		To:   &r.contractAddr,
+		Data: calldata,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("redemption would fail: %w", err)
+	}
+	tipCap, feeCap, err := r.fees(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// The fee must cover the gas limit that the transaction is submitted
+	// with, since that is what the relayer could end up paying.
+	gasLimit := dexeth.RelayGasLimit(gas)
+	if rr.Token == (common.Address{}) {
+		if gasCost := new(big.Int).Mul(feeCap, new(big.Int).SetUint64(gasLimit)); rr.RelayerFee.Cmp(gasCost) < 0 {
+			return common.Hash{}, fmt.Errorf("relayer fee %s does not cover the gas cost %s", rr.RelayerFee, gasCost)
+		}
+	} else if rr.RelayerFee.Cmp(r.minTokenFee) < 0 {
+		return common.Hash{}, fmt.Errorf("relayer fee %s is less than the minimum %s", rr.RelayerFee, r.minTokenFee)
+	}
+
+	nonce, err := r.client.PendingNonceAt(ctx, r.addr)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting nonce: %w", err)
+	}
+	tx, err := types.SignNewTx(r.privKey, types.LatestSignerForChainID(r.chainID), &types.DynamicFeeTx{
+		ChainID:   r.chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gasLimit,
+		To:        &r.contractAddr,
+		Data:      calldata,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error signing transaction: %w", err)
+	}
+	if err := r.client.SendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, fmt.Errorf("error sending transaction: %w", err)
+	}
+	return tx.Hash(), nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, thing any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(thing); err != nil {
+		log.Errorf("error writing response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -626,6 +626,19 @@ func newV1Contractor(net dex.Network, swapContractAddr, acctAddr common.Address,
 	}, nil
 }
 
+// newV2Contractor constructs a contractor for the version 2 swap contract. The
+// version 2 contract only adds redeemWithSignature to version 1, so its
+// swaps, redemptions, and refunds are handled by the version 1 contractor.
+// Relayed redemptions are built by (*assetWallet).RelayRedeem.
+func newV2Contractor(net dex.Network, swapContractAddr, acctAddr common.Address, cb bind.ContractBackend) (contractor, error) {
+	c, err := newV1Contractor(net, swapContractAddr, acctAddr, cb)
+	if err != nil {
+		return nil, err
+	}
+	c.(*contractorV1).abi = dexeth.ABIs[2]
+	return c, nil
+}
+
 func (c *contractorV1) status(ctx context.Context, locator []byte) (*dexeth.SwapStatus, error) {
 	v, err := dexeth.ParseV1Locator(locator)
 	if err != nil {
@@ -941,4 +954,5 @@ func estimateGas(ctx context.Context, from, to common.Address, abi *abi.ABI, cb
 var contractorConstructors = map[uint32]contractorConstructor{
 	0: newV0Contractor,
 	1: newV1Contractor,
+	2: newV2Contractor,
 }
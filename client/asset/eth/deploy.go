@@ -17,6 +17,7 @@ import (
 	multibal "decred.org/dcrdex/dex/networks/eth/contracts/multibalance"
 	ethv0 "decred.org/dcrdex/dex/networks/eth/contracts/v0"
 	ethv1 "decred.org/dcrdex/dex/networks/eth/contracts/v1"
+	ethv2 "decred.org/dcrdex/dex/networks/eth/contracts/v2"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -147,6 +148,8 @@ func (contractDeployer) txData(contractVer uint32, tokenAddr common.Address) (tx
 			return common.FromHex(ethv0.ETHSwapBin), nil
 		case 1:
 			return common.FromHex(ethv1.ETHSwapBin), nil
+		case 2:
+			return common.FromHex(ethv2.ETHSwapBin), nil
 		}
 	}
 	var abi *abi.ABI
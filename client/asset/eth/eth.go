@@ -86,6 +86,7 @@ const (
 	walletTypeToken = "token"
 
 	providersKey = "providers"
+	relayURLKey  = "relayurl"
 
 	// onChainDataFetchTimeout is the max amount of time allocated to fetching
 	// on-chain data. Testing on testnet has shown spikes up to 2.5 seconds
@@ -129,6 +130,14 @@ var (
 				"wallet.  Units: gwei / gas",
 			DefaultValue: strconv.FormatUint(defaultGasFeeLimit, 10),
 		},
+		{
+			Key:         relayURLKey,
+			DisplayName: "Relayer URL",
+			Description: "URL of a relayer for gas-sponsored redemptions. If set, " +
+				"swaps that support relayed redemption are redeemed through the " +
+				"relayer when the wallet cannot pay the redemption gas itself. The " +
+				"relayer's fee is deducted from the redeemed amount.",
+		},
 	}
 	RPCOpts = []*asset.ConfigOption{
 		{
@@ -152,7 +161,7 @@ var (
 		// exposed though any Driver methods or assets/driver functions. Use the
 		// parent wallet's WalletInfo via (*Driver).Info if you need a token's
 		// supported versions before a wallet is available.
-		SupportedVersions: []uint32{0, 1},
+		SupportedVersions: []uint32{0, 1, 2},
 		UnitInfo:          dexeth.UnitInfo,
 		AvailableWallets: []*asset.WalletDefinition{
 			// {
@@ -217,6 +226,7 @@ func safeConfsBig(tip uint64, blockNum *big.Int) uint64 {
 // WalletConfig are wallet-level configuration settings.
 type WalletConfig struct {
 	GasFeeLimit uint64 `ini:"gasfeelimit"`
+	RelayURL    string `ini:"relayurl"`
 }
 
 // parseWalletConfig parses the settings map into a *WalletConfig.
@@ -479,6 +489,7 @@ type assetWallet struct {
 
 	contractorV0 contractor
 	contractorV1 contractor
+	contractorV2 contractor
 
 	evmify  func(uint64) *big.Int
 	atomize func(*big.Int) uint64
@@ -912,6 +923,8 @@ func (w *ETHWallet) Connect(ctx context.Context) (_ *sync.WaitGroup, err error)
 			w.contractorV0 = c
 		case 1:
 			w.contractorV1 = c
+		case 2:
+			w.contractorV2 = c
 		}
 	}
 
@@ -1662,6 +1675,13 @@ func (w *assetWallet) unlockFunds(amt uint64, t fundReserveType) {
 	*reserve -= amt
 }
 
+// reserved returns the funds reserved for a particular use case.
+func (w *assetWallet) reserved(t fundReserveType) uint64 {
+	w.lockedFunds.mtx.RLock()
+	defer w.lockedFunds.mtx.RUnlock()
+	return *w.fundReserveOfType(t)
+}
+
 // amountLocked returns the total amount currently locked.
 func (w *assetWallet) amountLocked() uint64 {
 	w.lockedFunds.mtx.RLock()
@@ -2488,7 +2508,7 @@ func acToLocator(contractVer uint32, swap *asset.Contract, evmValue *big.Int, fr
 	switch contractVer {
 	case 0:
 		return swap.SecretHash
-	case 1:
+	case 1, 2:
 		var secretHash [32]byte
 		copy(secretHash[:], swap.SecretHash)
 		return (&dexeth.SwapVector{
@@ -2694,6 +2714,16 @@ func (w *assetWallet) Redeem(form *asset.RedeemForm, feeWallet *assetWallet, non
 	gasLimit, gasFeeCap := g.Redeem*n, form.FeeSuggestion
 	originalFundsReserved := gasLimit * gasFeeCap
 
+	// If the fee wallet can't pay for the redemption and the swaps support
+	// it, redeem through the configured relayer instead.
+	if contractVer >= dexeth.RelayContractVersion && bal.Available+feeWallet.reserved(redemptionReserve) < originalFundsReserved {
+		if relayURL := w.relayURL(); relayURL != "" {
+			w.log.Infof("Insufficient %s balance to redeem. Redeeming through relayer %s",
+				dex.BipIDSymbol(feeWallet.assetID), relayURL)
+			return w.relayRedeem(w.ctx, relayURL, form, g, redeemedValue)
+		}
+	}
+
 	/* We could get a gas estimate via RPC, but this will reveal the secret key
 	   before submitting the redeem transaction. This is not OK for maker.
 	   Disable for now.
@@ -3598,7 +3628,7 @@ func (w *assetWallet) AuditContract(coinID, contract, serializedTx dex.Bytes, re
 		participant = initiation.Participant.String()
 		lockTime = initiation.LockTime
 		secretHashB = secretHash[:]
-	case 1:
+	case 1, 2:
 		vec, err := dexeth.ParseV1Locator(locator)
 		if err != nil {
 			return nil, err
@@ -4082,7 +4112,7 @@ func (w *assetWallet) SwapConfirmations(ctx context.Context, coinID dex.Bytes, c
 	}
 
 	spent = status.Step >= dexeth.SSRedeemed
-	if spent && contractVer == 1 {
+	if spent && contractVer >= 1 {
 		// Gotta get the confirimations directly.
 		var txHash common.Hash
 		copy(txHash[:], coinID)
@@ -4305,7 +4335,7 @@ func extractSecretHashes(tx *types.Transaction, contractVer uint32, isInit bool)
 			}
 		}
 		return locators, locators, nil
-	case 1:
+	case 1, 2:
 		if isInit {
 			_, vectors, err := dexeth.ParseInitiateDataV1(tx.Data())
 			if err != nil {
@@ -4821,7 +4851,11 @@ func (w *assetWallet) getConfirmedBalance() (*big.Int, error) {
 }
 
 func (w *assetWallet) contractors() map[uint32]contractor {
-	return map[uint32]contractor{0: w.contractorV0, 1: w.contractorV1}
+	m := map[uint32]contractor{0: w.contractorV0, 1: w.contractorV1}
+	if w.contractorV2 != nil {
+		m[2] = w.contractorV2
+	}
+	return m
 }
 
 func (w *assetWallet) balanceWithTxPool() (*Balance, error) {
@@ -5152,6 +5186,18 @@ func (w *assetWallet) loadContractors(parent *assetWallet) error {
 		}
 		w.contractorV1 = c
 	}
+
+	if _, found := netToken.SwapContracts[2]; found && parent.contractorV2 != nil {
+		cgen, ok := parent.contractorV2.(unifiedContractor)
+		if !ok {
+			return errors.New("parent version 2 contractor ain't unified")
+		}
+		c, err := cgen.tokenContractor(token)
+		if err != nil {
+			return fmt.Errorf("error constructing version 2 token %s contractor: %w", token.Name, err)
+		}
+		w.contractorV2 = c
+	}
 	return nil
 }
 
@@ -5181,6 +5227,11 @@ func (w *assetWallet) withContractor(contractVer uint32, f func(contractor) erro
 			return errors.New("no version 1 contractor")
 		}
 		c = w.contractorV1
+	case 2:
+		if w.contractorV2 == nil {
+			return errors.New("no version 2 contractor")
+		}
+		c = w.contractorV2
 	}
 	return f(c)
 }
@@ -6289,8 +6340,8 @@ func quickNode(ctx context.Context, walletDir string, contractVer uint32,
 			if err != nil {
 				return nil, nil, fmt.Errorf("token contractor constructor error: %v", err)
 			}
-		case 1:
-			bc, err := newV1Contractor(net, wParams.ContractAddr, cl.address(), cl.contractBackend())
+		case 1, 2:
+			bc, err := contractorConstructors[contractVer](net, wParams.ContractAddr, cl.address(), cl.contractBackend())
 			if err != nil {
 				return nil, nil, fmt.Errorf("base contractor constructor error: %v", err)
 			}
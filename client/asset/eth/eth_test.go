@@ -10,10 +10,13 @@ import (
 	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"strings"
 	"sync"
@@ -5686,3 +5689,247 @@ func parseRecoveryID(c asset.Coin) []byte {
 func randomHash() common.Hash {
 	return common.BytesToHash(encode.RandomBytes(20))
 }
+
+func TestRelayRedeem(t *testing.T) {
+	_, eth, node, shutdown := tassetWallet(BipID)
+	defer shutdown()
+
+	var secret [32]byte
+	copy(secret[:], encode.RandomBytes(32))
+	v := &dexeth.SwapVector{
+		From:       testAddressA,
+		To:         node.addr,
+		Value:      dexeth.GweiToWei(1e6),
+		SecretHash: sha256.Sum256(secret[:]),
+		LockTime:   uint64(time.Now().Unix()),
+	}
+	newForm := func(ver uint32) *asset.RedeemForm {
+		return &asset.RedeemForm{
+			Redemptions: []*asset.Redemption{{
+				Spends: &asset.AuditInfo{
+					Contract:   dexeth.EncodeContractData(ver, v.Locator()),
+					SecretHash: v.SecretHash[:],
+				},
+				Secret: secret[:],
+			}},
+		}
+	}
+
+	txHash := common.Hash{0x01}
+	var relayErr string
+	quote := &dexeth.RelayFeeQuote{GasFeeCap: dexeth.GweiToWei(1), MinTokenFee: big.NewInt(0)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fee" {
+			json.NewEncoder(w).Encode(quote)
+			return
+		}
+		var req dexeth.RelayedRedemption
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err == nil {
+			err = req.Validate()
+		}
+		if err == nil && relayErr != "" {
+			err = errors.New(relayErr)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		json.NewEncoder(w).Encode(&dexeth.RelayResponse{TxHash: txHash})
+	}))
+	defer srv.Close()
+
+	// No relay contract.
+	if _, err := eth.RelayRedeem(context.Background(), srv.URL, newForm(dexeth.RelayContractVersion), 1e4); err == nil {
+		t.Fatalf("no error without a relay contract")
+	}
+	eth.versionedContracts[dexeth.RelayContractVersion] = common.Address{0x02}
+
+	// Version 1 swaps can't be relayed.
+	if _, err := eth.RelayRedeem(context.Background(), srv.URL, newForm(1), 1e4); err == nil {
+		t.Fatalf("no error for version 1 swap")
+	}
+
+	// Fee too high.
+	if _, err := eth.RelayRedeem(context.Background(), srv.URL, newForm(dexeth.RelayContractVersion), 1e6); err == nil {
+		t.Fatalf("no error for fee exceeding redeemed amount")
+	}
+
+	coinID, err := eth.RelayRedeem(context.Background(), srv.URL, newForm(dexeth.RelayContractVersion), 1e4)
+	if err != nil {
+		t.Fatalf("RelayRedeem error: %v", err)
+	}
+	if coinID != txHash.String() {
+		t.Fatalf("wrong tx hash %s", coinID)
+	}
+
+	relayErr = "insufficient fee"
+	if _, err := eth.RelayRedeem(context.Background(), srv.URL, newForm(dexeth.RelayContractVersion), 1e4); err == nil ||
+		!strings.Contains(err.Error(), relayErr) {
+		t.Fatalf("relayer error not returned, got %v", err)
+	}
+	relayErr = ""
+
+	// Redeem uses the configured relayer when the wallet has no balance for
+	// the redemption gas.
+	eth.contractorV2 = node.tContractor
+	node.tContractor.swapMap[v.SecretHash] = &dexeth.SwapState{
+		BlockHeight: 1,
+		LockTime:    time.Unix(int64(v.LockTime), 0),
+		Initiator:   v.From,
+		Participant: v.To,
+		Value:       v.Value,
+		State:       dexeth.SSInitiated,
+	}
+	node.bal = new(big.Int)
+	eth.settings = map[string]string{relayURLKey: srv.URL}
+	form := newForm(dexeth.RelayContractVersion)
+	form.FeeSuggestion = 100
+	coinIDs, out, fees, err := eth.Redeem(form, nil, nil)
+	if err != nil {
+		t.Fatalf("relayed Redeem error: %v", err)
+	}
+	expFee := dexeth.RelayGasLimit(dexeth.VersionedGases[2].RedeemN(1) + dexeth.RelayedRedeemGasOverhead)
+	if fees != expFee {
+		t.Fatalf("wrong relayed redeem fees. expected %d, got %d", expFee, fees)
+	}
+	if !bytes.Equal(coinIDs[0], txHash[:]) || !bytes.Equal(out.ID(), txHash[:]) {
+		t.Fatalf("wrong relayed redeem coin IDs")
+	}
+	if out.Value() != 1e6-expFee {
+		t.Fatalf("wrong relayed redeem output value %d", out.Value())
+	}
+
+	// The relayer's quote exceeds the redeemed amount.
+	quote.GasFeeCap = dexeth.GweiToWei(1e3)
+	if _, _, _, err := eth.Redeem(form, nil, nil); err == nil {
+		t.Fatalf("no error for relayer fee exceeding redeemed amount")
+	}
+}
+
+func TestRelayerFee(t *testing.T) {
+	_, eth, _, shutdown := tassetWallet(BipID)
+	defer shutdown()
+
+	quote := &dexeth.RelayFeeQuote{GasFeeCap: big.NewInt(dexeth.GweiFactor + 1)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(quote)
+	}))
+	defer srv.Close()
+
+	g := dexeth.VersionedGases[2]
+	fee, err := eth.relayFee(context.Background(), srv.URL, g, 2)
+	if err != nil {
+		t.Fatalf("relayFee error: %v", err)
+	}
+	// The fee covers the gas limit that the relayer submits the transaction
+	// with, and is rounded up to the next gwei.
+	gasLimit := dexeth.RelayGasLimit(g.RedeemN(2) + dexeth.RelayedRedeemGasOverhead)
+	if exp := gasLimit + 1; fee != exp {
+		t.Fatalf("wrong relayer fee. expected %d, got %d", exp, fee)
+	}
+
+	quote.GasFeeCap = nil
+	if _, err := eth.relayFee(context.Background(), srv.URL, g, 2); err == nil {
+		t.Fatalf("no error for missing gas fee cap")
+	}
+}
+
+func TestSendMulti(t *testing.T) {
+	t.Run("eth", func(t *testing.T) { testSendMulti(t, BipID) })
+	t.Run("token", func(t *testing.T) { testSendMulti(t, usdcEthID) })
+}
+
+func testSendMulti(t *testing.T, assetID uint32) {
+	w, eth, node, shutdown := tassetWallet(assetID)
+	defer shutdown()
+	sender := w.(asset.MultiSender)
+
+	tx := tTx(0, 0, 0, &testAddressA, nil, 21000)
+	node.sendTxTx = tx
+	node.tokenContractor.transferTx = tx
+
+	maxFeeRate, _, _ := eth.recommendedMaxFeeRate(eth.ctx)
+	ethFees := dexeth.WeiToGwei(maxFeeRate) * defaultSendGasLimit
+	tokenFees := dexeth.WeiToGwei(maxFeeRate) * tokenGasesV1.Transfer
+
+	const val = 10e9
+	payments := []*asset.Payment{
+		{Address: testAddressA.String(), Value: val},
+		{Address: testAddressB.String(), Value: val},
+	}
+	tests := []struct {
+		name            string
+		sendAdj, feeAdj uint64
+		sendTxErr       error
+		payments        []*asset.Payment
+		wantErr         bool
+	}{{
+		name:     "ok",
+		payments: payments,
+	}, {
+		name:     "not enough",
+		sendAdj:  1,
+		payments: payments,
+		wantErr:  true,
+	}, {
+		name:     "low fees",
+		feeAdj:   1,
+		payments: payments,
+		wantErr:  true,
+	}, {
+		name:      "send error",
+		sendTxErr: errors.New("test error"),
+		payments:  payments,
+		wantErr:   true,
+	}, {
+		name:     "invalid address",
+		payments: []*asset.Payment{{Address: "", Value: val}},
+		wantErr:  true,
+	}, {
+		name:    "no payments",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		node.setBalanceError(eth, nil)
+		node.sendTxErr = test.sendTxErr
+		node.tokenContractor.transferErr = test.sendTxErr
+		if assetID == BipID {
+			node.bal = dexeth.GweiToWei(2*val + 2*ethFees - test.sendAdj - test.feeAdj)
+		} else {
+			node.tokenContractor.bal = dexeth.GweiToWei(2*val - test.sendAdj)
+			node.bal = dexeth.GweiToWei(2*tokenFees - test.feeAdj)
+		}
+		coins, err := sender.SendMulti(test.payments, 0)
+		if test.wantErr {
+			if err == nil {
+				t.Fatalf("expected error for test %q", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error for test %q: %v", test.name, err)
+		}
+		if len(coins) != len(test.payments) {
+			t.Fatalf("expected %d coins, got %d", len(test.payments), len(coins))
+		}
+	}
+
+	// The estimate includes a 20% buffer.
+	node.setBalanceError(eth, nil)
+	node.bal = dexeth.GweiToWei(1e12)
+	node.tokenContractor.bal = dexeth.GweiToWei(1e12)
+	fees, err := sender.EstimateSendMultiTxFee(payments, 0)
+	if err != nil {
+		t.Fatalf("EstimateSendMultiTxFee error: %v", err)
+	}
+	expFees := 2 * ethFees
+	if assetID != BipID {
+		expFees = 2 * tokenFees
+	}
+	if fees != expFees*12/10 {
+		t.Fatalf("wrong fee estimate. wanted %d, got %d", expFees*12/10, fees)
+	}
+}
@@ -0,0 +1,148 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"decred.org/dcrdex/client/asset"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var _ asset.MultiSender = (*ETHWallet)(nil)
+var _ asset.MultiSender = (*TokenWallet)(nil)
+
+// validatePayments checks the payments' addresses and values, returning the
+// total value of the payments.
+func validatePayments(payments []*asset.Payment) (total uint64, err error) {
+	if len(payments) == 0 {
+		return 0, errors.New("no payments")
+	}
+	for _, p := range payments {
+		if err := isValidSend(p.Address, p.Value, false); err != nil {
+			return 0, err
+		}
+		total += p.Value
+	}
+	return total, nil
+}
+
+// sendMulti sends the payments in sequence, with nonces assigned by sendToAddr.
+// If a send fails, the coins for the payments that were already sent are
+// returned with the error.
+func sendMulti(payments []*asset.Payment, maxFeeRate, tipRate *big.Int,
+	sendToAddr func(common.Address, uint64, *big.Int, *big.Int) (*types.Transaction, error)) ([]asset.Coin, error) {
+
+	coins := make([]asset.Coin, 0, len(payments))
+	for i, p := range payments {
+		tx, err := sendToAddr(common.HexToAddress(p.Address), p.Value, maxFeeRate, tipRate)
+		if err != nil {
+			return coins, fmt.Errorf("error sending payment %d of %d to %s: %w", i+1, len(payments), p.Address, err)
+		}
+		coins = append(coins, &coin{id: tx.Hash(), value: p.Value})
+	}
+	return coins, nil
+}
+
+// SendMulti sends a transaction for each payment. The provided fee rate is
+// ignored since all sends will use an internally derived fee rate. Part of the
+// asset.MultiSender interface.
+func (w *ETHWallet) SendMulti(payments []*asset.Payment, _ uint64) ([]asset.Coin, error) {
+	total, err := validatePayments(payments)
+	if err != nil {
+		return nil, err
+	}
+	_, maxFeeRate, tipRate, err := w.canSendMulti(total, len(payments), true, false)
+	if err != nil {
+		return nil, err
+	}
+	return sendMulti(payments, maxFeeRate, tipRate, w.sendToAddr)
+}
+
+// EstimateSendMultiTxFee returns the maximum total fees for SendMulti. Part of
+// the asset.MultiSender interface.
+func (w *ETHWallet) EstimateSendMultiTxFee(payments []*asset.Payment, _ uint64) (uint64, error) {
+	total, err := validatePayments(payments)
+	if err != nil {
+		return 0, err
+	}
+	maxFee, _, _, err := w.canSendMulti(total, len(payments), true, true)
+	return maxFee, err
+}
+
+// canSendMulti is like canSend, but for n transactions sending a total value.
+func (w *ETHWallet) canSendMulti(total uint64, n int, verifyBalance, isPreEstimate bool) (maxFee uint64, maxFeeRate, tipRate *big.Int, err error) {
+	maxFee, maxFeeRate, tipRate, err = w.canSend(total, false, isPreEstimate)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	maxFee *= uint64(n)
+	if verifyBalance {
+		bal, err := w.Balance()
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		if avail := bal.Available; avail < total+maxFee {
+			return 0, nil, nil, fmt.Errorf("available funds %d gwei cannot cover value being sent: need %d gwei + %d gwei max fee",
+				avail, total, maxFee)
+		}
+	}
+	return
+}
+
+// SendMulti sends a transaction for each payment. Fees are taken from the
+// parent wallet. The provided fee rate is ignored since all sends will use an
+// internally derived fee rate. Part of the asset.MultiSender interface.
+func (w *TokenWallet) SendMulti(payments []*asset.Payment, _ uint64) ([]asset.Coin, error) {
+	total, err := validatePayments(payments)
+	if err != nil {
+		return nil, err
+	}
+	_, maxFeeRate, tipRate, err := w.canSendMulti(total, len(payments), true, false)
+	if err != nil {
+		return nil, err
+	}
+	return sendMulti(payments, maxFeeRate, tipRate, w.sendToAddr)
+}
+
+// EstimateSendMultiTxFee returns the maximum total fees for SendMulti, which
+// are paid by the parent wallet. Part of the asset.MultiSender interface.
+func (w *TokenWallet) EstimateSendMultiTxFee(payments []*asset.Payment, _ uint64) (uint64, error) {
+	total, err := validatePayments(payments)
+	if err != nil {
+		return 0, err
+	}
+	maxFee, _, _, err := w.canSendMulti(total, len(payments), true, true)
+	return maxFee, err
+}
+
+// canSendMulti is like canSend, but for n transactions sending a total value.
+func (w *TokenWallet) canSendMulti(total uint64, n int, verifyBalance, isPreEstimate bool) (maxFee uint64, maxFeeRate, tipRate *big.Int, err error) {
+	maxFee, maxFeeRate, tipRate, err = w.canSend(total, false, isPreEstimate)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	maxFee *= uint64(n)
+	if verifyBalance {
+		bal, err := w.Balance()
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		if avail := bal.Available; avail < total {
+			return 0, nil, nil, fmt.Errorf("not enough tokens: have %[1]d %[3]s need %[2]d %[3]s", avail, total, w.ui.AtomicUnit)
+		}
+		ethBal, err := w.parent.Balance()
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("error getting base chain balance: %w", err)
+		}
+		if ethBal.Available < maxFee {
+			return 0, nil, nil, fmt.Errorf("insufficient balance to cover token transfer fees. %d < %d",
+				ethBal.Available, maxFee)
+		}
+	}
+	return
+}
@@ -2208,6 +2208,166 @@ func TestConfirmedNonce(t *testing.T) {
 	}
 }
 
+// TestRelayedRedeem initiates a swap with the version 2 contract and redeems
+// it through the relayer in cmd/relay, which pays the gas. The participant
+// should receive the swap value less the relayer's fee.
+func TestRelayedRedeem(t *testing.T) {
+	if isTestnet {
+		t.Skip("relayed redemption is only tested on simnet")
+	}
+	const relayListen = "127.0.0.1:7251"
+	relayURL := "http://" + relayListen
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	runSimnetMiner(ctx, "eth", tLogger)
+
+	contractAddr := dexeth.ContractAddresses[dexeth.RelayContractVersion][dex.Simnet]
+	if contractAddr == (common.Address{}) {
+		t.Fatalf("no simnet address for the version %d swap contract", dexeth.RelayContractVersion)
+	}
+	c, err := newV2Contractor(dex.Simnet, contractAddr, simnetAddr, ethClient.contractBackend())
+	if err != nil {
+		t.Fatalf("newV2Contractor error: %v", err)
+	}
+
+	// Create and fund the relayer's account.
+	relayKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	relayAddr := crypto.PubkeyToAddress(relayKey.PublicKey)
+	tmpDir := t.TempDir()
+	keyPath := filepath.Join(tmpDir, "relay.key")
+	if err := os.WriteFile(keyPath, []byte(hex.EncodeToString(crypto.FromECDSA(relayKey))), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.CommandContext(ctx, "./sendtoaddress", relayAddr.String(), "10")
+	cmd.Dir = harnessCtlDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("error funding relayer: %v: %s", err, out)
+	}
+
+	// Start the relayer.
+	relayExe := filepath.Join(tmpDir, "relay")
+	if out, err := exec.Command("go", "build", "-o", relayExe, "./cmd/relay").CombinedOutput(); err != nil {
+		t.Fatalf("error building relayer: %v: %s", err, out)
+	}
+	relay := exec.CommandContext(ctx, relayExe, "-key", keyPath, "-contract", contractAddr.String(), "-listen", relayListen)
+	relay.Stdout, relay.Stderr = os.Stdout, os.Stderr
+	if err := relay.Start(); err != nil {
+		t.Fatalf("error starting relayer: %v", err)
+	}
+	defer relay.Wait()
+	defer cancel()
+
+	// Initiate the swap.
+	var secret [32]byte
+	copy(secret[:], encode.RandomBytes(32))
+	secretHash := sha256.Sum256(secret[:])
+	const valg = 1e9 // 1 ETH
+	lockTime := uint64(time.Now().Add(time.Hour).Unix())
+	v := &dexeth.SwapVector{
+		From:       simnetAddr,
+		To:         participantAddr,
+		Value:      dexeth.GweiToWei(valg),
+		SecretHash: secretHash,
+		LockTime:   lockTime,
+	}
+	locator := v.Locator()
+	txOpts, err := ethClient.txOpts(ctx, valg, ethGases.SwapN(1), dexeth.GweiToWei(maxFeeRate), nil, nil)
+	if err != nil {
+		t.Fatalf("txOpts error: %v", err)
+	}
+	tx, err := c.initiate(txOpts, []*asset.Contract{newContract(lockTime, secretHash, valg)})
+	if err != nil {
+		t.Fatalf("initiate error: %v", err)
+	}
+	if err := waitForMined(); err != nil {
+		t.Fatalf("unexpected error while waiting to mine: %v", err)
+	}
+	receipt, err := waitForReceipt(ethClient, tx)
+	if err != nil {
+		t.Fatalf("failed retrieving initiate receipt: %v", err)
+	}
+	if err := checkTxStatus(receipt, txOpts.GasLimit); err != nil {
+		t.Fatalf("failed init transaction status: %v", err)
+	}
+
+	// Redeem through the relayer as the participant.
+	w := &assetWallet{
+		baseWallet: &baseWallet{
+			net:         dex.Simnet,
+			node:        participantEthClient,
+			addr:        participantAddr,
+			baseChainID: BipID,
+			chainID:     dexeth.ChainIDs[dex.Simnet],
+		},
+		log:                tLogger,
+		assetID:            BipID,
+		versionedContracts: map[uint32]common.Address{dexeth.RelayContractVersion: contractAddr},
+		evmify:             dexeth.GweiToWei,
+		atomize:            dexeth.WeiToGwei,
+		ui:                 dexeth.UnitInfo,
+	}
+	var relayerFee uint64
+	for i := 0; ; i++ {
+		if relayerFee, err = w.relayFee(ctx, relayURL, dexeth.VersionedGases[dexeth.RelayContractVersion], 1); err == nil {
+			break
+		}
+		if i == 10 {
+			t.Fatalf("relayer fee error: %v", err)
+		}
+		time.Sleep(time.Second)
+	}
+	balBefore, err := participantEthClient.addressBalance(ctx, participantAddr)
+	if err != nil {
+		t.Fatalf("balance error: %v", err)
+	}
+	form := &asset.RedeemForm{
+		Redemptions: []*asset.Redemption{{
+			Spends: &asset.AuditInfo{
+				SecretHash: secretHash[:],
+				Recipient:  participantAddr.String(),
+				Expiration: time.Unix(int64(lockTime), 0),
+				Coin:       &coin{value: valg},
+				Contract:   dexeth.EncodeContractData(dexeth.RelayContractVersion, locator),
+			},
+			Secret: secret[:],
+		}},
+	}
+	txHashStr, err := w.RelayRedeem(ctx, relayURL, form, relayerFee)
+	if err != nil {
+		t.Fatalf("RelayRedeem error: %v", err)
+	}
+	if err := waitForMined(); err != nil {
+		t.Fatalf("unexpected error while waiting to mine: %v", err)
+	}
+	receipt, err = participantEthClient.transactionReceipt(ctx, common.HexToHash(txHashStr))
+	if err != nil {
+		t.Fatalf("failed retrieving relayed redeem receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("relayed redeem transaction failed")
+	}
+
+	status, err := c.status(ctx, locator)
+	if err != nil {
+		t.Fatalf("status error: %v", err)
+	}
+	if status.Step != dexeth.SSRedeemed {
+		t.Fatalf("unexpected swap state: want %s got %s", dexeth.SSRedeemed, status.Step)
+	}
+	balAfter, err := participantEthClient.addressBalance(ctx, participantAddr)
+	if err != nil {
+		t.Fatalf("balance error: %v", err)
+	}
+	wantDiff := dexeth.GweiToWei(valg - relayerFee)
+	if diff := new(big.Int).Sub(balAfter, balBefore); diff.Cmp(wantDiff) != 0 {
+		t.Fatalf("participant received %s wei, expected %s", diff, wantDiff)
+	}
+}
+
 func bytesToArray(b []byte) (a [32]byte) {
 	copy(a[:], b)
 	return
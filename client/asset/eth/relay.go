@@ -0,0 +1,197 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package eth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/dexnet"
+	dexeth "decred.org/dcrdex/dex/networks/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// relayDeadline is how long a relayer has to submit a relayed redemption
+// before the participant's authorization expires.
+const relayDeadline = 10 * time.Minute
+
+// RelayRedeem redeems the swaps through a relayer, which submits the redeem
+// transaction and pays the gas in exchange for relayerFee, which is deducted
+// from the redeemed amount. This allows redemption with no native asset
+// balance. The swaps must have been initiated with a swap contract version
+// that supports relayed redemptions. The hash of the relayer's transaction is
+// returned.
+func (w *assetWallet) RelayRedeem(ctx context.Context, relayURL string, form *asset.RedeemForm, relayerFee uint64) (string, error) {
+	r, err := w.relayedRedemption(form.Redemptions, relayerFee, time.Now().Add(relayDeadline))
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("error encoding relayed redemption: %w", err)
+	}
+	var res dexeth.RelayResponse
+	var errRes struct {
+		Error string `json:"error"`
+	}
+	if err := dexnet.Post(ctx, relayURL, &res, b, dexnet.WithErrorParsing(&errRes)); err != nil {
+		if errRes.Error != "" {
+			return "", fmt.Errorf("relayer error: %s", errRes.Error)
+		}
+		return "", fmt.Errorf("error submitting relayed redemption: %w", err)
+	}
+	return res.TxHash.String(), nil
+}
+
+// relayURL is the relayer URL from the wallet settings, or an empty string if
+// relayed redemption is not configured.
+func (w *baseWallet) relayURL() string {
+	w.settingsMtx.RLock()
+	defer w.settingsMtx.RUnlock()
+	return strings.TrimSpace(w.settings[relayURLKey])
+}
+
+// relayFee gets the relayer's fee quote and computes the fee, in the asset's
+// atomic units, that the relayer will accept to redeem n swaps.
+func (w *assetWallet) relayFee(ctx context.Context, relayURL string, g *dexeth.Gases, n int) (uint64, error) {
+	var quote dexeth.RelayFeeQuote
+	if err := dexnet.Get(ctx, strings.TrimSuffix(relayURL, "/")+"/fee", &quote); err != nil {
+		return 0, fmt.Errorf("error getting relayer fee quote: %w", err)
+	}
+	var fee *big.Int
+	if w.assetID == w.baseChainID {
+		if quote.GasFeeCap == nil {
+			return 0, errors.New("relayer did not quote a gas fee cap")
+		}
+		gasLimit := dexeth.RelayGasLimit(g.RedeemN(n) + dexeth.RelayedRedeemGasOverhead)
+		fee = new(big.Int).Mul(quote.GasFeeCap, new(big.Int).SetUint64(gasLimit))
+	} else {
+		if quote.MinTokenFee == nil {
+			return 0, errors.New("relayer did not quote a token fee")
+		}
+		fee = quote.MinTokenFee
+	}
+	// Round up to the atomic unit so the fee isn't short of the quote.
+	atoms := w.atomize(fee)
+	if w.evmify(atoms).Cmp(fee) < 0 {
+		atoms++
+	}
+	return atoms, nil
+}
+
+// relayRedeem redeems the swaps through the relayer for its quoted fee. The
+// returned output coin is the relayer's transaction, and its value is the
+// redeemed amount less the relayer's fee. The relayer's fee is reported as the
+// redemption fee for the base chain asset. A token's fee is paid in the token,
+// so there is no fee in the parent asset.
+func (w *assetWallet) relayRedeem(ctx context.Context, relayURL string, form *asset.RedeemForm, g *dexeth.Gases,
+	redeemedValue uint64) ([]dex.Bytes, asset.Coin, uint64, error) {
+
+	relayerFee, err := w.relayFee(ctx, relayURL, g, len(form.Redemptions))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if relayerFee >= redeemedValue {
+		return nil, nil, 0, fmt.Errorf("relayer fee %d %s exceeds the redeemed amount %d", relayerFee, w.ui.AtomicUnit, redeemedValue)
+	}
+	txHashStr, err := w.RelayRedeem(ctx, relayURL, form, relayerFee)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	txHash := common.HexToHash(txHashStr)
+	txs := make([]dex.Bytes, len(form.Redemptions))
+	for i := range txs {
+		txs[i] = txHash[:]
+	}
+	var fees uint64
+	if w.assetID == w.baseChainID {
+		fees = relayerFee
+	}
+	return txs, &coin{id: txHash, value: redeemedValue - relayerFee}, fees, nil
+}
+
+// relayedRedemption builds and signs the authorization for a relayer to
+// redeem the swaps on the wallet's behalf.
+func (w *assetWallet) relayedRedemption(redeems []*asset.Redemption, relayerFee uint64, deadline time.Time) (*dexeth.RelayedRedemption, error) {
+	if len(redeems) == 0 {
+		return nil, errors.New("no redemptions")
+	}
+	contractAddr, found := w.versionedContracts[dexeth.RelayContractVersion]
+	if !found || contractAddr == (common.Address{}) {
+		return nil, fmt.Errorf("no version %d swap contract for %s", dexeth.RelayContractVersion, w.ui.Conventional.Unit)
+	}
+	r := &dexeth.RelayedRedemption{
+		ChainID:      w.chainID,
+		ContractAddr: contractAddr,
+		Token:        w.tokenAddr,
+		Redemptions:  make([]*dexeth.RedemptionV1, 0, len(redeems)),
+		RelayerFee:   w.evmify(relayerFee),
+		Deadline:     uint64(deadline.Unix()),
+	}
+	for _, redeem := range redeems {
+		ver, locator, err := dexeth.DecodeContractData(redeem.Spends.Contract)
+		if err != nil {
+			return nil, fmt.Errorf("invalid versioned swap contract data: %w", err)
+		}
+		if ver < dexeth.RelayContractVersion {
+			return nil, fmt.Errorf("swap contract version %d does not support relayed redemptions", ver)
+		}
+		v, err := dexeth.ParseV1Locator(locator)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing locator: %w", err)
+		}
+		if v.To != w.addr {
+			return nil, fmt.Errorf("swap participant %s is not this wallet's address", v.To)
+		}
+		if secretHash := sha256.Sum256(redeem.Secret); secretHash != v.SecretHash {
+			return nil, errors.New("wrong secret")
+		}
+		var secret [32]byte
+		copy(secret[:], redeem.Secret)
+		r.Redemptions = append(r.Redemptions, &dexeth.RedemptionV1{
+			Secret:   secret,
+			Contract: v,
+		})
+	}
+	if r.RelayerFee.Cmp(r.Value()) >= 0 {
+		return nil, fmt.Errorf("relayer fee %d exceeds redeemed amount", relayerFee)
+	}
+
+	sig, pubKey, err := w.node.signData(r.Message())
+	if err != nil {
+		return nil, fmt.Errorf("error signing relayed redemption: %w", err)
+	}
+	digest := r.Digest()
+	if r.Signature, err = recoverableSignature(digest[:], sig, pubKey); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// recoverableSignature adds the recovery ID to the [R || S] signature from
+// signData, producing the [R || S || V] signature needed for ecrecover.
+func recoverableSignature(hash, sig, pubKey []byte) ([]byte, error) {
+	if len(sig) < crypto.SignatureLength-1 {
+		return nil, fmt.Errorf("unexpected signature length %d", len(sig))
+	}
+	recSig := make([]byte, crypto.SignatureLength)
+	copy(recSig, sig[:crypto.SignatureLength-1])
+	for v := byte(0); v < 2; v++ {
+		recSig[64] = v
+		if recPubKey, err := crypto.Ecrecover(hash, recSig); err == nil && bytes.Equal(recPubKey, pubKey) {
+			return recSig, nil
+		}
+	}
+	return nil, errors.New("could not determine signature recovery ID")
+}
@@ -36,6 +36,8 @@ const (
 	WalletTraitHistorian                              // This wallet can return its transaction history
 	WalletTraitFundsMixer                             // The wallet can mix funds.
 	WalletTraitDynamicSwapper                         // The wallet has dynamic fees.
+	WalletTraitPSBTer                                 // The wallet can author PSBTs for an external signer.
+	WalletTraitMultiSender                            // The wallet can pay multiple addresses at once.
 )
 
 // IsRescanner tests if the WalletTrait has the WalletTraitRescanner bit set.
@@ -149,6 +151,18 @@ func (wt WalletTrait) IsDynamicSwapper() bool {
 	return wt&WalletTraitDynamicSwapper != 0
 }
 
+// IsPSBTer tests if the WalletTrait has the WalletTraitPSBTer bit set, which
+// indicates the wallet implements the PSBTer interface.
+func (wt WalletTrait) IsPSBTer() bool {
+	return wt&WalletTraitPSBTer != 0
+}
+
+// IsMultiSender tests if the WalletTrait has the WalletTraitMultiSender bit
+// set, which indicates the wallet implements the MultiSender interface.
+func (wt WalletTrait) IsMultiSender() bool {
+	return wt&WalletTraitMultiSender != 0
+}
+
 // DetermineWalletTraits returns the WalletTrait bitset for the provided Wallet.
 func DetermineWalletTraits(w Wallet) (t WalletTrait) {
 	if _, is := w.(Rescanner); is {
@@ -205,6 +219,12 @@ func DetermineWalletTraits(w Wallet) (t WalletTrait) {
 	if _, is := w.(DynamicSwapper); is {
 		t |= WalletTraitDynamicSwapper
 	}
+	if _, is := w.(PSBTer); is {
+		t |= WalletTraitPSBTer
+	}
+	if _, is := w.(MultiSender); is {
+		t |= WalletTraitMultiSender
+	}
 	return t
 }
 
@@ -734,6 +754,55 @@ type Bridger interface {
 	BridgeHistory(n int, refID *string, past bool) ([]*WalletTransaction, error)
 }
 
+// PSBTer is a wallet that can author unsigned partially signed transactions
+// (PSBT, BIP 174) so that the keys controlling the wallet's funds may be held
+// by an external signer, such as an air-gapped device. None of the methods
+// require the wallet to be unlocked. The inputs spent by an unsigned PSBT
+// remain locked until the signed PSBT is broadcast with BroadcastPSBT, the PSBT
+// is abandoned with AbandonPSBT, or the wallet is restarted. Transactions are
+// not recorded in the wallet's history until they are broadcast.
+type PSBTer interface {
+	// SendPSBT authors an unsigned PSBT that sends value to the address. If
+	// subtract is true, the fees are subtracted from the value, as with
+	// Withdrawer.Withdraw.
+	SendPSBT(address string, value, feeRate uint64, subtract bool) ([]byte, error)
+	// MakeBondPSBT is like Bonder.MakeBondTx, but the funding inputs are not
+	// signed, and the Bond's SignedTx is nil. The bond becomes valid once the
+	// signed PSBT is broadcast with BroadcastPSBT. The returned function may
+	// be used to abandon the bond iff the PSBT has not been broadcast.
+	MakeBondPSBT(ver uint16, amt, feeRate uint64, lockTime time.Time, privKey *secp256k1.PrivateKey, acctID []byte) (*Bond, []byte, func(), error)
+	// RefundBondPSBT authors an unsigned PSBT that refunds an expired bond to
+	// a new wallet address. The bond input must be signed with the bond's
+	// private key, which is not known to the wallet.
+	RefundBondPSBT(ver uint16, coinID, script []byte, amt, feeRate uint64) ([]byte, error)
+	// BroadcastPSBT finalizes a fully signed PSBT and broadcasts the
+	// extracted transaction, returning the transaction ID.
+	BroadcastPSBT(psbt []byte) (string, error)
+	// AbandonPSBT unlocks the wallet inputs of an unsigned PSBT that will not
+	// be broadcast, returning the transaction ID.
+	AbandonPSBT(psbt []byte) (string, error)
+}
+
+// Payment is a single destination of a multi-output send.
+type Payment struct {
+	Address string `json:"address"`
+	Value   uint64 `json:"value"`
+}
+
+// MultiSender is a wallet that can pay multiple addresses at once, e.g. for a
+// batch of payouts. UTXO-based wallets pay every address with a single
+// transaction. Account-based wallets send a sequence of transactions.
+type MultiSender interface {
+	// SendMulti sends the exact value of each payment to its address. The
+	// returned coins correspond to the payments. The fees are in addition to
+	// the payment values.
+	SendMulti(payments []*Payment, feeRate uint64) ([]Coin, error)
+	// EstimateSendMultiTxFee returns an estimate of the total fees for
+	// SendMulti, and ensures the wallet has enough to cover the payments and
+	// fees.
+	EstimateSendMultiTxFee(payments []*Payment, feeRate uint64) (fee uint64, err error)
+}
+
 // Sweeper is a wallet that can clear the entire balance of the wallet/account
 // to an address. Similar to Withdraw, but no input value is required.
 type Sweeper interface {
@@ -1265,6 +1334,22 @@ type WalletHistorian interface {
 	WalletTransaction(ctx context.Context, txID string) (*WalletTransaction, error)
 }
 
+// Watchtowered is a wallet that can prepare transactions for a swap
+// watchtower to broadcast on its behalf while the client is offline.
+type Watchtowered interface {
+	// SignedRefund creates, but does not broadcast, a signed refund
+	// transaction for the swap contract at coinID. The transaction will not be
+	// accepted by the network until the contract's lock time has passed. The
+	// serialized transaction is returned.
+	SignedRefund(coinID, contract dex.Bytes, feeRate uint64) (dex.Bytes, error)
+	// RedeemTemplate creates a signed redeem transaction for the counterparty
+	// swap described by the AuditInfo, with a placeholder in place of the
+	// secret. Since the secret is not covered by the signature, the
+	// transaction is valid once the 32-byte secret is written at secretOffset
+	// of the serialized transaction.
+	RedeemTemplate(swap *AuditInfo, feeRate uint64) (tx dex.Bytes, secretOffset int, err error)
+}
+
 // Bond is the fidelity bond info generated for a certain account ID, amount,
 // and lock time. These data are intended for the "post bond" request, in which
 // the server pre-validates the unsigned transaction, the client then publishes
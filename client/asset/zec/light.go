@@ -0,0 +1,1803 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package zec
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/asset/btc"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/config"
+	"decred.org/dcrdex/dex/encrypt"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	dexzec "decred.org/dcrdex/dex/networks/zec"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/dcrjson/v4"
+)
+
+const (
+	walletTypeLight = "lightwalletd"
+
+	lightWalletFileName = "lightwallet.json"
+	// lightGapLimit is the number of consecutive unused addresses that will be
+	// checked before address discovery is considered complete. The zecWallet
+	// requests a new address for every change output and redemption, so some
+	// of these will go unused.
+	lightGapLimit = 100
+	// lightReorgDepth is how far back from the last scanned height we rescan
+	// address histories on startup.
+	lightReorgDepth = 100
+	// lightMedianTimeBlocks is the number of blocks used to calculate the
+	// median time past.
+	lightMedianTimeBlocks = 11
+	// lightRedemptionTicker is how often we check for a contract redemption.
+	lightRedemptionTicker = 5 * time.Second
+
+	bip44Purpose       = 44
+	bip44CoinTypeZEC   = 133
+	bip44CoinTypeTest  = 1
+	lightExternalChain = 0
+)
+
+// errLightShielded is returned for any request that would use a shielded
+// pool. The lightwalletd wallet is deliberately transparent-only: it holds
+// and trades transparent funds, and cannot send to or receive on Orchard or
+// Sapling addresses. Use the zcashd wallet for shielded funds.
+var errLightShielded = errors.New("the lightwalletd wallet is transparent-only; " +
+	"Orchard and Sapling addresses are not supported (use zcashd for shielded funds)")
+
+// lightWalletDir is the directory in which the light wallet stores its
+// files for the given network.
+func lightWalletDir(dataDir string, btcParams *chaincfg.Params) string {
+	return filepath.Join(dataDir, btcParams.Name)
+}
+
+func lightWalletExists(dir string) (bool, error) {
+	_, err := os.Stat(filepath.Join(dir, lightWalletFileName))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return false, err
+}
+
+// lightKeystoreFile is the persisted light wallet state. The seed is
+// encrypted with the wallet password. The public key and chain code of the
+// BIP44 external branch are stored in the clear so that addresses can be
+// derived while the wallet is locked.
+type lightKeystoreFile struct {
+	Crypter    dex.Bytes `json:"crypter"`
+	EncSeed    dex.Bytes `json:"encseed"`
+	BranchPub  dex.Bytes `json:"branchpub"`
+	ChainCode  dex.Bytes `json:"chaincode"`
+	Birthday   uint64    `json:"birthday"`
+	NextIndex  uint32    `json:"nextindex"`
+	Discovered bool      `json:"discovered"`
+	ScanHeight uint64    `json:"scanheight"`
+}
+
+// lightKeystore manages the HD keys of a light wallet.
+type lightKeystore struct {
+	path       string
+	btcParams  *chaincfg.Params
+	addrParams *dexzec.AddressParams
+
+	mtx        sync.RWMutex
+	file       lightKeystoreFile
+	branchPub  *hdkeychain.ExtendedKey
+	branchPriv *hdkeychain.ExtendedKey // nil when locked
+}
+
+func bip44CoinType(net dex.Network) uint32 {
+	if net == dex.Mainnet {
+		return bip44CoinTypeZEC
+	}
+	return bip44CoinTypeTest
+}
+
+// deriveBranch derives the private extended key for the external branch of
+// the zeroth BIP44 account, m/44'/coin'/0'/0.
+func deriveBranch(seed []byte, net dex.Network, btcParams *chaincfg.Params) (*hdkeychain.ExtendedKey, error) {
+	k, err := hdkeychain.NewMaster(seed, btcParams)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range []uint32{
+		hdkeychain.HardenedKeyStart + bip44Purpose,
+		hdkeychain.HardenedKeyStart + bip44CoinType(net),
+		hdkeychain.HardenedKeyStart,
+		lightExternalChain,
+	} {
+		if k, err = k.Derive(i); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// createLightKeystore creates a new encrypted keystore file in dir.
+func createLightKeystore(dir string, seed, pw []byte, birthday uint64, net dex.Network, btcParams *chaincfg.Params) error {
+	branch, err := deriveBranch(seed, net, btcParams)
+	if err != nil {
+		return fmt.Errorf("error deriving keys: %w", err)
+	}
+	pub, err := branch.ECPubKey()
+	if err != nil {
+		return err
+	}
+	crypter := encrypt.NewCrypter(pw)
+	defer crypter.Close()
+	encSeed, err := crypter.Encrypt(seed)
+	if err != nil {
+		return fmt.Errorf("error encrypting seed: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("error creating wallet directory: %w", err)
+	}
+	ks := &lightKeystore{
+		path: filepath.Join(dir, lightWalletFileName),
+		file: lightKeystoreFile{
+			Crypter:   crypter.Serialize(),
+			EncSeed:   encSeed,
+			BranchPub: pub.SerializeCompressed(),
+			ChainCode: branch.ChainCode(),
+			Birthday:  birthday,
+		},
+	}
+	return ks.save()
+}
+
+// loadLightKeystore loads the keystore from the file in dir. The keystore is
+// locked.
+func loadLightKeystore(dir string, btcParams *chaincfg.Params, addrParams *dexzec.AddressParams) (*lightKeystore, error) {
+	path := filepath.Join(dir, lightWalletFileName)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading light wallet file: %w", err)
+	}
+	ks := &lightKeystore{
+		path:       path,
+		btcParams:  btcParams,
+		addrParams: addrParams,
+	}
+	if err := json.Unmarshal(b, &ks.file); err != nil {
+		return nil, fmt.Errorf("error decoding light wallet file: %w", err)
+	}
+	ks.branchPub = hdkeychain.NewExtendedKey(btcParams.HDPublicKeyID[:], ks.file.BranchPub,
+		ks.file.ChainCode, []byte{0, 0, 0, 0}, 4, lightExternalChain, false)
+	return ks, nil
+}
+
+// save writes the keystore file. The caller must not hold the write lock.
+func (ks *lightKeystore) save() error {
+	ks.mtx.RLock()
+	b, err := json.MarshalIndent(&ks.file, "", "    ")
+	ks.mtx.RUnlock()
+	if err != nil {
+		return err
+	}
+	tmpPath := ks.path + ".tmp"
+	if err := os.WriteFile(tmpPath, b, 0600); err != nil {
+		return fmt.Errorf("error writing light wallet file: %w", err)
+	}
+	return os.Rename(tmpPath, ks.path)
+}
+
+func (ks *lightKeystore) unlock(pw []byte, net dex.Network) error {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	if ks.branchPriv != nil {
+		return nil
+	}
+	crypter, err := encrypt.Deserialize(pw, ks.file.Crypter)
+	if err != nil {
+		return fmt.Errorf("error decoding crypter: %w", err)
+	}
+	defer crypter.Close()
+	seed, err := crypter.Decrypt(ks.file.EncSeed)
+	if err != nil {
+		return fmt.Errorf("incorrect password: %w", err)
+	}
+	branch, err := deriveBranch(seed, net, ks.btcParams)
+	for i := range seed {
+		seed[i] = 0
+	}
+	if err != nil {
+		return err
+	}
+	pub, err := branch.ECPubKey()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(pub.SerializeCompressed(), ks.file.BranchPub) {
+		return errors.New("decrypted seed does not match wallet keys")
+	}
+	ks.branchPriv = branch
+	return nil
+}
+
+func (ks *lightKeystore) lock() {
+	ks.mtx.Lock()
+	defer ks.mtx.Unlock()
+	if ks.branchPriv != nil {
+		ks.branchPriv.Zero()
+		ks.branchPriv = nil
+	}
+}
+
+func (ks *lightKeystore) locked() bool {
+	ks.mtx.RLock()
+	defer ks.mtx.RUnlock()
+	return ks.branchPriv == nil
+}
+
+// address derives the transparent address at the index.
+func (ks *lightKeystore) address(i uint32) (string, error) {
+	k, err := ks.branchPub.Derive(i)
+	if err != nil {
+		return "", err
+	}
+	pub, err := k.ECPubKey()
+	if err != nil {
+		return "", err
+	}
+	addr, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(pub.SerializeCompressed()), ks.btcParams)
+	if err != nil {
+		return "", err
+	}
+	return dexzec.EncodeAddress(addr, ks.addrParams)
+}
+
+// privKey derives the private key at the index. The wallet must be unlocked.
+func (ks *lightKeystore) privKey(i uint32) (*btcec.PrivateKey, error) {
+	ks.mtx.RLock()
+	defer ks.mtx.RUnlock()
+	if ks.branchPriv == nil {
+		return nil, errors.New("wallet is locked")
+	}
+	k, err := ks.branchPriv.Derive(i)
+	if err != nil {
+		return nil, err
+	}
+	return k.ECPrivKey()
+}
+
+// fingerprint identifies the wallet without revealing any keys.
+func (ks *lightKeystore) fingerprint() string {
+	h := sha256.Sum256(append(append([]byte{}, ks.file.BranchPub...), ks.file.ChainCode...))
+	return hex.EncodeToString(h[:8])
+}
+
+// lightWalletTx is a transaction that pays to or spends from the wallet.
+type lightWalletTx struct {
+	// height is zero until the transaction is mined.
+	height uint64
+	send   bool
+}
+
+// lightPendingTx is a transaction broadcast by the wallet that is not yet
+// mined.
+type lightPendingTx struct {
+	tx    *dexzec.Tx
+	stamp time.Time
+}
+
+// lightOutput is a transparent wallet output.
+type lightOutput struct {
+	address string
+	script  []byte
+	value   uint64
+	// height is zero for outputs of unmined transactions.
+	height uint64
+}
+
+// lightNode is a btc.RawRequester that emulates the subset of the zcashd RPC
+// API used by zecWallet, with chain data from a lightwalletd server and keys
+// from a lightKeystore. Only transparent addresses are supported.
+//
+// The confirmed UTXO set is queried from lightwalletd with every new block.
+// Unconfirmed outputs are only known for transactions broadcast by this
+// wallet, so incoming transparent payments are not seen until they are mined.
+type lightNode struct {
+	log        dex.Logger
+	net        dex.Network
+	cl         lightwalletdClient
+	ks         *lightKeystore
+	btcParams  *chaincfg.Params
+	addrParams *dexzec.AddressParams
+
+	refreshMtx sync.Mutex
+
+	mtx          sync.RWMutex
+	tip          *lightBlock
+	blocks       map[chainhash.Hash]*lightBlock
+	heights      map[uint64]*lightBlock
+	addrs        map[string]uint32
+	utxos        map[btc.OutPoint]*lightOutput
+	utxosTip     chainhash.Hash
+	pending      map[chainhash.Hash]*lightPendingTx
+	lockedOuts   map[btc.OutPoint]bool
+	walletTxs    map[chainhash.Hash]*lightWalletTx
+	rawTxs       map[chainhash.Hash][]byte
+	firstSeen    map[chainhash.Hash]time.Time
+	utxosFetched bool
+}
+
+var _ btc.RawRequester = (*lightNode)(nil)
+
+func newLightNode(cl lightwalletdClient, ks *lightKeystore, net dex.Network, btcParams *chaincfg.Params,
+	addrParams *dexzec.AddressParams, log dex.Logger) *lightNode {
+
+	return &lightNode{
+		log:        log,
+		net:        net,
+		cl:         cl,
+		ks:         ks,
+		btcParams:  btcParams,
+		addrParams: addrParams,
+		blocks:     make(map[chainhash.Hash]*lightBlock),
+		heights:    make(map[uint64]*lightBlock),
+		addrs:      make(map[string]uint32),
+		utxos:      make(map[btc.OutPoint]*lightOutput),
+		pending:    make(map[chainhash.Hash]*lightPendingTx),
+		lockedOuts: make(map[btc.OutPoint]bool),
+		walletTxs:  make(map[chainhash.Hash]*lightWalletTx),
+		rawTxs:     make(map[chainhash.Hash][]byte),
+		firstSeen:  make(map[chainhash.Hash]time.Time),
+	}
+}
+
+// connect syncs the tip, discovers used addresses if this is a new or
+// restored wallet, and scans the wallet's address histories.
+func (n *lightNode) connect(ctx context.Context) error {
+	if _, err := n.cl.info(ctx); err != nil {
+		return fmt.Errorf("error connecting to lightwalletd: %w", err)
+	}
+	tip, err := n.bestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting best block: %w", err)
+	}
+
+	n.ks.mtx.RLock()
+	discovered, nextIdx, scanHeight := n.ks.file.Discovered, n.ks.file.NextIndex, n.ks.file.ScanHeight
+	n.ks.mtx.RUnlock()
+
+	if !discovered {
+		if err := n.discoverAddresses(ctx, tip.height); err != nil {
+			return fmt.Errorf("address discovery error: %w", err)
+		}
+	} else {
+		for i := uint32(0); i < nextIdx; i++ {
+			addr, err := n.ks.address(i)
+			if err != nil {
+				return err
+			}
+			n.addrs[addr] = i
+		}
+		start := uint64(1)
+		if scanHeight > lightReorgDepth {
+			start = scanHeight - lightReorgDepth
+		}
+		for addr := range n.addrs {
+			txs, err := n.cl.addressTxs(ctx, addr, start, tip.height)
+			if err != nil {
+				return fmt.Errorf("error scanning address %s: %w", addr, err)
+			}
+			n.recordTxs(txs)
+		}
+	}
+
+	n.ks.mtx.Lock()
+	n.ks.file.ScanHeight = tip.height
+	n.ks.mtx.Unlock()
+	if err := n.ks.save(); err != nil {
+		return err
+	}
+	return n.refreshUTXOs(ctx)
+}
+
+// discoverAddresses scans addresses until lightGapLimit consecutive unused
+// addresses are found.
+func (n *lightNode) discoverAddresses(ctx context.Context, tipHeight uint64) error {
+	lastUsed := int64(-1)
+	for i := uint32(0); int64(i)-lastUsed <= lightGapLimit; i++ {
+		addr, err := n.ks.address(i)
+		if err != nil {
+			return err
+		}
+		txs, err := n.cl.addressTxs(ctx, addr, 1, tipHeight)
+		if err != nil {
+			return fmt.Errorf("error scanning address %s: %w", addr, err)
+		}
+		if len(txs) > 0 {
+			lastUsed = int64(i)
+			n.recordTxs(txs)
+		}
+	}
+	nextIdx := uint32(lastUsed + 1)
+	for i := uint32(0); i < nextIdx; i++ {
+		addr, err := n.ks.address(i)
+		if err != nil {
+			return err
+		}
+		n.addrs[addr] = i
+	}
+	n.log.Infof("Discovered %d used addresses", nextIdx)
+	n.ks.mtx.Lock()
+	n.ks.file.NextIndex = nextIdx
+	n.ks.file.Discovered = true
+	n.ks.mtx.Unlock()
+	return n.ks.save()
+}
+
+// recordTxs adds transactions from an address history to the wallet.
+func (n *lightNode) recordTxs(txs []*lightRawTx) {
+	for _, rawTx := range txs {
+		tx, err := dexzec.DeserializeTx(rawTx.data)
+		if err != nil {
+			n.log.Errorf("Error decoding transaction from lightwalletd: %v", err)
+			continue
+		}
+		txHash := tx.TxHash()
+		n.mtx.Lock()
+		n.rawTxs[txHash] = rawTx.data
+		n.walletTxs[txHash] = &lightWalletTx{height: rawTx.height}
+		n.mtx.Unlock()
+	}
+}
+
+// newAddress returns the next unused address.
+func (n *lightNode) newAddress() (uint32, string, error) {
+	n.ks.mtx.Lock()
+	i := n.ks.file.NextIndex
+	addr, err := n.ks.address(i)
+	if err != nil {
+		n.ks.mtx.Unlock()
+		return 0, "", err
+	}
+	n.ks.file.NextIndex++
+	n.ks.mtx.Unlock()
+	if err := n.ks.save(); err != nil {
+		return 0, "", err
+	}
+	n.mtx.Lock()
+	n.addrs[addr] = i
+	n.mtx.Unlock()
+	return i, addr, nil
+}
+
+func (n *lightNode) addressIndex(addr string) (uint32, bool) {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	i, found := n.addrs[addr]
+	return i, found
+}
+
+func (n *lightNode) addressList() []string {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	addrs := make([]string, 0, len(n.addrs))
+	for addr := range n.addrs {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+// scriptAddress returns the address for a P2PKH or P2SH pkScript.
+func (n *lightNode) scriptAddress(pkScript []byte) string {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, n.btcParams)
+	if err != nil || len(addrs) != 1 {
+		return ""
+	}
+	addr, err := dexzec.EncodeAddress(addrs[0], n.addrParams)
+	if err != nil {
+		return ""
+	}
+	return addr
+}
+
+// bestBlock gets the current tip from lightwalletd, updating the block cache.
+func (n *lightNode) bestBlock(ctx context.Context) (*lightBlock, error) {
+	id, err := n.cl.latestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	n.mtx.RLock()
+	tip := n.tip
+	n.mtx.RUnlock()
+	if tip != nil && tip.hash == id.hash {
+		return tip, nil
+	}
+	blk, err := n.cl.block(ctx, id.height)
+	if err != nil {
+		return nil, err
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if n.tip != nil && blk.prevHash != n.tip.hash {
+		// Reorg, or more than one new block. Either way, the height index
+		// might be stale.
+		n.heights = make(map[uint64]*lightBlock)
+	}
+	n.tip = blk
+	n.blocks[blk.hash] = blk
+	n.heights[blk.height] = blk
+	return blk, nil
+}
+
+// blockAt gets the mainchain block at the height.
+func (n *lightNode) blockAt(ctx context.Context, height uint64) (*lightBlock, error) {
+	n.mtx.RLock()
+	blk, found := n.heights[height]
+	n.mtx.RUnlock()
+	if found {
+		return blk, nil
+	}
+	blk, err := n.cl.block(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	n.mtx.Lock()
+	n.blocks[blk.hash] = blk
+	n.heights[height] = blk
+	n.mtx.Unlock()
+	return blk, nil
+}
+
+func (n *lightNode) tipHeight() uint64 {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	if n.tip == nil {
+		return 0
+	}
+	return n.tip.height
+}
+
+func confirms(height, tipHeight uint64) int64 {
+	if height == 0 || height > tipHeight {
+		return 0
+	}
+	return int64(tipHeight - height + 1)
+}
+
+// refreshUTXOs updates the confirmed UTXO set if the tip has changed since the
+// last update, and updates the status of pending transactions.
+func (n *lightNode) refreshUTXOs(ctx context.Context) error {
+	n.refreshMtx.Lock()
+	defer n.refreshMtx.Unlock()
+
+	tip, err := n.bestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	n.mtx.RLock()
+	upToDate := n.utxosFetched && n.utxosTip == tip.hash
+	n.mtx.RUnlock()
+	if upToDate {
+		return nil
+	}
+
+	addrs := n.addressList()
+	var utxos []*lightUTXO
+	if len(addrs) > 0 {
+		if utxos, err = n.cl.addressUTXOs(ctx, addrs); err != nil {
+			return fmt.Errorf("error fetching UTXOs: %w", err)
+		}
+	}
+
+	n.mtx.RLock()
+	pending := make(map[chainhash.Hash]*lightPendingTx, len(n.pending))
+	for h, ptx := range n.pending {
+		pending[h] = ptx
+	}
+	n.mtx.RUnlock()
+
+	mined := make(map[chainhash.Hash]uint64)
+	dropped := make(map[chainhash.Hash]bool)
+	for txHash := range pending {
+		rawTx, err := n.cl.transaction(ctx, &txHash)
+		if err != nil {
+			if errors.Is(err, errLightTxNotFound) {
+				n.log.Warnf("Pending transaction %s is no longer known to lightwalletd", txHash)
+				dropped[txHash] = true
+				continue
+			}
+			return fmt.Errorf("error checking pending transaction %s: %w", txHash, err)
+		}
+		if rawTx.height > 0 {
+			mined[txHash] = rawTx.height
+		}
+	}
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.utxos = make(map[btc.OutPoint]*lightOutput, len(utxos))
+	for _, u := range utxos {
+		n.utxos[btc.NewOutPoint(&u.txHash, u.vout)] = &lightOutput{
+			address: u.address,
+			script:  u.script,
+			value:   u.value,
+			height:  u.height,
+		}
+		if _, known := n.walletTxs[u.txHash]; !known {
+			n.walletTxs[u.txHash] = &lightWalletTx{height: u.height}
+		}
+	}
+	for txHash, height := range mined {
+		delete(n.pending, txHash)
+		if wt := n.walletTxs[txHash]; wt != nil {
+			wt.height = height
+		}
+	}
+	for txHash := range dropped {
+		delete(n.pending, txHash)
+		delete(n.walletTxs, txHash)
+	}
+	n.utxosTip = tip.hash
+	n.utxosFetched = true
+
+	n.ks.mtx.Lock()
+	n.ks.file.ScanHeight = tip.height
+	n.ks.mtx.Unlock()
+	if err := n.ks.save(); err != nil {
+		n.log.Errorf("Error saving scan height: %v", err)
+	}
+	return nil
+}
+
+// unspents is the wallet's UTXO set with the effects of pending transactions
+// applied. The caller must hold the mtx.
+func (n *lightNode) unspents() map[btc.OutPoint]*lightOutput {
+	outs := make(map[btc.OutPoint]*lightOutput, len(n.utxos))
+	for op, u := range n.utxos {
+		outs[op] = u
+	}
+	for txHash, ptx := range n.pending {
+		for vout, txOut := range ptx.tx.TxOut {
+			addr := n.scriptAddress(txOut.PkScript)
+			if _, ours := n.addrs[addr]; !ours {
+				continue
+			}
+			op := btc.NewOutPoint(&txHash, uint32(vout))
+			outs[op] = &lightOutput{
+				address: addr,
+				script:  txOut.PkScript,
+				value:   uint64(txOut.Value),
+			}
+		}
+	}
+	for _, ptx := range n.pending {
+		for _, txIn := range ptx.tx.TxIn {
+			delete(outs, btc.NewOutPoint(&txIn.PreviousOutPoint.Hash, txIn.PreviousOutPoint.Index))
+		}
+	}
+	return outs
+}
+
+// spentByPending checks whether the outpoint is spent by a pending wallet
+// transaction. The caller must hold the mtx.
+func (n *lightNode) spentByPending(op btc.OutPoint) bool {
+	for _, ptx := range n.pending {
+		for _, txIn := range ptx.tx.TxIn {
+			if txIn.PreviousOutPoint.Hash == op.TxHash && txIn.PreviousOutPoint.Index == op.Vout {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// transaction gets the raw transaction and its height. Transaction bytes are
+// cached, but the height is always refreshed for unconfirmed or recently
+// confirmed transactions.
+func (n *lightNode) transaction(ctx context.Context, txHash *chainhash.Hash) (*lightRawTx, error) {
+	n.mtx.RLock()
+	raw, haveRaw := n.rawTxs[*txHash]
+	wt := n.walletTxs[*txHash]
+	_, isPending := n.pending[*txHash]
+	n.mtx.RUnlock()
+	if isPending {
+		return &lightRawTx{data: raw}, nil
+	}
+	// A wallet tx with enough confirmations to be beyond reorg concern can be
+	// served from cache.
+	if haveRaw && wt != nil && confirms(wt.height, n.tipHeight()) > lightReorgDepth {
+		return &lightRawTx{data: raw, height: wt.height}, nil
+	}
+	rawTx, err := n.cl.transaction(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	n.mtx.Lock()
+	if _, found := n.firstSeen[*txHash]; !found {
+		n.firstSeen[*txHash] = time.Now()
+	}
+	if wt := n.walletTxs[*txHash]; wt != nil {
+		n.rawTxs[*txHash] = rawTx.data
+		wt.height = rawTx.height
+	}
+	n.mtx.Unlock()
+	return rawTx, nil
+}
+
+func txNotFoundRPCError() error {
+	return dcrjson.NewRPCError(dcrjson.RPCErrorCode(btcjson.ErrRPCNoTxInfo), "No information available about transaction")
+}
+
+func blockNotFoundRPCError() error {
+	return dcrjson.NewRPCError(dcrjson.RPCErrorCode(btcjson.ErrRPCInvalidAddressOrKey), "Block not found")
+}
+
+// parseParams decodes the positional params into the args. Missing trailing
+// params leave the args unchanged.
+func parseParams(params []json.RawMessage, args ...any) error {
+	for i, p := range params {
+		if i >= len(args) {
+			break
+		}
+		if err := json.Unmarshal(p, args[i]); err != nil {
+			return fmt.Errorf("error parsing param %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// RawRequest satisfies btc.RawRequester, emulating the zcashd RPC methods
+// used by zecWallet.
+func (n *lightNode) RawRequest(ctx context.Context, method string, params []json.RawMessage) (json.RawMessage, error) {
+	var res any
+	var err error
+	switch method {
+	case "getnetworkinfo":
+		res, err = n.getNetworkInfo(ctx)
+	case "getblockchaininfo":
+		res, err = n.getBlockchainInfo(ctx)
+	case "getbestblockhash":
+		var tip *lightBlock
+		if tip, err = n.bestBlock(ctx); err == nil {
+			res = tip.hash.String()
+		}
+	case "getblockhash":
+		res, err = n.getBlockHash(ctx, params)
+	case "getblockheader":
+		res, err = n.getBlockHeader(ctx, params)
+	case "getrawmempool":
+		res, err = n.getRawMempool(ctx)
+	case "getrawtransaction":
+		res, err = n.getRawTransaction(ctx, params)
+	case "gettransaction":
+		res, err = n.getTransaction(ctx, params)
+	case "gettxout":
+		res, err = n.getTxOut(ctx, params)
+	case "listunspent":
+		res, err = n.listUnspent(ctx, params)
+	case "lockunspent":
+		res, err = n.lockUnspent(params)
+	case "listlockunspent":
+		res = n.listLockUnspent()
+	case "getbalance":
+		res, err = n.getBalance(ctx, params)
+	case "getreceivedbyaddress":
+		res, err = n.getReceivedByAddress(ctx, params)
+	case "signrawtransaction":
+		res, err = n.signRawTransaction(ctx, params)
+	case "sendrawtransaction":
+		res, err = n.sendRawTransaction(ctx, params)
+	case "dumpprivkey":
+		res, err = n.dumpPrivKey(params)
+	case "getwalletinfo":
+		res = &walletInfoRes{MnemonicSeedfp: n.ks.fingerprint()}
+	case "listsinceblock":
+		res, err = n.listSinceBlock(ctx, params)
+	case methodZListAccounts:
+		res = []*zListAccountsResult{{Number: shieldedAcctNumber}}
+	case methodZGetNewAccount:
+		res = map[string]uint32{"account": shieldedAcctNumber}
+	case methodZGetAddressForAccount:
+		res, err = n.zGetAddressForAccount(params)
+	case methodZListUnifiedReceivers:
+		res, err = n.zListUnifiedReceivers(params)
+	case methodZGetBalanceForAccount:
+		res, err = n.zGetBalanceForAccount(ctx, params)
+	case methodZGetNotesCount:
+		res = &zNotesCount{}
+	case methodZValidateAddress:
+		res, err = n.zValidateAddress(params)
+	case methodZListUnspent:
+		res = []*zListUnspentResult{}
+	case methodZSendMany, methodZGetOperationResult:
+		err = errLightShielded
+	default:
+		err = dcrjson.NewRPCError(dcrjson.RPCErrorCode(btcjson.ErrRPCMethodNotFound.Code),
+			fmt.Sprintf("method %q not supported by the lightwalletd wallet", method))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(res)
+}
+
+func (n *lightNode) getNetworkInfo(ctx context.Context) (any, error) {
+	info, err := n.cl.info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The zcashd version check in connectRPC is about the RPC API, which we
+	// are emulating, so just report the minimum.
+	return map[string]any{
+		"version":     minNetworkVersion,
+		"subversion":  "/lightwalletd:" + info.zcashdBuild + "/",
+		"connections": 1,
+	}, nil
+}
+
+func (n *lightNode) getBlockchainInfo(ctx context.Context) (*btc.GetBlockchainInfoResult, error) {
+	info, err := n.cl.info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := n.bestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	headers := info.estimatedHeight
+	if headers < tip.height {
+		headers = tip.height
+	}
+	return &btc.GetBlockchainInfoResult{
+		Chain:         info.chainName,
+		Blocks:        int64(tip.height),
+		Headers:       int64(headers),
+		BestBlockHash: tip.hash.String(),
+	}, nil
+}
+
+func (n *lightNode) getBlockHash(ctx context.Context, params []json.RawMessage) (string, error) {
+	var height int64
+	if err := parseParams(params, &height); err != nil {
+		return "", err
+	}
+	if height < 0 {
+		return "", fmt.Errorf("invalid height %d", height)
+	}
+	blk, err := n.blockAt(ctx, uint64(height))
+	if err != nil {
+		if height == 0 {
+			// lightwalletd may not serve blocks before Sapling activation.
+			// listsinceblock treats the zero hash as the genesis block.
+			return (&chainhash.Hash{}).String(), nil
+		}
+		return "", err
+	}
+	return blk.hash.String(), nil
+}
+
+func (n *lightNode) getBlockHeader(ctx context.Context, params []json.RawMessage) (*btc.BlockHeader, error) {
+	var hashStr string
+	verbose := true
+	if err := parseParams(params, &hashStr, &verbose); err != nil {
+		return nil, err
+	}
+	if !verbose {
+		return nil, errors.New("serialized block headers are not available from lightwalletd")
+	}
+	blockHash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		return nil, err
+	}
+	n.mtx.RLock()
+	blk, found := n.blocks[*blockHash]
+	n.mtx.RUnlock()
+	if !found {
+		return nil, blockNotFoundRPCError()
+	}
+	tip, err := n.bestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hdr := &btc.BlockHeader{
+		Hash:              blk.hash.String(),
+		Confirmations:     -1,
+		Height:            int64(blk.height),
+		Time:              int64(blk.time),
+		PreviousBlockHash: blk.prevHash.String(),
+	}
+	if blk.height <= tip.height {
+		mainchainBlk, err := n.blockAt(ctx, blk.height)
+		if err != nil {
+			return nil, err
+		}
+		if mainchainBlk.hash == blk.hash {
+			hdr.Confirmations = confirms(blk.height, tip.height)
+		}
+	}
+	return hdr, nil
+}
+
+func (n *lightNode) getRawMempool(ctx context.Context) ([]string, error) {
+	txIDs, err := n.cl.mempoolTxIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, 0, len(txIDs))
+	for _, h := range txIDs {
+		strs = append(strs, h.String())
+	}
+	return strs, nil
+}
+
+func (n *lightNode) txHashParam(params []json.RawMessage) (*chainhash.Hash, error) {
+	var txid string
+	if err := parseParams(params, &txid); err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(txid)
+}
+
+func (n *lightNode) getRawTransaction(ctx context.Context, params []json.RawMessage) (dex.Bytes, error) {
+	txHash, err := n.txHashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	rawTx, err := n.transaction(ctx, txHash)
+	if err != nil {
+		if errors.Is(err, errLightTxNotFound) {
+			return nil, txNotFoundRPCError()
+		}
+		return nil, err
+	}
+	return rawTx.data, nil
+}
+
+func (n *lightNode) getTransaction(ctx context.Context, params []json.RawMessage) (*GetTransactionResult, error) {
+	txHash, err := n.txHashParam(params)
+	if err != nil {
+		return nil, err
+	}
+	rawTx, err := n.transaction(ctx, txHash)
+	if err != nil {
+		if errors.Is(err, errLightTxNotFound) {
+			return nil, txNotFoundRPCError()
+		}
+		return nil, err
+	}
+	tip, err := n.bestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	n.mtx.RLock()
+	seen := n.firstSeen[*txHash]
+	n.mtx.RUnlock()
+	res := &GetTransactionResult{
+		TxID:         txHash.String(),
+		Time:         uint64(seen.Unix()),
+		TimeReceived: uint64(seen.Unix()),
+		Bytes:        rawTx.data,
+	}
+	if rawTx.height > 0 && rawTx.height <= tip.height {
+		blk, err := n.blockAt(ctx, rawTx.height)
+		if err != nil {
+			return nil, err
+		}
+		res.Confirmations = confirms(rawTx.height, tip.height)
+		res.BlockHash = blk.hash.String()
+		res.BlockTime = uint64(blk.time)
+		res.Time = uint64(blk.time)
+	}
+	return res, nil
+}
+
+func (n *lightNode) txOutResult(tip *lightBlock, out *lightOutput) *btcjson.GetTxOutResult {
+	return &btcjson.GetTxOutResult{
+		BestBlock:     tip.hash.String(),
+		Confirmations: confirms(out.height, tip.height),
+		Value:         btcutil.Amount(out.value).ToBTC(),
+		ScriptPubKey: btcjson.ScriptPubKeyResult{
+			Hex:       hex.EncodeToString(out.script),
+			Addresses: []string{out.address},
+		},
+	}
+}
+
+// getTxOut returns nil for spent or unknown outputs. Outputs that are not the
+// wallet's are checked against the UTXO set of their address.
+func (n *lightNode) getTxOut(ctx context.Context, params []json.RawMessage) (*btcjson.GetTxOutResult, error) {
+	var txid string
+	var vout uint32
+	if err := parseParams(params, &txid, &vout); err != nil {
+		return nil, err
+	}
+	txHash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.refreshUTXOs(ctx); err != nil {
+		return nil, err
+	}
+	op := btc.NewOutPoint(txHash, vout)
+	n.mtx.RLock()
+	tip := n.tip
+	out, ours := n.unspents()[op]
+	spent := n.spentByPending(op)
+	n.mtx.RUnlock()
+	if ours {
+		return n.txOutResult(tip, out), nil
+	}
+	if spent {
+		return nil, nil
+	}
+	rawTx, err := n.transaction(ctx, txHash)
+	if err != nil {
+		if errors.Is(err, errLightTxNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tx, err := dexzec.DeserializeTx(rawTx.data)
+	if err != nil {
+		return nil, err
+	}
+	if int(vout) >= len(tx.TxOut) {
+		return nil, nil
+	}
+	txOut := tx.TxOut[vout]
+	out = &lightOutput{
+		address: n.scriptAddress(txOut.PkScript),
+		script:  txOut.PkScript,
+		value:   uint64(txOut.Value),
+		height:  rawTx.height,
+	}
+	if rawTx.height == 0 || out.address == "" {
+		// We can't check the mempool for spends, and we can't look up the UTXO
+		// set for a non-standard script.
+		return n.txOutResult(tip, out), nil
+	}
+	utxos, err := n.cl.addressUTXOs(ctx, []string{out.address})
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range utxos {
+		if u.txHash == *txHash && u.vout == vout {
+			return n.txOutResult(tip, out), nil
+		}
+	}
+	return nil, nil
+}
+
+func (n *lightNode) listUnspent(ctx context.Context, params []json.RawMessage) ([]*btc.ListUnspentResult, error) {
+	var minConf int64
+	if err := parseParams(params, &minConf); err != nil {
+		return nil, err
+	}
+	if err := n.refreshUTXOs(ctx); err != nil {
+		return nil, err
+	}
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	safe := true
+	res := make([]*btc.ListUnspentResult, 0)
+	for op, u := range n.unspents() {
+		confs := confirms(u.height, n.tip.height)
+		if n.lockedOuts[op] || confs < minConf {
+			continue
+		}
+		res = append(res, &btc.ListUnspentResult{
+			TxID:          op.TxHash.String(),
+			Vout:          op.Vout,
+			Address:       u.address,
+			ScriptPubKey:  u.script,
+			Amount:        btcutil.Amount(u.value).ToBTC(),
+			Confirmations: uint32(confs),
+			Spendable:     true,
+			Solvable:      true,
+			SafePtr:       &safe,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].TxID == res[j].TxID {
+			return res[i].Vout < res[j].Vout
+		}
+		return res[i].TxID < res[j].TxID
+	})
+	return res, nil
+}
+
+func (n *lightNode) lockUnspent(params []json.RawMessage) (bool, error) {
+	var unlock bool
+	var ops []*btc.RPCOutpoint
+	if err := parseParams(params, &unlock, &ops); err != nil {
+		return false, err
+	}
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if unlock && ops == nil {
+		n.lockedOuts = make(map[btc.OutPoint]bool)
+		return true, nil
+	}
+	for _, rpcOp := range ops {
+		txHash, err := chainhash.NewHashFromStr(rpcOp.TxID)
+		if err != nil {
+			return false, err
+		}
+		op := btc.NewOutPoint(txHash, rpcOp.Vout)
+		if unlock {
+			delete(n.lockedOuts, op)
+		} else {
+			n.lockedOuts[op] = true
+		}
+	}
+	return true, nil
+}
+
+func (n *lightNode) listLockUnspent() []*btc.RPCOutpoint {
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	ops := make([]*btc.RPCOutpoint, 0, len(n.lockedOuts))
+	for op := range n.lockedOuts {
+		ops = append(ops, &btc.RPCOutpoint{TxID: op.TxHash.String(), Vout: op.Vout})
+	}
+	return ops
+}
+
+// balance sums the wallet's outputs with at least minConf confirmations.
+func (n *lightNode) balance(ctx context.Context, minConf int64, includeLocked bool) (uint64, error) {
+	if err := n.refreshUTXOs(ctx); err != nil {
+		return 0, err
+	}
+	n.mtx.RLock()
+	defer n.mtx.RUnlock()
+	var sum uint64
+	for op, u := range n.unspents() {
+		if confirms(u.height, n.tip.height) < minConf || (!includeLocked && n.lockedOuts[op]) {
+			continue
+		}
+		sum += u.value
+	}
+	return sum, nil
+}
+
+func (n *lightNode) getBalance(ctx context.Context, params []json.RawMessage) (uint64, error) {
+	var acct string
+	var minConf int64
+	if err := parseParams(params, &acct, &minConf); err != nil {
+		return 0, err
+	}
+	return n.balance(ctx, minConf, true)
+}
+
+func (n *lightNode) getReceivedByAddress(ctx context.Context, params []json.RawMessage) (uint64, error) {
+	var addr string
+	if err := parseParams(params, &addr); err != nil {
+		return 0, err
+	}
+	if _, ours := n.addressIndex(addr); !ours {
+		return 0, fmt.Errorf("address %s is not a wallet address", addr)
+	}
+	txs, err := n.cl.addressTxs(ctx, addr, 1, n.tipHeight())
+	if err != nil {
+		return 0, err
+	}
+	n.mtx.RLock()
+	for _, ptx := range n.pending {
+		b, err := ptx.tx.Bytes()
+		if err == nil {
+			txs = append(txs, &lightRawTx{data: b})
+		}
+	}
+	n.mtx.RUnlock()
+	var recv uint64
+	for _, rawTx := range txs {
+		tx, err := dexzec.DeserializeTx(rawTx.data)
+		if err != nil {
+			return 0, err
+		}
+		for _, txOut := range tx.TxOut {
+			if n.scriptAddress(txOut.PkScript) == addr {
+				recv += uint64(txOut.Value)
+			}
+		}
+	}
+	return recv, nil
+}
+
+// prevOut finds the output spent by a transaction input.
+func (n *lightNode) prevOut(ctx context.Context, op btc.OutPoint) (*wire.TxOut, error) {
+	n.mtx.RLock()
+	u, found := n.unspents()[op]
+	n.mtx.RUnlock()
+	if found {
+		return wire.NewTxOut(int64(u.value), u.script), nil
+	}
+	rawTx, err := n.transaction(ctx, &op.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := dexzec.DeserializeTx(rawTx.data)
+	if err != nil {
+		return nil, err
+	}
+	if int(op.Vout) >= len(tx.TxOut) {
+		return nil, fmt.Errorf("invalid output index %d for transaction %s", op.Vout, op.TxHash)
+	}
+	return tx.TxOut[op.Vout], nil
+}
+
+// signRawTransaction signs any inputs spending P2PKH outputs that pay to
+// wallet addresses. Inputs that are already signed are left as-is.
+func (n *lightNode) signRawTransaction(ctx context.Context, params []json.RawMessage) (*btc.SignTxResult, error) {
+	var txB dex.Bytes
+	if err := parseParams(params, &txB); err != nil {
+		return nil, err
+	}
+	tx, err := dexzec.DeserializeTx(txB)
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]int64, len(tx.TxIn))
+	prevScripts := make([][]byte, len(tx.TxIn))
+	for i, txIn := range tx.TxIn {
+		prevOut, err := n.prevOut(ctx, btc.NewOutPoint(&txIn.PreviousOutPoint.Hash, txIn.PreviousOutPoint.Index))
+		if err != nil {
+			return nil, fmt.Errorf("error finding previous output for input %d: %w", i, err)
+		}
+		vals[i] = prevOut.Value
+		prevScripts[i] = prevOut.PkScript
+	}
+	res := &btc.SignTxResult{Complete: true}
+	for i, txIn := range tx.TxIn {
+		if len(txIn.SignatureScript) > 0 {
+			continue
+		}
+		signErr := func(s string, a ...any) {
+			res.Complete = false
+			res.Errors = append(res.Errors, &btc.SignTxError{
+				TxID:  txIn.PreviousOutPoint.Hash.String(),
+				Vout:  txIn.PreviousOutPoint.Index,
+				Error: fmt.Sprintf(s, a...),
+			})
+		}
+		if !txscript.IsPayToPubKeyHash(prevScripts[i]) {
+			signErr("input %d does not spend a P2PKH output", i)
+			continue
+		}
+		idx, ours := n.addressIndex(n.scriptAddress(prevScripts[i]))
+		if !ours {
+			signErr("input %d does not spend a wallet output", i)
+			continue
+		}
+		key, err := n.ks.privKey(idx)
+		if err != nil {
+			signErr("error getting key for input %d: %v", i, err)
+			continue
+		}
+		sigHash, err := tx.SignatureDigest(i, txscript.SigHashAll, prevScripts[i], vals, prevScripts)
+		if err != nil {
+			key.Zero()
+			return nil, fmt.Errorf("sighash calculation error: %w", err)
+		}
+		sig := append(ecdsa.Sign(key, sigHash[:]).Serialize(), byte(txscript.SigHashAll))
+		pubKey := key.PubKey().SerializeCompressed()
+		key.Zero()
+		sigScript, err := txscript.NewScriptBuilder().AddData(sig).AddData(pubKey).Script()
+		if err != nil {
+			return nil, err
+		}
+		txIn.SignatureScript = sigScript
+	}
+	if res.Hex, err = tx.Bytes(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (n *lightNode) sendRawTransaction(ctx context.Context, params []json.RawMessage) (string, error) {
+	var txB dex.Bytes
+	if err := parseParams(params, &txB); err != nil {
+		return "", err
+	}
+	tx, err := dexzec.DeserializeTx(txB)
+	if err != nil {
+		return "", err
+	}
+	if err := n.cl.sendTransaction(ctx, txB); err != nil {
+		return "", err
+	}
+	txHash := tx.TxHash()
+	n.mtx.Lock()
+	n.pending[txHash] = &lightPendingTx{tx: tx, stamp: time.Now()}
+	n.rawTxs[txHash] = txB
+	n.walletTxs[txHash] = &lightWalletTx{send: true}
+	n.firstSeen[txHash] = time.Now()
+	n.mtx.Unlock()
+	return txHash.String(), nil
+}
+
+func (n *lightNode) dumpPrivKey(params []json.RawMessage) (string, error) {
+	var addr string
+	if err := parseParams(params, &addr); err != nil {
+		return "", err
+	}
+	idx, ours := n.addressIndex(addr)
+	if !ours {
+		return "", fmt.Errorf("address %s is not a wallet address", addr)
+	}
+	key, err := n.ks.privKey(idx)
+	if err != nil {
+		return "", err
+	}
+	wif, err := btcutil.NewWIF(key, n.btcParams, true)
+	if err != nil {
+		return "", err
+	}
+	return wif.String(), nil
+}
+
+// spendsWalletOutput checks whether any of the transaction's inputs spend an
+// output paying to a wallet address. The caller must hold the mtx.
+func (n *lightNode) spendsWalletOutput(tx *dexzec.Tx) bool {
+	for _, txIn := range tx.TxIn {
+		prevB, found := n.rawTxs[txIn.PreviousOutPoint.Hash]
+		if !found {
+			continue
+		}
+		prevTx, err := dexzec.DeserializeTx(prevB)
+		if err != nil || int(txIn.PreviousOutPoint.Index) >= len(prevTx.TxOut) {
+			continue
+		}
+		if _, ours := n.addrs[n.scriptAddress(prevTx.TxOut[txIn.PreviousOutPoint.Index].PkScript)]; ours {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *lightNode) listSinceBlock(ctx context.Context, params []json.RawMessage) (*listSinceBlockRes, error) {
+	var hashStr string
+	if err := parseParams(params, &hashStr); err != nil {
+		return nil, err
+	}
+	blockHash, err := chainhash.NewHashFromStr(hashStr)
+	if err != nil {
+		return nil, err
+	}
+	var startHeight uint64
+	if *blockHash != (chainhash.Hash{}) {
+		n.mtx.RLock()
+		blk, found := n.blocks[*blockHash]
+		n.mtx.RUnlock()
+		if !found {
+			return nil, blockNotFoundRPCError()
+		}
+		startHeight = blk.height
+	}
+	if err := n.refreshUTXOs(ctx); err != nil {
+		return nil, err
+	}
+
+	type txEntry struct {
+		txHash chainhash.Hash
+		height uint64
+		send   bool
+	}
+	var entries []*txEntry
+	n.mtx.RLock()
+	for txHash, wt := range n.walletTxs {
+		if wt.height != 0 && wt.height < startHeight {
+			continue
+		}
+		send := wt.send
+		if !send {
+			if b, found := n.rawTxs[txHash]; found {
+				if tx, err := dexzec.DeserializeTx(b); err == nil {
+					send = n.spendsWalletOutput(tx)
+				}
+			}
+		}
+		entries = append(entries, &txEntry{txHash: txHash, height: wt.height, send: send})
+	}
+	n.mtx.RUnlock()
+
+	res := &listSinceBlockRes{Transactions: make([]btcjson.ListTransactionsResult, 0, len(entries))}
+	for _, e := range entries {
+		category := "receive"
+		if e.send {
+			category = sendCategory
+		}
+		lt := btcjson.ListTransactionsResult{
+			TxID:     e.txHash.String(),
+			Category: category,
+		}
+		if e.height > 0 {
+			blk, err := n.blockAt(ctx, e.height)
+			if err != nil {
+				return nil, err
+			}
+			height := int64(e.height)
+			lt.BlockIndex = &height
+			lt.BlockHash = blk.hash.String()
+			lt.BlockTime = int64(blk.time)
+		}
+		res.Transactions = append(res.Transactions, lt)
+	}
+	return res, nil
+}
+
+func (n *lightNode) zGetAddressForAccount(params []json.RawMessage) (*zGetAddressForAccountResult, error) {
+	var acct uint32
+	var addrTypes []string
+	if err := parseParams(params, &acct, &addrTypes); err != nil {
+		return nil, err
+	}
+	var transparent bool
+	for _, t := range addrTypes {
+		if t == transparentAddressType {
+			transparent = true
+		}
+	}
+	if !transparent {
+		return nil, errLightShielded
+	}
+	idx, addr, err := n.newAddress()
+	if err != nil {
+		return nil, err
+	}
+	return &zGetAddressForAccountResult{
+		Account:          acct,
+		DiversifierIndex: idx,
+		ReceiverTypes:    []string{transparentAddressType},
+		Address:          addr,
+	}, nil
+}
+
+func (n *lightNode) zListUnifiedReceivers(params []json.RawMessage) (*unifiedReceivers, error) {
+	var addr string
+	if err := parseParams(params, &addr); err != nil {
+		return nil, err
+	}
+	if _, err := dexzec.DecodeAddress(addr, n.addrParams, n.btcParams); err != nil {
+		return nil, errLightShielded
+	}
+	return &unifiedReceivers{Transparent: addr}, nil
+}
+
+func (n *lightNode) zGetBalanceForAccount(ctx context.Context, params []json.RawMessage) (*zAccountBalance, error) {
+	var acct uint32
+	var minConf int64
+	if err := parseParams(params, &acct, &minConf); err != nil {
+		return nil, err
+	}
+	bal, err := n.balance(ctx, minConf, false)
+	if err != nil {
+		return nil, err
+	}
+	return &zAccountBalance{Pools: zBalancePools{Transparent: valZat{ValueZat: bal}}}, nil
+}
+
+func (n *lightNode) zValidateAddress(params []json.RawMessage) (*zValidateAddressResult, error) {
+	var addrStr string
+	if err := parseParams(params, &addrStr); err != nil {
+		return nil, err
+	}
+	addr, err := dexzec.DecodeAddress(addrStr, n.addrParams, n.btcParams)
+	if err != nil {
+		return &zValidateAddressResult{Address: addrStr}, nil
+	}
+	addrType := transparentAddressType
+	if _, isP2SH := addr.(*btcutil.AddressScriptHash); isP2SH {
+		addrType = "p2sh"
+	}
+	_, ours := n.addressIndex(addrStr)
+	return &zValidateAddressResult{
+		IsValid:     true,
+		Address:     addrStr,
+		AddressType: addrType,
+		IsMine:      ours,
+	}, nil
+}
+
+// findOutputSpender looks for a transaction spending the output, first in the
+// address history of the output's address, then in the mempool.
+func (n *lightNode) findOutputSpender(ctx context.Context, op btc.OutPoint) (*wire.MsgTx, uint32, error) {
+	rawTx, err := n.transaction(ctx, &op.TxHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	tx, err := dexzec.DeserializeTx(rawTx.data)
+	if err != nil {
+		return nil, 0, err
+	}
+	if int(op.Vout) >= len(tx.TxOut) {
+		return nil, 0, fmt.Errorf("invalid output index %d for transaction %s", op.Vout, op.TxHash)
+	}
+	addr := n.scriptAddress(tx.TxOut[op.Vout].PkScript)
+	if addr == "" {
+		return nil, 0, fmt.Errorf("unable to decode address of output %s", op)
+	}
+	spender := func(tx *dexzec.Tx) (uint32, bool) {
+		for vin, txIn := range tx.TxIn {
+			if txIn.PreviousOutPoint.Hash == op.TxHash && txIn.PreviousOutPoint.Index == op.Vout {
+				return uint32(vin), true
+			}
+		}
+		return 0, false
+	}
+	var candidates []*lightRawTx
+	if rawTx.height > 0 {
+		if candidates, err = n.cl.addressTxs(ctx, addr, rawTx.height, n.tipHeight()); err != nil {
+			return nil, 0, err
+		}
+	}
+	for _, c := range candidates {
+		spendTx, err := dexzec.DeserializeTx(c.data)
+		if err != nil {
+			return nil, 0, err
+		}
+		if vin, found := spender(spendTx); found {
+			return spendTx.MsgTx, vin, nil
+		}
+	}
+	mempool, err := n.cl.mempoolTxIDs(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range mempool {
+		c, err := n.cl.transaction(ctx, &mempool[i])
+		if err != nil {
+			if errors.Is(err, errLightTxNotFound) {
+				continue // mined or evicted since listed
+			}
+			return nil, 0, err
+		}
+		spendTx, err := dexzec.DeserializeTx(c.data)
+		if err != nil {
+			return nil, 0, err
+		}
+		if vin, found := spender(spendTx); found {
+			return spendTx.MsgTx, vin, nil
+		}
+	}
+	return nil, 0, nil
+}
+
+// medianTime calculates the median time of the last lightMedianTimeBlocks
+// mainchain blocks.
+func (n *lightNode) medianTime(ctx context.Context) (time.Time, error) {
+	tip, err := n.bestBlock(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	stamps := make([]int64, 0, lightMedianTimeBlocks)
+	for i := uint64(0); i < lightMedianTimeBlocks && i < tip.height; i++ {
+		blk, err := n.blockAt(ctx, tip.height-i)
+		if err != nil {
+			return time.Time{}, err
+		}
+		stamps = append(stamps, int64(blk.time))
+	}
+	if len(stamps) == 0 {
+		return time.Unix(int64(tip.time), 0), nil
+	}
+	sort.Slice(stamps, func(i, j int) bool { return stamps[i] < stamps[j] })
+	return time.Unix(stamps[len(stamps)/2], 0), nil
+}
+
+// lightWallet is a zecWallet backed by a lightNode. Only the transparent
+// pool is supported. Orchard support would require note scanning with trial
+// decryption and Halo 2 proving, neither of which are available in Go, so the
+// shielded-first behavior of the zcashd wallet does not apply: there is no
+// shielded balance, funding uses transparent outputs only, and shielded
+// addresses are rejected with errLightShielded.
+type lightWallet struct {
+	*zecWallet
+	node *lightNode
+}
+
+var _ asset.Wallet = (*lightWallet)(nil)
+var _ asset.Authenticator = (*lightWallet)(nil)
+
+func newLightWallet(cfg *asset.WalletConfig, walletCfg *WalletConfig, logger dex.Logger, net dex.Network,
+	btcParams *chaincfg.Params, addrParams *dexzec.AddressParams) (*lightWallet, error) {
+
+	var lwCfg lightwalletdConfig
+	if err := config.Unmapify(cfg.Settings, &lwCfg); err != nil {
+		return nil, fmt.Errorf("error reading settings: %w", err)
+	}
+	dir := lightWalletDir(cfg.DataDir, btcParams)
+	ks, err := loadLightKeystore(dir, btcParams, addrParams)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := newLightwalletdClient(&lwCfg)
+	if err != nil {
+		return nil, err
+	}
+	node := newLightNode(cl, ks, net, btcParams, addrParams, logger)
+	zw, err := newZecWallet(cfg, walletCfg, dir, node, logger, net, btcParams, addrParams)
+	if err != nil {
+		cl.close()
+		return nil, err
+	}
+	return &lightWallet{
+		zecWallet: zw,
+		node:      node,
+	}, nil
+}
+
+// Connect syncs with lightwalletd before connecting the zecWallet.
+func (w *lightWallet) Connect(ctx context.Context) (*sync.WaitGroup, error) {
+	if err := w.node.connect(ctx); err != nil {
+		w.node.cl.close()
+		return nil, err
+	}
+	wg, err := w.zecWallet.Connect(ctx)
+	if err != nil {
+		w.node.cl.close()
+		return nil, err
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		w.node.ks.lock()
+		if err := w.node.cl.close(); err != nil {
+			w.log.Errorf("Error closing lightwalletd connection: %v", err)
+		}
+	}()
+	return wg, nil
+}
+
+// Unlock unlocks the wallet. Part of the asset.Authenticator interface.
+func (w *lightWallet) Unlock(pw []byte) error {
+	return w.node.ks.unlock(pw, w.net)
+}
+
+// Lock locks the wallet. Part of the asset.Authenticator interface.
+func (w *lightWallet) Lock() error {
+	w.node.ks.lock()
+	return nil
+}
+
+// Locked will be true if the wallet is currently locked. Part of the
+// asset.Authenticator interface.
+func (w *lightWallet) Locked() bool {
+	return w.node.ks.locked()
+}
+
+// Balance is the zecWallet balance without the shielded category, which is
+// always empty for the transparent-only light wallet.
+func (w *lightWallet) Balance() (*asset.Balance, error) {
+	bal, err := w.zecWallet.Balance()
+	if err != nil {
+		return nil, err
+	}
+	delete(bal.Other, asset.BalanceCategoryShielded)
+	return bal, nil
+}
+
+// DepositAddress returns a new transparent address.
+func (w *lightWallet) DepositAddress() (string, error) {
+	return transparentAddressString(w)
+}
+
+// NewAddress returns a new transparent address.
+func (w *lightWallet) NewAddress() (string, error) {
+	return w.DepositAddress()
+}
+
+// Send sends the value to a transparent address.
+func (w *lightWallet) Send(addr string, value, feeRate uint64) (asset.Coin, error) {
+	txHash, _, sent, err := w.send(addr, value, false)
+	if err != nil {
+		return nil, err
+	}
+
+	selfSend, err := w.OwnsDepositAddress(addr)
+	if err != nil {
+		w.log.Errorf("error checking if address %q is owned: %v", addr, err)
+	}
+	txType := asset.Send
+	if selfSend {
+		txType = asset.SelfSend
+	}
+
+	tx, err := getTransaction(w, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find tx after send %s: %v", txHash, err)
+	}
+
+	w.addTxToHistory(&asset.WalletTransaction{
+		Type:      txType,
+		ID:        txHash.String(),
+		Amount:    sent,
+		Fees:      tx.RequiredTxFeesZIP317(),
+		Recipient: &addr,
+	}, txHash, true)
+
+	return &txCoin{
+		txHash: txHash,
+		v:      sent,
+	}, nil
+}
+
+// FindRedemption watches the contract address for the transaction spending
+// the swap output. lightwalletd doesn't provide full blocks, so the
+// RedemptionFinder can't be used.
+func (w *lightWallet) FindRedemption(ctx context.Context, coinID, contract dex.Bytes) (redemptionCoin, secret dex.Bytes, err error) {
+	txHash, vout, err := decodeCoinID(coinID)
+	if err != nil {
+		return nil, nil, err
+	}
+	contractHash := btcutil.Hash160(contract)
+	op := btc.NewOutPoint(txHash, vout)
+
+	ticker := time.NewTicker(lightRedemptionTicker)
+	defer ticker.Stop()
+	for {
+		msgTx, vin, err := w.node.findOutputSpender(ctx, op)
+		if err != nil {
+			w.log.Errorf("Error searching for redemption of %s: %v", op, err)
+		} else if msgTx != nil {
+			txIn := msgTx.TxIn[vin]
+			secret, err := dexbtc.FindKeyPush(txIn.Witness, txIn.SignatureScript, contractHash, false, w.btcParams)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to extract secret key from tx %s input %d: %w",
+					hashTx(msgTx), vin, err)
+			}
+			return btc.ToCoinID(hashTx(msgTx), vin), secret, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("context cancelled during search for redemption for %s", op)
+		}
+	}
+}
+
+// LockTimeExpired checks the lock time against the median time of recent
+// blocks.
+func (w *lightWallet) LockTimeExpired(ctx context.Context, lockTime time.Time) (bool, error) {
+	medianTime, err := w.node.medianTime(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error getting median time: %w", err)
+	}
+	return medianTime.After(lockTime), nil
+}
+
+// ContractLockTimeExpired returns true if the specified contract's locktime
+// has expired, making it possible to issue a Refund.
+func (w *lightWallet) ContractLockTimeExpired(ctx context.Context, contract dex.Bytes) (bool, time.Time, error) {
+	_, _, locktime, _, err := dexbtc.ExtractSwapDetails(contract, false /* segwit */, w.btcParams)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("error extracting contract locktime: %w", err)
+	}
+	contractExpiry := time.Unix(int64(locktime), 0).UTC()
+	expired, err := w.LockTimeExpired(ctx, contractExpiry)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	return expired, contractExpiry, nil
+}
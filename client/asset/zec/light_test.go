@@ -0,0 +1,590 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package zec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/asset/btc"
+	"decred.org/dcrdex/client/asset/zec/walletrpc"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	dexzec "decred.org/dcrdex/dex/networks/zec"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type tLightwalletd struct {
+	mtx     sync.Mutex
+	blocks  []*lightBlock
+	txs     map[chainhash.Hash]*lightRawTx
+	utxos   []*lightUTXO
+	sent    [][]byte
+	mempool []chainhash.Hash
+	sendErr error
+}
+
+var _ lightwalletdClient = (*tLightwalletd)(nil)
+
+func newTLightwalletd() *tLightwalletd {
+	c := &tLightwalletd{txs: make(map[chainhash.Hash]*lightRawTx)}
+	c.mine(nil)
+	return c
+}
+
+// mine adds a block with the transactions.
+func (c *tLightwalletd) mine(txs []*dexzec.Tx) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	blk := &lightBlock{height: uint64(len(c.blocks)), time: uint32(1e6 + len(c.blocks)*75)}
+	copy(blk.hash[:], encode.RandomBytes(32))
+	if len(c.blocks) > 0 {
+		blk.prevHash = c.blocks[len(c.blocks)-1].hash
+	}
+	c.blocks = append(c.blocks, blk)
+	for _, tx := range txs {
+		b, _ := tx.Bytes()
+		c.txs[tx.TxHash()] = &lightRawTx{data: b, height: blk.height}
+	}
+}
+
+func (c *tLightwalletd) latestBlock(ctx context.Context) (*lightBlockID, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	blk := c.blocks[len(c.blocks)-1]
+	return &lightBlockID{height: blk.height, hash: blk.hash}, nil
+}
+
+func (c *tLightwalletd) block(ctx context.Context, height uint64) (*lightBlock, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if height >= uint64(len(c.blocks)) {
+		return nil, errors.New("no block")
+	}
+	return c.blocks[height], nil
+}
+
+func (c *tLightwalletd) transaction(ctx context.Context, txHash *chainhash.Hash) (*lightRawTx, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	tx, found := c.txs[*txHash]
+	if !found {
+		return nil, errLightTxNotFound
+	}
+	return tx, nil
+}
+
+func (c *tLightwalletd) sendTransaction(ctx context.Context, rawTx []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.sendErr != nil {
+		return c.sendErr
+	}
+	tx, err := dexzec.DeserializeTx(rawTx)
+	if err != nil {
+		return err
+	}
+	c.sent = append(c.sent, rawTx)
+	c.txs[tx.TxHash()] = &lightRawTx{data: rawTx}
+	return nil
+}
+
+func (c *tLightwalletd) addressTxs(ctx context.Context, addr string, start, end uint64) (txs []*lightRawTx, _ error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for _, rawTx := range c.txs {
+		if rawTx.height < start || rawTx.height > end || rawTx.height == 0 {
+			continue
+		}
+		tx, _ := dexzec.DeserializeTx(rawTx.data)
+		for _, txOut := range tx.TxOut {
+			if tScriptAddress(txOut.PkScript) == addr {
+				txs = append(txs, rawTx)
+				break
+			}
+		}
+	}
+	return txs, nil
+}
+
+func (c *tLightwalletd) addressUTXOs(ctx context.Context, addrs []string) (utxos []*lightUTXO, _ error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for _, u := range c.utxos {
+		for _, addr := range addrs {
+			if u.address == addr {
+				utxos = append(utxos, u)
+			}
+		}
+	}
+	return utxos, nil
+}
+
+func (c *tLightwalletd) info(ctx context.Context) (*lightdInfo, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	tip := uint64(len(c.blocks) - 1)
+	return &lightdInfo{chainName: "regtest", blockHeight: tip, estimatedHeight: tip}, nil
+}
+
+func (c *tLightwalletd) mempoolTxIDs(ctx context.Context) ([]chainhash.Hash, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.mempool, nil
+}
+
+func (c *tLightwalletd) close() error { return nil }
+
+func tScriptAddress(pkScript []byte) string {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, dexzec.RegressionNetParams)
+	if err != nil || len(addrs) != 1 {
+		return ""
+	}
+	addr, _ := dexzec.EncodeAddress(addrs[0], dexzec.RegressionNetAddressParams)
+	return addr
+}
+
+func tNewLightNode(t *testing.T) (*lightNode, *tLightwalletd) {
+	t.Helper()
+	dir := t.TempDir()
+	seed := encode.RandomBytes(64)
+	pw := []byte("abc")
+	if err := createLightKeystore(dir, seed, pw, 0, dex.Regtest, dexzec.RegressionNetParams); err != nil {
+		t.Fatalf("createLightKeystore error: %v", err)
+	}
+	ks, err := loadLightKeystore(dir, dexzec.RegressionNetParams, dexzec.RegressionNetAddressParams)
+	if err != nil {
+		t.Fatalf("loadLightKeystore error: %v", err)
+	}
+	if err := ks.unlock(pw, dex.Regtest); err != nil {
+		t.Fatalf("unlock error: %v", err)
+	}
+	cl := newTLightwalletd()
+	n := newLightNode(cl, ks, dex.Regtest, dexzec.RegressionNetParams, dexzec.RegressionNetAddressParams, tLogger)
+	return n, cl
+}
+
+func TestLightKeystore(t *testing.T) {
+	dir := t.TempDir()
+	seed := encode.RandomBytes(64)
+	pw := []byte("abc")
+	if err := createLightKeystore(dir, seed, pw, 0, dex.Regtest, dexzec.RegressionNetParams); err != nil {
+		t.Fatalf("createLightKeystore error: %v", err)
+	}
+	if exists, err := lightWalletExists(dir); err != nil || !exists {
+		t.Fatalf("wallet doesn't exist after creation. err = %v", err)
+	}
+
+	ks, err := loadLightKeystore(dir, dexzec.RegressionNetParams, dexzec.RegressionNetAddressParams)
+	if err != nil {
+		t.Fatalf("loadLightKeystore error: %v", err)
+	}
+	if !ks.locked() {
+		t.Fatalf("keystore not locked after load")
+	}
+
+	// Addresses can be derived while locked, but keys cannot.
+	addr, err := ks.address(5)
+	if err != nil {
+		t.Fatalf("address error: %v", err)
+	}
+	if _, err := ks.privKey(5); err == nil {
+		t.Fatalf("no error for privKey while locked")
+	}
+
+	if err := ks.unlock([]byte("wrong"), dex.Regtest); err == nil {
+		t.Fatalf("no error for wrong password")
+	}
+	if err := ks.unlock(pw, dex.Regtest); err != nil {
+		t.Fatalf("unlock error: %v", err)
+	}
+	key, err := ks.privKey(5)
+	if err != nil {
+		t.Fatalf("privKey error: %v", err)
+	}
+	pkh, _ := btcutil.NewAddressPubKeyHash(btcutil.Hash160(key.PubKey().SerializeCompressed()), dexzec.RegressionNetParams)
+	if keyAddr, _ := dexzec.EncodeAddress(pkh, dexzec.RegressionNetAddressParams); keyAddr != addr {
+		t.Fatalf("wrong address for key. %s != %s", keyAddr, addr)
+	}
+
+	// The same seed derives the same keys.
+	dir2 := t.TempDir()
+	if err := createLightKeystore(dir2, seed, []byte("def"), 0, dex.Regtest, dexzec.RegressionNetParams); err != nil {
+		t.Fatalf("createLightKeystore error: %v", err)
+	}
+	ks2, _ := loadLightKeystore(dir2, dexzec.RegressionNetParams, dexzec.RegressionNetAddressParams)
+	if addr2, _ := ks2.address(5); addr2 != addr {
+		t.Fatalf("different address for same seed")
+	}
+
+	ks.lock()
+	if !ks.locked() {
+		t.Fatalf("not locked after lock")
+	}
+}
+
+func TestLightNode(t *testing.T) {
+	n, cl := tNewLightNode(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	call := func(method string, args []any, thing any) error {
+		return btc.Call(ctx, n, method, args, thing)
+	}
+
+	// A used address is discovered on connect.
+	addr0, _ := n.ks.address(0)
+	pkh, _ := dexzec.DecodeAddress(addr0, dexzec.RegressionNetAddressParams, dexzec.RegressionNetParams)
+	p2pkh, _ := txscript.PayToAddrScript(pkh)
+	fundingTx := makeRawTx([]dex.Bytes{p2pkh}, []*wire.TxIn{dummyInput()})
+	fundingTx.TxOut[0].Value = 1e8
+	cl.mine([]*dexzec.Tx{fundingTx})
+	fundingHash := fundingTx.TxHash()
+	cl.utxos = []*lightUTXO{{address: addr0, txHash: fundingHash, script: p2pkh, value: 1e8, height: 1}}
+	cl.mine(nil)
+
+	if err := n.connect(ctx); err != nil {
+		t.Fatalf("connect error: %v", err)
+	}
+	if n.ks.file.NextIndex != 1 {
+		t.Fatalf("wrong next index after discovery. wanted 1, got %d", n.ks.file.NextIndex)
+	}
+
+	unspents, err := listUnspent(rpcCallerFunc(call))
+	if err != nil {
+		t.Fatalf("listUnspent error: %v", err)
+	}
+	if len(unspents) != 1 || unspents[0].Confirmations != 2 || toZats(unspents[0].Amount) != 1e8 {
+		t.Fatalf("wrong unspents %+v", unspents)
+	}
+
+	bal, err := zGetBalanceForAccount(rpcCallerFunc(call), shieldedAcctNumber, 1)
+	if err != nil {
+		t.Fatalf("zGetBalanceForAccount error: %v", err)
+	}
+	if bal.Transparent != 1e8 || bal.Orchard != 0 {
+		t.Fatalf("wrong balance %+v", bal)
+	}
+
+	// Locked outputs are excluded from listunspent and the account balance,
+	// but not getbalance.
+	op := btc.NewOutput(&fundingHash, 0, 1e8)
+	if err := lockUnspent(rpcCallerFunc(call), false, []*btc.Output{op}); err != nil {
+		t.Fatalf("lockUnspent error: %v", err)
+	}
+	if unspents, _ = listUnspent(rpcCallerFunc(call)); len(unspents) != 0 {
+		t.Fatalf("locked output listed")
+	}
+	if fullBal, _ := getBalance(rpcCallerFunc(call)); fullBal != 1e8 {
+		t.Fatalf("wrong full balance %d", fullBal)
+	}
+	if err := lockUnspent(rpcCallerFunc(call), true, nil); err != nil {
+		t.Fatalf("lockUnspent error: %v", err)
+	}
+
+	// New addresses are transparent.
+	addr1, err := transparentAddressString(rpcCallerFunc(call))
+	if err != nil {
+		t.Fatalf("transparentAddressString error: %v", err)
+	}
+	if addr1 == addr0 {
+		t.Fatalf("address reused")
+	}
+	res, err := zValidateAddress(rpcCallerFunc(call), addr1)
+	if err != nil || !res.IsMine {
+		t.Fatalf("new address not ours. err = %v", err)
+	}
+	if _, err := zGetAddressForAccount(rpcCallerFunc(call), shieldedAcctNumber, []string{orchardAddressType}); !errors.Is(err, errLightShielded) {
+		t.Fatalf("wrong error for orchard address: %v", err)
+	}
+
+	// Sign and send a tx spending the funding output.
+	recipient := encode.RandomBytes(20)
+	recipScript, _ := txscript.NewScriptBuilder().AddOp(txscript.OP_DUP).AddOp(txscript.OP_HASH160).
+		AddData(recipient).AddOp(txscript.OP_EQUALVERIFY).AddOp(txscript.OP_CHECKSIG).Script()
+	addr1PKH, _ := dexzec.DecodeAddress(addr1, dexzec.RegressionNetAddressParams, dexzec.RegressionNetParams)
+	changeScript, _ := txscript.PayToAddrScript(addr1PKH)
+	spendTx := makeRawTx([]dex.Bytes{recipScript, changeScript},
+		[]*wire.TxIn{wire.NewTxIn(wire.NewOutPoint(&fundingHash, 0), nil, nil)})
+	spendTx.TxOut[0].Value = 5e7
+	spendTx.TxOut[1].Value = 5e7 - 1e4
+	signedTx, err := signTxByRPC(rpcCallerFunc(call), spendTx)
+	if err != nil {
+		t.Fatalf("signTxByRPC error: %v", err)
+	}
+
+	// Check the signature.
+	pushes, err := txscript.PushedData(signedTx.TxIn[0].SignatureScript)
+	if err != nil || len(pushes) != 2 {
+		t.Fatalf("bad sig script. err = %v", err)
+	}
+	pubKey, _ := btcec.ParsePubKey(pushes[1])
+	sig, err := ecdsa.ParseDERSignature(pushes[0][:len(pushes[0])-1])
+	if err != nil {
+		t.Fatalf("error parsing signature: %v", err)
+	}
+	sigHash, _ := signedTx.SignatureDigest(0, txscript.SigHashAll, p2pkh, []int64{1e8}, [][]byte{p2pkh})
+	if !sig.Verify(sigHash[:], pubKey) {
+		t.Fatalf("invalid signature")
+	}
+
+	txHash, err := sendRawTransaction(rpcCallerFunc(call), signedTx)
+	if err != nil {
+		t.Fatalf("sendRawTransaction error: %v", err)
+	}
+	if len(cl.sent) != 1 {
+		t.Fatalf("tx not sent")
+	}
+
+	// The spent output is gone and the change output is available.
+	if txOut, _, err := getTxOut(rpcCallerFunc(call), &fundingHash, 0); err != nil || txOut != nil {
+		t.Fatalf("spent output still returned from gettxout. err = %v", err)
+	}
+	unspents, _ = listUnspent(rpcCallerFunc(call))
+	if len(unspents) != 1 || unspents[0].TxID != txHash.String() || unspents[0].Vout != 1 || unspents[0].Confirmations != 0 {
+		t.Fatalf("wrong unspents after send %+v", unspents)
+	}
+
+	// The send is listed in the history.
+	var lsb listSinceBlockRes
+	if err := call("listsinceblock", []any{(&chainhash.Hash{}).String()}, &lsb); err != nil {
+		t.Fatalf("listsinceblock error: %v", err)
+	}
+	var foundSend, foundReceive bool
+	for _, tx := range lsb.Transactions {
+		switch tx.TxID {
+		case txHash.String():
+			foundSend = tx.Category == sendCategory
+		case fundingHash.String():
+			foundReceive = tx.Category == "receive" && tx.BlockIndex != nil && *tx.BlockIndex == 1
+		}
+	}
+	if !foundSend || !foundReceive {
+		t.Fatalf("wrong history %+v", lsb.Transactions)
+	}
+
+	// Mine the tx. The pending tx is cleared.
+	cl.utxos = []*lightUTXO{{address: addr1, txHash: *txHash, vout: 1, script: changeScript, value: 5e7 - 1e4, height: 3}}
+	cl.mine([]*dexzec.Tx{signedTx})
+	unspents, _ = listUnspent(rpcCallerFunc(call))
+	if len(unspents) != 1 || unspents[0].Confirmations != 1 {
+		t.Fatalf("wrong unspents after mining %+v", unspents)
+	}
+	if len(n.pending) != 0 {
+		t.Fatalf("pending tx not cleared")
+	}
+	wt, err := getWalletTransaction(rpcCallerFunc(call), txHash)
+	if err != nil {
+		t.Fatalf("getWalletTransaction error: %v", err)
+	}
+	if wt.Confirmations != 1 || wt.BlockHash != cl.blocks[3].hash.String() {
+		t.Fatalf("wrong wallet tx %+v", wt)
+	}
+
+	// Unknown transactions are reported as such.
+	if _, err := getWalletTransaction(rpcCallerFunc(call), &chainhash.Hash{0x01}); !errors.Is(err, asset.CoinNotFoundError) {
+		t.Fatalf("wrong error for unknown tx: %v", err)
+	}
+
+	// Verbose block headers are available for known blocks.
+	hdr, mainchain, err := getVerboseBlockHeader(rpcCallerFunc(call), &cl.blocks[3].hash)
+	if err != nil || !mainchain || hdr.Height != 3 {
+		t.Fatalf("wrong header. err = %v, mainchain = %t, hdr = %+v", err, mainchain, hdr)
+	}
+
+	// Shielded sends are not supported.
+	if _, err := zSendMany(rpcCallerFunc(call), addr0, singleSendManyRecipient(addr0, 1), NoPrivacy); !errors.Is(err, errLightShielded) {
+		t.Fatalf("wrong error for z_sendmany: %v", err)
+	}
+}
+
+func TestLightFindOutputSpender(t *testing.T) {
+	n, cl := tNewLightNode(t)
+	ctx := context.Background()
+
+	_, _, pkScript, contract, _, _, _ := makeSwapContract(-1)
+	swapTx := makeRawTx([]dex.Bytes{pkScript}, []*wire.TxIn{dummyInput()})
+	cl.mine([]*dexzec.Tx{swapTx})
+	swapHash := swapTx.TxHash()
+	op := btc.NewOutPoint(&swapHash, 0)
+	if err := n.connect(ctx); err != nil {
+		t.Fatalf("connect error: %v", err)
+	}
+
+	// Not yet spent.
+	spender, _, err := n.findOutputSpender(ctx, op)
+	if err != nil || spender != nil {
+		t.Fatalf("unexpected spender. err = %v", err)
+	}
+
+	// Spent in mempool.
+	sigScript, _ := txscript.NewScriptBuilder().AddData(encode.RandomBytes(72)).AddData(encode.RandomBytes(33)).
+		AddData(encode.RandomBytes(32)).AddInt64(1).AddData(contract).Script()
+	redeemTx := makeRawTx([]dex.Bytes{encode.RandomBytes(25)}, []*wire.TxIn{dummyInput(), wire.NewTxIn(&wire.OutPoint{Hash: swapHash}, sigScript, nil)})
+	redeemB, _ := redeemTx.Bytes()
+	redeemHash := redeemTx.TxHash()
+	cl.txs[redeemHash] = &lightRawTx{data: redeemB}
+	cl.mempool = []chainhash.Hash{redeemHash}
+	spender, vin, err := n.findOutputSpender(ctx, op)
+	if err != nil || spender == nil || vin != 1 {
+		t.Fatalf("mempool spender not found. err = %v", err)
+	}
+
+	// Spent in a block.
+	cl.mempool = nil
+	cl.mine([]*dexzec.Tx{redeemTx})
+	// The fake only indexes by output address, so make the redemption pay to
+	// the contract address.
+	redeemTx.TxOut[0].PkScript = pkScript
+	cl.mine([]*dexzec.Tx{redeemTx})
+	n.bestBlock(ctx)
+	spender, vin, err = n.findOutputSpender(ctx, op)
+	if err != nil || spender == nil || vin != 1 {
+		t.Fatalf("mined spender not found. err = %v", err)
+	}
+}
+
+// tLightwalletdServer is a walletrpc.CompactTxStreamerServer for testing the
+// gRPC lightwalletd client.
+type tLightwalletdServer struct {
+	walletrpc.UnimplementedCompactTxStreamerServer
+	tip     *walletrpc.BlockID
+	txs     []*walletrpc.RawTransaction
+	utxos   []*walletrpc.GetAddressUtxosReply
+	mempool [][]byte
+	sendErr *walletrpc.SendResponse
+}
+
+func (s *tLightwalletdServer) GetLatestBlock(context.Context, *walletrpc.ChainSpec) (*walletrpc.BlockID, error) {
+	return s.tip, nil
+}
+
+func (s *tLightwalletdServer) GetTransaction(context.Context, *walletrpc.TxFilter) (*walletrpc.RawTransaction, error) {
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
+func (s *tLightwalletdServer) SendTransaction(context.Context, *walletrpc.RawTransaction) (*walletrpc.SendResponse, error) {
+	return s.sendErr, nil
+}
+
+func (s *tLightwalletdServer) GetTaddressTxids(f *walletrpc.TransparentAddressBlockFilter, stream grpc.ServerStreamingServer[walletrpc.RawTransaction]) error {
+	for _, tx := range s.txs {
+		if tx.Height >= f.Range.Start.Height && tx.Height <= f.Range.End.Height || tx.Height == math.MaxUint64 {
+			if err := stream.Send(tx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *tLightwalletdServer) GetAddressUtxos(context.Context, *walletrpc.GetAddressUtxosArg) (*walletrpc.GetAddressUtxosReplyList, error) {
+	return &walletrpc.GetAddressUtxosReplyList{AddressUtxos: s.utxos}, nil
+}
+
+func (s *tLightwalletdServer) GetMempoolTx(_ *walletrpc.Exclude, stream grpc.ServerStreamingServer[walletrpc.CompactTx]) error {
+	for _, h := range s.mempool {
+		if err := stream.Send(&walletrpc.CompactTx{Hash: h}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestLightwalletdClient(t *testing.T) {
+	srv := &tLightwalletdServer{
+		tip: &walletrpc.BlockID{Height: 100, Hash: encode.RandomBytes(32)},
+		txs: []*walletrpc.RawTransaction{
+			{Data: []byte{1}, Height: 10},
+			{Data: []byte{2}, Height: 50},
+			// Mempool heights reported as -1 are converted to zero.
+			{Data: []byte{3}, Height: math.MaxUint64},
+		},
+		utxos: []*walletrpc.GetAddressUtxosReply{
+			{Txid: encode.RandomBytes(32), Index: 1, Script: encode.RandomBytes(25), ValueZat: 1e8, Height: 90, Address: tAddr},
+		},
+		mempool: [][]byte{encode.RandomBytes(32), encode.RandomBytes(32)},
+		sendErr: &walletrpc.SendResponse{ErrorCode: -26, ErrorMessage: "bad-txns"},
+	}
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	walletrpc.RegisterCompactTxStreamerServer(s, srv)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	cl := &grpcLightwalletdClient{conn: conn, rpc: walletrpc.NewCompactTxStreamerClient(conn)}
+	defer cl.close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tip, err := cl.latestBlock(ctx)
+	if err != nil {
+		t.Fatalf("latestBlock error: %v", err)
+	}
+	if tip.height != 100 || !bytes.Equal(tip.hash[:], srv.tip.Hash) {
+		t.Fatalf("wrong tip %+v", tip)
+	}
+
+	txs, err := cl.addressTxs(ctx, tAddr, 20, 100)
+	if err != nil {
+		t.Fatalf("addressTxs error: %v", err)
+	}
+	if len(txs) != 2 || txs[0].height != 50 || txs[1].height != 0 || !bytes.Equal(txs[1].data, []byte{3}) {
+		t.Fatalf("wrong address txs %+v", txs)
+	}
+
+	utxos, err := cl.addressUTXOs(ctx, []string{tAddr})
+	if err != nil {
+		t.Fatalf("addressUTXOs error: %v", err)
+	}
+	if len(utxos) != 1 || utxos[0].value != 1e8 || utxos[0].vout != 1 || !bytes.Equal(utxos[0].txHash[:], srv.utxos[0].Txid) {
+		t.Fatalf("wrong utxos %+v", utxos)
+	}
+
+	txIDs, err := cl.mempoolTxIDs(ctx)
+	if err != nil {
+		t.Fatalf("mempoolTxIDs error: %v", err)
+	}
+	if len(txIDs) != 2 || !bytes.Equal(txIDs[1][:], srv.mempool[1]) {
+		t.Fatalf("wrong mempool tx IDs %v", txIDs)
+	}
+
+	if _, err := cl.transaction(ctx, &chainhash.Hash{}); !errors.Is(err, errLightTxNotFound) {
+		t.Fatalf("expected errLightTxNotFound, got %v", err)
+	}
+
+	if err := cl.sendTransaction(ctx, []byte{1}); err == nil || !strings.Contains(err.Error(), "bad-txns") {
+		t.Fatalf("expected SendTransaction error, got %v", err)
+	}
+}
+
+// rpcCallerFunc is an rpcCaller from a function.
+type rpcCallerFunc func(method string, args []any, thing any) error
+
+func (f rpcCallerFunc) CallRPC(method string, args []any, thing any) error {
+	return f(method, args, thing)
+}
@@ -0,0 +1,312 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package zec
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"decred.org/dcrdex/client/asset/zec/walletrpc"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// Only the transparent-related methods of the lightwalletd CompactTxStreamer
+// service are used. The generated client is in the walletrpc package.
+const (
+	lightwalletdMaxUTXOs         = 0 // no limit
+	lightwalletdMaxRecvMsgSizeMB = 32
+)
+
+// errLightTxNotFound is returned from lightwalletdClient.transaction when
+// the backing node has no record of the transaction.
+var errLightTxNotFound = errors.New("transaction not found")
+
+// lightwalletdClient is the subset of the lightwalletd API needed to back a
+// transparent light wallet. All hashes are in internal (chainhash) byte order.
+type lightwalletdClient interface {
+	latestBlock(ctx context.Context) (*lightBlockID, error)
+	block(ctx context.Context, height uint64) (*lightBlock, error)
+	transaction(ctx context.Context, txHash *chainhash.Hash) (*lightRawTx, error)
+	sendTransaction(ctx context.Context, rawTx []byte) error
+	addressTxs(ctx context.Context, addr string, start, end uint64) ([]*lightRawTx, error)
+	addressUTXOs(ctx context.Context, addrs []string) ([]*lightUTXO, error)
+	info(ctx context.Context) (*lightdInfo, error)
+	mempoolTxIDs(ctx context.Context) ([]chainhash.Hash, error)
+	close() error
+}
+
+type lightBlockID struct {
+	height uint64
+	hash   chainhash.Hash
+}
+
+// lightBlock is the header data from a CompactBlock. The shielded
+// transaction data is ignored.
+type lightBlock struct {
+	height   uint64
+	hash     chainhash.Hash
+	prevHash chainhash.Hash
+	time     uint32
+}
+
+type lightRawTx struct {
+	data []byte
+	// height is zero for mempool transactions.
+	height uint64
+}
+
+type lightUTXO struct {
+	address string
+	txHash  chainhash.Hash
+	vout    uint32
+	script  []byte
+	value   uint64
+	height  uint64
+}
+
+type lightdInfo struct {
+	chainName       string
+	blockHeight     uint64
+	estimatedHeight uint64
+	zcashdBuild     string
+}
+
+func hashFromBytes(b []byte) (h chainhash.Hash, err error) {
+	if len(b) != chainhash.HashSize {
+		return h, fmt.Errorf("invalid hash length %d", len(b))
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+// newLightRawTx converts the RawTransaction.
+func newLightRawTx(m *walletrpc.RawTransaction) *lightRawTx {
+	height := m.Height
+	// lightwalletd reports mempool transactions with height 0, or with
+	// height -1 cast to a uint64 by older versions.
+	if height == math.MaxUint64 {
+		height = 0
+	}
+	return &lightRawTx{data: m.Data, height: height}
+}
+
+// lightwalletdConfig is the connection configuration for a lightwalletd
+// server.
+type lightwalletdConfig struct {
+	// Address is host:port of the lightwalletd gRPC server.
+	Address string `ini:"lightwalletd"`
+	// Insecure disables TLS. Only use with a local server, e.g. for
+	// development or the simnet harness.
+	Insecure bool `ini:"lightwalletdinsecure"`
+	// TLSCert is an optional path to a PEM certificate for a lightwalletd
+	// server with a self-signed certificate. The system roots are used if not
+	// set.
+	TLSCert string `ini:"lightwalletdcert"`
+}
+
+// grpcLightwalletdClient is a lightwalletdClient backed by a gRPC connection.
+type grpcLightwalletdClient struct {
+	conn *grpc.ClientConn
+	rpc  walletrpc.CompactTxStreamerClient
+}
+
+var _ lightwalletdClient = (*grpcLightwalletdClient)(nil)
+
+func newLightwalletdClient(cfg *lightwalletdConfig) (*grpcLightwalletdClient, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("no lightwalletd address specified")
+	}
+	var creds credentials.TransportCredentials
+	switch {
+	case cfg.Insecure:
+		creds = insecure.NewCredentials()
+	case cfg.TLSCert != "":
+		var err error
+		if creds, err = credentials.NewClientTLSFromFile(cfg.TLSCert, ""); err != nil {
+			return nil, fmt.Errorf("error loading lightwalletd TLS certificate: %w", err)
+		}
+	default:
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(cfg.Address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(lightwalletdMaxRecvMsgSizeMB<<20)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating lightwalletd client: %w", err)
+	}
+	return &grpcLightwalletdClient{
+		conn: conn,
+		rpc:  walletrpc.NewCompactTxStreamerClient(conn),
+	}, nil
+}
+
+// recvAll calls f with each message received from the stream until the
+// server closes it.
+func recvAll[T any](s grpc.ServerStreamingClient[T], f func(*T) error) error {
+	for {
+		msg, err := s.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := f(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *grpcLightwalletdClient) latestBlock(ctx context.Context) (*lightBlockID, error) {
+	res, err := c.rpc.GetLatestBlock(ctx, &walletrpc.ChainSpec{})
+	if err != nil {
+		return nil, err
+	}
+	h, err := hashFromBytes(res.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return &lightBlockID{height: res.Height, hash: h}, nil
+}
+
+func (c *grpcLightwalletdClient) block(ctx context.Context, height uint64) (*lightBlock, error) {
+	res, err := c.rpc.GetBlock(ctx, &walletrpc.BlockID{Height: height})
+	if err != nil {
+		return nil, err
+	}
+	h, err := hashFromBytes(res.Hash)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := hashFromBytes(res.PrevHash)
+	if err != nil {
+		return nil, err
+	}
+	return &lightBlock{height: res.Height, hash: h, prevHash: prev, time: res.Time}, nil
+}
+
+func (c *grpcLightwalletdClient) transaction(ctx context.Context, txHash *chainhash.Hash) (*lightRawTx, error) {
+	res, err := c.rpc.GetTransaction(ctx, &walletrpc.TxFilter{Hash: txHash[:]})
+	if err != nil {
+		if isLightTxNotFoundErr(err) {
+			return nil, errLightTxNotFound
+		}
+		return nil, err
+	}
+	return newLightRawTx(res), nil
+}
+
+// isLightTxNotFoundErr checks whether the error from GetTransaction indicates
+// that the transaction is unknown. lightwalletd passes along zcashd's error
+// message, with varying status codes across versions.
+func isLightTxNotFoundErr(err error) bool {
+	if status.Code(err) == codes.NotFound {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "No such mempool or blockchain transaction") ||
+		strings.Contains(msg, "No information available about transaction")
+}
+
+func (c *grpcLightwalletdClient) sendTransaction(ctx context.Context, rawTx []byte) error {
+	res, err := c.rpc.SendTransaction(ctx, &walletrpc.RawTransaction{Data: rawTx})
+	if err != nil {
+		return err
+	}
+	if res.ErrorCode != 0 {
+		return fmt.Errorf("lightwalletd SendTransaction error %d: %s", res.ErrorCode, res.ErrorMessage)
+	}
+	return nil
+}
+
+func (c *grpcLightwalletdClient) addressTxs(ctx context.Context, addr string, start, end uint64) ([]*lightRawTx, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s, err := c.rpc.GetTaddressTxids(ctx, &walletrpc.TransparentAddressBlockFilter{
+		Address: addr,
+		Range: &walletrpc.BlockRange{
+			Start: &walletrpc.BlockID{Height: start},
+			End:   &walletrpc.BlockID{Height: end},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var txs []*lightRawTx
+	err = recvAll(s, func(m *walletrpc.RawTransaction) error {
+		txs = append(txs, newLightRawTx(m))
+		return nil
+	})
+	return txs, err
+}
+
+func (c *grpcLightwalletdClient) addressUTXOs(ctx context.Context, addrs []string) ([]*lightUTXO, error) {
+	res, err := c.rpc.GetAddressUtxos(ctx, &walletrpc.GetAddressUtxosArg{Addresses: addrs, MaxEntries: lightwalletdMaxUTXOs})
+	if err != nil {
+		return nil, err
+	}
+	utxos := make([]*lightUTXO, 0, len(res.AddressUtxos))
+	for _, u := range res.AddressUtxos {
+		h, err := hashFromBytes(u.Txid)
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, &lightUTXO{
+			address: u.Address,
+			txHash:  h,
+			vout:    uint32(u.Index),
+			script:  u.Script,
+			value:   uint64(u.ValueZat),
+			height:  u.Height,
+		})
+	}
+	return utxos, nil
+}
+
+func (c *grpcLightwalletdClient) info(ctx context.Context) (*lightdInfo, error) {
+	res, err := c.rpc.GetLightdInfo(ctx, &walletrpc.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return &lightdInfo{
+		chainName:       res.ChainName,
+		blockHeight:     res.BlockHeight,
+		estimatedHeight: res.EstimatedHeight,
+		zcashdBuild:     res.ZcashdBuild,
+	}, nil
+}
+
+func (c *grpcLightwalletdClient) mempoolTxIDs(ctx context.Context) ([]chainhash.Hash, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s, err := c.rpc.GetMempoolTx(ctx, &walletrpc.Exclude{})
+	if err != nil {
+		return nil, err
+	}
+	var txIDs []chainhash.Hash
+	err = recvAll(s, func(m *walletrpc.CompactTx) error {
+		h, err := hashFromBytes(m.Hash)
+		if err != nil {
+			return err
+		}
+		txIDs = append(txIDs, h)
+		return nil
+	})
+	return txIDs, err
+}
+
+func (c *grpcLightwalletdClient) close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,10 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package walletrpc contains the protocol buffer types and the generated gRPC
+// client for the lightwalletd CompactTxStreamer service. service.proto and
+// compact_formats.proto are copied unmodified from
+// github.com/zcash/lightwalletd v0.4.16.
+package walletrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative,Mcompact_formats.proto=decred.org/dcrdex/client/asset/zec/walletrpc,Mservice.proto=decred.org/dcrdex/client/asset/zec/walletrpc --go-grpc_out=. --go-grpc_opt=paths=source_relative,Mservice.proto=decred.org/dcrdex/client/asset/zec/walletrpc,Mcompact_formats.proto=decred.org/dcrdex/client/asset/zec/walletrpc compact_formats.proto service.proto
@@ -117,15 +117,37 @@ var (
 				"wallet.  Units: BTC/kB",
 			DefaultValue: strconv.FormatFloat(defaultFeeRateLimit*1000/1e8, 'f', -1, 64),
 		},
+		txSplitConfigOpt,
+	}
+	txSplitConfigOpt = &asset.ConfigOption{
+		Key:         "txsplit",
+		DisplayName: "Pre-split funding inputs",
+		Description: "When placing an order, create a \"split\" transaction to fund the order without locking more of the wallet balance than " +
+			"necessary. Otherwise, excess funds may be reserved to fund the order until the first swap contract is broadcast " +
+			"during match settlement, or the order is canceled. This an extra transaction for which network mining fees are paid. " +
+			"Used only for standing-type orders, e.g. limit orders without immediate time-in-force.",
+		IsBoolean: true,
+	}
+	lightConfigOpts = []*asset.ConfigOption{
 		{
-			Key:         "txsplit",
-			DisplayName: "Pre-split funding inputs",
-			Description: "When placing an order, create a \"split\" transaction to fund the order without locking more of the wallet balance than " +
-				"necessary. Otherwise, excess funds may be reserved to fund the order until the first swap contract is broadcast " +
-				"during match settlement, or the order is canceled. This an extra transaction for which network mining fees are paid. " +
-				"Used only for standing-type orders, e.g. limit orders without immediate time-in-force.",
-			IsBoolean: true,
+			Key:         "lightwalletd",
+			DisplayName: "lightwalletd Address",
+			Description: "<host>:<port> of a lightwalletd gRPC server",
+			Required:    true,
 		},
+		{
+			Key:         "lightwalletdcert",
+			DisplayName: "lightwalletd TLS Certificate",
+			Description: "Path to the TLS certificate of a lightwalletd server with a self-signed certificate. " +
+				"The system certificate pool is used if not set.",
+		},
+		{
+			Key:         "lightwalletdinsecure",
+			DisplayName: "Disable TLS",
+			Description: "Connect to lightwalletd without TLS. Only use this with a local server.",
+			IsBoolean:   true,
+		},
+		txSplitConfigOpt,
 	}
 	// WalletInfo defines some general information about a Zcash wallet.
 	WalletInfo = &asset.WalletInfo{
@@ -139,6 +161,12 @@ var (
 			DefaultConfigPath: dexbtc.SystemConfigPath("zcash"),
 			ConfigOpts:        configOpts,
 			NoAuth:            true,
+		}, {
+			Type:        walletTypeLight,
+			Tab:         "Native",
+			Description: "Use the built-in light wallet with a lightwalletd server. Transparent pool only, no Orchard or Sapling.",
+			ConfigOpts:  lightConfigOpts,
+			Seeded:      true,
 		}},
 	}
 
@@ -177,6 +205,44 @@ func (d *Driver) Info() *asset.WalletInfo {
 	return WalletInfo
 }
 
+// Exists checks the existence of the wallet. Part of the Creator interface,
+// so only used for the lightwalletd wallet.
+func (d *Driver) Exists(walletType, dataDir string, settings map[string]string, net dex.Network) (bool, error) {
+	if walletType != walletTypeLight {
+		return false, fmt.Errorf("no Zcash wallet of type %q available", walletType)
+	}
+	btcParams, _, err := netParams(net)
+	if err != nil {
+		return false, err
+	}
+	return lightWalletExists(lightWalletDir(dataDir, btcParams))
+}
+
+// Create creates a new lightwalletd wallet from the seed. Part of the Creator
+// interface.
+func (d *Driver) Create(params *asset.CreateWalletParams) error {
+	if params.Type != walletTypeLight {
+		return fmt.Errorf("lightwalletd is the only seeded wallet type. required = %q, requested = %q", walletTypeLight, params.Type)
+	}
+	if len(params.Seed) == 0 {
+		return errors.New("wallet seed cannot be empty")
+	}
+	if len(params.DataDir) == 0 {
+		return errors.New("must specify wallet data directory")
+	}
+	btcParams, _, err := netParams(params.Net)
+	if err != nil {
+		return err
+	}
+	dir := lightWalletDir(params.DataDir, btcParams)
+	if exists, err := lightWalletExists(dir); err != nil {
+		return err
+	} else if exists {
+		return fmt.Errorf("wallet already exists in %s", dir)
+	}
+	return createLightKeystore(dir, params.Seed, params.Pass, params.Birthday, params.Net, btcParams)
+}
+
 // MinLotSize calculates the minimum bond size for a given fee rate that avoids
 // dust outputs on the swap and refund txs, assuming the maxFeeRate doesn't
 // change.
@@ -206,43 +272,27 @@ func newRPCConnection(cfg *dexbtc.RPCConfig) (*rpcclient.Client, error) {
 // canceled. The configPath can be an empty string, in which case the standard
 // system location of the zcashd config file is assumed.
 func NewWallet(cfg *asset.WalletConfig, logger dex.Logger, net dex.Network) (asset.Wallet, error) {
-	var btcParams *chaincfg.Params
-	var addrParams *dexzec.AddressParams
-	switch net {
-	case dex.Mainnet:
-		btcParams = dexzec.MainNetParams
-		addrParams = dexzec.MainNetAddressParams
-	case dex.Testnet:
-		btcParams = dexzec.TestNet4Params
-		addrParams = dexzec.TestNet4AddressParams
-	case dex.Regtest:
-		btcParams = dexzec.RegressionNetParams
-		addrParams = dexzec.RegressionNetAddressParams
-	default:
-		return nil, fmt.Errorf("unknown network ID %v", net)
-	}
-
-	// Designate the clone ports. These will be overwritten by any explicit
-	// settings in the configuration file.
-	ports := dexbtc.NetPorts{
-		Mainnet: "8232",
-		Testnet: "18232",
-		Simnet:  "18232",
+	btcParams, addrParams, err := netParams(net)
+	if err != nil {
+		return nil, err
 	}
 
 	var walletCfg WalletConfig
-	err := config.Unmapify(cfg.Settings, &walletCfg)
+	err = config.Unmapify(cfg.Settings, &walletCfg)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := os.MkdirAll(cfg.DataDir, 0700); err != nil {
-		return nil, fmt.Errorf("error creating data directory at %q: %w", cfg.DataDir, err)
+	if cfg.Type == walletTypeLight {
+		return newLightWallet(cfg, &walletCfg, logger, net, btcParams, addrParams)
 	}
 
-	ar, err := btc.NewAddressRecycler(filepath.Join(cfg.DataDir, "recycled-addrs.txt"), logger)
-	if err != nil {
-		return nil, fmt.Errorf("error creating address recycler: %w", err)
+	// Designate the clone ports. These will be overwritten by any explicit
+	// settings in the configuration file.
+	ports := dexbtc.NetPorts{
+		Mainnet: "8232",
+		Testnet: "18232",
+		Simnet:  "18232",
 	}
 
 	var rpcCfg dexbtc.RPCConfig
@@ -259,6 +309,36 @@ func NewWallet(cfg *asset.WalletConfig, logger dex.Logger, net dex.Network) (ass
 		return nil, fmt.Errorf("error constructing rpc client: %w", err)
 	}
 
+	return newZecWallet(cfg, &walletCfg, cfg.DataDir, cl, logger, net, btcParams, addrParams)
+}
+
+func netParams(net dex.Network) (*chaincfg.Params, *dexzec.AddressParams, error) {
+	switch net {
+	case dex.Mainnet:
+		return dexzec.MainNetParams, dexzec.MainNetAddressParams, nil
+	case dex.Testnet:
+		return dexzec.TestNet4Params, dexzec.TestNet4AddressParams, nil
+	case dex.Regtest:
+		return dexzec.RegressionNetParams, dexzec.RegressionNetAddressParams, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown network ID %v", net)
+	}
+}
+
+// newZecWallet constructs a zecWallet that uses the node for all chain and
+// wallet data. walletDir is where the wallet's files are stored.
+func newZecWallet(cfg *asset.WalletConfig, walletCfg *WalletConfig, walletDir string, node btc.RawRequester,
+	logger dex.Logger, net dex.Network, btcParams *chaincfg.Params, addrParams *dexzec.AddressParams) (*zecWallet, error) {
+
+	if err := os.MkdirAll(walletDir, 0700); err != nil {
+		return nil, fmt.Errorf("error creating data directory at %q: %w", walletDir, err)
+	}
+
+	ar, err := btc.NewAddressRecycler(filepath.Join(walletDir, "recycled-addrs.txt"), logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating address recycler: %w", err)
+	}
+
 	zw := &zecWallet{
 		peersChange: cfg.PeersChange,
 		emit:        cfg.Emit,
@@ -270,11 +350,11 @@ func NewWallet(cfg *asset.WalletConfig, logger dex.Logger, net dex.Network) (ass
 			return dexzec.DecodeAddress(addr, addrParams, btcParams)
 		},
 		ar:         ar,
-		node:       cl,
-		walletDir:  cfg.DataDir,
+		node:       node,
+		walletDir:  walletDir,
 		pendingTxs: make(map[chainhash.Hash]*btc.ExtendedWalletTx),
 	}
-	zw.walletCfg.Store(&walletCfg)
+	zw.walletCfg.Store(walletCfg)
 	zw.prepareCoinManager()
 	zw.prepareRedemptionFinder()
 	return zw, nil
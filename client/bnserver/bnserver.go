@@ -0,0 +1,452 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package bnserver provides a REST API compatible with a subset of the
+// Binance spot API, so that trading bots written for Binance can trade on a
+// DEX host through the client core. Quantities and prices use the
+// conventional units of the assets, and are checked against the DEX market's
+// lot size and rate step.
+package bnserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/apikey"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	// apiKeyHeader is the request header with the API key.
+	apiKeyHeader = "X-MBX-APIKEY"
+	// defaultRecvWindow is the number of milliseconds after the timestamp of
+	// a signed request that it is valid, if the request does not specify a
+	// recvWindow.
+	defaultRecvWindow = 5000
+	// maxRecvWindow is the largest recvWindow accepted.
+	maxRecvWindow = 60000
+	// maxBodySize is the largest request body accepted.
+	maxBodySize = 1 << 16
+	// timeoutSeconds is the number of seconds a request may take.
+	timeoutSeconds = 30
+)
+
+var (
+	// Check that core.Core satisfies clientCore.
+	_   clientCore = (*core.Core)(nil)
+	log dex.Logger
+)
+
+// clientCore is satisfied by core.Core.
+type clientCore interface {
+	Exchanges() map[string]*core.Exchange
+	Book(host string, base, quote uint32) (*core.OrderBook, error)
+	AssetBalance(assetID uint32) (*core.WalletBalance, error)
+	Trade(pw []byte, form *core.TradeForm) (*core.Order, error)
+	Cancel(oid dex.Bytes) error
+	Order(oid dex.Bytes) (*core.Order, error)
+	Orders(filter *core.OrderFilter) ([]*core.Order, error)
+}
+
+// Config is the configuration for the Server.
+type Config struct {
+	Core clientCore
+	// Addr is the listen address. Without TLS, it must be a loopback
+	// address.
+	Addr string
+	// Host is the DEX host that orders are placed on.
+	Host string
+	// APIKey and Secret are the credentials that requests are signed with.
+	APIKey, Secret string
+	// Cert and Key are the TLS certificate and key files. If they are set,
+	// the server uses HTTPS, and the pair is generated if the files don't
+	// exist. CertHosts are the hosts of a generated certificate.
+	Cert, Key string
+	CertHosts []string
+	// ClientIDsFile is where the clientOrderIds assigned by the trading bot
+	// are saved. If empty, they are only kept in memory.
+	ClientIDsFile string
+}
+
+// Server is an HTTP server with a Binance-compatible REST API. Orders are
+// placed without a password, so the wallets must be unlocked.
+type Server struct {
+	core      clientCore
+	host      string
+	addr      string
+	apiKey    string
+	secret    []byte
+	mux       *chi.Mux
+	srv       *http.Server
+	tlsConfig *tls.Config
+	wg        sync.WaitGroup
+
+	// Binance identifies orders with an integer orderId and a string
+	// clientOrderId. The orderId is derived from the DEX order ID, so it is
+	// the same across restarts. A clientOrderId chosen by the trading bot is
+	// saved to the clientIDsFile. Otherwise it is the hex-encoded DEX order
+	// ID, which is always accepted.
+	idsMtx        sync.Mutex
+	clientIDsFile string
+	clientIDs     map[string]string // by hex order ID, only those chosen by the bot
+	refs          map[string]*orderRef
+	byNum         map[int64]dex.Bytes
+	byClient      map[string]dex.Bytes
+}
+
+// orderRef is the Binance IDs assigned to a DEX order.
+type orderRef struct {
+	num      int64
+	clientID string
+}
+
+// SetLogger sets the logger for the bnserver package.
+func SetLogger(logger dex.Logger) {
+	log = logger
+}
+
+// isLoopback checks whether the listen address is only reachable from this
+// machine.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// New is the constructor for a Server.
+func New(cfg *Config) (*Server, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("missing DEX host")
+	}
+	if cfg.APIKey == "" || cfg.Secret == "" {
+		return nil, errors.New("missing API key or secret")
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.Cert != "" || cfg.Key != "" {
+		keyExists := dex.FileExists(cfg.Key)
+		certExists := dex.FileExists(cfg.Cert)
+		if certExists != keyExists {
+			return nil, fmt.Errorf("missing cert pair file")
+		}
+		if !keyExists {
+			if err := apikey.GenCertPair(cfg.Cert, cfg.Key, cfg.CertHosts, log); err != nil {
+				return nil, err
+			}
+		}
+		keypair, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{keypair},
+			MinVersion:   tls.VersionTLS12,
+		}
+	} else if !isLoopback(cfg.Addr) {
+		// The API key is sent in the clear, and the signatures can be replayed
+		// within the recvWindow.
+		return nil, fmt.Errorf("refusing to listen on %q without TLS. Use a loopback address or set a certificate", cfg.Addr)
+	}
+
+	clientIDs, err := loadClientIDs(cfg.ClientIDsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := chi.NewRouter()
+	s := &Server{
+		core:   cfg.Core,
+		host:   cfg.Host,
+		addr:   cfg.Addr,
+		apiKey: cfg.APIKey,
+		secret: []byte(cfg.Secret),
+		mux:    mux,
+		srv: &http.Server{
+			Handler:      mux,
+			ReadTimeout:  timeoutSeconds * time.Second,
+			WriteTimeout: timeoutSeconds * time.Second,
+		},
+		tlsConfig:     tlsConfig,
+		clientIDsFile: cfg.ClientIDsFile,
+		clientIDs:     make(map[string]string, len(clientIDs)),
+		refs:          make(map[string]*orderRef),
+		byNum:         make(map[int64]dex.Bytes),
+		byClient:      make(map[string]dex.Bytes),
+	}
+	for oidStr, clientID := range clientIDs {
+		oid, err := hex.DecodeString(oidStr)
+		if err != nil || len(oid) != order.OrderIDSize {
+			log.Warnf("Ignoring saved clientOrderId %q for invalid order ID %q", clientID, oidStr)
+			continue
+		}
+		s.clientIDs[oidStr] = clientID
+		s.addRef(oid, clientID)
+	}
+
+	mux.Use(middleware.Recoverer)
+	mux.Route("/api/v3", func(r chi.Router) {
+		r.Get("/ping", s.handlePing)
+		r.Get("/time", s.handleTime)
+		r.Get("/exchangeInfo", s.handleExchangeInfo)
+		r.Get("/depth", s.handleDepth)
+		r.Group(func(r chi.Router) {
+			r.Use(s.authMiddleware)
+			r.Get("/account", s.handleAccount)
+			r.Get("/order", s.handleGetOrder)
+			r.Post("/order", s.handlePostOrder)
+			r.Delete("/order", s.handleDeleteOrder)
+			r.Get("/openOrders", s.handleOpenOrders)
+			r.Get("/allOrders", s.handleAllOrders)
+		})
+	})
+	mux.NotFound(handleUnsupported)
+	mux.MethodNotAllowed(handleUnsupported)
+
+	return s, nil
+}
+
+// Addr is the listening address. It is only valid after Connect.
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// Connect starts the server. Satisfies the dex.Connector interface.
+func (s *Server) Connect(ctx context.Context) (*sync.WaitGroup, error) {
+	var listener net.Listener
+	var err error
+	if s.tlsConfig != nil {
+		listener, err = tls.Listen("tcp", s.addr, s.tlsConfig)
+	} else {
+		listener, err = net.Listen("tcp", s.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't listen on %s. binance api server quitting: %w", s.addr, err)
+	}
+	// Update the listening address in case a :0 was provided.
+	s.addr = listener.Addr().String()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		<-ctx.Done()
+		if err := s.srv.Shutdown(context.Background()); err != nil {
+			log.Errorf("HTTP server Shutdown: %v", err)
+		}
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			log.Warnf("unexpected (http.Server).Serve error: %v", err)
+		}
+		log.Infof("Binance API server off")
+	}()
+	scheme := "http"
+	if s.tlsConfig != nil {
+		scheme = "https"
+	}
+	log.Infof("Binance API server listening on %s://%s, trading on %s", scheme, s.addr, s.host)
+	return &s.wg, nil
+}
+
+// Binance error codes.
+const (
+	codeUnknown          = -1000
+	codeFilterFailure    = -1013
+	codeInvalidTimestamp = -1021
+	codeInvalidSignature = -1022
+	codeIllegalChars     = -1100
+	codeMandatoryParam   = -1102
+	codeParamNotRequired = -1106
+	codeInvalidTIF       = -1115
+	codeInvalidOrderType = -1116
+	codeInvalidSide      = -1117
+	codeBadSymbol        = -1121
+	codeBadRecvWindow    = -1131
+	codeNewOrderRejected = -2010
+	codeCancelRejected   = -2011
+	codeNoSuchOrder      = -2013
+	codeRejectedAPIKey   = -2015
+)
+
+// apiError is a Binance error response.
+type apiError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+	// status is the HTTP status code.
+	status int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Msg)
+}
+
+// newError creates a Binance error response with HTTP status 400.
+func newError(code int, format string, args ...any) *apiError {
+	return &apiError{Code: code, Msg: fmt.Sprintf(format, args...), status: http.StatusBadRequest}
+}
+
+// writeJSON marshals the provided interface and writes the bytes to the
+// ResponseWriter. The response code is assumed to be StatusOK.
+func writeJSON(w http.ResponseWriter, thing any) {
+	writeJSONWithStatus(w, thing, http.StatusOK)
+}
+
+// writeJSONWithStatus marshals the provided interface and writes the bytes to
+// the ResponseWriter with the specified response code.
+func writeJSONWithStatus(w http.ResponseWriter, thing any, code int) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	b, err := json.Marshal(thing)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Errorf("JSON encode error: %v", err)
+		return
+	}
+	w.WriteHeader(code)
+	if _, err = w.Write(b); err != nil {
+		log.Errorf("Write error: %v", err)
+	}
+}
+
+// writeError writes a Binance error response.
+func writeError(w http.ResponseWriter, r *http.Request, e *apiError) {
+	log.Debugf("%s %s error: %v", r.Method, r.URL.Path, e)
+	writeJSONWithStatus(w, e, e.status)
+}
+
+// handleUnsupported responds to the Binance endpoints that have no DEX
+// equivalent, e.g. margin, futures and withdrawals.
+func handleUnsupported(w http.ResponseWriter, r *http.Request) {
+	e := newError(codeUnknown, "%s %s is not supported by the DEX", r.Method, r.URL.Path)
+	e.status = http.StatusNotFound
+	writeError(w, r, e)
+}
+
+type ctxKey int
+
+// ctxParams is the request context key for the url.Values of a signed
+// request, which combine the query and the body.
+const ctxParams ctxKey = iota
+
+// requestParams returns the parameters of the request.
+func requestParams(r *http.Request) url.Values {
+	if params, ok := r.Context().Value(ctxParams).(url.Values); ok {
+		return params
+	}
+	return r.URL.Query()
+}
+
+// authMiddleware checks the API key and the HMAC-SHA256 signature of a
+// request. The signature is of the query string, without the signature
+// parameter, followed by the body.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get(apiKeyHeader); !hmac.Equal([]byte(key), []byte(s.apiKey)) {
+			log.Warnf("authentication failure from ip: %s", r.RemoteAddr)
+			e := newError(codeRejectedAPIKey, "Invalid API-key, IP, or permissions for action.")
+			e.status = http.StatusUnauthorized
+			writeError(w, r, e)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		r.Body.Close()
+		if err != nil {
+			writeError(w, r, newError(codeUnknown, "error reading request body"))
+			return
+		}
+		var sig string
+		rawQuery := make([]string, 0)
+		for _, kv := range strings.Split(r.URL.RawQuery, "&") {
+			if v, found := strings.CutPrefix(kv, "signature="); found {
+				sig = v
+				continue
+			}
+			if kv != "" {
+				rawQuery = append(rawQuery, kv)
+			}
+		}
+		if sig == "" {
+			writeError(w, r, newError(codeMandatoryParam, "Mandatory parameter 'signature' was not sent, was empty/null, or malformed."))
+			return
+		}
+		sigB, err := hex.DecodeString(sig)
+		if err != nil {
+			writeError(w, r, newError(codeInvalidSignature, "Signature for this request is not valid."))
+			return
+		}
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write([]byte(strings.Join(rawQuery, "&")))
+		mac.Write(body)
+		if !hmac.Equal(sigB, mac.Sum(nil)) {
+			log.Warnf("invalid signature from ip: %s", r.RemoteAddr)
+			writeError(w, r, newError(codeInvalidSignature, "Signature for this request is not valid."))
+			return
+		}
+
+		params := r.URL.Query()
+		params.Del("signature")
+		bodyParams, err := url.ParseQuery(string(body))
+		if err != nil {
+			writeError(w, r, newError(codeIllegalChars, "Illegal characters found in the request body."))
+			return
+		}
+		for k, vs := range bodyParams {
+			for _, v := range vs {
+				params.Add(k, v)
+			}
+		}
+		if e := checkTimestamp(params, time.Now()); e != nil {
+			writeError(w, r, e)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxParams, params)))
+	})
+}
+
+// checkTimestamp checks that a signed request is within its recvWindow.
+func checkTimestamp(params url.Values, now time.Time) *apiError {
+	stamp, err := strconv.ParseInt(params.Get("timestamp"), 10, 64)
+	if err != nil {
+		return newError(codeMandatoryParam, "Mandatory parameter 'timestamp' was not sent, was empty/null, or malformed.")
+	}
+	recvWindow := int64(defaultRecvWindow)
+	if rw := params.Get("recvWindow"); rw != "" {
+		recvWindow, err = strconv.ParseInt(rw, 10, 64)
+		if err != nil || recvWindow <= 0 || recvWindow > maxRecvWindow {
+			return newError(codeBadRecvWindow, "recvWindow must be less than %d.", maxRecvWindow)
+		}
+	}
+	nowMs := now.UnixMilli()
+	// Binance allows a client clock to be up to a second ahead.
+	if stamp > nowMs+1000 || nowMs-stamp > recvWindow {
+		return newError(codeInvalidTimestamp, "Timestamp for this request is outside of the recvWindow.")
+	}
+	return nil
+}
@@ -0,0 +1,790 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package bnserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/client/mm/libxc/bntypes"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+)
+
+const (
+	tHost   = "dex.example.com"
+	tKey    = "tkey"
+	tSecret = "tsecret"
+)
+
+var tUnitInfo = dex.UnitInfo{Conventional: dex.Denomination{ConversionFactor: 1e8}}
+
+type TCore struct {
+	book      *core.OrderBook
+	tradeForm *core.TradeForm
+	tradeErr  error
+	cancelErr error
+	canceled  dex.Bytes
+	orders    map[string]*core.Order
+	filter    *core.OrderFilter
+}
+
+func newTCore() *TCore {
+	return &TCore{
+		book:   &core.OrderBook{},
+		orders: make(map[string]*core.Order),
+	}
+}
+
+func (c *TCore) Exchanges() map[string]*core.Exchange {
+	return map[string]*core.Exchange{
+		tHost: {
+			Host: tHost,
+			Markets: map[string]*core.Market{
+				"dcr_btc": {
+					Name:        "dcr_btc",
+					BaseID:      42,
+					BaseSymbol:  "dcr",
+					QuoteID:     0,
+					QuoteSymbol: "btc",
+					LotSize:     1e7,
+					RateStep:    1e3,
+				},
+			},
+			Assets: map[uint32]*dex.Asset{
+				42: {ID: 42, Symbol: "dcr", UnitInfo: tUnitInfo},
+				0:  {ID: 0, Symbol: "btc", UnitInfo: tUnitInfo},
+			},
+		},
+	}
+}
+func (c *TCore) Book(host string, base, quote uint32) (*core.OrderBook, error) {
+	return c.book, nil
+}
+func (c *TCore) AssetBalance(assetID uint32) (*core.WalletBalance, error) {
+	if assetID == 0 {
+		return nil, errors.New("no wallet")
+	}
+	return &core.WalletBalance{Balance: &db.Balance{Balance: asset.Balance{Available: 15e7, Locked: 1e7}}}, nil
+}
+func (c *TCore) Trade(pw []byte, form *core.TradeForm) (*core.Order, error) {
+	if c.tradeErr != nil {
+		return nil, c.tradeErr
+	}
+	c.tradeForm = form
+	oid := make(dex.Bytes, order.OrderIDSize)
+	oid[0] = byte(len(c.orders) + 1)
+	ord := &core.Order{
+		Host:        form.Host,
+		BaseID:      form.Base,
+		QuoteID:     form.Quote,
+		ID:          oid,
+		Type:        order.MarketOrderType,
+		Status:      order.OrderStatusEpoch,
+		SubmitTime:  uint64(time.Now().UnixMilli()),
+		Qty:         form.Qty,
+		Sell:        form.Sell,
+		Rate:        form.Rate,
+		TimeInForce: order.ImmediateTiF,
+	}
+	if form.IsLimit {
+		ord.Type = order.LimitOrderType
+		if !form.TifNow {
+			ord.TimeInForce = order.StandingTiF
+		}
+	}
+	c.orders[oid.String()] = ord
+	return ord, nil
+}
+func (c *TCore) Cancel(oid dex.Bytes) error {
+	if c.cancelErr != nil {
+		return c.cancelErr
+	}
+	c.canceled = oid
+	c.orders[oid.String()].Cancelling = true
+	return nil
+}
+func (c *TCore) Order(oid dex.Bytes) (*core.Order, error) {
+	ord := c.orders[oid.String()]
+	if ord == nil {
+		return nil, errors.New("not found")
+	}
+	return ord, nil
+}
+func (c *TCore) Orders(filter *core.OrderFilter) ([]*core.Order, error) {
+	c.filter = filter
+	ords := make([]*core.Order, 0, len(c.orders))
+	for _, ord := range c.orders {
+		if len(filter.Statuses) > 0 && ord.Status != order.OrderStatusEpoch && ord.Status != order.OrderStatusBooked {
+			continue
+		}
+		ords = append(ords, ord)
+	}
+	return ords, nil
+}
+
+func newTServer(t *testing.T) (*TCore, *httptest.Server) {
+	t.Helper()
+	tCore := newTCore()
+	return tCore, newTServerWithCore(t, tCore, filepath.Join(t.TempDir(), "clientids.json"))
+}
+
+func newTServerWithCore(t *testing.T, tCore *TCore, clientIDsFile string) *httptest.Server {
+	t.Helper()
+	s, err := New(&Config{
+		Core:          tCore,
+		Addr:          "127.0.0.1:0",
+		Host:          tHost,
+		APIKey:        tKey,
+		Secret:        tSecret,
+		ClientIDsFile: clientIDsFile,
+	})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	srv := httptest.NewServer(s.mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// tRequest makes a request the way the libxc Binance client does, signing the
+// query and body if sign is true.
+func tRequest(t *testing.T, srv *httptest.Server, method, endpoint string, query, form url.Values, sign bool, thing any) (int, *apiError) {
+	t.Helper()
+	if query == nil {
+		query = make(url.Values)
+	}
+	if sign {
+		query.Add("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	}
+	queryString := query.Encode()
+	bodyString := form.Encode()
+	if sign {
+		mac := hmac.New(sha256.New, []byte(tSecret))
+		mac.Write([]byte(queryString + bodyString))
+		v := url.Values{}
+		v.Set("signature", hex.EncodeToString(mac.Sum(nil)))
+		if queryString == "" {
+			queryString = v.Encode()
+		} else {
+			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
+		}
+	}
+	return tDo(t, srv, method, endpoint, queryString, bodyString, tKey, thing)
+}
+
+func tDo(t *testing.T, srv *httptest.Server, method, endpoint, queryString, bodyString, key string, thing any) (int, *apiError) {
+	t.Helper()
+	fullURL := srv.URL + endpoint
+	if queryString != "" {
+		fullURL += "?" + queryString
+	}
+	req, err := http.NewRequest(method, fullURL, bytes.NewBufferString(bodyString))
+	if err != nil {
+		t.Fatalf("NewRequest error: %v", err)
+	}
+	if bodyString != "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set(apiKeyHeader, key)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		apiErr := new(apiError)
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			t.Fatalf("error decoding error response: %v", err)
+		}
+		return resp.StatusCode, apiErr
+	}
+	if err := json.NewDecoder(resp.Body).Decode(thing); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	return resp.StatusCode, nil
+}
+
+func TestMain(m *testing.M) {
+	SetLogger(dex.StdOutLogger("TEST", dex.LevelTrace))
+	os.Exit(m.Run())
+}
+
+func TestExchangeInfo(t *testing.T) {
+	_, srv := newTServer(t)
+	var info bntypes.ExchangeInfo
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/exchangeInfo", nil, nil, false, &info); apiErr != nil {
+		t.Fatalf("exchangeInfo error: %v", apiErr)
+	}
+	if len(info.Symbols) != 1 {
+		t.Fatalf("expected 1 symbol, got %d", len(info.Symbols))
+	}
+	mkt := info.Symbols[0]
+	if mkt.Symbol != "DCRBTC" || mkt.BaseAsset != "DCR" || mkt.QuoteAsset != "BTC" || mkt.BaseAssetPrecision != 8 {
+		t.Fatalf("wrong market %+v", mkt)
+	}
+	var lotSize, tickSize, maxPrice, maxQty float64
+	for _, f := range mkt.Filters {
+		switch f.Type {
+		case "LOT_SIZE":
+			lotSize, maxQty = f.StepSize, f.MaxQty
+		case "PRICE_FILTER":
+			tickSize, maxPrice = f.TickSize, f.MaxPrice
+		}
+	}
+	if lotSize != 0.1 || tickSize != 0.00001 {
+		t.Fatalf("wrong lot size %f or tick size %f", lotSize, tickSize)
+	}
+	if maxQty < 1e9 || maxPrice < 1e9 {
+		t.Fatalf("max qty %f and price %f should be unlimited", maxQty, maxPrice)
+	}
+
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/exchangeInfo", url.Values{"symbol": {"ETHBTC"}}, nil, false, &info); apiErr == nil || apiErr.Code != codeBadSymbol {
+		t.Fatalf("expected bad symbol error, got %v", apiErr)
+	}
+}
+
+func TestDepth(t *testing.T) {
+	tCore, srv := newTServer(t)
+	tCore.book.Buys = []*core.MiniOrder{
+		{QtyAtomic: 1e7, MsgRate: 2000},
+		{QtyAtomic: 2e7, MsgRate: 2000},
+		{QtyAtomic: 1e7, MsgRate: 1000},
+	}
+	tCore.book.Sells = []*core.MiniOrder{{QtyAtomic: 5e8, MsgRate: 3000}}
+
+	var snap bntypes.OrderbookSnapshot
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/depth", url.Values{"symbol": {"DCRBTC"}}, nil, false, &snap); apiErr != nil {
+		t.Fatalf("depth error: %v", apiErr)
+	}
+	if len(snap.Bids) != 2 || len(snap.Asks) != 1 {
+		t.Fatalf("expected 2 bids and 1 ask, got %d and %d", len(snap.Bids), len(snap.Asks))
+	}
+	if snap.Bids[0] != [2]json.Number{"0.00002", "0.3"} || snap.Bids[1] != [2]json.Number{"0.00001", "0.1"} {
+		t.Fatalf("wrong bids %v", snap.Bids)
+	}
+	if snap.Asks[0] != [2]json.Number{"0.00003", "5"} {
+		t.Fatalf("wrong asks %v", snap.Asks)
+	}
+
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/depth", url.Values{"symbol": {"DCRBTC"}, "limit": {"1"}}, nil, false, &snap); apiErr != nil {
+		t.Fatalf("depth error: %v", apiErr)
+	}
+	if len(snap.Bids) != 1 {
+		t.Fatalf("expected 1 bid with limit, got %d", len(snap.Bids))
+	}
+
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/depth", nil, nil, false, &snap); apiErr == nil || apiErr.Code != codeMandatoryParam {
+		t.Fatalf("expected missing symbol error, got %v", apiErr)
+	}
+}
+
+func TestAccount(t *testing.T) {
+	_, srv := newTServer(t)
+	var acct bntypes.Account
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/account", nil, nil, true, &acct); apiErr != nil {
+		t.Fatalf("account error: %v", apiErr)
+	}
+	// There is no BTC wallet.
+	if len(acct.Balances) != 1 {
+		t.Fatalf("expected 1 balance, got %d", len(acct.Balances))
+	}
+	if bal := acct.Balances[0]; bal.Asset != "DCR" || bal.Free != 1.5 || bal.Locked != 0.1 {
+		t.Fatalf("wrong balance %+v", bal)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	_, srv := newTServer(t)
+	var acct bntypes.Account
+
+	sign := func(s string) string {
+		mac := hmac.New(sha256.New, []byte(tSecret))
+		mac.Write([]byte(s))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-30*time.Second).UnixMilli(), 10)
+
+	tests := []struct {
+		name, query, key string
+		wantStatus       int
+		wantCode         int
+	}{{
+		name:       "bad key",
+		query:      "timestamp=" + now + "&signature=" + sign("timestamp="+now),
+		key:        "other",
+		wantStatus: http.StatusUnauthorized,
+		wantCode:   codeRejectedAPIKey,
+	}, {
+		name:       "no signature",
+		query:      "timestamp=" + now,
+		key:        tKey,
+		wantStatus: http.StatusBadRequest,
+		wantCode:   codeMandatoryParam,
+	}, {
+		name:       "bad signature",
+		query:      "timestamp=" + now + "&signature=" + sign("timestamp=1"),
+		key:        tKey,
+		wantStatus: http.StatusBadRequest,
+		wantCode:   codeInvalidSignature,
+	}, {
+		name:       "stale",
+		query:      "timestamp=" + stale + "&signature=" + sign("timestamp="+stale),
+		key:        tKey,
+		wantStatus: http.StatusBadRequest,
+		wantCode:   codeInvalidTimestamp,
+	}, {
+		name:       "stale with recvWindow",
+		query:      "timestamp=" + stale + "&recvWindow=60000&signature=" + sign("timestamp="+stale+"&recvWindow=60000"),
+		key:        tKey,
+		wantStatus: http.StatusOK,
+	}}
+	for _, tt := range tests {
+		status, apiErr := tDo(t, srv, http.MethodGet, "/api/v3/account", tt.query, "", tt.key, &acct)
+		if status != tt.wantStatus {
+			t.Fatalf("%s: wanted status %d, got %d", tt.name, tt.wantStatus, status)
+		}
+		if tt.wantCode != 0 && apiErr.Code != tt.wantCode {
+			t.Fatalf("%s: wanted code %d, got %d", tt.name, tt.wantCode, apiErr.Code)
+		}
+	}
+
+	// Endpoints that have no DEX equivalent.
+	status, apiErr := tRequest(t, srv, http.MethodPost, "/sapi/v1/capital/withdraw/apply", nil, url.Values{"coin": {"DCR"}}, true, &acct)
+	if status != http.StatusNotFound || !strings.Contains(apiErr.Msg, "not supported") {
+		t.Fatalf("expected unsupported error, got %d, %v", status, apiErr)
+	}
+}
+
+func TestPostOrder(t *testing.T) {
+	limitOrder := func() url.Values {
+		// The parameters sent by the libxc Binance client.
+		return url.Values{
+			"symbol":           {"DCRBTC"},
+			"side":             {"BUY"},
+			"type":             {"LIMIT"},
+			"timeInForce":      {"GTC"},
+			"newClientOrderId": {"trade1"},
+			"quantity":         {"1.2"},
+			"price":            {"0.00123"},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(url.Values)
+		inBody   bool
+		tradeErr error
+		wantForm *core.TradeForm
+		wantCode int
+	}{{
+		name:     "limit",
+		wantForm: &core.TradeForm{Host: tHost, IsLimit: true, Base: 42, Qty: 12e7, Rate: 123000},
+	}, {
+		name:     "limit in body",
+		inBody:   true,
+		wantForm: &core.TradeForm{Host: tHost, IsLimit: true, Base: 42, Qty: 12e7, Rate: 123000},
+	}, {
+		name: "ioc sell",
+		modify: func(v url.Values) {
+			v.Set("side", "SELL")
+			v.Set("timeInForce", "IOC")
+		},
+		wantForm: &core.TradeForm{Host: tHost, IsLimit: true, Sell: true, Base: 42, Qty: 12e7, Rate: 123000, TifNow: true},
+	}, {
+		name: "market sell",
+		modify: func(v url.Values) {
+			v.Set("side", "SELL")
+			v.Set("type", "MARKET")
+			v.Del("timeInForce")
+			v.Del("price")
+		},
+		wantForm: &core.TradeForm{Host: tHost, Sell: true, Base: 42, Qty: 12e7},
+	}, {
+		name: "market buy",
+		modify: func(v url.Values) {
+			v.Set("type", "MARKET")
+			v.Del("timeInForce")
+			v.Del("price")
+			v.Del("quantity")
+			v.Set("quoteOrderQty", "0.05")
+		},
+		wantForm: &core.TradeForm{Host: tHost, Base: 42, Qty: 5e6},
+	}, {
+		name: "market buy with quantity",
+		modify: func(v url.Values) {
+			v.Set("type", "MARKET")
+			v.Del("timeInForce")
+			v.Del("price")
+		},
+		wantCode: codeParamNotRequired,
+	}, {
+		name:     "fok",
+		modify:   func(v url.Values) { v.Set("timeInForce", "FOK") },
+		wantCode: codeInvalidTIF,
+	}, {
+		name:     "stop loss",
+		modify:   func(v url.Values) { v.Set("type", "STOP_LOSS_LIMIT") },
+		wantCode: codeInvalidOrderType,
+	}, {
+		name:     "bad side",
+		modify:   func(v url.Values) { v.Set("side", "SHORT") },
+		wantCode: codeInvalidSide,
+	}, {
+		name:     "not a lot multiple",
+		modify:   func(v url.Values) { v.Set("quantity", "1.25") },
+		wantCode: codeFilterFailure,
+	}, {
+		name:     "not a rate step multiple",
+		modify:   func(v url.Values) { v.Set("price", "0.0012345") },
+		wantCode: codeFilterFailure,
+	}, {
+		name:     "bad quantity",
+		modify:   func(v url.Values) { v.Set("quantity", "1e2") },
+		wantCode: codeIllegalChars,
+	}, {
+		name:     "missing price",
+		modify:   func(v url.Values) { v.Del("price") },
+		wantCode: codeMandatoryParam,
+	}, {
+		name:     "bad symbol",
+		modify:   func(v url.Values) { v.Set("symbol", "ETHBTC") },
+		wantCode: codeBadSymbol,
+	}, {
+		name:     "trade error",
+		tradeErr: errors.New("insufficient funds"),
+		wantCode: codeNewOrderRejected,
+	}}
+
+	for _, tt := range tests {
+		tCore, srv := newTServer(t)
+		tCore.tradeErr = tt.tradeErr
+		params := limitOrder()
+		if tt.modify != nil {
+			tt.modify(params)
+		}
+		var query, form url.Values
+		if tt.inBody {
+			form = params
+		} else {
+			query = params
+		}
+		var resp bntypes.OrderResponse
+		_, apiErr := tRequest(t, srv, http.MethodPost, "/api/v3/order", query, form, true, &resp)
+		if tt.wantCode != 0 {
+			if apiErr == nil || apiErr.Code != tt.wantCode {
+				t.Fatalf("%s: wanted code %d, got %v", tt.name, tt.wantCode, apiErr)
+			}
+			continue
+		}
+		if apiErr != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, apiErr)
+		}
+		if !reflect.DeepEqual(tCore.tradeForm, tt.wantForm) {
+			t.Fatalf("%s: wanted form %+v, got %+v", tt.name, tt.wantForm, tCore.tradeForm)
+		}
+		if resp.Symbol != "DCRBTC" || resp.Status != statusNew {
+			t.Fatalf("%s: wrong response %+v", tt.name, resp)
+		}
+		if tt.wantForm.IsLimit && (resp.OrigQty != 1.2 || resp.Price != 0.00123) {
+			t.Fatalf("%s: wrong qty %f or price %f", tt.name, resp.OrigQty, resp.Price)
+		}
+	}
+
+	// A client order ID can't be reused.
+	_, srv := newTServer(t)
+	var resp bntypes.OrderResponse
+	if _, apiErr := tRequest(t, srv, http.MethodPost, "/api/v3/order", limitOrder(), nil, true, &resp); apiErr != nil {
+		t.Fatalf("unexpected error: %v", apiErr)
+	}
+	if _, apiErr := tRequest(t, srv, http.MethodPost, "/api/v3/order", limitOrder(), nil, true, &resp); apiErr == nil || apiErr.Code != codeNewOrderRejected {
+		t.Fatalf("expected duplicate order error, got %v", apiErr)
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	tCore, srv := newTServer(t)
+	var resp bntypes.OrderResponse
+	_, apiErr := tRequest(t, srv, http.MethodPost, "/api/v3/order", url.Values{
+		"symbol":           {"DCRBTC"},
+		"side":             {"SELL"},
+		"type":             {"LIMIT"},
+		"timeInForce":      {"GTC"},
+		"newClientOrderId": {"trade1"},
+		"quantity":         {"1"},
+		"price":            {"0.001"},
+	}, nil, true, &resp)
+	if apiErr != nil {
+		t.Fatalf("trade error: %v", apiErr)
+	}
+	var ord *core.Order
+	for _, o := range tCore.orders {
+		ord = o
+	}
+	ord.Status = order.OrderStatusBooked
+	ord.Matches = []*core.Match{{Qty: 4e7, Rate: 100000}, {Qty: 1, IsCancel: true}}
+
+	getOrder := func(query url.Values) (*bntypes.BookedOrder, *apiError) {
+		t.Helper()
+		var bo bntypes.BookedOrder
+		_, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/order", query, nil, true, &bo)
+		return &bo, apiErr
+	}
+
+	bo, apiErr := getOrder(url.Values{"symbol": {"DCRBTC"}, "origClientOrderId": {"trade1"}})
+	if apiErr != nil {
+		t.Fatalf("order error: %v", apiErr)
+	}
+	num := orderNum(ord.ID)
+	if bo.ClientOrderID != "trade1" || bo.OrderID != num || bo.Side != "SELL" || bo.TimeInForce != "GTC" {
+		t.Fatalf("wrong order %+v", bo)
+	}
+	if bo.Status != statusPartiallyFilled || bo.ExecutedQty != 0.4 || bo.CumulativeQuoteQty != 0.0004 {
+		t.Fatalf("wrong fill %s, %f, %f", bo.Status, bo.ExecutedQty, bo.CumulativeQuoteQty)
+	}
+
+	// By orderId and by DEX order ID.
+	for _, q := range []url.Values{
+		{"symbol": {"DCRBTC"}, "orderId": {strconv.FormatInt(num, 10)}},
+		{"symbol": {"DCRBTC"}, "origClientOrderId": {ord.ID.String()}},
+	} {
+		if bo, apiErr = getOrder(q); apiErr != nil || bo.ClientOrderID != "trade1" {
+			t.Fatalf("order lookup %v error: %v", q, apiErr)
+		}
+	}
+
+	// Unknown orders.
+	for _, q := range []url.Values{
+		{"symbol": {"DCRBTC"}, "orderId": {strconv.FormatInt(num+1, 10)}},
+		{"symbol": {"DCRBTC"}, "origClientOrderId": {"trade2"}},
+	} {
+		if _, apiErr = getOrder(q); apiErr == nil || apiErr.Code != codeNoSuchOrder {
+			t.Fatalf("expected unknown order error for %v, got %v", q, apiErr)
+		}
+	}
+
+	var open []*bntypes.BookedOrder
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/openOrders", nil, nil, true, &open); apiErr != nil {
+		t.Fatalf("openOrders error: %v", apiErr)
+	}
+	if len(open) != 1 || open[0].OrderID != num || tCore.filter.Hosts[0] != tHost {
+		t.Fatalf("wrong open orders %v", open)
+	}
+
+	// Cancel. The order is pending cancel until the cancel order is matched.
+	tCore.cancelErr = errors.New("too late")
+	q := url.Values{"symbol": {"DCRBTC"}, "origClientOrderId": {"trade1"}}
+	if _, apiErr := tRequest(t, srv, http.MethodDelete, "/api/v3/order", q, nil, true, &bo); apiErr == nil || apiErr.Code != codeCancelRejected {
+		t.Fatalf("expected cancel error, got %v", apiErr)
+	}
+	tCore.cancelErr = nil
+	q = url.Values{"symbol": {"DCRBTC"}, "origClientOrderId": {"trade1"}}
+	if _, apiErr := tRequest(t, srv, http.MethodDelete, "/api/v3/order", q, nil, true, &bo); apiErr != nil {
+		t.Fatalf("cancel error: %v", apiErr)
+	}
+	if !bytes.Equal(tCore.canceled, ord.ID) || bo.Status != statusPendingCancel {
+		t.Fatalf("order not canceled, status = %s", bo.Status)
+	}
+
+	ord.Status = order.OrderStatusCanceled
+	var all []*bntypes.BookedOrder
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/allOrders", url.Values{"symbol": {"DCRBTC"}}, nil, true, &all); apiErr != nil {
+		t.Fatalf("allOrders error: %v", apiErr)
+	}
+	if len(all) != 1 || all[0].Status != statusCanceled || tCore.filter.N != defaultOrdersLimit {
+		t.Fatalf("wrong orders %v", all)
+	}
+}
+
+func TestOrderIDsRestart(t *testing.T) {
+	tCore := newTCore()
+	clientIDsFile := filepath.Join(t.TempDir(), "clientids.json")
+	srv := newTServerWithCore(t, tCore, clientIDsFile)
+	var resp bntypes.OrderResponse
+	for _, clientID := range []string{"trade1", ""} {
+		form := url.Values{
+			"symbol":      {"DCRBTC"},
+			"side":        {"BUY"},
+			"type":        {"LIMIT"},
+			"timeInForce": {"GTC"},
+			"quantity":    {"1"},
+			"price":       {"0.001"},
+		}
+		if clientID != "" {
+			form.Set("newClientOrderId", clientID)
+		}
+		if _, apiErr := tRequest(t, srv, http.MethodPost, "/api/v3/order", form, nil, true, &resp); apiErr != nil {
+			t.Fatalf("trade error: %v", apiErr)
+		}
+	}
+	srv.Close()
+
+	// A new server finds the orders by the same IDs.
+	srv = newTServerWithCore(t, tCore, clientIDsFile)
+	for _, ord := range tCore.orders {
+		var bo bntypes.BookedOrder
+		q := url.Values{"symbol": {"DCRBTC"}, "orderId": {strconv.FormatInt(orderNum(ord.ID), 10)}}
+		if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/order", q, nil, true, &bo); apiErr != nil {
+			t.Fatalf("order lookup by orderId error: %v", apiErr)
+		}
+		if bo.OrderID != orderNum(ord.ID) || (bo.ClientOrderID != "trade1" && bo.ClientOrderID != ord.ID.String()) {
+			t.Fatalf("wrong order %+v", bo)
+		}
+	}
+	var bo bntypes.BookedOrder
+	q := url.Values{"symbol": {"DCRBTC"}, "origClientOrderId": {"trade1"}}
+	if _, apiErr := tRequest(t, srv, http.MethodGet, "/api/v3/order", q, nil, true, &bo); apiErr != nil || bo.ClientOrderID != "trade1" {
+		t.Fatalf("order lookup by saved clientOrderId error: %v", apiErr)
+	}
+	form := url.Values{"symbol": {"DCRBTC"}, "side": {"BUY"}, "type": {"LIMIT"}, "timeInForce": {"GTC"},
+		"quantity": {"1"}, "price": {"0.001"}, "newClientOrderId": {"trade1"}}
+	if _, apiErr := tRequest(t, srv, http.MethodPost, "/api/v3/order", form, nil, true, &resp); apiErr == nil || apiErr.Code != codeNewOrderRejected {
+		t.Fatalf("expected duplicate clientOrderId error after restart, got %v", apiErr)
+	}
+}
+
+func TestOrderStatus(t *testing.T) {
+	mkt := &market{
+		Market:      &core.Market{BaseID: 42, QuoteID: 0, LotSize: 1e7, RateStep: 1e3},
+		symbol:      "DCRBTC",
+		baseFactor:  1e8,
+		quoteFactor: 1e8,
+	}
+	fill := []*core.Match{{Qty: 1e8, Rate: 1e5}}
+	partial := []*core.Match{{Qty: 1e7, Rate: 1e5}}
+	tests := []struct {
+		name    string
+		ord     *core.Order
+		wantStr string
+	}{
+		{"epoch", &core.Order{Type: order.LimitOrderType, Status: order.OrderStatusEpoch, Qty: 1e8}, statusNew},
+		{"partial", &core.Order{Type: order.LimitOrderType, Status: order.OrderStatusBooked, Qty: 1e8, Matches: partial}, statusPartiallyFilled},
+		{"cancelling", &core.Order{Type: order.LimitOrderType, Status: order.OrderStatusBooked, Qty: 1e8, Cancelling: true}, statusPendingCancel},
+		{"filled", &core.Order{Type: order.LimitOrderType, Status: order.OrderStatusExecuted, Qty: 1e8, Matches: fill}, statusFilled},
+		{"ioc remainder", &core.Order{Type: order.LimitOrderType, Status: order.OrderStatusExecuted, Qty: 1e8, Matches: partial}, statusExpired},
+		{"market buy", &core.Order{Type: order.MarketOrderType, Status: order.OrderStatusExecuted, Qty: 1e5, Matches: partial}, statusFilled},
+		{"canceled", &core.Order{Type: order.LimitOrderType, Status: order.OrderStatusCanceled, Qty: 1e8}, statusCanceled},
+		{"revoked", &core.Order{Type: order.LimitOrderType, Status: order.OrderStatusRevoked, Qty: 1e8}, statusExpired},
+	}
+	for _, tt := range tests {
+		res := mkt.orderResult(tt.ord, &orderRef{num: 1, clientID: "a"})
+		if res.Status != tt.wantStr {
+			t.Fatalf("%s: wanted %s, got %s", tt.name, tt.wantStr, res.Status)
+		}
+	}
+}
+
+func TestConversions(t *testing.T) {
+	// Quantities and rates are parsed exactly. 0.3 is not 0.1 + 0.2 as a
+	// float64.
+	if qty, err := parseQty("0.3", 1e8); err != nil || qty != 3e7 {
+		t.Fatalf("wrong qty %d, %v", qty, err)
+	}
+	if _, err := parseQty("0.000000001", 1e8); !errors.Is(err, errPrecision) {
+		t.Fatalf("expected precision error, got %v", err)
+	}
+	for _, s := range []string{"", "-1", "0", "1/3", "1e8", "abc"} {
+		if _, err := parseQty(s, 1e8); !errors.Is(err, errBadDecimal) {
+			t.Fatalf("expected bad decimal error for %q, got %v", s, err)
+		}
+	}
+	// ETH has 9 decimals on the DEX.
+	mkt := &market{baseFactor: 1e9, quoteFactor: 1e8}
+	rate, err := mkt.parseRate("0.05")
+	if err != nil || rate != 5e5 {
+		t.Fatalf("wrong rate %d, %v", rate, err)
+	}
+	if s := mkt.formatRate(rate); s != "0.05" {
+		t.Fatalf("wrong formatted rate %s", s)
+	}
+	if s := formatQty(123456789, 1e9); s != "0.123456789" {
+		t.Fatalf("wrong formatted qty %s", s)
+	}
+	if s := formatQty(5e9, 1e9); s != "5" {
+		t.Fatalf("wrong formatted qty %s", s)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	s, err := New(&Config{Core: newTCore(), Addr: "127.0.0.1:0", Host: tHost, APIKey: tKey, Secret: tSecret})
+	if err != nil {
+		t.Fatalf("New error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	wg, err := s.Connect(ctx)
+	if err != nil {
+		t.Fatalf("Connect error: %v", err)
+	}
+	resp, err := http.Get("http://" + s.Addr() + "/api/v3/ping")
+	if err != nil {
+		t.Fatalf("ping error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ping status %d", resp.StatusCode)
+	}
+	cancel()
+	wg.Wait()
+
+	// TLS.
+	dir := t.TempDir()
+	s, err = New(&Config{Core: newTCore(), Addr: "127.0.0.1:0", Host: tHost, APIKey: tKey, Secret: tSecret,
+		Cert: filepath.Join(dir, "bn.cert"), Key: filepath.Join(dir, "bn.key"), CertHosts: []string{"127.0.0.1"}})
+	if err != nil {
+		t.Fatalf("New with TLS error: %v", err)
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	wg, err = s.Connect(ctx)
+	if err != nil {
+		t.Fatalf("Connect with TLS error: %v", err)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err = client.Get("https://" + s.Addr() + "/api/v3/ping")
+	if err != nil {
+		t.Fatalf("TLS ping error: %v", err)
+	}
+	resp.Body.Close()
+	cancel()
+	wg.Wait()
+
+	// Non-loopback addresses require TLS.
+	for _, addr := range []string{"", ":5760", "0.0.0.0:5760", "192.168.1.2:5760", "example.com:5760"} {
+		if _, err := New(&Config{Core: newTCore(), Addr: addr, Host: tHost, APIKey: tKey, Secret: tSecret}); err == nil {
+			t.Fatalf("no error for %q without TLS", addr)
+		}
+	}
+	for _, addr := range []string{"localhost:5760", "[::1]:5760", "127.0.0.3:5760"} {
+		if _, err := New(&Config{Core: newTCore(), Addr: addr, Host: tHost, APIKey: tKey, Secret: tSecret}); err != nil {
+			t.Fatalf("error for loopback %q: %v", addr, err)
+		}
+	}
+
+	if _, err := New(&Config{Core: newTCore(), APIKey: tKey, Secret: tSecret}); err == nil {
+		t.Fatalf("no error for missing host")
+	}
+	if _, err := New(&Config{Core: newTCore(), Host: tHost}); err == nil {
+		t.Fatalf("no error for missing credentials")
+	}
+}
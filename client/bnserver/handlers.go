@@ -0,0 +1,582 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package bnserver
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+)
+
+const (
+	defaultDepthLimit  = 100
+	maxDepthLimit      = 5000
+	defaultOrdersLimit = 500
+	maxOrdersLimit     = 1000
+)
+
+// markets returns the markets of the DEX host by Binance symbol.
+func (s *Server) markets() (map[string]*market, *apiError) {
+	xc := s.core.Exchanges()[s.host]
+	if xc == nil {
+		return nil, newError(codeUnknown, "not connected to %s", s.host)
+	}
+	mkts := make(map[string]*market, len(xc.Markets))
+	for _, mkt := range xc.Markets {
+		b, q := xc.Assets[mkt.BaseID], xc.Assets[mkt.QuoteID]
+		if b == nil || q == nil {
+			continue
+		}
+		sym := marketSymbol(mkt)
+		mkts[sym] = &market{
+			Market:      mkt,
+			symbol:      sym,
+			baseFactor:  b.UnitInfo.Conventional.ConversionFactor,
+			quoteFactor: q.UnitInfo.Conventional.ConversionFactor,
+		}
+	}
+	return mkts, nil
+}
+
+// market looks up a market by Binance symbol.
+func (s *Server) market(symbol string) (*market, *apiError) {
+	if symbol == "" {
+		return nil, newError(codeMandatoryParam, "Mandatory parameter 'symbol' was not sent, was empty/null, or malformed.")
+	}
+	mkts, apiErr := s.markets()
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	mkt := mkts[symbol]
+	if mkt == nil {
+		return nil, newError(codeBadSymbol, "Invalid symbol.")
+	}
+	return mkt, nil
+}
+
+// orderNum is the Binance orderId of a DEX order. It is the first 53 bits of
+// the order ID, which JavaScript numbers represent exactly.
+func orderNum(oid dex.Bytes) int64 {
+	if len(oid) < 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(oid[:8]) >> 11)
+}
+
+// loadClientIDs loads the saved clientOrderIds, by hex order ID.
+func loadClientIDs(path string) (map[string]string, error) {
+	clientIDs := make(map[string]string)
+	if path == "" {
+		return clientIDs, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return clientIDs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading clientOrderIds file: %w", err)
+	}
+	if err := json.Unmarshal(b, &clientIDs); err != nil {
+		return nil, fmt.Errorf("error parsing clientOrderIds file %s: %w", path, err)
+	}
+	return clientIDs, nil
+}
+
+// saveClientIDs writes the clientOrderIds to the clientIDsFile. The idsMtx
+// MUST be held.
+func (s *Server) saveClientIDs() error {
+	if s.clientIDsFile == "" {
+		return nil
+	}
+	b, err := json.Marshal(s.clientIDs)
+	if err != nil {
+		return err
+	}
+	tmp := s.clientIDsFile + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.clientIDsFile)
+}
+
+// addRef records the Binance IDs of an order. The idsMtx MUST be held.
+func (s *Server) addRef(oid dex.Bytes, clientID string) *orderRef {
+	ref := &orderRef{num: orderNum(oid), clientID: clientID}
+	s.refs[oid.String()] = ref
+	s.byNum[ref.num] = oid
+	s.byClient[clientID] = oid
+	return ref
+}
+
+// orderRef gets or assigns the Binance IDs of an order. A non-empty clientID
+// is saved for an order that has not been seen before.
+func (s *Server) orderRef(oid dex.Bytes, clientID string) *orderRef {
+	s.idsMtx.Lock()
+	defer s.idsMtx.Unlock()
+	oidStr := oid.String()
+	if ref := s.refs[oidStr]; ref != nil {
+		return ref
+	}
+	if clientID == "" {
+		clientID = oidStr
+	} else {
+		s.clientIDs[oidStr] = clientID
+		if err := s.saveClientIDs(); err != nil {
+			log.Errorf("Error saving clientOrderId %q for order %s: %v", clientID, oidStr, err)
+		}
+	}
+	return s.addRef(oid, clientID)
+}
+
+// clientIDUsed checks whether a clientOrderId is already assigned.
+func (s *Server) clientIDUsed(clientID string) bool {
+	s.idsMtx.Lock()
+	defer s.idsMtx.Unlock()
+	_, found := s.byClient[clientID]
+	return found
+}
+
+// findOrderNum finds the order on the market with the orderId. It is used for
+// orders that have not been seen since the server started.
+func (s *Server) findOrderNum(num int64, mkt *market) dex.Bytes {
+	ords, err := s.core.Orders(&core.OrderFilter{
+		Hosts: []string{s.host},
+		Market: &struct {
+			Base  uint32 `json:"baseID"`
+			Quote uint32 `json:"quoteID"`
+		}{Base: mkt.BaseID, Quote: mkt.QuoteID},
+	})
+	if err != nil {
+		log.Errorf("Error retrieving orders for orderId %d: %v", num, err)
+		return nil
+	}
+	for _, ord := range ords {
+		if orderNum(ord.ID) == num {
+			return ord.ID
+		}
+	}
+	return nil
+}
+
+// lookupOrder finds the order identified by the orderId or origClientOrderId
+// parameter. The order must be on the market.
+func (s *Server) lookupOrder(params url.Values, mkt *market) (*core.Order, *apiError) {
+	var oid dex.Bytes
+	if numStr := params.Get("orderId"); numStr != "" {
+		num, err := strconv.ParseInt(numStr, 10, 64)
+		if err != nil {
+			return nil, newError(codeIllegalChars, "Illegal characters found in parameter 'orderId'.")
+		}
+		s.idsMtx.Lock()
+		oid = s.byNum[num]
+		s.idsMtx.Unlock()
+		if oid == nil {
+			oid = s.findOrderNum(num, mkt)
+		}
+	} else if clientID := params.Get("origClientOrderId"); clientID != "" {
+		s.idsMtx.Lock()
+		oid = s.byClient[clientID]
+		s.idsMtx.Unlock()
+		if oid == nil {
+			// The hex-encoded DEX order ID.
+			if b, err := hex.DecodeString(clientID); err == nil && len(b) == order.OrderIDSize {
+				oid = b
+			}
+		}
+	} else {
+		return nil, newError(codeMandatoryParam, "Param 'origClientOrderId' or 'orderId' must be sent, but both were empty/null!")
+	}
+	if oid == nil {
+		return nil, newError(codeNoSuchOrder, "Order does not exist.")
+	}
+	ord, err := s.core.Order(oid)
+	if err != nil || ord.Host != s.host || ord.BaseID != mkt.BaseID || ord.QuoteID != mkt.QuoteID {
+		return nil, newError(codeNoSuchOrder, "Order does not exist.")
+	}
+	return ord, nil
+}
+
+// handlePing handles GET /api/v3/ping.
+func (s *Server) handlePing(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, struct{}{})
+}
+
+// handleTime handles GET /api/v3/time.
+func (s *Server) handleTime(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, &struct {
+		ServerTime int64 `json:"serverTime"`
+	}{time.Now().UnixMilli()})
+}
+
+// handleExchangeInfo handles GET /api/v3/exchangeInfo.
+func (s *Server) handleExchangeInfo(w http.ResponseWriter, r *http.Request) {
+	mkts, apiErr := s.markets()
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	info := &exchangeInfo{
+		Timezone:   "UTC",
+		ServerTime: time.Now().UnixMilli(),
+		RateLimits: []struct{}{},
+		Symbols:    make([]*symbolInfo, 0, len(mkts)),
+	}
+	if symbol := r.URL.Query().Get("symbol"); symbol != "" {
+		mkt := mkts[symbol]
+		if mkt == nil {
+			writeError(w, r, newError(codeBadSymbol, "Invalid symbol."))
+			return
+		}
+		info.Symbols = append(info.Symbols, mkt.symbolInfo())
+	} else {
+		for _, mkt := range mkts {
+			info.Symbols = append(info.Symbols, mkt.symbolInfo())
+		}
+		sort.Slice(info.Symbols, func(i, j int) bool {
+			return info.Symbols[i].Symbol < info.Symbols[j].Symbol
+		})
+	}
+	writeJSON(w, info)
+}
+
+// handleDepth handles GET /api/v3/depth. Booked orders are aggregated into
+// price levels. Epoch orders are not included.
+func (s *Server) handleDepth(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	mkt, apiErr := s.market(params.Get("symbol"))
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	limit := defaultDepthLimit
+	if l := params.Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 || limit > maxDepthLimit {
+			writeError(w, r, newError(codeIllegalChars, "Illegal characters found in parameter 'limit'; legal range is '1' to '%d'.", maxDepthLimit))
+			return
+		}
+	}
+	book, err := s.core.Book(s.host, mkt.BaseID, mkt.QuoteID)
+	if err != nil {
+		writeError(w, r, newError(codeUnknown, "unable to retrieve order book: %v", err))
+		return
+	}
+	writeJSON(w, &depth{
+		LastUpdateID: time.Now().UnixMilli(),
+		Bids:         mkt.depthLevels(book.Buys, limit),
+		Asks:         mkt.depthLevels(book.Sells, limit),
+	})
+}
+
+// handleAccount handles GET /api/v3/account. The balances are those of the
+// assets traded on the DEX host that have a wallet.
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request) {
+	xc := s.core.Exchanges()[s.host]
+	if xc == nil {
+		writeError(w, r, newError(codeUnknown, "not connected to %s", s.host))
+		return
+	}
+	acct := &account{
+		CanTrade:    true,
+		AccountType: "SPOT",
+		UpdateTime:  time.Now().UnixMilli(),
+		Balances:    make([]*balance, 0, len(xc.Assets)),
+		Permissions: []string{"SPOT"},
+	}
+	for assetID, a := range xc.Assets {
+		bal, err := s.core.AssetBalance(assetID)
+		if err != nil || bal.Balance == nil {
+			continue
+		}
+		factor := a.UnitInfo.Conventional.ConversionFactor
+		acct.Balances = append(acct.Balances, &balance{
+			Asset:  assetSymbol(a.Symbol),
+			Free:   formatQty(bal.Available, factor),
+			Locked: formatQty(bal.Locked, factor),
+		})
+	}
+	sort.Slice(acct.Balances, func(i, j int) bool {
+		return acct.Balances[i].Asset < acct.Balances[j].Asset
+	})
+	writeJSON(w, acct)
+}
+
+// handlePostOrder handles POST /api/v3/order. Limit orders may be GTC, which
+// are booked, or IOC. Market sells are sized in the base asset with quantity
+// and market buys in the quote asset with quoteOrderQty. Quantities must be
+// multiples of the lot size and prices multiples of the rate step.
+func (s *Server) handlePostOrder(w http.ResponseWriter, r *http.Request) {
+	params := requestParams(r)
+	mkt, apiErr := s.market(params.Get("symbol"))
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	form, apiErr := mkt.tradeForm(params)
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	form.Host = s.host
+	clientID := params.Get("newClientOrderId")
+	if clientID != "" && s.clientIDUsed(clientID) {
+		writeError(w, r, newError(codeNewOrderRejected, "Duplicate order sent."))
+		return
+	}
+	ord, err := s.core.Trade(nil, form)
+	if err != nil {
+		writeError(w, r, newError(codeNewOrderRejected, "%v", err))
+		return
+	}
+	res := mkt.orderResult(ord, s.orderRef(ord.ID, clientID))
+	res.TransactTime = time.Now().UnixMilli()
+	writeJSON(w, res)
+}
+
+// mandatoryParam is the error for a missing parameter.
+func mandatoryParam(name string) *apiError {
+	return newError(codeMandatoryParam, "Mandatory parameter '%s' was not sent, was empty/null, or malformed.", name)
+}
+
+// tradeForm translates the order parameters to a TradeForm.
+func (m *market) tradeForm(params url.Values) (*core.TradeForm, *apiError) {
+	form := &core.TradeForm{
+		Base:  m.BaseID,
+		Quote: m.QuoteID,
+	}
+	switch side := params.Get("side"); side {
+	case sideBuy:
+	case sideSell:
+		form.Sell = true
+	case "":
+		return nil, mandatoryParam("side")
+	default:
+		return nil, newError(codeInvalidSide, "Invalid side.")
+	}
+
+	parseLots := func() *apiError {
+		qtyStr := params.Get("quantity")
+		if qtyStr == "" {
+			return mandatoryParam("quantity")
+		}
+		qty, err := parseQty(qtyStr, m.baseFactor)
+		if errors.Is(err, errPrecision) || (err == nil && qty%m.LotSize != 0) {
+			return newError(codeFilterFailure, "Filter failure: LOT_SIZE")
+		}
+		if err != nil {
+			return newError(codeIllegalChars, "Illegal characters found in parameter 'quantity'.")
+		}
+		form.Qty = qty
+		return nil
+	}
+
+	switch orderType := params.Get("type"); orderType {
+	case typeLimit:
+		form.IsLimit = true
+		switch tif := params.Get("timeInForce"); tif {
+		case tifGTC:
+			form.TifNow = false
+		case tifIOC:
+			form.TifNow = true
+		case tifFOK:
+			return nil, newError(codeInvalidTIF, "Invalid timeInForce. FOK orders are not supported by the DEX.")
+		case "":
+			return nil, mandatoryParam("timeInForce")
+		default:
+			return nil, newError(codeInvalidTIF, "Invalid timeInForce.")
+		}
+		if params.Get("quoteOrderQty") != "" {
+			return nil, newError(codeParamNotRequired, "Parameter 'quoteOrderQty' sent when not required.")
+		}
+		if apiErr := parseLots(); apiErr != nil {
+			return nil, apiErr
+		}
+		priceStr := params.Get("price")
+		if priceStr == "" {
+			return nil, mandatoryParam("price")
+		}
+		rate, err := m.parseRate(priceStr)
+		if errors.Is(err, errPrecision) || (err == nil && (rate%m.RateStep != 0 || rate < m.MinimumRate)) {
+			return nil, newError(codeFilterFailure, "Filter failure: PRICE_FILTER")
+		}
+		if err != nil {
+			return nil, newError(codeIllegalChars, "Illegal characters found in parameter 'price'.")
+		}
+		form.Rate = rate
+	case typeMarket:
+		if params.Get("price") != "" {
+			return nil, newError(codeParamNotRequired, "Parameter 'price' sent when not required.")
+		}
+		if form.Sell {
+			if params.Get("quoteOrderQty") != "" {
+				return nil, newError(codeParamNotRequired, "Parameter 'quoteOrderQty' sent when not required. DEX market sells are sized in the base asset.")
+			}
+			if apiErr := parseLots(); apiErr != nil {
+				return nil, apiErr
+			}
+			break
+		}
+		if params.Get("quantity") != "" {
+			return nil, newError(codeParamNotRequired, "Parameter 'quantity' sent when not required. DEX market buys are sized in the quote asset with quoteOrderQty.")
+		}
+		qtyStr := params.Get("quoteOrderQty")
+		if qtyStr == "" {
+			return nil, mandatoryParam("quoteOrderQty")
+		}
+		qty, err := parseQty(qtyStr, m.quoteFactor)
+		if errors.Is(err, errPrecision) {
+			return nil, newError(codeFilterFailure, "Filter failure: NOTIONAL")
+		}
+		if err != nil {
+			return nil, newError(codeIllegalChars, "Illegal characters found in parameter 'quoteOrderQty'.")
+		}
+		form.Qty = qty
+	case "":
+		return nil, mandatoryParam("type")
+	default:
+		return nil, newError(codeInvalidOrderType, "Invalid orderType. %s orders are not supported by the DEX.", orderType)
+	}
+	return form, nil
+}
+
+// handleGetOrder handles GET /api/v3/order.
+func (s *Server) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	params := requestParams(r)
+	mkt, apiErr := s.market(params.Get("symbol"))
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	ord, apiErr := s.lookupOrder(params, mkt)
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	writeJSON(w, mkt.orderResult(ord, s.orderRef(ord.ID, "")))
+}
+
+// handleDeleteOrder handles DELETE /api/v3/order. DEX cancel orders are
+// matched in the next epoch, so the order is PENDING_CANCEL until then.
+func (s *Server) handleDeleteOrder(w http.ResponseWriter, r *http.Request) {
+	params := requestParams(r)
+	mkt, apiErr := s.market(params.Get("symbol"))
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	ord, apiErr := s.lookupOrder(params, mkt)
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	if err := s.core.Cancel(ord.ID); err != nil {
+		writeError(w, r, newError(codeCancelRejected, "%v", err))
+		return
+	}
+	if updated, err := s.core.Order(ord.ID); err == nil {
+		ord = updated
+	} else {
+		ord.Cancelling = true
+	}
+	writeJSON(w, mkt.orderResult(ord, s.orderRef(ord.ID, "")))
+}
+
+// orders returns the orders on the DEX host, optionally filtered by market,
+// as Binance order results.
+func (s *Server) orders(filter *core.OrderFilter, mkt *market) ([]*orderResult, *apiError) {
+	var mkts map[string]*market
+	if mkt == nil {
+		var apiErr *apiError
+		if mkts, apiErr = s.markets(); apiErr != nil {
+			return nil, apiErr
+		}
+	} else {
+		mkts = map[string]*market{mkt.symbol: mkt}
+		filter.Market = &struct {
+			Base  uint32 `json:"baseID"`
+			Quote uint32 `json:"quoteID"`
+		}{Base: mkt.BaseID, Quote: mkt.QuoteID}
+	}
+	byIDs := make(map[[2]uint32]*market, len(mkts))
+	for _, m := range mkts {
+		byIDs[[2]uint32{m.BaseID, m.QuoteID}] = m
+	}
+	filter.Hosts = []string{s.host}
+	ords, err := s.core.Orders(filter)
+	if err != nil {
+		return nil, newError(codeUnknown, "unable to retrieve orders: %v", err)
+	}
+	res := make([]*orderResult, 0, len(ords))
+	for _, ord := range ords {
+		m := byIDs[[2]uint32{ord.BaseID, ord.QuoteID}]
+		if m == nil || ord.Type == order.CancelOrderType {
+			continue
+		}
+		res = append(res, m.orderResult(ord, s.orderRef(ord.ID, "")))
+	}
+	// Binance lists orders oldest first.
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Time < res[j].Time
+	})
+	return res, nil
+}
+
+// handleOpenOrders handles GET /api/v3/openOrders. The symbol is optional.
+func (s *Server) handleOpenOrders(w http.ResponseWriter, r *http.Request) {
+	params := requestParams(r)
+	var mkt *market
+	if symbol := params.Get("symbol"); symbol != "" {
+		var apiErr *apiError
+		if mkt, apiErr = s.market(symbol); apiErr != nil {
+			writeError(w, r, apiErr)
+			return
+		}
+	}
+	res, apiErr := s.orders(&core.OrderFilter{
+		Statuses: []order.OrderStatus{order.OrderStatusEpoch, order.OrderStatusBooked},
+	}, mkt)
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	writeJSON(w, res)
+}
+
+// handleAllOrders handles GET /api/v3/allOrders.
+func (s *Server) handleAllOrders(w http.ResponseWriter, r *http.Request) {
+	params := requestParams(r)
+	mkt, apiErr := s.market(params.Get("symbol"))
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	limit := defaultOrdersLimit
+	if l := params.Get("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 || limit > maxOrdersLimit {
+			writeError(w, r, newError(codeIllegalChars, "Illegal characters found in parameter 'limit'; legal range is '1' to '%d'.", maxOrdersLimit))
+			return
+		}
+	}
+	res, apiErr := s.orders(&core.OrderFilter{N: limit}, mkt)
+	if apiErr != nil {
+		writeError(w, r, apiErr)
+		return
+	}
+	writeJSON(w, res)
+}
@@ -0,0 +1,331 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package bnserver
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+)
+
+// Binance order statuses, sides, types and times in force.
+const (
+	statusNew             = "NEW"
+	statusPartiallyFilled = "PARTIALLY_FILLED"
+	statusFilled          = "FILLED"
+	statusCanceled        = "CANCELED"
+	statusPendingCancel   = "PENDING_CANCEL"
+	statusExpired         = "EXPIRED"
+
+	sideBuy  = "BUY"
+	sideSell = "SELL"
+
+	typeLimit  = "LIMIT"
+	typeMarket = "MARKET"
+
+	tifGTC = "GTC"
+	tifIOC = "IOC"
+	tifFOK = "FOK"
+)
+
+// maxAmount is reported as the maxPrice and maxQty of every market. The DEX
+// has no such limits.
+const maxAmount = "9000000000.00000000"
+
+// market is a DEX market along with the conversion factors of its assets.
+type market struct {
+	*core.Market
+	symbol      string
+	baseFactor  uint64
+	quoteFactor uint64
+}
+
+// marketSymbol is the Binance symbol of a market, e.g. DCRBTC. Token symbols
+// keep their network suffix, e.g. USDC.ETH.
+func marketSymbol(mkt *core.Market) string {
+	return strings.ToUpper(mkt.BaseSymbol + mkt.QuoteSymbol)
+}
+
+// assetSymbol is the Binance symbol of an asset.
+func assetSymbol(symbol string) string {
+	return strings.ToUpper(symbol)
+}
+
+// ratFactor is the conversion factor as a *big.Rat.
+func ratFactor(factor uint64) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).SetUint64(factor))
+}
+
+// rateMultiplier converts a conventional rate to a message-rate.
+func (m *market) rateMultiplier() *big.Rat {
+	num := new(big.Int).Mul(big.NewInt(calc.RateEncodingFactor), new(big.Int).SetUint64(m.quoteFactor))
+	return new(big.Rat).SetFrac(num, new(big.Int).SetUint64(m.baseFactor))
+}
+
+var (
+	errBadDecimal = errors.New("not a positive decimal number")
+	errPrecision  = errors.New("too much precision")
+)
+
+// parseDecimal parses a conventional decimal string, e.g. 1.25, multiplied
+// by mult. The result must be an integer. Parsing is exact, so a quantity
+// that is a multiple of the lot size is never rejected because of floating
+// point error.
+func parseDecimal(s string, mult *big.Rat) (uint64, error) {
+	if s == "" || strings.ContainsAny(s, "/eE") {
+		return 0, errBadDecimal
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return 0, errBadDecimal
+	}
+	r.Mul(r, mult)
+	if !r.IsInt() {
+		return 0, errPrecision
+	}
+	if !r.Num().IsUint64() {
+		return 0, errBadDecimal
+	}
+	return r.Num().Uint64(), nil
+}
+
+// parseQty parses a conventional quantity of an asset into atoms.
+func parseQty(s string, factor uint64) (uint64, error) {
+	return parseDecimal(s, ratFactor(factor))
+}
+
+// parseRate parses a conventional rate into a message-rate.
+func (m *market) parseRate(s string) (uint64, error) {
+	return parseDecimal(s, m.rateMultiplier())
+}
+
+// formatRat formats r with as many decimal places as needed, up to 18.
+func formatRat(r *big.Rat) string {
+	ten := big.NewRat(10, 1)
+	x := new(big.Rat).Set(r)
+	for prec := 0; prec < 18; prec++ {
+		if x.IsInt() {
+			return r.FloatString(prec)
+		}
+		x.Mul(x, ten)
+	}
+	return r.FloatString(18)
+}
+
+// formatQty formats an amount of atoms in conventional units.
+func formatQty(v, factor uint64) string {
+	r := new(big.Rat).SetInt(new(big.Int).SetUint64(v))
+	return formatRat(r.Quo(r, ratFactor(factor)))
+}
+
+// formatRate formats a message-rate as a conventional rate.
+func (m *market) formatRate(msgRate uint64) string {
+	r := new(big.Rat).SetInt(new(big.Int).SetUint64(msgRate))
+	return formatRat(r.Quo(r, m.rateMultiplier()))
+}
+
+// precision is the number of decimal places of an asset.
+func precision(factor uint64) int {
+	return len(strconv.FormatUint(factor, 10)) - 1
+}
+
+type symbolFilter struct {
+	FilterType string `json:"filterType"`
+	MinPrice   string `json:"minPrice,omitempty"`
+	MaxPrice   string `json:"maxPrice,omitempty"`
+	TickSize   string `json:"tickSize,omitempty"`
+	MinQty     string `json:"minQty,omitempty"`
+	MaxQty     string `json:"maxQty,omitempty"`
+	StepSize   string `json:"stepSize,omitempty"`
+}
+
+type symbolInfo struct {
+	Symbol               string          `json:"symbol"`
+	Status               string          `json:"status"`
+	BaseAsset            string          `json:"baseAsset"`
+	BaseAssetPrecision   int             `json:"baseAssetPrecision"`
+	QuoteAsset           string          `json:"quoteAsset"`
+	QuoteAssetPrecision  int             `json:"quoteAssetPrecision"`
+	OrderTypes           []string        `json:"orderTypes"`
+	IsSpotTradingAllowed bool            `json:"isSpotTradingAllowed"`
+	Filters              []*symbolFilter `json:"filters"`
+}
+
+type exchangeInfo struct {
+	Timezone   string        `json:"timezone"`
+	ServerTime int64         `json:"serverTime"`
+	RateLimits []struct{}    `json:"rateLimits"`
+	Symbols    []*symbolInfo `json:"symbols"`
+}
+
+// symbolInfo describes the market with the lot size as the LOT_SIZE filter's
+// step size and the rate step as the PRICE_FILTER's tick size.
+func (m *market) symbolInfo() *symbolInfo {
+	lotSize := formatQty(m.LotSize, m.baseFactor)
+	minPrice := m.MinimumRate
+	if minPrice < m.RateStep {
+		minPrice = m.RateStep
+	}
+	return &symbolInfo{
+		Symbol:               m.symbol,
+		Status:               "TRADING",
+		BaseAsset:            assetSymbol(m.BaseSymbol),
+		BaseAssetPrecision:   precision(m.baseFactor),
+		QuoteAsset:           assetSymbol(m.QuoteSymbol),
+		QuoteAssetPrecision:  precision(m.quoteFactor),
+		OrderTypes:           []string{typeLimit, typeMarket},
+		IsSpotTradingAllowed: true,
+		Filters: []*symbolFilter{{
+			FilterType: "PRICE_FILTER",
+			MinPrice:   m.formatRate(minPrice),
+			MaxPrice:   maxAmount,
+			TickSize:   m.formatRate(m.RateStep),
+		}, {
+			FilterType: "LOT_SIZE",
+			MinQty:     lotSize,
+			MaxQty:     maxAmount,
+			StepSize:   lotSize,
+		}},
+	}
+}
+
+type depth struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+// depthLevels aggregates the book orders, which are sorted best first, into
+// at most limit price levels.
+func (m *market) depthLevels(ords []*core.MiniOrder, limit int) [][2]string {
+	levels := make([][2]string, 0, limit)
+	var rate, qty uint64
+	add := func() {
+		levels = append(levels, [2]string{m.formatRate(rate), formatQty(qty, m.baseFactor)})
+	}
+	for _, o := range ords {
+		if o.MsgRate != rate && qty > 0 {
+			add()
+			if len(levels) == limit {
+				return levels
+			}
+			qty = 0
+		}
+		rate = o.MsgRate
+		qty += o.QtyAtomic
+	}
+	if qty > 0 {
+		add()
+	}
+	return levels
+}
+
+type balance struct {
+	Asset  string `json:"asset"`
+	Free   string `json:"free"`
+	Locked string `json:"locked"`
+}
+
+type account struct {
+	CanTrade    bool       `json:"canTrade"`
+	CanWithdraw bool       `json:"canWithdraw"`
+	CanDeposit  bool       `json:"canDeposit"`
+	AccountType string     `json:"accountType"`
+	UpdateTime  int64      `json:"updateTime"`
+	Balances    []*balance `json:"balances"`
+	Permissions []string   `json:"permissions"`
+}
+
+type orderResult struct {
+	Symbol             string `json:"symbol"`
+	OrderID            int64  `json:"orderId"`
+	OrderListID        int64  `json:"orderListId"`
+	ClientOrderID      string `json:"clientOrderId"`
+	TransactTime       int64  `json:"transactTime,omitempty"`
+	Time               int64  `json:"time"`
+	Price              string `json:"price"`
+	OrigQty            string `json:"origQty"`
+	OrigQuoteQty       string `json:"origQuoteOrderQty"`
+	ExecutedQty        string `json:"executedQty"`
+	CumulativeQuoteQty string `json:"cummulativeQuoteQty"`
+	Status             string `json:"status"`
+	TimeInForce        string `json:"timeInForce"`
+	Type               string `json:"type"`
+	Side               string `json:"side"`
+	IsWorking          bool   `json:"isWorking"`
+}
+
+// orderResult converts a DEX order. Market buys are sized in the quote asset,
+// so their origQty is zero and the quote quantity is the origQuoteOrderQty.
+func (m *market) orderResult(ord *core.Order, ref *orderRef) *orderResult {
+	var executed, executedQuote uint64
+	for _, match := range ord.Matches {
+		if match.IsCancel {
+			continue
+		}
+		executed += match.Qty
+		executedQuote += calc.BaseToQuote(match.Rate, match.Qty)
+	}
+	res := &orderResult{
+		Symbol:             m.symbol,
+		OrderID:            ref.num,
+		OrderListID:        -1,
+		ClientOrderID:      ref.clientID,
+		Time:               int64(ord.SubmitTime),
+		Price:              "0",
+		OrigQty:            formatQty(ord.Qty, m.baseFactor),
+		OrigQuoteQty:       "0",
+		ExecutedQty:        formatQty(executed, m.baseFactor),
+		CumulativeQuoteQty: formatQty(executedQuote, m.quoteFactor),
+		Type:               typeMarket,
+		TimeInForce:        tifGTC,
+		Side:               sideBuy,
+		IsWorking:          ord.Status == order.OrderStatusBooked,
+	}
+	if ord.Sell {
+		res.Side = sideSell
+	}
+	marketBuy := ord.Type == order.MarketOrderType && !ord.Sell
+	if marketBuy {
+		res.OrigQty = "0"
+		res.OrigQuoteQty = formatQty(ord.Qty, m.quoteFactor)
+	}
+	if ord.Type == order.LimitOrderType {
+		res.Type = typeLimit
+		res.Price = m.formatRate(ord.Rate)
+		if ord.TimeInForce == order.ImmediateTiF {
+			res.TimeInForce = tifIOC
+		}
+	}
+
+	switch ord.Status {
+	case order.OrderStatusEpoch, order.OrderStatusBooked:
+		switch {
+		case ord.Cancelling:
+			res.Status = statusPendingCancel
+		case executed > 0:
+			res.Status = statusPartiallyFilled
+		default:
+			res.Status = statusNew
+		}
+	case order.OrderStatusExecuted:
+		// An executed order that did not fill completely was an immediate
+		// order with an unmatched remainder.
+		if (marketBuy && executed > 0) || (!marketBuy && executed >= ord.Qty) {
+			res.Status = statusFilled
+		} else {
+			res.Status = statusExpired
+		}
+	case order.OrderStatusCanceled:
+		res.Status = statusCanceled
+	default: // revoked or unknown
+		res.Status = statusExpired
+	}
+	return res
+}
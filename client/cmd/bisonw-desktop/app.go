@@ -62,10 +62,14 @@ import (
 	"syscall"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/app"
 	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/bnserver"
 	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/grpcserver"
 	"decred.org/dcrdex/client/mm"
+	"decred.org/dcrdex/client/notify"
 	"decred.org/dcrdex/client/rpcserver"
 	"decred.org/dcrdex/client/webserver"
 	"decred.org/dcrdex/dex"
@@ -202,8 +206,36 @@ func mainCore() error {
 		cm.Wait()
 	}()
 
+	if cfg.NotifyCfg != "" {
+		notifyCfg, err := notify.LoadConfig(cfg.NotifyCfg)
+		if err != nil {
+			return err
+		}
+		dispatcher, err := notify.NewDispatcher(clientCore, notifyCfg, logMaker.Logger("NTFY"))
+		if err != nil {
+			return fmt.Errorf("error configuring notification sinks: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cm := dex.NewConnectionMaster(dispatcher)
+			if err := cm.ConnectOnce(appCtx); err != nil {
+				log.Errorf("Error starting notification sinks: %v", err)
+				return
+			}
+			cm.Wait()
+		}()
+	}
+
+	apiKeys, err := apikey.NewStore(cfg.AppData)
+	if err != nil {
+		return fmt.Errorf("error loading api keys: %w", err)
+	}
+
 	if cfg.RPCOn {
-		rpcSrv, err := rpcserver.New(cfg.RPC(clientCore, marketMaker, logMaker.Logger("RPC")))
+		rpcCfg := cfg.RPC(clientCore, marketMaker, logMaker.Logger("RPC"))
+		rpcCfg.APIKeys = apiKeys
+		rpcSrv, err := rpcserver.New(rpcCfg)
 		if err != nil {
 			return fmt.Errorf("failed to create rpc server: %w", err)
 		}
@@ -222,7 +254,51 @@ func mainCore() error {
 		}()
 	}
 
-	webSrv, err := webserver.New(cfg.Web(clientCore, marketMaker, logMaker.Logger("WEB"), utc))
+	if cfg.GRPCOn {
+		grpcCfg := cfg.GRPC(clientCore, marketMaker, logMaker.Logger("GRPC"))
+		grpcCfg.APIKeys = apiKeys
+		grpcSrv, err := grpcserver.New(grpcCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create grpc server: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cm := dex.NewConnectionMaster(grpcSrv)
+			err := cm.Connect(appCtx)
+			if err != nil {
+				log.Errorf("Error starting grpc server: %v", err)
+				cancel()
+				return
+			}
+			cm.Wait()
+		}()
+	}
+
+	if cfg.BNAPIOn {
+		bnSrv, err := bnserver.New(cfg.BNAPI(clientCore, logMaker.Logger("BNAPI")))
+		if err != nil {
+			return fmt.Errorf("failed to create binance api server: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cm := dex.NewConnectionMaster(bnSrv)
+			err := cm.Connect(appCtx)
+			if err != nil {
+				log.Errorf("Error starting binance api server: %v", err)
+				cancel()
+				return
+			}
+			cm.Wait()
+		}()
+	}
+
+	webCfg := cfg.Web(clientCore, marketMaker, logMaker.Logger("WEB"), utc)
+	webCfg.APIKeys = apiKeys
+	webSrv, err := webserver.New(webCfg)
 	if err != nil {
 		return fmt.Errorf("failed creating web server: %w", err)
 	}
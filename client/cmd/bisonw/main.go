@@ -17,11 +17,15 @@ import (
 	"sync"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/app"
 	"decred.org/dcrdex/client/asset"
 	_ "decred.org/dcrdex/client/asset/importall"
+	"decred.org/dcrdex/client/bnserver"
 	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/grpcserver"
 	"decred.org/dcrdex/client/mm"
+	"decred.org/dcrdex/client/notify"
 	"decred.org/dcrdex/client/rpcserver"
 	"decred.org/dcrdex/client/webserver"
 	"decred.org/dcrdex/dex"
@@ -33,7 +37,10 @@ const appName = "bisonw"
 var (
 	appCtx, cancel = context.WithCancel(context.Background())
 	webserverReady = make(chan string, 1)
-	log            dex.Logger
+	// closeWebserverReady closes webserverReady when running without a web
+	// server, which is only done once, even if the profile is switched.
+	closeWebserverReady sync.Once
+	log                 dex.Logger
 )
 
 func runCore(cfg *app.Config) error {
@@ -42,9 +49,9 @@ func runCore(cfg *app.Config) error {
 	asset.SetNetwork(cfg.Net)
 
 	// If explicitly running without web server then you must run the rpc
-	// server.
-	if cfg.NoWeb && !cfg.RPCOn {
-		return fmt.Errorf("cannot run without web server unless --rpc is specified")
+	// or grpc server.
+	if cfg.NoWeb && !cfg.RPCOn && !cfg.GRPCOn {
+		return fmt.Errorf("cannot run without web server unless --rpc or --grpc is specified")
 	}
 
 	if cfg.CPUProfile != "" {
@@ -60,6 +67,27 @@ func runCore(cfg *app.Config) error {
 		defer pprof.StopCPUProfile()
 	}
 
+	// Each profile has its own Core and market maker. Switching profiles
+	// shuts them down and starts them again with the new profile's config.
+	profiles := app.NewProfiles(cfg.AppData)
+	for {
+		next, err := runProfile(cfg, profiles)
+		if err != nil || next == "" {
+			return err
+		}
+		if cfg, err = cfg.WithProfile(next); err != nil {
+			return fmt.Errorf("error configuring profile %q: %w", next, err)
+		}
+	}
+}
+
+// runProfile runs the Core, market maker and servers for the profile
+// configured by cfg. If a switch to another profile is requested, everything
+// is stopped and the requested profile is returned.
+func runProfile(cfg *app.Config, profiles *app.Profiles) (nextProfile string, err error) {
+	ctx, stop := context.WithCancel(appCtx)
+	defer stop()
+
 	// Initialize logging.
 	utc := !cfg.LocalLogs
 	logMaker, closeLogger := app.InitLogging(cfg.LogPath, cfg.DebugLevel, true, utc)
@@ -70,7 +98,7 @@ func runCore(cfg *app.Config) error {
 		log.Infof("Logging with UTC time stamps. Current local time is %v",
 			time.Now().Local().Format("15:04:05 MST"))
 	}
-	log.Infof("bisonw starting for network: %s", cfg.Net)
+	log.Infof("bisonw starting for network: %s, profile: %s", cfg.Net, cfg.Profile)
 	log.Infof("Swap locktimes config: maker %s, taker %s",
 		dex.LockTimeMaker(cfg.Net), dex.LockTimeTaker(cfg.Net))
 
@@ -84,23 +112,38 @@ func runCore(cfg *app.Config) error {
 	// Prepare the Core.
 	clientCore, err := core.New(cfg.Core(logMaker.Logger("CORE")))
 	if err != nil {
-		return fmt.Errorf("error creating client core: %w", err)
+		return "", fmt.Errorf("error creating client core: %w", err)
 	}
 
 	marketMaker, err := mm.NewMarketMaker(clientCore, cfg.MMConfig.EventLogDBPath, cfg.MMConfig.BotConfigPath, logMaker.Logger("MM"))
 	if err != nil {
-		return fmt.Errorf("error creating market maker: %w", err)
+		return "", fmt.Errorf("error creating market maker: %w", err)
 	}
 
+	// Switching profiles requires logging out, which fails with active
+	// orders, and stopping any bots.
+	profiles.Activate(cfg.Profile, func() error {
+		if marketMaker != nil && len(marketMaker.RunningBotsStatus().Bots) > 0 {
+			return errors.New("bots are running")
+		}
+		return clientCore.Logout()
+	})
+
 	// Catch interrupt signal (e.g. ctrl+c), prompting to shutdown if the user
 	// is logged in, and there are active orders or matches.
 	killChan := make(chan os.Signal, 1)
 	signal.Notify(killChan, os.Interrupt)
+	defer signal.Stop(killChan)
 	go func() {
-		for range killChan {
-			if promptShutdown(clientCore) {
-				log.Infof("Shutting down...")
-				cancel()
+		for {
+			select {
+			case <-killChan:
+				if promptShutdown(clientCore) {
+					log.Infof("Shutting down...")
+					cancel()
+					return
+				}
+			case <-ctx.Done():
 				return
 			}
 		}
@@ -110,40 +153,81 @@ func runCore(cfg *app.Config) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		clientCore.Run(appCtx)
-		cancel() // in the event that Run returns prematurely prior to context cancellation
+		clientCore.Run(ctx)
+		stop() // in the event that Run returns prematurely prior to context cancellation
 	}()
 
 	<-clientCore.Ready()
 
 	var mmCM *dex.ConnectionMaster
 	defer func() {
-		log.Info("Exiting bisonw main.")
-		cancel()  // no-op with clean rpc/web server setup
+		log.Infof("Exiting bisonw profile %s.", cfg.Profile)
+		stop()    // no-op with clean rpc/web server setup
 		wg.Wait() // no-op with clean setup and shutdown
 		if mmCM != nil {
 			mmCM.Wait()
 		}
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case nextProfile = <-profiles.SwitchRequests():
+			log.Infof("Switching to profile %s...", nextProfile)
+			stop()
+		case <-ctx.Done():
+		}
+	}()
+
 	if marketMaker != nil {
 		mmCM = dex.NewConnectionMaster(marketMaker)
-		if err := mmCM.ConnectOnce(appCtx); err != nil {
-			return fmt.Errorf("Error connecting market maker")
+		if err := mmCM.ConnectOnce(ctx); err != nil {
+			return "", fmt.Errorf("Error connecting market maker")
 		}
 	}
 
+	if cfg.NotifyCfg != "" {
+		notifyCfg, err := notify.LoadConfig(cfg.NotifyCfg)
+		if err != nil {
+			return "", err
+		}
+		dispatcher, err := notify.NewDispatcher(clientCore, notifyCfg, logMaker.Logger("NTFY"))
+		if err != nil {
+			return "", fmt.Errorf("error configuring notification sinks: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cm := dex.NewConnectionMaster(dispatcher)
+			if err := cm.ConnectOnce(ctx); err != nil {
+				log.Errorf("Error starting notification sinks: %v", err)
+				return
+			}
+			cm.Wait()
+		}()
+	}
+
+	// API keys are shared by all profiles, like the RPC user and password.
+	apiKeys, err := apikey.NewStore(cfg.AppData)
+	if err != nil {
+		return "", fmt.Errorf("error loading api keys: %w", err)
+	}
+
 	if cfg.RPCOn {
-		rpcSrv, err := rpcserver.New(cfg.RPC(clientCore, marketMaker, logMaker.Logger("RPC")))
+		rpcCfg := cfg.RPC(clientCore, marketMaker, logMaker.Logger("RPC"))
+		rpcCfg.Profiles = profiles
+		rpcCfg.APIKeys = apiKeys
+		rpcSrv, err := rpcserver.New(rpcCfg)
 		if err != nil {
-			return fmt.Errorf("failed to create rpc server: %w", err)
+			return "", fmt.Errorf("failed to create rpc server: %w", err)
 		}
 
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			cm := dex.NewConnectionMaster(rpcSrv)
-			err := cm.Connect(appCtx)
+			err := cm.Connect(ctx)
 			if err != nil {
 				log.Errorf("Error starting rpc server: %v", err)
 				cancel()
@@ -153,33 +237,82 @@ func runCore(cfg *app.Config) error {
 		}()
 	}
 
+	if cfg.GRPCOn {
+		grpcCfg := cfg.GRPC(clientCore, marketMaker, logMaker.Logger("GRPC"))
+		grpcCfg.APIKeys = apiKeys
+		grpcSrv, err := grpcserver.New(grpcCfg)
+		if err != nil {
+			return "", fmt.Errorf("failed to create grpc server: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cm := dex.NewConnectionMaster(grpcSrv)
+			err := cm.Connect(ctx)
+			if err != nil {
+				log.Errorf("Error starting grpc server: %v", err)
+				cancel()
+				return
+			}
+			cm.Wait()
+		}()
+	}
+
+	if cfg.BNAPIOn {
+		bnSrv, err := bnserver.New(cfg.BNAPI(clientCore, logMaker.Logger("BNAPI")))
+		if err != nil {
+			return "", fmt.Errorf("failed to create binance api server: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cm := dex.NewConnectionMaster(bnSrv)
+			err := cm.Connect(ctx)
+			if err != nil {
+				log.Errorf("Error starting binance api server: %v", err)
+				cancel()
+				return
+			}
+			cm.Wait()
+		}()
+	}
+
 	if !cfg.NoWeb {
-		webSrv, err := webserver.New(cfg.Web(clientCore, marketMaker, logMaker.Logger("WEB"), utc))
+		webCfg := cfg.Web(clientCore, marketMaker, logMaker.Logger("WEB"), utc)
+		webCfg.Profiles = profiles
+		webCfg.APIKeys = apiKeys
+		webSrv, err := webserver.New(webCfg)
 		if err != nil {
-			return fmt.Errorf("failed creating web server: %w", err)
+			return "", fmt.Errorf("failed creating web server: %w", err)
 		}
 
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			cm := dex.NewConnectionMaster(webSrv)
-			err := cm.Connect(appCtx)
+			err := cm.Connect(ctx)
 			if err != nil {
 				log.Errorf("Error starting web server: %v", err)
 				cancel()
 				return
 			}
-			webserverReady <- webSrv.Addr()
+			// Only the first profile's address is received.
+			select {
+			case webserverReady <- webSrv.Addr():
+			default:
+			}
 			cm.Wait()
 		}()
 	} else {
-		close(webserverReady)
+		closeWebserverReady.Do(func() { close(webserverReady) })
 	}
 
 	// Wait for everything to stop.
 	wg.Wait()
 
-	return nil
+	return nextProfile, nil
 }
 
 // promptShutdown checks if there are active orders and asks confirmation to
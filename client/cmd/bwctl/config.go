@@ -36,8 +36,8 @@ type config struct {
 	ShowVersion  bool     `short:"V" long:"version" description:"Display version information and exit"`
 	ListCommands bool     `short:"l" long:"listcommands" description:"List all of the supported commands and exit"`
 	Config       string   `short:"C" long:"config" description:"Path to configuration file"`
-	RPCUser      string   `short:"u" long:"rpcuser" description:"RPC username"`
-	RPCPass      string   `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
+	RPCUser      string   `short:"u" long:"rpcuser" description:"RPC username, or an API key name"`
+	RPCPass      string   `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password, or an API key secret"`
 	RPCAddr      string   `short:"a" long:"rpcaddr" description:"RPC server to connect to"`
 	RPCCert      string   `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
 	PrintJSON    bool     `short:"j" long:"json" description:"Print json messages sent and received"`
@@ -47,6 +47,7 @@ type config struct {
 	PasswordArgs []string `short:"p" long:"passarg" description:"Password arguments to bypass stdin prompts."`
 	Testnet      bool     `long:"testnet" description:"use testnet"`
 	Simnet       bool     `long:"simnet" description:"use simnet"`
+	Profile      string   `long:"profile" description:"The bisonw user profile that commands are intended for. Commands fail if the profile is not active."`
 }
 
 // configure parses command line options and a config file if present. Returns
@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"os"
 
+	"decred.org/dcrdex/client/rpcserver"
 	"decred.org/dcrdex/dex/msgjson"
 	"github.com/decred/go-socks/socks"
 )
@@ -88,6 +89,9 @@ func sendPostRequest(marshalledJSON []byte, cfg *config) (*msgjson.Message, erro
 	}
 	httpRequest.Close = true
 	httpRequest.Header.Set("Content-Type", "application/json")
+	if cfg.Profile != "" {
+		httpRequest.Header.Set(rpcserver.ProfileHeader, cfg.Profile)
+	}
 
 	// Configure basic access authorization.
 	httpRequest.SetBasicAuth(cfg.RPCUser, cfg.RPCPass)
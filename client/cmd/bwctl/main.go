@@ -54,10 +54,17 @@ var promptPasswords = map[string][]string{
 	"openwallet":        {"App password:"},
 	"register":          {"App password:"},
 	"postbond":          {"App password:"},
+	"postbondpsbt":      {"App password:"},
+	"postmeshbond":      {"App password:"},
 	"trade":             {"App password:"},
 	"withdraw":          {"App password:"},
 	"send":              {"App password:"},
+	"sendmulti":         {"App password:"},
+	"conditionalorder":  {"App password:"},
+	"routedtrade":       {"App password:"},
 	"appseed":           {"App password:"},
+	"exportappstate":    {"App password:"},
+	"restoreappstate":   {"Backup password:"},
 	"startmarketmaking": {"App password:"},
 	"multitrade":        {"App password:"},
 	"purchasetickets":   {"App password:"},
@@ -69,12 +76,16 @@ var promptPasswords = map[string][]string{
 // the text content of a file, where the file path _may_ be found in the route's
 // cmd args at the specified index.
 var optionalTextFiles = map[string]int{
-	"discoveracct": 1,
-	"bondassets":   1,
-	"postbond":     4,
-	"getdexconfig": 1,
-	"register":     3,
-	"newwallet":    2,
+	"discoveracct":    1,
+	"bondassets":      1,
+	"postbond":        4,
+	"postbondpsbt":    4,
+	"getdexconfig":    1,
+	"register":        3,
+	"newwallet":       2,
+	"broadcastpsbt":   1,
+	"abandonpsbt":     1,
+	"restoreappstate": 0,
 }
 
 // promptPWs prompts for passwords on stdin and returns an error if prompting
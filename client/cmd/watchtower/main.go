@@ -0,0 +1,127 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package main
+
+/*
+ * watchtower is a standalone swap watchtower. Clients register their active
+ * swaps, and the watchtower broadcasts refunds after lock time, or redeems
+ * when the counterparty's secret appears on chain, while the clients are
+ * offline. Each supported asset needs a full node RPC connection, specified
+ * with a --node flag as <assetID>,<host:port>,<user>,<pass>. e.g.
+ *
+ *   watchtower --addr=:7720 --node=0,127.0.0.1:8332,user,pass
+ */
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/watchtower"
+	"decred.org/dcrdex/dex"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+var log = dex.StdOutLogger("WT", dex.LevelInfo)
+
+// nodeFlags are the repeatable --node flags.
+type nodeFlags []string
+
+func (n *nodeFlags) String() string {
+	return strings.Join(*n, " ")
+}
+
+func (n *nodeFlags) Set(s string) error {
+	*n = append(*n, s)
+	return nil
+}
+
+func main() {
+	if err := mainErr(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainErr() error {
+	var addr, appDir string
+	var logDebug bool
+	var checkInterval time.Duration
+	var nodes nodeFlags
+	flag.StringVar(&addr, "addr", "127.0.0.1:7720", "HTTP listen address")
+	flag.StringVar(&appDir, "appdata", dcrutil.AppDataDir("dexwatchtower", false), "application data directory")
+	flag.DurationVar(&checkInterval, "interval", 30*time.Second, "interval between checks of watched swaps")
+	flag.Var(&nodes, "node", "full node RPC for an asset, <assetID>,<host:port>,<user>,<pass>. repeatable")
+	flag.BoolVar(&logDebug, "debug", false, "use debug logging")
+	flag.Parse()
+
+	if logDebug {
+		log = dex.StdOutLogger("WT", dex.LevelDebug)
+	}
+	if len(nodes) == 0 {
+		return errors.New("no --node configured")
+	}
+
+	chains := make(map[uint32]watchtower.Chain, len(nodes))
+	for _, n := range nodes {
+		parts := strings.Split(n, ",")
+		if len(parts) != 4 {
+			return fmt.Errorf("invalid --node %q", n)
+		}
+		assetID, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid asset ID in --node %q", n)
+		}
+		symbol := dex.BipIDSymbol(uint32(assetID))
+		var newChain func(host, user, pass string, log dex.Logger) (*watchtower.BTCChain, error)
+		switch symbol {
+		case "btc":
+			newChain = watchtower.NewBTCChain
+		case "bch":
+			newChain = watchtower.NewBCHChain
+		default:
+			return fmt.Errorf("asset %d (%s) is not supported", assetID, symbol)
+		}
+		chain, err := newChain(parts[1], parts[2], parts[3], log.SubLogger(strings.ToUpper(symbol)))
+		if err != nil {
+			return fmt.Errorf("error connecting to %s node: %w", symbol, err)
+		}
+		defer chain.Shutdown()
+		chains[uint32(assetID)] = chain
+	}
+
+	if err := os.MkdirAll(appDir, 0700); err != nil {
+		return fmt.Errorf("error creating app directory: %w", err)
+	}
+	srv, err := watchtower.NewServer(&watchtower.Config{
+		DBPath:        filepath.Join(appDir, "watchtower.db"),
+		Addr:          addr,
+		Chains:        chains,
+		Logger:        log,
+		CheckInterval: checkInterval,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	killChan := make(chan os.Signal, 1)
+	signal.Notify(killChan, os.Interrupt)
+	go func() {
+		<-killChan
+		log.Info("Shutting down...")
+		cancel()
+	}()
+
+	return srv.Run(ctx)
+}
@@ -0,0 +1,545 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/msgjson"
+)
+
+const (
+	// marketAlertTick is how often the market alerts are checked in the
+	// absence of order book, spot price, and fiat rate updates.
+	marketAlertTick = 30 * time.Second
+	// volumeBaselineWindow is the trailing period over which the 24-hour
+	// volume is averaged for volume alerts.
+	volumeBaselineWindow = 6 * time.Hour
+	// volumeBaselineMinAge is how much volume history is needed before a
+	// volume alert can fire.
+	volumeBaselineMinAge = time.Hour
+	// volumeSampleInterval is the minimum time between volume samples.
+	volumeSampleInterval = 5 * time.Minute
+)
+
+// MarketAlertForm is used to create a market alert. The fields used depend on
+// the Kind. See db.MarketAlert.
+type MarketAlertForm struct {
+	// Kind is db.AlertPrice, db.AlertSpread, db.AlertVolume, or db.AlertFiat.
+	Kind string `json:"kind"`
+	// Host, Base and Quote identify the market. For a db.AlertFiat alert, only
+	// Base is used, and is the asset whose fiat rate is watched.
+	Host  string `json:"host"`
+	Base  uint32 `json:"base"`
+	Quote uint32 `json:"quote"`
+	// Rate and Above are the mid-gap message-rate and direction for a
+	// db.AlertPrice alert.
+	Rate  uint64 `json:"rate"`
+	Above bool   `json:"above"`
+	// Spread is the spread, as a fraction of the mid-gap rate, for a
+	// db.AlertSpread alert.
+	Spread float64 `json:"spread"`
+	// Percent is the fiat rate change, in percent, for a db.AlertFiat alert,
+	// or the rise of the 24-hour volume above its trailing average, in
+	// percent, for a db.AlertVolume alert.
+	Percent float64 `json:"percent"`
+	// Repeat alerts stay active after firing.
+	Repeat bool `json:"repeat"`
+}
+
+// marketAlerts tracks the active market alerts and the order book feeds needed
+// to evaluate them.
+type marketAlerts struct {
+	mtx    sync.Mutex
+	active map[string]*db.MarketAlert
+	// fired is the repeating alerts that have fired and are waiting for the
+	// condition to clear before they can fire again.
+	fired map[string]bool
+	feeds map[string]context.CancelFunc
+	// volumes is the recent 24-hour volume samples of the markets with volume
+	// alerts, oldest first.
+	volumes map[string][]*volumeSample
+	check   chan struct{}
+}
+
+// volumeSample is the 24-hour volume of a market at a point in time.
+type volumeSample struct {
+	stamp time.Time
+	vol   uint64
+}
+
+func newMarketAlerts() *marketAlerts {
+	return &marketAlerts{
+		active:  make(map[string]*db.MarketAlert),
+		fired:   make(map[string]bool),
+		feeds:   make(map[string]context.CancelFunc),
+		volumes: make(map[string][]*volumeSample),
+		check:   make(chan struct{}, 1),
+	}
+}
+
+// marketAlertKey identifies the market of the alert.
+func marketAlertKey(alert *db.MarketAlert) string {
+	return alert.Host + "|" + marketName(alert.Base, alert.Quote)
+}
+
+// recheck schedules a check of the alerts.
+func (ma *marketAlerts) recheck() {
+	select {
+	case ma.check <- struct{}{}:
+	default:
+	}
+}
+
+// validateMarketAlertForm checks the alert parameters, returning the
+// normalized host for a market alert.
+func (c *Core) validateMarketAlertForm(form *MarketAlertForm) (string, error) {
+	switch form.Kind {
+	case db.AlertPrice:
+		if form.Rate == 0 {
+			return "", newError(orderParamsErr, "zero alert rate")
+		}
+	case db.AlertSpread:
+		if form.Spread <= 0 || form.Spread >= 1 {
+			return "", newError(orderParamsErr, "alert spread %f is not between 0 and 1", form.Spread)
+		}
+	case db.AlertVolume:
+		if form.Percent <= 0 {
+			return "", newError(orderParamsErr, "invalid alert percent %f", form.Percent)
+		}
+	case db.AlertFiat:
+		if form.Percent <= 0 {
+			return "", newError(orderParamsErr, "invalid alert percent %f", form.Percent)
+		}
+		if _, err := asset.UnitInfo(form.Base); err != nil {
+			return "", newError(assetSupportErr, "unsupported asset %d", form.Base)
+		}
+		return "", nil
+	default:
+		return "", newError(orderParamsErr, "unknown market alert kind %q", form.Kind)
+	}
+	dc, _, err := c.dex(form.Host)
+	if err != nil {
+		return "", err
+	}
+	mktID := marketName(form.Base, form.Quote)
+	if dc.marketConfig(mktID) == nil {
+		return "", newError(marketErr, "unknown market %q", mktID)
+	}
+	return dc.acct.host, nil
+}
+
+// AddMarketAlert creates a market alert. Core fires a MarketAlertNote when the
+// alert's condition is met.
+func (c *Core) AddMarketAlert(form *MarketAlertForm) (*db.MarketAlert, error) {
+	host, err := c.validateMarketAlertForm(form)
+	if err != nil {
+		return nil, err
+	}
+	alert := &db.MarketAlert{
+		ID:      encode.RandomBytes(8),
+		Kind:    form.Kind,
+		Host:    host,
+		Base:    form.Base,
+		Quote:   form.Quote,
+		Rate:    form.Rate,
+		Above:   form.Above,
+		Spread:  form.Spread,
+		Percent: form.Percent,
+		Repeat:  form.Repeat,
+		Stamp:   uint64(time.Now().UnixMilli()),
+	}
+	switch alert.Kind {
+	case db.AlertFiat:
+		// Without a current rate, the reference rate is set when the first
+		// rate is available.
+		alert.RefFiatRate = c.fiatConversions()[alert.Base]
+	case db.AlertVolume:
+		// The rise is measured from the trailing average volume.
+	default:
+		alert.Percent = 0
+	}
+
+	ma := c.alerts
+	ma.mtx.Lock()
+	if err := c.db.UpdateMarketAlert(alert); err != nil {
+		ma.mtx.Unlock()
+		return nil, fmt.Errorf("error storing market alert: %w", err)
+	}
+	ma.active[alert.ID.String()] = alert
+	cp := *alert
+	ma.mtx.Unlock()
+
+	ma.recheck()
+	return &cp, nil
+}
+
+// MarketAlerts returns all market alerts, newest first.
+func (c *Core) MarketAlerts() ([]*db.MarketAlert, error) {
+	c.alerts.mtx.Lock()
+	alerts, err := c.db.MarketAlerts()
+	c.alerts.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Stamp > alerts[j].Stamp })
+	return alerts, nil
+}
+
+// RemoveMarketAlert deletes a market alert.
+func (c *Core) RemoveMarketAlert(id dex.Bytes) error {
+	ma := c.alerts
+	ma.mtx.Lock()
+	defer ma.mtx.Unlock()
+	if err := c.db.DeleteMarketAlert(id); err != nil {
+		return err
+	}
+	delete(ma.active, id.String())
+	delete(ma.fired, id.String())
+	ma.recheck()
+	return nil
+}
+
+// watchMarketAlerts loads the active market alerts and checks them until the
+// context is canceled.
+func (c *Core) watchMarketAlerts(ctx context.Context) {
+	ma := c.alerts
+	alerts, err := c.db.MarketAlerts()
+	if err != nil {
+		c.log.Errorf("Error loading market alerts: %v", err)
+	}
+	ma.mtx.Lock()
+	for _, alert := range alerts {
+		if alert.Status == db.MarketAlertActive {
+			ma.active[alert.ID.String()] = alert
+		}
+	}
+	ma.mtx.Unlock()
+
+	defer func() {
+		ma.mtx.Lock()
+		for k, stop := range ma.feeds {
+			stop()
+			delete(ma.feeds, k)
+		}
+		ma.mtx.Unlock()
+	}()
+
+	// Spot price and fiat rate updates prompt a check.
+	feed := c.NotificationFeed()
+	defer feed.ReturnFeed()
+	go func() {
+		for {
+			select {
+			case n := <-feed.C:
+				if t := n.Type(); t == NoteTypeSpots || t == NoteTypeFiatRates {
+					ma.recheck()
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	tick := time.NewTicker(marketAlertTick)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		case <-ma.check:
+		}
+		c.checkMarketAlerts(ctx)
+	}
+}
+
+// syncMarketAlertBooks subscribes to the order books needed for price and
+// spread alerts, and closes those no longer needed.
+func (c *Core) syncMarketAlertBooks(ctx context.Context) {
+	ma := c.alerts
+	ma.mtx.Lock()
+	needed := make(map[string]*db.MarketAlert)
+	for _, alert := range ma.active {
+		if alert.Kind == db.AlertPrice || alert.Kind == db.AlertSpread {
+			needed[marketAlertKey(alert)] = alert
+		}
+	}
+	for k, stop := range ma.feeds {
+		if needed[k] == nil {
+			stop()
+			delete(ma.feeds, k)
+		}
+	}
+	for k := range needed {
+		if ma.feeds[k] != nil {
+			delete(needed, k)
+		}
+	}
+	ma.mtx.Unlock()
+
+	// Subscribe without holding the mutex, since SyncBook may need to request
+	// the book from the server. A feed that is no longer needed by the time
+	// it is added is closed on the next check.
+	for k, alert := range needed {
+		_, feed, err := c.SyncBook(alert.Host, alert.Base, alert.Quote)
+		if err != nil {
+			c.log.Debugf("Unable to sync %s book at %s for market alerts: %v",
+				marketName(alert.Base, alert.Quote), alert.Host, err)
+			continue
+		}
+		feedCtx, stop := context.WithCancel(ctx)
+		ma.mtx.Lock()
+		if ma.feeds[k] != nil {
+			ma.mtx.Unlock()
+			stop()
+			feed.Close()
+			continue
+		}
+		ma.feeds[k] = stop
+		ma.mtx.Unlock()
+		go func() {
+			defer feed.Close()
+			for {
+				select {
+				case <-feed.Next():
+					ma.recheck()
+				case <-feedCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+// marketAlertData is the market data used to evaluate alerts. Zero values
+// indicate that the data is not available.
+type marketAlertData struct {
+	midGap   uint64
+	spread   float64
+	vol24    uint64
+	fiatRate float64
+	// volBaseline is the trailing average of the 24-hour volume.
+	volBaseline float64
+}
+
+// marketSpot is the latest spot price of the market, or nil if unknown.
+func (c *Core) marketSpot(host string, base, quote uint32) *msgjson.Spot {
+	dc, _, err := c.dex(host)
+	if err != nil {
+		return nil
+	}
+	dc.spotsMtx.RLock()
+	defer dc.spotsMtx.RUnlock()
+	return dc.spots[marketName(base, quote)]
+}
+
+// volumeBaselines samples the 24-hour volume of the markets with volume
+// alerts, and returns the trailing average volume of each market that has
+// enough history. The marketAlerts mtx must be held.
+func (c *Core) volumeBaselines() map[string]float64 {
+	ma := c.alerts
+	now := time.Now()
+	baselines := make(map[string]float64)
+	volumes := make(map[string][]*volumeSample)
+	for _, alert := range ma.active {
+		k := marketAlertKey(alert)
+		if alert.Kind != db.AlertVolume || volumes[k] != nil {
+			continue
+		}
+		samples := ma.volumes[k]
+		for len(samples) > 0 && now.Sub(samples[0].stamp) > volumeBaselineWindow {
+			samples = samples[1:]
+		}
+		if len(samples) > 0 && now.Sub(samples[0].stamp) >= volumeBaselineMinAge {
+			var sum float64
+			for _, s := range samples {
+				sum += float64(s.vol)
+			}
+			baselines[k] = sum / float64(len(samples))
+		}
+		spot := c.marketSpot(alert.Host, alert.Base, alert.Quote)
+		if spot != nil && (len(samples) == 0 || now.Sub(samples[len(samples)-1].stamp) >= volumeSampleInterval) {
+			samples = append(samples, &volumeSample{stamp: now, vol: spot.Vol24})
+		}
+		volumes[k] = samples
+	}
+	ma.volumes = volumes
+	return baselines
+}
+
+// marketAlertData gets the current market data for the alert. The order book
+// mid-gap rate is used for price alerts, falling back to the spot rate if the
+// book is not synced.
+func (c *Core) marketAlertData(alert *db.MarketAlert, fiatRates map[uint32]float64, volBaselines map[string]float64) *marketAlertData {
+	if alert.Kind == db.AlertFiat {
+		return &marketAlertData{fiatRate: fiatRates[alert.Base]}
+	}
+	dc, _, err := c.dex(alert.Host)
+	if err != nil {
+		return &marketAlertData{}
+	}
+	mktID := marketName(alert.Base, alert.Quote)
+	d := &marketAlertData{volBaseline: volBaselines[marketAlertKey(alert)]}
+	if book := dc.bookie(mktID); book != nil {
+		if midGap, err := book.MidGap(); err == nil {
+			d.midGap = midGap
+			bids, _, _ := book.BestNOrders(1, false)
+			asks, _, _ := book.BestNOrders(1, true)
+			if len(bids) == 1 && len(asks) == 1 && asks[0].Rate >= bids[0].Rate {
+				d.spread = float64(asks[0].Rate-bids[0].Rate) / float64(midGap)
+			}
+		}
+	}
+	if spot := c.marketSpot(alert.Host, alert.Base, alert.Quote); spot != nil {
+		if d.midGap == 0 {
+			d.midGap = spot.Rate
+		}
+		d.vol24 = spot.Vol24
+	}
+	return d
+}
+
+// marketAlertTriggered checks if the alert's condition is met.
+func marketAlertTriggered(alert *db.MarketAlert, d *marketAlertData) bool {
+	switch alert.Kind {
+	case db.AlertPrice:
+		if d.midGap == 0 {
+			return false
+		}
+		if alert.Above {
+			return d.midGap >= alert.Rate
+		}
+		return d.midGap <= alert.Rate
+	case db.AlertSpread:
+		return d.spread > 0 && d.spread <= alert.Spread
+	case db.AlertVolume:
+		if d.volBaseline <= 0 || alert.Percent <= 0 {
+			return false
+		}
+		return (float64(d.vol24)-d.volBaseline)/d.volBaseline*100 >= alert.Percent
+	case db.AlertFiat:
+		if d.fiatRate <= 0 || alert.RefFiatRate <= 0 {
+			return false
+		}
+		return math.Abs(d.fiatRate-alert.RefFiatRate)/alert.RefFiatRate*100 >= alert.Percent
+	}
+	return false
+}
+
+// checkMarketAlerts fires the alerts whose conditions are met.
+func (c *Core) checkMarketAlerts(ctx context.Context) {
+	c.syncMarketAlertBooks(ctx)
+
+	ma := c.alerts
+	ma.mtx.Lock()
+	if len(ma.active) == 0 {
+		ma.mtx.Unlock()
+		return
+	}
+
+	var fiatRates map[uint32]float64
+	for _, alert := range ma.active {
+		if alert.Kind == db.AlertFiat {
+			fiatRates = c.fiatConversions()
+			break
+		}
+	}
+
+	volBaselines := c.volumeBaselines()
+
+	var notes []Notification
+	for k, alert := range ma.active {
+		d := c.marketAlertData(alert, fiatRates, volBaselines)
+		if alert.Kind == db.AlertFiat && alert.RefFiatRate == 0 && d.fiatRate > 0 {
+			alert.RefFiatRate = d.fiatRate
+			if err := c.db.UpdateMarketAlert(alert); err != nil {
+				c.log.Errorf("Error storing market alert %s: %v", alert.ID, err)
+			}
+			continue
+		}
+		if !marketAlertTriggered(alert, d) {
+			delete(ma.fired, k)
+			continue
+		}
+		if ma.fired[k] {
+			continue
+		}
+		note := c.marketAlertNote(alert, d)
+		alert.FiredStamp = uint64(time.Now().UnixMilli())
+		switch {
+		case !alert.Repeat:
+			alert.Status = db.MarketAlertTriggered
+			delete(ma.active, k)
+		case alert.Kind == db.AlertFiat:
+			// Measure the next move from here.
+			alert.RefFiatRate = d.fiatRate
+		default:
+			ma.fired[k] = true
+		}
+		if err := c.db.UpdateMarketAlert(alert); err != nil {
+			c.log.Errorf("Error storing fired market alert %s: %v", alert.ID, err)
+		}
+		cp := *alert
+		note.Alert = &cp
+		notes = append(notes, note)
+	}
+	ma.mtx.Unlock()
+
+	for _, n := range notes {
+		c.notify(n)
+	}
+}
+
+// marketAlertNote creates the notification for a fired alert.
+func (c *Core) marketAlertNote(alert *db.MarketAlert, d *marketAlertData) *MarketAlertNote {
+	var topic Topic
+	var subject, details string
+	switch alert.Kind {
+	case db.AlertFiat:
+		topic = TopicFiatRateAlert
+		change := (d.fiatRate - alert.RefFiatRate) / alert.RefFiatRate * 100
+		subject, details = c.formatDetails(topic, unbip(alert.Base), change, d.fiatRate)
+	case db.AlertPrice:
+		topic = TopicPriceAlert
+		subject, details = c.formatDetails(topic, c.alertMarketName(alert), alert.Host,
+			c.alertRateString(alert, d.midGap), c.alertRateString(alert, alert.Rate))
+	case db.AlertSpread:
+		topic = TopicSpreadAlert
+		subject, details = c.formatDetails(topic, c.alertMarketName(alert), alert.Host, d.spread*100, alert.Spread*100)
+	case db.AlertVolume:
+		topic = TopicVolumeAlert
+		change := (float64(d.vol24) - d.volBaseline) / d.volBaseline * 100
+		vol, baseline := fmt.Sprint(d.vol24), fmt.Sprintf("%.0f", d.volBaseline)
+		if ui, err := asset.UnitInfo(alert.Base); err == nil {
+			vol, baseline = ui.FormatAtoms(d.vol24), ui.FormatAtoms(uint64(math.Round(d.volBaseline)))
+		}
+		subject, details = c.formatDetails(topic, c.alertMarketName(alert), alert.Host, change, vol, baseline)
+	}
+	return newMarketAlertNote(topic, subject, details, db.Success, alert)
+}
+
+func (c *Core) alertMarketName(alert *db.MarketAlert) string {
+	return fmt.Sprintf("%s-%s", unbip(alert.Base), unbip(alert.Quote))
+}
+
+// alertRateString formats the message-rate as a conventional rate, if the
+// market is known.
+func (c *Core) alertRateString(alert *db.MarketAlert, msgRate uint64) string {
+	if dc, _, err := c.dex(alert.Host); err == nil {
+		if mkt := dc.coreMarket(marketName(alert.Base, alert.Quote)); mkt != nil {
+			return fmt.Sprintf("%.8g", mkt.MsgRateToConventional(msgRate))
+		}
+	}
+	return fmt.Sprint(msgRate)
+}
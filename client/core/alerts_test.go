@@ -0,0 +1,223 @@
+//go:build !harness && !botlive
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex/msgjson"
+)
+
+func TestMarketAlertTriggered(t *testing.T) {
+	tests := []struct {
+		name  string
+		alert *db.MarketAlert
+		data  *marketAlertData
+		exp   bool
+	}{
+		{"price above, below", &db.MarketAlert{Kind: db.AlertPrice, Rate: 100, Above: true}, &marketAlertData{midGap: 99}, false},
+		{"price above, at", &db.MarketAlert{Kind: db.AlertPrice, Rate: 100, Above: true}, &marketAlertData{midGap: 100}, true},
+		{"price below, above", &db.MarketAlert{Kind: db.AlertPrice, Rate: 100}, &marketAlertData{midGap: 101}, false},
+		{"price below, below", &db.MarketAlert{Kind: db.AlertPrice, Rate: 100}, &marketAlertData{midGap: 99}, true},
+		{"price below, no rate", &db.MarketAlert{Kind: db.AlertPrice, Rate: 100}, &marketAlertData{}, false},
+		{"spread wide", &db.MarketAlert{Kind: db.AlertSpread, Spread: 0.01}, &marketAlertData{spread: 0.02}, false},
+		{"spread narrow", &db.MarketAlert{Kind: db.AlertSpread, Spread: 0.01}, &marketAlertData{spread: 0.005}, true},
+		{"spread unknown", &db.MarketAlert{Kind: db.AlertSpread, Spread: 0.01}, &marketAlertData{}, false},
+		{"volume small rise", &db.MarketAlert{Kind: db.AlertVolume, Percent: 50}, &marketAlertData{vol24: 1499, volBaseline: 1000}, false},
+		{"volume spike", &db.MarketAlert{Kind: db.AlertVolume, Percent: 50}, &marketAlertData{vol24: 1500, volBaseline: 1000}, true},
+		{"volume drop", &db.MarketAlert{Kind: db.AlertVolume, Percent: 50}, &marketAlertData{vol24: 400, volBaseline: 1000}, false},
+		{"volume no baseline", &db.MarketAlert{Kind: db.AlertVolume, Percent: 50}, &marketAlertData{vol24: 1500}, false},
+		{"volume no percent", &db.MarketAlert{Kind: db.AlertVolume}, &marketAlertData{vol24: 1500, volBaseline: 1000}, false},
+		{"fiat small move", &db.MarketAlert{Kind: db.AlertFiat, Percent: 5, RefFiatRate: 10}, &marketAlertData{fiatRate: 10.4}, false},
+		{"fiat up", &db.MarketAlert{Kind: db.AlertFiat, Percent: 5, RefFiatRate: 10}, &marketAlertData{fiatRate: 10.5}, true},
+		{"fiat down", &db.MarketAlert{Kind: db.AlertFiat, Percent: 5, RefFiatRate: 10}, &marketAlertData{fiatRate: 9.4}, true},
+		{"fiat no reference", &db.MarketAlert{Kind: db.AlertFiat, Percent: 5}, &marketAlertData{fiatRate: 9.4}, false},
+	}
+	for _, tt := range tests {
+		if triggered := marketAlertTriggered(tt.alert, tt.data); triggered != tt.exp {
+			t.Fatalf("%s: expected triggered = %t, got %t", tt.name, tt.exp, triggered)
+		}
+	}
+}
+
+func TestMarketAlerts(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+
+	// Bad forms.
+	for name, form := range map[string]*MarketAlertForm{
+		"bad kind":      {Kind: "vibes", Host: tDexHost, Base: tUTXOAssetA.ID, Quote: tUTXOAssetB.ID},
+		"zero rate":     {Kind: db.AlertPrice, Host: tDexHost, Base: tUTXOAssetA.ID, Quote: tUTXOAssetB.ID},
+		"bad spread":    {Kind: db.AlertSpread, Host: tDexHost, Base: tUTXOAssetA.ID, Quote: tUTXOAssetB.ID, Spread: 1.5},
+		"zero volume %": {Kind: db.AlertVolume, Host: tDexHost, Base: tUTXOAssetA.ID, Quote: tUTXOAssetB.ID},
+		"unknown host":  {Kind: db.AlertVolume, Host: "otherdex.tld", Base: tUTXOAssetA.ID, Quote: tUTXOAssetB.ID, Percent: 1},
+		"bad market":    {Kind: db.AlertVolume, Host: tDexHost, Base: tUTXOAssetA.ID, Quote: 12345, Percent: 1},
+		"zero percent":  {Kind: db.AlertFiat, Base: tUTXOAssetA.ID},
+		"unknown asset": {Kind: db.AlertFiat, Base: 12345, Percent: 1},
+	} {
+		if _, err := tCore.AddMarketAlert(form); err == nil {
+			t.Fatalf("%s: no error", name)
+		}
+	}
+
+	volAlert, err := tCore.AddMarketAlert(&MarketAlertForm{
+		Kind:    db.AlertVolume,
+		Host:    tDexHost,
+		Base:    tUTXOAssetA.ID,
+		Quote:   tUTXOAssetB.ID,
+		Percent: 50,
+	})
+	if err != nil {
+		t.Fatalf("AddMarketAlert (volume) error: %v", err)
+	}
+	time.Sleep(time.Millisecond) // distinct stamps
+	fiatAlert, err := tCore.AddMarketAlert(&MarketAlertForm{
+		Kind:    db.AlertFiat,
+		Base:    tUTXOAssetA.ID,
+		Percent: 10,
+		Repeat:  true,
+	})
+	if err != nil {
+		t.Fatalf("AddMarketAlert (fiat) error: %v", err)
+	}
+
+	alerts, err := tCore.MarketAlerts()
+	if err != nil {
+		t.Fatalf("MarketAlerts error: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 market alerts, got %d", len(alerts))
+	}
+	if !alerts[0].ID.Equal(fiatAlert.ID) {
+		t.Fatalf("market alerts not sorted newest first")
+	}
+
+	feed := tCore.NotificationFeed()
+	defer feed.ReturnFeed()
+	expectNote := func(topic Topic) *MarketAlertNote {
+		t.Helper()
+		for {
+			select {
+			case n := <-feed.C:
+				if note, ok := n.(*MarketAlertNote); ok {
+					if note.Topic() != topic {
+						t.Fatalf("expected %s note, got %s", topic, note.Topic())
+					}
+					return note
+				}
+			default:
+				t.Fatalf("no %s note", topic)
+			}
+		}
+	}
+	expectNoNote := func() {
+		t.Helper()
+		for {
+			select {
+			case n := <-feed.C:
+				if _, ok := n.(*MarketAlertNote); ok {
+					t.Fatalf("unexpected %s note", n.Topic())
+				}
+			default:
+				return
+			}
+		}
+	}
+	stored := func(id []byte) *db.MarketAlert {
+		t.Helper()
+		for _, a := range rig.db.marketAlerts {
+			if a.ID.Equal(id) {
+				return a
+			}
+		}
+		t.Fatalf("market alert %x not stored", id)
+		return nil
+	}
+
+	// No data yet. Nothing fires.
+	tCore.checkMarketAlerts(tCtx)
+	expectNoNote()
+
+	// The first fiat rate sets the reference rate.
+	source := newCommonRateSource(tFetcher)
+	source.fiatRates = map[uint32]*fiatRateInfo{
+		tUTXOAssetA.ID: {rate: 20, lastUpdate: time.Now()},
+	}
+	tCore.fiatRateSources["test"] = source
+	tCore.checkMarketAlerts(tCtx)
+	expectNoNote()
+	if ref := stored(fiatAlert.ID).RefFiatRate; ref != 20 {
+		t.Fatalf("expected reference rate 20, got %f", ref)
+	}
+
+	// The rate moves 10%. The repeating alert fires and measures from the new
+	// rate.
+	source.fiatRates[tUTXOAssetA.ID] = &fiatRateInfo{rate: 22, lastUpdate: time.Now()}
+	tCore.checkMarketAlerts(tCtx)
+	if note := expectNote(TopicFiatRateAlert); note.Alert.RefFiatRate != 22 {
+		t.Fatalf("reference rate not updated in note")
+	}
+	if a := stored(fiatAlert.ID); a.Status != db.MarketAlertActive || a.FiredStamp == 0 {
+		t.Fatalf("repeating alert not active after firing")
+	}
+	tCore.checkMarketAlerts(tCtx)
+	expectNoNote()
+
+	// Without enough volume history, a volume spike does not fire.
+	mktID := marketName(tUTXOAssetA.ID, tUTXOAssetB.ID)
+	setVol := func(vol uint64) {
+		rig.dc.spotsMtx.Lock()
+		rig.dc.spots[mktID] = &msgjson.Spot{Vol24: vol}
+		rig.dc.spotsMtx.Unlock()
+	}
+	setVol(1e8)
+	tCore.checkMarketAlerts(tCtx)
+	volKey := marketAlertKey(volAlert)
+	if samples := tCore.alerts.volumes[volKey]; len(samples) != 1 || samples[0].vol != 1e8 {
+		t.Fatalf("volume not sampled")
+	}
+	setVol(2e8)
+	tCore.checkMarketAlerts(tCtx)
+	expectNoNote()
+
+	// With a trailing average of 1e8, a rise to 1.4e8 is not a spike.
+	now := time.Now()
+	tCore.alerts.volumes[volKey] = []*volumeSample{
+		{stamp: now.Add(-volumeBaselineWindow - time.Minute), vol: 5e8}, // dropped
+		{stamp: now.Add(-2 * time.Hour), vol: 0.8e8},
+		{stamp: now.Add(-time.Hour), vol: 1.2e8},
+	}
+	setVol(1.4e8)
+	tCore.checkMarketAlerts(tCtx)
+	expectNoNote()
+
+	// The 24-hour volume spikes, firing the volume alert once.
+	tCore.alerts.volumes[volKey] = []*volumeSample{
+		{stamp: now.Add(-2 * time.Hour), vol: 0.8e8},
+		{stamp: now.Add(-time.Hour), vol: 1.2e8},
+	}
+	setVol(2e8)
+	tCore.checkMarketAlerts(tCtx)
+	expectNote(TopicVolumeAlert)
+	if s := stored(volAlert.ID).Status; s != db.MarketAlertTriggered {
+		t.Fatalf("expected triggered volume alert, got %s", s)
+	}
+	tCore.checkMarketAlerts(tCtx)
+	expectNoNote()
+
+	// Remove the alerts.
+	for _, a := range []*db.MarketAlert{volAlert, fiatAlert} {
+		if err := tCore.RemoveMarketAlert(a.ID); err != nil {
+			t.Fatalf("RemoveMarketAlert error: %v", err)
+		}
+	}
+	if len(rig.db.marketAlerts) != 0 {
+		t.Fatalf("market alerts not deleted")
+	}
+	if err := tCore.RemoveMarketAlert(fiatAlert.ID); err == nil {
+		t.Fatalf("no error removing an unknown market alert")
+	}
+}
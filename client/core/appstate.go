@@ -0,0 +1,292 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex/encode"
+)
+
+// appStateBackupVersion is the version of the AppStateBackup. Restore
+// rejects backups with a newer version.
+const appStateBackupVersion = 1
+
+// AppStateBackup is the decrypted contents of an application state backup
+// bundle.
+type AppStateBackup struct {
+	Version uint32 `json:"version"`
+	// Created is the time the backup was created, in milliseconds.
+	Created uint64 `json:"created"`
+	// Seed is the app seed, as returned by ExportSeed. The mnemonic encodes
+	// the seed's birthday.
+	Seed                string                     `json:"seed"`
+	Language            string                     `json:"language,omitempty"`
+	DisabledRateSources []string                   `json:"disabledRateSources,omitempty"`
+	Accounts            []*AccountBackup           `json:"accounts"`
+	Wallets             []*WalletBackup            `json:"wallets"`
+	Sections            map[string]json.RawMessage `json:"sections,omitempty"`
+}
+
+// AccountBackup is a DEX account and its bonds.
+type AccountBackup struct {
+	Account  *Account   `json:"account"`
+	Bonds    []*db.Bond `json:"bonds"`
+	Disabled bool       `json:"disabled,omitempty"`
+}
+
+// WalletBackup is a wallet's configuration.
+type WalletBackup struct {
+	AssetID  uint32            `json:"assetID"`
+	Type     string            `json:"type"`
+	Settings map[string]string `json:"settings"`
+	// Password is the wallet password for wallets that are not seeded by
+	// the app. Empty if secrets were excluded.
+	Password string `json:"password,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// AppStateExportForm is information necessary to export the application
+// state.
+type AppStateExportForm struct {
+	// ExcludeSecrets omits wallet passwords and any wallet settings that are
+	// not echoed, such as RPC passwords.
+	ExcludeSecrets bool `json:"excludeSecrets"`
+	// Sections is state from other subsystems, such as the market maker, to
+	// include in the backup. The sections are returned as-is on restore.
+	Sections map[string]json.RawMessage `json:"sections"`
+}
+
+// AppStateRestoration is the result of RestoreAppState.
+type AppStateRestoration struct {
+	// Sections are the AppStateExportForm.Sections from the backup, to be
+	// restored by their subsystems.
+	Sections map[string]json.RawMessage `json:"sections"`
+	// Errors are the errors encountered restoring individual wallets,
+	// accounts, and preferences. These do not prevent the rest of the state
+	// from being restored.
+	Errors []string `json:"errors"`
+}
+
+// ExportAppState creates a password-encrypted backup of the application state,
+// including the app seed, DEX accounts and bonds, wallet configurations, and
+// preferences. The bundle can be restored to an uninitialized client with
+// RestoreAppState.
+func (c *Core) ExportAppState(pw []byte, form *AppStateExportForm) ([]byte, error) {
+	crypter, err := c.encryptionKey(pw)
+	if err != nil {
+		return nil, codedError(passwordErr, err)
+	}
+	defer crypter.Close()
+
+	seed, err := c.ExportSeed(pw)
+	if err != nil {
+		return nil, err
+	}
+	backup := &AppStateBackup{
+		Version:  appStateBackupVersion,
+		Created:  uint64(time.Now().UnixMilli()),
+		Seed:     seed,
+		Accounts: make([]*AccountBackup, 0),
+		Wallets:  make([]*WalletBackup, 0),
+		Sections: form.Sections,
+	}
+	if backup.Language, err = c.db.Language(); err != nil {
+		return nil, codedError(dbErr, err)
+	}
+	if backup.DisabledRateSources, err = c.db.DisabledRateSources(); err != nil {
+		return nil, codedError(dbErr, err)
+	}
+
+	accts, err := c.db.Accounts()
+	if err != nil {
+		return nil, codedError(dbErr, err)
+	}
+	for _, acctInfo := range accts {
+		acct, bonds, err := c.AccountExport(pw, acctInfo.Host)
+		if err != nil {
+			return nil, fmt.Errorf("error exporting %s account: %w", acctInfo.Host, err)
+		}
+		backup.Accounts = append(backup.Accounts, &AccountBackup{
+			Account:  acct,
+			Bonds:    bonds,
+			Disabled: acctInfo.Disabled,
+		})
+	}
+
+	wallets, err := c.db.Wallets()
+	if err != nil {
+		return nil, codedError(dbErr, err)
+	}
+	for _, w := range wallets {
+		wb := &WalletBackup{
+			AssetID:  w.AssetID,
+			Type:     w.Type,
+			Settings: make(map[string]string, len(w.Settings)),
+			Disabled: w.Disabled,
+		}
+		var secretKeys map[string]bool
+		walletDef, err := asset.WalletDef(w.AssetID, w.Type)
+		if err != nil {
+			c.log.Warnf("No wallet definition for %s wallet type %q: %v", unbip(w.AssetID), w.Type, err)
+		} else if form.ExcludeSecrets {
+			secretKeys = make(map[string]bool)
+			for _, opt := range walletDef.ConfigOpts {
+				if opt.NoEcho {
+					secretKeys[strings.ToLower(opt.Key)] = true
+				}
+			}
+		}
+		for k, v := range w.Settings {
+			if !secretKeys[k] {
+				wb.Settings[k] = v
+			}
+		}
+		// Seeded wallet passwords are derived from the app seed again on
+		// restore.
+		seeded := walletDef != nil && walletDef.Seeded
+		if !form.ExcludeSecrets && !seeded && len(w.EncryptedPW) > 0 {
+			walletPW, err := crypter.Decrypt(w.EncryptedPW)
+			if err != nil {
+				return nil, fmt.Errorf("error decrypting %s wallet password: %w", unbip(w.AssetID), err)
+			}
+			wb.Password = string(walletPW)
+			encode.ClearBytes(walletPW)
+		}
+		backup.Wallets = append(backup.Wallets, wb)
+	}
+
+	b, err := json.Marshal(backup)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding backup: %w", err)
+	}
+	backupCrypter := c.newCrypter(pw)
+	defer backupCrypter.Close()
+	encBackup, err := backupCrypter.Encrypt(b)
+	if err != nil {
+		return nil, codedError(encryptionErr, err)
+	}
+	return encode.BuildyBytes{0}.AddData(backupCrypter.Serialize()).AddData(encBackup), nil
+}
+
+// decryptAppState decrypts and decodes a backup bundle created by
+// ExportAppState.
+func (c *Core) decryptAppState(pw, bundle []byte) (*AppStateBackup, error) {
+	ver, pushes, err := encode.DecodeBlob(bundle, 2)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding backup bundle: %w", err)
+	}
+	if ver != 0 || len(pushes) != 2 {
+		return nil, fmt.Errorf("unknown backup bundle version %d with %d pushes", ver, len(pushes))
+	}
+	crypter, err := c.reCrypter(pw, pushes[0])
+	if err != nil {
+		return nil, codedError(passwordErr, err)
+	}
+	defer crypter.Close()
+	b, err := crypter.Decrypt(pushes[1])
+	if err != nil {
+		return nil, codedError(passwordErr, err)
+	}
+	backup := new(AppStateBackup)
+	if err := json.Unmarshal(b, backup); err != nil {
+		return nil, fmt.Errorf("error decoding backup: %w", err)
+	}
+	if backup.Version > appStateBackupVersion {
+		return nil, fmt.Errorf("backup version %d is not supported. upgrade to restore", backup.Version)
+	}
+	return backup, nil
+}
+
+// RestoreAppState initializes the client from a backup bundle created by
+// ExportAppState, using the password the bundle was created with, which
+// becomes the app password. The client must not already be initialized.
+// Wallets, accounts and preferences that fail to restore are reported in the
+// AppStateRestoration.Errors rather than aborting the restore, since a wallet
+// backend may be unavailable on the new machine.
+func (c *Core) RestoreAppState(pw, bundle []byte) (*AppStateRestoration, error) {
+	if c.IsInitialized() {
+		return nil, errors.New("already initialized. app state can only be restored to a new client")
+	}
+	backup, err := c.decryptAppState(pw, bundle)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.InitializeClient(pw, &backup.Seed); err != nil {
+		return nil, fmt.Errorf("error initializing from backup seed: %w", err)
+	}
+	if err := c.Login(pw); err != nil {
+		return nil, fmt.Errorf("error logging in: %w", err)
+	}
+
+	res := &AppStateRestoration{
+		Sections: backup.Sections,
+		Errors:   make([]string, 0),
+	}
+	addErr := func(format string, args ...any) {
+		s := fmt.Sprintf(format, args...)
+		c.log.Errorf("App state restore: %s", s)
+		res.Errors = append(res.Errors, s)
+	}
+
+	if backup.Language != "" {
+		if err := c.SetLanguage(backup.Language); err != nil {
+			addErr("error setting language %q: %v", backup.Language, err)
+		}
+	}
+	for _, src := range backup.DisabledRateSources {
+		if err := c.ToggleRateSourceStatus(src, true); err != nil {
+			addErr("error disabling rate source %s: %v", src, err)
+		}
+	}
+
+	// Parent assets must be created before their tokens.
+	sort.SliceStable(backup.Wallets, func(i, j int) bool {
+		return asset.TokenInfo(backup.Wallets[i].AssetID) == nil && asset.TokenInfo(backup.Wallets[j].AssetID) != nil
+	})
+	for _, wb := range backup.Wallets {
+		var walletPW []byte
+		if wb.Password != "" {
+			walletPW = []byte(wb.Password)
+		}
+		form := &WalletForm{
+			AssetID: wb.AssetID,
+			Config:  wb.Settings,
+			Type:    wb.Type,
+		}
+		if err := c.CreateWallet(pw, walletPW, form); err != nil {
+			addErr("error creating %s wallet: %v", unbip(wb.AssetID), err)
+			continue
+		}
+		if wb.Disabled {
+			if err := c.ToggleWalletStatus(wb.AssetID, true); err != nil {
+				addErr("error disabling %s wallet: %v", unbip(wb.AssetID), err)
+			}
+		}
+	}
+
+	for _, ab := range backup.Accounts {
+		if ab.Account == nil {
+			continue
+		}
+		host := ab.Account.Host
+		if err := c.AccountImport(pw, ab.Account, ab.Bonds); err != nil {
+			addErr("error importing %s account: %v", host, err)
+			continue
+		}
+		if ab.Disabled {
+			if err := c.ToggleAccountStatus(pw, host, true); err != nil {
+				addErr("error disabling %s account: %v", host, err)
+			}
+		}
+	}
+	return res, nil
+}
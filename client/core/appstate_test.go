@@ -0,0 +1,90 @@
+//go:build !harness && !botlive
+
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"decred.org/dcrdex/client/db"
+)
+
+func TestAppStateBackup(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+
+	rig.db.wallets = []*db.Wallet{{
+		AssetID:     tUTXOAssetA.ID,
+		Type:        "type",
+		Settings:    map[string]string{"rpcuser": "user"},
+		EncryptedPW: []byte("walletpw"),
+	}}
+	form := &AppStateExportForm{
+		Sections: map[string]json.RawMessage{"mm": json.RawMessage(`{"botConfigs":[]}`)},
+	}
+	bundle, err := tCore.ExportAppState(tPW, form)
+	if err != nil {
+		t.Fatalf("ExportAppState error: %v", err)
+	}
+	backup, err := tCore.decryptAppState(tPW, bundle)
+	if err != nil {
+		t.Fatalf("decryptAppState error: %v", err)
+	}
+	seed, _ := tCore.ExportSeed(tPW)
+	if backup.Version != appStateBackupVersion || backup.Seed != seed {
+		t.Fatalf("wrong backup version or seed")
+	}
+	if len(backup.Wallets) != 1 || backup.Wallets[0].Password != "walletpw" || backup.Wallets[0].Settings["rpcuser"] != "user" {
+		t.Fatalf("wallet not backed up")
+	}
+	if string(backup.Sections["mm"]) != `{"botConfigs":[]}` {
+		t.Fatalf("section not backed up")
+	}
+
+	// Secrets excluded.
+	form.ExcludeSecrets = true
+	noSecrets, err := tCore.ExportAppState(tPW, form)
+	if err != nil {
+		t.Fatalf("ExportAppState (no secrets) error: %v", err)
+	}
+	if backup, err = tCore.decryptAppState(tPW, noSecrets); err != nil {
+		t.Fatalf("decryptAppState (no secrets) error: %v", err)
+	}
+	if backup.Wallets[0].Password != "" {
+		t.Fatalf("wallet password exported with secrets excluded")
+	}
+
+	// Can't restore to an initialized client.
+	if _, err := tCore.RestoreAppState(tPW, bundle); err == nil {
+		t.Fatalf("no error restoring to an initialized client")
+	}
+
+	// The test drivers can't open wallets, so restore a bundle without any.
+	rig.db.wallets = nil
+	if bundle, err = tCore.ExportAppState(tPW, form); err != nil {
+		t.Fatalf("ExportAppState (no wallets) error: %v", err)
+	}
+
+	// Bad bundles.
+	tCore.credentials = nil
+	if _, err := tCore.RestoreAppState(tPW, bundle[:10]); err == nil {
+		t.Fatalf("no error restoring a truncated bundle")
+	}
+	rig.crypter.(*tCrypter).recryptErr = tErr
+	if _, err := tCore.RestoreAppState(tPW, bundle); err == nil {
+		t.Fatalf("no error restoring with a bad password")
+	}
+	rig.crypter.(*tCrypter).recryptErr = nil
+
+	res, err := tCore.RestoreAppState(tPW, bundle)
+	if err != nil {
+		t.Fatalf("RestoreAppState error: %v", err)
+	}
+	if !tCore.IsInitialized() {
+		t.Fatalf("not initialized after restore")
+	}
+	if string(res.Sections["mm"]) != `{"botConfigs":[]}` {
+		t.Fatalf("section not restored")
+	}
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"math"
@@ -684,7 +685,7 @@ func (c *Core) postRequiredBonds(
 		return
 	}
 
-	_, err = c.makeAndPostBond(dc, true, wallet, amt, c.feeSuggestionAny(wallet.AssetID), lockTime, bondAsset)
+	_, _, err = c.makeAndPostBond(dc, true, wallet, amt, c.feeSuggestionAny(wallet.AssetID), lockTime, bondAsset, false)
 	if err != nil {
 		c.log.Errorf("Unable to post bond: %v", err)
 		return
@@ -706,6 +707,8 @@ func (c *Core) rotateBonds(ctx context.Context) {
 
 	now := time.Now().Unix()
 
+	c.refundMeshBonds(ctx, now)
+
 	for _, dc := range c.dexConnections() {
 		initialized, unlocked := dc.acct.status()
 		if !initialized {
@@ -893,7 +896,9 @@ func (c *Core) monitorBondConfs(dc *dexConnection, bond *asset.Bond, reqConfs ui
 		return
 	}
 
-	if coinNotFound || (len(rebroadcast) > 0 && rebroadcast[0]) {
+	// A bond authored as a PSBT has no signed tx until it is broadcast with
+	// BroadcastPSBT, so there is nothing to rebroadcast.
+	if len(bond.SignedTx) > 0 && (coinNotFound || (len(rebroadcast) > 0 && rebroadcast[0])) {
 		// Broadcast the bond and start waiting for confs.
 		c.log.Infof("Rebroadcasting bond %v (%s), data = %x.\n\n"+
 			"BACKUP refund tx paying to current wallet: %x\n\n",
@@ -1385,6 +1390,9 @@ func (c *Core) PostBond(form *PostBondForm) (*PostBondResult, error) {
 	if _, ok := wallet.Wallet.(asset.Bonder); !ok { // will fail in MakeBondTx, but assert early
 		return nil, fmt.Errorf("wallet %v is not an asset.Bonder", bondAssetSymbol)
 	}
+	if _, ok := wallet.Wallet.(asset.PSBTer); form.PSBT && !ok {
+		return nil, fmt.Errorf("%s wallet does not support PSBTs", bondAssetSymbol)
+	}
 	err = wallet.checkPeersAndSyncStatus()
 	if err != nil {
 		return nil, err
@@ -1532,14 +1540,18 @@ func (c *Core) PostBond(form *PostBondForm) (*PostBondResult, error) {
 	}
 
 	// Make a bond transaction for the account ID generated from our public key.
-	bondCoin, err := c.makeAndPostBond(dc, acctExists, wallet, form.Bond, feeRate, lockTime, bondAsset)
+	bondCoin, unsignedPSBT, err := c.makeAndPostBond(dc, acctExists, wallet, form.Bond, feeRate, lockTime, bondAsset, form.PSBT)
 	if err != nil {
 		return nil, err
 	}
 	c.updateBondReserves() // Can probably reduce reserves because of the pending bond.
 	success = true
 	bondCoinStr := coinIDString(bondAssetID, bondCoin)
-	return &PostBondResult{BondID: bondCoinStr, ReqConfirms: uint16(bondAsset.Confs)}, nil
+	res := &PostBondResult{BondID: bondCoinStr, ReqConfirms: uint16(bondAsset.Confs)}
+	if form.PSBT {
+		res.PSBT = base64.StdEncoding.EncodeToString(unsignedPSBT)
+	}
+	return res, nil
 }
 
 // calculateMergingLockTime calculates a locktime for a new bond for the
@@ -1581,19 +1593,30 @@ func (c *Core) calculateMergingLockTime(dc *dexConnection) (time.Time, error) {
 	return lockTime, nil
 }
 
+// makeAndPostBond creates and broadcasts a bond transaction, and starts
+// monitoring it for confirmations. If asPSBT is true, the bond's funding inputs
+// are not signed, and the serialized PSBT is returned instead of broadcasting.
+// The bond is monitored until the signed PSBT is broadcast with BroadcastPSBT,
+// or the bond is dropped with AbandonPSBT.
 func (c *Core) makeAndPostBond(dc *dexConnection, acctExists bool, wallet *xcWallet, amt, feeRate uint64,
-	lockTime time.Time, bondAsset *msgjson.BondAsset) ([]byte, error) {
+	lockTime time.Time, bondAsset *msgjson.BondAsset, asPSBT bool) (coinID, unsignedPSBT []byte, err error) {
 
 	bondKey, keyIndex, err := c.nextBondKey(bondAsset.ID)
 	if err != nil {
-		return nil, fmt.Errorf("bond key derivation failed: %v", err)
+		return nil, nil, fmt.Errorf("bond key derivation failed: %v", err)
 	}
 	defer bondKey.Zero()
 
 	acctID := dc.acct.ID()
-	bond, abandon, err := wallet.MakeBondTx(bondAsset.Version, amt, feeRate, lockTime, bondKey, acctID[:])
+	var bond *asset.Bond
+	var abandon func()
+	if asPSBT {
+		bond, unsignedPSBT, abandon, err = wallet.MakeBondPSBT(bondAsset.Version, amt, feeRate, lockTime, bondKey, acctID[:])
+	} else {
+		bond, abandon, err = wallet.MakeBondTx(bondAsset.Version, amt, feeRate, lockTime, bondKey, acctID[:])
+	}
 	if err != nil {
-		return nil, codedError(bondPostErr, err)
+		return nil, nil, codedError(bondPostErr, err)
 	}
 	// MakeBondTx lock coins and reduces reserves in proportion
 
@@ -1606,7 +1629,7 @@ func (c *Core) makeAndPostBond(dc *dexConnection, acctExists bool, wallet *xcWal
 
 	// Do prevalidatebond with the *unsigned* txn.
 	if err = c.preValidateBond(dc, bond); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	reqConfs := bondAsset.Confs
@@ -1633,7 +1656,7 @@ func (c *Core) makeAndPostBond(dc *dexConnection, acctExists bool, wallet *xcWal
 	if acctExists {
 		err = c.db.AddBond(dc.acct.host, dbBond)
 		if err != nil {
-			return nil, fmt.Errorf("failed to store bond %v (%s) for dex %v: %w",
+			return nil, nil, fmt.Errorf("failed to store bond %v (%s) for dex %v: %w",
 				bondCoinStr, unbip(bond.AssetID), dc.acct.host, err)
 		}
 	} else {
@@ -1650,7 +1673,7 @@ func (c *Core) makeAndPostBond(dc *dexConnection, acctExists bool, wallet *xcWal
 		}
 		err = c.dbCreateOrUpdateAccount(dc, ai)
 		if err != nil {
-			return nil, fmt.Errorf("failed to store account %v for dex %v: %w",
+			return nil, nil, fmt.Errorf("failed to store account %v for dex %v: %w",
 				dc.acct.id, dc.acct.host, err)
 		}
 	}
@@ -1666,20 +1689,18 @@ func (c *Core) makeAndPostBond(dc *dexConnection, acctExists bool, wallet *xcWal
 		// NOTE: it's still not authed if this was the first bond
 	}
 
-	// Broadcast the bond and start waiting for confs.
-	c.log.Infof("Broadcasting bond %v (%s) with lock time %v, data = %x.\n\n"+
-		"BACKUP refund tx paying to current wallet: %x\n\n",
-		bondCoinStr, unbip(bond.AssetID), lockTime, bond.Data, bond.RedeemTx)
-	if bondCoinCast, err := wallet.SendTransaction(bond.SignedTx); err != nil {
-		c.log.Warnf("Failed to broadcast bond txn (%v). Tx bytes: %x", err, bond.SignedTx)
-		// There is a good possibility it actually made it to the network. We
-		// should start monitoring, perhaps even rebroadcast. It's tempting to
-		// abort and remove the pending bond, but that's bad if it's sent.
-	} else if !bytes.Equal(bond.CoinID, bondCoinCast) {
-		c.log.Warnf("Broadcasted bond %v; was expecting %v!",
-			coinIDString(bond.AssetID, bondCoinCast), bondCoinStr)
+	// Broadcast the bond and start waiting for confs. A PSBT bond is broadcast
+	// with BroadcastPSBT once it is signed externally.
+	if asPSBT {
+		c.log.Infof("Bond %v (%s) with lock time %v, data = %x is awaiting an external signature.\n\n"+
+			"BACKUP refund tx paying to current wallet: %x\n\n",
+			bondCoinStr, unbip(bond.AssetID), lockTime, bond.Data, bond.RedeemTx)
+	} else {
+		c.log.Infof("Broadcasting bond %v (%s) with lock time %v, data = %x.\n\n"+
+			"BACKUP refund tx paying to current wallet: %x\n\n",
+			bondCoinStr, unbip(bond.AssetID), lockTime, bond.Data, bond.RedeemTx)
+		c.broadcastBond(wallet, bond)
 	}
-
 	// Set up the coin waiter, which watches confirmations so the user knows
 	// when to expect their account to be marked paid by the server.
 	c.monitorBondConfs(dc, bond, reqConfs)
@@ -1691,7 +1712,20 @@ func (c *Core) makeAndPostBond(dc *dexConnection, acctExists bool, wallet *xcWal
 	c.notify(newBondPostNoteWithConfirmations(TopicBondConfirming, subject,
 		details, db.Success, bond.AssetID, bondCoinStr, 0, dc.acct.host, c.exchangeAuth(dc)))
 
-	return bond.CoinID, nil
+	return bond.CoinID, unsignedPSBT, nil
+}
+
+// broadcastBond broadcasts a signed bond transaction.
+func (c *Core) broadcastBond(wallet *xcWallet, bond *asset.Bond) {
+	if bondCoinCast, err := wallet.SendTransaction(bond.SignedTx); err != nil {
+		c.log.Warnf("Failed to broadcast bond txn (%v). Tx bytes: %x", err, bond.SignedTx)
+		// There is a good possibility it actually made it to the network. We
+		// should start monitoring, perhaps even rebroadcast. It's tempting to
+		// abort and remove the pending bond, but that's bad if it's sent.
+	} else if !bytes.Equal(bond.CoinID, bondCoinCast) {
+		c.log.Warnf("Broadcasted bond %v; was expecting %v!",
+			coinIDString(bond.AssetID, bondCoinCast), coinIDString(bond.AssetID, bond.CoinID))
+	}
 }
 
 func (c *Core) updatePendingBondConfs(dc *dexConnection, assetID uint32, coinID []byte, confs uint32) {
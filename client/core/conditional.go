@@ -0,0 +1,517 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+)
+
+// conditionalOrderTick is how often the conditional order triggers are checked
+// in the absence of order book updates.
+const conditionalOrderTick = 10 * time.Second
+
+// conditionalOrderRetry is how long to wait before resubmitting a triggered
+// conditional order whose trade failed with an error that may be temporary,
+// such as a locked wallet or a lost connection.
+const conditionalOrderRetry = time.Minute
+
+// ConditionalOrderForm is used to create a conditional order. The Trade is
+// submitted when the trigger is hit.
+type ConditionalOrderForm struct {
+	// Kind is db.StopLoss or db.TakeProfit. Together with the trade side, the
+	// Kind determines whether the order triggers when the price rises or falls.
+	Kind  string     `json:"kind"`
+	Trade *TradeForm `json:"trade"`
+	// TriggerSource is db.TriggerMidGap or db.TriggerFiat.
+	TriggerSource string `json:"triggerSource"`
+	// TriggerRate is the book mid-gap message-rate that triggers a
+	// db.TriggerMidGap order.
+	TriggerRate uint64 `json:"triggerRate"`
+	// TriggerFiatRate is the USD price of the base asset that triggers a
+	// db.TriggerFiat order.
+	TriggerFiatRate float64 `json:"triggerFiatRate"`
+	// OCO is an optional one-cancels-other partner on the same market. When
+	// either order is triggered or canceled, the other is canceled.
+	OCO *ConditionalOrderForm `json:"oco,omitempty"`
+}
+
+// conditionalOrders tracks the active conditional orders and the order book
+// feeds needed to watch their triggers.
+type conditionalOrders struct {
+	mtx    sync.Mutex
+	active map[string]*db.ConditionalOrder
+	// retryAt is when a triggered order whose trade failed with a temporary
+	// error may be resubmitted.
+	retryAt map[string]time.Time
+	feeds   map[string]context.CancelFunc
+	check   chan struct{}
+}
+
+func newConditionalOrders() *conditionalOrders {
+	return &conditionalOrders{
+		active:  make(map[string]*db.ConditionalOrder),
+		retryAt: make(map[string]time.Time),
+		feeds:   make(map[string]context.CancelFunc),
+		check:   make(chan struct{}, 1),
+	}
+}
+
+// recheck schedules a check of the triggers.
+func (co *conditionalOrders) recheck() {
+	select {
+	case co.check <- struct{}{}:
+	default:
+	}
+}
+
+// validateConditionalOrderForm checks the conditional order parameters. The
+// trade parameters are validated for the market, but funding is only checked
+// when the order is triggered.
+func (c *Core) validateConditionalOrderForm(form *ConditionalOrderForm) (*dexConnection, error) {
+	if form.Kind != db.StopLoss && form.Kind != db.TakeProfit {
+		return nil, newError(orderParamsErr, "unknown conditional order kind %q", form.Kind)
+	}
+	trade := form.Trade
+	if trade == nil {
+		return nil, newError(orderParamsErr, "no trade specified for conditional order")
+	}
+	switch form.TriggerSource {
+	case db.TriggerMidGap:
+		if form.TriggerRate == 0 {
+			return nil, newError(orderParamsErr, "zero trigger rate")
+		}
+	case db.TriggerFiat:
+		if form.TriggerFiatRate <= 0 {
+			return nil, newError(orderParamsErr, "invalid trigger fiat rate %f", form.TriggerFiatRate)
+		}
+	default:
+		return nil, newError(orderParamsErr, "unknown trigger source %q", form.TriggerSource)
+	}
+	dc, err := c.registeredDEX(trade.Host)
+	if err != nil {
+		return nil, err
+	}
+	mktID := marketName(trade.Base, trade.Quote)
+	mkt := dc.marketConfig(mktID)
+	if mkt == nil {
+		return nil, newError(marketErr, "unknown market %q", mktID)
+	}
+	if trade.Qty == 0 {
+		return nil, newError(orderParamsErr, "zero quantity not allowed")
+	}
+	if trade.IsLimit && trade.Rate == 0 {
+		return nil, newError(orderParamsErr, "zero-rate order not allowed")
+	}
+	// The quantity of a market buy order is in units of the quote asset.
+	if (trade.IsLimit || trade.Sell) && trade.Qty%mkt.LotSize != 0 {
+		return nil, newError(orderParamsErr, "order quantity must be a multiple of the lot size %d", mkt.LotSize)
+	}
+	return dc, nil
+}
+
+func newConditionalOrder(host string, form *ConditionalOrderForm) *db.ConditionalOrder {
+	t := form.Trade
+	return &db.ConditionalOrder{
+		ID:              encode.RandomBytes(8),
+		Kind:            form.Kind,
+		Host:            host,
+		IsLimit:         t.IsLimit,
+		Sell:            t.Sell,
+		Base:            t.Base,
+		Quote:           t.Quote,
+		Qty:             t.Qty,
+		Rate:            t.Rate,
+		TifNow:          t.TifNow,
+		Options:         t.Options,
+		TriggerSource:   form.TriggerSource,
+		TriggerRate:     form.TriggerRate,
+		TriggerFiatRate: form.TriggerFiatRate,
+		Stamp:           uint64(time.Now().UnixMilli()),
+	}
+}
+
+// PlaceConditionalOrder creates a conditional order, and its one-cancels-other
+// partner if specified. The orders are held by Core until triggered, at which
+// point the trade is submitted. Core must be logged in with the relevant
+// wallets unlocked for a triggered order to be submitted. The created orders
+// are returned.
+func (c *Core) PlaceConditionalOrder(pw []byte, form *ConditionalOrderForm) ([]*db.ConditionalOrder, error) {
+	if len(pw) > 0 {
+		crypter, err := c.encryptionKey(pw)
+		if err != nil {
+			return nil, fmt.Errorf("password error: %w", err)
+		}
+		crypter.Close()
+	}
+
+	dc, err := c.validateConditionalOrderForm(form)
+	if err != nil {
+		return nil, err
+	}
+	ords := []*db.ConditionalOrder{newConditionalOrder(dc.acct.host, form)}
+	if oco := form.OCO; oco != nil {
+		if oco.OCO != nil {
+			return nil, newError(orderParamsErr, "one-cancels-other orders must be a pair")
+		}
+		if oco.Trade == nil || oco.Trade.Base != form.Trade.Base || oco.Trade.Quote != form.Trade.Quote {
+			return nil, newError(orderParamsErr, "one-cancels-other orders must be on the same market")
+		}
+		ocoDC, err := c.validateConditionalOrderForm(oco)
+		if err != nil {
+			return nil, fmt.Errorf("invalid one-cancels-other order: %w", err)
+		}
+		if ocoDC != dc {
+			return nil, newError(orderParamsErr, "one-cancels-other orders must be on the same DEX")
+		}
+		ocoOrd := newConditionalOrder(dc.acct.host, oco)
+		ords[0].OCO, ocoOrd.OCO = ocoOrd.ID, ords[0].ID
+		ords = append(ords, ocoOrd)
+	}
+
+	co := c.condOrders
+	co.mtx.Lock()
+	for _, ord := range ords {
+		if err := c.db.UpdateConditionalOrder(ord); err != nil {
+			co.mtx.Unlock()
+			return nil, fmt.Errorf("error storing conditional order: %w", err)
+		}
+		co.active[ord.ID.String()] = ord
+	}
+	co.mtx.Unlock()
+
+	for _, ord := range ords {
+		c.notify(newConditionalOrderNote(TopicConditionalOrderPlaced, "", "", db.Data, ord))
+	}
+	co.recheck()
+	return copyConditionalOrders(ords), nil
+}
+
+// ConditionalOrders returns all conditional orders, newest first.
+func (c *Core) ConditionalOrders() ([]*db.ConditionalOrder, error) {
+	c.condOrders.mtx.Lock()
+	ords, err := c.db.ConditionalOrders()
+	c.condOrders.mtx.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ords, func(i, j int) bool { return ords[i].Stamp > ords[j].Stamp })
+	return ords, nil
+}
+
+// CancelConditionalOrder cancels an active conditional order, along with its
+// one-cancels-other partner.
+func (c *Core) CancelConditionalOrder(id dex.Bytes) error {
+	co := c.condOrders
+	co.mtx.Lock()
+	ord, found := co.active[id.String()]
+	if !found {
+		co.mtx.Unlock()
+		return fmt.Errorf("no active conditional order %s", id)
+	}
+	canceled := []*db.ConditionalOrder{ord}
+	if partner := co.active[ord.OCO.String()]; len(ord.OCO) > 0 && partner != nil {
+		canceled = append(canceled, partner)
+	}
+	for _, o := range canceled {
+		delete(co.active, o.ID.String())
+		delete(co.retryAt, o.ID.String())
+		o.Status = db.ConditionalOrderCanceled
+		if err := c.db.UpdateConditionalOrder(o); err != nil {
+			c.log.Errorf("Error storing canceled conditional order %s: %v", o.ID, err)
+		}
+	}
+	co.mtx.Unlock()
+
+	for _, o := range canceled {
+		c.notify(newConditionalOrderNote(TopicConditionalOrderCanceled, "", "", db.Data, o))
+	}
+	co.recheck()
+	return nil
+}
+
+// watchConditionalOrders loads the active conditional orders and checks their
+// triggers until the context is canceled.
+func (c *Core) watchConditionalOrders(ctx context.Context) {
+	co := c.condOrders
+	ords, err := c.db.ConditionalOrders()
+	if err != nil {
+		c.log.Errorf("Error loading conditional orders: %v", err)
+	}
+	co.mtx.Lock()
+	for _, ord := range ords {
+		if ord.Status == db.ConditionalOrderActive {
+			co.active[ord.ID.String()] = ord
+		}
+	}
+	co.mtx.Unlock()
+
+	defer func() {
+		co.mtx.Lock()
+		for k, stop := range co.feeds {
+			stop()
+			delete(co.feeds, k)
+		}
+		co.mtx.Unlock()
+	}()
+
+	tick := time.NewTicker(conditionalOrderTick)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		case <-co.check:
+		}
+		c.checkConditionalOrders(ctx)
+	}
+}
+
+// syncConditionalOrderBooks subscribes to the order books needed for mid-gap
+// triggers, and closes those no longer needed.
+func (c *Core) syncConditionalOrderBooks(ctx context.Context) {
+	co := c.condOrders
+	co.mtx.Lock()
+	needed := make(map[string]*db.ConditionalOrder)
+	for _, ord := range co.active {
+		if ord.TriggerSource == db.TriggerMidGap {
+			needed[ord.Host+"|"+marketName(ord.Base, ord.Quote)] = ord
+		}
+	}
+	for k, stop := range co.feeds {
+		if needed[k] == nil {
+			stop()
+			delete(co.feeds, k)
+		}
+	}
+	for k := range needed {
+		if co.feeds[k] != nil {
+			delete(needed, k)
+		}
+	}
+	co.mtx.Unlock()
+
+	// Subscribe without holding the mutex, since SyncBook may need to request
+	// the book from the server. A feed that is no longer needed by the time
+	// it is added is closed on the next check.
+	for k, ord := range needed {
+		_, feed, err := c.SyncBook(ord.Host, ord.Base, ord.Quote)
+		if err != nil {
+			c.log.Debugf("Unable to sync %s book at %s for conditional orders: %v",
+				marketName(ord.Base, ord.Quote), ord.Host, err)
+			continue
+		}
+		feedCtx, stop := context.WithCancel(ctx)
+		co.mtx.Lock()
+		if co.feeds[k] != nil {
+			co.mtx.Unlock()
+			stop()
+			feed.Close()
+			continue
+		}
+		co.feeds[k] = stop
+		co.mtx.Unlock()
+		go func() {
+			defer feed.Close()
+			for {
+				select {
+				case <-feed.Next():
+					co.recheck()
+				case <-feedCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+// conditionalOrderPrice gets the current price for the order's trigger source.
+func (c *Core) conditionalOrderPrice(ord *db.ConditionalOrder, fiatRates map[uint32]float64) (midGap uint64, fiatRate float64, ok bool) {
+	switch ord.TriggerSource {
+	case db.TriggerMidGap:
+		dc, _, err := c.dex(ord.Host)
+		if err != nil {
+			return 0, 0, false
+		}
+		book := dc.bookie(marketName(ord.Base, ord.Quote))
+		if book == nil {
+			return 0, 0, false
+		}
+		midGap, err := book.MidGap()
+		if err != nil { // empty book
+			return 0, 0, false
+		}
+		return midGap, 0, true
+	case db.TriggerFiat:
+		fiatRate = fiatRates[ord.Base]
+		return 0, fiatRate, fiatRate > 0
+	}
+	return 0, 0, false
+}
+
+// conditionalOrderTriggered checks if the order's trigger has been hit.
+func conditionalOrderTriggered(ord *db.ConditionalOrder, midGap uint64, fiatRate float64) bool {
+	above := ord.TriggersAbove()
+	switch ord.TriggerSource {
+	case db.TriggerMidGap:
+		if above {
+			return midGap >= ord.TriggerRate
+		}
+		return midGap <= ord.TriggerRate
+	case db.TriggerFiat:
+		if above {
+			return fiatRate >= ord.TriggerFiatRate
+		}
+		return fiatRate <= ord.TriggerFiatRate
+	}
+	return false
+}
+
+// checkConditionalOrders submits the orders for any triggers that have been
+// hit.
+func (c *Core) checkConditionalOrders(ctx context.Context) {
+	c.loginMtx.Lock()
+	loggedIn := c.loggedIn
+	c.loginMtx.Unlock()
+	if !loggedIn {
+		return
+	}
+
+	c.syncConditionalOrderBooks(ctx)
+
+	co := c.condOrders
+	co.mtx.Lock()
+	if len(co.active) == 0 {
+		co.mtx.Unlock()
+		return
+	}
+
+	var fiatRates map[uint32]float64
+	for _, ord := range co.active {
+		if ord.TriggerSource == db.TriggerFiat {
+			fiatRates = c.fiatConversions()
+			break
+		}
+	}
+
+	active := make([]*db.ConditionalOrder, 0, len(co.active))
+	for _, ord := range co.active {
+		active = append(active, ord)
+	}
+	// If both orders of a pair are triggered, the older one wins.
+	sort.Slice(active, func(i, j int) bool { return active[i].Stamp < active[j].Stamp })
+
+	now := time.Now()
+	var triggered []*db.ConditionalOrder
+	skip := make(map[string]bool) // partners of triggered orders
+	for _, ord := range active {
+		id := ord.ID.String()
+		if skip[id] || now.Before(co.retryAt[id]) {
+			continue
+		}
+		midGap, fiatRate, ok := c.conditionalOrderPrice(ord, fiatRates)
+		if !ok || !conditionalOrderTriggered(ord, midGap, fiatRate) {
+			continue
+		}
+		triggered = append(triggered, ord)
+		if len(ord.OCO) > 0 {
+			skip[ord.OCO.String()] = true
+		}
+	}
+	co.mtx.Unlock()
+
+	for _, ord := range triggered {
+		c.submitConditionalOrder(ord)
+	}
+}
+
+// submitConditionalOrder submits the trade for a triggered conditional order.
+// If the trade is placed, the one-cancels-other partner is canceled. If the
+// trade fails with an error that may be temporary, the order remains active
+// and is resubmitted after conditionalOrderRetry. Invalid order parameters
+// fail the order.
+func (c *Core) submitConditionalOrder(ord *db.ConditionalOrder) {
+	mktName := fmt.Sprintf("%s-%s", unbip(ord.Base), unbip(ord.Quote))
+	// An empty password is accepted if the wallets are unlocked.
+	corder, err := c.Trade(nil, &TradeForm{
+		Host:    ord.Host,
+		IsLimit: ord.IsLimit,
+		Sell:    ord.Sell,
+		Base:    ord.Base,
+		Quote:   ord.Quote,
+		Qty:     ord.Qty,
+		Rate:    ord.Rate,
+		TifNow:  ord.TifNow,
+		Options: ord.Options,
+	})
+
+	co := c.condOrders
+	id := ord.ID.String()
+	var notes []Notification
+	store := func(o *db.ConditionalOrder) {
+		if err := c.db.UpdateConditionalOrder(o); err != nil {
+			c.log.Errorf("Error storing conditional order %s: %v", o.ID, err)
+		}
+	}
+	co.mtx.Lock()
+	switch {
+	case err == nil:
+		delete(co.active, id)
+		delete(co.retryAt, id)
+		ord.Status = db.ConditionalOrderTriggered
+		ord.OrderID = corder.ID
+		ord.Error = ""
+		store(ord)
+		subject, details := c.formatDetails(TopicConditionalOrderTriggered, ord.Kind, ord.ID, mktName, ord.Host, corder.ID)
+		notes = append(notes, newConditionalOrderNote(TopicConditionalOrderTriggered, subject, details, db.Success, ord))
+		if partner := co.active[ord.OCO.String()]; len(ord.OCO) > 0 && partner != nil {
+			delete(co.active, partner.ID.String())
+			delete(co.retryAt, partner.ID.String())
+			partner.Status = db.ConditionalOrderCanceled
+			store(partner)
+			notes = append(notes, newConditionalOrderNote(TopicConditionalOrderCanceled, "", "", db.Data, partner))
+		}
+	case co.active[id] == nil:
+		// Canceled while the trade was being submitted.
+	case errorHasCode(err, orderParamsErr):
+		delete(co.active, id)
+		delete(co.retryAt, id)
+		ord.Status = db.ConditionalOrderFailed
+		ord.Error = err.Error()
+		store(ord)
+		subject, details := c.formatDetails(TopicConditionalOrderFailed, ord.Kind, ord.ID, mktName, ord.Host, err)
+		notes = append(notes, newConditionalOrderNote(TopicConditionalOrderFailed, subject, details, db.ErrorLevel, ord))
+	default:
+		co.retryAt[id] = time.Now().Add(conditionalOrderRetry)
+		// Only notify the first time an error is encountered.
+		if ord.Error != err.Error() {
+			ord.Error = err.Error()
+			store(ord)
+			subject, details := c.formatDetails(TopicConditionalOrderFailed, ord.Kind, ord.ID, mktName, ord.Host, err)
+			notes = append(notes, newConditionalOrderNote(TopicConditionalOrderFailed, subject, details, db.WarningLevel, ord))
+		}
+	}
+	co.mtx.Unlock()
+
+	for _, note := range notes {
+		c.notify(note)
+	}
+}
+
+func copyConditionalOrders(ords []*db.ConditionalOrder) []*db.ConditionalOrder {
+	cp := make([]*db.ConditionalOrder, 0, len(ords))
+	for _, ord := range ords {
+		o := *ord
+		cp = append(cp, &o)
+	}
+	return cp
+}
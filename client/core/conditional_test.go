@@ -0,0 +1,217 @@
+//go:build !harness && !botlive
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/msgjson"
+)
+
+func TestConditionalOrderTriggered(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		sell     bool
+		source   string
+		midGap   uint64
+		fiatRate float64
+		exp      bool
+	}{
+		{"sell stop-loss, above", db.StopLoss, true, db.TriggerMidGap, 101, 0, false},
+		{"sell stop-loss, at", db.StopLoss, true, db.TriggerMidGap, 100, 0, true},
+		{"sell stop-loss, below", db.StopLoss, true, db.TriggerMidGap, 99, 0, true},
+		{"sell take-profit, below", db.TakeProfit, true, db.TriggerMidGap, 99, 0, false},
+		{"sell take-profit, above", db.TakeProfit, true, db.TriggerMidGap, 101, 0, true},
+		{"buy stop-loss, below", db.StopLoss, false, db.TriggerMidGap, 99, 0, false},
+		{"buy stop-loss, above", db.StopLoss, false, db.TriggerMidGap, 101, 0, true},
+		{"buy take-profit, above", db.TakeProfit, false, db.TriggerMidGap, 101, 0, false},
+		{"buy take-profit, below", db.TakeProfit, false, db.TriggerMidGap, 99, 0, true},
+		{"fiat sell stop-loss, above", db.StopLoss, true, db.TriggerFiat, 0, 10.5, false},
+		{"fiat sell stop-loss, below", db.StopLoss, true, db.TriggerFiat, 0, 9.5, true},
+		{"fiat buy stop-loss, above", db.StopLoss, false, db.TriggerFiat, 0, 10.5, true},
+	}
+	for _, tt := range tests {
+		ord := &db.ConditionalOrder{
+			Kind:            tt.kind,
+			Sell:            tt.sell,
+			TriggerSource:   tt.source,
+			TriggerRate:     100,
+			TriggerFiatRate: 10,
+		}
+		if triggered := conditionalOrderTriggered(ord, tt.midGap, tt.fiatRate); triggered != tt.exp {
+			t.Fatalf("%s: expected triggered = %t, got %t", tt.name, tt.exp, triggered)
+		}
+	}
+}
+
+func TestConditionalOrders(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+	tCore.loggedIn = true
+
+	newForm := func(kind string, fiatRate float64) *ConditionalOrderForm {
+		return &ConditionalOrderForm{
+			Kind: kind,
+			Trade: &TradeForm{
+				Host:    tDexHost,
+				IsLimit: true,
+				Sell:    true,
+				Base:    tUTXOAssetA.ID,
+				Quote:   tUTXOAssetB.ID,
+				Qty:     dcrBtcLotSize * 2,
+				Rate:    dcrBtcRateStep * 100,
+			},
+			TriggerSource:   db.TriggerFiat,
+			TriggerFiatRate: fiatRate,
+		}
+	}
+
+	// Bad forms.
+	for name, mod := range map[string]func(*ConditionalOrderForm){
+		"bad kind":     func(f *ConditionalOrderForm) { f.Kind = "stop" },
+		"no trade":     func(f *ConditionalOrderForm) { f.Trade = nil },
+		"bad source":   func(f *ConditionalOrderForm) { f.TriggerSource = "vibes" },
+		"zero trigger": func(f *ConditionalOrderForm) { f.TriggerFiatRate = 0 },
+		"unknown host": func(f *ConditionalOrderForm) { f.Trade.Host = "otherdex.tld" },
+		"bad market":   func(f *ConditionalOrderForm) { f.Trade.Quote = 12345 },
+		"zero qty":     func(f *ConditionalOrderForm) { f.Trade.Qty = 0 },
+		"lot size":     func(f *ConditionalOrderForm) { f.Trade.Qty = dcrBtcLotSize + 1 },
+		"zero rate":    func(f *ConditionalOrderForm) { f.Trade.Rate = 0 },
+		"oco market":   func(f *ConditionalOrderForm) { f.OCO = newForm(db.TakeProfit, 20); f.OCO.Trade.Base = 60 },
+		"oco triple": func(f *ConditionalOrderForm) {
+			f.OCO = newForm(db.TakeProfit, 20)
+			f.OCO.OCO = newForm(db.TakeProfit, 30)
+		},
+	} {
+		form := newForm(db.StopLoss, 10)
+		mod(form)
+		if _, err := tCore.PlaceConditionalOrder(nil, form); err == nil {
+			t.Fatalf("%s: no error", name)
+		}
+	}
+
+	// A stop-loss / take-profit pair, and an unrelated take-profit.
+	form := newForm(db.StopLoss, 10)
+	form.OCO = newForm(db.TakeProfit, 20)
+	pair, err := tCore.PlaceConditionalOrder(nil, form)
+	if err != nil {
+		t.Fatalf("PlaceConditionalOrder error: %v", err)
+	}
+	if len(pair) != 2 || !pair[0].OCO.Equal(pair[1].ID) || !pair[1].OCO.Equal(pair[0].ID) {
+		t.Fatalf("one-cancels-other orders not linked")
+	}
+	time.Sleep(time.Millisecond) // distinct stamps
+	single, err := tCore.PlaceConditionalOrder(nil, newForm(db.TakeProfit, 30))
+	if err != nil {
+		t.Fatalf("PlaceConditionalOrder (single) error: %v", err)
+	}
+
+	ords, err := tCore.ConditionalOrders()
+	if err != nil {
+		t.Fatalf("ConditionalOrders error: %v", err)
+	}
+	if len(ords) != 3 {
+		t.Fatalf("expected 3 conditional orders, got %d", len(ords))
+	}
+	if !ords[0].ID.Equal(single[0].ID) {
+		t.Fatalf("conditional orders not sorted newest first")
+	}
+
+	status := func(id []byte) db.ConditionalOrderStatus {
+		t.Helper()
+		ord := rig.db.condOrders[dex.Bytes(id).String()]
+		if ord == nil {
+			t.Fatalf("conditional order %x not stored", id)
+		}
+		return ord.Status
+	}
+
+	// No fiat rate yet. Nothing triggers.
+	tCore.checkConditionalOrders(tCtx)
+	for _, ord := range append(pair, single...) {
+		if s := status(ord.ID); s != db.ConditionalOrderActive {
+			t.Fatalf("conditional order %s status %s before rate", ord.ID, s)
+		}
+	}
+
+	// The fiat rate rises above 20, triggering the take-profit. There are no
+	// wallets, so the trade fails. The error may be temporary, so the order
+	// remains active, and the stop-loss partner is not canceled.
+	source := newCommonRateSource(tFetcher)
+	source.fiatRates = map[uint32]*fiatRateInfo{
+		tUTXOAssetA.ID: {rate: 25, lastUpdate: time.Now()},
+	}
+	tCore.fiatRateSources["test"] = source
+	tCore.checkConditionalOrders(tCtx)
+	if s := status(pair[1].ID); s != db.ConditionalOrderActive {
+		t.Fatalf("expected active take-profit after a temporary error, got %s", s)
+	}
+	if rig.db.condOrders[pair[1].ID.String()].Error == "" {
+		t.Fatalf("no error recorded for failed conditional order submission")
+	}
+	if s := status(pair[0].ID); s != db.ConditionalOrderActive {
+		t.Fatalf("expected active stop-loss, got %s", s)
+	}
+
+	// Add the wallets and server response needed to place the order.
+	dcrWallet, tDcrWallet := newTWallet(tUTXOAssetA.ID)
+	tCore.wallets[tUTXOAssetA.ID] = dcrWallet
+	dcrWallet.address = "DsVmA7aqqWeKWy461hXjytbZbgCqbB8g2dq"
+	dcrWallet.Unlock(rig.crypter)
+	tDcrWallet.fundingCoins = asset.Coins{&tCoin{id: encode.RandomBytes(36), val: dcrBtcLotSize * 4}}
+	tDcrWallet.fundRedeemScripts = []dex.Bytes{nil}
+	btcWallet, _ := newTWallet(tUTXOAssetB.ID)
+	tCore.wallets[tUTXOAssetB.ID] = btcWallet
+	btcWallet.address = "12DXGkvxFjuq5btXYkwWfBZaz1rVwFgini"
+	btcWallet.Unlock(rig.crypter)
+	rig.ws.queueResponse(msgjson.LimitRoute, func(msg *msgjson.Message, f msgFunc) error {
+		msgOrder := new(msgjson.LimitOrder)
+		if err := msg.Unmarshal(msgOrder); err != nil {
+			t.Fatalf("unmarshal error: %v", err)
+		}
+		f(orderResponse(msg.ID, msgOrder, convertMsgLimitOrder(msgOrder), false, false, false))
+		return nil
+	})
+
+	// The order is not resubmitted until the retry delay has passed.
+	tCore.checkConditionalOrders(tCtx)
+	if s := status(pair[1].ID); s != db.ConditionalOrderActive {
+		t.Fatalf("expected active take-profit before retry, got %s", s)
+	}
+	tCore.condOrders.mtx.Lock()
+	tCore.condOrders.retryAt[pair[1].ID.String()] = time.Now()
+	tCore.condOrders.mtx.Unlock()
+
+	// Now the trade is placed, and the stop-loss partner is canceled.
+	tCore.checkConditionalOrders(tCtx)
+	if s := status(pair[1].ID); s != db.ConditionalOrderTriggered {
+		t.Fatalf("expected triggered take-profit, got %s", s)
+	}
+	if ord := rig.db.condOrders[pair[1].ID.String()]; len(ord.OrderID) == 0 || ord.Error != "" {
+		t.Fatalf("triggered conditional order has order ID %s and error %q", ord.OrderID, ord.Error)
+	}
+	if s := status(pair[0].ID); s != db.ConditionalOrderCanceled {
+		t.Fatalf("expected canceled stop-loss, got %s", s)
+	}
+	if s := status(single[0].ID); s != db.ConditionalOrderActive {
+		t.Fatalf("expected active take-profit, got %s", s)
+	}
+
+	// Cancel the remaining order.
+	if err := tCore.CancelConditionalOrder(single[0].ID); err != nil {
+		t.Fatalf("CancelConditionalOrder error: %v", err)
+	}
+	if s := status(single[0].ID); s != db.ConditionalOrderCanceled {
+		t.Fatalf("expected canceled take-profit, got %s", s)
+	}
+	if err := tCore.CancelConditionalOrder(single[0].ID); err == nil {
+		t.Fatalf("no error canceling an inactive conditional order")
+	}
+}
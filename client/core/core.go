@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/csv"
 	"encoding/hex"
@@ -31,6 +32,7 @@ import (
 	"decred.org/dcrdex/client/comms"
 	"decred.org/dcrdex/client/db"
 	"decred.org/dcrdex/client/db/bolt"
+	"decred.org/dcrdex/client/db/lexidb"
 	"decred.org/dcrdex/client/mnemonic"
 	"decred.org/dcrdex/client/orderbook"
 	"decred.org/dcrdex/dex"
@@ -43,6 +45,8 @@ import (
 	"decred.org/dcrdex/dex/wait"
 	"decred.org/dcrdex/server/account"
 	serverdex "decred.org/dcrdex/server/dex"
+	"decred.org/dcrdex/tatanka/client/mesh"
+	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/decred/dcrd/crypto/blake256"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
@@ -1427,9 +1431,23 @@ type blockWaiter struct {
 
 // Config is the configuration for the Core.
 type Config struct {
-	// DBPath is a filepath to use for the client database. If the database does
-	// not already exist, it will be created.
+	// DBPath is a filepath to use for the client database. The database is
+	// stored in a directory next to DBPath, with the file extension replaced by
+	// ".lexi". If the database does not already exist, it will be created, and
+	// the contents of any legacy bolt database file at DBPath will be migrated.
+	// The bolt file is left in place.
 	DBPath string
+	// UseBoltDB instructs Core to use the legacy bolt database file at DBPath
+	// instead of the lexi database. This can be used to opt out of the
+	// migration, or to roll back after it, though anything recorded after the
+	// migration is only in the lexi database.
+	UseBoltDB bool
+	// RestoreDB is the path of a lexi database backup file, as written by
+	// BackupDB or on shutdown, to restore before the database is opened.
+	// There must not already be a lexi database, so the existing one must be
+	// moved or removed first. Legacy bolt backups are copies of the database
+	// file, and are restored by copying them to DBPath.
+	RestoreDB string
 	// Net is the current network.
 	Net dex.Network
 	// Logger is the Core's logger and is also used to create the sub-loggers
@@ -1465,6 +1483,13 @@ type Config struct {
 	ExtensionModeFile string
 
 	TheOneHost string
+	// Watchtowers are the URLs of swap watchtowers. Active matches are
+	// registered with each watchtower so that refunds and redeems are
+	// broadcast while the client is offline.
+	Watchtowers []string
+	// Mesh configures trading on the Tatanka Mesh. Core connects to the mesh
+	// on login.
+	Mesh *MeshConfig
 }
 
 // locale is data associated with the currently selected language.
@@ -1538,6 +1563,37 @@ type Core struct {
 
 	requestedActionMtx sync.RWMutex
 	requestedActions   map[string]*asset.ActionRequiredNote
+
+	condOrders *conditionalOrders
+	alerts     *marketAlerts
+
+	newMeshClient func(*mesh.Config) (meshClient, error)
+	meshMtx       sync.RWMutex
+	mesh          *meshVenue
+}
+
+// openDB opens the client database specified by the Config.
+func openDB(cfg *Config) (db.DB, error) {
+	log := cfg.Logger.SubLogger("DB")
+	if cfg.UseBoltDB {
+		if cfg.RestoreDB != "" {
+			return nil, errors.New("cannot restore a lexi database backup to the bolt database")
+		}
+		return bolt.NewDB(cfg.DBPath, log, bolt.Opts{
+			BackupOnShutdown: !cfg.NoAutoDBBackup,
+		})
+	}
+	dbPath := strings.TrimSuffix(cfg.DBPath, filepath.Ext(cfg.DBPath)) + ".lexi"
+	if cfg.RestoreDB != "" {
+		if err := lexidb.Restore(cfg.RestoreDB, dbPath); err != nil {
+			return nil, fmt.Errorf("error restoring database backup: %w", err)
+		}
+		log.Infof("Restored database %s from backup %s", dbPath, cfg.RestoreDB)
+	}
+	return lexidb.NewDB(dbPath, log, lexidb.Opts{
+		BackupOnShutdown: !cfg.NoAutoDBBackup,
+		BoltPath:         cfg.DBPath,
+	})
 }
 
 // New is the constructor for a new Core.
@@ -1545,10 +1601,7 @@ func New(cfg *Config) (*Core, error) {
 	if cfg.Logger == nil {
 		return nil, fmt.Errorf("Core.Config must specify a Logger")
 	}
-	dbOpts := bolt.Opts{
-		BackupOnShutdown: !cfg.NoAutoDBBackup,
-	}
-	boltDB, err := bolt.NewDB(cfg.DBPath, cfg.Logger.SubLogger("DB"), dbOpts)
+	clientDB, err := openDB(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("database initialization error: %w", err)
 	}
@@ -1594,7 +1647,7 @@ func New(cfg *Config) (*Core, error) {
 	lang := language.Und
 
 	// Check if the user has set a language with SetLanguage.
-	if langStr, err := boltDB.Language(); err != nil {
+	if langStr, err := clientDB.Language(); err != nil {
 		cfg.Logger.Errorf("Error loading language from database: %v", err)
 	} else if len(langStr) > 0 {
 		if lang, err = parseLanguage(langStr); err != nil {
@@ -1624,12 +1677,12 @@ func New(cfg *Config) (*Core, error) {
 
 	// Try to get the primary credentials, but ignore no-credentials error here
 	// because the client may not be initialized.
-	creds, err := boltDB.PrimaryCredentials()
+	creds, err := clientDB.PrimaryCredentials()
 	if err != nil && !errors.Is(err, db.ErrNoCredentials) {
 		return nil, err
 	}
 
-	seedGenerationTime, err := boltDB.SeedGenerationTime()
+	seedGenerationTime, err := clientDB.SeedGenerationTime()
 	if err != nil && !errors.Is(err, db.ErrNoSeedGenTime) {
 		return nil, err
 	}
@@ -1651,7 +1704,7 @@ func New(cfg *Config) (*Core, error) {
 		ready:         make(chan struct{}),
 		rotate:        make(chan struct{}, 1),
 		log:           cfg.Logger,
-		db:            boltDB,
+		db:            clientDB,
 		conns:         make(map[string]*dexConnection),
 		wallets:       make(map[uint32]*xcWallet),
 		net:           cfg.Net,
@@ -1676,6 +1729,9 @@ func New(cfg *Config) (*Core, error) {
 
 		notes:            make(chan asset.WalletNotification, 128),
 		requestedActions: make(map[string]*asset.ActionRequiredNote),
+		condOrders:       newConditionalOrders(),
+		alerts:           newMarketAlerts(),
+		newMeshClient:    newMeshClient,
 	}
 
 	c.intl.Store(&locale{
@@ -1762,6 +1818,29 @@ fetchers:
 		c.watchBonds(ctx)
 	}()
 
+	// Watch conditional order triggers.
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.watchConditionalOrders(ctx)
+	}()
+
+	// Watch market alerts.
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.watchMarketAlerts(ctx)
+	}()
+
+	// Register active matches with watchtowers.
+	if len(c.cfg.Watchtowers) > 0 {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			c.registerWithWatchtowers(ctx)
+		}()
+	}
+
 	// Handle wallet notifications.
 	c.wg.Add(1)
 	go func() {
@@ -1879,7 +1958,9 @@ func (c *Core) Language() string {
 }
 
 // BackupDB makes a backup of the database at the specified location, optionally
-// overwriting any existing file and compacting the database.
+// overwriting any existing file and compacting the database. A backup of the
+// lexi database is a badger backup stream, not a database file, and is
+// restored with Config.RestoreDB.
 func (c *Core) BackupDB(dst string, overwrite, compact bool) error {
 	return c.db.BackupTo(dst, overwrite, compact)
 }
@@ -1963,12 +2044,22 @@ func (c *Core) Exchanges() map[string]*Exchange {
 	for _, dc := range dcs {
 		infos[dc.acct.host] = c.exchangeInfo(dc)
 	}
+	if v := c.meshVenue(); v != nil {
+		infos[MeshHost] = c.meshExchange(v)
+	}
 	return infos
 }
 
 // Exchange returns an exchange with a certain host. It returns an error if
 // no exchange exists at that host.
 func (c *Core) Exchange(host string) (*Exchange, error) {
+	if host == MeshHost {
+		v, err := c.connectedMesh()
+		if err != nil {
+			return nil, err
+		}
+		return c.meshExchange(v), nil
+	}
 	dc, _, err := c.dex(host)
 	if err != nil {
 		return nil, err
@@ -4620,6 +4711,7 @@ func (c *Core) Login(pw []byte) error {
 		}
 	}
 
+	var meshKey *secp256k1.PrivateKey
 	login := func() (needInit bool, err error) {
 		c.loginMtx.Lock()
 		defer c.loginMtx.Unlock()
@@ -4634,6 +4726,11 @@ func (c *Core) Login(pw []byte) error {
 			if err != nil {
 				return false, fmt.Errorf("GenDeepChild error: %w", err)
 			}
+			if c.cfg.Mesh != nil {
+				if meshKey, err = deriveMeshKey(seed); err != nil {
+					return false, fmt.Errorf("error deriving mesh key: %w", err)
+				}
+			}
 			c.loggedIn = true
 			return true, nil
 		}
@@ -4654,6 +4751,12 @@ func (c *Core) Login(pw []byte) error {
 		c.resolveActiveTrades(crypter)
 		c.notify(newLoginNote("Connecting to DEX servers..."))
 		c.initializeDEXConnections(crypter)
+		if meshKey != nil {
+			c.notify(newLoginNote("Connecting to the Tatanka Mesh..."))
+			if err := c.connectMesh(meshKey); err != nil {
+				c.log.Errorf("Error connecting to the Tatanka Mesh: %v", err)
+			}
+		}
 	}
 
 	return nil
@@ -4848,6 +4951,14 @@ func (c *Core) initializePrimaryCredentials(pw []byte, oldKeyParams []byte) erro
 	return nil
 }
 
+// LoggedIn is true if the app password has been used to log in since startup
+// or the last logout.
+func (c *Core) LoggedIn() bool {
+	c.loginMtx.Lock()
+	defer c.loginMtx.Unlock()
+	return c.loggedIn
+}
+
 // ActiveOrders returns a map of host to all of their active orders from db if
 // core is not yet logged in or from loaded trades map if core is logged in.
 // Inflight orders are also returned for all dex servers if any.
@@ -4888,7 +4999,7 @@ func (c *Core) Active() bool {
 			return true
 		}
 	}
-	return false
+	return c.meshActive()
 }
 
 // Logout logs the user out
@@ -4930,6 +5041,8 @@ func (c *Core) Logout() error {
 		dc.acct.lock()
 	}
 
+	c.disconnectMesh()
+
 	c.bondXPriv.Zero()
 	c.bondXPriv = nil
 
@@ -5315,6 +5428,11 @@ func (c *Core) feeSuggestionAny(assetID uint32, preferredConns ...*dexConnection
 		}
 	}
 
+	// Check the mesh fee rate oracle.
+	if r := c.meshFeeRate(assetID); r != 0 {
+		return r
+	}
+
 	// Look for cached rates from epoch_report messages.
 	conns := append(preferredConns, c.dexConnections()...)
 	for _, dc := range conns {
@@ -5431,6 +5549,226 @@ func (c *Core) Send(pw []byte, assetID uint32, value uint64, address string, sub
 	return coin, nil
 }
 
+// SendMulti pays multiple addresses from an exchange wallet. UTXO-based
+// wallets pay every address with a single transaction, while account-based
+// wallets send a transaction for each payment. Fees are in addition to the
+// payment values. The returned coins correspond to the payments. If some of an
+// account-based wallet's transactions were sent before an error was
+// encountered, the coins for those payments are returned with the error.
+func (c *Core) SendMulti(pw []byte, assetID uint32, payments []*asset.Payment) ([]asset.Coin, error) {
+	var crypter encrypt.Crypter
+	if len(pw) > 0 {
+		var err error
+		crypter, err = c.encryptionKey(pw)
+		if err != nil {
+			return nil, fmt.Errorf("Trade password error: %w", err)
+		}
+		defer crypter.Close()
+	}
+
+	if len(payments) == 0 {
+		return nil, errors.New("no payments")
+	}
+	for _, p := range payments {
+		if p.Value == 0 {
+			return nil, fmt.Errorf("cannot send zero %s to %s", unbip(assetID), p.Address)
+		}
+	}
+	wallet, found := c.wallet(assetID)
+	if !found {
+		return nil, newError(missingWalletErr, "no wallet found for %s", unbip(assetID))
+	}
+	multiSender, is := wallet.Wallet.(asset.MultiSender)
+	if !is {
+		return nil, fmt.Errorf("%s wallet does not support sending to multiple addresses", unbip(assetID))
+	}
+	err := c.connectAndUnlock(crypter, wallet)
+	if err != nil {
+		return nil, err
+	}
+	if err = wallet.checkPeersAndSyncStatus(); err != nil {
+		return nil, err
+	}
+
+	coins, err := multiSender.SendMulti(payments, c.feeSuggestionAny(assetID))
+	for i, coin := range coins {
+		sentValue := wallet.Info().UnitInfo.ConventionalString(coin.Value())
+		subject, details := c.formatDetails(TopicSendSuccess, sentValue, unbip(assetID), payments[i].Address, coin)
+		c.notify(newSendNote(TopicSendSuccess, subject, details, db.Success))
+	}
+	if err != nil {
+		subject, details := c.formatDetails(TopicSendError, unbip(assetID), err)
+		c.notify(newSendNote(TopicSendError, subject, details, db.ErrorLevel))
+	}
+	if len(coins) > 0 {
+		c.updateAssetBalance(assetID)
+	}
+	return coins, err
+}
+
+// EstimateSendMultiTxFee returns an estimate of the total fees needed for
+// SendMulti to pay the addresses.
+func (c *Core) EstimateSendMultiTxFee(assetID uint32, payments []*asset.Payment) (uint64, error) {
+	wallet, found := c.wallet(assetID)
+	if !found {
+		return 0, newError(missingWalletErr, "no wallet found for %s", unbip(assetID))
+	}
+	if !wallet.traits.IsMultiSender() {
+		return 0, fmt.Errorf("%s wallet does not support sending to multiple addresses", unbip(assetID))
+	}
+	multiSender, is := wallet.Wallet.(asset.MultiSender)
+	if !is {
+		return 0, fmt.Errorf("%s wallet does not support sending to multiple addresses", unbip(assetID))
+	}
+	return multiSender.EstimateSendMultiTxFee(payments, c.feeSuggestionAny(assetID))
+}
+
+// SendPSBT authors an unsigned PSBT that sends value to the address, for
+// signing by an external signer. If subtract is true, the network fees are
+// subtracted from the value, as with a withdraw. The wallet does not need to be
+// unlocked. The PSBT is returned base64-encoded.
+func (c *Core) SendPSBT(assetID uint32, value uint64, address string, subtract bool) (string, error) {
+	if value == 0 {
+		return "", fmt.Errorf("cannot send/withdraw zero %s", unbip(assetID))
+	}
+	wallet, err := c.connectedWallet(assetID)
+	if err != nil {
+		return "", err
+	}
+	psbter, is := wallet.Wallet.(asset.PSBTer)
+	if !is {
+		return "", fmt.Errorf("%s wallet does not support PSBTs", unbip(assetID))
+	}
+	if err = wallet.checkPeersAndSyncStatus(); err != nil {
+		return "", err
+	}
+	b, err := psbter.SendPSBT(address, value, c.feeSuggestionAny(assetID), subtract)
+	if err != nil {
+		return "", err
+	}
+	c.updateAssetBalance(assetID)
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// BroadcastPSBT finalizes a base64-encoded PSBT that was signed by an external
+// signer and broadcasts the transaction. The transaction ID is returned.
+func (c *Core) BroadcastPSBT(assetID uint32, signedPSBT string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signedPSBT))
+	if err != nil {
+		return "", fmt.Errorf("error decoding PSBT: %w", err)
+	}
+	wallet, err := c.connectedWallet(assetID)
+	if err != nil {
+		return "", err
+	}
+	psbter, is := wallet.Wallet.(asset.PSBTer)
+	if !is {
+		return "", fmt.Errorf("%s wallet does not support PSBTs", unbip(assetID))
+	}
+	txID, err := psbter.BroadcastPSBT(b)
+	if err != nil {
+		subject, details := c.formatDetails(TopicSendError, unbip(assetID), err)
+		c.notify(newSendNote(TopicSendError, subject, details, db.ErrorLevel))
+		return "", err
+	}
+	c.updateAssetBalance(assetID)
+	return txID, nil
+}
+
+// AbandonPSBT unlocks the wallet inputs of a base64-encoded unsigned PSBT
+// that will not be signed and broadcast. If the PSBT funds a pending bond
+// posted with PostBondForm.PSBT, the bond is dropped. The transaction ID is
+// returned.
+func (c *Core) AbandonPSBT(assetID uint32, unsignedPSBT string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(unsignedPSBT))
+	if err != nil {
+		return "", fmt.Errorf("error decoding PSBT: %w", err)
+	}
+	wallet, err := c.connectedWallet(assetID)
+	if err != nil {
+		return "", err
+	}
+	psbter, is := wallet.Wallet.(asset.PSBTer)
+	if !is {
+		return "", fmt.Errorf("%s wallet does not support PSBTs", unbip(assetID))
+	}
+	txID, err := psbter.AbandonPSBT(b)
+	if err != nil {
+		return "", err
+	}
+	c.dropUnsignedBonds(assetID, txID)
+	c.updateBondReserves()
+	c.updateAssetBalance(assetID)
+	return txID, nil
+}
+
+// dropUnsignedBonds removes any pending bonds that were authored as PSBTs in
+// the transaction with the given ID. The bonds were never broadcast, so there
+// is nothing to refund.
+func (c *Core) dropUnsignedBonds(assetID uint32, txID string) {
+	for _, dc := range c.dexConnections() {
+		var dropped []*db.Bond
+		dc.acct.authMtx.Lock()
+		for i := 0; i < len(dc.acct.pendingBonds); i++ {
+			bond := dc.acct.pendingBonds[i]
+			if bond.AssetID != assetID || len(bond.SignedTx) > 0 ||
+				!strings.HasPrefix(coinIDString(assetID, bond.CoinID), txID+":") {
+				continue
+			}
+			dc.acct.pendingBonds = cutBond(dc.acct.pendingBonds, i)
+			delete(dc.acct.pendingBondsConfs, coinIDString(assetID, bond.CoinID))
+			dropped = append(dropped, bond)
+			i--
+		}
+		dc.acct.authMtx.Unlock()
+		for _, bond := range dropped {
+			bondIDStr := coinIDString(assetID, bond.CoinID)
+			c.removeWaiter(bondIDStr)
+			if err := c.db.BondRefunded(dc.acct.host, assetID, bond.CoinID); err != nil {
+				c.log.Errorf("Failed to mark abandoned bond %s as refunded: %v", bondIDStr, err)
+			}
+			c.log.Infof("Dropped unsigned bond %s for %s", bondIDStr, dc.acct.host)
+		}
+	}
+}
+
+// RefundBondPSBT authors a base64-encoded unsigned PSBT that refunds an
+// expired bond to the bond asset wallet. The bond input must be signed with the
+// bond's private key before the PSBT is broadcast with BroadcastPSBT.
+func (c *Core) RefundBondPSBT(host string, assetID uint32, bondID string) (string, error) {
+	dc, _, err := c.dex(host)
+	if err != nil {
+		return "", err
+	}
+	var bond *db.Bond
+	dc.acct.authMtx.RLock()
+	for _, bonds := range [][]*db.Bond{dc.acct.expiredBonds, dc.acct.bonds} {
+		for _, b := range bonds {
+			if b.AssetID == assetID && coinIDString(assetID, b.CoinID) == bondID {
+				bond = b
+				break
+			}
+		}
+	}
+	dc.acct.authMtx.RUnlock()
+	if bond == nil {
+		return "", fmt.Errorf("no active or expired %s bond %s for %s", unbip(assetID), bondID, host)
+	}
+	wallet, err := c.connectedWallet(assetID)
+	if err != nil {
+		return "", err
+	}
+	psbter, is := wallet.Wallet.(asset.PSBTer)
+	if !is {
+		return "", fmt.Errorf("%s wallet does not support PSBTs", unbip(assetID))
+	}
+	b, err := psbter.RefundBondPSBT(bond.Version, bond.CoinID, bond.Data, bond.Amount, c.feeSuggestionAny(assetID))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
 // ValidateAddress checks that the provided address is valid.
 func (c *Core) ValidateAddress(address string, assetID uint32) (bool, error) {
 	if address == "" {
@@ -5961,6 +6299,9 @@ func (c *Core) WalletTransaction(assetID uint32, txID string) (*asset.WalletTran
 
 // Trade is used to place a market or limit order.
 func (c *Core) Trade(pw []byte, form *TradeForm) (*Order, error) {
+	if form.Host == MeshHost {
+		return c.tradeMesh(pw, form)
+	}
 	req, err := c.prepareTradeRequest(pw, form)
 	if err != nil {
 		return nil, err
@@ -6712,6 +7053,11 @@ func (c *Core) walletSet(dc *dexConnection, baseID, quoteID uint32, sell bool) (
 }
 
 func (c *Core) Cancel(oidB dex.Bytes) error {
+	if len(oidB) == len(tanka.ID40{}) {
+		var oid tanka.ID40
+		copy(oid[:], oidB)
+		return c.cancelMeshOrder(oid)
+	}
 	oid, err := order.IDFromBytes(oidB)
 	if err != nil {
 		return err
@@ -10544,6 +10890,14 @@ func (c *Core) refreshFiatRates(ctx context.Context) {
 	fiatRatesMap := c.fiatConversions()
 	if len(fiatRatesMap) != 0 {
 		c.notify(newFiatRatesUpdate(fiatRatesMap))
+		// Keep a history of rates for valuing past events in the ledger.
+		snapshot := &db.FiatRateSnapshot{
+			Stamp: uint64(time.Now().UnixMilli()),
+			Rates: fiatRatesMap,
+		}
+		if err := c.db.StoreFiatRates(snapshot); err != nil {
+			c.log.Errorf("Error storing fiat rate history: %v", err)
+		}
 	}
 }
 
@@ -10588,6 +10942,11 @@ func (c *Core) fiatConversions() map[uint32]float64 {
 				rateSum += rateInfo.rate
 			}
 		}
+		// The mesh fiat rate oracle counts as another source.
+		if rate := c.meshFiatRate(assetID); rate > 0 {
+			sources++
+			rateSum += rate
+		}
 		if rateSum != 0 {
 			fiatRatesMap[assetID] = rateSum / float64(sources) // get average rate.
 		}
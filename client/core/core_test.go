@@ -7,6 +7,7 @@ import (
 	"context"
 	crand "crypto/rand"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -44,7 +45,17 @@ import (
 
 var rand = mrand.New(mrand.NewPCG(0xbadc0de, 0xdeadbeef))
 
+// tPSBTAssetID is a test asset with coin IDs in the txid:vout format.
+const (
+	tPSBTAssetID = 0x7fff0001
+	tPSBTTxID    = "abcd"
+)
+
 func init() {
+	asset.Register(tPSBTAssetID, &tDriver{
+		decodedCoinID: tPSBTTxID + ":0",
+		winfo:         tWalletInfo,
+	})
 	asset.Register(tUTXOAssetA.ID, &tDriver{
 		decodedCoinID: tUTXOAssetA.Symbol + "-coin",
 		winfo:         tWalletInfo,
@@ -387,6 +398,12 @@ type TDB struct {
 	deleteInactiveMatchesErr error
 	archivedMatches          int
 	updateAccountInfoErr     error
+	condOrders               map[string]*db.ConditionalOrder
+	marketAlerts             map[string]*db.MarketAlert
+	meshBonds                map[string]*db.Bond
+	routedOrders             []*db.RoutedOrder
+	fiatRates                []*db.FiatRateSnapshot
+	wallets                  []*db.Wallet
 }
 
 func (tdb *TDB) Run(context.Context) {}
@@ -523,7 +540,7 @@ func (tdb *TDB) UpdateWalletStatus(wid []byte, disable bool) error {
 }
 
 func (tdb *TDB) Wallets() ([]*db.Wallet, error) {
-	return nil, nil
+	return tdb.wallets, nil
 }
 
 func (tdb *TDB) Wallet([]byte) (*db.Wallet, error) {
@@ -583,6 +600,93 @@ func (tdb *TDB) Backup() error {
 
 func (tdb *TDB) AckNotification(id []byte) error { return nil }
 
+func (tdb *TDB) UpdateConditionalOrder(ord *db.ConditionalOrder) error {
+	if tdb.condOrders == nil {
+		tdb.condOrders = make(map[string]*db.ConditionalOrder)
+	}
+	o := *ord
+	tdb.condOrders[ord.ID.String()] = &o
+	return nil
+}
+
+func (tdb *TDB) ConditionalOrders() ([]*db.ConditionalOrder, error) {
+	ords := make([]*db.ConditionalOrder, 0, len(tdb.condOrders))
+	for _, ord := range tdb.condOrders {
+		o := *ord
+		ords = append(ords, &o)
+	}
+	return ords, nil
+}
+
+func (tdb *TDB) UpdateMarketAlert(alert *db.MarketAlert) error {
+	if tdb.marketAlerts == nil {
+		tdb.marketAlerts = make(map[string]*db.MarketAlert)
+	}
+	a := *alert
+	tdb.marketAlerts[alert.ID.String()] = &a
+	return nil
+}
+
+func (tdb *TDB) MarketAlerts() ([]*db.MarketAlert, error) {
+	alerts := make([]*db.MarketAlert, 0, len(tdb.marketAlerts))
+	for _, alert := range tdb.marketAlerts {
+		a := *alert
+		alerts = append(alerts, &a)
+	}
+	return alerts, nil
+}
+
+func (tdb *TDB) UpdateMeshBond(bond *db.Bond) error {
+	if tdb.meshBonds == nil {
+		tdb.meshBonds = make(map[string]*db.Bond)
+	}
+	b := *bond
+	tdb.meshBonds[dex.Bytes(bond.UniqueID()).String()] = &b
+	return nil
+}
+
+func (tdb *TDB) MeshBonds() ([]*db.Bond, error) {
+	bonds := make([]*db.Bond, 0, len(tdb.meshBonds))
+	for _, bond := range tdb.meshBonds {
+		b := *bond
+		bonds = append(bonds, &b)
+	}
+	return bonds, nil
+}
+
+func (tdb *TDB) DeleteMarketAlert(id []byte) error {
+	k := dex.Bytes(id).String()
+	if tdb.marketAlerts[k] == nil {
+		return fmt.Errorf("market alert %x not found", id)
+	}
+	delete(tdb.marketAlerts, k)
+	return nil
+}
+
+func (tdb *TDB) UpdateRoutedOrder(ord *db.RoutedOrder) error {
+	tdb.routedOrders = append(tdb.routedOrders, ord)
+	return nil
+}
+
+func (tdb *TDB) RoutedOrders() ([]*db.RoutedOrder, error) {
+	return tdb.routedOrders, nil
+}
+
+func (tdb *TDB) StoreFiatRates(snapshot *db.FiatRateSnapshot) error {
+	tdb.fiatRates = append(tdb.fiatRates, snapshot)
+	return nil
+}
+
+func (tdb *TDB) FiatRateHistory(from, to uint64) ([]*db.FiatRateSnapshot, error) {
+	snaps := make([]*db.FiatRateSnapshot, 0, len(tdb.fiatRates))
+	for _, snap := range tdb.fiatRates {
+		if snap.Stamp >= from && snap.Stamp <= to {
+			snaps = append(snaps, snap)
+		}
+	}
+	return snaps, nil
+}
+
 func (tdb *TDB) SetLanguage(lang string) error {
 	return nil
 }
@@ -1361,6 +1465,8 @@ func newTestRig() *testRig {
 			notes:            make(chan asset.WalletNotification, 128),
 			pokesCache:       newPokesCache(pokesCapacity),
 			requestedActions: make(map[string]*asset.ActionRequiredNote),
+			condOrders:       newConditionalOrders(),
+			alerts:           newMarketAlerts(),
 		},
 		db:      tdb,
 		queue:   queue,
@@ -11291,3 +11397,155 @@ func TestTakeAction(t *testing.T) {
 	}
 
 }
+
+type tPSBTWallet struct {
+	*TXCWallet
+	refundPSBT []byte
+	abandonErr error
+	abandonID  string
+}
+
+func (w *tPSBTWallet) SendPSBT(address string, value, feeRate uint64, subtract bool) ([]byte, error) {
+	return nil, nil
+}
+
+func (w *tPSBTWallet) MakeBondPSBT(ver uint16, amt, feeRate uint64, lockTime time.Time, privKey *secp256k1.PrivateKey, acctID []byte) (*asset.Bond, []byte, func(), error) {
+	return nil, nil, nil, tErr
+}
+
+func (w *tPSBTWallet) RefundBondPSBT(ver uint16, coinID, script []byte, amt, feeRate uint64) ([]byte, error) {
+	return w.refundPSBT, nil
+}
+
+func (w *tPSBTWallet) BroadcastPSBT(b []byte) (string, error) {
+	return "", nil
+}
+
+func (w *tPSBTWallet) AbandonPSBT(b []byte) (string, error) {
+	return w.abandonID, w.abandonErr
+}
+
+func TestBondPSBTs(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+	dc := rig.dc
+
+	assetID := uint32(tPSBTAssetID)
+	wallet, tWallet := newTWallet(assetID)
+	psbtWallet := &tPSBTWallet{TXCWallet: tWallet, refundPSBT: []byte{0x01}}
+	wallet.Wallet = psbtWallet
+	tCore.wallets[assetID] = wallet
+
+	unsignedBond := &db.Bond{AssetID: assetID, CoinID: encode.RandomBytes(36)}
+	signedBond := &db.Bond{AssetID: assetID, CoinID: unsignedBond.CoinID, SignedTx: []byte{0x01}}
+	txID := tPSBTTxID
+	unsignedPSBT64 := base64.StdEncoding.EncodeToString([]byte{0x02})
+
+	// A signed bond is not dropped.
+	dc.acct.pendingBonds = []*db.Bond{signedBond}
+	psbtWallet.abandonID = txID
+	if _, err := tCore.AbandonPSBT(assetID, unsignedPSBT64); err != nil {
+		t.Fatalf("AbandonPSBT error: %v", err)
+	}
+	if len(dc.acct.pendingBonds) != 1 {
+		t.Fatalf("signed bond dropped")
+	}
+
+	// An unsigned bond in the abandoned tx is dropped.
+	dc.acct.pendingBonds = []*db.Bond{unsignedBond}
+	if _, err := tCore.AbandonPSBT(assetID, unsignedPSBT64); err != nil {
+		t.Fatalf("AbandonPSBT error: %v", err)
+	}
+	if len(dc.acct.pendingBonds) != 0 {
+		t.Fatalf("unsigned bond not dropped")
+	}
+
+	// Wallet error.
+	dc.acct.pendingBonds = []*db.Bond{unsignedBond}
+	psbtWallet.abandonErr = tErr
+	if _, err := tCore.AbandonPSBT(assetID, unsignedPSBT64); err == nil {
+		t.Fatalf("no error for wallet error")
+	}
+	if len(dc.acct.pendingBonds) != 1 {
+		t.Fatalf("bond dropped after wallet error")
+	}
+	psbtWallet.abandonErr = nil
+
+	// Bad encoding.
+	if _, err := tCore.AbandonPSBT(assetID, "%%%"); err == nil {
+		t.Fatalf("no error for invalid base64")
+	}
+
+	// Refund PSBTs are only authored for known bonds.
+	bondID := coinIDString(assetID, signedBond.CoinID)
+	dc.acct.pendingBonds = nil
+	if _, err := tCore.RefundBondPSBT(tDexHost, assetID, bondID); err == nil {
+		t.Fatalf("no error for unknown bond")
+	}
+	dc.acct.expiredBonds = []*db.Bond{signedBond}
+	b64, err := tCore.RefundBondPSBT(tDexHost, assetID, bondID)
+	if err != nil {
+		t.Fatalf("RefundBondPSBT error: %v", err)
+	}
+	if b64 != base64.StdEncoding.EncodeToString(psbtWallet.refundPSBT) {
+		t.Fatalf("wrong refund PSBT")
+	}
+
+	// Wallets that can't author PSBTs.
+	wallet.Wallet = tWallet
+	if _, err := tCore.RefundBondPSBT(tDexHost, assetID, bondID); err == nil {
+		t.Fatalf("no error for non-PSBT wallet")
+	}
+	if _, err := tCore.AbandonPSBT(assetID, unsignedPSBT64); err == nil {
+		t.Fatalf("no error for non-PSBT wallet")
+	}
+	_, err = tCore.PostBond(&PostBondForm{Addr: tDexHost, AppPass: tPW, Asset: &assetID, Bond: 1, PSBT: true})
+	if err == nil || !strings.Contains(err.Error(), "PSBT") {
+		t.Fatalf("wrong error for PSBT bond with non-PSBT wallet: %v", err)
+	}
+}
+
+type tHistorianWallet struct {
+	*TXCWallet
+	txs map[string]*asset.WalletTransaction
+}
+
+func (w *tHistorianWallet) TxHistory(n int, refID *string, past bool) ([]*asset.WalletTransaction, error) {
+	return nil, nil
+}
+
+func (w *tHistorianWallet) WalletTransaction(_ context.Context, txID string) (*asset.WalletTransaction, error) {
+	if tx, found := w.txs[txID]; found {
+		return tx, nil
+	}
+	return nil, asset.CoinNotFoundError
+}
+
+func TestRefundFee(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+
+	assetID := uint32(tPSBTAssetID)
+	wallet, tWallet := newTWallet(assetID)
+	tCore.wallets[assetID] = wallet
+	refundCoin := encode.RandomBytes(36)
+
+	// No history.
+	if fee := tCore.refundFee(wallet, refundCoin); fee != 0 {
+		t.Fatalf("expected zero fee without history, got %d", fee)
+	}
+
+	historian := &tHistorianWallet{TXCWallet: tWallet, txs: make(map[string]*asset.WalletTransaction)}
+	wallet.Wallet = historian
+	if fee := tCore.refundFee(wallet, refundCoin); fee != 0 {
+		t.Fatalf("expected zero fee for unknown transaction, got %d", fee)
+	}
+
+	// The refund coin's transaction ID is the coin ID without the output index.
+	historian.txs[tPSBTTxID] = &asset.WalletTransaction{Type: asset.Refund, ID: tPSBTTxID, Fees: 1234}
+	if fee := tCore.refundFee(wallet, refundCoin); fee != 1234 {
+		t.Fatalf("expected fee 1234, got %d", fee)
+	}
+}
@@ -0,0 +1,645 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex/calc"
+)
+
+// LedgerEventType is the type of a ledger event.
+type LedgerEventType string
+
+const (
+	// LedgerTrade is a completed DEX match. The swapped asset is sent and the
+	// redeemed asset is received.
+	LedgerTrade LedgerEventType = "trade"
+	// LedgerFee is a network fee not attributed to another event, such as
+	// swap, redeem, refund and split fees.
+	LedgerFee LedgerEventType = "fee"
+	// LedgerBondPost and LedgerBondRefund are fidelity bond transactions. The
+	// bonded amount remains the user's, so only the fees are recorded.
+	LedgerBondPost   LedgerEventType = "bondpost"
+	LedgerBondRefund LedgerEventType = "bondrefund"
+	LedgerSend       LedgerEventType = "send"
+	LedgerReceive    LedgerEventType = "receive"
+	LedgerBridgeOut  LedgerEventType = "bridgeout"
+	LedgerBridgeIn   LedgerEventType = "bridgein"
+	// LedgerCEXTrade is a trade made by a market making bot on a centralized
+	// exchange.
+	LedgerCEXTrade LedgerEventType = "cextrade"
+)
+
+// Cost basis methods.
+const (
+	CostBasisFIFO    = "fifo"
+	CostBasisLIFO    = "lifo"
+	CostBasisAverage = "average"
+)
+
+// LedgerCurrency is the fiat currency that ledger values are reported in. The
+// fiat rate sources are all USD.
+const LedgerCurrency = "USD"
+
+// ledgerRateMaxAge is how old a fiat rate snapshot can be and still be used to
+// value an event. Rates are not taken from snapshots after the event.
+const ledgerRateMaxAge = 24 * time.Hour
+
+// LedgerAmount is an amount of an asset and its fiat value at the time of the
+// event.
+type LedgerAmount struct {
+	AssetID uint32 `json:"assetID"`
+	Symbol  string `json:"symbol"`
+	// Amount is in atoms.
+	Amount uint64  `json:"amount"`
+	Value  float64 `json:"value"`
+	// Unvalued is true if there was no fiat rate for the asset within
+	// ledgerRateMaxAge before the event, in which case Value is zero.
+	Unvalued bool `json:"unvalued,omitempty"`
+}
+
+// LedgerEvent is a balance-changing event. Proceeds, CostBasis and Gain are
+// populated by the ledger.
+type LedgerEvent struct {
+	// Stamp is the time of the event, in milliseconds.
+	Stamp  uint64          `json:"stamp"`
+	Type   LedgerEventType `json:"type"`
+	Source string          `json:"source"`
+	ID     string          `json:"id"`
+	// LinkedID is the ID of a related event, e.g. the initiating
+	// transaction of a completed bridge.
+	LinkedID  string        `json:"linkedID,omitempty"`
+	Sent      *LedgerAmount `json:"sent,omitempty"`
+	Received  *LedgerAmount `json:"received,omitempty"`
+	Fee       *LedgerAmount `json:"fee,omitempty"`
+	Proceeds  float64       `json:"proceeds"`
+	CostBasis float64       `json:"costBasis"`
+	Gain      float64       `json:"gain"`
+	// Taxable is true for disposals that realize a gain or loss.
+	Taxable bool `json:"taxable"`
+}
+
+// LedgerForm is information necessary to generate a ledger.
+type LedgerForm struct {
+	// Start and End are the reporting period, in milliseconds. Events before
+	// Start still contribute to the cost basis. A zero End is now.
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+	// Method is the cost basis method, one of fifo, lifo, or average. The
+	// default is fifo.
+	Method string `json:"method"`
+	// ExtraEvents are events from other subsystems, e.g. market making CEX
+	// trades. The values are assigned by the ledger.
+	ExtraEvents []*LedgerEvent `json:"extraEvents,omitempty"`
+}
+
+// LedgerAssetSummary is the position in an asset at the end of the reporting
+// period.
+type LedgerAssetSummary struct {
+	AssetID      uint32  `json:"assetID"`
+	Symbol       string  `json:"symbol"`
+	Holdings     uint64  `json:"holdings"`
+	CostBasis    float64 `json:"costBasis"`
+	RealizedGain float64 `json:"realizedGain"`
+}
+
+// Ledger is a record of balance-changing events with their fiat values and
+// realized gains.
+type Ledger struct {
+	Method       string                `json:"method"`
+	Currency     string                `json:"currency"`
+	Start        uint64                `json:"start"`
+	End          uint64                `json:"end"`
+	Events       []*LedgerEvent        `json:"events"`
+	Assets       []*LedgerAssetSummary `json:"assets"`
+	RealizedGain float64               `json:"realizedGain"`
+}
+
+// Ledger generates a ledger of trades, fees, bonds, sends, receives and bridge
+// transfers, valued at the fiat rates recorded at the time of each event, with
+// gains realized according to the cost basis method. Fiat rates are recorded
+// while rate sources are enabled. Amounts with no rate recorded within
+// ledgerRateMaxAge before the event have a zero value and are flagged
+// Unvalued.
+func (c *Core) Ledger(form *LedgerForm) (*Ledger, error) {
+	method := form.Method
+	if method == "" {
+		method = CostBasisFIFO
+	}
+	switch method {
+	case CostBasisFIFO, CostBasisLIFO, CostBasisAverage:
+	default:
+		return nil, fmt.Errorf("unknown cost basis method %q", form.Method)
+	}
+	end := form.End
+	if end == 0 {
+		end = uint64(time.Now().UnixMilli())
+	}
+	if form.Start > end {
+		return nil, fmt.Errorf("start %d is after end %d", form.Start, end)
+	}
+
+	events, err := c.ledgerTradeEvents()
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, c.ledgerWalletEvents()...)
+	events = append(events, form.ExtraEvents...)
+
+	snapshots, err := c.db.FiatRateHistory(0, end)
+	if err != nil {
+		return nil, codedError(dbErr, err)
+	}
+	return buildLedger(events, snapshots, method, form.Start, end), nil
+}
+
+// ledgerTradeEvents creates trade events for completed matches and fee events
+// for the swap, redeem, refund and funding fees of all orders.
+func (c *Core) ledgerTradeEvents() ([]*LedgerEvent, error) {
+	ords, err := c.Orders(&OrderFilter{})
+	if err != nil {
+		return nil, err
+	}
+	var events []*LedgerEvent
+	for _, ord := range ords {
+		fromID, toID := ord.QuoteID, ord.BaseID
+		if ord.Sell {
+			fromID, toID = ord.BaseID, ord.QuoteID
+		}
+		lastStamp := ord.Stamp
+		for _, m := range ord.Matches {
+			if m.IsCancel || m.Redeem == nil || m.Refund != nil {
+				continue
+			}
+			if m.Stamp > lastStamp {
+				lastStamp = m.Stamp
+			}
+			baseQty, quoteQty := m.Qty, calc.BaseToQuote(m.Rate, m.Qty)
+			sent, received := ledgerAmount(ord.QuoteID, quoteQty), ledgerAmount(ord.BaseID, baseQty)
+			if ord.Sell {
+				sent, received = ledgerAmount(ord.BaseID, baseQty), ledgerAmount(ord.QuoteID, quoteQty)
+			}
+			events = append(events, &LedgerEvent{
+				Stamp:    m.Stamp,
+				Type:     LedgerTrade,
+				Source:   ord.Host,
+				ID:       m.MatchID.String(),
+				LinkedID: ord.ID.String(),
+				Sent:     sent,
+				Received: received,
+			})
+		}
+		if ord.FeesPaid == nil {
+			continue
+		}
+		for _, fee := range []struct {
+			desc    string
+			assetID uint32
+			amt     uint64
+		}{
+			{"swap", fromID, ord.FeesPaid.Swap},
+			{"redeem", toID, ord.FeesPaid.Redemption},
+			{"refund", fromID, ord.FeesPaid.Refund},
+			{"funding", fromID, ord.FeesPaid.Funding},
+		} {
+			if fee.amt == 0 {
+				continue
+			}
+			events = append(events, &LedgerEvent{
+				Stamp:    lastStamp,
+				Type:     LedgerFee,
+				Source:   ord.Host,
+				ID:       ord.ID.String() + "-" + fee.desc,
+				LinkedID: ord.ID.String(),
+				Fee:      ledgerAmount(feeAssetID(fee.assetID), fee.amt),
+			})
+		}
+	}
+	return events, nil
+}
+
+// ledgerWalletEvents creates events from the transaction histories of wallets
+// that keep one. Swaps, redeems and refunds are skipped, since their amounts
+// and fees are accounted for by the orders.
+func (c *Core) ledgerWalletEvents() []*LedgerEvent {
+	var events []*LedgerEvent
+	seen := make(map[string]bool)
+	for _, w := range c.xcWallets() {
+		if !w.connected() {
+			continue
+		}
+		txs, err := w.TxHistory(0, nil, false)
+		if err != nil {
+			c.log.Debugf("No transaction history for %s ledger: %v", unbip(w.AssetID), err)
+			continue
+		}
+		for _, tx := range txs {
+			if tx.Timestamp == 0 || tx.Type == asset.Swap || tx.Type == asset.Redeem || tx.Type == asset.Refund {
+				continue
+			}
+			amtAssetID := w.AssetID
+			if tx.TokenID != nil {
+				amtAssetID = *tx.TokenID
+			}
+			// Token transactions can be in both the parent and token wallet
+			// histories.
+			k := strconv.FormatUint(uint64(amtAssetID), 10) + tx.ID
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if e := ledgerWalletEvent(tx, amtAssetID, feeAssetID(w.AssetID)); e != nil {
+				events = append(events, e)
+			}
+		}
+	}
+	return events
+}
+
+// ledgerWalletEvent creates an event for a wallet transaction, or nil if the
+// transaction had no effect on the balance.
+func ledgerWalletEvent(tx *asset.WalletTransaction, amtAssetID, feeAssetID uint32) *LedgerEvent {
+	e := &LedgerEvent{
+		Stamp:  tx.Timestamp * 1000,
+		Type:   LedgerFee,
+		Source: unbip(amtAssetID),
+		ID:     tx.ID,
+	}
+	if tx.Fees > 0 {
+		e.Fee = ledgerAmount(feeAssetID, tx.Fees)
+	}
+	if tx.BridgeCounterpartTx != nil {
+		e.LinkedID = tx.BridgeCounterpartTx.ID
+	}
+	if !tx.Rejected && tx.Amount > 0 {
+		switch tx.Type {
+		case asset.Send:
+			e.Type = LedgerSend
+			e.Sent = ledgerAmount(amtAssetID, tx.Amount)
+		case asset.Receive:
+			e.Type = LedgerReceive
+			e.Received = ledgerAmount(amtAssetID, tx.Amount)
+		case asset.InitiateBridge:
+			e.Type = LedgerBridgeOut
+			e.Sent = ledgerAmount(amtAssetID, tx.Amount)
+		case asset.CompleteBridge:
+			e.Type = LedgerBridgeIn
+			e.Received = ledgerAmount(amtAssetID, tx.Amount)
+		}
+	}
+	switch tx.Type {
+	case asset.CreateBond:
+		e.Type = LedgerBondPost
+	case asset.RedeemBond:
+		e.Type = LedgerBondRefund
+	}
+	if e.Sent == nil && e.Received == nil && e.Fee == nil {
+		return nil
+	}
+	return e
+}
+
+func ledgerAmount(assetID uint32, amt uint64) *LedgerAmount {
+	return &LedgerAmount{
+		AssetID: assetID,
+		Symbol:  unbip(assetID),
+		Amount:  amt,
+	}
+}
+
+// fiatRateHistory looks up historical fiat rates.
+type fiatRateHistory []*db.FiatRateSnapshot
+
+// rate is the asset's rate in the latest snapshot at or before the stamp. If
+// there is no such snapshot within ledgerRateMaxAge, the asset is unvalued and
+// ok is false.
+func (h fiatRateHistory) rate(assetID uint32, stamp uint64) (r float64, ok bool) {
+	var oldest uint64
+	if maxAge := uint64(ledgerRateMaxAge.Milliseconds()); stamp > maxAge {
+		oldest = stamp - maxAge
+	}
+	i := sort.Search(len(h), func(i int) bool { return h[i].Stamp > stamp })
+	for j := i - 1; j >= 0 && h[j].Stamp >= oldest; j-- {
+		if r, found := h[j].Rates[assetID]; found {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+func (h fiatRateHistory) value(amt *LedgerAmount, stamp uint64) (float64, bool) {
+	ui, err := asset.UnitInfo(amt.AssetID)
+	if err != nil {
+		return 0, false
+	}
+	r, ok := h.rate(amt.AssetID, stamp)
+	if !ok {
+		return 0, false
+	}
+	return float64(amt.Amount) / float64(ui.Conventional.ConversionFactor) * r, true
+}
+
+// costLot is a quantity of an asset acquired at a cost.
+type costLot struct {
+	qty  uint64
+	cost float64
+}
+
+// costBasisTracker tracks the lots of each asset for a cost basis method.
+type costBasisTracker struct {
+	method string
+	lots   map[uint32][]*costLot
+}
+
+func newCostBasisTracker(method string) *costBasisTracker {
+	return &costBasisTracker{
+		method: method,
+		lots:   make(map[uint32][]*costLot),
+	}
+}
+
+func (t *costBasisTracker) acquire(assetID uint32, qty uint64, cost float64) {
+	if qty == 0 {
+		return
+	}
+	lots := t.lots[assetID]
+	if t.method == CostBasisAverage && len(lots) > 0 {
+		lots[0].qty += qty
+		lots[0].cost += cost
+		return
+	}
+	t.lots[assetID] = append(lots, &costLot{qty: qty, cost: cost})
+}
+
+// dispose removes the quantity from the asset's lots and returns its cost.
+// Any quantity exceeding the tracked lots, e.g. from before the wallet's
+// history, has a zero cost basis.
+func (t *costBasisTracker) dispose(assetID uint32, qty uint64) (basis float64) {
+	lots := t.lots[assetID]
+	for qty > 0 && len(lots) > 0 {
+		idx := 0
+		if t.method == CostBasisLIFO {
+			idx = len(lots) - 1
+		}
+		lot := lots[idx]
+		if qty < lot.qty {
+			cost := lot.cost * float64(qty) / float64(lot.qty)
+			lot.qty -= qty
+			lot.cost -= cost
+			basis += cost
+			break
+		}
+		basis += lot.cost
+		qty -= lot.qty
+		if idx == 0 {
+			lots = lots[1:]
+		} else {
+			lots = lots[:idx]
+		}
+	}
+	t.lots[assetID] = lots
+	return basis
+}
+
+func (t *costBasisTracker) holdings(assetID uint32) (qty uint64, cost float64) {
+	for _, lot := range t.lots[assetID] {
+		qty += lot.qty
+		cost += lot.cost
+	}
+	return
+}
+
+// buildLedger values the events and computes the cost basis and gains. All
+// events up to end contribute to the cost basis, but only those in the
+// reporting period are included in the Ledger.
+//
+// Trades dispose of the sent asset for proceeds equal to the value of the
+// received asset, which is acquired at that value. Fees are disposed of at
+// their value. Sends and bridge transfers out remove lots without realizing a
+// gain, and the basis of bridged lots is carried to the receiving asset.
+// Receives are acquired at their value.
+func buildLedger(events []*LedgerEvent, snapshots []*db.FiatRateSnapshot, method string, start, end uint64) *Ledger {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Stamp < events[j].Stamp })
+	rates := fiatRateHistory(snapshots)
+	tracker := newCostBasisTracker(method)
+	gains := make(map[uint32]float64)
+	bridged := make(map[string]float64)
+	ledger := &Ledger{
+		Method:   method,
+		Currency: LedgerCurrency,
+		Start:    start,
+		End:      end,
+		Events:   make([]*LedgerEvent, 0),
+		Assets:   make([]*LedgerAssetSummary, 0),
+	}
+	reported := func(e *LedgerEvent) bool { return e.Stamp >= start }
+	for _, e := range events {
+		if e.Stamp > end {
+			break
+		}
+		for _, amt := range []*LedgerAmount{e.Sent, e.Received, e.Fee} {
+			if amt != nil {
+				var ok bool
+				amt.Value, ok = rates.value(amt, e.Stamp)
+				amt.Unvalued = !ok
+			}
+		}
+		e.Proceeds, e.CostBasis, e.Gain, e.Taxable = 0, 0, 0, false
+		switch e.Type {
+		case LedgerTrade, LedgerCEXTrade:
+			if e.Sent == nil || e.Received == nil {
+				break
+			}
+			e.Proceeds = e.Received.Value
+			if e.Received.Unvalued {
+				e.Proceeds = e.Sent.Value
+			}
+			e.CostBasis = tracker.dispose(e.Sent.AssetID, e.Sent.Amount)
+			e.Gain = e.Proceeds - e.CostBasis
+			e.Taxable = true
+			if reported(e) {
+				gains[e.Sent.AssetID] += e.Gain
+			}
+			tracker.acquire(e.Received.AssetID, e.Received.Amount, e.Proceeds)
+		default:
+			if e.Sent != nil {
+				basis := tracker.dispose(e.Sent.AssetID, e.Sent.Amount)
+				if e.Type == LedgerBridgeOut {
+					bridged[e.ID] = basis
+				}
+				e.CostBasis = basis
+			}
+			if e.Received != nil {
+				cost, found := bridged[e.LinkedID]
+				if e.Type != LedgerBridgeIn || !found {
+					cost = e.Received.Value
+				}
+				delete(bridged, e.LinkedID)
+				tracker.acquire(e.Received.AssetID, e.Received.Amount, cost)
+				e.CostBasis = cost
+			}
+		}
+		if e.Fee != nil {
+			basis := tracker.dispose(e.Fee.AssetID, e.Fee.Amount)
+			gain := e.Fee.Value - basis
+			e.Proceeds += e.Fee.Value
+			e.Gain += gain
+			e.Taxable = true
+			if e.Sent == nil && e.Received == nil {
+				e.CostBasis = basis
+			}
+			if reported(e) {
+				gains[e.Fee.AssetID] += gain
+			}
+		}
+		if reported(e) {
+			ledger.Events = append(ledger.Events, e)
+			ledger.RealizedGain += e.Gain
+		}
+	}
+
+	assetIDs := make(map[uint32]bool, len(gains)+len(tracker.lots))
+	for assetID := range gains {
+		assetIDs[assetID] = true
+	}
+	for assetID := range tracker.lots {
+		assetIDs[assetID] = true
+	}
+	for assetID := range assetIDs {
+		qty, cost := tracker.holdings(assetID)
+		ledger.Assets = append(ledger.Assets, &LedgerAssetSummary{
+			AssetID:      assetID,
+			Symbol:       unbip(assetID),
+			Holdings:     qty,
+			CostBasis:    cost,
+			RealizedGain: gains[assetID],
+		})
+	}
+	sort.Slice(ledger.Assets, func(i, j int) bool { return ledger.Assets[i].AssetID < ledger.Assets[j].AssetID })
+	return ledger
+}
+
+// Ledger CSV formats.
+const (
+	LedgerCSVGeneric     = "generic"
+	LedgerCSVKoinly      = "koinly"
+	LedgerCSVCoinTracker = "cointracker"
+)
+
+// WriteCSV writes the ledger events as CSV in one of the generic, koinly or
+// cointracker formats.
+func (l *Ledger) WriteCSV(w io.Writer, format string) error {
+	var header []string
+	var row func(e *LedgerEvent) []string
+	switch format {
+	case LedgerCSVGeneric, "":
+		header = []string{"Time", "Type", "Source", "ID", "Sent Amount", "Sent Currency",
+			"Received Amount", "Received Currency", "Fee Amount", "Fee Currency",
+			"Proceeds (" + l.Currency + ")", "Cost Basis (" + l.Currency + ")",
+			"Gain (" + l.Currency + ")", "Taxable", "Unvalued"}
+		row = func(e *LedgerEvent) []string {
+			r := []string{time.UnixMilli(int64(e.Stamp)).UTC().Format(time.RFC3339), string(e.Type), e.Source, e.ID}
+			r = append(r, csvAmount(e.Sent)...)
+			r = append(r, csvAmount(e.Received)...)
+			r = append(r, csvAmount(e.Fee)...)
+			return append(r, csvFiat(e.Proceeds), csvFiat(e.CostBasis), csvFiat(e.Gain),
+				strconv.FormatBool(e.Taxable), strconv.FormatBool(ledgerEventUnvalued(e)))
+		}
+	case LedgerCSVKoinly:
+		header = []string{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+			"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"}
+		row = func(e *LedgerEvent) []string {
+			r := []string{time.UnixMilli(int64(e.Stamp)).UTC().Format("2006-01-02 15:04:05 UTC")}
+			r = append(r, csvAmount(e.Sent)...)
+			r = append(r, csvAmount(e.Received)...)
+			r = append(r, csvAmount(e.Fee)...)
+			var label string
+			if e.Sent == nil && e.Received == nil {
+				label = "cost"
+			}
+			// Koinly prices rows with an empty net worth itself.
+			netWorth, currency := "", ""
+			if v, ok := ledgerEventValue(e); ok {
+				netWorth, currency = csvFiat(v), l.Currency
+			}
+			return append(r, netWorth, currency, label, string(e.Type)+" "+e.Source, e.ID)
+		}
+	case LedgerCSVCoinTracker:
+		header = []string{"Date", "Received Quantity", "Received Currency", "Sent Quantity",
+			"Sent Currency", "Fee Amount", "Fee Currency", "Tag"}
+		row = func(e *LedgerEvent) []string {
+			r := []string{time.UnixMilli(int64(e.Stamp)).UTC().Format("01/02/2006 15:04:05")}
+			r = append(r, csvAmount(e.Received)...)
+			r = append(r, csvAmount(e.Sent)...)
+			r = append(r, csvAmount(e.Fee)...)
+			return append(r, "")
+		}
+	default:
+		return fmt.Errorf("unknown CSV format %q", format)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, e := range l.Events {
+		// CoinTracker doesn't accept rows without a sent or received amount,
+		// so fee-only events are recorded as sends of the fee.
+		if format == LedgerCSVCoinTracker && e.Sent == nil && e.Received == nil {
+			e = &LedgerEvent{Stamp: e.Stamp, Sent: e.Fee}
+		}
+		if err := writer.Write(row(e)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ledgerEventValue is the fiat value of the assets exchanged in the event, and
+// whether that value is known.
+func ledgerEventValue(e *LedgerEvent) (float64, bool) {
+	switch {
+	case e.Received != nil && (!e.Received.Unvalued || e.Sent == nil):
+		return e.Received.Value, !e.Received.Unvalued
+	case e.Sent != nil:
+		return e.Sent.Value, !e.Sent.Unvalued
+	case e.Fee != nil:
+		return e.Fee.Value, !e.Fee.Unvalued
+	}
+	return 0, false
+}
+
+// ledgerEventUnvalued is true if any of the event's amounts is unvalued.
+func ledgerEventUnvalued(e *LedgerEvent) bool {
+	for _, amt := range []*LedgerAmount{e.Sent, e.Received, e.Fee} {
+		if amt != nil && amt.Unvalued {
+			return true
+		}
+	}
+	return false
+}
+
+func csvAmount(amt *LedgerAmount) []string {
+	if amt == nil {
+		return []string{"", ""}
+	}
+	ui, err := asset.UnitInfo(amt.AssetID)
+	if err != nil {
+		return []string{strconv.FormatUint(amt.Amount, 10), amt.Symbol}
+	}
+	return []string{ui.ConventionalString(amt.Amount), ui.Conventional.Unit}
+}
+
+func csvFiat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,204 @@
+//go:build !harness && !botlive
+
+package core
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+
+	"decred.org/dcrdex/client/db"
+)
+
+func TestBuildLedger(t *testing.T) {
+	a, b := tUTXOAssetA.ID, tUTXOAssetB.ID
+	snapshots := []*db.FiatRateSnapshot{
+		{Stamp: 1000, Rates: map[uint32]float64{a: 10, b: 100}},
+		{Stamp: 3000, Rates: map[uint32]float64{a: 20}},
+	}
+	newEvents := func() []*LedgerEvent {
+		return []*LedgerEvent{
+			// Out of order. The ledger sorts them.
+			{Stamp: 4000, Type: LedgerTrade, ID: "trade", Sent: ledgerAmount(a, 1.5e8), Received: ledgerAmount(b, 0.3e8)},
+			{Stamp: 1000, Type: LedgerReceive, ID: "recv1", Received: ledgerAmount(a, 2e8)},
+			{Stamp: 3000, Type: LedgerReceive, ID: "recv2", Received: ledgerAmount(a, 1e8)},
+			{Stamp: 5000, Type: LedgerFee, ID: "fee", Fee: ledgerAmount(b, 0.1e8)},
+			{Stamp: 6000, Type: LedgerBridgeOut, ID: "out", Sent: ledgerAmount(a, 0.5e8)},
+			{Stamp: 7000, Type: LedgerBridgeIn, ID: "in", LinkedID: "out", Received: ledgerAmount(b, 0.05e8)},
+		}
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	tests := []struct {
+		method    string
+		start     uint64
+		end       uint64
+		events    int
+		tradeGain float64
+		gain      float64
+		aHoldings uint64
+		aBasis    float64
+		bBasis    float64
+	}{
+		// Trade basis is 1.5 of the 2 @ $10 lot = $15. The $10 fee is 1/3 of
+		// the $30 B lot, for no gain. The bridged 0.5 A is the rest of the
+		// first lot.
+		{CostBasisFIFO, 0, 8000, 6, 15, 15, 1e8, 20, 25},
+		// Trade basis is the 1 @ $20 lot, and 0.5 of the 2 @ $10 lot = $25.
+		// The bridged 0.5 A comes from the first lot.
+		{CostBasisLIFO, 0, 8000, 6, 5, 5, 1e8, 10, 25},
+		// Average cost is 3 A @ $40 total.
+		{CostBasisAverage, 0, 8000, 6, 10, 10, 1e8, 40.0 / 3, 20 + 20.0/3},
+		// Earlier events still contribute to the basis.
+		{CostBasisFIFO, 3500, 8000, 4, 15, 15, 1e8, 20, 25},
+		// Later events are excluded.
+		{CostBasisFIFO, 0, 4500, 3, 15, 15, 1.5e8, 25, 30},
+	}
+	for _, tt := range tests {
+		ledger := buildLedger(newEvents(), snapshots, tt.method, tt.start, tt.end)
+		if len(ledger.Events) != tt.events {
+			t.Fatalf("%s %d-%d: expected %d events, got %d", tt.method, tt.start, tt.end, tt.events, len(ledger.Events))
+		}
+		for i := 1; i < len(ledger.Events); i++ {
+			if ledger.Events[i].Stamp < ledger.Events[i-1].Stamp {
+				t.Fatalf("%s: events not sorted", tt.method)
+			}
+		}
+		var trade *LedgerEvent
+		for _, e := range ledger.Events {
+			if e.ID == "trade" {
+				trade = e
+			}
+		}
+		if trade == nil || !trade.Taxable || !near(trade.Proceeds, 30) || !near(trade.Gain, tt.tradeGain) {
+			t.Fatalf("%s: wrong trade %+v", tt.method, trade)
+		}
+		if !near(ledger.RealizedGain, tt.gain) {
+			t.Fatalf("%s %d-%d: expected gain %f, got %f", tt.method, tt.start, tt.end, tt.gain, ledger.RealizedGain)
+		}
+		if len(ledger.Assets) != 2 {
+			t.Fatalf("%s: expected 2 asset summaries, got %d", tt.method, len(ledger.Assets))
+		}
+		for _, s := range ledger.Assets {
+			switch s.AssetID {
+			case a:
+				if s.Holdings != tt.aHoldings || !near(s.CostBasis, tt.aBasis) {
+					t.Fatalf("%s: wrong A summary %+v", tt.method, s)
+				}
+			case b:
+				if !near(s.CostBasis, tt.bBasis) {
+					t.Fatalf("%s: wrong B summary %+v", tt.method, s)
+				}
+			}
+		}
+	}
+
+	// Rates are taken from the last snapshot at or before the event, if it is
+	// no older than ledgerRateMaxAge.
+	rates := fiatRateHistory(snapshots)
+	maxAge := uint64(ledgerRateMaxAge.Milliseconds())
+	for _, tt := range []struct {
+		assetID uint32
+		stamp   uint64
+		exp     float64
+		ok      bool
+	}{
+		{a, 500, 0, false},
+		{a, 2999, 10, true},
+		{a, 3000, 20, true},
+		{b, 5000, 100, true},
+		{b, 1000 + maxAge, 100, true},
+		{b, 1001 + maxAge, 0, false},
+		{12345, 5000, 0, false},
+	} {
+		if r, ok := rates.rate(tt.assetID, tt.stamp); r != tt.exp || ok != tt.ok {
+			t.Fatalf("expected rate %f (%t) for asset %d at %d, got %f (%t)", tt.exp, tt.ok, tt.assetID, tt.stamp, r, ok)
+		}
+	}
+
+	// Events with no recent rate are unvalued.
+	ledger := buildLedger([]*LedgerEvent{
+		{Stamp: 500, Type: LedgerReceive, ID: "early", Received: ledgerAmount(a, 1e8)},
+		{Stamp: 2000, Type: LedgerReceive, ID: "valued", Received: ledgerAmount(a, 1e8)},
+	}, snapshots, CostBasisFIFO, 0, 8000)
+	if early := ledger.Events[0]; !early.Received.Unvalued || early.Received.Value != 0 || !ledgerEventUnvalued(early) {
+		t.Fatalf("early event not unvalued %+v", early.Received)
+	}
+	if valued := ledger.Events[1]; valued.Received.Unvalued || valued.Received.Value != 10 {
+		t.Fatalf("wrong valued event %+v", valued.Received)
+	}
+}
+
+func TestLedgerCSV(t *testing.T) {
+	a, b := tUTXOAssetA.ID, tUTXOAssetB.ID
+	events := []*LedgerEvent{
+		{Stamp: 1000, Type: LedgerReceive, ID: "recv", Received: ledgerAmount(a, 2e8)},
+		{Stamp: 2000, Type: LedgerTrade, ID: "trade", Sent: ledgerAmount(a, 1e8), Received: ledgerAmount(b, 1e7)},
+		{Stamp: 3000, Type: LedgerFee, ID: "fee", Fee: ledgerAmount(b, 1e5)},
+	}
+	snapshots := []*db.FiatRateSnapshot{{Stamp: 1000, Rates: map[uint32]float64{a: 10, b: 100}}}
+	ledger := buildLedger(events, snapshots, CostBasisFIFO, 0, 4000)
+
+	for format, cols := range map[string]int{
+		LedgerCSVGeneric:     15,
+		LedgerCSVKoinly:      12,
+		LedgerCSVCoinTracker: 8,
+	} {
+		var buf bytes.Buffer
+		if err := ledger.WriteCSV(&buf, format); err != nil {
+			t.Fatalf("%s: WriteCSV error: %v", format, err)
+		}
+		rows, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: error reading CSV: %v", format, err)
+		}
+		if len(rows) != len(events)+1 {
+			t.Fatalf("%s: expected %d rows, got %d", format, len(events)+1, len(rows))
+		}
+		if len(rows[0]) != cols {
+			t.Fatalf("%s: expected %d columns, got %d", format, cols, len(rows[0]))
+		}
+		if format == LedgerCSVGeneric && (rows[2][4] != "1.00000000" || rows[2][10] != "10.00" || rows[2][12] != "0.00") {
+			t.Fatalf("wrong generic trade row %v", rows[2])
+		}
+	}
+
+	if err := ledger.WriteCSV(&bytes.Buffer{}, "turbotax"); err == nil {
+		t.Fatalf("no error for unknown format")
+	}
+}
+
+func TestLedger(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+
+	if _, err := tCore.Ledger(&LedgerForm{Method: "hifo"}); err == nil {
+		t.Fatalf("no error for unknown cost basis method")
+	}
+	if _, err := tCore.Ledger(&LedgerForm{Start: 2000, End: 1000}); err == nil {
+		t.Fatalf("no error for start after end")
+	}
+
+	rig.db.StoreFiatRates(&db.FiatRateSnapshot{Stamp: 1000, Rates: map[uint32]float64{tUTXOAssetA.ID: 10}})
+	ledger, err := tCore.Ledger(&LedgerForm{
+		ExtraEvents: []*LedgerEvent{{
+			Stamp:    2000,
+			Type:     LedgerCEXTrade,
+			Source:   "Binance",
+			ID:       "cex",
+			Sent:     ledgerAmount(tUTXOAssetB.ID, 1e6),
+			Received: ledgerAmount(tUTXOAssetA.ID, 1e8),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Ledger error: %v", err)
+	}
+	if ledger.Method != CostBasisFIFO || len(ledger.Events) != 1 {
+		t.Fatalf("wrong ledger %+v", ledger)
+	}
+	if e := ledger.Events[0]; e.Received.Value != 10 || e.Gain != 10 {
+		t.Fatalf("wrong CEX trade valuation %+v", e)
+	}
+}
@@ -81,6 +81,30 @@ var originLocale = map[Topic]*translation{
 		subject:  intl.Translation{T: "Trade limit exceeded"},
 		template: intl.Translation{T: "Order quantity exceeds current trade limit on %s", Notes: "args: [host]"},
 	},
+	TopicConditionalOrderTriggered: {
+		subject:  intl.Translation{T: "Conditional order triggered"},
+		template: intl.Translation{T: "The %s order %s on the %s market at %s was triggered. Order %s submitted.", Notes: "args: [kind, conditional order ID, market, host, order ID]"},
+	},
+	TopicConditionalOrderFailed: {
+		subject:  intl.Translation{T: "Conditional order failed"},
+		template: intl.Translation{T: "The %s order %s on the %s market at %s was triggered, but the order could not be placed: %v", Notes: "args: [kind, conditional order ID, market, host, error]"},
+	},
+	TopicPriceAlert: {
+		subject:  intl.Translation{T: "Price alert"},
+		template: intl.Translation{T: "The %s mid-gap rate at %s reached %s (alert rate %s)", Notes: "args: [market, host, rate, alert rate]"},
+	},
+	TopicSpreadAlert: {
+		subject:  intl.Translation{T: "Spread alert"},
+		template: intl.Translation{T: "The %s spread at %s narrowed to %.2f%% (alert spread %.2f%%)", Notes: "args: [market, host, spread percent, alert spread percent]"},
+	},
+	TopicVolumeAlert: {
+		subject:  intl.Translation{T: "Volume alert"},
+		template: intl.Translation{T: "The %s 24-hour volume at %s rose %.2f%% to %s (trailing average %s)", Notes: "args: [market, host, percent change, volume, trailing average volume]"},
+	},
+	TopicFiatRateAlert: {
+		subject:  intl.Translation{T: "Fiat rate alert"},
+		template: intl.Translation{T: "The %s fiat rate moved %+.2f%% to %.2f USD", Notes: "args: [asset symbol, percent change, fiat rate]"},
+	},
 	TopicOrderLoadFailure: {
 		subject:  intl.Translation{T: "Order load failure"},
 		template: intl.Translation{T: "Some orders failed to load from the database: %v", Notes: "args: [error]"},
@@ -0,0 +1,779 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/comms"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/keygen"
+	"decred.org/dcrdex/dex/order"
+	"decred.org/dcrdex/tatanka/client/mesh"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// MeshHost is the Exchange host of the Tatanka Mesh. The mesh is a trading
+// venue like a DEX server, but orders are matched and swapped between peers.
+const MeshHost = "tatanka-mesh"
+
+// meshMaxFeeRateMult is the multiplier applied to the current fee rate to set
+// the max fee rate of mesh orders. Orders are unbooked if the mesh oracle fee
+// rate rises above their max fee rate.
+const meshMaxFeeRateMult = 2
+
+// MeshConfig is the configuration for trading on the Tatanka Mesh.
+type MeshConfig struct {
+	// EntryNode is the tatanka node used to discover the rest of the mesh.
+	EntryNode *mesh.TatankaCredentials
+	// Markets are the mesh markets to subscribe to.
+	Markets []*MeshMarket
+}
+
+// MeshMarket is a mesh market. The mesh does not prescribe a lot size, so the
+// user chooses the lot size for their orders. Orders on the book with a
+// different lot size may still match.
+type MeshMarket struct {
+	BaseID  uint32 `json:"baseID"`
+	QuoteID uint32 `json:"quoteID"`
+	// LotSize must be a power of 2.
+	LotSize uint64 `json:"lotSize"`
+}
+
+// MeshBondForm is information necessary to post a bond to the mesh.
+type MeshBondForm struct {
+	AssetID uint32 `json:"assetID"`
+	Amount  uint64 `json:"amount"`
+	// Strength is the bond strength claimed. The tatanka nodes check that
+	// Amount is enough for the Strength.
+	Strength uint64    `json:"strength"`
+	LockTime time.Time `json:"lockTime"`
+}
+
+// meshClient is satisfied by *mesh.Mesh.
+type meshClient interface {
+	dex.Connector
+	ID() tanka.PeerID
+	SubscribeToFiatRates() error
+	SubscribeToFeeRateEstimates() error
+	SubscribeMarket(baseID, quoteID uint32) error
+	PostBond(bond *tanka.Bond) error
+	ActiveBonds() ([]*tanka.Bond, error)
+	PlaceOrder(ord *tanka.Order) error
+	CancelOrder(baseID, quoteID uint32, oid tanka.ID40) error
+	Book(baseID, quoteID uint32) (buys, sells []*tanka.Order, err error)
+	Orders(baseID, quoteID uint32) ([]*mesh.OrderStatus, error)
+	FiatRate(assetID uint32) float64
+	FeeRateEstimate(chainID uint32) uint64
+}
+
+var _ meshClient = (*mesh.Mesh)(nil)
+
+// meshVenue is a connection to the Tatanka Mesh.
+type meshVenue struct {
+	cfg    *MeshConfig
+	client meshClient
+	cm     *dex.ConnectionMaster
+	peerID tanka.PeerID
+	// nonce is the nonce of our last order. Together with our peer ID, the
+	// nonce is the order ID.
+	nonce atomic.Uint64
+}
+
+// market finds the configured market.
+func (v *meshVenue) market(baseID, quoteID uint32) *MeshMarket {
+	for _, mkt := range v.cfg.Markets {
+		if mkt.BaseID == baseID && mkt.QuoteID == quoteID {
+			return mkt
+		}
+	}
+	return nil
+}
+
+// committed is the quantity of the asset that our booked mesh orders have yet
+// to swap.
+func (v *meshVenue) committed(assetID uint32) uint64 {
+	var committed uint64
+	for _, mkt := range v.cfg.Markets {
+		if mkt.BaseID != assetID && mkt.QuoteID != assetID {
+			continue
+		}
+		ords, err := v.client.Orders(mkt.BaseID, mkt.QuoteID)
+		if err != nil {
+			continue
+		}
+		for _, ord := range ords {
+			if !ord.Booked {
+				continue
+			}
+			switch {
+			case ord.Sell && ord.BaseID == assetID:
+				committed += ord.Remaining
+			case !ord.Sell && ord.QuoteID == assetID:
+				committed += calc.BaseToQuote(ord.Rate, ord.Remaining)
+			}
+		}
+	}
+	return committed
+}
+
+// deriveMeshKey derives our peer identity key on the mesh from the app seed.
+func deriveMeshKey(seed []byte) (*secp256k1.PrivateKey, error) {
+	extKey, err := keygen.GenDeepChild(seed, []uint32{hdKeyPurposeMesh})
+	if err != nil {
+		return nil, err
+	}
+	privB, err := extKey.SerializedPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	return secp256k1.PrivKeyFromBytes(privB), nil
+}
+
+// newMeshClient constructs a *mesh.Mesh.
+func newMeshClient(cfg *mesh.Config) (meshClient, error) {
+	return mesh.New(cfg)
+}
+
+// connectMesh connects to the Tatanka Mesh and subscribes to the configured
+// markets and the mesh oracles.
+func (c *Core) connectMesh(priv *secp256k1.PrivateKey) error {
+	c.meshMtx.Lock()
+	defer c.meshMtx.Unlock()
+	if c.mesh != nil {
+		return nil
+	}
+	cfg := c.cfg.Mesh
+	for _, mkt := range cfg.Markets {
+		if mkt.LotSize == 0 || mkt.LotSize&(mkt.LotSize-1) != 0 {
+			return fmt.Errorf("mesh market %s lot size %d is not a power of 2", marketName(mkt.BaseID, mkt.QuoteID), mkt.LotSize)
+		}
+	}
+	client, err := c.newMeshClient(&mesh.Config{
+		DataDir:    filepath.Join(filepath.Dir(c.cfg.DBPath), "mesh"),
+		PrivateKey: priv,
+		Logger:     c.log.SubLogger("MESH"),
+		EntryNode:  cfg.EntryNode,
+		Net:        c.net,
+		Wallets: func(assetID uint32) (asset.Wallet, error) {
+			w, err := c.connectedWallet(assetID)
+			if err != nil {
+				return nil, err
+			}
+			if !w.unlocked() {
+				return nil, fmt.Errorf("%s wallet is locked", unbip(assetID))
+			}
+			return w.Wallet, nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating mesh client: %w", err)
+	}
+	cm := dex.NewConnectionMaster(client)
+	if err := cm.ConnectOnce(c.ctx); err != nil {
+		return fmt.Errorf("error connecting to mesh: %w", err)
+	}
+	if err := client.SubscribeToFiatRates(); err != nil {
+		c.log.Errorf("Error subscribing to mesh fiat rates: %v", err)
+	}
+	if err := client.SubscribeToFeeRateEstimates(); err != nil {
+		c.log.Errorf("Error subscribing to mesh fee rates: %v", err)
+	}
+	for _, mkt := range cfg.Markets {
+		if err := client.SubscribeMarket(mkt.BaseID, mkt.QuoteID); err != nil {
+			c.log.Errorf("Error subscribing to mesh market %s: %v", marketName(mkt.BaseID, mkt.QuoteID), err)
+		}
+	}
+	v := &meshVenue{
+		cfg:    cfg,
+		client: client,
+		cm:     cm,
+		peerID: client.ID(),
+	}
+	v.nonce.Store(uint64(time.Now().UnixNano()))
+	c.mesh = v
+	c.log.Infof("Connected to the Tatanka Mesh as %s", v.peerID)
+	return nil
+}
+
+// disconnectMesh disconnects from the Tatanka Mesh.
+func (c *Core) disconnectMesh() {
+	c.meshMtx.Lock()
+	defer c.meshMtx.Unlock()
+	if c.mesh == nil {
+		return
+	}
+	c.mesh.cm.Disconnect()
+	c.mesh = nil
+}
+
+// meshVenue is the connected mesh venue, or nil.
+func (c *Core) meshVenue() *meshVenue {
+	c.meshMtx.RLock()
+	defer c.meshMtx.RUnlock()
+	return c.mesh
+}
+
+// connectedMesh is the connected mesh venue, or an error if not connected.
+func (c *Core) connectedMesh() (*meshVenue, error) {
+	v := c.meshVenue()
+	if v == nil {
+		return nil, errors.New("not connected to the Tatanka Mesh")
+	}
+	return v, nil
+}
+
+// meshActive checks whether we have booked orders on the mesh.
+func (c *Core) meshActive() bool {
+	v := c.meshVenue()
+	if v == nil {
+		return false
+	}
+	for _, mkt := range v.cfg.Markets {
+		ords, err := v.client.Orders(mkt.BaseID, mkt.QuoteID)
+		if err != nil {
+			continue
+		}
+		for _, ord := range ords {
+			if ord.Booked {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// meshExchange is the mesh as an *Exchange.
+func (c *Core) meshExchange(v *meshVenue) *Exchange {
+	assets := make(map[uint32]*dex.Asset)
+	addAsset := func(assetID uint32) bool {
+		if _, found := assets[assetID]; found {
+			return true
+		}
+		ui, err := asset.UnitInfo(assetID)
+		if err != nil {
+			return false
+		}
+		assets[assetID] = &dex.Asset{
+			ID:       assetID,
+			Symbol:   unbip(assetID),
+			UnitInfo: ui,
+		}
+		return true
+	}
+	mkts := make(map[string]*Market, len(v.cfg.Markets))
+	for _, mkt := range v.cfg.Markets {
+		if !addAsset(mkt.BaseID) || !addAsset(mkt.QuoteID) {
+			continue
+		}
+		if m := c.meshMarket(v, mkt, assets[mkt.BaseID], assets[mkt.QuoteID]); m != nil {
+			mkts[m.Name] = m
+		}
+	}
+	var bondAssetID uint32
+	var liveStrength int64
+	if bonds, err := v.client.ActiveBonds(); err != nil {
+		c.log.Errorf("Error retrieving mesh bonds: %v", err)
+	} else {
+		for _, b := range bonds {
+			if b.Expiration.Before(time.Now()) {
+				continue
+			}
+			bondAssetID = b.AssetID
+			liveStrength += int64(b.Strength)
+		}
+	}
+	return &Exchange{
+		Host:             MeshHost,
+		AcctID:           v.peerID.String(),
+		Markets:          mkts,
+		Assets:           assets,
+		BondAssets:       make(map[string]*BondAsset),
+		ConnectionStatus: v.connectionStatus(),
+		Auth: ExchangeAuth{
+			BondAssetID:   bondAssetID,
+			LiveStrength:  liveStrength,
+			EffectiveTier: liveStrength,
+		},
+	}
+}
+
+// meshMarket is the mesh market as a *Market, including our orders.
+func (c *Core) meshMarket(v *meshVenue, mkt *MeshMarket, base, quote *dex.Asset) *Market {
+	bconv, qconv := base.UnitInfo.Conventional.ConversionFactor, quote.UnitInfo.Conventional.ConversionFactor
+	m := &Market{
+		Name:        marketName(mkt.BaseID, mkt.QuoteID),
+		BaseID:      mkt.BaseID,
+		BaseSymbol:  base.Symbol,
+		QuoteID:     mkt.QuoteID,
+		QuoteSymbol: quote.Symbol,
+		LotSize:     mkt.LotSize,
+		ParcelSize:  1,
+		RateStep:    1,
+		EpochLen:    uint64(tanka.EpochLength.Milliseconds()),
+		AtomToConv:  float64(bconv) / float64(qconv),
+	}
+	ords, err := v.client.Orders(mkt.BaseID, mkt.QuoteID)
+	if err != nil {
+		c.log.Errorf("Error retrieving mesh orders for %s: %v", m.Name, err)
+		return m
+	}
+	for _, ord := range ords {
+		m.Orders = append(m.Orders, coreOrderFromMesh(ord))
+	}
+	sort.Slice(m.Orders, func(i, j int) bool { return m.Orders[i].Stamp > m.Orders[j].Stamp })
+	return m
+}
+
+// connectionStatus is the status of the connection to the mesh.
+func (v *meshVenue) connectionStatus() comms.ConnectionStatus {
+	if v.cm.On() {
+		return comms.Connected
+	}
+	return comms.Disconnected
+}
+
+// coreOrderFromMesh converts one of our mesh orders to an *Order.
+func coreOrderFromMesh(ord *mesh.OrderStatus) *Order {
+	oid := ord.ID()
+	status := order.OrderStatusBooked
+	switch {
+	case ord.Remaining == 0:
+		status = order.OrderStatusExecuted
+	case !ord.Booked:
+		status = order.OrderStatusCanceled
+	}
+	return &Order{
+		Host:        MeshHost,
+		BaseID:      ord.BaseID,
+		BaseSymbol:  unbip(ord.BaseID),
+		QuoteID:     ord.QuoteID,
+		QuoteSymbol: unbip(ord.QuoteID),
+		MarketID:    marketName(ord.BaseID, ord.QuoteID),
+		Type:        order.LimitOrderType,
+		ID:          oid[:],
+		Stamp:       uint64(ord.Stamp.UnixMilli()),
+		SubmitTime:  uint64(ord.Stamp.UnixMilli()),
+		Status:      status,
+		Qty:         ord.Qty,
+		Sell:        ord.Sell,
+		Filled:      ord.Qty - ord.Remaining,
+		Rate:        ord.Rate,
+		TimeInForce: order.StandingTiF,
+	}
+}
+
+// meshBook is the mesh order book for a market.
+func (c *Core) meshBook(base, quote uint32) (*OrderBook, error) {
+	v, err := c.connectedMesh()
+	if err != nil {
+		return nil, err
+	}
+	baseUnits, err := asset.UnitInfo(base)
+	if err != nil {
+		return nil, err
+	}
+	quoteUnits, err := asset.UnitInfo(quote)
+	if err != nil {
+		return nil, err
+	}
+	buys, sells, err := v.client.Book(base, quote)
+	if err != nil {
+		return nil, err
+	}
+	translate := func(ords []*tanka.Order) []*MiniOrder {
+		outs := make([]*MiniOrder, 0, len(ords))
+		for _, o := range ords {
+			oid := o.ID()
+			outs = append(outs, &MiniOrder{
+				Qty:       float64(o.Qty) / float64(baseUnits.Conventional.ConversionFactor),
+				QtyAtomic: o.Qty,
+				Rate:      calc.ConventionalRate(o.Rate, baseUnits, quoteUnits),
+				MsgRate:   o.Rate,
+				Sell:      o.Sell,
+				Token:     token(oid[:]),
+			})
+		}
+		return outs
+	}
+	return &OrderBook{
+		Buys:  translate(buys),
+		Sells: translate(sells),
+	}, nil
+}
+
+// meshFeeAssetID is the asset whose chain's fee rate applies to swaps of the
+// asset.
+func meshFeeAssetID(assetID uint32) uint32 {
+	if tkn := asset.TokenInfo(assetID); tkn != nil {
+		return tkn.ParentID
+	}
+	return assetID
+}
+
+// meshFeeRate is the mesh oracle fee rate for the asset's chain, or zero if
+// not connected to the mesh or the rate is unknown.
+func (c *Core) meshFeeRate(assetID uint32) uint64 {
+	v := c.meshVenue()
+	if v == nil {
+		return 0
+	}
+	return v.client.FeeRateEstimate(meshFeeAssetID(assetID))
+}
+
+// meshFiatRate is the mesh oracle fiat rate for the asset, or zero if not
+// connected to the mesh or the rate is unknown.
+func (c *Core) meshFiatRate(assetID uint32) float64 {
+	v := c.meshVenue()
+	if v == nil {
+		return 0
+	}
+	return v.client.FiatRate(assetID)
+}
+
+// tradeMesh places a standing limit order on the mesh. Matches are negotiated
+// with peers by the mesh client.
+func (c *Core) tradeMesh(pw []byte, form *TradeForm) (*Order, error) {
+	v, err := c.connectedMesh()
+	if err != nil {
+		return nil, err
+	}
+	if !form.IsLimit || form.TifNow {
+		return nil, errors.New("only standing limit orders are supported on the mesh")
+	}
+	mkt := v.market(form.Base, form.Quote)
+	if mkt == nil {
+		return nil, fmt.Errorf("unknown mesh market %s", marketName(form.Base, form.Quote))
+	}
+	if form.Qty == 0 || form.Qty%mkt.LotSize != 0 {
+		return nil, newError(orderParamsErr, "quantity %d is not a multiple of the lot size %d", form.Qty, mkt.LotSize)
+	}
+	if form.Rate == 0 {
+		return nil, newError(orderParamsErr, "zero rate")
+	}
+
+	crypter, err := c.encryptionKey(pw)
+	if err != nil {
+		return nil, codedError(passwordErr, err)
+	}
+	defer crypter.Close()
+	for _, assetID := range []uint32{form.Base, form.Quote} {
+		w, err := c.connectedWallet(assetID)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.connectAndUnlock(crypter, w); err != nil {
+			return nil, err
+		}
+	}
+
+	fromID := form.Quote
+	if form.Sell {
+		fromID = form.Base
+	}
+	feeRate := c.meshFeeRate(fromID)
+	if feeRate == 0 {
+		feeRate = c.feeSuggestionAny(meshFeeAssetID(fromID))
+	}
+	if feeRate == 0 {
+		return nil, fmt.Errorf("no fee rate for %s", unbip(fromID))
+	}
+	if err := c.checkMeshFunding(v, form, mkt.LotSize, fromID, feeRate*meshMaxFeeRateMult); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ord := &tanka.Order{
+		From:       v.peerID,
+		BaseID:     form.Base,
+		QuoteID:    form.Quote,
+		Sell:       form.Sell,
+		Qty:        form.Qty,
+		Rate:       form.Rate,
+		LotSize:    mkt.LotSize,
+		Nonce:      v.nonce.Add(1),
+		Stamp:      now,
+		MaxFeeRate: feeRate * meshMaxFeeRateMult,
+	}
+	if err := v.client.PlaceOrder(ord); err != nil {
+		return nil, fmt.Errorf("error placing mesh order: %w", err)
+	}
+	return coreOrderFromMesh(&mesh.OrderStatus{Order: ord, Remaining: ord.Qty, Booked: true}), nil
+}
+
+// checkMeshFunding checks that the available balance of the from wallet
+// covers the order and its swap fees, in addition to what our other booked
+// mesh orders have yet to swap. Mesh matches are funded one at a time as they
+// are negotiated, so nothing is locked when the order is placed. For tokens,
+// the fees are checked against the parent wallet.
+func (c *Core) checkMeshFunding(v *meshVenue, form *TradeForm, lotSize uint64, fromID uint32, maxFeeRate uint64) error {
+	w, err := c.connectedWallet(fromID)
+	if err != nil {
+		return err
+	}
+	qty := form.Qty
+	if !form.Sell {
+		qty = calc.BaseToQuote(form.Rate, form.Qty)
+	}
+	// Every lot may be matched and swapped separately.
+	swapFees, _, err := w.SingleLotSwapRefundFees(meshAssetVersion(w.Wallet), maxFeeRate, false)
+	if err != nil {
+		return fmt.Errorf("error calculating swap fees: %w", err)
+	}
+	fees := swapFees * (form.Qty / lotSize)
+
+	bal, err := w.Balance()
+	if err != nil {
+		return newError(walletErr, "error getting %s balance: %w", unbip(fromID), err)
+	}
+	committed := v.committed(fromID)
+	req := qty + committed
+	if asset.TokenInfo(fromID) == nil {
+		req += fees
+	} else {
+		parentID := meshFeeAssetID(fromID)
+		pw, err := c.connectedWallet(parentID)
+		if err != nil {
+			return err
+		}
+		pbal, err := pw.Balance()
+		if err != nil {
+			return newError(walletErr, "error getting %s balance: %w", unbip(parentID), err)
+		}
+		if pbal.Available < fees {
+			return newError(walletBalanceErr, "insufficient %s balance for swap fees: %s available, %s required",
+				unbip(parentID), pw.amtString(pbal.Available), pw.amtString(fees))
+		}
+	}
+	if bal.Available < req {
+		return newError(walletBalanceErr, "insufficient %s balance: %s available, %s required (%s committed to booked mesh orders)",
+			unbip(fromID), w.amtString(bal.Available), w.amtString(req), w.amtString(committed))
+	}
+	return nil
+}
+
+// meshAssetVersion is the asset version used for mesh swaps, the newest
+// version supported by the wallet.
+func meshAssetVersion(w asset.Wallet) uint32 {
+	var v uint32
+	for _, sv := range w.Info().SupportedVersions {
+		if sv > v {
+			v = sv
+		}
+	}
+	return v
+}
+
+// cancelMeshOrder unbooks one of our standing mesh orders.
+func (c *Core) cancelMeshOrder(oid tanka.ID40) error {
+	v, err := c.connectedMesh()
+	if err != nil {
+		return err
+	}
+	for _, mkt := range v.cfg.Markets {
+		ords, err := v.client.Orders(mkt.BaseID, mkt.QuoteID)
+		if err != nil {
+			continue
+		}
+		for _, ord := range ords {
+			if ord.ID() != oid {
+				continue
+			}
+			if !ord.Booked {
+				return newError(orderParamsErr, "mesh order %s is not booked", oid)
+			}
+			if err := v.client.CancelOrder(mkt.BaseID, mkt.QuoteID, oid); err != nil {
+				return fmt.Errorf("error canceling mesh order: %w", err)
+			}
+			return nil
+		}
+	}
+	return newError(unknownOrderErr, "unknown mesh order %s", oid)
+}
+
+// PostMeshBond creates a bond transaction and posts the bond to the Tatanka
+// Mesh. The bond is locked to a key derived from the app seed and is stored,
+// so that it is refunded by the bond rotation loop once the lock time passes.
+func (c *Core) PostMeshBond(pw []byte, form *MeshBondForm) (*tanka.Bond, error) {
+	v, err := c.connectedMesh()
+	if err != nil {
+		return nil, err
+	}
+	if form.Amount == 0 || form.Strength == 0 {
+		return nil, newError(bondAmtErr, "zero bond amount or strength")
+	}
+	if lockDur := time.Until(form.LockTime); lockDur <= 0 || lockDur > lockTimeLimit {
+		return nil, newError(bondTimeErr, "invalid lock time %s", form.LockTime)
+	}
+
+	crypter, err := c.encryptionKey(pw)
+	if err != nil {
+		return nil, codedError(passwordErr, err)
+	}
+	defer crypter.Close()
+	wallet, err := c.connectedWallet(form.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.connectAndUnlock(crypter, wallet); err != nil {
+		return nil, err
+	}
+	bonder, is := wallet.Wallet.(asset.Bonder)
+	if !is {
+		return nil, fmt.Errorf("%s wallet does not support bonds", unbip(form.AssetID))
+	}
+
+	feeRate := c.meshFeeRate(form.AssetID)
+	if feeRate == 0 {
+		feeRate = c.feeSuggestionAny(meshFeeAssetID(form.AssetID))
+	}
+	bondKey, keyIndex, err := c.nextBondKey(form.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("bond key derivation failed: %v", err)
+	}
+	defer bondKey.Zero()
+
+	bond, abandon, err := bonder.MakeBondTx(0, form.Amount, feeRate, form.LockTime, bondKey, v.peerID[:])
+	if err != nil {
+		return nil, codedError(bondPostErr, err)
+	}
+	// Store the bond before broadcasting it, so that it will be refunded even
+	// if posting to the mesh fails.
+	dbBond := &db.Bond{
+		Version:    bond.Version,
+		AssetID:    form.AssetID,
+		CoinID:     bond.CoinID,
+		UnsignedTx: bond.UnsignedTx,
+		SignedTx:   bond.SignedTx,
+		Data:       bond.Data,
+		Amount:     form.Amount,
+		LockTime:   uint64(form.LockTime.Unix()),
+		KeyIndex:   keyIndex,
+		RefundTx:   bond.RedeemTx,
+		Strength:   uint32(form.Strength),
+	}
+	if err := c.db.UpdateMeshBond(dbBond); err != nil {
+		abandon()
+		return nil, fmt.Errorf("error storing mesh bond: %w", err)
+	}
+	c.log.Infof("Broadcasting mesh bond %s with lock time %v.\n\nBACKUP refund tx paying to current wallet: %x\n\n",
+		coinIDString(form.AssetID, bond.CoinID), form.LockTime, bond.RedeemTx)
+	if _, err := wallet.SendTransaction(bond.SignedTx); err != nil {
+		abandon()
+		// Nothing to refund.
+		dbBond.Refunded = true
+		if err := c.db.UpdateMeshBond(dbBond); err != nil {
+			c.log.Errorf("Failed to mark unbroadcast mesh bond as refunded: %v", err)
+		}
+		return nil, fmt.Errorf("error broadcasting bond transaction: %w", err)
+	}
+	c.updateAssetBalance(form.AssetID)
+
+	tb := &tanka.Bond{
+		PeerID:     v.peerID,
+		AssetID:    form.AssetID,
+		CoinID:     bond.CoinID,
+		Strength:   form.Strength,
+		Expiration: form.LockTime,
+		Maturation: time.Now(),
+	}
+	if err := v.client.PostBond(tb); err != nil {
+		return nil, fmt.Errorf("error posting bond to the mesh: %w", err)
+	}
+	return tb, nil
+}
+
+// MeshBonds are our active bonds on the Tatanka Mesh.
+func (c *Core) MeshBonds() ([]*tanka.Bond, error) {
+	v, err := c.connectedMesh()
+	if err != nil {
+		return nil, err
+	}
+	return v.client.ActiveBonds()
+}
+
+// refundMeshBonds refunds our mesh bonds with passed lock times. Mesh bonds are
+// refunded the same way as DEX bonds, but are not tied to a DEX account.
+func (c *Core) refundMeshBonds(ctx context.Context, now int64) {
+	bonds, err := c.db.MeshBonds()
+	if err != nil {
+		c.log.Errorf("Error retrieving mesh bonds: %v", err)
+		return
+	}
+	for _, bond := range bonds {
+		if bond.Refunded || now < int64(bond.LockTime) {
+			continue
+		}
+		bondIDStr := fmt.Sprintf("%v (%s)", coinIDString(bond.AssetID, bond.CoinID), unbip(bond.AssetID))
+		wallet, err := c.connectedWallet(bond.AssetID)
+		if err != nil {
+			c.log.Errorf("%v wallet not available to refund mesh bond %v: %v", unbip(bond.AssetID), bondIDStr, err)
+			continue
+		}
+		expired, err := wallet.LockTimeExpired(ctx, time.Unix(int64(bond.LockTime), 0))
+		if err != nil {
+			c.log.Errorf("Unable to check if mesh bond %v has expired: %v", bondIDStr, err)
+			continue
+		}
+		if !expired {
+			continue
+		}
+		if _, err := wallet.refreshUnlock(); err != nil {
+			c.log.Errorf("Failed to unlock bond asset wallet %v: %v", unbip(bond.AssetID), err)
+			continue
+		}
+
+		var refundCoinStr string
+		var refundVal uint64
+		priv, err := c.bondKeyIdx(bond.AssetID, bond.KeyIndex)
+		if err != nil {
+			c.log.Errorf("Failed to derive bond private key: %v", err)
+			continue
+		}
+		refundCoin, err := wallet.RefundBond(ctx, bond.Version, bond.CoinID, bond.Data, bond.Amount, priv)
+		priv.Zero()
+		bondAlreadySpent := errors.Is(err, asset.CoinNotFoundError) // or never mined!
+		if err != nil {
+			if errors.Is(err, asset.ErrIncorrectBondKey) {
+				c.log.Warnf("Private key to spend mesh bond %v is not available. Broadcasting backup refund tx.", bondIDStr)
+				refundCoinID, err := wallet.SendTransaction(bond.RefundTx)
+				if err != nil {
+					c.log.Errorf("Failed to broadcast mesh bond refund txn %x: %v", bond.RefundTx, err)
+					continue
+				}
+				refundCoinStr, _ = asset.DecodeCoinID(bond.AssetID, refundCoinID)
+			} else if !bondAlreadySpent {
+				c.log.Errorf("Failed to generate mesh bond refund tx: %v", err)
+				continue
+			}
+		} else {
+			refundCoinStr, refundVal = refundCoin.String(), refundCoin.Value()
+		}
+
+		if bondAlreadySpent {
+			c.log.Warnf("Mesh bond output not found, possibly already spent or never mined! "+
+				"Marking refunded. Backup refund transaction: %x", bond.RefundTx)
+		} else {
+			subject, details := c.formatDetails(TopicBondRefunded, makeCoinIDToken(bond.CoinID.String(), bond.AssetID), MeshHost,
+				makeCoinIDToken(refundCoinStr, bond.AssetID), wallet.amtString(refundVal), wallet.amtString(bond.Amount))
+			c.notify(newBondRefundNote(TopicBondRefunded, subject, details, db.Success))
+		}
+
+		bond.Refunded = true
+		if err := c.db.UpdateMeshBond(bond); err != nil { // next time we'll retry, hit bondAlreadySpent, and store here again
+			c.log.Errorf("Failed to mark mesh bond as refunded: %v", err)
+		}
+		c.updateAssetBalance(bond.AssetID)
+	}
+}
@@ -0,0 +1,246 @@
+//go:build !harness && !botlive
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/order"
+	"decred.org/dcrdex/tatanka/client/mesh"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+type tMeshClient struct {
+	peerID      tanka.PeerID
+	subs        [][2]uint32
+	bonds       []*tanka.Bond
+	placed      []*tanka.Order
+	canceled    []tanka.ID40
+	buys, sells []*tanka.Order
+	ords        []*mesh.OrderStatus
+	fiatRates   map[uint32]float64
+	feeRates    map[uint32]uint64
+}
+
+func (m *tMeshClient) Connect(ctx context.Context) (*sync.WaitGroup, error) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		<-ctx.Done()
+		wg.Done()
+	}()
+	return &wg, nil
+}
+func (m *tMeshClient) ID() tanka.PeerID                   { return m.peerID }
+func (m *tMeshClient) SubscribeToFiatRates() error        { return nil }
+func (m *tMeshClient) SubscribeToFeeRateEstimates() error { return nil }
+func (m *tMeshClient) SubscribeMarket(baseID, quoteID uint32) error {
+	m.subs = append(m.subs, [2]uint32{baseID, quoteID})
+	return nil
+}
+func (m *tMeshClient) PostBond(bond *tanka.Bond) error {
+	m.bonds = append(m.bonds, bond)
+	return nil
+}
+func (m *tMeshClient) ActiveBonds() ([]*tanka.Bond, error) { return m.bonds, nil }
+func (m *tMeshClient) PlaceOrder(ord *tanka.Order) error {
+	m.placed = append(m.placed, ord)
+	return nil
+}
+func (m *tMeshClient) CancelOrder(baseID, quoteID uint32, oid tanka.ID40) error {
+	m.canceled = append(m.canceled, oid)
+	return nil
+}
+func (m *tMeshClient) Book(baseID, quoteID uint32) (buys, sells []*tanka.Order, err error) {
+	return m.buys, m.sells, nil
+}
+func (m *tMeshClient) Orders(baseID, quoteID uint32) ([]*mesh.OrderStatus, error) {
+	return m.ords, nil
+}
+func (m *tMeshClient) FiatRate(assetID uint32) float64       { return m.fiatRates[assetID] }
+func (m *tMeshClient) FeeRateEstimate(chainID uint32) uint64 { return m.feeRates[chainID] }
+
+func TestMeshVenue(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+
+	const lotSize = 1 << 20
+	baseID, quoteID := tUTXOAssetA.ID, tUTXOAssetB.ID
+	tMesh := &tMeshClient{
+		peerID:    tanka.PeerID{0x01},
+		fiatRates: map[uint32]float64{baseID: 20},
+		feeRates:  map[uint32]uint64{baseID: 10, quoteID: 30},
+	}
+	tCore.newMeshClient = func(*mesh.Config) (meshClient, error) { return tMesh, nil }
+	tCore.cfg.Mesh = &MeshConfig{
+		Markets: []*MeshMarket{{BaseID: baseID, QuoteID: quoteID, LotSize: lotSize}},
+	}
+	baseWallet, _ := newTWallet(baseID)
+	tCore.wallets[baseID] = baseWallet
+	quoteWallet, tQuoteWallet := newTWallet(quoteID)
+	tCore.wallets[quoteID] = quoteWallet
+
+	if err := tCore.Login(tPW); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+	if len(tMesh.subs) != 1 || tMesh.subs[0] != [2]uint32{baseID, quoteID} {
+		t.Fatalf("market not subscribed")
+	}
+
+	// Mesh markets are listed with the server markets.
+	xc := tCore.Exchanges()[MeshHost]
+	if xc == nil {
+		t.Fatalf("no mesh exchange")
+	}
+	mkt := xc.Markets[marketName(baseID, quoteID)]
+	if mkt == nil || mkt.LotSize != lotSize {
+		t.Fatalf("wrong mesh market")
+	}
+	if _, found := tCore.Exchanges()[tDexHost]; !found {
+		t.Fatalf("server exchange missing")
+	}
+
+	tMesh.sells = []*tanka.Order{{From: tanka.PeerID{0x02}, Sell: true, Qty: lotSize * 2, Rate: 1e6}}
+	book, err := tCore.Book(MeshHost, baseID, quoteID)
+	if err != nil {
+		t.Fatalf("Book error: %v", err)
+	}
+	if len(book.Sells) != 1 || len(book.Buys) != 0 || book.Sells[0].QtyAtomic != lotSize*2 || !book.Sells[0].Sell {
+		t.Fatalf("wrong book")
+	}
+
+	// Oracle data.
+	if r := tCore.feeSuggestionAny(quoteID); r != 30 {
+		t.Fatalf("wrong fee suggestion %d", r)
+	}
+	if r := tCore.fiatConversions()[baseID]; r != 20 {
+		t.Fatalf("wrong fiat rate %f", r)
+	}
+
+	// Orders go to the mesh.
+	form := &TradeForm{
+		Host:    MeshHost,
+		IsLimit: true,
+		Base:    baseID,
+		Quote:   quoteID,
+		Qty:     lotSize * 3,
+		Rate:    1e6,
+	}
+	quoteQty := calc.BaseToQuote(form.Rate, form.Qty)
+	tQuoteWallet.bal = &asset.Balance{Available: quoteQty - 1}
+	if _, err := tCore.Trade(tPW, form); !errorHasCode(err, walletBalanceErr) {
+		t.Fatalf("expected a balance error, got %v", err)
+	}
+	// Funds committed to other booked orders are not available.
+	tQuoteWallet.bal.Available = quoteQty
+	tMesh.ords = []*mesh.OrderStatus{{Order: &tanka.Order{BaseID: baseID, QuoteID: quoteID, Rate: 1e6}, Remaining: lotSize, Booked: true}}
+	if _, err := tCore.Trade(tPW, form); !errorHasCode(err, walletBalanceErr) {
+		t.Fatalf("expected a balance error for committed funds, got %v", err)
+	}
+	tMesh.ords = nil
+	ord, err := tCore.Trade(tPW, form)
+	if err != nil {
+		t.Fatalf("Trade error: %v", err)
+	}
+	if len(tMesh.placed) != 1 {
+		t.Fatalf("order not placed")
+	}
+	placed := tMesh.placed[0]
+	oid := placed.ID()
+	if placed.From != tMesh.peerID || placed.LotSize != lotSize || placed.Qty != form.Qty || placed.Sell {
+		t.Fatalf("wrong order placed")
+	}
+	// A buy order swaps the quote asset.
+	if placed.MaxFeeRate != 30*meshMaxFeeRateMult {
+		t.Fatalf("wrong max fee rate %d", placed.MaxFeeRate)
+	}
+	if !bytes.Equal(ord.ID, oid[:]) || ord.Host != MeshHost || ord.Status != order.OrderStatusBooked {
+		t.Fatalf("wrong order returned")
+	}
+	for _, f := range []*TradeForm{
+		{Host: MeshHost, IsLimit: true, Base: baseID, Quote: quoteID, Qty: lotSize + 1, Rate: 1e6},
+		{Host: MeshHost, Base: baseID, Quote: quoteID, Qty: lotSize, Rate: 1e6},
+		{Host: MeshHost, IsLimit: true, Base: quoteID, Quote: baseID, Qty: lotSize, Rate: 1e6},
+	} {
+		if _, err := tCore.Trade(tPW, f); err == nil {
+			t.Fatalf("no error for bad trade form")
+		}
+	}
+
+	tMesh.ords = []*mesh.OrderStatus{{Order: placed, Remaining: lotSize, Booked: true}}
+	mkt = tCore.Exchanges()[MeshHost].Markets[marketName(baseID, quoteID)]
+	if len(mkt.Orders) != 1 || mkt.Orders[0].Filled != lotSize*2 {
+		t.Fatalf("wrong market orders")
+	}
+	if !tCore.Active() {
+		t.Fatalf("booked mesh order not active")
+	}
+
+	// Cancel.
+	if err := tCore.Cancel(oid[:]); err != nil {
+		t.Fatalf("Cancel error: %v", err)
+	}
+	if len(tMesh.canceled) != 1 || tMesh.canceled[0] != oid {
+		t.Fatalf("order not canceled")
+	}
+	if err := tCore.Cancel(make([]byte, len(oid))); err == nil {
+		t.Fatalf("no error canceling an unknown mesh order")
+	}
+	tMesh.ords[0].Booked = false
+	if tCore.Active() {
+		t.Fatalf("unbooked mesh order still active")
+	}
+	if err := tCore.Cancel(oid[:]); err == nil {
+		t.Fatalf("no error canceling an unbooked mesh order")
+	}
+
+	// Bonds.
+	tQuoteWallet.feeCoinSent = tQuoteWallet.bondTxCoinID
+	bond, err := tCore.PostMeshBond(tPW, &MeshBondForm{
+		AssetID:  quoteID,
+		Amount:   1e8,
+		Strength: 1,
+		LockTime: time.Now().Add(time.Hour * 24),
+	})
+	if err != nil {
+		t.Fatalf("PostMeshBond error: %v", err)
+	}
+	if len(tMesh.bonds) != 1 || bond.PeerID != tMesh.peerID || !bytes.Equal(bond.CoinID, tQuoteWallet.bondTxCoinID) {
+		t.Fatalf("wrong bond posted")
+	}
+	if xc := tCore.Exchanges()[MeshHost]; xc.Auth.LiveStrength != 1 {
+		t.Fatalf("wrong live strength %d", xc.Auth.LiveStrength)
+	}
+
+	// The bond is stored and refunded after the lock time.
+	dbBonds, _ := rig.db.MeshBonds()
+	if len(dbBonds) != 1 || !bytes.Equal(dbBonds[0].CoinID, bond.CoinID) || dbBonds[0].Refunded {
+		t.Fatalf("mesh bond not stored")
+	}
+	tCore.refundMeshBonds(tCtx, time.Now().Unix())
+	if dbBonds, _ = rig.db.MeshBonds(); dbBonds[0].Refunded {
+		t.Fatalf("mesh bond refunded before lock time")
+	}
+	tQuoteWallet.contractExpired = true
+	tQuoteWallet.refundBondCoin = &tCoin{id: encode.RandomBytes(36), val: 1e8}
+	tCore.refundMeshBonds(tCtx, time.Now().Add(time.Hour*25).Unix())
+	if dbBonds, _ = rig.db.MeshBonds(); !dbBonds[0].Refunded {
+		t.Fatalf("mesh bond not refunded")
+	}
+
+	// The mesh is disconnected on logout.
+	if err := tCore.Logout(); err != nil {
+		t.Fatalf("Logout error: %v", err)
+	}
+	if _, found := tCore.Exchanges()[MeshHost]; found {
+		t.Fatalf("mesh exchange listed after logout")
+	}
+}
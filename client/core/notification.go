@@ -43,6 +43,8 @@ const (
 	NoteTypeWalletNote     = "walletnote"
 	NoteTypeReputation     = "reputation"
 	NoteTypeActionRequired = "actionrequired"
+	NoteTypeConditional    = "conditionalorder"
+	NoteTypeMarketAlert    = "marketalert"
 )
 
 var noteChanCounter uint64
@@ -353,6 +355,48 @@ func newSendNote(topic Topic, subject, details string, severity db.Severity) *Se
 	}
 }
 
+// ConditionalOrderNote is a notification about a conditional order.
+type ConditionalOrderNote struct {
+	db.Notification
+	Order *db.ConditionalOrder `json:"order"`
+}
+
+const (
+	TopicConditionalOrderPlaced    Topic = "ConditionalOrderPlaced"
+	TopicConditionalOrderTriggered Topic = "ConditionalOrderTriggered"
+	TopicConditionalOrderFailed    Topic = "ConditionalOrderFailed"
+	TopicConditionalOrderCanceled  Topic = "ConditionalOrderCanceled"
+)
+
+func newConditionalOrderNote(topic Topic, subject, details string, severity db.Severity, ord *db.ConditionalOrder) *ConditionalOrderNote {
+	o := *ord
+	return &ConditionalOrderNote{
+		Notification: db.NewNotification(NoteTypeConditional, topic, subject, details, severity),
+		Order:        &o,
+	}
+}
+
+// MarketAlertNote is a notification that a market alert fired.
+type MarketAlertNote struct {
+	db.Notification
+	Alert *db.MarketAlert `json:"alert"`
+}
+
+const (
+	TopicPriceAlert    Topic = "PriceAlert"
+	TopicSpreadAlert   Topic = "SpreadAlert"
+	TopicVolumeAlert   Topic = "VolumeAlert"
+	TopicFiatRateAlert Topic = "FiatRateAlert"
+)
+
+func newMarketAlertNote(topic Topic, subject, details string, severity db.Severity, alert *db.MarketAlert) *MarketAlertNote {
+	a := *alert
+	return &MarketAlertNote{
+		Notification: db.NewNotification(NoteTypeMarketAlert, topic, subject, details, severity),
+		Alert:        &a,
+	}
+}
+
 // OrderNote is a notification about an order or a match.
 type OrderNote struct {
 	db.Notification
@@ -0,0 +1,540 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/encode"
+)
+
+// AggregatedOrder is an order on the aggregated book, annotated with the host
+// of the DEX where it is booked.
+type AggregatedOrder struct {
+	Host string `json:"host"`
+	*MiniOrder
+}
+
+// AggregatedBook is the combined order book for a market on multiple DEX
+// hosts. Each side is sorted best rate first.
+type AggregatedBook struct {
+	Base  uint32             `json:"base"`
+	Quote uint32             `json:"quote"`
+	Hosts []string           `json:"hosts"`
+	Buys  []*AggregatedOrder `json:"buys"`
+	Sells []*AggregatedOrder `json:"sells"`
+}
+
+// RoutedTradeForm is used to split a trade across the same market on multiple
+// DEX hosts.
+type RoutedTradeForm struct {
+	// Hosts limits the routing to the specified hosts. If empty, all hosts
+	// that list the market are considered.
+	Hosts []string `json:"hosts"`
+	Sell  bool     `json:"sell"`
+	Base  uint32   `json:"base"`
+	Quote uint32   `json:"quote"`
+	// Qty is the total quantity in units of the base asset, for both buys and
+	// sells.
+	Qty uint64 `json:"qty"`
+	// Rate is the worst acceptable message-rate. Zero means no limit.
+	Rate uint64 `json:"rate"`
+	// TifNow specifies that the child orders should not be booked if they are
+	// not matched in the first epoch.
+	TifNow  bool              `json:"tifnow"`
+	Options map[string]string `json:"options"`
+}
+
+// RouteAllocation is the part of a RoutePlan assigned to a single host.
+type RouteAllocation struct {
+	Host    string `json:"host"`
+	LotSize uint64 `json:"lotSize"`
+	Qty     uint64 `json:"qty"`
+	// Rate is the worst book rate needed to fill the allocation, and is used
+	// as the limit rate of the child order.
+	Rate uint64 `json:"rate"`
+	// QuoteQty is the quote asset value of the allocation at book rates.
+	QuoteQty uint64 `json:"quoteQty"`
+	// Fees is the estimated swap and redeem fees, in units of the quote asset.
+	Fees uint64 `json:"fees"`
+}
+
+// RoutePlan is the split of a routed trade across hosts.
+type RoutePlan struct {
+	Allocations []*RouteAllocation `json:"allocations"`
+	Qty         uint64             `json:"qty"`
+	QuoteQty    uint64             `json:"quoteQty"`
+	// AvgRate is the average message-rate of the plan at book rates, not
+	// including fees.
+	AvgRate uint64 `json:"avgRate"`
+	// Fees is the estimated swap and redeem fees, in units of the quote asset.
+	Fees uint64 `json:"fees"`
+}
+
+// RoutedChildOrder is a RoutedOrder's order on a single host.
+type RoutedChildOrder struct {
+	Host  string `json:"host"`
+	Qty   uint64 `json:"qty"`
+	Rate  uint64 `json:"rate"`
+	Order *Order `json:"order,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// RoutedOrder is a logical parent order whose quantity was split across
+// multiple hosts.
+type RoutedOrder struct {
+	ID     dex.Bytes `json:"id"`
+	Sell   bool      `json:"sell"`
+	Base   uint32    `json:"base"`
+	Quote  uint32    `json:"quote"`
+	Qty    uint64    `json:"qty"`
+	Rate   uint64    `json:"rate"`
+	Stamp  uint64    `json:"stamp"`
+	Filled uint64    `json:"filled"`
+	// Active is true if any of the child orders is still active.
+	Active   bool                `json:"active"`
+	Children []*RoutedChildOrder `json:"children"`
+}
+
+// routeLevel is a price level of the book side consumed by a routed trade.
+type routeLevel struct {
+	rate uint64
+	qty  uint64
+}
+
+// routeVenue is a host's market, as seen by the router.
+type routeVenue struct {
+	host    string
+	lotSize uint64
+	// lotFee is the estimated swap and redeem fees for one lot, in units of
+	// the quote asset.
+	lotFee uint64
+	// maxLots is the number of lots allowed by the user's trading limits.
+	maxLots uint64
+	// levels is the book side to consume, best rate first.
+	levels []*routeLevel
+}
+
+// venueState tracks a venue's allocation while a route is planned.
+type venueState struct {
+	*routeVenue
+	alloc *RouteAllocation
+	lots  uint64
+	// level and used mark the next unconsumed quantity of the book.
+	level int
+	used  uint64
+}
+
+// nextLot is the worst book rate and the quote value at book rates of the
+// venue's next lot, or false if the book can't fill another lot. A lot may
+// span multiple book levels.
+func (s *venueState) nextLot() (rate, quoteQty uint64, ok bool) {
+	need := s.lotSize
+	used := s.used
+	for lvl := s.level; lvl < len(s.levels) && need > 0; lvl++ {
+		l := s.levels[lvl]
+		avail := l.qty - used
+		used = 0
+		take := min(avail, need)
+		if take == 0 {
+			continue
+		}
+		need -= take
+		rate = l.rate
+		quoteQty += calc.BaseToQuote(l.rate, take)
+	}
+	return rate, quoteQty, need == 0
+}
+
+// consumeLot removes the venue's next lot from the book.
+func (s *venueState) consumeLot() {
+	need := s.lotSize
+	for s.level < len(s.levels) && need > 0 {
+		avail := s.levels[s.level].qty - s.used
+		if avail > need {
+			s.used += need
+			return
+		}
+		need -= avail
+		s.level++
+		s.used = 0
+	}
+}
+
+// planRoute splits qty across the venues to get the best average price net of
+// fees. Lots are allocated one at a time to the venue offering the best fee
+// adjusted value for its next lot. Because each venue's book is consumed best
+// rate first and the fees are constant per lot, allocating greedily is
+// optimal when the lot sizes are equal. rateLimit is the worst acceptable
+// rate, zero for no limit.
+func planRoute(sell bool, qty, rateLimit uint64, venues []*routeVenue) (*RoutePlan, error) {
+	states := make([]*venueState, 0, len(venues))
+	for _, v := range venues {
+		states = append(states, &venueState{
+			routeVenue: v,
+			alloc:      &RouteAllocation{Host: v.host, LotSize: v.lotSize},
+		})
+	}
+
+	acceptable := func(rate uint64) bool {
+		if rateLimit == 0 {
+			return true
+		}
+		if sell {
+			return rate >= rateLimit
+		}
+		return rate <= rateLimit
+	}
+
+	remain := qty
+	for remain > 0 {
+		var best *venueState
+		var bestRate, bestQuote uint64
+		var bestValue float64
+		for _, s := range states {
+			if s.lotSize == 0 || s.lotSize > remain || s.lots >= s.maxLots {
+				continue
+			}
+			rate, quoteQty, ok := s.nextLot()
+			if !ok || !acceptable(rate) {
+				continue
+			}
+			// The fee adjusted quote value per unit of base asset.
+			value := float64(quoteQty)
+			if sell {
+				value -= float64(s.lotFee)
+			} else {
+				value += float64(s.lotFee)
+			}
+			value /= float64(s.lotSize)
+			if best == nil || (sell && value > bestValue) || (!sell && value < bestValue) {
+				best, bestRate, bestQuote, bestValue = s, rate, quoteQty, value
+			}
+		}
+		if best == nil {
+			break
+		}
+		best.consumeLot()
+		best.lots++
+		best.alloc.Qty += best.lotSize
+		best.alloc.Rate = bestRate
+		best.alloc.QuoteQty += bestQuote
+		best.alloc.Fees += best.lotFee
+		remain -= best.lotSize
+	}
+	if remain > 0 {
+		return nil, fmt.Errorf("unable to route %d of %d: insufficient liquidity or trading limits, or quantity not divisible by lot sizes", remain, qty)
+	}
+
+	plan := &RoutePlan{Qty: qty}
+	for _, s := range states {
+		if s.lots == 0 {
+			continue
+		}
+		plan.Allocations = append(plan.Allocations, s.alloc)
+		plan.QuoteQty += s.alloc.QuoteQty
+		plan.Fees += s.alloc.Fees
+	}
+	if qty > 0 {
+		avg := new(big.Int).SetUint64(plan.QuoteQty)
+		avg.Mul(avg, big.NewInt(calc.RateEncodingFactor))
+		avg.Div(avg, new(big.Int).SetUint64(qty))
+		plan.AvgRate = avg.Uint64()
+	}
+	return plan, nil
+}
+
+// AggregatedBook combines the market's order books from the specified hosts,
+// or all connected hosts listing the market if none are specified.
+func (c *Core) AggregatedBook(base, quote uint32, hosts []string) (*AggregatedBook, error) {
+	mktID := marketName(base, quote)
+	book := &AggregatedBook{Base: base, Quote: quote}
+	for _, dc := range c.routeConnections(hosts) {
+		if dc.marketConfig(mktID) == nil {
+			continue
+		}
+		ob, err := c.Book(dc.acct.host, base, quote)
+		if err != nil {
+			c.log.Errorf("Error getting %s book from %s: %v", mktID, dc.acct.host, err)
+			continue
+		}
+		book.Hosts = append(book.Hosts, dc.acct.host)
+		for _, o := range ob.Buys {
+			book.Buys = append(book.Buys, &AggregatedOrder{Host: dc.acct.host, MiniOrder: o})
+		}
+		for _, o := range ob.Sells {
+			book.Sells = append(book.Sells, &AggregatedOrder{Host: dc.acct.host, MiniOrder: o})
+		}
+	}
+	if len(book.Hosts) == 0 {
+		return nil, newError(marketErr, "no connected DEX lists the %s market", mktID)
+	}
+	sort.SliceStable(book.Buys, func(i, j int) bool { return book.Buys[i].MsgRate > book.Buys[j].MsgRate })
+	sort.SliceStable(book.Sells, func(i, j int) bool { return book.Sells[i].MsgRate < book.Sells[j].MsgRate })
+	return book, nil
+}
+
+// routeConnections returns the dexConnections for the hosts, or all
+// connections if no hosts are specified.
+func (c *Core) routeConnections(hosts []string) []*dexConnection {
+	if len(hosts) == 0 {
+		return c.dexConnections()
+	}
+	conns := make([]*dexConnection, 0, len(hosts))
+	for _, host := range hosts {
+		dc, _, err := c.dex(host)
+		if err != nil {
+			c.log.Warnf("Routing: %v", err)
+			continue
+		}
+		conns = append(conns, dc)
+	}
+	return conns
+}
+
+// feeAssetID is the asset that pays the transaction fees for the asset.
+func feeAssetID(assetID uint32) uint32 {
+	if tkn := asset.TokenInfo(assetID); tkn != nil {
+		return tkn.ParentID
+	}
+	return assetID
+}
+
+// feesInQuote converts fees paid in the feeAsset to units of the quote asset.
+// Fees paid in a third asset, such as a token's parent asset, are converted
+// with the fiat rates, and are ignored if a fiat rate is not available.
+func (c *Core) feesInQuote(fees uint64, feeAsset, base, quote uint32, rate uint64, fiatRates map[uint32]float64) uint64 {
+	switch feeAsset {
+	case quote:
+		return fees
+	case base:
+		return calc.BaseToQuote(rate, fees)
+	}
+	feeUI, err := asset.UnitInfo(feeAsset)
+	if err != nil {
+		return 0
+	}
+	quoteUI, err := asset.UnitInfo(quote)
+	if err != nil {
+		return 0
+	}
+	feeFiat, quoteFiat := fiatRates[feeAsset], fiatRates[quote]
+	if feeFiat == 0 || quoteFiat == 0 {
+		c.log.Debugf("Routing: no fiat rates to convert %s fees to %s", unbip(feeAsset), unbip(quote))
+		return 0
+	}
+	usd := float64(fees) / float64(feeUI.Conventional.ConversionFactor) * feeFiat
+	return uint64(math.Round(usd / quoteFiat * float64(quoteUI.Conventional.ConversionFactor)))
+}
+
+// routeVenues gathers the book, fees and trading limits for the form's market
+// from each candidate host.
+func (c *Core) routeVenues(form *RoutedTradeForm) ([]*routeVenue, error) {
+	mktID := marketName(form.Base, form.Quote)
+	fromID, toID := form.Quote, form.Base
+	if form.Sell {
+		fromID, toID = form.Base, form.Quote
+	}
+	var fiatRates map[uint32]float64
+	venues := make([]*routeVenue, 0)
+	for _, dc := range c.routeConnections(form.Hosts) {
+		host := dc.acct.host
+		mkt := dc.marketConfig(mktID)
+		if mkt == nil {
+			continue
+		}
+		if _, err := c.registeredDEX(host); err != nil {
+			c.log.Debugf("Routing: skipping %s: %v", host, err)
+			continue
+		}
+		if !dc.running(mktID) {
+			continue
+		}
+		ob, err := c.Book(host, form.Base, form.Quote)
+		if err != nil {
+			c.log.Errorf("Routing: error getting %s book from %s: %v", mktID, host, err)
+			continue
+		}
+		side := ob.Buys
+		if !form.Sell {
+			side = ob.Sells
+		}
+		if len(side) == 0 {
+			continue
+		}
+		levels := make([]*routeLevel, 0, len(side))
+		for _, o := range side {
+			levels = append(levels, &routeLevel{rate: o.MsgRate, qty: o.QtyAtomic})
+		}
+		sort.SliceStable(levels, func(i, j int) bool {
+			if form.Sell {
+				return levels[i].rate > levels[j].rate
+			}
+			return levels[i].rate < levels[j].rate
+		})
+
+		swapFees, redeemFees, _, err := c.SingleLotFees(&SingleLotFeesForm{
+			Host:  host,
+			Base:  form.Base,
+			Quote: form.Quote,
+			Sell:  form.Sell,
+		})
+		if err != nil {
+			c.log.Debugf("Routing: skipping %s: fee estimate error: %v", host, err)
+			continue
+		}
+		if fiatRates == nil {
+			fiatRates = c.fiatConversions()
+		}
+		bestRate := levels[0].rate
+		lotFee := c.feesInQuote(swapFees, feeAssetID(fromID), form.Base, form.Quote, bestRate, fiatRates) +
+			c.feesInQuote(redeemFees, feeAssetID(toID), form.Base, form.Quote, bestRate, fiatRates)
+
+		userParcels, parcelLimit, err := c.TradingLimits(host)
+		if err != nil {
+			c.log.Debugf("Routing: skipping %s: trading limits error: %v", host, err)
+			continue
+		}
+		if parcelLimit <= userParcels {
+			continue
+		}
+		venues = append(venues, &routeVenue{
+			host:    host,
+			lotSize: mkt.LotSize,
+			lotFee:  lotFee,
+			maxLots: uint64(parcelLimit-userParcels) * uint64(mkt.ParcelSize),
+			levels:  levels,
+		})
+	}
+	if len(venues) == 0 {
+		return nil, newError(marketErr, "no hosts available to route a %s order", mktID)
+	}
+	return venues, nil
+}
+
+// PlanRoute previews how a routed trade would be split across hosts.
+func (c *Core) PlanRoute(form *RoutedTradeForm) (*RoutePlan, error) {
+	if form.Qty == 0 {
+		return nil, newError(orderParamsErr, "zero quantity not allowed")
+	}
+	venues, err := c.routeVenues(form)
+	if err != nil {
+		return nil, err
+	}
+	return planRoute(form.Sell, form.Qty, form.Rate, venues)
+}
+
+// RoutedTrade splits the trade across the market on multiple hosts to get the
+// best average price net of fees, within the user's trading limits, and
+// places a limit order on each host at the worst book rate needed to fill its
+// part. The orders are tracked as children of a logical parent RoutedOrder.
+// An error is only returned if no child orders could be placed.
+func (c *Core) RoutedTrade(pw []byte, form *RoutedTradeForm) (*RoutedOrder, error) {
+	plan, err := c.PlanRoute(form)
+	if err != nil {
+		return nil, err
+	}
+	dbOrd := &db.RoutedOrder{
+		ID:      encode.RandomBytes(8),
+		Sell:    form.Sell,
+		Base:    form.Base,
+		Quote:   form.Quote,
+		Qty:     form.Qty,
+		Rate:    form.Rate,
+		TifNow:  form.TifNow,
+		Options: form.Options,
+		Stamp:   uint64(time.Now().UnixMilli()),
+	}
+	var placed int
+	var errs []error
+	for _, alloc := range plan.Allocations {
+		child := &db.RoutedChild{
+			Host: alloc.Host,
+			Qty:  alloc.Qty,
+			Rate: alloc.Rate,
+		}
+		ord, err := c.Trade(pw, &TradeForm{
+			Host:    alloc.Host,
+			IsLimit: true,
+			Sell:    form.Sell,
+			Base:    form.Base,
+			Quote:   form.Quote,
+			Qty:     alloc.Qty,
+			Rate:    alloc.Rate,
+			TifNow:  form.TifNow,
+			Options: form.Options,
+		})
+		if err != nil {
+			c.log.Errorf("Routed order %s: error placing %d at %s: %v", dbOrd.ID, alloc.Qty, alloc.Host, err)
+			child.Error = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", alloc.Host, err))
+		} else {
+			child.OrderID = ord.ID
+			placed++
+		}
+		dbOrd.Children = append(dbOrd.Children, child)
+	}
+	if placed == 0 {
+		return nil, fmt.Errorf("no routed orders placed: %w", errors.Join(errs...))
+	}
+	if err := c.db.UpdateRoutedOrder(dbOrd); err != nil {
+		c.log.Errorf("Error storing routed order %s: %v", dbOrd.ID, err)
+	}
+	return c.routedOrder(dbOrd), nil
+}
+
+// routedOrder adds the current child order details to the db.RoutedOrder.
+func (c *Core) routedOrder(dbOrd *db.RoutedOrder) *RoutedOrder {
+	ord := &RoutedOrder{
+		ID:    dbOrd.ID,
+		Sell:  dbOrd.Sell,
+		Base:  dbOrd.Base,
+		Quote: dbOrd.Quote,
+		Qty:   dbOrd.Qty,
+		Rate:  dbOrd.Rate,
+		Stamp: dbOrd.Stamp,
+	}
+	for _, ch := range dbOrd.Children {
+		child := &RoutedChildOrder{
+			Host:  ch.Host,
+			Qty:   ch.Qty,
+			Rate:  ch.Rate,
+			Error: ch.Error,
+		}
+		if len(ch.OrderID) > 0 {
+			corder, err := c.Order(ch.OrderID)
+			if err != nil {
+				child.Error = fmt.Sprintf("error retrieving order: %v", err)
+			} else {
+				child.Order = corder
+				ord.Filled += corder.Filled
+				ord.Active = ord.Active || corder.Status.IsActive()
+			}
+		}
+		ord.Children = append(ord.Children, child)
+	}
+	return ord
+}
+
+// RoutedOrders returns the routed orders, newest first.
+func (c *Core) RoutedOrders() ([]*RoutedOrder, error) {
+	dbOrds, err := c.db.RoutedOrders()
+	if err != nil {
+		return nil, err
+	}
+	ords := make([]*RoutedOrder, 0, len(dbOrds))
+	for i := len(dbOrds) - 1; i >= 0; i-- {
+		ords = append(ords, c.routedOrder(dbOrds[i]))
+	}
+	return ords, nil
+}
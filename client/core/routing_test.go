@@ -0,0 +1,171 @@
+//go:build !harness && !botlive
+
+package core
+
+import (
+	"testing"
+)
+
+func TestPlanRoute(t *testing.T) {
+	const lotSize = 1e8
+	venue := func(host string, lotFee, maxLots uint64, levels ...uint64) *routeVenue {
+		v := &routeVenue{host: host, lotSize: lotSize, lotFee: lotFee, maxLots: maxLots}
+		for i := 0; i < len(levels); i += 2 {
+			v.levels = append(v.levels, &routeLevel{rate: levels[i], qty: levels[i+1]})
+		}
+		return v
+	}
+
+	type allocation struct {
+		host string
+		lots uint64
+		rate uint64
+	}
+	tests := []struct {
+		name      string
+		sell      bool
+		lots      uint64
+		rateLimit uint64
+		venues    []*routeVenue
+		exp       []allocation
+		wantErr   bool
+	}{
+		{
+			name:   "buy single venue",
+			lots:   2,
+			venues: []*routeVenue{venue("a", 0, 100, 1e6, 5*lotSize)},
+			exp:    []allocation{{"a", 2, 1e6}},
+		},
+		{
+			name: "buy split on price",
+			lots: 3,
+			venues: []*routeVenue{
+				venue("a", 0, 100, 1e6, lotSize, 3e6, 5*lotSize),
+				venue("b", 0, 100, 2e6, 5*lotSize),
+			},
+			exp: []allocation{{"a", 1, 1e6}, {"b", 2, 2e6}},
+		},
+		{
+			name: "buy fees favor other venue",
+			lots: 1,
+			venues: []*routeVenue{
+				venue("a", 5e5, 100, 1e6, 5*lotSize),
+				venue("b", 0, 100, 1.2e6, 5*lotSize),
+			},
+			exp: []allocation{{"b", 1, 1.2e6}},
+		},
+		{
+			name: "sell split on price",
+			sell: true,
+			lots: 3,
+			venues: []*routeVenue{
+				venue("a", 0, 100, 3e6, lotSize, 1e6, 5*lotSize),
+				venue("b", 0, 100, 2e6, 5*lotSize),
+			},
+			exp: []allocation{{"a", 1, 3e6}, {"b", 2, 2e6}},
+		},
+		{
+			name: "sell fees favor other venue",
+			sell: true,
+			lots: 1,
+			venues: []*routeVenue{
+				venue("a", 5e5, 100, 1.2e6, 5*lotSize),
+				venue("b", 0, 100, 1e6, 5*lotSize),
+			},
+			exp: []allocation{{"b", 1, 1e6}},
+		},
+		{
+			name: "trading limits",
+			lots: 3,
+			venues: []*routeVenue{
+				venue("a", 0, 1, 1e6, 5*lotSize),
+				venue("b", 0, 100, 2e6, 5*lotSize),
+			},
+			exp: []allocation{{"a", 1, 1e6}, {"b", 2, 2e6}},
+		},
+		{
+			name: "lot spans levels",
+			lots: 2,
+			venues: []*routeVenue{
+				venue("a", 0, 100, 1e6, lotSize/2, 2e6, lotSize, 3e6, lotSize),
+			},
+			exp: []allocation{{"a", 2, 3e6}},
+		},
+		{
+			name:      "rate limit",
+			lots:      2,
+			rateLimit: 2e6,
+			venues: []*routeVenue{
+				venue("a", 0, 100, 1e6, lotSize, 3e6, 5*lotSize),
+			},
+			wantErr: true,
+		},
+		{
+			name: "insufficient liquidity",
+			lots: 6,
+			venues: []*routeVenue{
+				venue("a", 0, 100, 1e6, 5*lotSize),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		plan, err := planRoute(tt.sell, tt.lots*lotSize, tt.rateLimit, tt.venues)
+		if err != nil {
+			if !tt.wantErr {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if tt.wantErr {
+			t.Fatalf("%s: no error", tt.name)
+		}
+		if len(plan.Allocations) != len(tt.exp) {
+			t.Fatalf("%s: expected %d allocations, got %d", tt.name, len(tt.exp), len(plan.Allocations))
+		}
+		var quoteQty uint64
+		for i, exp := range tt.exp {
+			alloc := plan.Allocations[i]
+			if alloc.Host != exp.host || alloc.Qty != exp.lots*lotSize || alloc.Rate != exp.rate {
+				t.Fatalf("%s: expected allocation %+v, got %+v", tt.name, exp, alloc)
+			}
+			quoteQty += alloc.QuoteQty
+		}
+		if plan.QuoteQty != quoteQty {
+			t.Fatalf("%s: wrong plan quote quantity %d != %d", tt.name, plan.QuoteQty, quoteQty)
+		}
+	}
+}
+
+func TestRoutedTrade(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+
+	// The test DEX has no book, so there is nowhere to route.
+	form := &RoutedTradeForm{
+		Sell:  true,
+		Base:  tUTXOAssetA.ID,
+		Quote: tUTXOAssetB.ID,
+		Qty:   dcrBtcLotSize,
+	}
+	if _, err := tCore.RoutedTrade(nil, form); err == nil {
+		t.Fatalf("no error for routed trade with no liquidity")
+	}
+	form.Qty = 0
+	if _, err := tCore.PlanRoute(form); err == nil {
+		t.Fatalf("no error for zero quantity")
+	}
+	if _, err := tCore.AggregatedBook(tUTXOAssetA.ID, 12345, nil); err == nil {
+		t.Fatalf("no error for unknown market")
+	}
+
+	ords, err := tCore.RoutedOrders()
+	if err != nil {
+		t.Fatalf("RoutedOrders error: %v", err)
+	}
+	if len(ords) != 0 {
+		t.Fatalf("expected no routed orders, got %d", len(ords))
+	}
+}
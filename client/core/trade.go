@@ -106,6 +106,9 @@ type matchTracker struct {
 	// request. Additional requests will just error and they don't really care
 	// if we redeem as taker anyway.
 	matchCompleteSent bool
+	// watchtowered is set when the match has been registered with the
+	// configured watchtowers.
+	watchtowered bool
 
 	// The fields below need to be modified without the parent trackedTrade's
 	// mutex being write locked, so they have dedicated mutexes.
@@ -3247,7 +3250,7 @@ func (c *Core) refundMatches(t *trackedTrade, matches []*matchTracker) (uint64,
 
 	refundWallet := t.wallets.fromWallet // refunding to our wallet
 	symbol, assetID := refundWallet.Symbol, refundWallet.AssetID
-	var refundedQty uint64
+	var refundedQty, refundFees uint64
 
 	for _, match := range matches {
 		if len(match.MetaData.Proof.RefundCoin) != 0 {
@@ -3331,6 +3334,7 @@ func (c *Core) refundMatches(t *trackedTrade, matches []*matchTracker) (uint64,
 		} else {
 			refundedQty += calc.BaseToQuote(match.Rate, match.Quantity)
 		}
+		refundFees += c.refundFee(refundWallet, refundCoin)
 		match.MetaData.Proof.RefundCoin = []byte(refundCoin)
 		match.MetaData.Proof.SelfRevoked = true // Set match as revoked.
 		err = t.db.UpdateMatch(&match.MetaMatch)
@@ -3339,9 +3343,29 @@ func (c *Core) refundMatches(t *trackedTrade, matches []*matchTracker) (uint64,
 		}
 	}
 
+	if refundFees > 0 {
+		t.metaData.RefundFeesPaid += refundFees
+		if err := t.db.UpdateOrderMetaData(t.ID(), t.metaData); err != nil {
+			errs.add("error storing refund fees in database: %v", err)
+		}
+	}
+
 	return refundedQty, errs.ifAny()
 }
 
+// refundFee is the fee paid by the refund transaction with the refund coin,
+// as recorded in the wallet's transaction history. Zero is returned if the
+// wallet does not keep a history.
+func (c *Core) refundFee(w *xcWallet, refundCoin dex.Bytes) uint64 {
+	txID, _, _ := strings.Cut(coinIDString(w.AssetID, refundCoin), ":")
+	tx, err := w.WalletTransaction(c.ctx, txID)
+	if err != nil {
+		c.log.Debugf("No %s refund transaction %s for fees: %v", w.Symbol, txID, err)
+		return 0
+	}
+	return tx.Fees
+}
+
 // processAuditMsg processes the audit request from the server. A non-nil error
 // is only returned if the match referenced by the Audit message is not known.
 func (t *trackedTrade) processAuditMsg(msgID uint64, audit *msgjson.Audit) error {
@@ -37,6 +37,9 @@ const (
 	// scheme to locate them on-chain:
 	//  m / hdKeyPurposeBonds / assetID' / bondIndex
 	hdKeyPurposeBonds uint32 = hdkeychain.HardenedKeyStart + 0x626f6e64 // ASCII "bond"
+	// hdKeyPurposeMesh is the BIP-43 purpose field for our peer identity key
+	// on the Tatanka Mesh.
+	hdKeyPurposeMesh uint32 = hdkeychain.HardenedKeyStart + 0x6d657368 // ASCII "mesh"
 )
 
 // errorSet is a slice of orders with a prefix prepended to the Error output.
@@ -239,6 +242,11 @@ type PostBondForm struct {
 	// is interpreted as a filepath, or a []byte, which is interpreted as the
 	// file contents of the certificate.
 	Cert any `json:"cert"`
+
+	// PSBT requests that the bond's funding inputs be left unsigned for an
+	// external signer. The unsigned PSBT is returned in PostBondResult, and
+	// the bond is broadcast with BroadcastPSBT once signed.
+	PSBT bool `json:"psbt,omitempty"`
 }
 
 // Match represents a match on an order. An order may have many matches.
@@ -438,8 +446,7 @@ type FeeBreakdown struct {
 	Swap       uint64 `json:"swap"`
 	Redemption uint64 `json:"redemption"`
 	Funding    uint64 `json:"funding"` // split fees
-	// TODO: Refund is not yet being populated.
-	Refund uint64 `json:"refund"`
+	Refund     uint64 `json:"refund"`
 }
 
 // coreOrderFromTrade constructs an *Order from the supplied limit or market
@@ -527,6 +534,7 @@ func coreOrderFromTrade(ord order.Order, metaData *db.OrderMetaData) *Order {
 			Swap:       metaData.SwapFeesPaid,
 			Redemption: metaData.RedemptionFeesPaid,
 			Funding:    metaData.FundingFeesPaid,
+			Refund:     metaData.RefundFeesPaid,
 		},
 		FundingCoins:      fundingCoins,
 		AccelerationCoins: accelerationCoins,
@@ -1117,6 +1125,9 @@ func coinIDString(assetID uint32, coinID []byte) string {
 type PostBondResult struct {
 	BondID      string `json:"bondID"`
 	ReqConfirms uint16 `json:"reqConfirms"`
+	// PSBT is the base64-encoded unsigned bond PSBT if PostBondForm.PSBT
+	// was set.
+	PSBT string `json:"psbt,omitempty"`
 }
 
 // OrderFilter is almost the same as db.OrderFilter, except the Offset order ID
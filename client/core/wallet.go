@@ -589,6 +589,16 @@ func (w *xcWallet) MakeBondTx(ver uint16, amt, feeRate uint64, lockTime time.Tim
 	return bonder.MakeBondTx(ver, amt, feeRate, lockTime, priv, acctID)
 }
 
+// MakeBondPSBT is like MakeBondTx, but the bond's funding inputs are left
+// unsigned for an external signer, and the serialized PSBT is returned.
+func (w *xcWallet) MakeBondPSBT(ver uint16, amt, feeRate uint64, lockTime time.Time, priv *secp256k1.PrivateKey, acctID []byte) (*asset.Bond, []byte, func(), error) {
+	psbter, ok := w.Wallet.(asset.PSBTer)
+	if !ok {
+		return nil, nil, nil, errors.New("wallet does not support PSBTs")
+	}
+	return psbter.MakeBondPSBT(ver, amt, feeRate, lockTime, priv, acctID)
+}
+
 // BondsFeeBuffer gets the bonds fee buffer based on the provided fee rate.
 func (w *xcWallet) BondsFeeBuffer(feeRate uint64) uint64 {
 	bonder, ok := w.Wallet.(asset.Bonder)
@@ -0,0 +1,149 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/watchtower"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+)
+
+const (
+	watchtowerTick           = time.Minute
+	watchtowerRequestTimeout = 20 * time.Second
+)
+
+// watchtowerMatch is the data needed to register a match with a watchtower.
+type watchtowerMatch struct {
+	match      *matchTracker
+	swapCoinID dex.Bytes
+	contract   dex.Bytes
+	// counterSwap is set for the taker, who needs the maker's secret to
+	// redeem.
+	counterSwap *asset.AuditInfo
+}
+
+// watchtowerMatches returns the active matches that are ready to be
+// registered with watchtowers, but are not yet registered. A match is ready
+// once our swap is broadcast. The maker knows the secret, so only the refund
+// is registered. The taker also registers a redeem template for the maker's
+// swap, which the watchtower completes when the maker redeems the taker's
+// swap and so reveals the secret.
+func (t *trackedTrade) watchtowerMatches() []*watchtowerMatch {
+	if _, is := t.wallets.fromWallet.Wallet.(asset.Watchtowered); !is {
+		return nil
+	}
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	var wms []*watchtowerMatch
+	for _, match := range t.matches {
+		proof := &match.MetaData.Proof
+		if match.watchtowered || len(proof.RefundCoin) > 0 {
+			continue
+		}
+		wm := &watchtowerMatch{match: match, contract: proof.ContractData}
+		switch {
+		case match.Side == order.Maker && len(proof.MakerRedeem) == 0 &&
+			(match.Status == order.MakerSwapCast || match.Status == order.TakerSwapCast):
+			wm.swapCoinID = dex.Bytes(proof.MakerSwap)
+		case match.Side == order.Taker && match.Status == order.TakerSwapCast && match.counterSwap != nil:
+			wm.swapCoinID = dex.Bytes(proof.TakerSwap)
+			wm.counterSwap = match.counterSwap
+		default:
+			continue
+		}
+		wms = append(wms, wm)
+	}
+	return wms
+}
+
+// watchtowerRegistration creates the watchtower registration for the match.
+func (c *Core) watchtowerRegistration(t *trackedTrade, wm *watchtowerMatch) (*watchtower.Registration, error) {
+	fromWallet, toWallet := t.wallets.fromWallet, t.wallets.toWallet
+	refund, err := fromWallet.Wallet.(asset.Watchtowered).SignedRefund(wm.swapCoinID, wm.contract, c.feeSuggestionAny(fromWallet.AssetID))
+	if err != nil {
+		return nil, fmt.Errorf("error signing refund: %w", err)
+	}
+	reg := &watchtower.Registration{
+		AssetID:    fromWallet.AssetID,
+		SwapCoinID: wm.swapCoinID,
+		Refund:     refund,
+	}
+	if wm.counterSwap == nil {
+		return reg, nil
+	}
+	redeemer, is := toWallet.Wallet.(asset.Watchtowered)
+	if !is {
+		c.log.Warnf("%s wallet cannot create a redeem template for match %s. Registering refund only.",
+			unbip(toWallet.AssetID), wm.match)
+		return reg, nil
+	}
+	tx, offset, err := redeemer.RedeemTemplate(wm.counterSwap, c.feeSuggestionAny(toWallet.AssetID))
+	if err != nil {
+		return nil, fmt.Errorf("error creating redeem template: %w", err)
+	}
+	reg.Redeem, err = watchtower.SealRedeem(toWallet.AssetID, tx, offset, wm.counterSwap.SecretHash)
+	if err != nil {
+		return nil, fmt.Errorf("error sealing redeem template: %w", err)
+	}
+	return reg, nil
+}
+
+// registerWithWatchtowers periodically registers active matches with the
+// configured watchtowers. A match is registered once, though a failed
+// registration is retried until it succeeds with every watchtower. Since
+// registration state is not stored, matches are registered again after a
+// restart. The watchtower does not accept replacements, so a match it
+// already has is considered registered.
+func (c *Core) registerWithWatchtowers(ctx context.Context) {
+	tick := time.NewTicker(watchtowerTick)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+			return
+		}
+		for _, dc := range c.dexConnections() {
+			for _, t := range dc.trackedTrades() {
+				c.registerTradeWithWatchtowers(ctx, t)
+			}
+		}
+	}
+}
+
+func (c *Core) registerTradeWithWatchtowers(ctx context.Context, t *trackedTrade) {
+	for _, wm := range t.watchtowerMatches() {
+		reg, err := c.watchtowerRegistration(t, wm)
+		if err != nil {
+			// Likely a locked wallet. Try again next tick.
+			c.log.Debugf("Unable to create watchtower registration for match %s: %v", wm.match, err)
+			continue
+		}
+		registered := true
+		for _, url := range c.cfg.Watchtowers {
+			reqCtx, cancel := context.WithTimeout(ctx, watchtowerRequestTimeout)
+			err := watchtower.Register(reqCtx, url, reg)
+			cancel()
+			if err != nil && !errors.Is(err, watchtower.ErrAlreadyRegistered) {
+				c.log.Warnf("Error registering match %s with watchtower %s: %v", wm.match, url, err)
+				registered = false
+			}
+		}
+		if !registered {
+			continue
+		}
+		c.log.Infof("Registered match %s with %d watchtower(s). Redeem template included = %t",
+			wm.match, len(c.cfg.Watchtowers), reg.Redeem != nil)
+		t.mtx.Lock()
+		wm.match.watchtowered = true
+		t.mtx.Unlock()
+	}
+}
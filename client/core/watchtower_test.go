@@ -0,0 +1,129 @@
+//go:build !harness && !botlive
+
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/client/watchtower"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/order"
+	ordertest "decred.org/dcrdex/dex/order/test"
+)
+
+type TWatchtoweredWallet struct {
+	*TXCWallet
+	refund    dex.Bytes
+	refundErr error
+	template  dex.Bytes
+}
+
+func (w *TWatchtoweredWallet) SignedRefund(coinID, contract dex.Bytes, feeRate uint64) (dex.Bytes, error) {
+	return w.refund, w.refundErr
+}
+
+func (w *TWatchtoweredWallet) RedeemTemplate(swap *asset.AuditInfo, feeRate uint64) (dex.Bytes, int, error) {
+	return w.template, 4, nil
+}
+
+func TestRegisterWithWatchtowers(t *testing.T) {
+	rig := newTestRig()
+	defer rig.shutdown()
+	tCore := rig.core
+
+	var regs []*watchtower.Registration
+	var fail bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			http.Error(w, "nope", http.StatusBadRequest)
+			return
+		}
+		reg := new(watchtower.Registration)
+		if err := json.NewDecoder(r.Body).Decode(reg); err != nil {
+			t.Errorf("error decoding registration: %v", err)
+		}
+		regs = append(regs, reg)
+	}))
+	defer srv.Close()
+	tCore.cfg.Watchtowers = []string{srv.URL}
+
+	fromWallet, tFromWallet := newTWallet(tUTXOAssetA.ID)
+	from := &TWatchtoweredWallet{TXCWallet: tFromWallet, refund: encode.RandomBytes(100)}
+	fromWallet.Wallet = from
+	toWallet, tToWallet := newTWallet(tUTXOAssetB.ID)
+	toWallet.Wallet = &TWatchtoweredWallet{TXCWallet: tToWallet, template: encode.RandomBytes(100)}
+
+	secretHash := sha256.Sum256(encode.RandomBytes(32))
+	newMatch := func(side order.MatchSide, status order.MatchStatus) *matchTracker {
+		m := &matchTracker{MetaMatch: db.MetaMatch{
+			UserMatch: &order.UserMatch{MatchID: ordertest.RandomMatchID(), Side: side, Status: status},
+			MetaData:  &db.MatchMetaData{},
+		}}
+		m.MetaData.Proof.MakerSwap = encode.RandomBytes(36)
+		m.MetaData.Proof.TakerSwap = encode.RandomBytes(36)
+		m.MetaData.Proof.ContractData = encode.RandomBytes(97)
+		if side == order.Taker {
+			m.counterSwap = &asset.AuditInfo{SecretHash: secretHash[:]}
+		}
+		return m
+	}
+	maker := newMatch(order.Maker, order.MakerSwapCast)
+	taker := newMatch(order.Taker, order.TakerSwapCast)
+	unswapped := newMatch(order.Taker, order.MakerSwapCast)
+	redeemed := newMatch(order.Maker, order.MakerRedeemed)
+	tracker := &trackedTrade{
+		wallets: &walletSet{fromWallet: fromWallet, toWallet: toWallet},
+		matches: map[order.MatchID]*matchTracker{
+			maker.MatchID:     maker,
+			taker.MatchID:     taker,
+			unswapped.MatchID: unswapped,
+			redeemed.MatchID:  redeemed,
+		},
+	}
+
+	// Wallet errors and watchtower errors are retried.
+	from.refundErr = tErr
+	tCore.registerTradeWithWatchtowers(tCtx, tracker)
+	from.refundErr = nil
+	fail = true
+	tCore.registerTradeWithWatchtowers(tCtx, tracker)
+	if len(regs) != 0 || maker.watchtowered || taker.watchtowered {
+		t.Fatalf("registered after errors")
+	}
+	fail = false
+
+	tCore.registerTradeWithWatchtowers(tCtx, tracker)
+	if len(regs) != 2 || !maker.watchtowered || !taker.watchtowered || unswapped.watchtowered || redeemed.watchtowered {
+		t.Fatalf("wrong registrations. %d registered", len(regs))
+	}
+	for _, reg := range regs {
+		if reg.AssetID != tUTXOAssetA.ID || !reg.Refund.Equal(from.refund) {
+			t.Fatalf("wrong registration %+v", reg)
+		}
+		switch {
+		case reg.SwapCoinID.Equal(maker.MetaData.Proof.MakerSwap):
+			if reg.Redeem != nil {
+				t.Fatalf("maker registered a redeem template")
+			}
+		case reg.SwapCoinID.Equal(taker.MetaData.Proof.TakerSwap):
+			if reg.Redeem == nil || reg.Redeem.AssetID != tUTXOAssetB.ID {
+				t.Fatalf("wrong taker redeem template %+v", reg.Redeem)
+			}
+		default:
+			t.Fatalf("unknown swap coin registered")
+		}
+	}
+
+	// Registered matches are not registered again.
+	tCore.registerTradeWithWatchtowers(tCtx, tracker)
+	if len(regs) != 2 {
+		t.Fatalf("matches registered twice")
+	}
+}
@@ -69,6 +69,11 @@ var (
 	notesBucket           = []byte("notes")
 	pokesBucket           = []byte("pokes")
 	credentialsBucket     = []byte("credentials")
+	condOrdersBucket      = []byte("conditionalOrders")
+	routedOrdersBucket    = []byte("routedOrders")
+	marketAlertsBucket    = []byte("marketAlerts")
+	meshBondsBucket       = []byte("meshBonds")
+	fiatRatesBucket       = []byte("fiatRateHistory")
 
 	// value keys
 	versionKey = []byte("version")
@@ -103,6 +108,7 @@ var (
 	redeemMaxFeeRateKey   = []byte("redeemMaxFeeRate")
 	redemptionFeesKey     = []byte("redeemFees")
 	fundingFeesKey        = []byte("fundingFees")
+	refundFeesKey         = []byte("refundFees")
 	accelerationsKey      = []byte("accelerations")
 	typeKey               = []byte("type")
 	seedGenTimeKey        = []byte("seedGenTime")
@@ -181,7 +187,9 @@ func NewDB(dbPath string, logger dex.Logger, opts ...Opts) (dexdb.DB, error) {
 		activeOrdersBucket, archivedOrdersBucket,
 		activeMatchesBucket, archivedMatchesBucket,
 		walletsBucket, notesBucket, credentialsBucket,
-		botProgramsBucket, pokesBucket,
+		botProgramsBucket, pokesBucket, condOrdersBucket,
+		routedOrdersBucket, marketAlertsBucket,
+		meshBondsBucket, fiatRatesBucket,
 	}); err != nil {
 		return nil, err
 	}
@@ -1245,6 +1253,11 @@ func decodeOrderBucket(oid []byte, oBkt *bbolt.Bucket) (*dexdb.MetaOrder, error)
 		fundingFeesPaid = intCoder.Uint64(fundingFeesB)
 	}
 
+	var refundFeesPaid uint64
+	if refundFeesB := oBkt.Get(refundFeesKey); len(refundFeesB) == 8 {
+		refundFeesPaid = intCoder.Uint64(refundFeesB)
+	}
+
 	return &dexdb.MetaOrder{
 		MetaData: &dexdb.OrderMetaData{
 			Proof:              *proof,
@@ -1266,6 +1279,7 @@ func decodeOrderBucket(oid []byte, oBkt *bbolt.Bucket) (*dexdb.MetaOrder, error)
 			RefundReserves:     refundReserves,
 			AccelerationCoins:  accelerationCoinIDs,
 			FundingFeesPaid:    fundingFeesPaid,
+			RefundFeesPaid:     refundFeesPaid,
 		},
 		Order: ord,
 	}, nil
@@ -1354,6 +1368,7 @@ func updateOrderMetaData(bkt *bbolt.Bucket, md *dexdb.OrderMetaData) error {
 		put(refundReservesKey, uint64Bytes(md.RefundReserves)).
 		put(accelerationsKey, accelerationsB).
 		put(fundingFeesKey, uint64Bytes(md.FundingFeesPaid)).
+		put(refundFeesKey, uint64Bytes(md.RefundFeesPaid)).
 		err()
 }
 
@@ -2423,6 +2438,188 @@ func (db *BoltDB) DisabledRateSources() (disabledSources []string, err error) {
 	})
 }
 
+// UpdateConditionalOrder saves the conditional order, overwriting any existing
+// entry with the same ID.
+func (db *BoltDB) UpdateConditionalOrder(ord *dexdb.ConditionalOrder) error {
+	if len(ord.ID) == 0 {
+		return fmt.Errorf("no conditional order ID")
+	}
+	b, err := json.Marshal(ord)
+	if err != nil {
+		return fmt.Errorf("JSON marshal error: %w", err)
+	}
+	return db.withBucket(condOrdersBucket, db.Update, func(bkt *bbolt.Bucket) error {
+		return bkt.Put(ord.ID, b)
+	})
+}
+
+// ConditionalOrders retrieves all conditional orders, sorted by creation time.
+func (db *BoltDB) ConditionalOrders() (ords []*dexdb.ConditionalOrder, _ error) {
+	err := db.withBucket(condOrdersBucket, db.View, func(bkt *bbolt.Bucket) error {
+		return bkt.ForEach(func(k, v []byte) error {
+			ord := new(dexdb.ConditionalOrder)
+			if err := json.Unmarshal(v, ord); err != nil {
+				return fmt.Errorf("error decoding conditional order %x: %w", k, err)
+			}
+			ords = append(ords, ord)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ords, func(i, j int) bool { return ords[i].Stamp < ords[j].Stamp })
+	return ords, nil
+}
+
+// UpdateRoutedOrder saves the routed order, overwriting any existing entry
+// with the same ID.
+func (db *BoltDB) UpdateRoutedOrder(ord *dexdb.RoutedOrder) error {
+	if len(ord.ID) == 0 {
+		return fmt.Errorf("no routed order ID")
+	}
+	b, err := json.Marshal(ord)
+	if err != nil {
+		return fmt.Errorf("JSON marshal error: %w", err)
+	}
+	return db.withBucket(routedOrdersBucket, db.Update, func(bkt *bbolt.Bucket) error {
+		return bkt.Put(ord.ID, b)
+	})
+}
+
+// RoutedOrders retrieves all routed orders, sorted by creation time.
+func (db *BoltDB) RoutedOrders() (ords []*dexdb.RoutedOrder, _ error) {
+	err := db.withBucket(routedOrdersBucket, db.View, func(bkt *bbolt.Bucket) error {
+		return bkt.ForEach(func(k, v []byte) error {
+			ord := new(dexdb.RoutedOrder)
+			if err := json.Unmarshal(v, ord); err != nil {
+				return fmt.Errorf("error decoding routed order %x: %w", k, err)
+			}
+			ords = append(ords, ord)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ords, func(i, j int) bool { return ords[i].Stamp < ords[j].Stamp })
+	return ords, nil
+}
+
+// UpdateMarketAlert saves the market alert, overwriting any existing entry
+// with the same ID.
+func (db *BoltDB) UpdateMarketAlert(alert *dexdb.MarketAlert) error {
+	if len(alert.ID) == 0 {
+		return fmt.Errorf("no market alert ID")
+	}
+	b, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("JSON marshal error: %w", err)
+	}
+	return db.withBucket(marketAlertsBucket, db.Update, func(bkt *bbolt.Bucket) error {
+		return bkt.Put(alert.ID, b)
+	})
+}
+
+// MarketAlerts retrieves all market alerts, sorted by creation time.
+func (db *BoltDB) MarketAlerts() (alerts []*dexdb.MarketAlert, _ error) {
+	err := db.withBucket(marketAlertsBucket, db.View, func(bkt *bbolt.Bucket) error {
+		return bkt.ForEach(func(k, v []byte) error {
+			alert := new(dexdb.MarketAlert)
+			if err := json.Unmarshal(v, alert); err != nil {
+				return fmt.Errorf("error decoding market alert %x: %w", k, err)
+			}
+			alerts = append(alerts, alert)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Stamp < alerts[j].Stamp })
+	return alerts, nil
+}
+
+// DeleteMarketAlert deletes the market alert with the ID.
+func (db *BoltDB) DeleteMarketAlert(id []byte) error {
+	return db.withBucket(marketAlertsBucket, db.Update, func(bkt *bbolt.Bucket) error {
+		if bkt.Get(id) == nil {
+			return fmt.Errorf("market alert %x not found", id)
+		}
+		return bkt.Delete(id)
+	})
+}
+
+// UpdateMeshBond saves a bond posted to the Tatanka Mesh, overwriting any
+// existing entry for the same bond.
+func (db *BoltDB) UpdateMeshBond(bond *dexdb.Bond) error {
+	b, err := json.Marshal(bond)
+	if err != nil {
+		return fmt.Errorf("JSON marshal error: %w", err)
+	}
+	return db.withBucket(meshBondsBucket, db.Update, func(bkt *bbolt.Bucket) error {
+		return bkt.Put(bond.UniqueID(), b)
+	})
+}
+
+// MeshBonds retrieves all bonds posted to the Tatanka Mesh, sorted by lock
+// time.
+func (db *BoltDB) MeshBonds() (bonds []*dexdb.Bond, _ error) {
+	err := db.withBucket(meshBondsBucket, db.View, func(bkt *bbolt.Bucket) error {
+		return bkt.ForEach(func(k, v []byte) error {
+			bond := new(dexdb.Bond)
+			if err := json.Unmarshal(v, bond); err != nil {
+				return fmt.Errorf("error decoding mesh bond %x: %w", k, err)
+			}
+			bonds = append(bonds, bond)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(bonds, func(i, j int) bool { return bonds[i].LockTime < bonds[j].LockTime })
+	return bonds, nil
+}
+
+// fiatRateInterval is the period of the stored fiat rate snapshots.
+const fiatRateInterval = uint64(time.Hour / time.Millisecond)
+
+// StoreFiatRates stores a snapshot of fiat exchange rates. Snapshots are
+// keyed by the hour, so a later snapshot in the same hour replaces an earlier
+// one.
+func (db *BoltDB) StoreFiatRates(snap *dexdb.FiatRateSnapshot) error {
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("JSON marshal error: %w", err)
+	}
+	return db.withBucket(fiatRatesBucket, db.Update, func(bkt *bbolt.Bucket) error {
+		return bkt.Put(uint64Bytes(snap.Stamp/fiatRateInterval), b)
+	})
+}
+
+// FiatRateHistory retrieves the fiat rate snapshots with stamps in the range
+// [from, to], sorted by time.
+func (db *BoltDB) FiatRateHistory(from, to uint64) (snaps []*dexdb.FiatRateSnapshot, _ error) {
+	err := db.withBucket(fiatRatesBucket, db.View, func(bkt *bbolt.Bucket) error {
+		c := bkt.Cursor()
+		for k, v := c.Seek(uint64Bytes(from / fiatRateInterval)); k != nil; k, v = c.Next() {
+			snap := new(dexdb.FiatRateSnapshot)
+			if err := json.Unmarshal(v, snap); err != nil {
+				return fmt.Errorf("error decoding fiat rate snapshot %x: %w", k, err)
+			}
+			if snap.Stamp > to {
+				break
+			}
+			if snap.Stamp >= from {
+				snaps = append(snaps, snap)
+			}
+		}
+		return nil
+	})
+	return snaps, err
+}
+
 // SetLanguage stores the language.
 func (db *BoltDB) SetLanguage(lang string) error {
 	return db.Update(func(dbTx *bbolt.Tx) error {
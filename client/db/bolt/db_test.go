@@ -5,9 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"decred.org/dcrdex/client/db"
 	dbtest "decred.org/dcrdex/client/db/test"
 	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
 	"decred.org/dcrdex/dex/order"
 	ordertest "decred.org/dcrdex/dex/order/test"
 	"go.etcd.io/bbolt"
@@ -470,6 +473,7 @@ func TestOrders(t *testing.T) {
 				Proof:              db.OrderProof{DEXSig: randBytes(73)},
 				SwapFeesPaid:       rand.Uint64(),
 				RedemptionFeesPaid: rand.Uint64(),
+				RefundFeesPaid:     rand.Uint64(),
 				MaxFeeRate:         rand.Uint64(),
 			},
 			Order: ord,
@@ -508,6 +512,9 @@ func TestOrders(t *testing.T) {
 	if firstOrd.MetaData.RedemptionFeesPaid != mord.MetaData.RedemptionFeesPaid {
 		t.Fatalf("wrong RedemptionFeesPaid. wanted %d, got %d", firstOrd.MetaData.RedemptionFeesPaid, mord.MetaData.RedemptionFeesPaid)
 	}
+	if firstOrd.MetaData.RefundFeesPaid != mord.MetaData.RefundFeesPaid {
+		t.Fatalf("wrong RefundFeesPaid. wanted %d, got %d", firstOrd.MetaData.RefundFeesPaid, mord.MetaData.RefundFeesPaid)
+	}
 	if firstOrd.MetaData.MaxFeeRate != mord.MetaData.MaxFeeRate {
 		t.Fatalf("wrong MaxFeeRate. wanted %d, got %d", firstOrd.MetaData.MaxFeeRate, mord.MetaData.MaxFeeRate)
 	}
@@ -1613,3 +1620,241 @@ func TestPokes(t *testing.T) {
 		t.Fatal("Result from second LoadPokes wasn't empty")
 	}
 }
+
+func TestConditionalOrders(t *testing.T) {
+	boltdb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	newOrd := func(stamp uint64) *db.ConditionalOrder {
+		return &db.ConditionalOrder{
+			ID:            encode.RandomBytes(8),
+			Kind:          db.StopLoss,
+			Host:          "somedex.tld:7232",
+			Sell:          true,
+			Base:          42,
+			Quote:         0,
+			Qty:           1e8,
+			Options:       map[string]string{"a": "b"},
+			TriggerSource: db.TriggerMidGap,
+			TriggerRate:   5e7,
+			Stamp:         stamp,
+		}
+	}
+
+	ords := []*db.ConditionalOrder{newOrd(3), newOrd(1), newOrd(2)}
+	ords[1].OCO, ords[2].OCO = ords[2].ID, ords[1].ID
+	for _, ord := range ords {
+		if err := boltdb.UpdateConditionalOrder(ord); err != nil {
+			t.Fatalf("UpdateConditionalOrder error: %v", err)
+		}
+	}
+
+	if err := boltdb.UpdateConditionalOrder(&db.ConditionalOrder{}); err == nil {
+		t.Fatalf("no error for conditional order without an ID")
+	}
+
+	// Update one.
+	ords[1].Status = db.ConditionalOrderTriggered
+	ords[1].OrderID = encode.RandomBytes(32)
+	if err := boltdb.UpdateConditionalOrder(ords[1]); err != nil {
+		t.Fatalf("UpdateConditionalOrder (update) error: %v", err)
+	}
+
+	reOrds, err := boltdb.ConditionalOrders()
+	if err != nil {
+		t.Fatalf("ConditionalOrders error: %v", err)
+	}
+	if len(reOrds) != len(ords) {
+		t.Fatalf("expected %d conditional orders, got %d", len(ords), len(reOrds))
+	}
+	// Sorted by stamp.
+	for i, ord := range []*db.ConditionalOrder{ords[1], ords[2], ords[0]} {
+		if !reflect.DeepEqual(ord, reOrds[i]) {
+			t.Fatalf("conditional order %d mismatch: wanted %+v, got %+v", i, ord, reOrds[i])
+		}
+	}
+}
+
+func TestRoutedOrders(t *testing.T) {
+	boltdb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	ord := &db.RoutedOrder{
+		ID:    encode.RandomBytes(8),
+		Sell:  true,
+		Base:  42,
+		Quote: 0,
+		Qty:   3e8,
+		Rate:  1e6,
+		Stamp: 2,
+		Children: []*db.RoutedChild{
+			{Host: "dex1.tld", Qty: 2e8, Rate: 1e6, OrderID: encode.RandomBytes(32)},
+			{Host: "dex2.tld", Qty: 1e8, Rate: 1e6, Error: "insufficient funds"},
+		},
+	}
+	older := &db.RoutedOrder{ID: encode.RandomBytes(8), Stamp: 1}
+	for _, o := range []*db.RoutedOrder{ord, older} {
+		if err := boltdb.UpdateRoutedOrder(o); err != nil {
+			t.Fatalf("UpdateRoutedOrder error: %v", err)
+		}
+	}
+	if err := boltdb.UpdateRoutedOrder(&db.RoutedOrder{}); err == nil {
+		t.Fatalf("no error for routed order without an ID")
+	}
+
+	reOrds, err := boltdb.RoutedOrders()
+	if err != nil {
+		t.Fatalf("RoutedOrders error: %v", err)
+	}
+	if len(reOrds) != 2 {
+		t.Fatalf("expected 2 routed orders, got %d", len(reOrds))
+	}
+	if !reflect.DeepEqual(older, reOrds[0]) || !reflect.DeepEqual(ord, reOrds[1]) {
+		t.Fatalf("routed orders mismatch")
+	}
+}
+
+func TestMarketAlerts(t *testing.T) {
+	boltdb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	alert := &db.MarketAlert{
+		ID:     encode.RandomBytes(8),
+		Kind:   db.AlertPrice,
+		Host:   "dex.tld",
+		Base:   42,
+		Quote:  0,
+		Rate:   1e6,
+		Above:  true,
+		Repeat: true,
+		Stamp:  2,
+	}
+	older := &db.MarketAlert{ID: encode.RandomBytes(8), Kind: db.AlertFiat, Base: 42, Percent: 5, RefFiatRate: 20, Stamp: 1}
+	for _, a := range []*db.MarketAlert{alert, older} {
+		if err := boltdb.UpdateMarketAlert(a); err != nil {
+			t.Fatalf("UpdateMarketAlert error: %v", err)
+		}
+	}
+	if err := boltdb.UpdateMarketAlert(&db.MarketAlert{}); err == nil {
+		t.Fatalf("no error for market alert without an ID")
+	}
+
+	// Update one.
+	older.Status = db.MarketAlertTriggered
+	older.FiredStamp = 3
+	if err := boltdb.UpdateMarketAlert(older); err != nil {
+		t.Fatalf("UpdateMarketAlert (update) error: %v", err)
+	}
+
+	alerts, err := boltdb.MarketAlerts()
+	if err != nil {
+		t.Fatalf("MarketAlerts error: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 market alerts, got %d", len(alerts))
+	}
+	if !reflect.DeepEqual(older, alerts[0]) || !reflect.DeepEqual(alert, alerts[1]) {
+		t.Fatalf("market alerts mismatch")
+	}
+
+	if err := boltdb.DeleteMarketAlert(older.ID); err != nil {
+		t.Fatalf("DeleteMarketAlert error: %v", err)
+	}
+	if err := boltdb.DeleteMarketAlert(older.ID); err == nil {
+		t.Fatalf("no error deleting unknown market alert")
+	}
+	if alerts, _ = boltdb.MarketAlerts(); len(alerts) != 1 {
+		t.Fatalf("expected 1 market alert after deletion, got %d", len(alerts))
+	}
+}
+
+func TestMeshBonds(t *testing.T) {
+	boltdb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	bond := &db.Bond{
+		Version:    0,
+		AssetID:    42,
+		CoinID:     encode.RandomBytes(36),
+		UnsignedTx: encode.RandomBytes(200),
+		SignedTx:   encode.RandomBytes(200),
+		Data:       encode.RandomBytes(50),
+		Amount:     1e8,
+		LockTime:   2,
+		KeyIndex:   3,
+		RefundTx:   encode.RandomBytes(150),
+		Strength:   1,
+	}
+	earlier := &db.Bond{
+		AssetID:    0,
+		CoinID:     encode.RandomBytes(36),
+		UnsignedTx: encode.RandomBytes(200),
+		SignedTx:   encode.RandomBytes(200),
+		Data:       encode.RandomBytes(50),
+		Amount:     1e7,
+		LockTime:   1,
+		RefundTx:   encode.RandomBytes(150),
+		Strength:   2,
+	}
+	for _, b := range []*db.Bond{bond, earlier} {
+		if err := boltdb.UpdateMeshBond(b); err != nil {
+			t.Fatalf("UpdateMeshBond error: %v", err)
+		}
+	}
+
+	// Update one.
+	earlier.Refunded = true
+	if err := boltdb.UpdateMeshBond(earlier); err != nil {
+		t.Fatalf("UpdateMeshBond (update) error: %v", err)
+	}
+
+	bonds, err := boltdb.MeshBonds()
+	if err != nil {
+		t.Fatalf("MeshBonds error: %v", err)
+	}
+	if len(bonds) != 2 {
+		t.Fatalf("expected 2 mesh bonds, got %d", len(bonds))
+	}
+	if !reflect.DeepEqual(earlier, bonds[0]) || !reflect.DeepEqual(bond, bonds[1]) {
+		t.Fatalf("mesh bonds mismatch")
+	}
+}
+
+func TestFiatRateHistory(t *testing.T) {
+	boltdb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	const hour = uint64(time.Hour / time.Millisecond)
+	base := uint64(1700000000000) / hour * hour
+	snaps := []*db.FiatRateSnapshot{
+		{Stamp: base + 10, Rates: map[uint32]float64{42: 10}},
+		// Replaces the first snapshot.
+		{Stamp: base + 20, Rates: map[uint32]float64{42: 11}},
+		{Stamp: base + hour, Rates: map[uint32]float64{42: 12, 0: 40000}},
+		{Stamp: base + 3*hour, Rates: map[uint32]float64{42: 13}},
+	}
+	for _, snap := range snaps {
+		if err := boltdb.StoreFiatRates(snap); err != nil {
+			t.Fatalf("StoreFiatRates error: %v", err)
+		}
+	}
+
+	history, err := boltdb.FiatRateHistory(0, base+2*hour)
+	if err != nil {
+		t.Fatalf("FiatRateHistory error: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(history))
+	}
+	if !reflect.DeepEqual(history[0], snaps[1]) || !reflect.DeepEqual(history[1], snaps[2]) {
+		t.Fatalf("wrong snapshots")
+	}
+
+	history, err = boltdb.FiatRateHistory(base+30, math.MaxUint64)
+	if err != nil {
+		t.Fatalf("FiatRateHistory error: %v", err)
+	}
+	if len(history) != 2 || history[0].Stamp != base+hour {
+		t.Fatalf("wrong snapshots for range")
+	}
+}
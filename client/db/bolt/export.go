@@ -0,0 +1,86 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package bolt
+
+import (
+	"errors"
+	"fmt"
+
+	dexdb "decred.org/dcrdex/client/db"
+	"go.etcd.io/bbolt"
+)
+
+// ForEachOrder calls f for every order in the database, active and archived,
+// along with the order's last update stamp. ForEachOrder is for exporting the
+// database, e.g. when migrating to another backend.
+func (db *BoltDB) ForEachOrder(f func(ord *dexdb.MetaOrder, stamp uint64) error) error {
+	return db.ordersView(func(ob, archivedOB *bbolt.Bucket) error {
+		for _, master := range []*bbolt.Bucket{ob, archivedOB} {
+			err := master.ForEach(func(oid, _ []byte) error {
+				oBkt := master.Bucket(oid)
+				if oBkt == nil {
+					return fmt.Errorf("order %x bucket is not a bucket", oid)
+				}
+				o, err := decodeOrderBucket(oid, oBkt)
+				if err != nil {
+					return err
+				}
+				var stamp uint64
+				if timeB := oBkt.Get(updateTimeKey); len(timeB) == 8 {
+					stamp = intCoder.Uint64(timeB)
+				}
+				return f(o, stamp)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ForEachMatch calls f for every match in the database, including cancel
+// matches and archived matches. active indicates whether the match is stored
+// as an active match.
+func (db *BoltDB) ForEachMatch(f func(m *dexdb.MetaMatch, active bool) error) error {
+	return db.matchesView(func(mb, archivedMB *bbolt.Bucket) error {
+		for _, master := range []*bbolt.Bucket{mb, archivedMB} {
+			active := master == mb
+			err := master.ForEach(func(k, _ []byte) error {
+				mBkt := master.Bucket(k)
+				if mBkt == nil {
+					return fmt.Errorf("match %x bucket is not a bucket", k)
+				}
+				m, err := loadMatchBucket(mBkt, false)
+				if err != nil {
+					return fmt.Errorf("loading match %x bucket: %w", k, err)
+				}
+				return f(m, active)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// BondKeyIndexes returns the next bond key index for every asset that has
+// one.
+func (db *BoltDB) BondKeyIndexes() (map[uint32]uint32, error) {
+	idxs := make(map[uint32]uint32)
+	return idxs, db.View(func(tx *bbolt.Tx) error {
+		bkt := tx.Bucket(bondIndexesBucket)
+		if bkt == nil {
+			return errors.New("no bond indexes bucket")
+		}
+		return bkt.ForEach(func(k, v []byte) error {
+			if len(k) != 4 || len(v) != 4 {
+				return fmt.Errorf("invalid bond index entry %x: %x", k, v)
+			}
+			idxs[intCoder.Uint32(k)] = intCoder.Uint32(v)
+			return nil
+		})
+	})
+}
@@ -151,6 +151,37 @@ type DB interface {
 	// SaveDisabledRateSources saves disabled fiat rate sources in the database.
 	// A source name must not contain a comma.
 	SaveDisabledRateSources(disabledSources []string) error
+	// UpdateConditionalOrder saves the conditional order. Any existing entry
+	// with the same ID will be overwritten.
+	UpdateConditionalOrder(*ConditionalOrder) error
+	// ConditionalOrders retrieves all conditional orders, sorted by creation
+	// time.
+	ConditionalOrders() ([]*ConditionalOrder, error)
+	// UpdateRoutedOrder saves the routed order. Any existing entry with the
+	// same ID will be overwritten.
+	UpdateRoutedOrder(*RoutedOrder) error
+	// RoutedOrders retrieves all routed orders, sorted by creation time.
+	RoutedOrders() ([]*RoutedOrder, error)
+	// UpdateMarketAlert saves the market alert. Any existing entry with the
+	// same ID will be overwritten.
+	UpdateMarketAlert(*MarketAlert) error
+	// MarketAlerts retrieves all market alerts, sorted by creation time.
+	MarketAlerts() ([]*MarketAlert, error)
+	// DeleteMarketAlert deletes the market alert with the ID.
+	DeleteMarketAlert(id []byte) error
+	// UpdateMeshBond saves a bond posted to the Tatanka Mesh. Any existing
+	// entry for the same bond will be overwritten.
+	UpdateMeshBond(*Bond) error
+	// MeshBonds retrieves all bonds posted to the Tatanka Mesh, sorted by
+	// lock time.
+	MeshBonds() ([]*Bond, error)
+	// StoreFiatRates stores a snapshot of fiat exchange rates. Only one
+	// snapshot is kept per hour. A later snapshot in the same hour replaces
+	// an earlier one.
+	StoreFiatRates(*FiatRateSnapshot) error
+	// FiatRateHistory retrieves the stored fiat rate snapshots with stamps in
+	// the range [from, to], sorted by time.
+	FiatRateHistory(from, to uint64) ([]*FiatRateSnapshot, error)
 	// SetLanguage stores the user's chosen language.
 	SetLanguage(lang string) error
 	// Language gets the language stored with SetLanguage.
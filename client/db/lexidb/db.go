@@ -0,0 +1,1647 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package lexidb is a client database backend built on the indexed tables of
+// the lexi DB. Orders and matches are indexed by host, market, activity and
+// time, so that queries don't need to scan the entire history.
+package lexidb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	dexdb "decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encrypt"
+	"decred.org/dcrdex/dex/lexi"
+	"decred.org/dcrdex/dex/order"
+	"github.com/dgraph-io/badger"
+)
+
+// DBVersion is the current version of the database schema.
+const DBVersion = 0
+
+var (
+	// meta table keys
+	versionKey            = []byte("version")
+	credentialsKey        = []byte("credentials")
+	seedGenTimeKey        = []byte("seedGenTime")
+	pokesKey              = []byte("pokes")
+	disabledRateSourceKey = []byte("disabledRateSources")
+	langKey               = []byte("lang")
+
+	backupDir = "backup"
+)
+
+// Opts is a set of options for the DB.
+type Opts struct {
+	BackupOnShutdown bool // default is true
+	// BoltPath is the path of a legacy bolt database. If there is no lexi
+	// database yet, the bolt database's data is migrated to the new database.
+	// The bolt database file is left in place.
+	BoltPath string
+}
+
+var defaultOpts = Opts{
+	BackupOnShutdown: true,
+}
+
+// LexiDB is a lexi-based database backend for Bison Wallet. LexiDB satisfies
+// the db.DB interface defined at decred.org/dcrdex/client/db.
+type LexiDB struct {
+	*lexi.DB
+	path string
+	opts Opts
+	log  dex.Logger
+
+	meta        *lexi.Table
+	accounts    *lexi.Table
+	bonds       *lexi.Table
+	bondHostIdx *lexi.Index
+	bondIndexes *lexi.Table
+	wallets     *lexi.Table
+
+	orders           *lexi.Table
+	orderStampIdx    *lexi.Index
+	orderHostIdx     *lexi.Index
+	orderMarketIdx   *lexi.Index
+	orderActiveIdx   *lexi.Index
+	orderInactiveIdx *lexi.Index
+
+	matches           *lexi.Table
+	matchOrderIdx     *lexi.Index
+	matchActiveIdx    *lexi.Index
+	matchInactiveIdx  *lexi.Index
+	notes             *lexi.Table
+	noteStampIdx      *lexi.Index
+	condOrders        *lexi.Table
+	routedOrders      *lexi.Table
+	marketAlerts      *lexi.Table
+	meshBonds         *lexi.Table
+	fiatRates         *lexi.Table
+	fiatRatesStampIdx *lexi.Index
+}
+
+// Check that LexiDB satisfies the db.DB interface.
+var _ dexdb.DB = (*LexiDB)(nil)
+
+// NewDB is a constructor for a *LexiDB. The database is stored in the
+// directory at dbPath.
+func NewDB(dbPath string, logger dex.Logger, opts ...Opts) (dexdb.DB, error) {
+	o := defaultOpts
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	_, err := os.Stat(dbPath)
+	isNew := errors.Is(err, fs.ErrNotExist)
+	if isNew && o.BoltPath != "" {
+		if _, err := os.Stat(o.BoltPath); err == nil {
+			if err := migrateBolt(o.BoltPath, dbPath, logger); err != nil {
+				return nil, fmt.Errorf("error migrating bolt database: %w", err)
+			}
+			isNew = false
+		}
+	}
+
+	db, err := openDB(dbPath, logger)
+	if err != nil {
+		return nil, err
+	}
+	db.opts = o
+
+	if isNew {
+		if err := db.meta.Set(versionKey, uint32Bytes(DBVersion)); err != nil {
+			return nil, fmt.Errorf("error setting db version: %w", err)
+		}
+		db.log.Infof("Created and started database (version = %d, dir = %s)", DBVersion, dbPath)
+		return db, nil
+	}
+
+	db.log.Infof("Started database (version = %d, dir = %s)", DBVersion, dbPath)
+
+	return db, nil
+}
+
+// openDB opens the database and prepares the tables and indexes.
+func openDB(dbPath string, logger dex.Logger) (*LexiDB, error) {
+	if err := os.MkdirAll(dbPath, 0700); err != nil {
+		return nil, fmt.Errorf("error creating database directory: %w", err)
+	}
+	ldb, err := lexi.New(&lexi.Config{
+		Path: dbPath,
+		Log:  logger,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Cannot acquire directory lock") {
+			err = fmt.Errorf("%w, could happen when database is already being used by another process", err)
+		}
+		return nil, err
+	}
+
+	db := &LexiDB{
+		DB:   ldb,
+		path: dbPath,
+		opts: defaultOpts,
+		log:  logger,
+	}
+
+	if err := db.prepareTables(); err != nil {
+		ldb.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func (db *LexiDB) prepareTables() (err error) {
+	table := func(name string) *lexi.Table {
+		if err != nil {
+			return nil
+		}
+		var t *lexi.Table
+		if t, err = db.Table(name); err != nil {
+			err = fmt.Errorf("error creating %s table: %w", name, err)
+			return nil
+		}
+		t.UseDefaultSetOptions(lexi.WithReplace())
+		return t
+	}
+	index := func(t *lexi.Table, name string, f func(k, v lexi.KV) ([]byte, error)) *lexi.Index {
+		if err != nil {
+			return nil
+		}
+		var idx *lexi.Index
+		if idx, err = t.AddIndex(name, f); err != nil {
+			err = fmt.Errorf("error creating %s index: %w", name, err)
+		}
+		return idx
+	}
+
+	db.meta = table("meta")
+	db.accounts = table("accounts")
+	db.bonds = table("bonds")
+	db.bondHostIdx = index(db.bonds, "host", func(k, _ lexi.KV) ([]byte, error) {
+		return k.([]byte), nil
+	})
+	db.bondIndexes = table("bondIndexes")
+	db.wallets = table("wallets")
+
+	db.orders = table("orders")
+	// Entries in the order indexes end with the update stamp and order ID,
+	// so that orders are sorted by time.
+	db.orderStampIdx = index(db.orders, "stamp", func(k, v lexi.KV) ([]byte, error) {
+		return append(uint64Bytes(v.(*orderRecord).stamp), k.([]byte)...), nil
+	})
+	db.orderHostIdx = index(db.orders, "host", func(k, v lexi.KV) ([]byte, error) {
+		r := v.(*orderRecord)
+		return slices.Concat(hostKey(r.MetaData.Host), uint64Bytes(r.stamp), k.([]byte)), nil
+	})
+	db.orderMarketIdx = index(db.orders, "market", func(k, v lexi.KV) ([]byte, error) {
+		r := v.(*orderRecord)
+		return slices.Concat(marketKey(r.MetaData.Host, r.Order.Base(), r.Order.Quote()), uint64Bytes(r.stamp), k.([]byte)), nil
+	})
+	db.orderActiveIdx = index(db.orders, "active", func(k, v lexi.KV) ([]byte, error) {
+		r := v.(*orderRecord)
+		if !r.MetaData.Status.IsActive() {
+			return nil, lexi.ErrNotIndexed
+		}
+		return append(hostKey(r.MetaData.Host), k.([]byte)...), nil
+	})
+	db.orderInactiveIdx = index(db.orders, "inactive", func(k, v lexi.KV) ([]byte, error) {
+		r := v.(*orderRecord)
+		if r.MetaData.Status.IsActive() {
+			return nil, lexi.ErrNotIndexed
+		}
+		return append(uint64Bytes(r.stamp), k.([]byte)...), nil
+	})
+
+	db.matches = table("matches")
+	db.matchOrderIdx = index(db.matches, "order", func(k, v lexi.KV) ([]byte, error) {
+		oid := v.(*matchRecord).OrderID
+		return append(oid[:], k.([]byte)...), nil
+	})
+	db.matchActiveIdx = index(db.matches, "active", func(k, v lexi.KV) ([]byte, error) {
+		r := v.(*matchRecord)
+		if !r.active {
+			return nil, lexi.ErrNotIndexed
+		}
+		return slices.Concat(hostKey(r.MetaData.DEX), r.OrderID[:], k.([]byte)), nil
+	})
+	db.matchInactiveIdx = index(db.matches, "inactive", func(k, v lexi.KV) ([]byte, error) {
+		r := v.(*matchRecord)
+		if r.active {
+			return nil, lexi.ErrNotIndexed
+		}
+		return append(uint64Bytes(r.MetaData.Stamp), k.([]byte)...), nil
+	})
+
+	db.notes = table("notes")
+	db.noteStampIdx = index(db.notes, "stamp", func(k, v lexi.KV) ([]byte, error) {
+		return append(uint64Bytes(v.(*noteRecord).TimeStamp), k.([]byte)...), nil
+	})
+	db.condOrders = table("conditionalOrders")
+	db.routedOrders = table("routedOrders")
+	db.marketAlerts = table("marketAlerts")
+	db.meshBonds = table("meshBonds")
+	db.fiatRates = table("fiatRates")
+	db.fiatRatesStampIdx = index(db.fiatRates, "stamp", func(_, v lexi.KV) ([]byte, error) {
+		return uint64Bytes(v.(*fiatRateRecord).Stamp), nil
+	})
+	return err
+}
+
+func marketKey(host string, base, quote uint32) []byte {
+	return slices.Concat(hostKey(host), uint32Bytes(base), uint32Bytes(quote))
+}
+
+// Run waits for context cancellation and closes the database.
+func (db *LexiDB) Run(ctx context.Context) {
+	dbCtx, cancel := context.WithCancel(context.Background())
+	wg, err := db.Connect(dbCtx)
+	if err != nil {
+		db.log.Errorf("Error starting database: %v", err)
+		cancel()
+		return
+	}
+
+	<-ctx.Done() // wait for shutdown to backup
+
+	if db.opts.BackupOnShutdown {
+		db.log.Infof("Backing up database...")
+		if err := db.Backup(); err != nil {
+			db.log.Errorf("Unable to backup database: %v", err)
+		}
+	}
+
+	cancel()
+	wg.Wait()
+}
+
+// closeDB closes a database that was not started with Run.
+func (db *LexiDB) closeDB() {
+	ctx, cancel := context.WithCancel(context.Background())
+	wg, err := db.Connect(ctx)
+	if err != nil {
+		db.Close()
+		cancel()
+		return
+	}
+	cancel()
+	wg.Wait()
+}
+
+// get retrieves the raw value for the key. A nil slice is returned without
+// an error if the key is not found.
+func get(t *lexi.Table, k lexi.KV) ([]byte, error) {
+	b, err := t.GetRaw(k)
+	if errors.Is(err, lexi.ErrKeyNotFound) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// getTxn is like get, but reads as part of the transaction.
+func getTxn(txn *badger.Txn, t *lexi.Table, k lexi.KV) ([]byte, error) {
+	b, err := t.GetRawTxn(txn, k)
+	if errors.Is(err, lexi.ErrKeyNotFound) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// Recrypt re-encrypts the wallet passwords and account private keys. As a
+// convenience, the provided *PrimaryCredentials are stored under the same
+// transaction.
+func (db *LexiDB) Recrypt(creds *dexdb.PrimaryCredentials, oldCrypter, newCrypter encrypt.Crypter) (walletUpdates map[uint32][]byte, acctUpdates map[string][]byte, err error) {
+	if err := validateCreds(creds); err != nil {
+		return nil, nil, err
+	}
+
+	wallets, err := db.Wallets()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading wallets: %w", err)
+	}
+	var accts []*dexdb.AccountInfo
+	if err := db.accounts.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			ai, err := decodeAccountRecord(bytes.Clone(vB))
+			if err != nil {
+				return err
+			}
+			accts = append(accts, ai)
+			return nil
+		})
+	}); err != nil {
+		return nil, nil, fmt.Errorf("error loading accounts: %w", err)
+	}
+
+	walletUpdates = make(map[uint32][]byte)
+	acctUpdates = make(map[string][]byte)
+
+	for _, w := range wallets {
+		if len(w.EncryptedPW) == 0 {
+			continue
+		}
+		pw, err := oldCrypter.Decrypt(w.EncryptedPW)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wallets update error: Decrypt error: %w", err)
+		}
+		w.EncryptedPW, err = newCrypter.Encrypt(pw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wallets update error: Encrypt error: %w", err)
+		}
+		walletUpdates[w.AssetID] = w.EncryptedPW
+	}
+
+	for _, ai := range accts {
+		if len(ai.LegacyEncKey) != 0 {
+			privB, err := oldCrypter.Decrypt(ai.LegacyEncKey)
+			if err != nil {
+				return nil, nil, fmt.Errorf("accounts update error: %w", err)
+			}
+			if ai.LegacyEncKey, err = newCrypter.Encrypt(privB); err != nil {
+				return nil, nil, fmt.Errorf("accounts update error: %w", err)
+			}
+			acctUpdates[ai.Host] = ai.LegacyEncKey
+		} else if len(ai.EncKeyV2) > 0 {
+			privB, err := oldCrypter.Decrypt(ai.EncKeyV2)
+			if err != nil {
+				return nil, nil, fmt.Errorf("accounts update error: %w", err)
+			}
+			if ai.EncKeyV2, err = newCrypter.Encrypt(privB); err != nil {
+				return nil, nil, fmt.Errorf("accounts update error: %w", err)
+			}
+			acctUpdates[ai.Host] = ai.EncKeyV2
+		}
+	}
+
+	return walletUpdates, acctUpdates, db.Update(func(txn *badger.Txn) error {
+		for _, w := range wallets {
+			if _, found := walletUpdates[w.AssetID]; !found {
+				continue
+			}
+			if err := db.wallets.SetTxn(txn, w.ID(), &walletRecord{w}); err != nil {
+				return fmt.Errorf("wallets update error: %w", err)
+			}
+		}
+		for _, ai := range accts {
+			if err := db.accounts.SetTxn(txn, []byte(ai.Host), &accountRecord{ai}); err != nil {
+				return fmt.Errorf("accounts update error: %w", err)
+			}
+		}
+		// Store the new credentials.
+		return db.meta.SetTxn(txn, credentialsKey, encodeCreds(creds))
+	})
+}
+
+// SetPrimaryCredentials validates and stores the PrimaryCredentials.
+func (db *LexiDB) SetPrimaryCredentials(creds *dexdb.PrimaryCredentials) error {
+	if err := validateCreds(creds); err != nil {
+		return err
+	}
+	return db.meta.Set(credentialsKey, encodeCreds(creds))
+}
+
+// validateCreds checks that the PrimaryCredentials fields are properly
+// populated.
+func validateCreds(creds *dexdb.PrimaryCredentials) error {
+	if len(creds.EncSeed) == 0 {
+		return errors.New("EncSeed not set")
+	}
+	if len(creds.EncInnerKey) == 0 {
+		return errors.New("EncInnerKey not set")
+	}
+	if len(creds.InnerKeyParams) == 0 {
+		return errors.New("InnerKeyParams not set")
+	}
+	if len(creds.OuterKeyParams) == 0 {
+		return errors.New("OuterKeyParams not set")
+	}
+	return nil
+}
+
+// PrimaryCredentials retrieves the *PrimaryCredentials, if they are stored. It
+// is an error if none have been stored.
+func (db *LexiDB) PrimaryCredentials() (*dexdb.PrimaryCredentials, error) {
+	b, err := get(db.meta, credentialsKey)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, dexdb.ErrNoCredentials
+	}
+	return decodeCreds(b)
+}
+
+// SetSeedGenerationTime stores the time the app seed was generated.
+func (db *LexiDB) SetSeedGenerationTime(time uint64) error {
+	return db.meta.Set(seedGenTimeKey, uint64Bytes(time))
+}
+
+// SeedGenerationTime returns the time the app seed was generated, if it was
+// stored. It returns dexdb.ErrNoSeedGenTime if it was not stored.
+func (db *LexiDB) SeedGenerationTime() (uint64, error) {
+	b, err := get(db.meta, seedGenTimeKey)
+	if err != nil {
+		return 0, err
+	}
+	if b == nil {
+		return 0, dexdb.ErrNoSeedGenTime
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("seed generation time length %v, expected 8", len(b))
+	}
+	return intCoder.Uint64(b), nil
+}
+
+// ListAccounts returns a list of DEX URLs. The DB is designed to have a single
+// account per DEX, so the account itself is identified by the DEX URL.
+func (db *LexiDB) ListAccounts() ([]string, error) {
+	var urls []string
+	err := db.accounts.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			ai, err := decodeAccountRecord(bytes.Clone(vB))
+			if err != nil {
+				return err
+			}
+			if !ai.Disabled {
+				urls = append(urls, ai.Host)
+			}
+			return nil
+		})
+	})
+	sort.Strings(urls)
+	return urls, err
+}
+
+// accountBonds loads the account's bonds, sorted by unique ID.
+func (db *LexiDB) accountBonds(host string) (bonds []*dexdb.Bond, _ error) {
+	return bonds, db.bondHostIdx.Iterate(hostKey(host), func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			r, err := decodeBondRecord(bytes.Clone(vB))
+			if err != nil {
+				db.log.Errorf("Invalid bond data encoding: %v", err)
+				return nil
+			}
+			bonds = append(bonds, r.Bond)
+			return nil
+		})
+	})
+}
+
+// Accounts returns a list of DEX Accounts, sorted by host.
+func (db *LexiDB) Accounts() ([]*dexdb.AccountInfo, error) {
+	var accounts []*dexdb.AccountInfo
+	if err := db.accounts.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			ai, err := decodeAccountRecord(bytes.Clone(vB))
+			if err != nil {
+				return err
+			}
+			accounts = append(accounts, ai)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Host < accounts[j].Host })
+	for _, ai := range accounts {
+		var err error
+		if ai.Bonds, err = db.accountBonds(ai.Host); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+// Account gets the AccountInfo associated with the specified DEX address.
+func (db *LexiDB) Account(host string) (*dexdb.AccountInfo, error) {
+	b, err := get(db.accounts, []byte(host))
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, dexdb.ErrAcctNotFound
+	}
+	ai, err := decodeAccountRecord(b)
+	if err != nil {
+		return nil, err
+	}
+	if ai.Bonds, err = db.accountBonds(host); err != nil {
+		return nil, err
+	}
+	return ai, nil
+}
+
+// CreateAccount saves the AccountInfo. If an account already exists for this
+// DEX, it will return an error.
+func (db *LexiDB) CreateAccount(ai *dexdb.AccountInfo) error {
+	if ai.Host == "" {
+		return fmt.Errorf("empty host not allowed")
+	}
+	if ai.DEXPubKey == nil {
+		return fmt.Errorf("nil DEXPubKey not allowed")
+	}
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.accounts, []byte(ai.Host))
+		if err != nil {
+			return err
+		}
+		if b != nil {
+			return fmt.Errorf("account for %s already exists", ai.Host)
+		}
+		acct := *ai
+		acct.Disabled = false
+		if err := db.accounts.SetTxn(txn, []byte(ai.Host), &accountRecord{&acct}); err != nil {
+			return fmt.Errorf("error storing account: %w", err)
+		}
+		return db.storeBonds(txn, ai.Host, ai.Bonds)
+	})
+}
+
+func (db *LexiDB) storeBonds(txn *badger.Txn, host string, bonds []*dexdb.Bond) error {
+	for _, bond := range bonds {
+		if err := db.bonds.SetTxn(txn, bondKey(host, bond.AssetID, bond.CoinID), &bondRecord{Bond: bond, host: host}); err != nil {
+			return fmt.Errorf("error storing bond %x: %w", bond.UniqueID(), err)
+		}
+	}
+	return nil
+}
+
+// NextBondKeyIndex returns the next bond key index and increments the stored
+// value so that subsequent calls will always return a higher index.
+func (db *LexiDB) NextBondKeyIndex(assetID uint32) (bondIndex uint32, _ error) {
+	return bondIndex, db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.bondIndexes, assetID)
+		if err != nil {
+			return err
+		}
+		bondIndex = uint32FromBytes(b)
+		return db.bondIndexes.SetTxn(txn, assetID, uint32Bytes(bondIndex+1))
+	})
+}
+
+// updateAccount loads the account record and stores it after it's modified
+// by f.
+func (db *LexiDB) updateAccount(host string, f func(txn *badger.Txn, ai *dexdb.AccountInfo) error) error {
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.accounts, []byte(host))
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("account not found for %s", host)
+		}
+		ai, err := decodeAccountRecord(b)
+		if err != nil {
+			return err
+		}
+		if err := f(txn, ai); err != nil {
+			return err
+		}
+		return db.accounts.SetTxn(txn, []byte(host), &accountRecord{ai})
+	})
+}
+
+// UpdateAccountInfo updates the account info for an existing account with
+// the same Host as the parameter. If no account exists with this host,
+// an error is returned.
+func (db *LexiDB) UpdateAccountInfo(ai *dexdb.AccountInfo) error {
+	return db.updateAccount(ai.Host, func(txn *badger.Txn, stored *dexdb.AccountInfo) error {
+		disabled := stored.Disabled
+		*stored = *ai
+		stored.Disabled = disabled
+		return db.storeBonds(txn, ai.Host, ai.Bonds)
+	})
+}
+
+// ToggleAccountStatus enables or disables the account associated with the given
+// host.
+func (db *LexiDB) ToggleAccountStatus(host string, disable bool) error {
+	return db.updateAccount(host, func(_ *badger.Txn, ai *dexdb.AccountInfo) error {
+		if ai.Disabled == disable {
+			if disable {
+				return errors.New("account is already disabled")
+			}
+			return errors.New("account is already enabled")
+		}
+		ai.Disabled = disable
+		return nil
+	})
+}
+
+// AddBond saves a new Bond or updates an existing bond for an existing DEX
+// account.
+func (db *LexiDB) AddBond(host string, bond *dexdb.Bond) error {
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.accounts, []byte(host))
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("account not found for %s", host)
+		}
+		return db.storeBonds(txn, host, []*dexdb.Bond{bond})
+	})
+}
+
+func (db *LexiDB) updateBond(host string, assetID uint32, bondCoinID []byte, f func(*dexdb.Bond)) error {
+	k := bondKey(host, assetID, bondCoinID)
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.bonds, k)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("bond does not exist: %x", dexdb.BondUID(assetID, bondCoinID))
+		}
+		r, err := decodeBondRecord(b)
+		if err != nil {
+			return err
+		}
+		f(r.Bond)
+		return db.bonds.SetTxn(txn, k, r)
+	})
+}
+
+// ConfirmBond marks a DEX account bond as confirmed by the DEX.
+func (db *LexiDB) ConfirmBond(host string, assetID uint32, bondCoinID []byte) error {
+	return db.updateBond(host, assetID, bondCoinID, func(b *dexdb.Bond) { b.Confirmed = true })
+}
+
+// BondRefunded marks a DEX account bond as refunded by the client wallet.
+func (db *LexiDB) BondRefunded(host string, assetID uint32, bondCoinID []byte) error {
+	return db.updateBond(host, assetID, bondCoinID, func(b *dexdb.Bond) { b.Refunded = true })
+}
+
+// UpdateOrder saves the order information in the database. Any existing order
+// info for the same order ID will be overwritten without indication.
+func (db *LexiDB) UpdateOrder(m *dexdb.MetaOrder) error {
+	ord, md := m.Order, m.MetaData
+	if md.Status == order.OrderStatusUnknown {
+		return fmt.Errorf("cannot set order %s status to unknown", ord.ID())
+	}
+	if md.Host == "" {
+		return fmt.Errorf("empty DEX not allowed")
+	}
+	if len(md.Proof.DEXSig) == 0 {
+		return fmt.Errorf("cannot save order without DEX signature")
+	}
+	oid := ord.ID()
+	return db.orders.Set(oid[:], &orderRecord{MetaOrder: m, stamp: timeNow()})
+}
+
+// updateOrder loads the order record and stores it after it's modified by f.
+func (db *LexiDB) updateOrder(oid order.OrderID, f func(r *orderRecord) error) error {
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.orders, oid[:])
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("order %s not found", oid)
+		}
+		r, err := decodeOrderRecord(b)
+		if err != nil {
+			return err
+		}
+		if err := f(r); err != nil {
+			return err
+		}
+		return db.orders.SetTxn(txn, oid[:], r)
+	})
+}
+
+// iterateOrders iterates the index, decoding the orders.
+func iterateOrders(idx *lexi.Index, prefix []byte, f func(it *lexi.Iter, r *orderRecord) error, opts ...lexi.IterationOption) error {
+	return idx.Iterate(prefix, func(it *lexi.Iter) error {
+		var r *orderRecord
+		if err := it.V(func(vB []byte) (err error) {
+			r, err = decodeOrderRecord(bytes.Clone(vB))
+			return err
+		}); err != nil {
+			return err
+		}
+		return f(it, r)
+	}, opts...)
+}
+
+// newestOrders returns up to n (unlimited if n is 0) orders from the
+// time-sorted index with the prefix, newest first, stopping at orders older
+// than since.
+func (db *LexiDB) newestOrders(idx *lexi.Index, prefix []byte, n int, since uint64) ([]*dexdb.MetaOrder, error) {
+	var ords []*dexdb.MetaOrder
+	return ords, iterateOrders(idx, prefix, func(_ *lexi.Iter, r *orderRecord) error {
+		if r.stamp < since {
+			return lexi.ErrEndIteration
+		}
+		ords = append(ords, r.MetaOrder)
+		if n > 0 && len(ords) >= n {
+			return lexi.ErrEndIteration
+		}
+		return nil
+	}, lexi.WithReverse())
+}
+
+// ActiveOrders retrieves all orders which appear to be in an active state,
+// which is either in the epoch queue or in the order book.
+func (db *LexiDB) ActiveOrders() ([]*dexdb.MetaOrder, error) {
+	return db.activeOrders(nil)
+}
+
+// ActiveDEXOrders retrieves all active orders for the specified DEX.
+func (db *LexiDB) ActiveDEXOrders(dex string) ([]*dexdb.MetaOrder, error) {
+	return db.activeOrders(hostKey(dex))
+}
+
+func (db *LexiDB) activeOrders(prefix []byte) ([]*dexdb.MetaOrder, error) {
+	var ords []*dexdb.MetaOrder
+	return ords, iterateOrders(db.orderActiveIdx, prefix, func(_ *lexi.Iter, r *orderRecord) error {
+		ords = append(ords, r.MetaOrder)
+		return nil
+	})
+}
+
+// AccountOrders retrieves all orders associated with the specified DEX. n = 0
+// applies no limit on number of orders returned. since = 0 is equivalent to
+// disabling the time filter, since no orders were created before 1970.
+func (db *LexiDB) AccountOrders(dex string, n int, since uint64) ([]*dexdb.MetaOrder, error) {
+	return db.newestOrders(db.orderHostIdx, hostKey(dex), n, since)
+}
+
+// MarketOrders retrieves all orders for the specified DEX and market. n = 0
+// applies no limit on number of orders returned. since = 0 is equivalent to
+// disabling the time filter, since no orders were created before 1970.
+func (db *LexiDB) MarketOrders(dex string, base, quote uint32, n int, since uint64) ([]*dexdb.MetaOrder, error) {
+	return db.newestOrders(db.orderMarketIdx, marketKey(dex, base, quote), n, since)
+}
+
+// Order fetches a MetaOrder by order ID.
+func (db *LexiDB) Order(oid order.OrderID) (*dexdb.MetaOrder, error) {
+	b, err := get(db.orders, oid[:])
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, fmt.Errorf("order %s not found", oid)
+	}
+	r, err := decodeOrderRecord(b)
+	if err != nil {
+		return nil, err
+	}
+	return r.MetaOrder, nil
+}
+
+// Orders fetches a slice of orders, sorted by descending time, and filtered
+// with the provided OrderFilter. Orders does not return cancel orders. If the
+// filter specifies a single host, the host or market index is used.
+// Otherwise, orders are read from the time index until N orders pass the
+// filter.
+func (db *LexiDB) Orders(orderFilter *dexdb.OrderFilter) ([]*dexdb.MetaOrder, error) {
+	idx, prefix := db.orderStampIdx, []byte(nil)
+	switch {
+	case len(orderFilter.Hosts) == 1 && orderFilter.Market != nil:
+		idx, prefix = db.orderMarketIdx, marketKey(orderFilter.Hosts[0], orderFilter.Market.Base, orderFilter.Market.Quote)
+	case len(orderFilter.Hosts) == 1:
+		idx, prefix = db.orderHostIdx, hostKey(orderFilter.Hosts[0])
+	}
+
+	// With an offset, only orders updated before the offset order, or at the
+	// same time but with a greater order ID, are accepted.
+	var offsetStamp []byte
+	var offsetOID order.OrderID
+	opts := []lexi.IterationOption{lexi.WithReverse()}
+	if !orderFilter.Offset.IsZero() {
+		offsetOID = orderFilter.Offset
+		b, err := get(db.orders, offsetOID[:])
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			return nil, fmt.Errorf("order %s not found", offsetOID)
+		}
+		r, err := decodeOrderRecord(b)
+		if err != nil {
+			return nil, err
+		}
+		offsetStamp = uint64Bytes(r.stamp)
+		maxOID := bytes.Repeat([]byte{0xff}, order.OrderIDSize)
+		opts = append(opts, lexi.WithSeek(slices.Concat(prefix, offsetStamp, maxOID)))
+	}
+
+	hosts := make(map[string]bool, len(orderFilter.Hosts))
+	for _, host := range orderFilter.Hosts {
+		hosts[host] = true
+	}
+	assetIDs := make(map[uint32]bool, len(orderFilter.Assets))
+	for _, assetID := range orderFilter.Assets {
+		assetIDs[assetID] = true
+	}
+	accept := func(r *orderRecord) bool {
+		ord, md := r.Order, r.MetaData
+		switch {
+		case ord.Type() == order.CancelOrderType:
+			return false
+		case len(hosts) > 0 && !hosts[md.Host]:
+			return false
+		case len(assetIDs) > 0 && !assetIDs[ord.Base()] && !assetIDs[ord.Quote()]:
+			return false
+		case len(orderFilter.Statuses) > 0 && !slices.Contains(orderFilter.Statuses, md.Status):
+			return false
+		case orderFilter.Market != nil && (orderFilter.Market.Base != ord.Base() || orderFilter.Market.Quote != ord.Quote()):
+			return false
+		}
+		return true
+	}
+
+	ords := make([]*dexdb.MetaOrder, 0, orderFilter.N)
+	return ords, idx.Iterate(prefix, func(it *lexi.Iter) error {
+		if offsetStamp != nil {
+			var accepted bool
+			if err := it.Entry(func(idxB []byte) error {
+				// Entries end with the stamp and order ID.
+				if len(idxB) < 8+order.OrderIDSize {
+					return fmt.Errorf("invalid order index entry %x", idxB)
+				}
+				stampB, oidB := idxB[len(idxB)-8-order.OrderIDSize:len(idxB)-order.OrderIDSize], idxB[len(idxB)-order.OrderIDSize:]
+				comp := bytes.Compare(stampB, offsetStamp)
+				accepted = comp < 0 || (comp == 0 && bytes.Compare(offsetOID[:], oidB) < 0)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if !accepted {
+				return nil
+			}
+		}
+		var r *orderRecord
+		if err := it.V(func(vB []byte) (err error) {
+			r, err = decodeOrderRecord(bytes.Clone(vB))
+			return err
+		}); err != nil {
+			return err
+		}
+		if !accept(r) {
+			return nil
+		}
+		ords = append(ords, r.MetaOrder)
+		if orderFilter.N > 0 && len(ords) >= orderFilter.N {
+			return lexi.ErrEndIteration
+		}
+		return nil
+	}, opts...)
+}
+
+// UpdateOrderMetaData updates the order metadata, not including the Host.
+func (db *LexiDB) UpdateOrderMetaData(oid order.OrderID, md *dexdb.OrderMetaData) error {
+	if md.Status == order.OrderStatusUnknown {
+		return fmt.Errorf("cannot set order %s status to unknown", oid)
+	}
+	return db.updateOrder(oid, func(r *orderRecord) error {
+		stored := r.MetaData
+		stored.Status = md.Status
+		stored.Proof = md.Proof
+		stored.ChangeCoin = md.ChangeCoin
+		stored.LinkedOrder = md.LinkedOrder
+		stored.SwapFeesPaid = md.SwapFeesPaid
+		stored.RedemptionFeesPaid = md.RedemptionFeesPaid
+		stored.Options = md.Options
+		stored.RedemptionReserves = md.RedemptionReserves
+		stored.RefundReserves = md.RefundReserves
+		stored.AccelerationCoins = md.AccelerationCoins
+		stored.FundingFeesPaid = md.FundingFeesPaid
+		stored.RefundFeesPaid = md.RefundFeesPaid
+		r.stamp = timeNow()
+		return nil
+	})
+}
+
+// UpdateOrderStatus sets the order status for an order.
+func (db *LexiDB) UpdateOrderStatus(oid order.OrderID, status order.OrderStatus) error {
+	if status == order.OrderStatusUnknown {
+		return fmt.Errorf("cannot set order %s status to unknown", oid)
+	}
+	return db.updateOrder(oid, func(r *orderRecord) error {
+		r.MetaData.Status = status
+		return nil
+	})
+}
+
+// LinkOrder sets the linked order.
+func (db *LexiDB) LinkOrder(oid, linkedID order.OrderID) error {
+	return db.updateOrder(oid, func(r *orderRecord) error {
+		r.MetaData.LinkedOrder = linkedID
+		return nil
+	})
+}
+
+// UpdateMatch updates the match information in the database. Any existing
+// entry for the same match ID will be overwritten without indication.
+func (db *LexiDB) UpdateMatch(m *dexdb.MetaMatch) error {
+	md := m.MetaData
+	if md.Quote == md.Base {
+		return fmt.Errorf("quote and base asset cannot be the same")
+	}
+	if md.DEX == "" {
+		return fmt.Errorf("empty DEX not allowed")
+	}
+	active := dexdb.MatchIsActive(m.UserMatch, &m.MetaData.Proof)
+	return db.matches.Set(m.MatchOrderUniqueID(), &matchRecord{MetaMatch: m, active: active})
+}
+
+// iterateMatches iterates the index, decoding the matches.
+func iterateMatches(idx *lexi.Index, prefix []byte, f func(it *lexi.Iter, r *matchRecord) error, opts ...lexi.IterationOption) error {
+	return idx.Iterate(prefix, func(it *lexi.Iter) error {
+		var r *matchRecord
+		if err := it.V(func(vB []byte) (err error) {
+			r, err = decodeMatchRecord(bytes.Clone(vB))
+			return err
+		}); err != nil {
+			return err
+		}
+		return f(it, r)
+	}, opts...)
+}
+
+// ActiveMatches retrieves the matches that are in an active state, which is
+// any match that is still active.
+func (db *LexiDB) ActiveMatches() ([]*dexdb.MetaMatch, error) {
+	var matches []*dexdb.MetaMatch
+	return matches, iterateMatches(db.matchActiveIdx, nil, func(_ *lexi.Iter, r *matchRecord) error {
+		// Don't bother with cancel matches that are never active.
+		if !isCancelMatch(r.MetaMatch) {
+			matches = append(matches, r.MetaMatch)
+		}
+		return nil
+	})
+}
+
+// activeMatchOrders returns the IDs of the orders with active matches, with
+// the host key prefix.
+func (db *LexiDB) activeMatchOrders(prefix []byte) (map[order.OrderID]bool, error) {
+	oids := make(map[order.OrderID]bool)
+	return oids, db.matchActiveIdx.Iterate(prefix, func(it *lexi.Iter) error {
+		return it.Entry(func(idxB []byte) error {
+			hostEnd := bytes.IndexByte(idxB, 0)
+			if hostEnd < 0 || len(idxB) < hostEnd+1+order.OrderIDSize {
+				return fmt.Errorf("invalid active match index entry %x", idxB)
+			}
+			var oid order.OrderID
+			copy(oid[:], idxB[hostEnd+1:])
+			oids[oid] = true
+			return nil
+		})
+	})
+}
+
+// DEXOrdersWithActiveMatches retrieves order IDs for any order that has active
+// matches, regardless of whether the order itself is in an active state.
+func (db *LexiDB) DEXOrdersWithActiveMatches(dex string) ([]order.OrderID, error) {
+	oids, err := db.activeMatchOrders(hostKey(dex))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]order.OrderID, 0, len(oids))
+	for oid := range oids {
+		ids = append(ids, oid)
+	}
+	return ids, nil
+}
+
+// MatchesForOrder retrieves the matches for the specified order ID.
+func (db *LexiDB) MatchesForOrder(oid order.OrderID, excludeCancels bool) ([]*dexdb.MetaMatch, error) {
+	var matches []*dexdb.MetaMatch
+	return matches, iterateMatches(db.matchOrderIdx, oid[:], func(_ *lexi.Iter, r *matchRecord) error {
+		if !excludeCancels || !isCancelMatch(r.MetaMatch) {
+			matches = append(matches, r.MetaMatch)
+		}
+		return nil
+	})
+}
+
+// UpdateWallet adds a wallet to the database, or updates the wallet
+// credentials and balance if it already exists. The wallet's stored status
+// is not changed.
+func (db *LexiDB) UpdateWallet(wallet *dexdb.Wallet) error {
+	if wallet.Balance == nil {
+		return fmt.Errorf("cannot UpdateWallet with nil Balance field")
+	}
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.wallets, wallet.ID())
+		if err != nil {
+			return err
+		}
+		w := *wallet
+		w.Disabled = false
+		if b != nil {
+			stored, err := decodeWalletRecord(b)
+			if err != nil {
+				return err
+			}
+			w.Disabled = stored.Disabled
+		}
+		return db.wallets.SetTxn(txn, w.ID(), &walletRecord{&w})
+	})
+}
+
+// updateWallet loads the wallet record and stores it after it's modified by
+// f.
+func (db *LexiDB) updateWallet(wid []byte, f func(w *dexdb.Wallet)) error {
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.wallets, wid)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("wallet with ID %x not known", wid)
+		}
+		w, err := decodeWalletRecord(b)
+		if err != nil {
+			return err
+		}
+		f(w)
+		return db.wallets.SetTxn(txn, wid, &walletRecord{w})
+	})
+}
+
+// SetWalletPassword set the encrypted password field for the wallet.
+func (db *LexiDB) SetWalletPassword(wid []byte, newEncPW []byte) error {
+	return db.updateWallet(wid, func(w *dexdb.Wallet) {
+		w.EncryptedPW = bytes.Clone(newEncPW)
+	})
+}
+
+// UpdateBalance updates the wallet's balance.
+func (db *LexiDB) UpdateBalance(wid []byte, bal *dexdb.Balance) error {
+	return db.updateWallet(wid, func(w *dexdb.Wallet) {
+		w.Balance = bal
+	})
+}
+
+// UpdateWalletStatus updates a wallet's status.
+func (db *LexiDB) UpdateWalletStatus(wid []byte, disable bool) error {
+	return db.updateWallet(wid, func(w *dexdb.Wallet) {
+		w.Disabled = disable
+	})
+}
+
+// Wallets loads all wallets from the database, sorted by ID.
+func (db *LexiDB) Wallets() ([]*dexdb.Wallet, error) {
+	var wallets []*dexdb.Wallet
+	if err := db.wallets.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			w, err := decodeWalletRecord(bytes.Clone(vB))
+			if err != nil {
+				return err
+			}
+			wallets = append(wallets, w)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(wallets, func(i, j int) bool { return bytes.Compare(wallets[i].ID(), wallets[j].ID()) < 0 })
+	return wallets, nil
+}
+
+// Wallet loads a single wallet from the database.
+func (db *LexiDB) Wallet(wid []byte) (*dexdb.Wallet, error) {
+	b, err := get(db.wallets, wid)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, fmt.Errorf("wallet with ID %x not known", wid)
+	}
+	return decodeWalletRecord(b)
+}
+
+// SaveNotification saves the notification.
+func (db *LexiDB) SaveNotification(note *dexdb.Notification) error {
+	if note.Severeness < dexdb.Success {
+		return fmt.Errorf("storage of notification with severity %s is forbidden", note.Severeness)
+	}
+	return db.notes.Set([]byte(note.ID()), &noteRecord{note})
+}
+
+// AckNotification sets the acknowledgement for a notification.
+func (db *LexiDB) AckNotification(id []byte) error {
+	return db.Update(func(txn *badger.Txn) error {
+		b, err := getTxn(txn, db.notes, id)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			return fmt.Errorf("notification not found")
+		}
+		note, err := decodeNoteRecord(b)
+		if err != nil {
+			return err
+		}
+		note.Ack = true
+		return db.notes.SetTxn(txn, id, &noteRecord{note})
+	})
+}
+
+// NotificationsN reads out the N most recent notifications.
+func (db *LexiDB) NotificationsN(n int) ([]*dexdb.Notification, error) {
+	notes := make([]*dexdb.Notification, 0, n)
+	return notes, db.noteStampIdx.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			note, err := decodeNoteRecord(bytes.Clone(vB))
+			if err != nil {
+				return err
+			}
+			notes = append(notes, note)
+			if n > 0 && len(notes) >= n {
+				return lexi.ErrEndIteration
+			}
+			return nil
+		})
+	}, lexi.WithReverse())
+}
+
+// SavePokes saves a slice of notifications, overwriting any previously saved
+// slice.
+func (db *LexiDB) SavePokes(pokes []*dexdb.Notification) error {
+	// Just save it as JSON.
+	b, err := json.Marshal(pokes)
+	if err != nil {
+		return fmt.Errorf("JSON marshal error: %w", err)
+	}
+	return db.meta.Set(pokesKey, b)
+}
+
+// LoadPokes loads the slice of notifications last saved with SavePokes. The
+// loaded pokes are deleted from the database.
+func (db *LexiDB) LoadPokes() (pokes []*dexdb.Notification, _ error) {
+	b, err := get(db.meta, pokesKey)
+	if err != nil || len(b) == 0 { // None saved
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &pokes); err != nil {
+		return nil, err
+	}
+	return pokes, db.meta.Delete(pokesKey)
+}
+
+// ovrFlag is the file flag for the overwrite setting.
+func ovrFlag(overwrite bool) int {
+	if overwrite {
+		return os.O_TRUNC
+	}
+	return os.O_EXCL
+}
+
+// BackupTo writes a backup of the database to the specified file, optionally
+// overwriting an existing file. Badger backups only contain the latest
+// version of each key, so the backup is always compact. Unlike the bolt
+// backups, which are copies of the database file, the backup is a badger
+// backup stream and must be restored with Restore.
+func (db *LexiDB) BackupTo(dst string, overwrite, _ bool) error {
+	// If relative path, use the directory containing the db.
+	if !filepath.IsAbs(dst) {
+		dst = filepath.Join(filepath.Dir(db.path), dst)
+	}
+	dst = filepath.Clean(dst)
+	if rel, err := filepath.Rel(db.path, dst); err == nil && !strings.HasPrefix(rel, "..") {
+		return errors.New("destination is in the active DB directory")
+	}
+
+	// Make the parent folder if it does not exists.
+	dir := filepath.Dir(dst)
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		if err = os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("unable to create backup directory: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE|ovrFlag(overwrite), 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := db.DB.Backup(f, 0); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// Backup makes a copy of the database in the "backup" folder, overwriting any
+// existing backup.
+func (db *LexiDB) Backup() error {
+	dir, name := filepath.Split(filepath.Clean(db.path))
+	return db.BackupTo(filepath.Join(dir, backupDir, name+".bak"), true, false)
+}
+
+// Restore recreates the database at dbPath from a backup file written by
+// BackupTo or Backup. There must not already be a database at dbPath. The
+// data is loaded into a temporary directory, which is renamed to dbPath when
+// the restore is complete.
+func Restore(backupPath, dbPath string) error {
+	if _, err := os.Stat(dbPath); !errors.Is(err, fs.ErrNotExist) {
+		if err == nil {
+			return fmt.Errorf("database already exists at %s", dbPath)
+		}
+		return err
+	}
+	f, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("error opening backup file: %w", err)
+	}
+	defer f.Close()
+
+	tmpPath := dbPath + ".restoring"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("error removing incomplete restore: %w", err)
+	}
+	bdb, err := badger.Open(badger.DefaultOptions(tmpPath).WithLogger(nil))
+	if err != nil {
+		return err
+	}
+	if err := bdb.Load(f, 256); err != nil {
+		bdb.Close()
+		return fmt.Errorf("error loading backup: %w", err)
+	}
+	if err := bdb.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		return fmt.Errorf("error moving restored database into place: %w", err)
+	}
+	return nil
+}
+
+// DeleteInactiveOrders deletes orders that are no longer needed for normal
+// operations. Optionally accepts a time to delete orders with a later time
+// stamp. Accepts an optional function to perform on deleted orders.
+func (db *LexiDB) DeleteInactiveOrders(ctx context.Context, olderThan *time.Time,
+	perOrderFn func(ords *dexdb.MetaOrder) error) (int, error) {
+
+	olderThanStamp := timeNow()
+	if olderThan != nil && !olderThan.IsZero() {
+		olderThanStamp = uint64(olderThan.UnixMilli())
+	}
+
+	// Some inactive orders may still be needed for active matches.
+	activeMatchOrders, err := db.activeMatchOrders(nil)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get active matches: %v", err)
+	}
+
+	start := time.Now()
+	n, err := deleteInactive(ctx, db.orderInactiveIdx, olderThanStamp, func(it *lexi.Iter, _ []byte) (bool, error) {
+		var r *orderRecord
+		if err := it.V(func(vB []byte) (err error) {
+			r, err = decodeOrderRecord(bytes.Clone(vB))
+			return err
+		}); err != nil {
+			return false, fmt.Errorf("failed to decode order: %v", err)
+		}
+		// Don't delete this order if it still has active matches.
+		if activeMatchOrders[r.Order.ID()] {
+			return false, nil
+		}
+		if err := it.Delete(); err != nil {
+			return false, fmt.Errorf("failed to delete order: %v", err)
+		}
+		if perOrderFn != nil {
+			if err := perOrderFn(r.MetaOrder); err != nil {
+				return false, fmt.Errorf("problem performing batch function: %v", err)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to delete orders: %v", err)
+	}
+
+	db.log.Infof("Deleted %d inactive orders from the database in %v", n, time.Since(start))
+
+	return n, nil
+}
+
+// DeleteInactiveMatches deletes matches that are no longer needed for normal
+// operations. Optionally accepts a time to delete matches with a later time
+// stamp. Accepts an optional function to perform on deleted matches.
+func (db *LexiDB) DeleteInactiveMatches(ctx context.Context, olderThan *time.Time,
+	perMatchFn func(mtch *dexdb.MetaMatch, isSell bool) error) (int, error) {
+
+	olderThanStamp := timeNow()
+	if olderThan != nil && !olderThan.IsZero() {
+		olderThanStamp = uint64(olderThan.UnixMilli())
+	}
+
+	// Some inactive matches still have active orders. Keep those just in
+	// case they are needed again.
+	activeOrders := make(map[order.OrderID]bool)
+	if err := db.orderActiveIdx.Iterate(nil, func(it *lexi.Iter) error {
+		k, err := it.K()
+		if err != nil {
+			return err
+		}
+		var oid order.OrderID
+		copy(oid[:], k)
+		activeOrders[oid] = true
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("unable to get active orders: %v", err)
+	}
+
+	start := time.Now()
+	n, err := deleteInactive(ctx, db.matchInactiveIdx, olderThanStamp, func(it *lexi.Iter, _ []byte) (bool, error) {
+		var r *matchRecord
+		if err := it.V(func(vB []byte) (err error) {
+			r, err = decodeMatchRecord(bytes.Clone(vB))
+			return err
+		}); err != nil {
+			return false, fmt.Errorf("failed to decode match: %v", err)
+		}
+		// Don't delete this match if it still has active orders.
+		if activeOrders[r.OrderID] {
+			return false, nil
+		}
+		if err := it.Delete(); err != nil {
+			return false, fmt.Errorf("failed to delete match: %v", err)
+		}
+		if perMatchFn != nil {
+			isSell, err := db.orderSide(r.OrderID)
+			if err != nil {
+				return false, fmt.Errorf("problem getting order side for order %v: %v", r.OrderID, err)
+			}
+			if err := perMatchFn(r.MetaMatch, isSell); err != nil {
+				return false, fmt.Errorf("problem performing batch function: %v", err)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to delete matches: %v", err)
+	}
+
+	db.log.Infof("Deleted %d inactive matches from the database in %v", n, time.Since(start))
+
+	return n, nil
+}
+
+// deleteInactive iterates an index of inactive records, whose entries begin
+// with a time stamp, and calls del for entries with a stamp no later than
+// olderThan. del returns true if the record was deleted. Deletions are done in
+// batches to prevent any single db transaction from becoming too large. If
+// del returns an error, the deletions in its batch are rolled back.
+func deleteInactive(ctx context.Context, idx *lexi.Index, olderThan uint64, del func(it *lexi.Iter, entry []byte) (bool, error)) (int, error) {
+	const batchSize = 1000
+	var nDeleted int
+	var seek []byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		var done bool
+		// The iteration function is retried if the transaction conflicts, so
+		// the deletions are tracked by entry.
+		deleted := make(map[string]bool, batchSize)
+		opts := []lexi.IterationOption{lexi.WithUpdate()}
+		if seek != nil {
+			opts = append(opts, lexi.WithSeek(seek))
+		}
+		var lastEntry []byte
+		err := idx.Iterate(nil, func(it *lexi.Iter) error {
+			var entry []byte
+			if err := it.Entry(func(idxB []byte) error {
+				entry = bytes.Clone(idxB)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if len(entry) < 8 {
+				return fmt.Errorf("invalid index entry %x", entry)
+			}
+			if bytes.Equal(entry, seek) {
+				return nil // kept in the last batch
+			}
+			if intCoder.Uint64(entry[:8]) > olderThan {
+				done = true
+				return lexi.ErrEndIteration
+			}
+			lastEntry = entry
+			ok, err := del(it, entry)
+			if err != nil {
+				return err
+			}
+			if ok {
+				deleted[string(entry)] = true
+			}
+			if len(deleted) >= batchSize {
+				return lexi.ErrEndIteration
+			}
+			return nil
+		}, opts...)
+		if err != nil {
+			return 0, err
+		}
+		nDeleted += len(deleted)
+		if done || len(deleted) < batchSize {
+			return nDeleted, nil
+		}
+		seek = lastEntry
+	}
+}
+
+// orderSide returns whether the order was for buying or selling the asset.
+func (db *LexiDB) orderSide(oid order.OrderID) (sell bool, err error) {
+	mo, err := db.Order(oid)
+	if err != nil {
+		return false, err
+	}
+	// Cancel orders have no side.
+	if mo.Order.Type() == order.CancelOrderType {
+		return false, nil
+	}
+	return mo.Order.Trade().Sell, nil
+}
+
+// SaveDisabledRateSources updates disabled fiat rate sources.
+func (db *LexiDB) SaveDisabledRateSources(disabledSources []string) error {
+	return db.meta.Set(disabledRateSourceKey, []byte(strings.Join(disabledSources, ",")))
+}
+
+// DisabledRateSources retrieves a map of disabled fiat rate sources.
+func (db *LexiDB) DisabledRateSources() (disabledSources []string, err error) {
+	b, err := get(db.meta, disabledRateSourceKey)
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	for _, token := range strings.Split(string(b), ",") {
+		if token != "" {
+			disabledSources = append(disabledSources, token)
+		}
+	}
+	return disabledSources, nil
+}
+
+// jsonRecords loads all of the JSON-encoded records in the table.
+func jsonRecords[T any](t *lexi.Table) (recs []*T, _ error) {
+	return recs, t.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			rec := new(T)
+			if err := json.Unmarshal(vB, rec); err != nil {
+				return err
+			}
+			recs = append(recs, rec)
+			return nil
+		})
+	})
+}
+
+// UpdateConditionalOrder saves the conditional order, overwriting any existing
+// entry with the same ID.
+func (db *LexiDB) UpdateConditionalOrder(ord *dexdb.ConditionalOrder) error {
+	if len(ord.ID) == 0 {
+		return fmt.Errorf("no conditional order ID")
+	}
+	return db.condOrders.Set([]byte(ord.ID), lexi.JSON(ord))
+}
+
+// ConditionalOrders retrieves all conditional orders, sorted by creation time.
+func (db *LexiDB) ConditionalOrders() ([]*dexdb.ConditionalOrder, error) {
+	ords, err := jsonRecords[dexdb.ConditionalOrder](db.condOrders)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding conditional orders: %w", err)
+	}
+	sort.Slice(ords, func(i, j int) bool { return ords[i].Stamp < ords[j].Stamp })
+	return ords, nil
+}
+
+// UpdateRoutedOrder saves the routed order, overwriting any existing entry
+// with the same ID.
+func (db *LexiDB) UpdateRoutedOrder(ord *dexdb.RoutedOrder) error {
+	if len(ord.ID) == 0 {
+		return fmt.Errorf("no routed order ID")
+	}
+	return db.routedOrders.Set([]byte(ord.ID), lexi.JSON(ord))
+}
+
+// RoutedOrders retrieves all routed orders, sorted by creation time.
+func (db *LexiDB) RoutedOrders() ([]*dexdb.RoutedOrder, error) {
+	ords, err := jsonRecords[dexdb.RoutedOrder](db.routedOrders)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding routed orders: %w", err)
+	}
+	sort.Slice(ords, func(i, j int) bool { return ords[i].Stamp < ords[j].Stamp })
+	return ords, nil
+}
+
+// UpdateMarketAlert saves the market alert, overwriting any existing entry
+// with the same ID.
+func (db *LexiDB) UpdateMarketAlert(alert *dexdb.MarketAlert) error {
+	if len(alert.ID) == 0 {
+		return fmt.Errorf("no market alert ID")
+	}
+	return db.marketAlerts.Set([]byte(alert.ID), lexi.JSON(alert))
+}
+
+// MarketAlerts retrieves all market alerts, sorted by creation time.
+func (db *LexiDB) MarketAlerts() ([]*dexdb.MarketAlert, error) {
+	alerts, err := jsonRecords[dexdb.MarketAlert](db.marketAlerts)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding market alerts: %w", err)
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Stamp < alerts[j].Stamp })
+	return alerts, nil
+}
+
+// DeleteMarketAlert deletes the market alert with the ID.
+func (db *LexiDB) DeleteMarketAlert(id []byte) error {
+	b, err := get(db.marketAlerts, id)
+	if err != nil {
+		return err
+	}
+	if b == nil {
+		return fmt.Errorf("market alert %x not found", id)
+	}
+	return db.marketAlerts.Delete(id)
+}
+
+// UpdateMeshBond saves a bond posted to the Tatanka Mesh, overwriting any
+// existing entry for the same bond.
+func (db *LexiDB) UpdateMeshBond(bond *dexdb.Bond) error {
+	return db.meshBonds.Set(bond.UniqueID(), lexi.JSON(bond))
+}
+
+// MeshBonds retrieves all bonds posted to the Tatanka Mesh, sorted by lock
+// time.
+func (db *LexiDB) MeshBonds() ([]*dexdb.Bond, error) {
+	bonds, err := jsonRecords[dexdb.Bond](db.meshBonds)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding mesh bonds: %w", err)
+	}
+	sort.Slice(bonds, func(i, j int) bool { return bonds[i].LockTime < bonds[j].LockTime })
+	return bonds, nil
+}
+
+// fiatRateInterval is the period of the stored fiat rate snapshots.
+const fiatRateInterval = uint64(time.Hour / time.Millisecond)
+
+// fiatRateRecord is a JSON-encoded FiatRateSnapshot.
+type fiatRateRecord struct {
+	*dexdb.FiatRateSnapshot
+}
+
+func (r *fiatRateRecord) MarshalBinary() ([]byte, error) {
+	return json.Marshal(r.FiatRateSnapshot)
+}
+
+// StoreFiatRates stores a snapshot of fiat exchange rates. Snapshots are
+// keyed by the hour, so a later snapshot in the same hour replaces an earlier
+// one.
+func (db *LexiDB) StoreFiatRates(snap *dexdb.FiatRateSnapshot) error {
+	return db.fiatRates.Set(uint64Bytes(snap.Stamp/fiatRateInterval), &fiatRateRecord{snap})
+}
+
+// FiatRateHistory retrieves the fiat rate snapshots with stamps in the range
+// [from, to], sorted by time.
+func (db *LexiDB) FiatRateHistory(from, to uint64) (snaps []*dexdb.FiatRateSnapshot, _ error) {
+	return snaps, db.fiatRatesStampIdx.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			snap := new(dexdb.FiatRateSnapshot)
+			if err := json.Unmarshal(vB, snap); err != nil {
+				return fmt.Errorf("error decoding fiat rate snapshot: %w", err)
+			}
+			if snap.Stamp > to {
+				return lexi.ErrEndIteration
+			}
+			if snap.Stamp >= from {
+				snaps = append(snaps, snap)
+			}
+			return nil
+		})
+	}, lexi.WithSeek(uint64Bytes(from)))
+}
+
+// SetLanguage stores the language.
+func (db *LexiDB) SetLanguage(lang string) error {
+	return db.meta.Set(langKey, []byte(lang))
+}
+
+// Language retrieves the language stored with SetLanguage. If no language
+// has been stored, an empty string is returned without an error.
+func (db *LexiDB) Language() (string, error) {
+	b, err := get(db.meta, langKey)
+	return string(b), err
+}
+
+// timeNow is the current unix timestamp in milliseconds.
+func timeNow() uint64 {
+	return uint64(time.Now().UnixMilli())
+}
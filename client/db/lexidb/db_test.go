@@ -0,0 +1,965 @@
+package lexidb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/db"
+	dbtest "decred.org/dcrdex/client/db/test"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/order"
+	ordertest "decred.org/dcrdex/dex/order/test"
+)
+
+var (
+	tLogger = dex.StdOutLogger("db_TEST", dex.LevelInfo)
+)
+
+func newTestDB(t *testing.T, opts ...Opts) (*LexiDB, func()) {
+	t.Helper()
+	return openTestDB(t, filepath.Join(t.TempDir(), "db.lexi"), opts...)
+}
+
+func openTestDB(t *testing.T, dbPath string, opts ...Opts) (*LexiDB, func()) {
+	t.Helper()
+	dbi, err := NewDB(dbPath, tLogger, opts...)
+	if err != nil {
+		t.Fatalf("error creating dB: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		dbi.Run(ctx)
+	}()
+	db, ok := dbi.(*LexiDB)
+	if !ok {
+		t.Fatalf("DB is not a *LexiDB")
+	}
+	shutdown := func() {
+		cancel()
+		wg.Wait()
+	}
+	return db, shutdown
+}
+
+var randU32 = func() uint32 { return uint32(rand.Int31()) }
+
+func randBytes(l int) []byte {
+	b := make([]byte, l)
+	rand.Read(b)
+	return b
+}
+
+func TestBackup(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	if err := lexidb.Backup(); err != nil {
+		t.Fatalf("unable to backup database: %v", err)
+	}
+	path := filepath.Join(filepath.Dir(lexidb.path), backupDir, filepath.Base(lexidb.path)+".bak")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("backup file does not exist: %v", err)
+	}
+	// Overwrite the backup.
+	if err := lexidb.Backup(); err != nil {
+		t.Fatalf("unable to overwrite backup: %v", err)
+	}
+	// Without overwrite.
+	if err := lexidb.BackupTo(path, false, false); err == nil {
+		t.Fatalf("no error for existing backup file without overwrite")
+	}
+	// Not into the db directory.
+	if err := lexidb.BackupTo(filepath.Join(lexidb.path, "backup.bak"), true, false); err == nil {
+		t.Fatalf("no error for backup into the database directory")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	if err := lexidb.SetLanguage("pt-BR"); err != nil {
+		t.Fatalf("error setting language: %v", err)
+	}
+	ai := dbtest.RandomAccountInfo()
+	if err := lexidb.CreateAccount(ai); err != nil {
+		t.Fatalf("error creating account: %v", err)
+	}
+	backupPath := filepath.Join(t.TempDir(), "db.bak")
+	if err := lexidb.BackupTo(backupPath, false, false); err != nil {
+		t.Fatalf("error backing up database: %v", err)
+	}
+
+	// Not over an existing database.
+	if err := Restore(backupPath, lexidb.path); err == nil {
+		t.Fatalf("no error restoring over an existing database")
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "restored.lexi")
+	if err := Restore(backupPath, dbPath); err != nil {
+		t.Fatalf("error restoring database: %v", err)
+	}
+	restored, shutdownRestored := openTestDB(t, dbPath)
+	defer shutdownRestored()
+
+	lang, err := restored.Language()
+	if err != nil {
+		t.Fatalf("error getting language: %v", err)
+	}
+	if lang != "pt-BR" {
+		t.Fatalf("wrong restored language %q", lang)
+	}
+	reAI, err := restored.Account(ai.Host)
+	if err != nil {
+		t.Fatalf("error getting restored account: %v", err)
+	}
+	dbtest.MustCompareAccountInfo(t, ai, reAI)
+}
+
+func TestAccounts(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	if _, err := lexidb.Account("nope"); !errors.Is(err, db.ErrAcctNotFound) {
+		t.Fatalf("expected ErrAcctNotFound, got %v", err)
+	}
+
+	const numAccts = 10
+	accts := make(map[string]*db.AccountInfo, numAccts)
+	for i := 0; i < numAccts; i++ {
+		ai := dbtest.RandomAccountInfo()
+		accts[ai.Host] = ai
+		if err := lexidb.CreateAccount(ai); err != nil {
+			t.Fatalf("CreateAccount error: %v", err)
+		}
+	}
+	var host string
+	for host = range accts {
+		break
+	}
+	if err := lexidb.CreateAccount(accts[host]); err == nil {
+		t.Fatalf("no error for duplicate account")
+	}
+
+	urls, err := lexidb.ListAccounts()
+	if err != nil {
+		t.Fatalf("ListAccounts error: %v", err)
+	}
+	if len(urls) != numAccts {
+		t.Fatalf("expected %d accounts, got %d", numAccts, len(urls))
+	}
+	for i := 1; i < len(urls); i++ {
+		if urls[i-1] > urls[i] {
+			t.Fatalf("accounts not sorted")
+		}
+	}
+
+	// Bonds.
+	ai := accts[host]
+	bond := &db.Bond{
+		Version:   0,
+		AssetID:   42,
+		CoinID:    randBytes(36),
+		Amount:    1e8,
+		LockTime:  uint64(time.Now().Unix()),
+		KeyIndex:  1,
+		RefundTx:  randBytes(120),
+		Confirmed: false,
+	}
+	if err := lexidb.AddBond("nope", bond); err == nil {
+		t.Fatalf("no error adding bond for unknown account")
+	}
+	if err := lexidb.AddBond(host, bond); err != nil {
+		t.Fatalf("AddBond error: %v", err)
+	}
+	if err := lexidb.ConfirmBond(host, bond.AssetID, bond.CoinID); err != nil {
+		t.Fatalf("ConfirmBond error: %v", err)
+	}
+	if err := lexidb.BondRefunded(host, bond.AssetID, randBytes(36)); err == nil {
+		t.Fatalf("no error refunding unknown bond")
+	}
+	reAI, err := lexidb.Account(host)
+	if err != nil {
+		t.Fatalf("Account error: %v", err)
+	}
+	dbtest.MustCompareAccountInfo(t, ai, reAI)
+	var found bool
+	for _, b := range reAI.Bonds {
+		if bytes.Equal(b.UniqueID(), bond.UniqueID()) {
+			found = true
+			if !b.Confirmed || b.Refunded {
+				t.Fatalf("wrong bond status")
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("bond not found")
+	}
+
+	// Disable the account.
+	if err := lexidb.ToggleAccountStatus(host, false); err == nil {
+		t.Fatalf("no error enabling enabled account")
+	}
+	if err := lexidb.ToggleAccountStatus(host, true); err != nil {
+		t.Fatalf("ToggleAccountStatus error: %v", err)
+	}
+	// UpdateAccountInfo doesn't change the status.
+	if err := lexidb.UpdateAccountInfo(ai); err != nil {
+		t.Fatalf("UpdateAccountInfo error: %v", err)
+	}
+	urls, _ = lexidb.ListAccounts()
+	if len(urls) != numAccts-1 {
+		t.Fatalf("disabled account was listed")
+	}
+	allAccts, err := lexidb.Accounts()
+	if err != nil {
+		t.Fatalf("Accounts error: %v", err)
+	}
+	if len(allAccts) != numAccts {
+		t.Fatalf("expected %d accounts, got %d", numAccts, len(allAccts))
+	}
+
+	// Bond key indexes.
+	for i := uint32(0); i < 3; i++ {
+		idx, err := lexidb.NextBondKeyIndex(42)
+		if err != nil {
+			t.Fatalf("NextBondKeyIndex error: %v", err)
+		}
+		if idx != i {
+			t.Fatalf("expected bond key index %d, got %d", i, idx)
+		}
+	}
+}
+
+func TestWallets(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	const numWallets = 10
+	wallets := make(map[string]*db.Wallet, numWallets)
+	for i := 0; i < numWallets; i++ {
+		w := dbtest.RandomWallet()
+		wallets[string(w.ID())] = w
+		if err := lexidb.UpdateWallet(w); err != nil {
+			t.Fatalf("UpdateWallet error: %v", err)
+		}
+	}
+	stored, err := lexidb.Wallets()
+	if err != nil {
+		t.Fatalf("Wallets error: %v", err)
+	}
+	if len(stored) != numWallets {
+		t.Fatalf("expected %d wallets, got %d", numWallets, len(stored))
+	}
+	for _, w := range stored {
+		dbtest.MustCompareWallets(t, w, wallets[string(w.ID())])
+	}
+
+	w := stored[0]
+	if err := lexidb.UpdateWalletStatus(w.ID(), true); err != nil {
+		t.Fatalf("UpdateWalletStatus error: %v", err)
+	}
+	// UpdateWallet doesn't change the status.
+	if err := lexidb.UpdateWallet(w); err != nil {
+		t.Fatalf("UpdateWallet error: %v", err)
+	}
+	newPW := randBytes(32)
+	if err := lexidb.SetWalletPassword(w.ID(), newPW); err != nil {
+		t.Fatalf("SetWalletPassword error: %v", err)
+	}
+	reW, err := lexidb.Wallet(w.ID())
+	if err != nil {
+		t.Fatalf("Wallet error: %v", err)
+	}
+	if !reW.Disabled {
+		t.Fatalf("wallet status not stored")
+	}
+	if string(reW.EncryptedPW) != string(newPW) {
+		t.Fatalf("wallet password not stored")
+	}
+	if _, err := lexidb.Wallet(randBytes(4)); err == nil {
+		t.Fatalf("no error for unknown wallet")
+	}
+	w.Balance = nil
+	if err := lexidb.UpdateWallet(w); err == nil {
+		t.Fatalf("no error for nil balance")
+	}
+}
+
+func TestOrders(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	host1, host2 := "somehost.co", "anotherhost.org"
+	base, quote := randU32(), randU32()
+
+	const numOrders = 120
+	var active, host1Mkt int
+	ords := make(map[order.OrderID]*db.MetaOrder, numOrders)
+	for i := 0; i < numOrders; i++ {
+		host := host1
+		if i%2 == 1 {
+			host = host2
+		}
+		o, _ := ordertest.RandomLimitOrder()
+		if i%3 == 0 {
+			o.BaseAsset, o.QuoteAsset = base, quote
+			if host == host1 {
+				host1Mkt++
+			}
+		}
+		status := order.OrderStatusExecuted
+		if i%4 == 0 {
+			status = order.OrderStatusBooked
+			if host == host1 {
+				active++
+			}
+		}
+		mo := &db.MetaOrder{
+			MetaData: &db.OrderMetaData{
+				Status: status,
+				Host:   host,
+				Proof:  db.OrderProof{DEXSig: randBytes(73)},
+			},
+			Order: o,
+		}
+		if err := lexidb.UpdateOrder(mo); err != nil {
+			t.Fatalf("UpdateOrder error: %v", err)
+		}
+		ords[o.ID()] = mo
+	}
+
+	activeOrds, err := lexidb.ActiveDEXOrders(host1)
+	if err != nil {
+		t.Fatalf("ActiveDEXOrders error: %v", err)
+	}
+	if len(activeOrds) != active {
+		t.Fatalf("expected %d active orders, got %d", active, len(activeOrds))
+	}
+
+	mktOrds, err := lexidb.MarketOrders(host1, base, quote, 0, 0)
+	if err != nil {
+		t.Fatalf("MarketOrders error: %v", err)
+	}
+	if len(mktOrds) != host1Mkt {
+		t.Fatalf("expected %d market orders, got %d", host1Mkt, len(mktOrds))
+	}
+	for _, mo := range mktOrds {
+		ordertest.MustCompareOrders(t, mo.Order, ords[mo.Order.ID()].Order)
+	}
+
+	acctOrds, err := lexidb.AccountOrders(host2, 5, 0)
+	if err != nil {
+		t.Fatalf("AccountOrders error: %v", err)
+	}
+	if len(acctOrds) != 5 {
+		t.Fatalf("expected 5 account orders, got %d", len(acctOrds))
+	}
+	for _, mo := range acctOrds {
+		if mo.MetaData.Host != host2 {
+			t.Fatalf("wrong host")
+		}
+	}
+
+	// Deactivate an order. The host isn't updated.
+	mo := activeOrds[0]
+	oid := mo.Order.ID()
+	md := *mo.MetaData
+	md.Status = order.OrderStatusCanceled
+	md.Host = host2
+	md.RefundFeesPaid = 1234
+	if err := lexidb.UpdateOrderMetaData(oid, &md); err != nil {
+		t.Fatalf("UpdateOrderMetaData error: %v", err)
+	}
+	reMO, err := lexidb.Order(oid)
+	if err != nil {
+		t.Fatalf("Order error: %v", err)
+	}
+	if reMO.MetaData.Status != order.OrderStatusCanceled || reMO.MetaData.Host != host1 || reMO.MetaData.RefundFeesPaid != 1234 {
+		t.Fatalf("wrong order metadata %+v", reMO.MetaData)
+	}
+
+	// Version 0 records have no refund fees.
+	recB, err := (&orderRecord{MetaOrder: reMO}).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary error: %v", err)
+	}
+	_, pushes, err := encode.DecodeBlob(recB)
+	if err != nil {
+		t.Fatalf("DecodeBlob error: %v", err)
+	}
+	v0 := encode.BuildyBytes{0}
+	for _, push := range pushes[:21] {
+		v0 = v0.AddData(push)
+	}
+	rec, err := decodeOrderRecord(v0)
+	if err != nil {
+		t.Fatalf("error decoding version 0 order record: %v", err)
+	}
+	if rec.MetaData.RefundFeesPaid != 0 || rec.MetaData.Status != order.OrderStatusCanceled {
+		t.Fatalf("wrong version 0 order metadata %+v", rec.MetaData)
+	}
+	if activeOrds, _ = lexidb.ActiveDEXOrders(host1); len(activeOrds) != active-1 {
+		t.Fatalf("canceled order still indexed as active")
+	}
+
+	linked := ordertest.RandomOrderID()
+	if err := lexidb.LinkOrder(oid, linked); err != nil {
+		t.Fatalf("LinkOrder error: %v", err)
+	}
+	if reMO, _ = lexidb.Order(oid); reMO.MetaData.LinkedOrder != linked {
+		t.Fatalf("linked order not stored")
+	}
+	if err := lexidb.UpdateOrderStatus(ordertest.RandomOrderID(), order.OrderStatusBooked); err == nil {
+		t.Fatalf("no error updating unknown order")
+	}
+
+	mo.MetaData.Proof.DEXSig = nil
+	if err := lexidb.UpdateOrder(mo); err == nil {
+		t.Fatalf("no error for order without DEX signature")
+	}
+}
+
+func TestOrderFilters(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	makeOrder := func(host string, base, quote uint32, stamp int64, status order.OrderStatus) *db.MetaOrder {
+		mord := &db.MetaOrder{
+			MetaData: &db.OrderMetaData{
+				Status: status,
+				Host:   host,
+				Proof:  db.OrderProof{DEXSig: randBytes(73)},
+			},
+			Order: &order.LimitOrder{
+				P: order.Prefix{
+					BaseAsset:  base,
+					QuoteAsset: quote,
+					ServerTime: time.UnixMilli(stamp),
+				},
+			},
+		}
+		oid := mord.Order.ID()
+		// Set the update time.
+		if err := lexidb.orders.Set(oid[:], &orderRecord{MetaOrder: mord, stamp: uint64(stamp)}); err != nil {
+			t.Fatalf("error inserting order: %v", err)
+		}
+		return mord
+	}
+
+	var start int64
+	host1 := "somehost.co"
+	host2 := "anotherhost.org"
+	var asset1 uint32 = 1
+	var asset2 uint32 = 2
+	var asset3 uint32 = 3
+	orders := []*db.MetaOrder{
+		makeOrder(host1, asset1, asset2, start, order.OrderStatusExecuted),   // 0, oid: 95318d1d4d1d19348d96f260e6d54eca942ce9bf0760f43edc7afa2c0173a401
+		makeOrder(host2, asset2, asset3, start+1, order.OrderStatusRevoked),  // 1, oid: 58148721dd3109647fd912fb1b3e29be7c72e72cf589dcbe5d0697735e1df8bb
+		makeOrder(host1, asset3, asset1, start+2, order.OrderStatusCanceled), // 2, oid: feea1852996c042174a40a88e74175f95009ce72b31d51402f452b28f2618a13
+		makeOrder(host2, asset1, asset3, start+3, order.OrderStatusEpoch),    // 3, oid: 8707caf2e70bc615845673cf30e44c67dec972064ab137a321d9ee98e8c96fe3
+		makeOrder(host1, asset2, asset3, start+4, order.OrderStatusBooked),   // 4, oid: e2fe7b28eae9a4511013ecb35be58e8031e5f7ec9f3a0e2f6411ec58efd4464a
+		makeOrder(host2, asset3, asset1, start+4, order.OrderStatusExecuted), // 5, oid: c76d4dfbc4ea8e0e8065e809f9c3ebfca98a1053a42f464e7632f79126f752d0
+	}
+	orderCount := len(orders)
+
+	tests := []struct {
+		name     string
+		filter   *db.OrderFilter
+		expected []int
+	}{
+		{
+			name: "zero-filter",
+			filter: &db.OrderFilter{
+				N: orderCount,
+			},
+			expected: []int{4, 5, 3, 2, 1, 0},
+		},
+		{
+			name: "all-hosts",
+			filter: &db.OrderFilter{
+				N:     orderCount,
+				Hosts: []string{host1, host2},
+			},
+			expected: []int{4, 5, 3, 2, 1, 0},
+		},
+		{
+			name: "host1",
+			filter: &db.OrderFilter{
+				N:     orderCount,
+				Hosts: []string{host1},
+			},
+			expected: []int{4, 2, 0},
+		},
+		{
+			name: "host1 + asset1",
+			filter: &db.OrderFilter{
+				N:      orderCount,
+				Hosts:  []string{host1},
+				Assets: []uint32{asset1},
+			},
+			expected: []int{2, 0},
+		},
+		{
+			name: "host1 + market",
+			filter: &db.OrderFilter{
+				N:      orderCount,
+				Hosts:  []string{host1},
+				Market: &db.OrderFilterMarket{Base: asset3, Quote: asset1},
+			},
+			expected: []int{2},
+		},
+		{
+			name: "asset1",
+			filter: &db.OrderFilter{
+				N:      orderCount,
+				Assets: []uint32{asset1},
+			},
+			expected: []int{5, 3, 2, 0},
+		},
+		{
+			name: "limit",
+			filter: &db.OrderFilter{
+				N: 2,
+			},
+			expected: []int{4, 5},
+		},
+		// Open filter with last order as Offset should return all but that
+		// order, since order 5 is lexicographically after order 4.
+		{
+			name: "offset",
+			filter: &db.OrderFilter{
+				N:      orderCount,
+				Offset: orders[5].Order.ID(),
+			},
+			expected: []int{4, 3, 2, 1, 0},
+		},
+		{
+			name: "host offset",
+			filter: &db.OrderFilter{
+				N:      orderCount,
+				Hosts:  []string{host1},
+				Offset: orders[2].Order.ID(),
+			},
+			expected: []int{0},
+		},
+		{
+			name: "epoch & booked",
+			filter: &db.OrderFilter{
+				N:        orderCount,
+				Statuses: []order.OrderStatus{order.OrderStatusEpoch, order.OrderStatusBooked},
+			},
+			expected: []int{4, 3},
+		},
+	}
+
+	for _, test := range tests {
+		ords, err := lexidb.Orders(test.filter)
+		if err != nil {
+			t.Fatalf("%s: Orders error: %v", test.name, err)
+		}
+		if len(ords) != len(test.expected) {
+			t.Fatalf("%s: wrong number of orders. wanted %d, got %d", test.name, len(test.expected), len(ords))
+		}
+		for i, j := range test.expected {
+			if ords[i].Order.ID() != orders[j].Order.ID() {
+				t.Fatalf("%s: index %d wrong ID. wanted %s, got %s", test.name, i, orders[j].Order.ID(), ords[i].Order.ID())
+			}
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	base, quote := randU32(), randU32()
+	acct := dbtest.RandomAccountInfo()
+
+	const numToDo, numActive = 48, 16
+	metaMatches := make([]*db.MetaMatch, 0, numToDo)
+	matchIndex := make(map[order.MatchID]*db.MetaMatch, numToDo)
+	for i := 0; i < numToDo; i++ {
+		m := &db.MetaMatch{
+			MetaData: &db.MatchMetaData{
+				Proof: *dbtest.RandomMatchProof(0.5),
+				DEX:   acct.Host,
+				Base:  base,
+				Quote: quote,
+				Stamp: rand.Uint64(),
+			},
+			UserMatch: ordertest.RandomUserMatch(),
+		}
+		if i < numActive {
+			m.Status = order.MatchStatus(rand.Intn(4))
+		} else {
+			m.Status = order.MatchConfirmed             // inactive
+			m.MetaData.Proof.Auth.RedeemSig = []byte{0} // redeemSig required for MatchComplete to be considered inactive
+		}
+		// Two matches per order.
+		if i%2 == 1 {
+			m.OrderID = metaMatches[i-1].OrderID
+		}
+		matchIndex[m.MatchID] = m
+		metaMatches = append(metaMatches, m)
+		if err := lexidb.UpdateMatch(m); err != nil {
+			t.Fatalf("update error: %v", err)
+		}
+	}
+
+	activeMatches, err := lexidb.ActiveMatches()
+	if err != nil {
+		t.Fatalf("error getting active matches: %v", err)
+	}
+	if len(activeMatches) != numActive {
+		t.Fatalf("expected %d active matches, got %d", numActive, len(activeMatches))
+	}
+	activeOrders := make(map[order.OrderID]bool)
+	for _, m1 := range activeMatches {
+		activeOrders[m1.OrderID] = true
+		m2 := matchIndex[m1.MatchID]
+		ordertest.MustCompareUserMatch(t, m1.UserMatch, m2.UserMatch)
+		dbtest.MustCompareMatchMetaData(t, m1.MetaData, m2.MetaData)
+	}
+
+	activeMatchOrders, err := lexidb.DEXOrdersWithActiveMatches(acct.Host)
+	if err != nil {
+		t.Fatalf("error retrieving active match orders: %v", err)
+	}
+	if len(activeMatchOrders) != len(activeOrders) {
+		t.Fatalf("wrong number of DEXOrdersWithActiveMatches returned. expected %d, got %d", len(activeOrders), len(activeMatchOrders))
+	}
+	for _, oid := range activeMatchOrders {
+		if !activeOrders[oid] {
+			t.Fatalf("active match order ID mismatch")
+		}
+	}
+
+	ordMatches, err := lexidb.MatchesForOrder(metaMatches[numToDo-1].OrderID, false)
+	if err != nil {
+		t.Fatalf("MatchesForOrder error: %v", err)
+	}
+	if len(ordMatches) != 2 {
+		t.Fatalf("expected 2 matches for order, got %d", len(ordMatches))
+	}
+
+	m := metaMatches[0]
+	m.MetaData.DEX = ""
+	if err := lexidb.UpdateMatch(m); err == nil {
+		t.Fatalf("no error on empty DEX")
+	}
+	m.MetaData.DEX = acct.Host
+	m.MetaData.Base, m.MetaData.Quote = 0, 0
+	if err := lexidb.UpdateMatch(m); err == nil {
+		t.Fatalf("no error on same base and quote")
+	}
+}
+
+func TestDeleteInactive(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	host := "somehost.co"
+	const numOrders = 30
+	var oldInactive []order.OrderID
+	var activeOID order.OrderID
+	for i := 0; i < numOrders; i++ {
+		o, _ := ordertest.RandomLimitOrder()
+		status := order.OrderStatusExecuted
+		if i == 0 {
+			status = order.OrderStatusBooked
+			activeOID = o.ID()
+		}
+		mo := &db.MetaOrder{
+			MetaData: &db.OrderMetaData{
+				Status: status,
+				Host:   host,
+				Proof:  db.OrderProof{DEXSig: randBytes(73)},
+			},
+			Order: o,
+		}
+		stamp := uint64(i) // old
+		if i%3 == 0 {
+			stamp = timeNow() + 1e6 // new
+		} else if i > 0 {
+			oldInactive = append(oldInactive, o.ID())
+		}
+		oid := o.ID()
+		if err := lexidb.orders.Set(oid[:], &orderRecord{MetaOrder: mo, stamp: stamp}); err != nil {
+			t.Fatalf("error storing order: %v", err)
+		}
+	}
+
+	// An old inactive order with an active match is kept.
+	m := &db.MetaMatch{
+		MetaData: &db.MatchMetaData{
+			Proof: *dbtest.RandomMatchProof(0.5),
+			DEX:   host,
+			Base:  1,
+			Quote: 2,
+			Stamp: 1,
+		},
+		UserMatch: ordertest.RandomUserMatch(),
+	}
+	m.Status = order.NewlyMatched
+	m.OrderID = oldInactive[0]
+	if err := lexidb.UpdateMatch(m); err != nil {
+		t.Fatalf("UpdateMatch error: %v", err)
+	}
+	// An old inactive match for the active order is kept.
+	m2 := &db.MetaMatch{
+		MetaData: &db.MatchMetaData{
+			Proof: *dbtest.RandomMatchProof(0.5),
+			DEX:   host,
+			Base:  1,
+			Quote: 2,
+			Stamp: 1,
+		},
+		UserMatch: ordertest.RandomUserMatch(),
+	}
+	m2.Status = order.MatchConfirmed
+	m2.MetaData.Proof.Auth.RedeemSig = []byte{0}
+	m2.OrderID = activeOID
+	// And an old inactive match for an archived order is deleted.
+	m3 := &db.MetaMatch{
+		MetaData: &db.MatchMetaData{
+			Proof: *dbtest.RandomMatchProof(0.5),
+			DEX:   host,
+			Base:  1,
+			Quote: 2,
+			Stamp: 1,
+		},
+		UserMatch: ordertest.RandomUserMatch(),
+	}
+	m3.Status = order.MatchConfirmed
+	m3.MetaData.Proof.Auth.RedeemSig = []byte{0}
+	m3.OrderID = oldInactive[1]
+	for _, m := range []*db.MetaMatch{m2, m3} {
+		if err := lexidb.UpdateMatch(m); err != nil {
+			t.Fatalf("UpdateMatch error: %v", err)
+		}
+	}
+
+	var perMatchCount int
+	n, err := lexidb.DeleteInactiveMatches(context.Background(), nil, func(m *db.MetaMatch, isSell bool) error {
+		perMatchCount++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DeleteInactiveMatches error: %v", err)
+	}
+	if n != 1 || perMatchCount != 1 {
+		t.Fatalf("expected 1 deleted match, got %d, %d", n, perMatchCount)
+	}
+
+	var perOrderCount int
+	n, err = lexidb.DeleteInactiveOrders(context.Background(), nil, func(*db.MetaOrder) error {
+		perOrderCount++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DeleteInactiveOrders error: %v", err)
+	}
+	if n != len(oldInactive)-1 || perOrderCount != n {
+		t.Fatalf("expected %d deleted orders, got %d, %d", len(oldInactive)-1, n, perOrderCount)
+	}
+	if _, err := lexidb.Order(oldInactive[0]); err != nil {
+		t.Fatalf("order with active match was deleted")
+	}
+	if _, err := lexidb.Order(oldInactive[1]); err == nil {
+		t.Fatalf("old inactive order not deleted")
+	}
+	if _, err := lexidb.Order(activeOID); err != nil {
+		t.Fatalf("active order was deleted")
+	}
+	ords, _ := lexidb.Orders(&db.OrderFilter{})
+	if len(ords) != numOrders-n {
+		t.Fatalf("expected %d orders left, got %d", numOrders-n, len(ords))
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	const numNotes = 20
+	notes := make([]*db.Notification, 0, numNotes)
+	for i := 0; i < numNotes; i++ {
+		note := dbtest.RandomNotification(uint64(i + 1))
+		note.TimeStamp = uint64(i + 1)
+		notes = append(notes, note)
+		if err := lexidb.SaveNotification(note); err != nil {
+			t.Fatalf("SaveNotification error: %v", err)
+		}
+	}
+
+	fetched, err := lexidb.NotificationsN(5)
+	if err != nil {
+		t.Fatalf("NotificationsN error: %v", err)
+	}
+	if len(fetched) != 5 {
+		t.Fatalf("expected 5 notifications, got %d", len(fetched))
+	}
+	for i, note := range fetched {
+		dbtest.MustCompareNotifications(t, note, notes[numNotes-1-i])
+	}
+
+	if err := lexidb.AckNotification(notes[numNotes-1].ID()); err != nil {
+		t.Fatalf("AckNotification error: %v", err)
+	}
+	if err := lexidb.AckNotification(randBytes(32)); err == nil {
+		t.Fatalf("no error acking unknown notification")
+	}
+	if fetched, _ = lexidb.NotificationsN(1); !fetched[0].Ack {
+		t.Fatalf("notification not acknowledged")
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	if _, err := lexidb.PrimaryCredentials(); !errors.Is(err, db.ErrNoCredentials) {
+		t.Fatalf("expected ErrNoCredentials, got %v", err)
+	}
+	if _, err := lexidb.SeedGenerationTime(); !errors.Is(err, db.ErrNoSeedGenTime) {
+		t.Fatalf("expected ErrNoSeedGenTime, got %v", err)
+	}
+
+	creds := dbtest.RandomPrimaryCredentials()
+	if err := lexidb.SetPrimaryCredentials(creds); err != nil {
+		t.Fatalf("SetPrimaryCredentials error: %v", err)
+	}
+	reCreds, err := lexidb.PrimaryCredentials()
+	if err != nil {
+		t.Fatalf("PrimaryCredentials error: %v", err)
+	}
+	if string(reCreds.EncSeed) != string(creds.EncSeed) || string(reCreds.EncInnerKey) != string(creds.EncInnerKey) ||
+		string(reCreds.InnerKeyParams) != string(creds.InnerKeyParams) || string(reCreds.OuterKeyParams) != string(creds.OuterKeyParams) ||
+		reCreds.Birthday.Unix() != creds.Birthday.Unix() || reCreds.Version != creds.Version {
+
+		t.Fatalf("wrong credentials")
+	}
+	creds.EncSeed = nil
+	if err := lexidb.SetPrimaryCredentials(creds); err == nil {
+		t.Fatalf("no error for credentials without seed")
+	}
+}
+
+func TestMarketAlerts(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	alert := &db.MarketAlert{
+		ID:     encode.RandomBytes(8),
+		Kind:   db.AlertPrice,
+		Host:   "dex.tld",
+		Base:   42,
+		Quote:  0,
+		Rate:   1e6,
+		Above:  true,
+		Repeat: true,
+		Stamp:  2,
+	}
+	older := &db.MarketAlert{ID: encode.RandomBytes(8), Kind: db.AlertFiat, Base: 42, Percent: 5, RefFiatRate: 20, Stamp: 1}
+	for _, a := range []*db.MarketAlert{alert, older} {
+		if err := lexidb.UpdateMarketAlert(a); err != nil {
+			t.Fatalf("UpdateMarketAlert error: %v", err)
+		}
+	}
+	if err := lexidb.UpdateMarketAlert(&db.MarketAlert{}); err == nil {
+		t.Fatalf("no error for market alert without an ID")
+	}
+
+	// Update one.
+	older.Status = db.MarketAlertTriggered
+	older.FiredStamp = 3
+	if err := lexidb.UpdateMarketAlert(older); err != nil {
+		t.Fatalf("UpdateMarketAlert (update) error: %v", err)
+	}
+
+	alerts, err := lexidb.MarketAlerts()
+	if err != nil {
+		t.Fatalf("MarketAlerts error: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 market alerts, got %d", len(alerts))
+	}
+	if !reflect.DeepEqual(older, alerts[0]) || !reflect.DeepEqual(alert, alerts[1]) {
+		t.Fatalf("market alerts mismatch")
+	}
+
+	if err := lexidb.DeleteMarketAlert(older.ID); err != nil {
+		t.Fatalf("DeleteMarketAlert error: %v", err)
+	}
+	if err := lexidb.DeleteMarketAlert(older.ID); err == nil {
+		t.Fatalf("no error deleting unknown market alert")
+	}
+	if alerts, _ = lexidb.MarketAlerts(); len(alerts) != 1 {
+		t.Fatalf("expected 1 market alert after deletion, got %d", len(alerts))
+	}
+}
+
+func TestMeshBonds(t *testing.T) {
+	lexidb, shutdown := newTestDB(t)
+	defer shutdown()
+
+	bond := &db.Bond{
+		Version:    0,
+		AssetID:    42,
+		CoinID:     encode.RandomBytes(36),
+		UnsignedTx: encode.RandomBytes(200),
+		SignedTx:   encode.RandomBytes(200),
+		Data:       encode.RandomBytes(50),
+		Amount:     1e8,
+		LockTime:   2,
+		KeyIndex:   3,
+		RefundTx:   encode.RandomBytes(150),
+		Strength:   1,
+	}
+	earlier := &db.Bond{
+		AssetID:    0,
+		CoinID:     encode.RandomBytes(36),
+		UnsignedTx: encode.RandomBytes(200),
+		SignedTx:   encode.RandomBytes(200),
+		Data:       encode.RandomBytes(50),
+		Amount:     1e7,
+		LockTime:   1,
+		RefundTx:   encode.RandomBytes(150),
+		Strength:   2,
+	}
+	for _, b := range []*db.Bond{bond, earlier} {
+		if err := lexidb.UpdateMeshBond(b); err != nil {
+			t.Fatalf("UpdateMeshBond error: %v", err)
+		}
+	}
+
+	// Update one.
+	earlier.Refunded = true
+	if err := lexidb.UpdateMeshBond(earlier); err != nil {
+		t.Fatalf("UpdateMeshBond (update) error: %v", err)
+	}
+
+	bonds, err := lexidb.MeshBonds()
+	if err != nil {
+		t.Fatalf("MeshBonds error: %v", err)
+	}
+	if len(bonds) != 2 {
+		t.Fatalf("expected 2 mesh bonds, got %d", len(bonds))
+	}
+	if !reflect.DeepEqual(earlier, bonds[0]) || !reflect.DeepEqual(bond, bonds[1]) {
+		t.Fatalf("mesh bonds mismatch")
+	}
+}
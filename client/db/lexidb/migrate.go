@@ -0,0 +1,218 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package lexidb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	dexdb "decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/client/db/bolt"
+	"decred.org/dcrdex/dex"
+	"github.com/dgraph-io/badger"
+)
+
+// migrateBolt copies the contents of the bolt database at boltPath to a new
+// lexi database at dbPath. The data is written to a temporary directory,
+// which is renamed to dbPath when the migration is complete, so that an
+// interrupted migration is started over on the next run. The bolt database is
+// left in place, though its own schema upgrades are applied and its saved
+// pokes are consumed.
+func migrateBolt(boltPath, dbPath string, log dex.Logger) error {
+	start := time.Now()
+	log.Infof("Migrating bolt database %s to %s", boltPath, dbPath)
+
+	bdb, err := bolt.NewDB(boltPath, log, bolt.Opts{BackupOnShutdown: false})
+	if err != nil {
+		return fmt.Errorf("error opening bolt database: %w", err)
+	}
+	boltDB, ok := bdb.(*bolt.BoltDB)
+	if !ok {
+		return fmt.Errorf("unexpected bolt database type %T", bdb)
+	}
+	defer boltDB.Close()
+
+	tmpPath := dbPath + ".migrating"
+	if err := os.RemoveAll(tmpPath); err != nil {
+		return fmt.Errorf("error removing incomplete migration: %w", err)
+	}
+	db, err := openDB(tmpPath, log)
+	if err != nil {
+		return err
+	}
+	if err := db.copyBolt(boltDB); err != nil {
+		db.closeDB()
+		return err
+	}
+	db.closeDB()
+
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		return fmt.Errorf("error moving migrated database into place: %w", err)
+	}
+
+	log.Infof("Migrated bolt database in %v", time.Since(start))
+	return nil
+}
+
+// copyBolt copies all of the bolt database's records.
+func (db *LexiDB) copyBolt(bdb *bolt.BoltDB) error {
+	if err := db.meta.Set(versionKey, uint32Bytes(DBVersion)); err != nil {
+		return fmt.Errorf("error setting db version: %w", err)
+	}
+
+	creds, err := bdb.PrimaryCredentials()
+	switch {
+	case err == nil:
+		if err := db.SetPrimaryCredentials(creds); err != nil {
+			return fmt.Errorf("error storing credentials: %w", err)
+		}
+	case !errors.Is(err, dexdb.ErrNoCredentials):
+		return fmt.Errorf("error loading credentials: %w", err)
+	}
+
+	seedGenTime, err := bdb.SeedGenerationTime()
+	switch {
+	case err == nil:
+		if err := db.SetSeedGenerationTime(seedGenTime); err != nil {
+			return fmt.Errorf("error storing seed generation time: %w", err)
+		}
+	case !errors.Is(err, dexdb.ErrNoSeedGenTime):
+		return fmt.Errorf("error loading seed generation time: %w", err)
+	}
+
+	accts, err := bdb.Accounts()
+	if err != nil {
+		return fmt.Errorf("error loading accounts: %w", err)
+	}
+	for _, ai := range accts {
+		if err := db.Update(func(txn *badger.Txn) error {
+			if err := db.accounts.SetTxn(txn, []byte(ai.Host), &accountRecord{ai}); err != nil {
+				return err
+			}
+			return db.storeBonds(txn, ai.Host, ai.Bonds)
+		}); err != nil {
+			return fmt.Errorf("error storing account %s: %w", ai.Host, err)
+		}
+	}
+
+	bondIdxs, err := bdb.BondKeyIndexes()
+	if err != nil {
+		return fmt.Errorf("error loading bond key indexes: %w", err)
+	}
+	for assetID, idx := range bondIdxs {
+		if err := db.bondIndexes.Set(assetID, uint32Bytes(idx)); err != nil {
+			return fmt.Errorf("error storing bond key index: %w", err)
+		}
+	}
+
+	wallets, err := bdb.Wallets()
+	if err != nil {
+		return fmt.Errorf("error loading wallets: %w", err)
+	}
+	for _, w := range wallets {
+		if err := db.wallets.Set(w.ID(), &walletRecord{w}); err != nil {
+			return fmt.Errorf("error storing %s wallet: %w", dex.BipIDSymbol(w.AssetID), err)
+		}
+	}
+
+	var nOrders, nMatches int
+	if err := bdb.ForEachOrder(func(mo *dexdb.MetaOrder, stamp uint64) error {
+		oid := mo.Order.ID()
+		nOrders++
+		return db.orders.Set(oid[:], &orderRecord{MetaOrder: mo, stamp: stamp})
+	}); err != nil {
+		return fmt.Errorf("error migrating orders: %w", err)
+	}
+	if err := bdb.ForEachMatch(func(m *dexdb.MetaMatch, active bool) error {
+		nMatches++
+		return db.matches.Set(m.MatchOrderUniqueID(), &matchRecord{MetaMatch: m, active: active})
+	}); err != nil {
+		return fmt.Errorf("error migrating matches: %w", err)
+	}
+
+	notes, err := bdb.NotificationsN(0)
+	if err != nil {
+		return fmt.Errorf("error loading notifications: %w", err)
+	}
+	for _, note := range notes {
+		if err := db.notes.Set([]byte(note.ID()), &noteRecord{note}); err != nil {
+			return fmt.Errorf("error storing notification: %w", err)
+		}
+	}
+
+	pokes, err := bdb.LoadPokes()
+	if err != nil {
+		return fmt.Errorf("error loading pokes: %w", err)
+	}
+	if len(pokes) > 0 {
+		if err := db.SavePokes(pokes); err != nil {
+			return fmt.Errorf("error storing pokes: %w", err)
+		}
+	}
+
+	rates, err := bdb.FiatRateHistory(0, math.MaxUint64)
+	if err != nil {
+		return fmt.Errorf("error loading fiat rate history: %w", err)
+	}
+	for _, snap := range rates {
+		if err := db.StoreFiatRates(snap); err != nil {
+			return fmt.Errorf("error storing fiat rates: %w", err)
+		}
+	}
+
+	lang, err := bdb.Language()
+	if err != nil {
+		return fmt.Errorf("error loading language: %w", err)
+	}
+	if lang != "" {
+		if err := db.SetLanguage(lang); err != nil {
+			return fmt.Errorf("error storing language: %w", err)
+		}
+	}
+
+	disabledSources, err := bdb.DisabledRateSources()
+	if err != nil {
+		return fmt.Errorf("error loading disabled rate sources: %w", err)
+	}
+	if err := db.SaveDisabledRateSources(disabledSources); err != nil {
+		return fmt.Errorf("error storing disabled rate sources: %w", err)
+	}
+
+	condOrders, err := bdb.ConditionalOrders()
+	if err != nil {
+		return fmt.Errorf("error loading conditional orders: %w", err)
+	}
+	for _, ord := range condOrders {
+		if err := db.UpdateConditionalOrder(ord); err != nil {
+			return fmt.Errorf("error storing conditional order: %w", err)
+		}
+	}
+
+	routedOrders, err := bdb.RoutedOrders()
+	if err != nil {
+		return fmt.Errorf("error loading routed orders: %w", err)
+	}
+	for _, ord := range routedOrders {
+		if err := db.UpdateRoutedOrder(ord); err != nil {
+			return fmt.Errorf("error storing routed order: %w", err)
+		}
+	}
+
+	alerts, err := bdb.MarketAlerts()
+	if err != nil {
+		return fmt.Errorf("error loading market alerts: %w", err)
+	}
+	for _, alert := range alerts {
+		if err := db.UpdateMarketAlert(alert); err != nil {
+			return fmt.Errorf("error storing market alert: %w", err)
+		}
+	}
+
+	db.log.Infof("Migrated %d accounts, %d wallets, %d orders, %d matches and %d notifications",
+		len(accts), len(wallets), nOrders, nMatches, len(notes))
+	return nil
+}
@@ -0,0 +1,121 @@
+package lexidb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/client/db/bolt"
+	dbtest "decred.org/dcrdex/client/db/test"
+	"decred.org/dcrdex/dex/order"
+	ordertest "decred.org/dcrdex/dex/order/test"
+)
+
+func TestMigrateBolt(t *testing.T) {
+	dir := t.TempDir()
+	boltPath := filepath.Join(dir, "dexc.db")
+
+	bdb, err := bolt.NewDB(boltPath, tLogger, bolt.Opts{})
+	if err != nil {
+		t.Fatalf("error creating bolt db: %v", err)
+	}
+
+	creds := dbtest.RandomPrimaryCredentials()
+	acct := dbtest.RandomAccountInfo()
+	wallet := dbtest.RandomWallet()
+	note := dbtest.RandomNotification(100)
+	o, _ := ordertest.RandomLimitOrder()
+	mo := &db.MetaOrder{
+		MetaData: &db.OrderMetaData{
+			Status: order.OrderStatusBooked,
+			Host:   acct.Host,
+			Proof:  db.OrderProof{DEXSig: randBytes(73)},
+		},
+		Order: o,
+	}
+	m := &db.MetaMatch{
+		MetaData: &db.MatchMetaData{
+			Proof: *dbtest.RandomMatchProof(0.5),
+			DEX:   acct.Host,
+			Base:  o.BaseAsset,
+			Quote: o.QuoteAsset,
+			Stamp: 1,
+		},
+		UserMatch: ordertest.RandomUserMatch(),
+	}
+	m.Status = order.NewlyMatched
+	m.OrderID = o.ID()
+
+	for _, err := range []error{
+		bdb.SetPrimaryCredentials(creds),
+		bdb.SetSeedGenerationTime(12345),
+		bdb.CreateAccount(acct),
+		bdb.ToggleAccountStatus(acct.Host, true),
+		bdb.UpdateWallet(wallet),
+		bdb.SaveNotification(note),
+		bdb.UpdateOrder(mo),
+		bdb.UpdateMatch(m),
+		bdb.SetLanguage("pl-PL"),
+		bdb.SaveDisabledRateSources([]string{"a", "b"}),
+	} {
+		if err != nil {
+			t.Fatalf("error populating bolt db: %v", err)
+		}
+	}
+	if _, err := bdb.NextBondKeyIndex(42); err != nil {
+		t.Fatalf("NextBondKeyIndex error: %v", err)
+	}
+	// Close the bolt db.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bdb.Run(ctx)
+	bdb.(*bolt.BoltDB).Close()
+
+	lexidb, shutdown := openTestDB(t, filepath.Join(dir, "dexc.lexi"), Opts{BoltPath: boltPath})
+	defer shutdown()
+
+	reCreds, err := lexidb.PrimaryCredentials()
+	if err != nil || string(reCreds.EncSeed) != string(creds.EncSeed) {
+		t.Fatalf("credentials not migrated: %v", err)
+	}
+	if stamp, err := lexidb.SeedGenerationTime(); err != nil || stamp != 12345 {
+		t.Fatalf("seed generation time not migrated: %d, %v", stamp, err)
+	}
+	reAcct, err := lexidb.Account(acct.Host)
+	if err != nil {
+		t.Fatalf("account not migrated: %v", err)
+	}
+	dbtest.MustCompareAccountInfo(t, acct, reAcct)
+	if !reAcct.Disabled {
+		t.Fatalf("account status not migrated")
+	}
+	reWallet, err := lexidb.Wallet(wallet.ID())
+	if err != nil {
+		t.Fatalf("wallet not migrated: %v", err)
+	}
+	dbtest.MustCompareWallets(t, reWallet, wallet)
+	notes, err := lexidb.NotificationsN(0)
+	if err != nil || len(notes) != 1 {
+		t.Fatalf("notification not migrated: %d, %v", len(notes), err)
+	}
+	dbtest.MustCompareNotifications(t, note, notes[0])
+	activeOrds, err := lexidb.ActiveDEXOrders(acct.Host)
+	if err != nil || len(activeOrds) != 1 {
+		t.Fatalf("order not migrated: %d, %v", len(activeOrds), err)
+	}
+	ordertest.MustCompareOrders(t, o, activeOrds[0].Order)
+	oids, err := lexidb.DEXOrdersWithActiveMatches(acct.Host)
+	if err != nil || len(oids) != 1 || oids[0] != o.ID() {
+		t.Fatalf("match not migrated: %v, %v", oids, err)
+	}
+	if lang, _ := lexidb.Language(); lang != "pl-PL" {
+		t.Fatalf("language not migrated")
+	}
+	if srcs, _ := lexidb.DisabledRateSources(); len(srcs) != 2 {
+		t.Fatalf("disabled rate sources not migrated")
+	}
+	if idx, _ := lexidb.NextBondKeyIndex(42); idx != 1 {
+		t.Fatalf("bond key index not migrated")
+	}
+}
@@ -0,0 +1,395 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package lexidb
+
+import (
+	"fmt"
+	"time"
+
+	dexdb "decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex/config"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/order"
+)
+
+// Short names for some commonly used imported functions.
+var (
+	intCoder    = encode.IntCoder
+	uint16Bytes = encode.Uint16Bytes
+	uint32Bytes = encode.Uint32Bytes
+	uint64Bytes = encode.Uint64Bytes
+	byteTrue    = encode.ByteTrue
+	byteFalse   = encode.ByteFalse
+)
+
+func boolBytes(b bool) []byte {
+	if b {
+		return byteTrue
+	}
+	return byteFalse
+}
+
+func isTrue(b []byte) bool {
+	return len(b) == 1 && b[0] == byteTrue[0]
+}
+
+// hostKey is the host's prefix in index entries. The zero byte terminator
+// prevents a host from matching the prefix of a longer host.
+func hostKey(host string) []byte {
+	return append([]byte(host), 0)
+}
+
+func uint64FromBytes(b []byte) uint64 {
+	if len(b) != 8 {
+		return 0
+	}
+	return intCoder.Uint64(b)
+}
+
+func uint32FromBytes(b []byte) uint32 {
+	if len(b) != 4 {
+		return 0
+	}
+	return intCoder.Uint32(b)
+}
+
+// orderRecord is a MetaOrder and the time it was last updated.
+type orderRecord struct {
+	*dexdb.MetaOrder
+	stamp uint64
+}
+
+func (r *orderRecord) MarshalBinary() ([]byte, error) {
+	md := r.MetaData
+	var accelerationsB encode.BuildyBytes
+	if len(md.AccelerationCoins) > 0 {
+		accelerationsB = encode.BuildyBytes{0}
+		for _, acceleration := range md.AccelerationCoins {
+			accelerationsB = accelerationsB.AddData(acceleration)
+		}
+	}
+	var linkedB []byte
+	if !md.LinkedOrder.IsZero() {
+		linkedB = md.LinkedOrder[:]
+	}
+	return encode.BuildyBytes{1}.
+		AddData(order.EncodeOrder(r.Order)).
+		AddData(uint64Bytes(r.stamp)).
+		AddData([]byte(md.Host)).
+		AddData(uint16Bytes(uint16(md.Status))).
+		AddData(md.Proof.Encode()).
+		AddData(md.ChangeCoin).
+		AddData(linkedB).
+		AddData(uint64Bytes(md.SwapFeesPaid)).
+		AddData(uint64Bytes(md.RedemptionFeesPaid)).
+		AddData(uint64Bytes(md.FundingFeesPaid)).
+		AddData(uint64Bytes(md.EpochDur)).
+		AddData(uint64Bytes(md.MaxFeeRate)).
+		AddData(uint64Bytes(md.RedeemMaxFeeRate)).
+		AddData(uint32Bytes(md.FromVersion)).
+		AddData(uint32Bytes(md.ToVersion)).
+		AddData(uint32Bytes(md.FromSwapConf)).
+		AddData(uint32Bytes(md.ToSwapConf)).
+		AddData(config.Data(md.Options)).
+		AddData(uint64Bytes(md.RedemptionReserves)).
+		AddData(uint64Bytes(md.RefundReserves)).
+		AddData(accelerationsB).
+		AddData(uint64Bytes(md.RefundFeesPaid)), nil
+}
+
+func decodeOrderRecord(b []byte) (*orderRecord, error) {
+	ver, pushes, err := encode.DecodeBlob(b, 22)
+	if err != nil {
+		return nil, err
+	}
+	// Version 1 added the refund fees.
+	var expPushes int
+	switch ver {
+	case 0:
+		expPushes = 21
+	case 1:
+		expPushes = 22
+	default:
+		return nil, fmt.Errorf("unknown order record version %d", ver)
+	}
+	if len(pushes) != expPushes {
+		return nil, fmt.Errorf("expected %d pushes for version %d order record, got %d", expPushes, ver, len(pushes))
+	}
+	var refundFeesPaid uint64
+	if ver > 0 {
+		refundFeesPaid = uint64FromBytes(pushes[21])
+	}
+	ord, err := order.DecodeOrder(pushes[0])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding order: %w", err)
+	}
+	proof, err := dexdb.DecodeOrderProof(pushes[4])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding order proof: %w", err)
+	}
+	var linkedID order.OrderID
+	copy(linkedID[:], pushes[6])
+	options, err := config.Parse(pushes[17])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding order options: %w", err)
+	}
+	var accelerationCoinIDs []order.CoinID
+	if len(pushes[20]) > 0 {
+		_, coinIDs, err := encode.DecodeBlob(pushes[20])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding accelerations: %w", err)
+		}
+		for _, coinID := range coinIDs {
+			accelerationCoinIDs = append(accelerationCoinIDs, order.CoinID(coinID))
+		}
+	}
+	return &orderRecord{
+		MetaOrder: &dexdb.MetaOrder{
+			MetaData: &dexdb.OrderMetaData{
+				Proof:              *proof,
+				Host:               string(pushes[2]),
+				Status:             order.OrderStatus(intCoder.Uint16(pushes[3])),
+				ChangeCoin:         order.CoinID(pushes[5]),
+				LinkedOrder:        linkedID,
+				SwapFeesPaid:       uint64FromBytes(pushes[7]),
+				RedemptionFeesPaid: uint64FromBytes(pushes[8]),
+				FundingFeesPaid:    uint64FromBytes(pushes[9]),
+				RefundFeesPaid:     refundFeesPaid,
+				EpochDur:           uint64FromBytes(pushes[10]),
+				MaxFeeRate:         uint64FromBytes(pushes[11]),
+				RedeemMaxFeeRate:   uint64FromBytes(pushes[12]),
+				FromVersion:        uint32FromBytes(pushes[13]),
+				ToVersion:          uint32FromBytes(pushes[14]),
+				FromSwapConf:       uint32FromBytes(pushes[15]),
+				ToSwapConf:         uint32FromBytes(pushes[16]),
+				Options:            options,
+				RedemptionReserves: uint64FromBytes(pushes[18]),
+				RefundReserves:     uint64FromBytes(pushes[19]),
+				AccelerationCoins:  accelerationCoinIDs,
+			},
+			Order: ord,
+		},
+		stamp: uint64FromBytes(pushes[1]),
+	}, nil
+}
+
+// matchRecord is a MetaMatch and whether it was active when it was stored.
+type matchRecord struct {
+	*dexdb.MetaMatch
+	active bool
+}
+
+func (r *matchRecord) MarshalBinary() ([]byte, error) {
+	md := r.MetaData
+	return encode.BuildyBytes{0}.
+		AddData(order.EncodeMatch(r.UserMatch)).
+		AddData(md.Proof.Encode()).
+		AddData([]byte(md.DEX)).
+		AddData(uint32Bytes(md.Base)).
+		AddData(uint32Bytes(md.Quote)).
+		AddData(uint64Bytes(md.Stamp)).
+		AddData(boolBytes(r.active)), nil
+}
+
+func decodeMatchRecord(b []byte) (*matchRecord, error) {
+	ver, pushes, err := encode.DecodeBlob(b, 7)
+	if err != nil {
+		return nil, err
+	}
+	if ver != 0 {
+		return nil, fmt.Errorf("unknown match record version %d", ver)
+	}
+	if len(pushes) != 7 {
+		return nil, fmt.Errorf("expected 7 pushes for match record, got %d", len(pushes))
+	}
+	match, _, err := order.DecodeMatch(pushes[0])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding match: %w", err)
+	}
+	proof, _, err := dexdb.DecodeMatchProof(pushes[1])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding match proof: %w", err)
+	}
+	return &matchRecord{
+		MetaMatch: &dexdb.MetaMatch{
+			MetaData: &dexdb.MatchMetaData{
+				Proof: *proof,
+				DEX:   string(pushes[2]),
+				Base:  uint32FromBytes(pushes[3]),
+				Quote: uint32FromBytes(pushes[4]),
+				Stamp: uint64FromBytes(pushes[5]),
+			},
+			UserMatch: match,
+		},
+		active: isTrue(pushes[6]),
+	}, nil
+}
+
+// isCancelMatch is true for the matches that are excluded from results when
+// cancel matches are not requested.
+func isCancelMatch(m *dexdb.MetaMatch) bool {
+	// A cancel match for a maker (trade) order has an empty address. A cancel
+	// match for a taker (the cancel) order is complete with no InitSig.
+	return m.Address == "" || (len(m.MetaData.Proof.Auth.InitSig) == 0 && m.Status == order.MatchComplete)
+}
+
+// accountRecord is an AccountInfo without its bonds, which are stored
+// separately.
+type accountRecord struct {
+	*dexdb.AccountInfo
+}
+
+func (r *accountRecord) MarshalBinary() ([]byte, error) {
+	return encode.BuildyBytes{0}.
+		AddData(r.Encode()).
+		AddData(boolBytes(r.Disabled)), nil
+}
+
+func decodeAccountRecord(b []byte) (*dexdb.AccountInfo, error) {
+	ver, pushes, err := encode.DecodeBlob(b, 2)
+	if err != nil {
+		return nil, err
+	}
+	if ver != 0 || len(pushes) != 2 {
+		return nil, fmt.Errorf("invalid account record, version %d, %d pushes", ver, len(pushes))
+	}
+	ai, err := dexdb.DecodeAccountInfo(pushes[0])
+	if err != nil {
+		return nil, err
+	}
+	ai.Disabled = isTrue(pushes[1])
+	return ai, nil
+}
+
+// bondRecord is a bond for the account with the host.
+type bondRecord struct {
+	*dexdb.Bond
+	host string
+}
+
+func bondKey(host string, assetID uint32, coinID []byte) []byte {
+	return append(hostKey(host), dexdb.BondUID(assetID, coinID)...)
+}
+
+func (r *bondRecord) MarshalBinary() ([]byte, error) {
+	return encode.BuildyBytes{0}.
+		AddData(r.Encode()).
+		AddData([]byte(r.host)).
+		AddData(boolBytes(r.Confirmed)).
+		AddData(boolBytes(r.Refunded)), nil
+}
+
+func decodeBondRecord(b []byte) (*bondRecord, error) {
+	ver, pushes, err := encode.DecodeBlob(b, 4)
+	if err != nil {
+		return nil, err
+	}
+	if ver != 0 || len(pushes) != 4 {
+		return nil, fmt.Errorf("invalid bond record, version %d, %d pushes", ver, len(pushes))
+	}
+	bond, err := dexdb.DecodeBond(pushes[0])
+	if err != nil {
+		return nil, err
+	}
+	bond.Confirmed = isTrue(pushes[2])
+	bond.Refunded = isTrue(pushes[3])
+	return &bondRecord{Bond: bond, host: string(pushes[1])}, nil
+}
+
+// walletRecord is a Wallet with its balance and status.
+type walletRecord struct {
+	*dexdb.Wallet
+}
+
+func (r *walletRecord) MarshalBinary() ([]byte, error) {
+	var balB []byte
+	if r.Balance != nil {
+		balB = r.Balance.Encode()
+	}
+	return encode.BuildyBytes{0}.
+		AddData(r.Encode()).
+		AddData(balB).
+		AddData(boolBytes(r.Disabled)), nil
+}
+
+func decodeWalletRecord(b []byte) (*dexdb.Wallet, error) {
+	ver, pushes, err := encode.DecodeBlob(b, 3)
+	if err != nil {
+		return nil, err
+	}
+	if ver != 0 || len(pushes) != 3 {
+		return nil, fmt.Errorf("invalid wallet record, version %d, %d pushes", ver, len(pushes))
+	}
+	w, err := dexdb.DecodeWallet(pushes[0])
+	if err != nil {
+		return nil, fmt.Errorf("DecodeWallet error: %w", err)
+	}
+	if len(pushes[1]) > 0 {
+		if w.Balance, err = dexdb.DecodeBalance(pushes[1]); err != nil {
+			return nil, fmt.Errorf("DecodeBalance error: %w", err)
+		}
+	}
+	w.Disabled = isTrue(pushes[2])
+	return w, nil
+}
+
+// noteRecord is a Notification and its acknowledgement.
+type noteRecord struct {
+	*dexdb.Notification
+}
+
+func (r *noteRecord) MarshalBinary() ([]byte, error) {
+	return encode.BuildyBytes{0}.
+		AddData(r.Encode()).
+		AddData(boolBytes(r.Ack)), nil
+}
+
+func decodeNoteRecord(b []byte) (*dexdb.Notification, error) {
+	ver, pushes, err := encode.DecodeBlob(b, 2)
+	if err != nil {
+		return nil, err
+	}
+	if ver != 0 || len(pushes) != 2 {
+		return nil, fmt.Errorf("invalid note record, version %d, %d pushes", ver, len(pushes))
+	}
+	note, err := dexdb.DecodeNotification(pushes[0])
+	if err != nil {
+		return nil, err
+	}
+	note.Ack = isTrue(pushes[1])
+	note.Id = note.ID()
+	return note, nil
+}
+
+func encodeCreds(creds *dexdb.PrimaryCredentials) []byte {
+	return encode.BuildyBytes{0}.
+		AddData(creds.EncSeed).
+		AddData(creds.EncInnerKey).
+		AddData(creds.InnerKeyParams).
+		AddData(creds.OuterKeyParams).
+		AddData(uint64Bytes(uint64(creds.Birthday.Unix()))).
+		AddData(uint16Bytes(creds.Version))
+}
+
+func decodeCreds(b []byte) (*dexdb.PrimaryCredentials, error) {
+	ver, pushes, err := encode.DecodeBlob(b, 6)
+	if err != nil {
+		return nil, err
+	}
+	if ver != 0 || len(pushes) != 6 {
+		return nil, fmt.Errorf("invalid credentials, version %d, %d pushes", ver, len(pushes))
+	}
+	var version uint16
+	if len(pushes[5]) == 2 {
+		version = intCoder.Uint16(pushes[5])
+	}
+	return &dexdb.PrimaryCredentials{
+		EncSeed:        pushes[0],
+		EncInnerKey:    pushes[1],
+		InnerKeyParams: pushes[2],
+		OuterKeyParams: pushes[3],
+		Birthday:       time.Unix(int64(uint64FromBytes(pushes[4])), 0),
+		Version:        version,
+	}, nil
+}
@@ -429,6 +429,8 @@ type OrderMetaData struct {
 	// FundingFeesPaid is the fees paid when funding the order. This is > 0
 	// when funding the order required a split tx.
 	FundingFeesPaid uint64
+	// RefundFeesPaid is the sum of the actual fees paid for all refunds.
+	RefundFeesPaid uint64
 
 	// EpochDur is the epoch duration for the market at the time the order was
 	// submitted. When considered with the order's ServerTime, we also know the
@@ -1220,6 +1222,200 @@ type OrderFilter struct {
 	Statuses []order.OrderStatus
 }
 
+// ConditionalOrderStatus is the status of a ConditionalOrder.
+type ConditionalOrderStatus uint8
+
+const (
+	// ConditionalOrderActive means the trigger is being watched.
+	ConditionalOrderActive ConditionalOrderStatus = iota
+	// ConditionalOrderTriggered means the trigger was hit and the order was
+	// submitted.
+	ConditionalOrderTriggered
+	// ConditionalOrderCanceled means the conditional order was canceled by the
+	// user, or by the triggering of its one-cancels-other partner.
+	ConditionalOrderCanceled
+	// ConditionalOrderFailed means the trigger was hit, but the order could not
+	// be submitted.
+	ConditionalOrderFailed
+)
+
+// String satisfies the Stringer interface.
+func (s ConditionalOrderStatus) String() string {
+	switch s {
+	case ConditionalOrderActive:
+		return "active"
+	case ConditionalOrderTriggered:
+		return "triggered"
+	case ConditionalOrderCanceled:
+		return "canceled"
+	case ConditionalOrderFailed:
+		return "failed"
+	}
+	return "unknown"
+}
+
+// Conditional order kinds.
+const (
+	StopLoss   = "stoploss"
+	TakeProfit = "takeprofit"
+)
+
+// Conditional order trigger price sources.
+const (
+	// TriggerMidGap triggers on the mid-gap rate of the DEX order book. The
+	// trigger rate is a message-rate.
+	TriggerMidGap = "midgap"
+	// TriggerFiat triggers on the fiat exchange rate of the base asset. The
+	// trigger rate is the USD price of one conventional unit of the base
+	// asset.
+	TriggerFiat = "fiat"
+)
+
+// ConditionalOrder is an order that is held by the client until a price
+// trigger is hit, at which point the order is submitted to the DEX.
+type ConditionalOrder struct {
+	ID   dex.Bytes `json:"id"`
+	Kind string    `json:"kind"`
+	// Trade parameters.
+	Host    string            `json:"host"`
+	IsLimit bool              `json:"isLimit"`
+	Sell    bool              `json:"sell"`
+	Base    uint32            `json:"base"`
+	Quote   uint32            `json:"quote"`
+	Qty     uint64            `json:"qty"`
+	Rate    uint64            `json:"rate"`
+	TifNow  bool              `json:"tifnow"`
+	Options map[string]string `json:"options"`
+	// Trigger parameters.
+	TriggerSource   string  `json:"triggerSource"`
+	TriggerRate     uint64  `json:"triggerRate,omitempty"`
+	TriggerFiatRate float64 `json:"triggerFiatRate,omitempty"`
+	// OCO is the ID of the one-cancels-other partner, if any.
+	OCO    dex.Bytes              `json:"oco,omitempty"`
+	Status ConditionalOrderStatus `json:"status"`
+	Stamp  uint64                 `json:"stamp"`
+	// OrderID is the ID of the submitted order, set when the status is
+	// ConditionalOrderTriggered.
+	OrderID dex.Bytes `json:"orderID,omitempty"`
+	// Error is the reason for the failure when the status is
+	// ConditionalOrderFailed. For an active order, it is the temporary error
+	// from the last attempt to submit the triggered order, which will be
+	// retried.
+	Error string `json:"error,omitempty"`
+}
+
+// TriggersAbove is true if the order is triggered when the price rises to or
+// above the trigger rate, and false if it is triggered when the price falls
+// to or below the trigger rate. A sell stop-loss or a buy take-profit triggers
+// below, while a sell take-profit or a buy stop-loss triggers above.
+func (o *ConditionalOrder) TriggersAbove() bool {
+	return (o.Kind == TakeProfit) == o.Sell
+}
+
+// RoutedOrder is a logical parent order whose quantity was split across the
+// same market on multiple DEX hosts.
+type RoutedOrder struct {
+	ID       dex.Bytes         `json:"id"`
+	Sell     bool              `json:"sell"`
+	Base     uint32            `json:"base"`
+	Quote    uint32            `json:"quote"`
+	Qty      uint64            `json:"qty"`
+	Rate     uint64            `json:"rate"`
+	TifNow   bool              `json:"tifnow"`
+	Options  map[string]string `json:"options"`
+	Stamp    uint64            `json:"stamp"`
+	Children []*RoutedChild    `json:"children"`
+}
+
+// RoutedChild is a child order of a RoutedOrder placed on a single host.
+type RoutedChild struct {
+	Host string `json:"host"`
+	Qty  uint64 `json:"qty"`
+	Rate uint64 `json:"rate"`
+	// OrderID is empty if the order could not be placed, in which case Error
+	// is set.
+	OrderID dex.Bytes `json:"orderID,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// Market alert kinds.
+const (
+	// AlertPrice fires when the mid-gap rate of the DEX market crosses the
+	// alert Rate.
+	AlertPrice = "price"
+	// AlertSpread fires when the spread of the DEX market narrows to the
+	// alert Spread.
+	AlertSpread = "spread"
+	// AlertVolume fires when the 24-hour volume of the DEX market rises by the
+	// alert Percent above its trailing average.
+	AlertVolume = "volume"
+	// AlertFiat fires when the fiat exchange rate of the Base asset moves by
+	// the alert Percent.
+	AlertFiat = "fiat"
+)
+
+// MarketAlertStatus is the status of a MarketAlert.
+type MarketAlertStatus uint8
+
+const (
+	// MarketAlertActive means the alert is being watched.
+	MarketAlertActive MarketAlertStatus = iota
+	// MarketAlertTriggered means the alert fired and does not repeat.
+	MarketAlertTriggered
+)
+
+// String satisfies the Stringer interface.
+func (s MarketAlertStatus) String() string {
+	switch s {
+	case MarketAlertActive:
+		return "active"
+	case MarketAlertTriggered:
+		return "triggered"
+	}
+	return "unknown"
+}
+
+// MarketAlert is a user-defined rule that fires a notification when market
+// conditions are met.
+type MarketAlert struct {
+	ID   dex.Bytes `json:"id"`
+	Kind string    `json:"kind"`
+	// Host is the DEX host of the market. Host and Quote are not used for an
+	// AlertFiat alert.
+	Host  string `json:"host,omitempty"`
+	Base  uint32 `json:"base"`
+	Quote uint32 `json:"quote"`
+	// Rate is the mid-gap message-rate for an AlertPrice alert. Above is true
+	// if the alert fires when the rate rises to or above Rate, and false if it
+	// fires when the rate falls to or below Rate.
+	Rate  uint64 `json:"rate,omitempty"`
+	Above bool   `json:"above,omitempty"`
+	// Spread is the spread, as a fraction of the mid-gap rate, at or below
+	// which an AlertSpread alert fires.
+	Spread float64 `json:"spread,omitempty"`
+	// Percent is the change, in percent, that fires an AlertFiat or
+	// AlertVolume alert. For an AlertFiat alert, the change of the fiat rate
+	// is measured from RefFiatRate. For an AlertVolume alert, the rise of the
+	// 24-hour volume is measured from its trailing average.
+	Percent     float64 `json:"percent,omitempty"`
+	RefFiatRate float64 `json:"refFiatRate,omitempty"`
+	// Repeat alerts stay active after firing. A repeating alert fires again
+	// after the condition has cleared. For an AlertFiat alert, the reference
+	// rate is reset to the rate when the alert fires.
+	Repeat bool              `json:"repeat"`
+	Status MarketAlertStatus `json:"status"`
+	Stamp  uint64            `json:"stamp"`
+	// FiredStamp is the time the alert last fired.
+	FiredStamp uint64 `json:"firedStamp,omitempty"`
+}
+
+// FiatRateSnapshot is the USD exchange rates of assets at a point in time.
+type FiatRateSnapshot struct {
+	// Stamp is the time of the snapshot, in milliseconds.
+	Stamp uint64             `json:"stamp"`
+	Rates map[uint32]float64 `json:"rates"`
+}
+
 // noteKeySize must be <= 32.
 const noteKeySize = 8
 
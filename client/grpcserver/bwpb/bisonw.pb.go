@@ -0,0 +1,4983 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: bisonw.proto
+
+package bwpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConnectionStatus int32
+
+const (
+	ConnectionStatus_DISCONNECTED ConnectionStatus = 0
+	ConnectionStatus_CONNECTED    ConnectionStatus = 1
+	ConnectionStatus_INVALID_CERT ConnectionStatus = 2
+)
+
+// Enum value maps for ConnectionStatus.
+var (
+	ConnectionStatus_name = map[int32]string{
+		0: "DISCONNECTED",
+		1: "CONNECTED",
+		2: "INVALID_CERT",
+	}
+	ConnectionStatus_value = map[string]int32{
+		"DISCONNECTED": 0,
+		"CONNECTED":    1,
+		"INVALID_CERT": 2,
+	}
+)
+
+func (x ConnectionStatus) Enum() *ConnectionStatus {
+	p := new(ConnectionStatus)
+	*p = x
+	return p
+}
+
+func (x ConnectionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bisonw_proto_enumTypes[0].Descriptor()
+}
+
+func (ConnectionStatus) Type() protoreflect.EnumType {
+	return &file_bisonw_proto_enumTypes[0]
+}
+
+func (x ConnectionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionStatus.Descriptor instead.
+func (ConnectionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderType int32
+
+const (
+	OrderType_UNKNOWN_ORDER_TYPE OrderType = 0
+	OrderType_LIMIT              OrderType = 1
+	OrderType_MARKET             OrderType = 2
+	OrderType_CANCEL             OrderType = 3
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "UNKNOWN_ORDER_TYPE",
+		1: "LIMIT",
+		2: "MARKET",
+		3: "CANCEL",
+	}
+	OrderType_value = map[string]int32{
+		"UNKNOWN_ORDER_TYPE": 0,
+		"LIMIT":              1,
+		"MARKET":             2,
+		"CANCEL":             3,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bisonw_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_bisonw_proto_enumTypes[1]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_UNKNOWN OrderStatus = 0
+	OrderStatus_EPOCH                OrderStatus = 1
+	OrderStatus_BOOKED               OrderStatus = 2
+	OrderStatus_EXECUTED             OrderStatus = 3
+	OrderStatus_CANCELED             OrderStatus = 4
+	OrderStatus_REVOKED              OrderStatus = 5
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_UNKNOWN",
+		1: "EPOCH",
+		2: "BOOKED",
+		3: "EXECUTED",
+		4: "CANCELED",
+		5: "REVOKED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_UNKNOWN": 0,
+		"EPOCH":                1,
+		"BOOKED":               2,
+		"EXECUTED":             3,
+		"CANCELED":             4,
+		"REVOKED":              5,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bisonw_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_bisonw_proto_enumTypes[2]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{2}
+}
+
+type TimeInForce int32
+
+const (
+	TimeInForce_IMMEDIATE TimeInForce = 0
+	TimeInForce_STANDING  TimeInForce = 1
+)
+
+// Enum value maps for TimeInForce.
+var (
+	TimeInForce_name = map[int32]string{
+		0: "IMMEDIATE",
+		1: "STANDING",
+	}
+	TimeInForce_value = map[string]int32{
+		"IMMEDIATE": 0,
+		"STANDING":  1,
+	}
+)
+
+func (x TimeInForce) Enum() *TimeInForce {
+	p := new(TimeInForce)
+	*p = x
+	return p
+}
+
+func (x TimeInForce) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
+	return file_bisonw_proto_enumTypes[3].Descriptor()
+}
+
+func (TimeInForce) Type() protoreflect.EnumType {
+	return &file_bisonw_proto_enumTypes[3]
+}
+
+func (x TimeInForce) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeInForce.Descriptor instead.
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{3}
+}
+
+type MatchStatus int32
+
+const (
+	MatchStatus_NEWLY_MATCHED   MatchStatus = 0
+	MatchStatus_MAKER_SWAP_CAST MatchStatus = 1
+	MatchStatus_TAKER_SWAP_CAST MatchStatus = 2
+	MatchStatus_MAKER_REDEEMED  MatchStatus = 3
+	MatchStatus_MATCH_COMPLETE  MatchStatus = 4
+	MatchStatus_MATCH_CONFIRMED MatchStatus = 5
+)
+
+// Enum value maps for MatchStatus.
+var (
+	MatchStatus_name = map[int32]string{
+		0: "NEWLY_MATCHED",
+		1: "MAKER_SWAP_CAST",
+		2: "TAKER_SWAP_CAST",
+		3: "MAKER_REDEEMED",
+		4: "MATCH_COMPLETE",
+		5: "MATCH_CONFIRMED",
+	}
+	MatchStatus_value = map[string]int32{
+		"NEWLY_MATCHED":   0,
+		"MAKER_SWAP_CAST": 1,
+		"TAKER_SWAP_CAST": 2,
+		"MAKER_REDEEMED":  3,
+		"MATCH_COMPLETE":  4,
+		"MATCH_CONFIRMED": 5,
+	}
+)
+
+func (x MatchStatus) Enum() *MatchStatus {
+	p := new(MatchStatus)
+	*p = x
+	return p
+}
+
+func (x MatchStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bisonw_proto_enumTypes[4].Descriptor()
+}
+
+func (MatchStatus) Type() protoreflect.EnumType {
+	return &file_bisonw_proto_enumTypes[4]
+}
+
+func (x MatchStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchStatus.Descriptor instead.
+func (MatchStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{4}
+}
+
+type MatchSide int32
+
+const (
+	MatchSide_MAKER MatchSide = 0
+	MatchSide_TAKER MatchSide = 1
+)
+
+// Enum value maps for MatchSide.
+var (
+	MatchSide_name = map[int32]string{
+		0: "MAKER",
+		1: "TAKER",
+	}
+	MatchSide_value = map[string]int32{
+		"MAKER": 0,
+		"TAKER": 1,
+	}
+)
+
+func (x MatchSide) Enum() *MatchSide {
+	p := new(MatchSide)
+	*p = x
+	return p
+}
+
+func (x MatchSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_bisonw_proto_enumTypes[5].Descriptor()
+}
+
+func (MatchSide) Type() protoreflect.EnumType {
+	return &file_bisonw_proto_enumTypes[5]
+}
+
+func (x MatchSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchSide.Descriptor instead.
+func (MatchSide) EnumDescriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{5}
+}
+
+type Severity int32
+
+const (
+	Severity_IGNORABLE   Severity = 0
+	Severity_DATA        Severity = 1
+	Severity_POKE        Severity = 2
+	Severity_SUCCESS     Severity = 3
+	Severity_WARNING     Severity = 4
+	Severity_ERROR_LEVEL Severity = 5
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "IGNORABLE",
+		1: "DATA",
+		2: "POKE",
+		3: "SUCCESS",
+		4: "WARNING",
+		5: "ERROR_LEVEL",
+	}
+	Severity_value = map[string]int32{
+		"IGNORABLE":   0,
+		"DATA":        1,
+		"POKE":        2,
+		"SUCCESS":     3,
+		"WARNING":     4,
+		"ERROR_LEVEL": 5,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_bisonw_proto_enumTypes[6].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_bisonw_proto_enumTypes[6]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{6}
+}
+
+type VersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionRequest) Reset() {
+	*x = VersionRequest{}
+	mi := &file_bisonw_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionRequest) ProtoMessage() {}
+
+func (x *VersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionRequest.ProtoReflect.Descriptor instead.
+func (*VersionRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{0}
+}
+
+type VersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BisonwVersion string                 `protobuf:"bytes,1,opt,name=bisonw_version,json=bisonwVersion,proto3" json:"bisonw_version,omitempty"`
+	ApiVersion    string                 `protobuf:"bytes,2,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	mi := &file_bisonw_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VersionResponse) GetBisonwVersion() string {
+	if x != nil {
+		return x.BisonwVersion
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+type ExchangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangesRequest) Reset() {
+	*x = ExchangesRequest{}
+	mi := &file_bisonw_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangesRequest) ProtoMessage() {}
+
+func (x *ExchangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangesRequest.ProtoReflect.Descriptor instead.
+func (*ExchangesRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{2}
+}
+
+type ExchangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exchanges     []*Exchange            `protobuf:"bytes,1,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangesResponse) Reset() {
+	*x = ExchangesResponse{}
+	mi := &file_bisonw_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangesResponse) ProtoMessage() {}
+
+func (x *ExchangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangesResponse.ProtoReflect.Descriptor instead.
+func (*ExchangesResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExchangesResponse) GetExchanges() []*Exchange {
+	if x != nil {
+		return x.Exchanges
+	}
+	return nil
+}
+
+type GetDEXConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Host  string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// cert is the contents of the host's TLS certificate, if it is self-signed.
+	Cert          []byte `protobuf:"bytes,2,opt,name=cert,proto3" json:"cert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDEXConfigRequest) Reset() {
+	*x = GetDEXConfigRequest{}
+	mi := &file_bisonw_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDEXConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDEXConfigRequest) ProtoMessage() {}
+
+func (x *GetDEXConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDEXConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetDEXConfigRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDEXConfigRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *GetDEXConfigRequest) GetCert() []byte {
+	if x != nil {
+		return x.Cert
+	}
+	return nil
+}
+
+type Exchange struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Host             string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	AcctId           string                 `protobuf:"bytes,2,opt,name=acct_id,json=acctId,proto3" json:"acct_id,omitempty"`
+	Markets          []*Market              `protobuf:"bytes,3,rep,name=markets,proto3" json:"markets,omitempty"`
+	Assets           []*Asset               `protobuf:"bytes,4,rep,name=assets,proto3" json:"assets,omitempty"`
+	BondExpiry       uint64                 `protobuf:"varint,5,opt,name=bond_expiry,json=bondExpiry,proto3" json:"bond_expiry,omitempty"`
+	BondAssets       []*BondAsset           `protobuf:"bytes,6,rep,name=bond_assets,json=bondAssets,proto3" json:"bond_assets,omitempty"`
+	ConnectionStatus ConnectionStatus       `protobuf:"varint,7,opt,name=connection_status,json=connectionStatus,proto3,enum=bisonw.ConnectionStatus" json:"connection_status,omitempty"`
+	ViewOnly         bool                   `protobuf:"varint,8,opt,name=view_only,json=viewOnly,proto3" json:"view_only,omitempty"`
+	Disabled         bool                   `protobuf:"varint,9,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	TargetTier       uint64                 `protobuf:"varint,10,opt,name=target_tier,json=targetTier,proto3" json:"target_tier,omitempty"`
+	LiveStrength     int64                  `protobuf:"varint,11,opt,name=live_strength,json=liveStrength,proto3" json:"live_strength,omitempty"`
+	PendingStrength  int64                  `protobuf:"varint,12,opt,name=pending_strength,json=pendingStrength,proto3" json:"pending_strength,omitempty"`
+	Score            int32                  `protobuf:"varint,13,opt,name=score,proto3" json:"score,omitempty"`
+	PenaltyThreshold uint32                 `protobuf:"varint,14,opt,name=penalty_threshold,json=penaltyThreshold,proto3" json:"penalty_threshold,omitempty"`
+	MaxScore         uint32                 `protobuf:"varint,15,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Exchange) Reset() {
+	*x = Exchange{}
+	mi := &file_bisonw_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Exchange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Exchange) ProtoMessage() {}
+
+func (x *Exchange) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Exchange.ProtoReflect.Descriptor instead.
+func (*Exchange) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Exchange) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Exchange) GetAcctId() string {
+	if x != nil {
+		return x.AcctId
+	}
+	return ""
+}
+
+func (x *Exchange) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+func (x *Exchange) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *Exchange) GetBondExpiry() uint64 {
+	if x != nil {
+		return x.BondExpiry
+	}
+	return 0
+}
+
+func (x *Exchange) GetBondAssets() []*BondAsset {
+	if x != nil {
+		return x.BondAssets
+	}
+	return nil
+}
+
+func (x *Exchange) GetConnectionStatus() ConnectionStatus {
+	if x != nil {
+		return x.ConnectionStatus
+	}
+	return ConnectionStatus_DISCONNECTED
+}
+
+func (x *Exchange) GetViewOnly() bool {
+	if x != nil {
+		return x.ViewOnly
+	}
+	return false
+}
+
+func (x *Exchange) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Exchange) GetTargetTier() uint64 {
+	if x != nil {
+		return x.TargetTier
+	}
+	return 0
+}
+
+func (x *Exchange) GetLiveStrength() int64 {
+	if x != nil {
+		return x.LiveStrength
+	}
+	return 0
+}
+
+func (x *Exchange) GetPendingStrength() int64 {
+	if x != nil {
+		return x.PendingStrength
+	}
+	return 0
+}
+
+func (x *Exchange) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Exchange) GetPenaltyThreshold() uint32 {
+	if x != nil {
+		return x.PenaltyThreshold
+	}
+	return 0
+}
+
+func (x *Exchange) GetMaxScore() uint32 {
+	if x != nil {
+		return x.MaxScore
+	}
+	return 0
+}
+
+type Market struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BaseId        uint32                 `protobuf:"varint,2,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+	BaseSymbol    string                 `protobuf:"bytes,3,opt,name=base_symbol,json=baseSymbol,proto3" json:"base_symbol,omitempty"`
+	QuoteId       uint32                 `protobuf:"varint,4,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	QuoteSymbol   string                 `protobuf:"bytes,5,opt,name=quote_symbol,json=quoteSymbol,proto3" json:"quote_symbol,omitempty"`
+	LotSize       uint64                 `protobuf:"varint,6,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	ParcelSize    uint32                 `protobuf:"varint,7,opt,name=parcel_size,json=parcelSize,proto3" json:"parcel_size,omitempty"`
+	RateStep      uint64                 `protobuf:"varint,8,opt,name=rate_step,json=rateStep,proto3" json:"rate_step,omitempty"`
+	EpochLen      uint64                 `protobuf:"varint,9,opt,name=epoch_len,json=epochLen,proto3" json:"epoch_len,omitempty"`
+	StartEpoch    uint64                 `protobuf:"varint,10,opt,name=start_epoch,json=startEpoch,proto3" json:"start_epoch,omitempty"`
+	MinimumRate   uint64                 `protobuf:"varint,11,opt,name=minimum_rate,json=minimumRate,proto3" json:"minimum_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Market) Reset() {
+	*x = Market{}
+	mi := &file_bisonw_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Market) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Market) ProtoMessage() {}
+
+func (x *Market) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Market.ProtoReflect.Descriptor instead.
+func (*Market) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Market) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Market) GetBaseId() uint32 {
+	if x != nil {
+		return x.BaseId
+	}
+	return 0
+}
+
+func (x *Market) GetBaseSymbol() string {
+	if x != nil {
+		return x.BaseSymbol
+	}
+	return ""
+}
+
+func (x *Market) GetQuoteId() uint32 {
+	if x != nil {
+		return x.QuoteId
+	}
+	return 0
+}
+
+func (x *Market) GetQuoteSymbol() string {
+	if x != nil {
+		return x.QuoteSymbol
+	}
+	return ""
+}
+
+func (x *Market) GetLotSize() uint64 {
+	if x != nil {
+		return x.LotSize
+	}
+	return 0
+}
+
+func (x *Market) GetParcelSize() uint32 {
+	if x != nil {
+		return x.ParcelSize
+	}
+	return 0
+}
+
+func (x *Market) GetRateStep() uint64 {
+	if x != nil {
+		return x.RateStep
+	}
+	return 0
+}
+
+func (x *Market) GetEpochLen() uint64 {
+	if x != nil {
+		return x.EpochLen
+	}
+	return 0
+}
+
+func (x *Market) GetStartEpoch() uint64 {
+	if x != nil {
+		return x.StartEpoch
+	}
+	return 0
+}
+
+func (x *Market) GetMinimumRate() uint64 {
+	if x != nil {
+		return x.MinimumRate
+	}
+	return 0
+}
+
+type Asset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Version       uint32                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	SwapConf      uint32                 `protobuf:"varint,4,opt,name=swap_conf,json=swapConf,proto3" json:"swap_conf,omitempty"`
+	MaxFeeRate    uint64                 `protobuf:"varint,5,opt,name=max_fee_rate,json=maxFeeRate,proto3" json:"max_fee_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_bisonw_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Asset) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Asset) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Asset) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Asset) GetSwapConf() uint32 {
+	if x != nil {
+		return x.SwapConf
+	}
+	return 0
+}
+
+func (x *Asset) GetMaxFeeRate() uint64 {
+	if x != nil {
+		return x.MaxFeeRate
+	}
+	return 0
+}
+
+type BondAsset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Id            uint32                 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Version       uint32                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Confs         uint32                 `protobuf:"varint,4,opt,name=confs,proto3" json:"confs,omitempty"`
+	Amount        uint64                 `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BondAsset) Reset() {
+	*x = BondAsset{}
+	mi := &file_bisonw_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BondAsset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BondAsset) ProtoMessage() {}
+
+func (x *BondAsset) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BondAsset.ProtoReflect.Descriptor instead.
+func (*BondAsset) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BondAsset) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BondAsset) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BondAsset) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BondAsset) GetConfs() uint32 {
+	if x != nil {
+		return x.Confs
+	}
+	return 0
+}
+
+func (x *BondAsset) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppPass       []byte                 `protobuf:"bytes,1,opt,name=app_pass,json=appPass,proto3" json:"app_pass,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_bisonw_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LoginRequest) GetAppPass() []byte {
+	if x != nil {
+		return x.AppPass
+	}
+	return nil
+}
+
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_bisonw_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{10}
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_bisonw_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{11}
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_bisonw_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{12}
+}
+
+type PostBondRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Host         string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	AppPass      []byte                 `protobuf:"bytes,2,opt,name=app_pass,json=appPass,proto3" json:"app_pass,omitempty"`
+	AssetId      uint32                 `protobuf:"varint,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Bond         uint64                 `protobuf:"varint,4,opt,name=bond,proto3" json:"bond,omitempty"`
+	MaintainTier *bool                  `protobuf:"varint,5,opt,name=maintain_tier,json=maintainTier,proto3,oneof" json:"maintain_tier,omitempty"`
+	// cert is the contents of the host's TLS certificate, if it is self-signed.
+	Cert          []byte `protobuf:"bytes,6,opt,name=cert,proto3" json:"cert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostBondRequest) Reset() {
+	*x = PostBondRequest{}
+	mi := &file_bisonw_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostBondRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostBondRequest) ProtoMessage() {}
+
+func (x *PostBondRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostBondRequest.ProtoReflect.Descriptor instead.
+func (*PostBondRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PostBondRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PostBondRequest) GetAppPass() []byte {
+	if x != nil {
+		return x.AppPass
+	}
+	return nil
+}
+
+func (x *PostBondRequest) GetAssetId() uint32 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *PostBondRequest) GetBond() uint64 {
+	if x != nil {
+		return x.Bond
+	}
+	return 0
+}
+
+func (x *PostBondRequest) GetMaintainTier() bool {
+	if x != nil && x.MaintainTier != nil {
+		return *x.MaintainTier
+	}
+	return false
+}
+
+func (x *PostBondRequest) GetCert() []byte {
+	if x != nil {
+		return x.Cert
+	}
+	return nil
+}
+
+type PostBondResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BondId        string                 `protobuf:"bytes,1,opt,name=bond_id,json=bondId,proto3" json:"bond_id,omitempty"`
+	ReqConfirms   uint32                 `protobuf:"varint,2,opt,name=req_confirms,json=reqConfirms,proto3" json:"req_confirms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostBondResponse) Reset() {
+	*x = PostBondResponse{}
+	mi := &file_bisonw_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostBondResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostBondResponse) ProtoMessage() {}
+
+func (x *PostBondResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostBondResponse.ProtoReflect.Descriptor instead.
+func (*PostBondResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PostBondResponse) GetBondId() string {
+	if x != nil {
+		return x.BondId
+	}
+	return ""
+}
+
+func (x *PostBondResponse) GetReqConfirms() uint32 {
+	if x != nil {
+		return x.ReqConfirms
+	}
+	return 0
+}
+
+type WalletsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletsRequest) Reset() {
+	*x = WalletsRequest{}
+	mi := &file_bisonw_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletsRequest) ProtoMessage() {}
+
+func (x *WalletsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletsRequest.ProtoReflect.Descriptor instead.
+func (*WalletsRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{15}
+}
+
+type WalletsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wallets       []*Wallet              `protobuf:"bytes,1,rep,name=wallets,proto3" json:"wallets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletsResponse) Reset() {
+	*x = WalletsResponse{}
+	mi := &file_bisonw_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletsResponse) ProtoMessage() {}
+
+func (x *WalletsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletsResponse.ProtoReflect.Descriptor instead.
+func (*WalletsResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WalletsResponse) GetWallets() []*Wallet {
+	if x != nil {
+		return x.Wallets
+	}
+	return nil
+}
+
+type Wallet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	AssetId       uint32                 `protobuf:"varint,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Version       uint32                 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Open          bool                   `protobuf:"varint,5,opt,name=open,proto3" json:"open,omitempty"`
+	Running       bool                   `protobuf:"varint,6,opt,name=running,proto3" json:"running,omitempty"`
+	Balance       *Balance               `protobuf:"bytes,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	Address       string                 `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
+	Units         string                 `protobuf:"bytes,9,opt,name=units,proto3" json:"units,omitempty"`
+	Encrypted     bool                   `protobuf:"varint,10,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	PeerCount     uint32                 `protobuf:"varint,11,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`
+	Synced        bool                   `protobuf:"varint,12,opt,name=synced,proto3" json:"synced,omitempty"`
+	SyncProgress  float32                `protobuf:"fixed32,13,opt,name=sync_progress,json=syncProgress,proto3" json:"sync_progress,omitempty"`
+	Disabled      bool                   `protobuf:"varint,14,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wallet) Reset() {
+	*x = Wallet{}
+	mi := &file_bisonw_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wallet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wallet) ProtoMessage() {}
+
+func (x *Wallet) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wallet.ProtoReflect.Descriptor instead.
+func (*Wallet) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Wallet) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Wallet) GetAssetId() uint32 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *Wallet) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Wallet) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Wallet) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+func (x *Wallet) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *Wallet) GetBalance() *Balance {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *Wallet) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Wallet) GetUnits() string {
+	if x != nil {
+		return x.Units
+	}
+	return ""
+}
+
+func (x *Wallet) GetEncrypted() bool {
+	if x != nil {
+		return x.Encrypted
+	}
+	return false
+}
+
+func (x *Wallet) GetPeerCount() uint32 {
+	if x != nil {
+		return x.PeerCount
+	}
+	return 0
+}
+
+func (x *Wallet) GetSynced() bool {
+	if x != nil {
+		return x.Synced
+	}
+	return false
+}
+
+func (x *Wallet) GetSyncProgress() float32 {
+	if x != nil {
+		return x.SyncProgress
+	}
+	return 0
+}
+
+func (x *Wallet) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type Balance struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Available      uint64                 `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	Immature       uint64                 `protobuf:"varint,2,opt,name=immature,proto3" json:"immature,omitempty"`
+	Locked         uint64                 `protobuf:"varint,3,opt,name=locked,proto3" json:"locked,omitempty"`
+	OrderLocked    uint64                 `protobuf:"varint,4,opt,name=order_locked,json=orderLocked,proto3" json:"order_locked,omitempty"`
+	ContractLocked uint64                 `protobuf:"varint,5,opt,name=contract_locked,json=contractLocked,proto3" json:"contract_locked,omitempty"`
+	BondLocked     uint64                 `protobuf:"varint,6,opt,name=bond_locked,json=bondLocked,proto3" json:"bond_locked,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_bisonw_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Balance) GetAvailable() uint64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Balance) GetImmature() uint64 {
+	if x != nil {
+		return x.Immature
+	}
+	return 0
+}
+
+func (x *Balance) GetLocked() uint64 {
+	if x != nil {
+		return x.Locked
+	}
+	return 0
+}
+
+func (x *Balance) GetOrderLocked() uint64 {
+	if x != nil {
+		return x.OrderLocked
+	}
+	return 0
+}
+
+func (x *Balance) GetContractLocked() uint64 {
+	if x != nil {
+		return x.ContractLocked
+	}
+	return 0
+}
+
+func (x *Balance) GetBondLocked() uint64 {
+	if x != nil {
+		return x.BondLocked
+	}
+	return 0
+}
+
+type OpenWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       uint32                 `protobuf:"varint,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	AppPass       []byte                 `protobuf:"bytes,2,opt,name=app_pass,json=appPass,proto3" json:"app_pass,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenWalletRequest) Reset() {
+	*x = OpenWalletRequest{}
+	mi := &file_bisonw_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenWalletRequest) ProtoMessage() {}
+
+func (x *OpenWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenWalletRequest.ProtoReflect.Descriptor instead.
+func (*OpenWalletRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OpenWalletRequest) GetAssetId() uint32 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *OpenWalletRequest) GetAppPass() []byte {
+	if x != nil {
+		return x.AppPass
+	}
+	return nil
+}
+
+type OpenWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenWalletResponse) Reset() {
+	*x = OpenWalletResponse{}
+	mi := &file_bisonw_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenWalletResponse) ProtoMessage() {}
+
+func (x *OpenWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenWalletResponse.ProtoReflect.Descriptor instead.
+func (*OpenWalletResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{20}
+}
+
+type CloseWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       uint32                 `protobuf:"varint,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseWalletRequest) Reset() {
+	*x = CloseWalletRequest{}
+	mi := &file_bisonw_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseWalletRequest) ProtoMessage() {}
+
+func (x *CloseWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseWalletRequest.ProtoReflect.Descriptor instead.
+func (*CloseWalletRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CloseWalletRequest) GetAssetId() uint32 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+type CloseWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseWalletResponse) Reset() {
+	*x = CloseWalletResponse{}
+	mi := &file_bisonw_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseWalletResponse) ProtoMessage() {}
+
+func (x *CloseWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseWalletResponse.ProtoReflect.Descriptor instead.
+func (*CloseWalletResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{22}
+}
+
+type NewDepositAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       uint32                 `protobuf:"varint,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewDepositAddressRequest) Reset() {
+	*x = NewDepositAddressRequest{}
+	mi := &file_bisonw_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewDepositAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewDepositAddressRequest) ProtoMessage() {}
+
+func (x *NewDepositAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewDepositAddressRequest.ProtoReflect.Descriptor instead.
+func (*NewDepositAddressRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NewDepositAddressRequest) GetAssetId() uint32 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+type NewDepositAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewDepositAddressResponse) Reset() {
+	*x = NewDepositAddressResponse{}
+	mi := &file_bisonw_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewDepositAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewDepositAddressResponse) ProtoMessage() {}
+
+func (x *NewDepositAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewDepositAddressResponse.ProtoReflect.Descriptor instead.
+func (*NewDepositAddressResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NewDepositAddressResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type SendRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppPass []byte                 `protobuf:"bytes,1,opt,name=app_pass,json=appPass,proto3" json:"app_pass,omitempty"`
+	AssetId uint32                 `protobuf:"varint,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Value   uint64                 `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	Address string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	// subtract subtracts the fees from the value, as with a withdraw.
+	Subtract      bool `protobuf:"varint,5,opt,name=subtract,proto3" json:"subtract,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendRequest) Reset() {
+	*x = SendRequest{}
+	mi := &file_bisonw_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendRequest) ProtoMessage() {}
+
+func (x *SendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendRequest.ProtoReflect.Descriptor instead.
+func (*SendRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SendRequest) GetAppPass() []byte {
+	if x != nil {
+		return x.AppPass
+	}
+	return nil
+}
+
+func (x *SendRequest) GetAssetId() uint32 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *SendRequest) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *SendRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SendRequest) GetSubtract() bool {
+	if x != nil {
+		return x.Subtract
+	}
+	return false
+}
+
+type SendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coin          string                 `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
+	TxId          string                 `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendResponse) Reset() {
+	*x = SendResponse{}
+	mi := &file_bisonw_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendResponse) ProtoMessage() {}
+
+func (x *SendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendResponse.ProtoReflect.Descriptor instead.
+func (*SendResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SendResponse) GetCoin() string {
+	if x != nil {
+		return x.Coin
+	}
+	return ""
+}
+
+func (x *SendResponse) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+type TxHistoryRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId uint32                 `protobuf:"varint,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// n is the maximum number of transactions. 0 means all of them.
+	N int32 `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	// ref_id is the ID of the transaction to list from.
+	RefId *string `protobuf:"bytes,3,opt,name=ref_id,json=refId,proto3,oneof" json:"ref_id,omitempty"`
+	// past lists transactions older than ref_id, instead of newer.
+	Past          bool `protobuf:"varint,4,opt,name=past,proto3" json:"past,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxHistoryRequest) Reset() {
+	*x = TxHistoryRequest{}
+	mi := &file_bisonw_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxHistoryRequest) ProtoMessage() {}
+
+func (x *TxHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxHistoryRequest.ProtoReflect.Descriptor instead.
+func (*TxHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TxHistoryRequest) GetAssetId() uint32 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *TxHistoryRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *TxHistoryRequest) GetRefId() string {
+	if x != nil && x.RefId != nil {
+		return *x.RefId
+	}
+	return ""
+}
+
+func (x *TxHistoryRequest) GetPast() bool {
+	if x != nil {
+		return x.Past
+	}
+	return false
+}
+
+type TxHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txs           []*WalletTransaction   `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxHistoryResponse) Reset() {
+	*x = TxHistoryResponse{}
+	mi := &file_bisonw_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxHistoryResponse) ProtoMessage() {}
+
+func (x *TxHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxHistoryResponse.ProtoReflect.Descriptor instead.
+func (*TxHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TxHistoryResponse) GetTxs() []*WalletTransaction {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+type WalletTransaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is an asset.TransactionType.
+	Type          uint32  `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Id            string  `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Amount        uint64  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Fees          uint64  `protobuf:"varint,4,opt,name=fees,proto3" json:"fees,omitempty"`
+	BlockNumber   uint64  `protobuf:"varint,5,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Timestamp     uint64  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TokenId       *uint32 `protobuf:"varint,7,opt,name=token_id,json=tokenId,proto3,oneof" json:"token_id,omitempty"`
+	Recipient     *string `protobuf:"bytes,8,opt,name=recipient,proto3,oneof" json:"recipient,omitempty"`
+	Confirmed     bool    `protobuf:"varint,9,opt,name=confirmed,proto3" json:"confirmed,omitempty"`
+	Rejected      bool    `protobuf:"varint,10,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletTransaction) Reset() {
+	*x = WalletTransaction{}
+	mi := &file_bisonw_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletTransaction) ProtoMessage() {}
+
+func (x *WalletTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletTransaction.ProtoReflect.Descriptor instead.
+func (*WalletTransaction) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WalletTransaction) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetFees() uint64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetTokenId() uint32 {
+	if x != nil && x.TokenId != nil {
+		return *x.TokenId
+	}
+	return 0
+}
+
+func (x *WalletTransaction) GetRecipient() string {
+	if x != nil && x.Recipient != nil {
+		return *x.Recipient
+	}
+	return ""
+}
+
+func (x *WalletTransaction) GetConfirmed() bool {
+	if x != nil {
+		return x.Confirmed
+	}
+	return false
+}
+
+func (x *WalletTransaction) GetRejected() bool {
+	if x != nil {
+		return x.Rejected
+	}
+	return false
+}
+
+type TradeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppPass       []byte                 `protobuf:"bytes,1,opt,name=app_pass,json=appPass,proto3" json:"app_pass,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	IsLimit       bool                   `protobuf:"varint,3,opt,name=is_limit,json=isLimit,proto3" json:"is_limit,omitempty"`
+	Sell          bool                   `protobuf:"varint,4,opt,name=sell,proto3" json:"sell,omitempty"`
+	Base          uint32                 `protobuf:"varint,5,opt,name=base,proto3" json:"base,omitempty"`
+	Quote         uint32                 `protobuf:"varint,6,opt,name=quote,proto3" json:"quote,omitempty"`
+	Qty           uint64                 `protobuf:"varint,7,opt,name=qty,proto3" json:"qty,omitempty"`
+	Rate          uint64                 `protobuf:"varint,8,opt,name=rate,proto3" json:"rate,omitempty"`
+	TifNow        bool                   `protobuf:"varint,9,opt,name=tif_now,json=tifNow,proto3" json:"tif_now,omitempty"`
+	Options       map[string]string      `protobuf:"bytes,10,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeRequest) Reset() {
+	*x = TradeRequest{}
+	mi := &file_bisonw_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeRequest) ProtoMessage() {}
+
+func (x *TradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeRequest.ProtoReflect.Descriptor instead.
+func (*TradeRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TradeRequest) GetAppPass() []byte {
+	if x != nil {
+		return x.AppPass
+	}
+	return nil
+}
+
+func (x *TradeRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *TradeRequest) GetIsLimit() bool {
+	if x != nil {
+		return x.IsLimit
+	}
+	return false
+}
+
+func (x *TradeRequest) GetSell() bool {
+	if x != nil {
+		return x.Sell
+	}
+	return false
+}
+
+func (x *TradeRequest) GetBase() uint32 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *TradeRequest) GetQuote() uint32 {
+	if x != nil {
+		return x.Quote
+	}
+	return 0
+}
+
+func (x *TradeRequest) GetQty() uint64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *TradeRequest) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TradeRequest) GetTifNow() bool {
+	if x != nil {
+		return x.TifNow
+	}
+	return false
+}
+
+func (x *TradeRequest) GetOptions() map[string]string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       []byte                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_bisonw_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CancelRequest) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+type CancelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_bisonw_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelResponse) ProtoMessage() {}
+
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{32}
+}
+
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	BaseId        uint32                 `protobuf:"varint,2,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+	BaseSymbol    string                 `protobuf:"bytes,3,opt,name=base_symbol,json=baseSymbol,proto3" json:"base_symbol,omitempty"`
+	QuoteId       uint32                 `protobuf:"varint,4,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	QuoteSymbol   string                 `protobuf:"bytes,5,opt,name=quote_symbol,json=quoteSymbol,proto3" json:"quote_symbol,omitempty"`
+	MarketId      string                 `protobuf:"bytes,6,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Type          OrderType              `protobuf:"varint,7,opt,name=type,proto3,enum=bisonw.OrderType" json:"type,omitempty"`
+	Id            []byte                 `protobuf:"bytes,8,opt,name=id,proto3" json:"id,omitempty"`
+	Stamp         uint64                 `protobuf:"varint,9,opt,name=stamp,proto3" json:"stamp,omitempty"`
+	SubmitTime    uint64                 `protobuf:"varint,10,opt,name=submit_time,json=submitTime,proto3" json:"submit_time,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,11,opt,name=status,proto3,enum=bisonw.OrderStatus" json:"status,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,12,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Qty           uint64                 `protobuf:"varint,13,opt,name=qty,proto3" json:"qty,omitempty"`
+	Sell          bool                   `protobuf:"varint,14,opt,name=sell,proto3" json:"sell,omitempty"`
+	Filled        uint64                 `protobuf:"varint,15,opt,name=filled,proto3" json:"filled,omitempty"`
+	Matches       []*Match               `protobuf:"bytes,16,rep,name=matches,proto3" json:"matches,omitempty"`
+	Cancelling    bool                   `protobuf:"varint,17,opt,name=cancelling,proto3" json:"cancelling,omitempty"`
+	Canceled      bool                   `protobuf:"varint,18,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	Rate          uint64                 `protobuf:"varint,19,opt,name=rate,proto3" json:"rate,omitempty"`
+	Tif           TimeInForce            `protobuf:"varint,20,opt,name=tif,proto3,enum=bisonw.TimeInForce" json:"tif,omitempty"`
+	TargetOrderId []byte                 `protobuf:"bytes,21,opt,name=target_order_id,json=targetOrderId,proto3" json:"target_order_id,omitempty"`
+	LockedAmt     uint64                 `protobuf:"varint,22,opt,name=locked_amt,json=lockedAmt,proto3" json:"locked_amt,omitempty"`
+	FeesPaid      *Fees                  `protobuf:"bytes,23,opt,name=fees_paid,json=feesPaid,proto3" json:"fees_paid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_bisonw_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Order) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Order) GetBaseId() uint32 {
+	if x != nil {
+		return x.BaseId
+	}
+	return 0
+}
+
+func (x *Order) GetBaseSymbol() string {
+	if x != nil {
+		return x.BaseSymbol
+	}
+	return ""
+}
+
+func (x *Order) GetQuoteId() uint32 {
+	if x != nil {
+		return x.QuoteId
+	}
+	return 0
+}
+
+func (x *Order) GetQuoteSymbol() string {
+	if x != nil {
+		return x.QuoteSymbol
+	}
+	return ""
+}
+
+func (x *Order) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *Order) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_UNKNOWN_ORDER_TYPE
+}
+
+func (x *Order) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Order) GetStamp() uint64 {
+	if x != nil {
+		return x.Stamp
+	}
+	return 0
+}
+
+func (x *Order) GetSubmitTime() uint64 {
+	if x != nil {
+		return x.SubmitTime
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_UNKNOWN
+}
+
+func (x *Order) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *Order) GetQty() uint64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *Order) GetSell() bool {
+	if x != nil {
+		return x.Sell
+	}
+	return false
+}
+
+func (x *Order) GetFilled() uint64 {
+	if x != nil {
+		return x.Filled
+	}
+	return 0
+}
+
+func (x *Order) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *Order) GetCancelling() bool {
+	if x != nil {
+		return x.Cancelling
+	}
+	return false
+}
+
+func (x *Order) GetCanceled() bool {
+	if x != nil {
+		return x.Canceled
+	}
+	return false
+}
+
+func (x *Order) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *Order) GetTif() TimeInForce {
+	if x != nil {
+		return x.Tif
+	}
+	return TimeInForce_IMMEDIATE
+}
+
+func (x *Order) GetTargetOrderId() []byte {
+	if x != nil {
+		return x.TargetOrderId
+	}
+	return nil
+}
+
+func (x *Order) GetLockedAmt() uint64 {
+	if x != nil {
+		return x.LockedAmt
+	}
+	return 0
+}
+
+func (x *Order) GetFeesPaid() *Fees {
+	if x != nil {
+		return x.FeesPaid
+	}
+	return nil
+}
+
+type Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       []byte                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Status        MatchStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=bisonw.MatchStatus" json:"status,omitempty"`
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Revoked       bool                   `protobuf:"varint,4,opt,name=revoked,proto3" json:"revoked,omitempty"`
+	Rate          uint64                 `protobuf:"varint,5,opt,name=rate,proto3" json:"rate,omitempty"`
+	Qty           uint64                 `protobuf:"varint,6,opt,name=qty,proto3" json:"qty,omitempty"`
+	Side          MatchSide              `protobuf:"varint,7,opt,name=side,proto3,enum=bisonw.MatchSide" json:"side,omitempty"`
+	FeeRate       uint64                 `protobuf:"varint,8,opt,name=fee_rate,json=feeRate,proto3" json:"fee_rate,omitempty"`
+	Swap          string                 `protobuf:"bytes,9,opt,name=swap,proto3" json:"swap,omitempty"`
+	CounterSwap   string                 `protobuf:"bytes,10,opt,name=counter_swap,json=counterSwap,proto3" json:"counter_swap,omitempty"`
+	Redeem        string                 `protobuf:"bytes,11,opt,name=redeem,proto3" json:"redeem,omitempty"`
+	CounterRedeem string                 `protobuf:"bytes,12,opt,name=counter_redeem,json=counterRedeem,proto3" json:"counter_redeem,omitempty"`
+	Refund        string                 `protobuf:"bytes,13,opt,name=refund,proto3" json:"refund,omitempty"`
+	Stamp         uint64                 `protobuf:"varint,14,opt,name=stamp,proto3" json:"stamp,omitempty"`
+	IsCancel      bool                   `protobuf:"varint,15,opt,name=is_cancel,json=isCancel,proto3" json:"is_cancel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_bisonw_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Match) GetMatchId() []byte {
+	if x != nil {
+		return x.MatchId
+	}
+	return nil
+}
+
+func (x *Match) GetStatus() MatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MatchStatus_NEWLY_MATCHED
+}
+
+func (x *Match) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Match) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+func (x *Match) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *Match) GetQty() uint64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *Match) GetSide() MatchSide {
+	if x != nil {
+		return x.Side
+	}
+	return MatchSide_MAKER
+}
+
+func (x *Match) GetFeeRate() uint64 {
+	if x != nil {
+		return x.FeeRate
+	}
+	return 0
+}
+
+func (x *Match) GetSwap() string {
+	if x != nil {
+		return x.Swap
+	}
+	return ""
+}
+
+func (x *Match) GetCounterSwap() string {
+	if x != nil {
+		return x.CounterSwap
+	}
+	return ""
+}
+
+func (x *Match) GetRedeem() string {
+	if x != nil {
+		return x.Redeem
+	}
+	return ""
+}
+
+func (x *Match) GetCounterRedeem() string {
+	if x != nil {
+		return x.CounterRedeem
+	}
+	return ""
+}
+
+func (x *Match) GetRefund() string {
+	if x != nil {
+		return x.Refund
+	}
+	return ""
+}
+
+func (x *Match) GetStamp() uint64 {
+	if x != nil {
+		return x.Stamp
+	}
+	return 0
+}
+
+func (x *Match) GetIsCancel() bool {
+	if x != nil {
+		return x.IsCancel
+	}
+	return false
+}
+
+type Fees struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Swap          uint64                 `protobuf:"varint,1,opt,name=swap,proto3" json:"swap,omitempty"`
+	Redemption    uint64                 `protobuf:"varint,2,opt,name=redemption,proto3" json:"redemption,omitempty"`
+	Funding       uint64                 `protobuf:"varint,3,opt,name=funding,proto3" json:"funding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fees) Reset() {
+	*x = Fees{}
+	mi := &file_bisonw_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fees) ProtoMessage() {}
+
+func (x *Fees) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fees.ProtoReflect.Descriptor instead.
+func (*Fees) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Fees) GetSwap() uint64 {
+	if x != nil {
+		return x.Swap
+	}
+	return 0
+}
+
+func (x *Fees) GetRedemption() uint64 {
+	if x != nil {
+		return x.Redemption
+	}
+	return 0
+}
+
+func (x *Fees) GetFunding() uint64 {
+	if x != nil {
+		return x.Funding
+	}
+	return 0
+}
+
+type BookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Base          uint32                 `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
+	Quote         uint32                 `protobuf:"varint,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookRequest) Reset() {
+	*x = BookRequest{}
+	mi := &file_bisonw_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookRequest) ProtoMessage() {}
+
+func (x *BookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookRequest.ProtoReflect.Descriptor instead.
+func (*BookRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BookRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *BookRequest) GetBase() uint32 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *BookRequest) GetQuote() uint32 {
+	if x != nil {
+		return x.Quote
+	}
+	return 0
+}
+
+type OrderBook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sells         []*BookOrder           `protobuf:"bytes,1,rep,name=sells,proto3" json:"sells,omitempty"`
+	Buys          []*BookOrder           `protobuf:"bytes,2,rep,name=buys,proto3" json:"buys,omitempty"`
+	Epoch         []*BookOrder           `protobuf:"bytes,3,rep,name=epoch,proto3" json:"epoch,omitempty"`
+	RecentMatches []*MatchSummary        `protobuf:"bytes,4,rep,name=recent_matches,json=recentMatches,proto3" json:"recent_matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBook) Reset() {
+	*x = OrderBook{}
+	mi := &file_bisonw_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBook) ProtoMessage() {}
+
+func (x *OrderBook) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
+func (*OrderBook) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *OrderBook) GetSells() []*BookOrder {
+	if x != nil {
+		return x.Sells
+	}
+	return nil
+}
+
+func (x *OrderBook) GetBuys() []*BookOrder {
+	if x != nil {
+		return x.Buys
+	}
+	return nil
+}
+
+func (x *OrderBook) GetEpoch() []*BookOrder {
+	if x != nil {
+		return x.Epoch
+	}
+	return nil
+}
+
+func (x *OrderBook) GetRecentMatches() []*MatchSummary {
+	if x != nil {
+		return x.RecentMatches
+	}
+	return nil
+}
+
+type BookOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Qty           uint64                 `protobuf:"varint,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	Rate          uint64                 `protobuf:"varint,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Sell          bool                   `protobuf:"varint,5,opt,name=sell,proto3" json:"sell,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookOrder) Reset() {
+	*x = BookOrder{}
+	mi := &file_bisonw_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookOrder) ProtoMessage() {}
+
+func (x *BookOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookOrder.ProtoReflect.Descriptor instead.
+func (*BookOrder) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *BookOrder) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *BookOrder) GetQty() uint64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *BookOrder) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *BookOrder) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *BookOrder) GetSell() bool {
+	if x != nil {
+		return x.Sell
+	}
+	return false
+}
+
+type MatchSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rate          uint64                 `protobuf:"varint,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	Qty           uint64                 `protobuf:"varint,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	Stamp         uint64                 `protobuf:"varint,3,opt,name=stamp,proto3" json:"stamp,omitempty"`
+	Sell          bool                   `protobuf:"varint,4,opt,name=sell,proto3" json:"sell,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchSummary) Reset() {
+	*x = MatchSummary{}
+	mi := &file_bisonw_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchSummary) ProtoMessage() {}
+
+func (x *MatchSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchSummary.ProtoReflect.Descriptor instead.
+func (*MatchSummary) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *MatchSummary) GetRate() uint64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *MatchSummary) GetQty() uint64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+func (x *MatchSummary) GetStamp() uint64 {
+	if x != nil {
+		return x.Stamp
+	}
+	return 0
+}
+
+func (x *MatchSummary) GetSell() bool {
+	if x != nil {
+		return x.Sell
+	}
+	return false
+}
+
+type RemainderUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Qty           uint64                 `protobuf:"varint,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemainderUpdate) Reset() {
+	*x = RemainderUpdate{}
+	mi := &file_bisonw_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemainderUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemainderUpdate) ProtoMessage() {}
+
+func (x *RemainderUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemainderUpdate.ProtoReflect.Descriptor instead.
+func (*RemainderUpdate) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RemainderUpdate) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RemainderUpdate) GetQty() uint64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+type EpochMatchSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Epoch         uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Matches       []*MatchSummary        `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EpochMatchSummary) Reset() {
+	*x = EpochMatchSummary{}
+	mi := &file_bisonw_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EpochMatchSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EpochMatchSummary) ProtoMessage() {}
+
+func (x *EpochMatchSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EpochMatchSummary.ProtoReflect.Descriptor instead.
+func (*EpochMatchSummary) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *EpochMatchSummary) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *EpochMatchSummary) GetMatches() []*MatchSummary {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type ResolvedEpoch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       uint64                 `protobuf:"varint,1,opt,name=current,proto3" json:"current,omitempty"`
+	Resolved      uint64                 `protobuf:"varint,2,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedEpoch) Reset() {
+	*x = ResolvedEpoch{}
+	mi := &file_bisonw_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedEpoch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedEpoch) ProtoMessage() {}
+
+func (x *ResolvedEpoch) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedEpoch.ProtoReflect.Descriptor instead.
+func (*ResolvedEpoch) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ResolvedEpoch) GetCurrent() uint64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *ResolvedEpoch) GetResolved() uint64 {
+	if x != nil {
+		return x.Resolved
+	}
+	return 0
+}
+
+type BookUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// action is one of book, book_order, epoch_order, unbook_order,
+	// update_remaining, epoch_match_summary or epoch_resolved.
+	Action   string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Host     string `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	MarketId string `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*BookUpdate_Book
+	//	*BookUpdate_Order
+	//	*BookUpdate_Remainder
+	//	*BookUpdate_MatchSummary
+	//	*BookUpdate_ResolvedEpoch
+	Payload       isBookUpdate_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookUpdate) Reset() {
+	*x = BookUpdate{}
+	mi := &file_bisonw_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookUpdate) ProtoMessage() {}
+
+func (x *BookUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookUpdate.ProtoReflect.Descriptor instead.
+func (*BookUpdate) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BookUpdate) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *BookUpdate) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *BookUpdate) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *BookUpdate) GetPayload() isBookUpdate_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *BookUpdate) GetBook() *OrderBook {
+	if x != nil {
+		if x, ok := x.Payload.(*BookUpdate_Book); ok {
+			return x.Book
+		}
+	}
+	return nil
+}
+
+func (x *BookUpdate) GetOrder() *BookOrder {
+	if x != nil {
+		if x, ok := x.Payload.(*BookUpdate_Order); ok {
+			return x.Order
+		}
+	}
+	return nil
+}
+
+func (x *BookUpdate) GetRemainder() *RemainderUpdate {
+	if x != nil {
+		if x, ok := x.Payload.(*BookUpdate_Remainder); ok {
+			return x.Remainder
+		}
+	}
+	return nil
+}
+
+func (x *BookUpdate) GetMatchSummary() *EpochMatchSummary {
+	if x != nil {
+		if x, ok := x.Payload.(*BookUpdate_MatchSummary); ok {
+			return x.MatchSummary
+		}
+	}
+	return nil
+}
+
+func (x *BookUpdate) GetResolvedEpoch() *ResolvedEpoch {
+	if x != nil {
+		if x, ok := x.Payload.(*BookUpdate_ResolvedEpoch); ok {
+			return x.ResolvedEpoch
+		}
+	}
+	return nil
+}
+
+type isBookUpdate_Payload interface {
+	isBookUpdate_Payload()
+}
+
+type BookUpdate_Book struct {
+	Book *OrderBook `protobuf:"bytes,4,opt,name=book,proto3,oneof"`
+}
+
+type BookUpdate_Order struct {
+	Order *BookOrder `protobuf:"bytes,5,opt,name=order,proto3,oneof"`
+}
+
+type BookUpdate_Remainder struct {
+	Remainder *RemainderUpdate `protobuf:"bytes,6,opt,name=remainder,proto3,oneof"`
+}
+
+type BookUpdate_MatchSummary struct {
+	MatchSummary *EpochMatchSummary `protobuf:"bytes,7,opt,name=match_summary,json=matchSummary,proto3,oneof"`
+}
+
+type BookUpdate_ResolvedEpoch struct {
+	ResolvedEpoch *ResolvedEpoch `protobuf:"bytes,8,opt,name=resolved_epoch,json=resolvedEpoch,proto3,oneof"`
+}
+
+func (*BookUpdate_Book) isBookUpdate_Payload() {}
+
+func (*BookUpdate_Order) isBookUpdate_Payload() {}
+
+func (*BookUpdate_Remainder) isBookUpdate_Payload() {}
+
+func (*BookUpdate_MatchSummary) isBookUpdate_Payload() {}
+
+func (*BookUpdate_ResolvedEpoch) isBookUpdate_Payload() {}
+
+type OrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// n is the maximum number of orders. 0 means all of them.
+	N int32 `protobuf:"varint,1,opt,name=n,proto3" json:"n,omitempty"`
+	// offset is the ID of the order to list from.
+	Offset        []byte        `protobuf:"bytes,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Hosts         []string      `protobuf:"bytes,3,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Assets        []uint32      `protobuf:"varint,4,rep,packed,name=assets,proto3" json:"assets,omitempty"`
+	Statuses      []OrderStatus `protobuf:"varint,5,rep,packed,name=statuses,proto3,enum=bisonw.OrderStatus" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrdersRequest) Reset() {
+	*x = OrdersRequest{}
+	mi := &file_bisonw_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrdersRequest) ProtoMessage() {}
+
+func (x *OrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrdersRequest.ProtoReflect.Descriptor instead.
+func (*OrdersRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *OrdersRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *OrdersRequest) GetOffset() []byte {
+	if x != nil {
+		return x.Offset
+	}
+	return nil
+}
+
+func (x *OrdersRequest) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *OrdersRequest) GetAssets() []uint32 {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *OrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type OrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrdersResponse) Reset() {
+	*x = OrdersResponse{}
+	mi := &file_bisonw_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrdersResponse) ProtoMessage() {}
+
+func (x *OrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrdersResponse.ProtoReflect.Descriptor instead.
+func (*OrdersResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *OrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       []byte                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_bisonw_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetOrderRequest) GetOrderId() []byte {
+	if x != nil {
+		return x.OrderId
+	}
+	return nil
+}
+
+type MMStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MMStatusRequest) Reset() {
+	*x = MMStatusRequest{}
+	mi := &file_bisonw_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MMStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MMStatusRequest) ProtoMessage() {}
+
+func (x *MMStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MMStatusRequest.ProtoReflect.Descriptor instead.
+func (*MMStatusRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{47}
+}
+
+type MMStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bots          []*BotStatus           `protobuf:"bytes,1,rep,name=bots,proto3" json:"bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MMStatusResponse) Reset() {
+	*x = MMStatusResponse{}
+	mi := &file_bisonw_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MMStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MMStatusResponse) ProtoMessage() {}
+
+func (x *MMStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MMStatusResponse.ProtoReflect.Descriptor instead.
+func (*MMStatusResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *MMStatusResponse) GetBots() []*BotStatus {
+	if x != nil {
+		return x.Bots
+	}
+	return nil
+}
+
+type BotStatus struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Host               string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	BaseId             uint32                 `protobuf:"varint,2,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+	QuoteId            uint32                 `protobuf:"varint,3,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	CexName            string                 `protobuf:"bytes,4,opt,name=cex_name,json=cexName,proto3" json:"cex_name,omitempty"`
+	Running            bool                   `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	StartTime          int64                  `protobuf:"varint,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	CompletedMatches   uint32                 `protobuf:"varint,7,opt,name=completed_matches,json=completedMatches,proto3" json:"completed_matches,omitempty"`
+	TradedUsd          float64                `protobuf:"fixed64,8,opt,name=traded_usd,json=tradedUsd,proto3" json:"traded_usd,omitempty"`
+	ProfitLoss         float64                `protobuf:"fixed64,9,opt,name=profit_loss,json=profitLoss,proto3" json:"profit_loss,omitempty"`
+	PendingDeposits    int32                  `protobuf:"varint,10,opt,name=pending_deposits,json=pendingDeposits,proto3" json:"pending_deposits,omitempty"`
+	PendingWithdrawals int32                  `protobuf:"varint,11,opt,name=pending_withdrawals,json=pendingWithdrawals,proto3" json:"pending_withdrawals,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BotStatus) Reset() {
+	*x = BotStatus{}
+	mi := &file_bisonw_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BotStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotStatus) ProtoMessage() {}
+
+func (x *BotStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotStatus.ProtoReflect.Descriptor instead.
+func (*BotStatus) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *BotStatus) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *BotStatus) GetBaseId() uint32 {
+	if x != nil {
+		return x.BaseId
+	}
+	return 0
+}
+
+func (x *BotStatus) GetQuoteId() uint32 {
+	if x != nil {
+		return x.QuoteId
+	}
+	return 0
+}
+
+func (x *BotStatus) GetCexName() string {
+	if x != nil {
+		return x.CexName
+	}
+	return ""
+}
+
+func (x *BotStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *BotStatus) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *BotStatus) GetCompletedMatches() uint32 {
+	if x != nil {
+		return x.CompletedMatches
+	}
+	return 0
+}
+
+func (x *BotStatus) GetTradedUsd() float64 {
+	if x != nil {
+		return x.TradedUsd
+	}
+	return 0
+}
+
+func (x *BotStatus) GetProfitLoss() float64 {
+	if x != nil {
+		return x.ProfitLoss
+	}
+	return 0
+}
+
+func (x *BotStatus) GetPendingDeposits() int32 {
+	if x != nil {
+		return x.PendingDeposits
+	}
+	return 0
+}
+
+func (x *BotStatus) GetPendingWithdrawals() int32 {
+	if x != nil {
+		return x.PendingWithdrawals
+	}
+	return 0
+}
+
+type StartBotRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AppPass []byte                 `protobuf:"bytes,1,opt,name=app_pass,json=appPass,proto3" json:"app_pass,omitempty"`
+	Host    string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	BaseId  uint32                 `protobuf:"varint,3,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+	QuoteId uint32                 `protobuf:"varint,4,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	// config_path is an alternate bot configuration file.
+	ConfigPath    string `protobuf:"bytes,5,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartBotRequest) Reset() {
+	*x = StartBotRequest{}
+	mi := &file_bisonw_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBotRequest) ProtoMessage() {}
+
+func (x *StartBotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBotRequest.ProtoReflect.Descriptor instead.
+func (*StartBotRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *StartBotRequest) GetAppPass() []byte {
+	if x != nil {
+		return x.AppPass
+	}
+	return nil
+}
+
+func (x *StartBotRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *StartBotRequest) GetBaseId() uint32 {
+	if x != nil {
+		return x.BaseId
+	}
+	return 0
+}
+
+func (x *StartBotRequest) GetQuoteId() uint32 {
+	if x != nil {
+		return x.QuoteId
+	}
+	return 0
+}
+
+func (x *StartBotRequest) GetConfigPath() string {
+	if x != nil {
+		return x.ConfigPath
+	}
+	return ""
+}
+
+type StartBotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartBotResponse) Reset() {
+	*x = StartBotResponse{}
+	mi := &file_bisonw_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBotResponse) ProtoMessage() {}
+
+func (x *StartBotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBotResponse.ProtoReflect.Descriptor instead.
+func (*StartBotResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{51}
+}
+
+type StopBotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	BaseId        uint32                 `protobuf:"varint,2,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+	QuoteId       uint32                 `protobuf:"varint,3,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopBotRequest) Reset() {
+	*x = StopBotRequest{}
+	mi := &file_bisonw_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopBotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopBotRequest) ProtoMessage() {}
+
+func (x *StopBotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopBotRequest.ProtoReflect.Descriptor instead.
+func (*StopBotRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *StopBotRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *StopBotRequest) GetBaseId() uint32 {
+	if x != nil {
+		return x.BaseId
+	}
+	return 0
+}
+
+func (x *StopBotRequest) GetQuoteId() uint32 {
+	if x != nil {
+		return x.QuoteId
+	}
+	return 0
+}
+
+type StopBotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopBotResponse) Reset() {
+	*x = StopBotResponse{}
+	mi := &file_bisonw_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopBotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopBotResponse) ProtoMessage() {}
+
+func (x *StopBotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopBotResponse.ProtoReflect.Descriptor instead.
+func (*StopBotResponse) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{53}
+}
+
+type NotificationFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationFeedRequest) Reset() {
+	*x = NotificationFeedRequest{}
+	mi := &file_bisonw_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationFeedRequest) ProtoMessage() {}
+
+func (x *NotificationFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationFeedRequest.ProtoReflect.Descriptor instead.
+func (*NotificationFeedRequest) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{54}
+}
+
+type Notification struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type     string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Topic    string                 `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	Subject  string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Details  string                 `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	Severity Severity               `protobuf:"varint,6,opt,name=severity,proto3,enum=bisonw.Severity" json:"severity,omitempty"`
+	Time     uint64                 `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
+	// payload is the JSON encoding of the notification, with any fields
+	// specific to its type.
+	Payload       []byte `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_bisonw_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_bisonw_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_bisonw_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Notification) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Notification) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Notification) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Notification) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Notification) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *Notification) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_IGNORABLE
+}
+
+func (x *Notification) GetTime() uint64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *Notification) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_bisonw_proto protoreflect.FileDescriptor
+
+var file_bisonw_proto_rawDesc = string([]byte{
+	0x0a, 0x0c, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x22, 0x10, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x59, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x62,
+	0x69, 0x73, 0x6f, 0x6e, 0x77, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x12, 0x0a, 0x10, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x43, 0x0a, 0x11, 0x45, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x09, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x3d, 0x0a, 0x13,
+	0x47, 0x65, 0x74, 0x44, 0x45, 0x58, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x22, 0xae, 0x04, 0x0a, 0x08,
+	0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x61, 0x63, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x63, 0x63, 0x74, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x07, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x12,
+	0x25, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52, 0x06,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6f, 0x6e, 0x64, 0x5f, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x62, 0x6f, 0x6e,
+	0x64, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x0b, 0x62, 0x6f, 0x6e, 0x64, 0x5f,
+	0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62,
+	0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x42, 0x6f, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x52,
+	0x0a, 0x62, 0x6f, 0x6e, 0x64, 0x41, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x11, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x69, 0x65, 0x77, 0x4f, 0x6e, 0x6c, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x69, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x69, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d,
+	0x6c, 0x69, 0x76, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x69, 0x76, 0x65, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x72,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x5f, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x70,
+	0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xce, 0x02, 0x0a,
+	0x06, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62,
+	0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x62, 0x61,
+	0x73, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6c, 0x6f, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x61, 0x72, 0x63, 0x65, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x63, 0x65, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x65, 0x70, 0x12, 0x1b, 0x0a, 0x09,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x4c, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69,
+	0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x75, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x22, 0x88, 0x01,
+	0x0a, 0x05, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x77, 0x61,
+	0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x77,
+	0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x65,
+	0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x46, 0x65, 0x65, 0x52, 0x61, 0x74, 0x65, 0x22, 0x7b, 0x0a, 0x09, 0x42, 0x6f, 0x6e, 0x64,
+	0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6e, 0x66, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x6e, 0x66, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x29, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x70, 0x61, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x70, 0x70, 0x50, 0x61, 0x73, 0x73,
+	0x22, 0x0f, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x0f, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x10, 0x0a, 0x0e, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbf, 0x01, 0x0a, 0x0f, 0x50, 0x6f, 0x73, 0x74, 0x42, 0x6f, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x61, 0x70, 0x70, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x61, 0x70, 0x70, 0x50, 0x61, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x62, 0x6f, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x0d, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x5f, 0x74, 0x69, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x0c, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x65, 0x72, 0x88, 0x01, 0x01,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x63, 0x65, 0x72, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x5f, 0x74, 0x69, 0x65, 0x72, 0x22, 0x4e, 0x0a, 0x10, 0x50, 0x6f, 0x73, 0x74, 0x42, 0x6f,
+	0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6f,
+	0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6f, 0x6e,
+	0x64, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x73, 0x22, 0x10, 0x0a, 0x0e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3b, 0x0a, 0x0f, 0x57, 0x61, 0x6c, 0x6c,
+	0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x77,
+	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x62,
+	0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x07, 0x77, 0x61,
+	0x6c, 0x6c, 0x65, 0x74, 0x73, 0x22, 0x88, 0x03, 0x0a, 0x06, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12,
+	0x29, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x65, 0x65, 0x72,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x65,
+	0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x73, 0x79, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x22, 0xc8, 0x01, 0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6d,
+	0x6d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x69, 0x6d,
+	0x6d, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x5f, 0x6c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x6f,
+	0x6e, 0x64, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x62, 0x6f, 0x6e, 0x64, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x22, 0x49, 0x0a, 0x11, 0x4f,
+	0x70, 0x65, 0x6e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x61,
+	0x70, 0x70, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61,
+	0x70, 0x70, 0x50, 0x61, 0x73, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x4f, 0x70, 0x65, 0x6e, 0x57, 0x61,
+	0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2f, 0x0a, 0x12,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x22, 0x15, 0x0a,
+	0x13, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x35, 0x0a, 0x18, 0x4e, 0x65, 0x77, 0x44, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x22, 0x35, 0x0a, 0x19, 0x4e,
+	0x65, 0x77, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x70, 0x70, 0x50, 0x61, 0x73, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x75, 0x62, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x22, 0x37, 0x0a, 0x0c, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x22, 0x76, 0x0a,
+	0x10, 0x54, 0x78, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x73, 0x73, 0x65, 0x74, 0x49, 0x64, 0x12, 0x0c, 0x0a, 0x01,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6e, 0x12, 0x1a, 0x0a, 0x06, 0x72, 0x65,
+	0x66, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65,
+	0x66, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x70, 0x61, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72,
+	0x65, 0x66, 0x5f, 0x69, 0x64, 0x22, 0x40, 0x0a, 0x11, 0x54, 0x78, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x78,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77,
+	0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x03, 0x74, 0x78, 0x73, 0x22, 0xbc, 0x02, 0x0a, 0x11, 0x57, 0x61, 0x6c, 0x6c,
+	0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x65, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x65, 0x65, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1e,
+	0x0a, 0x08, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x00, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x21,
+	0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x01, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x22, 0xce, 0x02, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x70,
+	0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x70, 0x70, 0x50, 0x61,
+	0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x73, 0x65, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x71, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x71, 0x74,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x69, 0x66, 0x5f, 0x6e, 0x6f, 0x77,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x74, 0x69, 0x66, 0x4e, 0x6f, 0x77, 0x12, 0x3b,
+	0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2a, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xb1, 0x05, 0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x62, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x19, 0x0a,
+	0x08, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x6f, 0x74,
+	0x65, 0x5f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x74, 0x79,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x71, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x65, 0x6c, 0x6c, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x65, 0x6c, 0x6c, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e,
+	0x77, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65,
+	0x12, 0x25, 0x0a, 0x03, 0x74, 0x69, 0x66, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46, 0x6f, 0x72,
+	0x63, 0x65, 0x52, 0x03, 0x74, 0x69, 0x66, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x6d, 0x74, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x41, 0x6d, 0x74, 0x12, 0x29,
+	0x0a, 0x09, 0x66, 0x65, 0x65, 0x73, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x46, 0x65, 0x65, 0x73, 0x52,
+	0x08, 0x66, 0x65, 0x65, 0x73, 0x50, 0x61, 0x69, 0x64, 0x22, 0xaa, 0x03, 0x0a, 0x05, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x2b,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72, 0x61, 0x74,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x71, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x65,
+	0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x66, 0x65,
+	0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x77, 0x61, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x53, 0x77, 0x61, 0x70, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x64, 0x65, 0x65, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x5f,
+	0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x66,
+	0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f,
+	0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x22, 0x54, 0x0a, 0x04, 0x46, 0x65, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x77, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x77,
+	0x61, 0x70, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x64, 0x65, 0x6d, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x72, 0x65, 0x64, 0x65, 0x6d, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x66, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x4b, 0x0a, 0x0b,
+	0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x62,
+	0x61, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x22, 0xc1, 0x01, 0x0a, 0x09, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x27, 0x0a, 0x05, 0x73, 0x65, 0x6c, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x42, 0x6f, 0x6f, 0x6b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x73, 0x65, 0x6c, 0x6c, 0x73,
+	0x12, 0x25, 0x0a, 0x04, 0x62, 0x75, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x52, 0x04, 0x62, 0x75, 0x79, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x42, 0x6f, 0x6f, 0x6b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x12, 0x3b, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e,
+	0x77, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x0d,
+	0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x71, 0x0a,
+	0x09, 0x42, 0x6f, 0x6f, 0x6b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x71, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x71,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x65, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x65, 0x6c, 0x6c,
+	0x22, 0x5e, 0x0a, 0x0c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x72, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x71, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x65, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x65, 0x6c, 0x6c,
+	0x22, 0x39, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x74, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x71, 0x74, 0x79, 0x22, 0x59, 0x0a, 0x11, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77,
+	0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x07, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x45, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x22, 0xef, 0x02,
+	0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x29,
+	0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x09, 0x72, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62,
+	0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x40, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x69, 0x73, 0x6f,
+	0x6e, 0x77, 0x2e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x12, 0x3e, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64,
+	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x62,
+	0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x45, 0x70,
+	0x6f, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22,
+	0x94, 0x01, 0x0a, 0x0d, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x61,
+	0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x22, 0x37, 0x0a, 0x0e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e,
+	0x77, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x22,
+	0x2c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x11, 0x0a,
+	0x0f, 0x4d, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x39, 0x0a, 0x10, 0x4d, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x62, 0x6f, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x42, 0x6f, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x62, 0x6f, 0x74, 0x73, 0x22, 0xf0, 0x02, 0x0a, 0x09,
+	0x42, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x49,
+	0x64, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x65, 0x78, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x65, 0x78, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
+	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x64, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x72, 0x61, 0x64, 0x65, 0x64, 0x55, 0x73,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x5f, 0x6c, 0x6f, 0x73, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x4c, 0x6f,
+	0x73, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x12, 0x2f, 0x0a,
+	0x13, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61,
+	0x77, 0x61, 0x6c, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x70, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x57, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x73, 0x22, 0x95,
+	0x01, 0x0a, 0x0f, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x70, 0x70, 0x50, 0x61, 0x73, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x50, 0x61, 0x74, 0x68, 0x22, 0x12, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42,
+	0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x58, 0x0a, 0x0e, 0x53, 0x74,
+	0x6f, 0x70, 0x42, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x49, 0x64, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x6f, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x0a, 0x17, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0xd8, 0x01, 0x0a, 0x0c, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x12, 0x2c, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x53, 0x65, 0x76,
+	0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2a, 0x45, 0x0a,
+	0x10, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x43, 0x45,
+	0x52, 0x54, 0x10, 0x02, 0x2a, 0x46, 0x0a, 0x09, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x4f, 0x52, 0x44,
+	0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x49, 0x4d,
+	0x49, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x45, 0x54, 0x10, 0x02,
+	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x10, 0x03, 0x2a, 0x67, 0x0a, 0x0b,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x14, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x50, 0x4f, 0x43, 0x48, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x42, 0x4f, 0x4f, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08,
+	0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x56, 0x4f,
+	0x4b, 0x45, 0x44, 0x10, 0x05, 0x2a, 0x2a, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x46,
+	0x6f, 0x72, 0x63, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4d, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x54,
+	0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x2a, 0x87, 0x01, 0x0a, 0x0b, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x45, 0x57, 0x4c, 0x59, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x4b, 0x45, 0x52, 0x5f, 0x53, 0x57,
+	0x41, 0x50, 0x5f, 0x43, 0x41, 0x53, 0x54, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x41, 0x4b,
+	0x45, 0x52, 0x5f, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x43, 0x41, 0x53, 0x54, 0x10, 0x02, 0x12, 0x12,
+	0x0a, 0x0e, 0x4d, 0x41, 0x4b, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x44, 0x45, 0x45, 0x4d, 0x45, 0x44,
+	0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x43, 0x4f, 0x4d, 0x50,
+	0x4c, 0x45, 0x54, 0x45, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f,
+	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x05, 0x2a, 0x21, 0x0a, 0x09, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x41, 0x4b, 0x45,
+	0x52, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x41, 0x4b, 0x45, 0x52, 0x10, 0x01, 0x2a, 0x58,
+	0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x47,
+	0x4e, 0x4f, 0x52, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54,
+	0x41, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x4b, 0x45, 0x10, 0x02, 0x12, 0x0b, 0x0a,
+	0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41,
+	0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x10, 0x05, 0x32, 0xce, 0x0a, 0x0a, 0x06, 0x42, 0x69, 0x73,
+	0x6f, 0x6e, 0x77, 0x12, 0x3a, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x40, 0x0a, 0x09, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x62,
+	0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x44, 0x45, 0x58, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x1b, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x45,
+	0x58, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x34, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x14, 0x2e, 0x62, 0x69, 0x73, 0x6f,
+	0x6e, 0x77, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74,
+	0x12, 0x15, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77,
+	0x2e, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3d, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x74, 0x42, 0x6f, 0x6e, 0x64, 0x12, 0x17, 0x2e, 0x62, 0x69,
+	0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x42, 0x6f, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x50, 0x6f,
+	0x73, 0x74, 0x42, 0x6f, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a,
+	0x0a, 0x07, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x62, 0x69, 0x73, 0x6f,
+	0x6e, 0x77, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x4f, 0x70,
+	0x65, 0x6e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e,
+	0x77, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4f, 0x70, 0x65,
+	0x6e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x46, 0x0a, 0x0b, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x1a,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x57, 0x61, 0x6c,
+	0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x62, 0x69, 0x73,
+	0x6f, 0x6e, 0x77, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x11, 0x4e, 0x65, 0x77, 0x44, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x2e, 0x62,
+	0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4e, 0x65, 0x77, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4e, 0x65, 0x77, 0x44, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x31, 0x0a, 0x04, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x13, 0x2e, 0x62, 0x69, 0x73, 0x6f,
+	0x6e, 0x77, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x54, 0x78, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x18, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x54, 0x78, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x62, 0x69,
+	0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x54, 0x78, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x64, 0x65, 0x12,
+	0x14, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x15,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
+	0x04, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x13, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x62, 0x69, 0x73,
+	0x6f, 0x6e, 0x77, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x36, 0x0a,
+	0x09, 0x57, 0x61, 0x74, 0x63, 0x68, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x13, 0x2e, 0x62, 0x69, 0x73,
+	0x6f, 0x6e, 0x77, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x06, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x15, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32,
+	0x0a, 0x08, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x62, 0x69, 0x73,
+	0x6f, 0x6e, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x12, 0x3d, 0x0a, 0x08, 0x4d, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17,
+	0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4d, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77,
+	0x2e, 0x4d, 0x4d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3d, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6f, 0x74, 0x12, 0x17, 0x2e,
+	0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3a, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x6f, 0x74, 0x12, 0x16, 0x2e, 0x62, 0x69,
+	0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x42, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x53, 0x74, 0x6f,
+	0x70, 0x42, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x10,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x64,
+	0x12, 0x1f, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x62, 0x69, 0x73, 0x6f, 0x6e, 0x77, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x42, 0x2a, 0x5a, 0x28, 0x64, 0x65, 0x63,
+	0x72, 0x65, 0x64, 0x2e, 0x6f, 0x72, 0x67, 0x2f, 0x64, 0x63, 0x72, 0x64, 0x65, 0x78, 0x2f, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2f, 0x62, 0x77, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_bisonw_proto_rawDescOnce sync.Once
+	file_bisonw_proto_rawDescData []byte
+)
+
+func file_bisonw_proto_rawDescGZIP() []byte {
+	file_bisonw_proto_rawDescOnce.Do(func() {
+		file_bisonw_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_bisonw_proto_rawDesc), len(file_bisonw_proto_rawDesc)))
+	})
+	return file_bisonw_proto_rawDescData
+}
+
+var file_bisonw_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_bisonw_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
+var file_bisonw_proto_goTypes = []any{
+	(ConnectionStatus)(0),             // 0: bisonw.ConnectionStatus
+	(OrderType)(0),                    // 1: bisonw.OrderType
+	(OrderStatus)(0),                  // 2: bisonw.OrderStatus
+	(TimeInForce)(0),                  // 3: bisonw.TimeInForce
+	(MatchStatus)(0),                  // 4: bisonw.MatchStatus
+	(MatchSide)(0),                    // 5: bisonw.MatchSide
+	(Severity)(0),                     // 6: bisonw.Severity
+	(*VersionRequest)(nil),            // 7: bisonw.VersionRequest
+	(*VersionResponse)(nil),           // 8: bisonw.VersionResponse
+	(*ExchangesRequest)(nil),          // 9: bisonw.ExchangesRequest
+	(*ExchangesResponse)(nil),         // 10: bisonw.ExchangesResponse
+	(*GetDEXConfigRequest)(nil),       // 11: bisonw.GetDEXConfigRequest
+	(*Exchange)(nil),                  // 12: bisonw.Exchange
+	(*Market)(nil),                    // 13: bisonw.Market
+	(*Asset)(nil),                     // 14: bisonw.Asset
+	(*BondAsset)(nil),                 // 15: bisonw.BondAsset
+	(*LoginRequest)(nil),              // 16: bisonw.LoginRequest
+	(*LoginResponse)(nil),             // 17: bisonw.LoginResponse
+	(*LogoutRequest)(nil),             // 18: bisonw.LogoutRequest
+	(*LogoutResponse)(nil),            // 19: bisonw.LogoutResponse
+	(*PostBondRequest)(nil),           // 20: bisonw.PostBondRequest
+	(*PostBondResponse)(nil),          // 21: bisonw.PostBondResponse
+	(*WalletsRequest)(nil),            // 22: bisonw.WalletsRequest
+	(*WalletsResponse)(nil),           // 23: bisonw.WalletsResponse
+	(*Wallet)(nil),                    // 24: bisonw.Wallet
+	(*Balance)(nil),                   // 25: bisonw.Balance
+	(*OpenWalletRequest)(nil),         // 26: bisonw.OpenWalletRequest
+	(*OpenWalletResponse)(nil),        // 27: bisonw.OpenWalletResponse
+	(*CloseWalletRequest)(nil),        // 28: bisonw.CloseWalletRequest
+	(*CloseWalletResponse)(nil),       // 29: bisonw.CloseWalletResponse
+	(*NewDepositAddressRequest)(nil),  // 30: bisonw.NewDepositAddressRequest
+	(*NewDepositAddressResponse)(nil), // 31: bisonw.NewDepositAddressResponse
+	(*SendRequest)(nil),               // 32: bisonw.SendRequest
+	(*SendResponse)(nil),              // 33: bisonw.SendResponse
+	(*TxHistoryRequest)(nil),          // 34: bisonw.TxHistoryRequest
+	(*TxHistoryResponse)(nil),         // 35: bisonw.TxHistoryResponse
+	(*WalletTransaction)(nil),         // 36: bisonw.WalletTransaction
+	(*TradeRequest)(nil),              // 37: bisonw.TradeRequest
+	(*CancelRequest)(nil),             // 38: bisonw.CancelRequest
+	(*CancelResponse)(nil),            // 39: bisonw.CancelResponse
+	(*Order)(nil),                     // 40: bisonw.Order
+	(*Match)(nil),                     // 41: bisonw.Match
+	(*Fees)(nil),                      // 42: bisonw.Fees
+	(*BookRequest)(nil),               // 43: bisonw.BookRequest
+	(*OrderBook)(nil),                 // 44: bisonw.OrderBook
+	(*BookOrder)(nil),                 // 45: bisonw.BookOrder
+	(*MatchSummary)(nil),              // 46: bisonw.MatchSummary
+	(*RemainderUpdate)(nil),           // 47: bisonw.RemainderUpdate
+	(*EpochMatchSummary)(nil),         // 48: bisonw.EpochMatchSummary
+	(*ResolvedEpoch)(nil),             // 49: bisonw.ResolvedEpoch
+	(*BookUpdate)(nil),                // 50: bisonw.BookUpdate
+	(*OrdersRequest)(nil),             // 51: bisonw.OrdersRequest
+	(*OrdersResponse)(nil),            // 52: bisonw.OrdersResponse
+	(*GetOrderRequest)(nil),           // 53: bisonw.GetOrderRequest
+	(*MMStatusRequest)(nil),           // 54: bisonw.MMStatusRequest
+	(*MMStatusResponse)(nil),          // 55: bisonw.MMStatusResponse
+	(*BotStatus)(nil),                 // 56: bisonw.BotStatus
+	(*StartBotRequest)(nil),           // 57: bisonw.StartBotRequest
+	(*StartBotResponse)(nil),          // 58: bisonw.StartBotResponse
+	(*StopBotRequest)(nil),            // 59: bisonw.StopBotRequest
+	(*StopBotResponse)(nil),           // 60: bisonw.StopBotResponse
+	(*NotificationFeedRequest)(nil),   // 61: bisonw.NotificationFeedRequest
+	(*Notification)(nil),              // 62: bisonw.Notification
+	nil,                               // 63: bisonw.TradeRequest.OptionsEntry
+}
+var file_bisonw_proto_depIdxs = []int32{
+	12, // 0: bisonw.ExchangesResponse.exchanges:type_name -> bisonw.Exchange
+	13, // 1: bisonw.Exchange.markets:type_name -> bisonw.Market
+	14, // 2: bisonw.Exchange.assets:type_name -> bisonw.Asset
+	15, // 3: bisonw.Exchange.bond_assets:type_name -> bisonw.BondAsset
+	0,  // 4: bisonw.Exchange.connection_status:type_name -> bisonw.ConnectionStatus
+	24, // 5: bisonw.WalletsResponse.wallets:type_name -> bisonw.Wallet
+	25, // 6: bisonw.Wallet.balance:type_name -> bisonw.Balance
+	36, // 7: bisonw.TxHistoryResponse.txs:type_name -> bisonw.WalletTransaction
+	63, // 8: bisonw.TradeRequest.options:type_name -> bisonw.TradeRequest.OptionsEntry
+	1,  // 9: bisonw.Order.type:type_name -> bisonw.OrderType
+	2,  // 10: bisonw.Order.status:type_name -> bisonw.OrderStatus
+	41, // 11: bisonw.Order.matches:type_name -> bisonw.Match
+	3,  // 12: bisonw.Order.tif:type_name -> bisonw.TimeInForce
+	42, // 13: bisonw.Order.fees_paid:type_name -> bisonw.Fees
+	4,  // 14: bisonw.Match.status:type_name -> bisonw.MatchStatus
+	5,  // 15: bisonw.Match.side:type_name -> bisonw.MatchSide
+	45, // 16: bisonw.OrderBook.sells:type_name -> bisonw.BookOrder
+	45, // 17: bisonw.OrderBook.buys:type_name -> bisonw.BookOrder
+	45, // 18: bisonw.OrderBook.epoch:type_name -> bisonw.BookOrder
+	46, // 19: bisonw.OrderBook.recent_matches:type_name -> bisonw.MatchSummary
+	46, // 20: bisonw.EpochMatchSummary.matches:type_name -> bisonw.MatchSummary
+	44, // 21: bisonw.BookUpdate.book:type_name -> bisonw.OrderBook
+	45, // 22: bisonw.BookUpdate.order:type_name -> bisonw.BookOrder
+	47, // 23: bisonw.BookUpdate.remainder:type_name -> bisonw.RemainderUpdate
+	48, // 24: bisonw.BookUpdate.match_summary:type_name -> bisonw.EpochMatchSummary
+	49, // 25: bisonw.BookUpdate.resolved_epoch:type_name -> bisonw.ResolvedEpoch
+	2,  // 26: bisonw.OrdersRequest.statuses:type_name -> bisonw.OrderStatus
+	40, // 27: bisonw.OrdersResponse.orders:type_name -> bisonw.Order
+	56, // 28: bisonw.MMStatusResponse.bots:type_name -> bisonw.BotStatus
+	6,  // 29: bisonw.Notification.severity:type_name -> bisonw.Severity
+	7,  // 30: bisonw.Bisonw.Version:input_type -> bisonw.VersionRequest
+	9,  // 31: bisonw.Bisonw.Exchanges:input_type -> bisonw.ExchangesRequest
+	11, // 32: bisonw.Bisonw.GetDEXConfig:input_type -> bisonw.GetDEXConfigRequest
+	16, // 33: bisonw.Bisonw.Login:input_type -> bisonw.LoginRequest
+	18, // 34: bisonw.Bisonw.Logout:input_type -> bisonw.LogoutRequest
+	20, // 35: bisonw.Bisonw.PostBond:input_type -> bisonw.PostBondRequest
+	22, // 36: bisonw.Bisonw.Wallets:input_type -> bisonw.WalletsRequest
+	26, // 37: bisonw.Bisonw.OpenWallet:input_type -> bisonw.OpenWalletRequest
+	28, // 38: bisonw.Bisonw.CloseWallet:input_type -> bisonw.CloseWalletRequest
+	30, // 39: bisonw.Bisonw.NewDepositAddress:input_type -> bisonw.NewDepositAddressRequest
+	32, // 40: bisonw.Bisonw.Send:input_type -> bisonw.SendRequest
+	34, // 41: bisonw.Bisonw.TxHistory:input_type -> bisonw.TxHistoryRequest
+	37, // 42: bisonw.Bisonw.Trade:input_type -> bisonw.TradeRequest
+	38, // 43: bisonw.Bisonw.Cancel:input_type -> bisonw.CancelRequest
+	43, // 44: bisonw.Bisonw.Book:input_type -> bisonw.BookRequest
+	43, // 45: bisonw.Bisonw.WatchBook:input_type -> bisonw.BookRequest
+	51, // 46: bisonw.Bisonw.Orders:input_type -> bisonw.OrdersRequest
+	53, // 47: bisonw.Bisonw.GetOrder:input_type -> bisonw.GetOrderRequest
+	54, // 48: bisonw.Bisonw.MMStatus:input_type -> bisonw.MMStatusRequest
+	57, // 49: bisonw.Bisonw.StartBot:input_type -> bisonw.StartBotRequest
+	59, // 50: bisonw.Bisonw.StopBot:input_type -> bisonw.StopBotRequest
+	61, // 51: bisonw.Bisonw.NotificationFeed:input_type -> bisonw.NotificationFeedRequest
+	8,  // 52: bisonw.Bisonw.Version:output_type -> bisonw.VersionResponse
+	10, // 53: bisonw.Bisonw.Exchanges:output_type -> bisonw.ExchangesResponse
+	12, // 54: bisonw.Bisonw.GetDEXConfig:output_type -> bisonw.Exchange
+	17, // 55: bisonw.Bisonw.Login:output_type -> bisonw.LoginResponse
+	19, // 56: bisonw.Bisonw.Logout:output_type -> bisonw.LogoutResponse
+	21, // 57: bisonw.Bisonw.PostBond:output_type -> bisonw.PostBondResponse
+	23, // 58: bisonw.Bisonw.Wallets:output_type -> bisonw.WalletsResponse
+	27, // 59: bisonw.Bisonw.OpenWallet:output_type -> bisonw.OpenWalletResponse
+	29, // 60: bisonw.Bisonw.CloseWallet:output_type -> bisonw.CloseWalletResponse
+	31, // 61: bisonw.Bisonw.NewDepositAddress:output_type -> bisonw.NewDepositAddressResponse
+	33, // 62: bisonw.Bisonw.Send:output_type -> bisonw.SendResponse
+	35, // 63: bisonw.Bisonw.TxHistory:output_type -> bisonw.TxHistoryResponse
+	40, // 64: bisonw.Bisonw.Trade:output_type -> bisonw.Order
+	39, // 65: bisonw.Bisonw.Cancel:output_type -> bisonw.CancelResponse
+	44, // 66: bisonw.Bisonw.Book:output_type -> bisonw.OrderBook
+	50, // 67: bisonw.Bisonw.WatchBook:output_type -> bisonw.BookUpdate
+	52, // 68: bisonw.Bisonw.Orders:output_type -> bisonw.OrdersResponse
+	40, // 69: bisonw.Bisonw.GetOrder:output_type -> bisonw.Order
+	55, // 70: bisonw.Bisonw.MMStatus:output_type -> bisonw.MMStatusResponse
+	58, // 71: bisonw.Bisonw.StartBot:output_type -> bisonw.StartBotResponse
+	60, // 72: bisonw.Bisonw.StopBot:output_type -> bisonw.StopBotResponse
+	62, // 73: bisonw.Bisonw.NotificationFeed:output_type -> bisonw.Notification
+	52, // [52:74] is the sub-list for method output_type
+	30, // [30:52] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_bisonw_proto_init() }
+func file_bisonw_proto_init() {
+	if File_bisonw_proto != nil {
+		return
+	}
+	file_bisonw_proto_msgTypes[13].OneofWrappers = []any{}
+	file_bisonw_proto_msgTypes[27].OneofWrappers = []any{}
+	file_bisonw_proto_msgTypes[29].OneofWrappers = []any{}
+	file_bisonw_proto_msgTypes[43].OneofWrappers = []any{
+		(*BookUpdate_Book)(nil),
+		(*BookUpdate_Order)(nil),
+		(*BookUpdate_Remainder)(nil),
+		(*BookUpdate_MatchSummary)(nil),
+		(*BookUpdate_ResolvedEpoch)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bisonw_proto_rawDesc), len(file_bisonw_proto_rawDesc)),
+			NumEnums:      7,
+			NumMessages:   57,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bisonw_proto_goTypes,
+		DependencyIndexes: file_bisonw_proto_depIdxs,
+		EnumInfos:         file_bisonw_proto_enumTypes,
+		MessageInfos:      file_bisonw_proto_msgTypes,
+	}.Build()
+	File_bisonw_proto = out.File
+	file_bisonw_proto_goTypes = nil
+	file_bisonw_proto_depIdxs = nil
+}
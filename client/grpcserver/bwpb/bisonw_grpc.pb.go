@@ -0,0 +1,983 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bisonw.proto
+
+package bwpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Bisonw_Version_FullMethodName           = "/bisonw.Bisonw/Version"
+	Bisonw_Exchanges_FullMethodName         = "/bisonw.Bisonw/Exchanges"
+	Bisonw_GetDEXConfig_FullMethodName      = "/bisonw.Bisonw/GetDEXConfig"
+	Bisonw_Login_FullMethodName             = "/bisonw.Bisonw/Login"
+	Bisonw_Logout_FullMethodName            = "/bisonw.Bisonw/Logout"
+	Bisonw_PostBond_FullMethodName          = "/bisonw.Bisonw/PostBond"
+	Bisonw_Wallets_FullMethodName           = "/bisonw.Bisonw/Wallets"
+	Bisonw_OpenWallet_FullMethodName        = "/bisonw.Bisonw/OpenWallet"
+	Bisonw_CloseWallet_FullMethodName       = "/bisonw.Bisonw/CloseWallet"
+	Bisonw_NewDepositAddress_FullMethodName = "/bisonw.Bisonw/NewDepositAddress"
+	Bisonw_Send_FullMethodName              = "/bisonw.Bisonw/Send"
+	Bisonw_TxHistory_FullMethodName         = "/bisonw.Bisonw/TxHistory"
+	Bisonw_Trade_FullMethodName             = "/bisonw.Bisonw/Trade"
+	Bisonw_Cancel_FullMethodName            = "/bisonw.Bisonw/Cancel"
+	Bisonw_Book_FullMethodName              = "/bisonw.Bisonw/Book"
+	Bisonw_WatchBook_FullMethodName         = "/bisonw.Bisonw/WatchBook"
+	Bisonw_Orders_FullMethodName            = "/bisonw.Bisonw/Orders"
+	Bisonw_GetOrder_FullMethodName          = "/bisonw.Bisonw/GetOrder"
+	Bisonw_MMStatus_FullMethodName          = "/bisonw.Bisonw/MMStatus"
+	Bisonw_StartBot_FullMethodName          = "/bisonw.Bisonw/StartBot"
+	Bisonw_StopBot_FullMethodName           = "/bisonw.Bisonw/StopBot"
+	Bisonw_NotificationFeed_FullMethodName  = "/bisonw.Bisonw/NotificationFeed"
+)
+
+// BisonwClient is the client API for Bisonw service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Bisonw is the gRPC API for Bison Wallet. Requests are authenticated with a
+// "authorization" metadata header carrying HTTP basic credentials for the RPC
+// user, or for an API key.
+type BisonwClient interface {
+	// Version returns the bisonw and gRPC API versions.
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+	// Exchanges lists the known DEX hosts and their markets.
+	Exchanges(ctx context.Context, in *ExchangesRequest, opts ...grpc.CallOption) (*ExchangesResponse, error)
+	// GetDEXConfig fetches the configuration of a DEX host.
+	GetDEXConfig(ctx context.Context, in *GetDEXConfigRequest, opts ...grpc.CallOption) (*Exchange, error)
+	// Login logs in and connects to the known DEX hosts.
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	// Logout logs out. It fails while there are active orders.
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	// PostBond posts a fidelity bond, registering with the host if needed.
+	PostBond(ctx context.Context, in *PostBondRequest, opts ...grpc.CallOption) (*PostBondResponse, error)
+	// Wallets lists the wallets.
+	Wallets(ctx context.Context, in *WalletsRequest, opts ...grpc.CallOption) (*WalletsResponse, error)
+	// OpenWallet unlocks a wallet.
+	OpenWallet(ctx context.Context, in *OpenWalletRequest, opts ...grpc.CallOption) (*OpenWalletResponse, error)
+	// CloseWallet locks a wallet.
+	CloseWallet(ctx context.Context, in *CloseWalletRequest, opts ...grpc.CallOption) (*CloseWalletResponse, error)
+	// NewDepositAddress generates a new deposit address for a wallet.
+	NewDepositAddress(ctx context.Context, in *NewDepositAddressRequest, opts ...grpc.CallOption) (*NewDepositAddressResponse, error)
+	// Send sends funds to an address.
+	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// TxHistory lists a wallet's transactions.
+	TxHistory(ctx context.Context, in *TxHistoryRequest, opts ...grpc.CallOption) (*TxHistoryResponse, error)
+	// Trade places a limit or market order.
+	Trade(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Order, error)
+	// Cancel cancels an order.
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	// Book returns a snapshot of a market's order book.
+	Book(ctx context.Context, in *BookRequest, opts ...grpc.CallOption) (*OrderBook, error)
+	// WatchBook streams a market's order book. The first update is a snapshot
+	// of the book.
+	WatchBook(ctx context.Context, in *BookRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BookUpdate], error)
+	// Orders lists orders matching a filter, newest first.
+	Orders(ctx context.Context, in *OrdersRequest, opts ...grpc.CallOption) (*OrdersResponse, error)
+	// GetOrder returns an order by ID.
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// MMStatus returns the status of the running market making bots.
+	MMStatus(ctx context.Context, in *MMStatusRequest, opts ...grpc.CallOption) (*MMStatusResponse, error)
+	// StartBot starts a market making bot.
+	StartBot(ctx context.Context, in *StartBotRequest, opts ...grpc.CallOption) (*StartBotResponse, error)
+	// StopBot stops a market making bot.
+	StopBot(ctx context.Context, in *StopBotRequest, opts ...grpc.CallOption) (*StopBotResponse, error)
+	// NotificationFeed streams Core's notifications.
+	NotificationFeed(ctx context.Context, in *NotificationFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error)
+}
+
+type bisonwClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBisonwClient(cc grpc.ClientConnInterface) BisonwClient {
+	return &bisonwClient{cc}
+}
+
+func (c *bisonwClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Version_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Exchanges(ctx context.Context, in *ExchangesRequest, opts ...grpc.CallOption) (*ExchangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExchangesResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Exchanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) GetDEXConfig(ctx context.Context, in *GetDEXConfigRequest, opts ...grpc.CallOption) (*Exchange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Exchange)
+	err := c.cc.Invoke(ctx, Bisonw_GetDEXConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) PostBond(ctx context.Context, in *PostBondRequest, opts ...grpc.CallOption) (*PostBondResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostBondResponse)
+	err := c.cc.Invoke(ctx, Bisonw_PostBond_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Wallets(ctx context.Context, in *WalletsRequest, opts ...grpc.CallOption) (*WalletsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WalletsResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Wallets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) OpenWallet(ctx context.Context, in *OpenWalletRequest, opts ...grpc.CallOption) (*OpenWalletResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OpenWalletResponse)
+	err := c.cc.Invoke(ctx, Bisonw_OpenWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) CloseWallet(ctx context.Context, in *CloseWalletRequest, opts ...grpc.CallOption) (*CloseWalletResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseWalletResponse)
+	err := c.cc.Invoke(ctx, Bisonw_CloseWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) NewDepositAddress(ctx context.Context, in *NewDepositAddressRequest, opts ...grpc.CallOption) (*NewDepositAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NewDepositAddressResponse)
+	err := c.cc.Invoke(ctx, Bisonw_NewDepositAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Send_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) TxHistory(ctx context.Context, in *TxHistoryRequest, opts ...grpc.CallOption) (*TxHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TxHistoryResponse)
+	err := c.cc.Invoke(ctx, Bisonw_TxHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Trade(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, Bisonw_Trade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) Book(ctx context.Context, in *BookRequest, opts ...grpc.CallOption) (*OrderBook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderBook)
+	err := c.cc.Invoke(ctx, Bisonw_Book_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) WatchBook(ctx context.Context, in *BookRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BookUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Bisonw_ServiceDesc.Streams[0], Bisonw_WatchBook_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BookRequest, BookUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Bisonw_WatchBookClient = grpc.ServerStreamingClient[BookUpdate]
+
+func (c *bisonwClient) Orders(ctx context.Context, in *OrdersRequest, opts ...grpc.CallOption) (*OrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrdersResponse)
+	err := c.cc.Invoke(ctx, Bisonw_Orders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, Bisonw_GetOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) MMStatus(ctx context.Context, in *MMStatusRequest, opts ...grpc.CallOption) (*MMStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MMStatusResponse)
+	err := c.cc.Invoke(ctx, Bisonw_MMStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) StartBot(ctx context.Context, in *StartBotRequest, opts ...grpc.CallOption) (*StartBotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartBotResponse)
+	err := c.cc.Invoke(ctx, Bisonw_StartBot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) StopBot(ctx context.Context, in *StopBotRequest, opts ...grpc.CallOption) (*StopBotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopBotResponse)
+	err := c.cc.Invoke(ctx, Bisonw_StopBot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bisonwClient) NotificationFeed(ctx context.Context, in *NotificationFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Bisonw_ServiceDesc.Streams[1], Bisonw_NotificationFeed_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[NotificationFeedRequest, Notification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Bisonw_NotificationFeedClient = grpc.ServerStreamingClient[Notification]
+
+// BisonwServer is the server API for Bisonw service.
+// All implementations must embed UnimplementedBisonwServer
+// for forward compatibility.
+//
+// Bisonw is the gRPC API for Bison Wallet. Requests are authenticated with a
+// "authorization" metadata header carrying HTTP basic credentials for the RPC
+// user, or for an API key.
+type BisonwServer interface {
+	// Version returns the bisonw and gRPC API versions.
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+	// Exchanges lists the known DEX hosts and their markets.
+	Exchanges(context.Context, *ExchangesRequest) (*ExchangesResponse, error)
+	// GetDEXConfig fetches the configuration of a DEX host.
+	GetDEXConfig(context.Context, *GetDEXConfigRequest) (*Exchange, error)
+	// Login logs in and connects to the known DEX hosts.
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// Logout logs out. It fails while there are active orders.
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	// PostBond posts a fidelity bond, registering with the host if needed.
+	PostBond(context.Context, *PostBondRequest) (*PostBondResponse, error)
+	// Wallets lists the wallets.
+	Wallets(context.Context, *WalletsRequest) (*WalletsResponse, error)
+	// OpenWallet unlocks a wallet.
+	OpenWallet(context.Context, *OpenWalletRequest) (*OpenWalletResponse, error)
+	// CloseWallet locks a wallet.
+	CloseWallet(context.Context, *CloseWalletRequest) (*CloseWalletResponse, error)
+	// NewDepositAddress generates a new deposit address for a wallet.
+	NewDepositAddress(context.Context, *NewDepositAddressRequest) (*NewDepositAddressResponse, error)
+	// Send sends funds to an address.
+	Send(context.Context, *SendRequest) (*SendResponse, error)
+	// TxHistory lists a wallet's transactions.
+	TxHistory(context.Context, *TxHistoryRequest) (*TxHistoryResponse, error)
+	// Trade places a limit or market order.
+	Trade(context.Context, *TradeRequest) (*Order, error)
+	// Cancel cancels an order.
+	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
+	// Book returns a snapshot of a market's order book.
+	Book(context.Context, *BookRequest) (*OrderBook, error)
+	// WatchBook streams a market's order book. The first update is a snapshot
+	// of the book.
+	WatchBook(*BookRequest, grpc.ServerStreamingServer[BookUpdate]) error
+	// Orders lists orders matching a filter, newest first.
+	Orders(context.Context, *OrdersRequest) (*OrdersResponse, error)
+	// GetOrder returns an order by ID.
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	// MMStatus returns the status of the running market making bots.
+	MMStatus(context.Context, *MMStatusRequest) (*MMStatusResponse, error)
+	// StartBot starts a market making bot.
+	StartBot(context.Context, *StartBotRequest) (*StartBotResponse, error)
+	// StopBot stops a market making bot.
+	StopBot(context.Context, *StopBotRequest) (*StopBotResponse, error)
+	// NotificationFeed streams Core's notifications.
+	NotificationFeed(*NotificationFeedRequest, grpc.ServerStreamingServer[Notification]) error
+	mustEmbedUnimplementedBisonwServer()
+}
+
+// UnimplementedBisonwServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBisonwServer struct{}
+
+func (UnimplementedBisonwServer) Version(context.Context, *VersionRequest) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (UnimplementedBisonwServer) Exchanges(context.Context, *ExchangesRequest) (*ExchangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exchanges not implemented")
+}
+func (UnimplementedBisonwServer) GetDEXConfig(context.Context, *GetDEXConfigRequest) (*Exchange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDEXConfig not implemented")
+}
+func (UnimplementedBisonwServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedBisonwServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedBisonwServer) PostBond(context.Context, *PostBondRequest) (*PostBondResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostBond not implemented")
+}
+func (UnimplementedBisonwServer) Wallets(context.Context, *WalletsRequest) (*WalletsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Wallets not implemented")
+}
+func (UnimplementedBisonwServer) OpenWallet(context.Context, *OpenWalletRequest) (*OpenWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenWallet not implemented")
+}
+func (UnimplementedBisonwServer) CloseWallet(context.Context, *CloseWalletRequest) (*CloseWalletResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseWallet not implemented")
+}
+func (UnimplementedBisonwServer) NewDepositAddress(context.Context, *NewDepositAddressRequest) (*NewDepositAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewDepositAddress not implemented")
+}
+func (UnimplementedBisonwServer) Send(context.Context, *SendRequest) (*SendResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Send not implemented")
+}
+func (UnimplementedBisonwServer) TxHistory(context.Context, *TxHistoryRequest) (*TxHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxHistory not implemented")
+}
+func (UnimplementedBisonwServer) Trade(context.Context, *TradeRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Trade not implemented")
+}
+func (UnimplementedBisonwServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedBisonwServer) Book(context.Context, *BookRequest) (*OrderBook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Book not implemented")
+}
+func (UnimplementedBisonwServer) WatchBook(*BookRequest, grpc.ServerStreamingServer[BookUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBook not implemented")
+}
+func (UnimplementedBisonwServer) Orders(context.Context, *OrdersRequest) (*OrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Orders not implemented")
+}
+func (UnimplementedBisonwServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedBisonwServer) MMStatus(context.Context, *MMStatusRequest) (*MMStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MMStatus not implemented")
+}
+func (UnimplementedBisonwServer) StartBot(context.Context, *StartBotRequest) (*StartBotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBot not implemented")
+}
+func (UnimplementedBisonwServer) StopBot(context.Context, *StopBotRequest) (*StopBotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopBot not implemented")
+}
+func (UnimplementedBisonwServer) NotificationFeed(*NotificationFeedRequest, grpc.ServerStreamingServer[Notification]) error {
+	return status.Errorf(codes.Unimplemented, "method NotificationFeed not implemented")
+}
+func (UnimplementedBisonwServer) mustEmbedUnimplementedBisonwServer() {}
+func (UnimplementedBisonwServer) testEmbeddedByValue()                {}
+
+// UnsafeBisonwServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BisonwServer will
+// result in compilation errors.
+type UnsafeBisonwServer interface {
+	mustEmbedUnimplementedBisonwServer()
+}
+
+func RegisterBisonwServer(s grpc.ServiceRegistrar, srv BisonwServer) {
+	// If the following call pancis, it indicates UnimplementedBisonwServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Bisonw_ServiceDesc, srv)
+}
+
+func _Bisonw_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Version_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Exchanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Exchanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Exchanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Exchanges(ctx, req.(*ExchangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_GetDEXConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDEXConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).GetDEXConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_GetDEXConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).GetDEXConfig(ctx, req.(*GetDEXConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_PostBond_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostBondRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).PostBond(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_PostBond_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).PostBond(ctx, req.(*PostBondRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Wallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Wallets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Wallets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Wallets(ctx, req.(*WalletsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_OpenWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).OpenWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_OpenWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).OpenWallet(ctx, req.(*OpenWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_CloseWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).CloseWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_CloseWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).CloseWallet(ctx, req.(*CloseWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_NewDepositAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewDepositAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).NewDepositAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_NewDepositAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).NewDepositAddress(ctx, req.(*NewDepositAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Send_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Send(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_TxHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).TxHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_TxHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).TxHistory(ctx, req.(*TxHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Trade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Trade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Trade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Trade(ctx, req.(*TradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_Book_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Book(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Book_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Book(ctx, req.(*BookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_WatchBook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BisonwServer).WatchBook(m, &grpc.GenericServerStream[BookRequest, BookUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Bisonw_WatchBookServer = grpc.ServerStreamingServer[BookUpdate]
+
+func _Bisonw_Orders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).Orders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_Orders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).Orders(ctx, req.(*OrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_MMStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MMStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).MMStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_MMStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).MMStatus(ctx, req.(*MMStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_StartBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartBotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).StartBot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_StartBot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).StartBot(ctx, req.(*StartBotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_StopBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopBotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BisonwServer).StopBot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Bisonw_StopBot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BisonwServer).StopBot(ctx, req.(*StopBotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bisonw_NotificationFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotificationFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BisonwServer).NotificationFeed(m, &grpc.GenericServerStream[NotificationFeedRequest, Notification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Bisonw_NotificationFeedServer = grpc.ServerStreamingServer[Notification]
+
+// Bisonw_ServiceDesc is the grpc.ServiceDesc for Bisonw service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Bisonw_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bisonw.Bisonw",
+	HandlerType: (*BisonwServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Version",
+			Handler:    _Bisonw_Version_Handler,
+		},
+		{
+			MethodName: "Exchanges",
+			Handler:    _Bisonw_Exchanges_Handler,
+		},
+		{
+			MethodName: "GetDEXConfig",
+			Handler:    _Bisonw_GetDEXConfig_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _Bisonw_Login_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _Bisonw_Logout_Handler,
+		},
+		{
+			MethodName: "PostBond",
+			Handler:    _Bisonw_PostBond_Handler,
+		},
+		{
+			MethodName: "Wallets",
+			Handler:    _Bisonw_Wallets_Handler,
+		},
+		{
+			MethodName: "OpenWallet",
+			Handler:    _Bisonw_OpenWallet_Handler,
+		},
+		{
+			MethodName: "CloseWallet",
+			Handler:    _Bisonw_CloseWallet_Handler,
+		},
+		{
+			MethodName: "NewDepositAddress",
+			Handler:    _Bisonw_NewDepositAddress_Handler,
+		},
+		{
+			MethodName: "Send",
+			Handler:    _Bisonw_Send_Handler,
+		},
+		{
+			MethodName: "TxHistory",
+			Handler:    _Bisonw_TxHistory_Handler,
+		},
+		{
+			MethodName: "Trade",
+			Handler:    _Bisonw_Trade_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _Bisonw_Cancel_Handler,
+		},
+		{
+			MethodName: "Book",
+			Handler:    _Bisonw_Book_Handler,
+		},
+		{
+			MethodName: "Orders",
+			Handler:    _Bisonw_Orders_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _Bisonw_GetOrder_Handler,
+		},
+		{
+			MethodName: "MMStatus",
+			Handler:    _Bisonw_MMStatus_Handler,
+		},
+		{
+			MethodName: "StartBot",
+			Handler:    _Bisonw_StartBot_Handler,
+		},
+		{
+			MethodName: "StopBot",
+			Handler:    _Bisonw_StopBot_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBook",
+			Handler:       _Bisonw_WatchBook_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "NotificationFeed",
+			Handler:       _Bisonw_NotificationFeed_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bisonw.proto",
+}
@@ -0,0 +1,9 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package bwpb contains the protocol buffer types and the generated gRPC
+// client and server stubs for the bisonw gRPC API defined in bisonw.proto.
+// Clients in other languages can generate their own stubs from bisonw.proto.
+package bwpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative bisonw.proto
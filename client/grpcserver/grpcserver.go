@@ -0,0 +1,352 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package grpcserver provides a gRPC API to communicate with the client core.
+// The service is defined in bwpb/bisonw.proto.
+package grpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/apikey"
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/grpcserver/bwpb"
+	"decred.org/dcrdex/client/mm"
+	"decred.org/dcrdex/client/orderbook"
+	"decred.org/dcrdex/dex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// apiVersion is the gRPC API's semantic version. Move major up one for
+	// breaking changes to bisonw.proto. Move minor for backwards compatible
+	// features. Move patch for bug fixes.
+	apiVersion = "0.1.0"
+
+	// authHeader is the metadata key for the HTTP basic credentials.
+	authHeader = "authorization"
+)
+
+var (
+	_   clientCore    = (*core.Core)(nil)
+	_   marketMaker   = (*mm.MarketMaker)(nil)
+	_   apiKeyManager = (*apikey.Store)(nil)
+	log dex.Logger
+)
+
+// clientCore is satisfied by core.Core.
+type clientCore interface {
+	Exchanges() map[string]*core.Exchange
+	GetDEXConfig(dexAddr string, certI any) (*core.Exchange, error)
+	Login(appPass []byte) error
+	Logout() error
+	PostBond(form *core.PostBondForm) (*core.PostBondResult, error)
+	Wallets() []*core.WalletState
+	OpenWallet(assetID uint32, appPass []byte) error
+	CloseWallet(assetID uint32) error
+	NewDepositAddress(assetID uint32) (string, error)
+	Send(appPass []byte, assetID uint32, value uint64, addr string, subtract bool) (asset.Coin, error)
+	TxHistory(assetID uint32, n int, refID *string, past bool) ([]*asset.WalletTransaction, error)
+	Trade(appPass []byte, form *core.TradeForm) (*core.Order, error)
+	Cancel(oid dex.Bytes) error
+	Book(host string, base, quote uint32) (*core.OrderBook, error)
+	SyncBook(host string, base, quote uint32) (*orderbook.OrderBook, core.BookFeed, error)
+	Orders(filter *core.OrderFilter) ([]*core.Order, error)
+	Order(oid dex.Bytes) (*core.Order, error)
+	NotificationFeed() *core.NoteFeed
+}
+
+// marketMaker runs the market making bots. It is satisfied by
+// *mm.MarketMaker.
+type marketMaker interface {
+	StartBot(startCfg *mm.StartConfig, alternateConfigPath *string, appPW []byte, overrideLotSizeChange bool) error
+	StopBot(mkt *mm.MarketWithHost) error
+	RunningBotsStatus() *mm.Status
+}
+
+// apiKeyManager authenticates API keys and records the audit log. It is
+// satisfied by *apikey.Store.
+type apiKeyManager interface {
+	Authenticate(name, secret string) (*apikey.Key, bool)
+	Audit(entry *apikey.AuditEntry) error
+}
+
+// Config is the configuration for the gRPC server.
+type Config struct {
+	Core clientCore
+	// MarketMaker is optional. If not set, the market making methods fail.
+	MarketMaker marketMaker
+	// Addr is the listen address. User and Pass are the credentials
+	// required of clients. Cert and Key are the TLS certificate pair, which
+	// is generated if it does not exist.
+	Addr, User, Pass, Cert, Key string
+	CertHosts                   []string
+	// Version is the bisonw version.
+	Version string
+	// APIKeys is optional. If not set, only the RPC user and password are
+	// accepted.
+	APIKeys apiKeyManager
+}
+
+// Server is a gRPC server for Bison Wallet.
+type Server struct {
+	bwpb.UnimplementedBisonwServer
+	core    clientCore
+	mm      marketMaker
+	addr    string
+	srv     *grpc.Server
+	authSHA [32]byte
+	version string
+	apiKeys apiKeyManager
+	wg      sync.WaitGroup
+}
+
+// SetLogger sets the logger for the grpcserver package.
+func SetLogger(logger dex.Logger) {
+	log = logger
+}
+
+// New is the constructor for a Server.
+func New(cfg *Config) (*Server, error) {
+	if cfg.Pass == "" {
+		return nil, fmt.Errorf("missing RPC password")
+	}
+
+	// Find or create the key pair.
+	keyExists := dex.FileExists(cfg.Key)
+	certExists := dex.FileExists(cfg.Cert)
+	if certExists == !keyExists {
+		return nil, fmt.Errorf("missing cert pair file")
+	}
+	if !keyExists && !certExists {
+		err := apikey.GenCertPair(cfg.Cert, cfg.Key, cfg.CertHosts, log)
+		if err != nil {
+			return nil, err
+		}
+	}
+	keypair, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{keypair},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	s := &Server{
+		core:    cfg.Core,
+		mm:      cfg.MarketMaker,
+		addr:    cfg.Addr,
+		version: cfg.Version,
+		apiKeys: cfg.APIKeys,
+	}
+
+	// Create authSHA to verify requests against.
+	login := cfg.User + ":" + cfg.Pass
+	auth := "Basic " +
+		base64.StdEncoding.EncodeToString([]byte(login))
+	s.authSHA = sha256.Sum256([]byte(auth))
+
+	s.srv = grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.UnaryInterceptor(s.unaryAuth),
+		grpc.StreamInterceptor(s.streamAuth),
+	)
+	bwpb.RegisterBisonwServer(s.srv, s)
+
+	return s, nil
+}
+
+// Addr is the listening address. It is only valid after Connect.
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// Connect starts the gRPC server. Satisfies the dex.Connector interface.
+func (s *Server) Connect(ctx context.Context) (*sync.WaitGroup, error) {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return nil, fmt.Errorf("can't listen on %s. grpc server quitting: %w", s.addr, err)
+	}
+	// Update the listening address in case a :0 was provided.
+	s.addr = listener.Addr().String()
+
+	// Stop the server on context cancellation. Stop closes the listener and
+	// cancels the streams.
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		<-ctx.Done()
+		s.srv.Stop()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.srv.Serve(listener); err != nil {
+			log.Warnf("unexpected (grpc.Server).Serve error: %v", err)
+		}
+		log.Infof("gRPC server off")
+	}()
+	log.Infof("gRPC server listening on %s", s.addr)
+	return &s.wg, nil
+}
+
+// authenticate checks the credentials in the request metadata. The returned
+// key is nil for the RPC user.
+func (s *Server) authenticate(ctx context.Context, addr, method string, req any) (*apikey.Key, error) {
+	fail := func() error {
+		log.Warnf("authentication failure from ip: %s", addr)
+		return status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := md.Get(authHeader)
+	if len(auth) == 0 {
+		return nil, fail()
+	}
+	authSHA := sha256.Sum256([]byte(auth[0]))
+	if subtle.ConstantTimeCompare(s.authSHA[:], authSHA[:]) == 1 {
+		log.Debugf("authenticated user with ip: %s", addr)
+		return nil, nil
+	}
+	if s.apiKeys == nil {
+		return nil, fail()
+	}
+	// The user is the API key name and the password is its secret.
+	name, secret, ok := apikey.ParseBasicAuth(auth[0])
+	if !ok {
+		return nil, fail()
+	}
+	k, ok := s.apiKeys.Authenticate(name, secret)
+	if !ok {
+		return nil, fail()
+	}
+	if !k.AllowsIP(apikey.ParseIP(addr)) {
+		return nil, s.deny(k, addr, method, "ip address not allowed")
+	}
+	if err := k.Authorize(methodPermissions, method, func() ([]string, error) {
+		return withdrawAddresses(method, req)
+	}); err != nil {
+		return nil, s.deny(k, addr, method, err.Error())
+	}
+	log.Debugf("authenticated api key %q with ip: %s", k.Name, addr)
+	return k, nil
+}
+
+// peerAddr is the address of the connection.
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// unaryAuth authenticates unary calls. Calls made with an API key are audited
+// when they complete.
+func (s *Server) unaryAuth(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	addr := peerAddr(ctx)
+	k, err := s.authenticate(ctx, addr, info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	if k != nil {
+		var reason string
+		if err != nil {
+			reason = status.Convert(err).Message()
+		}
+		s.audit(k, addr, info.FullMethod, true, reason)
+	}
+	return resp, err
+}
+
+// streamAuth authenticates streaming calls. Streams opened with an API key are
+// audited when they open.
+func (s *Server) streamAuth(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	addr := peerAddr(ss.Context())
+	k, err := s.authenticate(ss.Context(), addr, info.FullMethod, nil)
+	if err != nil {
+		return err
+	}
+	if k != nil {
+		s.audit(k, addr, info.FullMethod, true, "")
+	}
+	return handler(srv, ss)
+}
+
+// methodPermissions is the permission an API key needs for each method.
+var methodPermissions = map[string]apikey.Permission{
+	bwpb.Bisonw_Version_FullMethodName:           apikey.PermRead,
+	bwpb.Bisonw_Exchanges_FullMethodName:         apikey.PermRead,
+	bwpb.Bisonw_GetDEXConfig_FullMethodName:      apikey.PermRead,
+	bwpb.Bisonw_Wallets_FullMethodName:           apikey.PermRead,
+	bwpb.Bisonw_TxHistory_FullMethodName:         apikey.PermRead,
+	bwpb.Bisonw_Book_FullMethodName:              apikey.PermRead,
+	bwpb.Bisonw_WatchBook_FullMethodName:         apikey.PermRead,
+	bwpb.Bisonw_Orders_FullMethodName:            apikey.PermRead,
+	bwpb.Bisonw_GetOrder_FullMethodName:          apikey.PermRead,
+	bwpb.Bisonw_MMStatus_FullMethodName:          apikey.PermRead,
+	bwpb.Bisonw_NotificationFeed_FullMethodName:  apikey.PermRead,
+	bwpb.Bisonw_NewDepositAddress_FullMethodName: apikey.PermRead,
+	bwpb.Bisonw_Trade_FullMethodName:             apikey.PermTrade,
+	bwpb.Bisonw_Cancel_FullMethodName:            apikey.PermTrade,
+	bwpb.Bisonw_StartBot_FullMethodName:          apikey.PermBots,
+	bwpb.Bisonw_StopBot_FullMethodName:           apikey.PermBots,
+	bwpb.Bisonw_Send_FullMethodName:              apikey.PermFunds,
+	bwpb.Bisonw_PostBond_FullMethodName:          apikey.PermFunds,
+}
+
+// withdrawAddresses returns the addresses that a funds request pays. Every
+// method with apikey.PermFunds in methodPermissions must be handled, so that a
+// new funds method cannot bypass a withdrawal address allowlist.
+func withdrawAddresses(method string, req any) ([]string, error) {
+	switch method {
+	case bwpb.Bisonw_Send_FullMethodName:
+		send, ok := req.(*bwpb.SendRequest)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s request type %T", method, req)
+		}
+		return []string{send.Address}, nil
+	case bwpb.Bisonw_PostBond_FullMethodName:
+		// Bonds are paid to a DEX, not an external address.
+		return nil, nil
+	}
+	return nil, fmt.Errorf("cannot check the withdrawal addresses of %s", method)
+}
+
+// deny audits and rejects a request made with an API key.
+func (s *Server) deny(k *apikey.Key, addr, method, reason string) error {
+	log.Warnf("api key %q denied %s from ip %s: %s", k.Name, method, addr, reason)
+	s.audit(k, addr, method, false, reason)
+	return status.Error(codes.PermissionDenied, reason)
+}
+
+// audit records a call made with an API key in the audit log.
+func (s *Server) audit(k *apikey.Key, addr, method string, allowed bool, reason string) {
+	entry := &apikey.AuditEntry{
+		Stamp:   time.Now(),
+		Key:     k.Name,
+		IP:      addr,
+		Route:   method,
+		Allowed: allowed,
+		Error:   reason,
+	}
+	if err := s.apiKeys.Audit(entry); err != nil {
+		log.Errorf("error writing audit log entry: %v", err)
+	}
+}
@@ -0,0 +1,495 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/apikey"
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/client/grpcserver/bwpb"
+	"decred.org/dcrdex/client/orderbook"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var tCtx context.Context
+
+type tCoin struct{}
+
+func (tCoin) ID() dex.Bytes  { return dex.Bytes{0x01} }
+func (tCoin) String() string { return "abcd:0" }
+func (tCoin) Value() uint64  { return 1e8 }
+func (tCoin) TxID() string   { return "abcd" }
+
+type tBookFeed struct {
+	c chan *core.BookUpdate
+}
+
+func (f *tBookFeed) Next() <-chan *core.BookUpdate { return f.c }
+func (f *tBookFeed) Close()                        {}
+func (f *tBookFeed) Candles(dur string) error      { return nil }
+
+type TCore struct {
+	loginErr  error
+	loggedIn  []byte
+	tradeForm *core.TradeForm
+	tradeErr  error
+	sendAddr  string
+	feed      *tBookFeed
+	notes     chan core.Notification
+}
+
+func (c *TCore) Exchanges() map[string]*core.Exchange {
+	return map[string]*core.Exchange{
+		"dex.example.com": {
+			Host: "dex.example.com",
+			Markets: map[string]*core.Market{
+				"dcr_btc": {Name: "dcr_btc", BaseID: 42, QuoteID: 0, LotSize: 1e8},
+			},
+			Assets: map[uint32]*dex.Asset{42: {ID: 42, Symbol: "dcr"}},
+		},
+	}
+}
+func (c *TCore) GetDEXConfig(dexAddr string, certI any) (*core.Exchange, error) {
+	return &core.Exchange{Host: dexAddr}, nil
+}
+func (c *TCore) Login(appPass []byte) error {
+	c.loggedIn = append([]byte(nil), appPass...)
+	return c.loginErr
+}
+func (c *TCore) Logout() error { return nil }
+func (c *TCore) PostBond(form *core.PostBondForm) (*core.PostBondResult, error) {
+	return &core.PostBondResult{BondID: "bond", ReqConfirms: 2}, nil
+}
+func (c *TCore) Wallets() []*core.WalletState {
+	return []*core.WalletState{{
+		Symbol:  "dcr",
+		AssetID: 42,
+		Open:    true,
+		Balance: &core.WalletBalance{
+			Balance:     &db.Balance{Balance: asset.Balance{Available: 5e8}},
+			OrderLocked: 1e8,
+		},
+	}}
+}
+func (c *TCore) OpenWallet(assetID uint32, appPass []byte) error { return nil }
+func (c *TCore) CloseWallet(assetID uint32) error                { return nil }
+func (c *TCore) NewDepositAddress(assetID uint32) (string, error) {
+	return "Dsaddr", nil
+}
+func (c *TCore) Send(appPass []byte, assetID uint32, value uint64, addr string, subtract bool) (asset.Coin, error) {
+	c.sendAddr = addr
+	return tCoin{}, nil
+}
+func (c *TCore) TxHistory(assetID uint32, n int, refID *string, past bool) ([]*asset.WalletTransaction, error) {
+	return nil, nil
+}
+func (c *TCore) Trade(appPass []byte, form *core.TradeForm) (*core.Order, error) {
+	c.tradeForm = form
+	if c.tradeErr != nil {
+		return nil, c.tradeErr
+	}
+	return &core.Order{
+		Host:   form.Host,
+		BaseID: form.Base,
+		Type:   order.LimitOrderType,
+		Status: order.OrderStatusEpoch,
+		Qty:    form.Qty,
+		Rate:   form.Rate,
+		Sell:   form.Sell,
+		Matches: []*core.Match{{
+			Status: order.MakerSwapCast,
+			Side:   order.Taker,
+			Swap:   &core.Coin{StringID: "swap:0"},
+		}},
+	}, nil
+}
+func (c *TCore) Cancel(oid dex.Bytes) error { return nil }
+func (c *TCore) Book(host string, base, quote uint32) (*core.OrderBook, error) {
+	return &core.OrderBook{}, nil
+}
+func (c *TCore) SyncBook(host string, base, quote uint32) (*orderbook.OrderBook, core.BookFeed, error) {
+	return nil, c.feed, nil
+}
+func (c *TCore) Orders(filter *core.OrderFilter) ([]*core.Order, error) {
+	return []*core.Order{{Host: "dex.example.com", Status: order.OrderStatus(filter.Statuses[0])}}, nil
+}
+func (c *TCore) Order(oid dex.Bytes) (*core.Order, error) {
+	return &core.Order{ID: oid}, nil
+}
+func (c *TCore) NotificationFeed() *core.NoteFeed {
+	return &core.NoteFeed{C: c.notes}
+}
+
+func newTServer(t *testing.T, tCore *TCore, apiKeys apiKeyManager) (*Server, string) {
+	t.Helper()
+	tempDir := t.TempDir()
+	cert, key := filepath.Join(tempDir, "cert.cert"), filepath.Join(tempDir, "key.key")
+	s, err := New(&Config{
+		Core:    tCore,
+		Addr:    "127.0.0.1:0",
+		User:    "user",
+		Pass:    "pass",
+		Cert:    cert,
+		Key:     key,
+		Version: "1.2.3",
+		APIKeys: apiKeys,
+	})
+	if err != nil {
+		t.Fatalf("error creating server: %v", err)
+	}
+	ctx, cancel := context.WithCancel(tCtx)
+	cm := dex.NewConnectionMaster(s)
+	if err := cm.ConnectOnce(ctx); err != nil {
+		cancel()
+		t.Fatalf("error starting server: %v", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		cm.Wait()
+	})
+	return s, cert
+}
+
+func newTClient(t *testing.T, s *Server, cert string) bwpb.BisonwClient {
+	t.Helper()
+	creds, err := credentials.NewClientTLSFromFile(cert, "localhost")
+	if err != nil {
+		t.Fatalf("error loading cert: %v", err)
+	}
+	conn, err := grpc.NewClient(s.Addr(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return bwpb.NewBisonwClient(conn)
+}
+
+func withAuth(ctx context.Context, user, pass string) context.Context {
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+	return metadata.AppendToOutgoingContext(ctx, authHeader, auth)
+}
+
+func TestMain(m *testing.M) {
+	SetLogger(dex.StdOutLogger("TEST", dex.LevelTrace))
+	var shutdown func()
+	tCtx, shutdown = context.WithCancel(context.Background())
+	doIt := func() int {
+		defer shutdown()
+		return m.Run()
+	}
+	os.Exit(doIt())
+}
+
+func TestNewMissingCertPair(t *testing.T) {
+	tempDir := t.TempDir()
+	cert, key := filepath.Join(tempDir, "cert.cert"), filepath.Join(tempDir, "key.key")
+	if err := os.WriteFile(cert, []byte("cert"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(&Config{Pass: "pass", Cert: cert, Key: key}); err == nil {
+		t.Fatal("no error for missing key file")
+	}
+	if _, err := New(&Config{Cert: cert, Key: key}); err == nil {
+		t.Fatal("no error for missing password")
+	}
+}
+
+func TestUnary(t *testing.T) {
+	tCore := &TCore{}
+	s, cert := newTServer(t, tCore, nil)
+	client := newTClient(t, s, cert)
+	ctx := withAuth(tCtx, "user", "pass")
+
+	// Bad credentials.
+	_, err := client.Version(withAuth(tCtx, "user", "wrong"), &bwpb.VersionRequest{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for wrong password, got %v", err)
+	}
+	if _, err = client.Version(tCtx, &bwpb.VersionRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without credentials, got %v", err)
+	}
+
+	ver, err := client.Version(ctx, &bwpb.VersionRequest{})
+	if err != nil {
+		t.Fatalf("Version error: %v", err)
+	}
+	if ver.BisonwVersion != "1.2.3" || ver.ApiVersion != apiVersion {
+		t.Fatalf("wrong version %s, api version %s", ver.BisonwVersion, ver.ApiVersion)
+	}
+
+	if _, err := client.Login(ctx, &bwpb.LoginRequest{AppPass: []byte("abc")}); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+	if string(tCore.loggedIn) != "abc" {
+		t.Fatalf("wrong password %q", tCore.loggedIn)
+	}
+	tCore.loginErr = errors.New("wrong password")
+	if _, err := client.Login(ctx, &bwpb.LoginRequest{AppPass: []byte("abc")}); status.Code(err) != codes.Unknown {
+		t.Fatalf("expected Unknown for core error, got %v", err)
+	}
+
+	xcs, err := client.Exchanges(ctx, &bwpb.ExchangesRequest{})
+	if err != nil {
+		t.Fatalf("Exchanges error: %v", err)
+	}
+	if len(xcs.Exchanges) != 1 || len(xcs.Exchanges[0].Markets) != 1 || xcs.Exchanges[0].Markets[0].LotSize != 1e8 {
+		t.Fatalf("wrong exchanges %v", xcs.Exchanges)
+	}
+
+	wallets, err := client.Wallets(ctx, &bwpb.WalletsRequest{})
+	if err != nil {
+		t.Fatalf("Wallets error: %v", err)
+	}
+	if bal := wallets.Wallets[0].Balance; bal.Available != 5e8 || bal.OrderLocked != 1e8 {
+		t.Fatalf("wrong balance %v", bal)
+	}
+
+	ord, err := client.Trade(ctx, &bwpb.TradeRequest{
+		Host:    "dex.example.com",
+		IsLimit: true,
+		Base:    42,
+		Qty:     1e8,
+		Rate:    1e6,
+	})
+	if err != nil {
+		t.Fatalf("Trade error: %v", err)
+	}
+	if !tCore.tradeForm.IsLimit || tCore.tradeForm.Rate != 1e6 {
+		t.Fatalf("wrong trade form %+v", tCore.tradeForm)
+	}
+	if ord.Type != bwpb.OrderType_LIMIT || ord.Status != bwpb.OrderStatus_EPOCH {
+		t.Fatalf("wrong order type %s or status %s", ord.Type, ord.Status)
+	}
+	if m := ord.Matches[0]; m.Status != bwpb.MatchStatus_MAKER_SWAP_CAST || m.Side != bwpb.MatchSide_TAKER || m.Swap != "swap:0" {
+		t.Fatalf("wrong match %v", m)
+	}
+	if _, err := client.Trade(ctx, &bwpb.TradeRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for missing host, got %v", err)
+	}
+
+	ords, err := client.Orders(ctx, &bwpb.OrdersRequest{Statuses: []bwpb.OrderStatus{bwpb.OrderStatus_BOOKED}})
+	if err != nil {
+		t.Fatalf("Orders error: %v", err)
+	}
+	if ords.Orders[0].Status != bwpb.OrderStatus_BOOKED {
+		t.Fatalf("wrong order status %s", ords.Orders[0].Status)
+	}
+
+	if _, err := client.Cancel(ctx, &bwpb.CancelRequest{OrderId: []byte{1}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for short order id, got %v", err)
+	}
+
+	// No MarketMaker.
+	if _, err := client.MMStatus(ctx, &bwpb.MMStatusRequest{}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected Unimplemented without a market maker, got %v", err)
+	}
+}
+
+func TestWatchBook(t *testing.T) {
+	tCore := &TCore{feed: &tBookFeed{c: make(chan *core.BookUpdate, 4)}}
+	s, cert := newTServer(t, tCore, nil)
+	client := newTClient(t, s, cert)
+	ctx, cancel := context.WithCancel(withAuth(tCtx, "user", "pass"))
+	defer cancel()
+
+	tCore.feed.c <- &core.BookUpdate{
+		Action:   core.FreshBookAction,
+		MarketID: "dcr_btc",
+		Payload: &core.MarketOrderBook{Book: &core.OrderBook{
+			Sells: []*core.MiniOrder{{Token: "aa", QtyAtomic: 1e8, MsgRate: 1e6, Sell: true}},
+		}},
+	}
+	// Candles are not forwarded.
+	tCore.feed.c <- &core.BookUpdate{Action: core.CandleUpdateAction, Payload: core.CandleUpdate{}}
+	tCore.feed.c <- &core.BookUpdate{
+		Action:  core.UpdateRemainingAction,
+		Payload: &core.RemainderUpdate{Token: "aa", QtyAtomic: 5e7},
+	}
+	tCore.feed.c <- &core.BookUpdate{
+		Action:  core.EpochResolved,
+		Payload: &core.ResolvedEpoch{Current: 3, Resolved: 2},
+	}
+
+	stream, err := client.WatchBook(ctx, &bwpb.BookRequest{Host: "dex.example.com", Base: 42})
+	if err != nil {
+		t.Fatalf("WatchBook error: %v", err)
+	}
+	u, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv error: %v", err)
+	}
+	if book := u.GetBook(); book == nil || len(book.Sells) != 1 || book.Sells[0].Qty != 1e8 {
+		t.Fatalf("wrong book %v", u)
+	}
+	if u, err = stream.Recv(); err != nil {
+		t.Fatalf("Recv error: %v", err)
+	}
+	if rem := u.GetRemainder(); rem == nil || rem.Qty != 5e7 {
+		t.Fatalf("wrong remainder update %v", u)
+	}
+	if u, err = stream.Recv(); err != nil {
+		t.Fatalf("Recv error: %v", err)
+	}
+	if ep := u.GetResolvedEpoch(); ep == nil || ep.Resolved != 2 {
+		t.Fatalf("wrong resolved epoch %v", u)
+	}
+
+	close(tCore.feed.c)
+	if _, err = stream.Recv(); err != io.EOF {
+		t.Fatalf("expected EOF after feed closed, got %v", err)
+	}
+}
+
+func TestNotificationFeed(t *testing.T) {
+	tCore := &TCore{notes: make(chan core.Notification, 1)}
+	s, cert := newTServer(t, tCore, nil)
+	client := newTClient(t, s, cert)
+	ctx, cancel := context.WithCancel(withAuth(tCtx, "user", "pass"))
+	defer cancel()
+
+	stream, err := client.NotificationFeed(ctx, &bwpb.NotificationFeedRequest{})
+	if err != nil {
+		t.Fatalf("NotificationFeed error: %v", err)
+	}
+	note := &core.SecurityNote{
+		Notification: db.NewNotification(core.NoteTypeSecurity, core.TopicSeedNeedsSaving, "subject", "details", db.WarningLevel),
+	}
+	tCore.notes <- note
+	n, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv error: %v", err)
+	}
+	if n.Type != core.NoteTypeSecurity || n.Topic != string(core.TopicSeedNeedsSaving) || n.Severity != bwpb.Severity_WARNING {
+		t.Fatalf("wrong notification %v", n)
+	}
+	if !bytes.Equal(n.Id, note.ID()) || !bytes.Contains(n.Payload, []byte(`"subject":"subject"`)) {
+		t.Fatalf("wrong notification id or payload %v", n)
+	}
+
+	// The stream ends when the client cancels it.
+	cancel()
+	timeout := time.After(time.Second)
+	for {
+		if _, err := stream.Recv(); status.Code(err) == codes.Canceled {
+			break
+		}
+		select {
+		case <-timeout:
+			t.Fatal("stream not canceled")
+		default:
+		}
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	keys, err := apikey.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, readSecret, _ := keys.Create(&apikey.KeyForm{Name: "reader", Permissions: []apikey.Permission{apikey.PermRead}})
+	_, fundsSecret, _ := keys.Create(&apikey.KeyForm{
+		Name:          "funds",
+		Permissions:   []apikey.Permission{apikey.PermFunds},
+		WithdrawAddrs: []string{"Dsgood"},
+	})
+	_, remoteSecret, _ := keys.Create(&apikey.KeyForm{
+		Name:        "remote",
+		Permissions: []apikey.Permission{apikey.PermRead},
+		AllowedIPs:  []string{"10.0.0.0/8"},
+	})
+
+	tCore := &TCore{}
+	s, cert := newTServer(t, tCore, keys)
+	client := newTClient(t, s, cert)
+
+	// The RPC user can still call anything.
+	if _, err := client.Login(withAuth(tCtx, "user", "pass"), &bwpb.LoginRequest{}); err != nil {
+		t.Fatalf("Login error for the rpc user: %v", err)
+	}
+
+	readCtx := withAuth(tCtx, "reader", readSecret)
+	if _, err := client.Wallets(readCtx, &bwpb.WalletsRequest{}); err != nil {
+		t.Fatalf("Wallets error with read key: %v", err)
+	}
+	if _, err := client.Trade(readCtx, &bwpb.TradeRequest{Host: "dex.example.com"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for trade with read key, got %v", err)
+	}
+	if _, err := client.Login(readCtx, &bwpb.LoginRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for login with an api key, got %v", err)
+	}
+	if _, err := client.Wallets(withAuth(tCtx, "reader", "wrong"), &bwpb.WalletsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for wrong secret, got %v", err)
+	}
+
+	fundsCtx := withAuth(tCtx, "funds", fundsSecret)
+	if _, err := client.Send(fundsCtx, &bwpb.SendRequest{AssetId: 42, Value: 1, Address: "Dsbad"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for address not in allowlist, got %v", err)
+	}
+	if tCore.sendAddr != "" {
+		t.Fatalf("sent to %s", tCore.sendAddr)
+	}
+	if _, err := client.Send(fundsCtx, &bwpb.SendRequest{AssetId: 42, Value: 1, Address: "Dsgood"}); err != nil {
+		t.Fatalf("Send error for allowed address: %v", err)
+	}
+
+	if _, err := client.Wallets(withAuth(tCtx, "remote", remoteSecret), &bwpb.WalletsRequest{}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for ip not in allowlist, got %v", err)
+	}
+
+	log, err := keys.AuditLog(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var allowed, denied int
+	for _, e := range log {
+		if e.Allowed {
+			allowed++
+		} else {
+			denied++
+		}
+	}
+	if allowed != 2 || denied != 4 {
+		t.Fatalf("expected 2 allowed and 4 denied audit entries, got %d and %d", allowed, denied)
+	}
+}
+
+func TestWithdrawAddresses(t *testing.T) {
+	reqs := map[string]any{
+		bwpb.Bisonw_Send_FullMethodName:     &bwpb.SendRequest{Address: "Dsgood"},
+		bwpb.Bisonw_PostBond_FullMethodName: &bwpb.PostBondRequest{},
+	}
+	// Every funds method must report its withdrawal addresses.
+	for method, perm := range methodPermissions {
+		if perm != apikey.PermFunds {
+			continue
+		}
+		if _, err := withdrawAddresses(method, reqs[method]); err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+	}
+	addrs, _ := withdrawAddresses(bwpb.Bisonw_Send_FullMethodName, reqs[bwpb.Bisonw_Send_FullMethodName])
+	if len(addrs) != 1 || addrs[0] != "Dsgood" {
+		t.Fatalf("wrong send addresses %v", addrs)
+	}
+	if _, err := withdrawAddresses("/bwpb.Bisonw/Unknown", nil); err == nil {
+		t.Fatalf("no error for an unknown method")
+	}
+}
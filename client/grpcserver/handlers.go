@@ -0,0 +1,373 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package grpcserver
+
+import (
+	"context"
+	"sort"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/grpcserver/bwpb"
+	"decred.org/dcrdex/client/mm"
+	"decred.org/dcrdex/dex/order"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errNoMarketMaker is returned by the market making methods when the server
+// was not configured with a MarketMaker.
+var errNoMarketMaker = status.Error(codes.Unimplemented, "market making is not available")
+
+// coreError wraps an error from Core.
+func coreError(what string, err error) error {
+	return status.Errorf(codes.Unknown, "unable to %s: %v", what, err)
+}
+
+// clearBytes zeros a password.
+func clearBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Version returns the bisonw and gRPC API versions.
+func (s *Server) Version(context.Context, *bwpb.VersionRequest) (*bwpb.VersionResponse, error) {
+	return &bwpb.VersionResponse{
+		BisonwVersion: s.version,
+		ApiVersion:    apiVersion,
+	}, nil
+}
+
+// Exchanges lists the known DEX hosts and their markets.
+func (s *Server) Exchanges(context.Context, *bwpb.ExchangesRequest) (*bwpb.ExchangesResponse, error) {
+	xcs := s.core.Exchanges()
+	res := &bwpb.ExchangesResponse{Exchanges: make([]*bwpb.Exchange, 0, len(xcs))}
+	for _, xc := range xcs {
+		res.Exchanges = append(res.Exchanges, exchangeMsg(xc))
+	}
+	sort.Slice(res.Exchanges, func(i, j int) bool {
+		return res.Exchanges[i].Host < res.Exchanges[j].Host
+	})
+	return res, nil
+}
+
+// GetDEXConfig fetches the configuration of a DEX host.
+func (s *Server) GetDEXConfig(_ context.Context, req *bwpb.GetDEXConfigRequest) (*bwpb.Exchange, error) {
+	if req.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing host")
+	}
+	var cert any
+	if len(req.Cert) > 0 {
+		cert = req.Cert
+	}
+	xc, err := s.core.GetDEXConfig(req.Host, cert)
+	if err != nil {
+		return nil, coreError("get dex config", err)
+	}
+	return exchangeMsg(xc), nil
+}
+
+// Login logs in and connects to the known DEX hosts.
+func (s *Server) Login(_ context.Context, req *bwpb.LoginRequest) (*bwpb.LoginResponse, error) {
+	defer clearBytes(req.AppPass)
+	if err := s.core.Login(req.AppPass); err != nil {
+		return nil, coreError("login", err)
+	}
+	return &bwpb.LoginResponse{}, nil
+}
+
+// Logout logs out.
+func (s *Server) Logout(context.Context, *bwpb.LogoutRequest) (*bwpb.LogoutResponse, error) {
+	if err := s.core.Logout(); err != nil {
+		return nil, coreError("logout", err)
+	}
+	return &bwpb.LogoutResponse{}, nil
+}
+
+// PostBond posts a fidelity bond.
+func (s *Server) PostBond(_ context.Context, req *bwpb.PostBondRequest) (*bwpb.PostBondResponse, error) {
+	defer clearBytes(req.AppPass)
+	if req.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing host")
+	}
+	assetID := req.AssetId
+	form := &core.PostBondForm{
+		Addr:         req.Host,
+		AppPass:      req.AppPass,
+		Asset:        &assetID,
+		Bond:         req.Bond,
+		MaintainTier: req.MaintainTier,
+	}
+	if len(req.Cert) > 0 {
+		form.Cert = req.Cert
+	}
+	res, err := s.core.PostBond(form)
+	if err != nil {
+		return nil, coreError("post bond", err)
+	}
+	return &bwpb.PostBondResponse{
+		BondId:      res.BondID,
+		ReqConfirms: uint32(res.ReqConfirms),
+	}, nil
+}
+
+// Wallets lists the wallets.
+func (s *Server) Wallets(context.Context, *bwpb.WalletsRequest) (*bwpb.WalletsResponse, error) {
+	states := s.core.Wallets()
+	res := &bwpb.WalletsResponse{Wallets: make([]*bwpb.Wallet, 0, len(states))}
+	for _, w := range states {
+		res.Wallets = append(res.Wallets, walletMsg(w))
+	}
+	return res, nil
+}
+
+// OpenWallet unlocks a wallet.
+func (s *Server) OpenWallet(_ context.Context, req *bwpb.OpenWalletRequest) (*bwpb.OpenWalletResponse, error) {
+	defer clearBytes(req.AppPass)
+	if err := s.core.OpenWallet(req.AssetId, req.AppPass); err != nil {
+		return nil, coreError("open wallet", err)
+	}
+	return &bwpb.OpenWalletResponse{}, nil
+}
+
+// CloseWallet locks a wallet.
+func (s *Server) CloseWallet(_ context.Context, req *bwpb.CloseWalletRequest) (*bwpb.CloseWalletResponse, error) {
+	if err := s.core.CloseWallet(req.AssetId); err != nil {
+		return nil, coreError("close wallet", err)
+	}
+	return &bwpb.CloseWalletResponse{}, nil
+}
+
+// NewDepositAddress generates a new deposit address for a wallet.
+func (s *Server) NewDepositAddress(_ context.Context, req *bwpb.NewDepositAddressRequest) (*bwpb.NewDepositAddressResponse, error) {
+	addr, err := s.core.NewDepositAddress(req.AssetId)
+	if err != nil {
+		return nil, coreError("get new address", err)
+	}
+	return &bwpb.NewDepositAddressResponse{Address: addr}, nil
+}
+
+// Send sends funds to an address.
+func (s *Server) Send(_ context.Context, req *bwpb.SendRequest) (*bwpb.SendResponse, error) {
+	defer clearBytes(req.AppPass)
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing address")
+	}
+	if req.Value == 0 {
+		return nil, status.Error(codes.InvalidArgument, "value must be positive")
+	}
+	coin, err := s.core.Send(req.AppPass, req.AssetId, req.Value, req.Address, req.Subtract)
+	if err != nil {
+		return nil, coreError("send", err)
+	}
+	return &bwpb.SendResponse{
+		Coin: coin.String(),
+		TxId: coin.TxID(),
+	}, nil
+}
+
+// TxHistory lists a wallet's transactions.
+func (s *Server) TxHistory(_ context.Context, req *bwpb.TxHistoryRequest) (*bwpb.TxHistoryResponse, error) {
+	txs, err := s.core.TxHistory(req.AssetId, int(req.N), req.RefId, req.Past)
+	if err != nil {
+		return nil, coreError("get tx history", err)
+	}
+	res := &bwpb.TxHistoryResponse{Txs: make([]*bwpb.WalletTransaction, 0, len(txs))}
+	for _, tx := range txs {
+		res.Txs = append(res.Txs, &bwpb.WalletTransaction{
+			Type:        uint32(tx.Type),
+			Id:          tx.ID,
+			Amount:      tx.Amount,
+			Fees:        tx.Fees,
+			BlockNumber: tx.BlockNumber,
+			Timestamp:   tx.Timestamp,
+			TokenId:     tx.TokenID,
+			Recipient:   tx.Recipient,
+			Confirmed:   tx.Confirmed,
+			Rejected:    tx.Rejected,
+		})
+	}
+	return res, nil
+}
+
+// Trade places a limit or market order.
+func (s *Server) Trade(_ context.Context, req *bwpb.TradeRequest) (*bwpb.Order, error) {
+	defer clearBytes(req.AppPass)
+	if req.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing host")
+	}
+	form := &core.TradeForm{
+		Host:    req.Host,
+		IsLimit: req.IsLimit,
+		Sell:    req.Sell,
+		Base:    req.Base,
+		Quote:   req.Quote,
+		Qty:     req.Qty,
+		Rate:    req.Rate,
+		TifNow:  req.TifNow,
+		Options: req.Options,
+	}
+	ord, err := s.core.Trade(req.AppPass, form)
+	if err != nil {
+		return nil, coreError("trade", err)
+	}
+	return orderMsg(ord), nil
+}
+
+// Cancel cancels an order.
+func (s *Server) Cancel(_ context.Context, req *bwpb.CancelRequest) (*bwpb.CancelResponse, error) {
+	if len(req.OrderId) != order.OrderIDSize {
+		return nil, status.Errorf(codes.InvalidArgument, "order id must be %d bytes", order.OrderIDSize)
+	}
+	if err := s.core.Cancel(req.OrderId); err != nil {
+		return nil, coreError("cancel order", err)
+	}
+	return &bwpb.CancelResponse{}, nil
+}
+
+// Book returns a snapshot of a market's order book.
+func (s *Server) Book(_ context.Context, req *bwpb.BookRequest) (*bwpb.OrderBook, error) {
+	book, err := s.core.Book(req.Host, req.Base, req.Quote)
+	if err != nil {
+		return nil, coreError("get order book", err)
+	}
+	return orderBookMsg(book), nil
+}
+
+// WatchBook streams a market's order book. Core sends the book as the first
+// update of a new feed.
+func (s *Server) WatchBook(req *bwpb.BookRequest, stream bwpb.Bisonw_WatchBookServer) error {
+	_, feed, err := s.core.SyncBook(req.Host, req.Base, req.Quote)
+	if err != nil {
+		return coreError("get order feed", err)
+	}
+	defer feed.Close()
+	ctx := stream.Context()
+	for {
+		select {
+		case u, ok := <-feed.Next():
+			if !ok {
+				return nil
+			}
+			msg := bookUpdateMsg(u)
+			if msg == nil {
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Orders lists orders matching a filter.
+func (s *Server) Orders(_ context.Context, req *bwpb.OrdersRequest) (*bwpb.OrdersResponse, error) {
+	filter := &core.OrderFilter{
+		N:      int(req.N),
+		Offset: req.Offset,
+		Hosts:  req.Hosts,
+		Assets: req.Assets,
+	}
+	for _, st := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, order.OrderStatus(st))
+	}
+	ords, err := s.core.Orders(filter)
+	if err != nil {
+		return nil, coreError("get orders", err)
+	}
+	res := &bwpb.OrdersResponse{Orders: make([]*bwpb.Order, 0, len(ords))}
+	for _, ord := range ords {
+		res.Orders = append(res.Orders, orderMsg(ord))
+	}
+	return res, nil
+}
+
+// GetOrder returns an order by ID.
+func (s *Server) GetOrder(_ context.Context, req *bwpb.GetOrderRequest) (*bwpb.Order, error) {
+	if len(req.OrderId) != order.OrderIDSize {
+		return nil, status.Errorf(codes.InvalidArgument, "order id must be %d bytes", order.OrderIDSize)
+	}
+	ord, err := s.core.Order(req.OrderId)
+	if err != nil {
+		return nil, coreError("get order", err)
+	}
+	return orderMsg(ord), nil
+}
+
+// MMStatus returns the status of the running market making bots.
+func (s *Server) MMStatus(context.Context, *bwpb.MMStatusRequest) (*bwpb.MMStatusResponse, error) {
+	if s.mm == nil {
+		return nil, errNoMarketMaker
+	}
+	st := s.mm.RunningBotsStatus()
+	res := &bwpb.MMStatusResponse{Bots: make([]*bwpb.BotStatus, 0, len(st.Bots))}
+	for _, bot := range st.Bots {
+		res.Bots = append(res.Bots, botStatusMsg(bot))
+	}
+	return res, nil
+}
+
+// StartBot starts a market making bot.
+func (s *Server) StartBot(_ context.Context, req *bwpb.StartBotRequest) (*bwpb.StartBotResponse, error) {
+	defer clearBytes(req.AppPass)
+	if s.mm == nil {
+		return nil, errNoMarketMaker
+	}
+	var cfgPath *string
+	if req.ConfigPath != "" {
+		cfgPath = &req.ConfigPath
+	}
+	startCfg := &mm.StartConfig{MarketWithHost: mm.MarketWithHost{
+		Host:    req.Host,
+		BaseID:  req.BaseId,
+		QuoteID: req.QuoteId,
+	}}
+	if err := s.mm.StartBot(startCfg, cfgPath, req.AppPass, true); err != nil {
+		return nil, coreError("start market making", err)
+	}
+	return &bwpb.StartBotResponse{}, nil
+}
+
+// StopBot stops a market making bot.
+func (s *Server) StopBot(_ context.Context, req *bwpb.StopBotRequest) (*bwpb.StopBotResponse, error) {
+	if s.mm == nil {
+		return nil, errNoMarketMaker
+	}
+	mkt := &mm.MarketWithHost{
+		Host:    req.Host,
+		BaseID:  req.BaseId,
+		QuoteID: req.QuoteId,
+	}
+	if err := s.mm.StopBot(mkt); err != nil {
+		return nil, coreError("stop market making", err)
+	}
+	return &bwpb.StopBotResponse{}, nil
+}
+
+// NotificationFeed streams Core's notifications.
+func (s *Server) NotificationFeed(_ *bwpb.NotificationFeedRequest, stream bwpb.Bisonw_NotificationFeedServer) error {
+	feed := s.core.NotificationFeed()
+	defer feed.ReturnFeed()
+	ctx := stream.Context()
+	for {
+		select {
+		case n, ok := <-feed.C:
+			if !ok {
+				return nil
+			}
+			msg, err := notificationMsg(n)
+			if err != nil {
+				log.Errorf("error encoding %s notification: %v", n.Type(), err)
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,275 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package grpcserver
+
+import (
+	"encoding/json"
+	"sort"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/grpcserver/bwpb"
+	"decred.org/dcrdex/client/mm"
+	"decred.org/dcrdex/client/orderbook"
+)
+
+// The bwpb enums have the same values as the Go types they are converted
+// from, e.g. bwpb.OrderStatus and order.OrderStatus.
+
+func exchangeMsg(xc *core.Exchange) *bwpb.Exchange {
+	msg := &bwpb.Exchange{
+		Host:             xc.Host,
+		AcctId:           xc.AcctID,
+		BondExpiry:       xc.BondExpiry,
+		ConnectionStatus: bwpb.ConnectionStatus(xc.ConnectionStatus),
+		ViewOnly:         xc.ViewOnly,
+		Disabled:         xc.Disabled,
+		TargetTier:       xc.Auth.TargetTier,
+		LiveStrength:     xc.Auth.LiveStrength,
+		PendingStrength:  xc.Auth.PendingStrength,
+		Score:            xc.Auth.Rep.Score,
+		PenaltyThreshold: xc.PenaltyThreshold,
+		MaxScore:         xc.MaxScore,
+	}
+	for _, mkt := range xc.Markets {
+		msg.Markets = append(msg.Markets, &bwpb.Market{
+			Name:        mkt.Name,
+			BaseId:      mkt.BaseID,
+			BaseSymbol:  mkt.BaseSymbol,
+			QuoteId:     mkt.QuoteID,
+			QuoteSymbol: mkt.QuoteSymbol,
+			LotSize:     mkt.LotSize,
+			ParcelSize:  mkt.ParcelSize,
+			RateStep:    mkt.RateStep,
+			EpochLen:    mkt.EpochLen,
+			StartEpoch:  mkt.StartEpoch,
+			MinimumRate: mkt.MinimumRate,
+		})
+	}
+	sort.Slice(msg.Markets, func(i, j int) bool {
+		return msg.Markets[i].Name < msg.Markets[j].Name
+	})
+	for _, a := range xc.Assets {
+		msg.Assets = append(msg.Assets, &bwpb.Asset{
+			Id:         a.ID,
+			Symbol:     a.Symbol,
+			Version:    a.Version,
+			SwapConf:   a.SwapConf,
+			MaxFeeRate: a.MaxFeeRate,
+		})
+	}
+	sort.Slice(msg.Assets, func(i, j int) bool {
+		return msg.Assets[i].Id < msg.Assets[j].Id
+	})
+	for symbol, b := range xc.BondAssets {
+		msg.BondAssets = append(msg.BondAssets, &bwpb.BondAsset{
+			Symbol:  symbol,
+			Id:      b.ID,
+			Version: uint32(b.Version),
+			Confs:   b.Confs,
+			Amount:  b.Amt,
+		})
+	}
+	sort.Slice(msg.BondAssets, func(i, j int) bool {
+		return msg.BondAssets[i].Id < msg.BondAssets[j].Id
+	})
+	return msg
+}
+
+func walletMsg(w *core.WalletState) *bwpb.Wallet {
+	msg := &bwpb.Wallet{
+		Symbol:       w.Symbol,
+		AssetId:      w.AssetID,
+		Version:      w.Version,
+		Type:         w.WalletType,
+		Open:         w.Open,
+		Running:      w.Running,
+		Address:      w.Address,
+		Units:        w.Units,
+		Encrypted:    w.Encrypted,
+		PeerCount:    w.PeerCount,
+		Synced:       w.Synced,
+		SyncProgress: w.SyncProgress,
+		Disabled:     w.Disabled,
+	}
+	if w.Balance != nil && w.Balance.Balance != nil {
+		msg.Balance = &bwpb.Balance{
+			Available:      w.Balance.Available,
+			Immature:       w.Balance.Immature,
+			Locked:         w.Balance.Locked,
+			OrderLocked:    w.Balance.OrderLocked,
+			ContractLocked: w.Balance.ContractLocked,
+			BondLocked:     w.Balance.BondLocked,
+		}
+	}
+	return msg
+}
+
+func coinStr(c *core.Coin) string {
+	if c == nil {
+		return ""
+	}
+	return c.StringID
+}
+
+func orderMsg(ord *core.Order) *bwpb.Order {
+	msg := &bwpb.Order{
+		Host:          ord.Host,
+		BaseId:        ord.BaseID,
+		BaseSymbol:    ord.BaseSymbol,
+		QuoteId:       ord.QuoteID,
+		QuoteSymbol:   ord.QuoteSymbol,
+		MarketId:      ord.MarketID,
+		Type:          bwpb.OrderType(ord.Type),
+		Id:            ord.ID,
+		Stamp:         ord.Stamp,
+		SubmitTime:    ord.SubmitTime,
+		Status:        bwpb.OrderStatus(ord.Status),
+		Epoch:         ord.Epoch,
+		Qty:           ord.Qty,
+		Sell:          ord.Sell,
+		Filled:        ord.Filled,
+		Cancelling:    ord.Cancelling,
+		Canceled:      ord.Canceled,
+		Rate:          ord.Rate,
+		Tif:           bwpb.TimeInForce(ord.TimeInForce),
+		TargetOrderId: ord.TargetOrderID,
+		LockedAmt:     ord.LockedAmt,
+	}
+	if ord.FeesPaid != nil {
+		msg.FeesPaid = &bwpb.Fees{
+			Swap:       ord.FeesPaid.Swap,
+			Redemption: ord.FeesPaid.Redemption,
+			Funding:    ord.FeesPaid.Funding,
+		}
+	}
+	for _, m := range ord.Matches {
+		msg.Matches = append(msg.Matches, &bwpb.Match{
+			MatchId:       m.MatchID,
+			Status:        bwpb.MatchStatus(m.Status),
+			Active:        m.Active,
+			Revoked:       m.Revoked,
+			Rate:          m.Rate,
+			Qty:           m.Qty,
+			Side:          bwpb.MatchSide(m.Side),
+			FeeRate:       m.FeeRate,
+			Swap:          coinStr(m.Swap),
+			CounterSwap:   coinStr(m.CounterSwap),
+			Redeem:        coinStr(m.Redeem),
+			CounterRedeem: coinStr(m.CounterRedeem),
+			Refund:        coinStr(m.Refund),
+			Stamp:         m.Stamp,
+			IsCancel:      m.IsCancel,
+		})
+	}
+	return msg
+}
+
+func bookOrderMsg(o *core.MiniOrder) *bwpb.BookOrder {
+	return &bwpb.BookOrder{
+		Token: o.Token,
+		Qty:   o.QtyAtomic,
+		Rate:  o.MsgRate,
+		Epoch: o.Epoch,
+		Sell:  o.Sell,
+	}
+}
+
+func matchSummaryMsgs(ms []*orderbook.MatchSummary) []*bwpb.MatchSummary {
+	msgs := make([]*bwpb.MatchSummary, 0, len(ms))
+	for _, m := range ms {
+		msgs = append(msgs, &bwpb.MatchSummary{
+			Rate:  m.Rate,
+			Qty:   m.Qty,
+			Stamp: m.Stamp,
+			Sell:  m.Sell,
+		})
+	}
+	return msgs
+}
+
+func orderBookMsg(book *core.OrderBook) *bwpb.OrderBook {
+	msg := &bwpb.OrderBook{RecentMatches: matchSummaryMsgs(book.RecentMatches)}
+	for _, o := range book.Sells {
+		msg.Sells = append(msg.Sells, bookOrderMsg(o))
+	}
+	for _, o := range book.Buys {
+		msg.Buys = append(msg.Buys, bookOrderMsg(o))
+	}
+	for _, o := range book.Epoch {
+		msg.Epoch = append(msg.Epoch, bookOrderMsg(o))
+	}
+	return msg
+}
+
+// bookUpdateMsg converts an order book update. Updates that are not part of
+// the order book, e.g. candles, are not converted and nil is returned.
+func bookUpdateMsg(u *core.BookUpdate) *bwpb.BookUpdate {
+	msg := &bwpb.BookUpdate{
+		Action:   u.Action,
+		Host:     u.Host,
+		MarketId: u.MarketID,
+	}
+	switch p := u.Payload.(type) {
+	case *core.MarketOrderBook:
+		msg.Payload = &bwpb.BookUpdate_Book{Book: orderBookMsg(p.Book)}
+	case *core.MiniOrder:
+		msg.Payload = &bwpb.BookUpdate_Order{Order: bookOrderMsg(p)}
+	case *core.RemainderUpdate:
+		msg.Payload = &bwpb.BookUpdate_Remainder{Remainder: &bwpb.RemainderUpdate{
+			Token: p.Token,
+			Qty:   p.QtyAtomic,
+		}}
+	case *core.EpochMatchSummaryPayload:
+		msg.Payload = &bwpb.BookUpdate_MatchSummary{MatchSummary: &bwpb.EpochMatchSummary{
+			Epoch:   p.Epoch,
+			Matches: matchSummaryMsgs(p.MatchSummaries),
+		}}
+	case *core.ResolvedEpoch:
+		msg.Payload = &bwpb.BookUpdate_ResolvedEpoch{ResolvedEpoch: &bwpb.ResolvedEpoch{
+			Current:  p.Current,
+			Resolved: p.Resolved,
+		}}
+	default:
+		return nil
+	}
+	return msg
+}
+
+func botStatusMsg(bot *mm.BotStatus) *bwpb.BotStatus {
+	msg := &bwpb.BotStatus{Running: bot.Running}
+	if bot.Config != nil {
+		msg.Host = bot.Config.Host
+		msg.BaseId = bot.Config.BaseID
+		msg.QuoteId = bot.Config.QuoteID
+		msg.CexName = bot.Config.CEXName
+	}
+	if st := bot.RunStats; st != nil {
+		msg.StartTime = st.StartTime
+		msg.CompletedMatches = st.CompletedMatches
+		msg.TradedUsd = st.TradedUSD
+		msg.PendingDeposits = int32(st.PendingDeposits)
+		msg.PendingWithdrawals = int32(st.PendingWithdrawals)
+		if st.ProfitLoss != nil {
+			msg.ProfitLoss = st.ProfitLoss.Profit
+		}
+	}
+	return msg
+}
+
+func notificationMsg(n core.Notification) (*bwpb.Notification, error) {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	return &bwpb.Notification{
+		Id:       n.ID(),
+		Type:     n.Type(),
+		Topic:    string(n.Topic()),
+		Subject:  n.Subject(),
+		Details:  n.Details(),
+		Severity: bwpb.Severity(n.Severity()),
+		Time:     n.Time(),
+		Payload:  payload,
+	}, nil
+}
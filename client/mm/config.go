@@ -159,6 +159,7 @@ type BotConfig struct {
 	BasicMMConfig        *BasicMarketMakingConfig `json:"basicMarketMakingConfig,omitempty"`
 	SimpleArbConfig      *SimpleArbConfig         `json:"simpleArbConfig,omitempty"`
 	ArbMarketMakerConfig *ArbMarketMakerConfig    `json:"arbMarketMakingConfig,omitempty"`
+	ScriptConfig         *ScriptConfig            `json:"scriptConfig,omitempty"`
 }
 
 func (c *BotConfig) copy() *BotConfig {
@@ -183,6 +184,9 @@ func (c *BotConfig) copy() *BotConfig {
 	if c.ArbMarketMakerConfig != nil {
 		b.ArbMarketMakerConfig = c.ArbMarketMakerConfig.copy()
 	}
+	if c.ScriptConfig != nil {
+		b.ScriptConfig = c.ScriptConfig.copy()
+	}
 
 	return &b
 }
@@ -204,6 +208,8 @@ func (c *BotConfig) validate() error {
 		return c.SimpleArbConfig.validate()
 	} else if c.ArbMarketMakerConfig != nil {
 		return c.ArbMarketMakerConfig.validate()
+	} else if c.ScriptConfig != nil {
+		return c.ScriptConfig.validate()
 	}
 
 	return fmt.Errorf("no bot config set")
@@ -212,7 +218,8 @@ func (c *BotConfig) validate() error {
 func validateConfigUpdate(old, new *BotConfig) error {
 	if (old.BasicMMConfig == nil) != (new.BasicMMConfig == nil) ||
 		(old.SimpleArbConfig == nil) != (new.SimpleArbConfig == nil) ||
-		(old.ArbMarketMakerConfig == nil) != (new.ArbMarketMakerConfig == nil) {
+		(old.ArbMarketMakerConfig == nil) != (new.ArbMarketMakerConfig == nil) ||
+		(old.ScriptConfig == nil) != (new.ScriptConfig == nil) {
 		return fmt.Errorf("cannot change bot type")
 	}
 
@@ -254,6 +261,8 @@ func (c *BotConfig) maxPlacements() (buy, sell uint32) {
 		return uint32(len(c.ArbMarketMakerConfig.BuyPlacements)), uint32(len(c.ArbMarketMakerConfig.SellPlacements))
 	case c.BasicMMConfig != nil:
 		return uint32(len(c.BasicMMConfig.BuyPlacements)), uint32(len(c.BasicMMConfig.SellPlacements))
+	case c.ScriptConfig != nil && c.ScriptConfig.MaxPlacements > 0:
+		return c.ScriptConfig.MaxPlacements, c.ScriptConfig.MaxPlacements
 	default:
 		return 1, 1
 	}
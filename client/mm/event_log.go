@@ -9,6 +9,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"os"
 
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/dex"
@@ -120,6 +121,11 @@ type eventLogDB interface {
 	// including and after the event with the ID will be returned. If
 	// pendingOnly is true, only pending events will be returned.
 	runEvents(startTime int64, mkt *MarketWithHost, n uint64, refID *uint64, pendingOnly bool, filters *RunLogFilters) ([]*MarketMakingEvent, error)
+	// backup returns a copy of the entire database.
+	backup() ([]byte, error)
+	// restore adds the runs from a database copy created by backup. Runs that
+	// are already in the database are not modified.
+	restore(b []byte) error
 }
 
 // eventUpdate is used to asynchronously add events to the event log.
@@ -388,6 +394,81 @@ func (db *boltEventLogDB) Close() error {
 	return db.DB.Close()
 }
 
+// backup returns a copy of the entire database.
+func (db *boltEventLogDB) backup() ([]byte, error) {
+	var b bytes.Buffer
+	err := db.View(func(tx *bbolt.Tx) error {
+		_, err := tx.WriteTo(&b)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// restore adds the runs from a database copy created by backup. Runs that are
+// already in the database are not modified. Backups from older database
+// versions have no compatible runs.
+func (db *boltEventLogDB) restore(b []byte) error {
+	f, err := os.CreateTemp("", "eventlog-restore-*.db")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	src, err := bbolt.Open(f.Name(), 0600, &bbolt.Options{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("error opening event log backup: %w", err)
+	}
+	defer src.Close()
+
+	return src.View(func(srcTx *bbolt.Tx) error {
+		srcRuns := srcTx.Bucket(botRunsBucket)
+		if srcRuns == nil {
+			return fmt.Errorf("no runs bucket in event log backup")
+		}
+		versionB := srcRuns.Get(versionKey)
+		if versionB == nil || encode.BytesToUint32(versionB) != balanceStateDBVersion {
+			db.log.Warnf("Not restoring runs from incompatible event log backup")
+			return nil
+		}
+		return db.Update(func(tx *bbolt.Tx) error {
+			botRuns := tx.Bucket(botRunsBucket)
+			return srcRuns.ForEachBucket(func(k []byte) error {
+				if botRuns.Bucket(k) != nil {
+					return nil
+				}
+				runBucket, err := botRuns.CreateBucket(k)
+				if err != nil {
+					return err
+				}
+				return copyBucket(runBucket, srcRuns.Bucket(k))
+			})
+		})
+	})
+}
+
+// copyBucket copies the keys and nested buckets of src to dst.
+func copyBucket(dst, src *bbolt.Bucket) error {
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		nested, err := dst.CreateBucketIfNotExists(k)
+		if err != nil {
+			return err
+		}
+		return copyBucket(nested, src.Bucket(k))
+	})
+}
+
 func (db *boltEventLogDB) storeCfgUpdate(runBucket *bbolt.Bucket, newCfg *BotConfig, timestamp int64) error {
 	cfgsBucket, err := runBucket.CreateBucketIfNotExists(cfgsBucket)
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -537,3 +538,133 @@ func TestUpdateFinalBalanceDueToEventDiff(t *testing.T) {
 
 	tryWithTimeout(t, checkFinalState)
 }
+
+func TestEventLogDBBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srcDB, err := newBoltEventLogDB(ctx, filepath.Join(dir, "src.db"), tLogger)
+	if err != nil {
+		t.Fatalf("error creating event log db: %v", err)
+	}
+	dstDB, err := newBoltEventLogDB(ctx, filepath.Join(dir, "dst.db"), tLogger)
+	if err != nil {
+		t.Fatalf("error creating event log db: %v", err)
+	}
+
+	mkt := &MarketWithHost{Host: "dex.com", BaseID: 42, QuoteID: 60}
+	cfg := &BotConfig{Host: "dex.com", BaseID: 42, QuoteID: 60}
+	initialState := &BalanceState{Balances: map[uint32]*BotBalance{42: {Available: 1e8}}}
+	startTimes := []int64{1000, 2000}
+	if err := srcDB.storeNewRun(startTimes[0], mkt, cfg, initialState); err != nil {
+		t.Fatalf("error storing run: %v", err)
+	}
+	if err := srcDB.storeNewRun(startTimes[1], mkt, cfg, initialState); err != nil {
+		t.Fatalf("error storing run: %v", err)
+	}
+	srcDB.storeEvent(startTimes[0], mkt, &MarketMakingEvent{ID: 1, TimeStamp: 1001}, initialState)
+	tryWithTimeout(t, func() error {
+		events, err := srcDB.runEvents(startTimes[0], mkt, 0, nil, false, nil)
+		if err != nil {
+			return err
+		}
+		if len(events) != 1 {
+			return fmt.Errorf("expected 1 event, got %d", len(events))
+		}
+		return nil
+	})
+
+	// The second run is already in the destination and is not overwritten.
+	dstState := &BalanceState{Balances: map[uint32]*BotBalance{42: {Available: 5}}}
+	if err := dstDB.storeNewRun(startTimes[1], mkt, cfg, dstState); err != nil {
+		t.Fatalf("error storing run: %v", err)
+	}
+
+	b, err := srcDB.backup()
+	if err != nil {
+		t.Fatalf("backup error: %v", err)
+	}
+	if err := dstDB.restore(b); err != nil {
+		t.Fatalf("restore error: %v", err)
+	}
+	runs, err := dstDB.runs(0, nil, nil)
+	if err != nil {
+		t.Fatalf("error getting runs: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(runs))
+	}
+	events, err := dstDB.runEvents(startTimes[0], mkt, 0, nil, false, nil)
+	if err != nil {
+		t.Fatalf("error getting events: %v", err)
+	}
+	if len(events) != 1 || events[0].ID != 1 {
+		t.Fatalf("event not restored")
+	}
+	overview, err := dstDB.runOverview(startTimes[1], mkt)
+	if err != nil {
+		t.Fatalf("error getting run overview: %v", err)
+	}
+	if overview.InitialBalances[42] != 5 {
+		t.Fatalf("existing run was overwritten")
+	}
+}
+
+func TestLedgerEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventLogDB, err := newBoltEventLogDB(ctx, filepath.Join(t.TempDir(), "event_log.db"), tLogger)
+	if err != nil {
+		t.Fatalf("error creating event log db: %v", err)
+	}
+	mkt := &MarketWithHost{Host: "dex.com", BaseID: 42, QuoteID: 60}
+	cfg := &BotConfig{Host: "dex.com", BaseID: 42, QuoteID: 60, CEXName: "Binance"}
+	state := &BalanceState{Balances: map[uint32]*BotBalance{42: {Available: 1e8}}}
+	if err := eventLogDB.storeNewRun(1000, mkt, cfg, state); err != nil {
+		t.Fatalf("error storing run: %v", err)
+	}
+	for _, e := range []*MarketMakingEvent{
+		{ID: 1, TimeStamp: 1001, CEXOrderEvent: &CEXOrderEvent{ID: "sell", Sell: true, BaseFilled: 1e8, QuoteFilled: 2e6}},
+		{ID: 2, TimeStamp: 1002, CEXOrderEvent: &CEXOrderEvent{ID: "buy", BaseFilled: 3e8, QuoteFilled: 5e6}},
+		{ID: 3, TimeStamp: 1003, Pending: true, CEXOrderEvent: &CEXOrderEvent{ID: "pending", BaseFilled: 1e8}},
+		{ID: 4, TimeStamp: 1004, CEXOrderEvent: &CEXOrderEvent{ID: "unfilled"}},
+		{ID: 5, TimeStamp: 1005, DEXOrderEvent: &DEXOrderEvent{ID: "dex"}},
+	} {
+		eventLogDB.storeEvent(1000, mkt, e, state)
+	}
+	tryWithTimeout(t, func() error {
+		events, err := eventLogDB.runEvents(1000, mkt, 0, nil, false, nil)
+		if err != nil {
+			return err
+		}
+		if len(events) != 5 {
+			return fmt.Errorf("expected 5 events, got %d", len(events))
+		}
+		return nil
+	})
+
+	m := &MarketMaker{eventLogDB: eventLogDB}
+	events, err := m.LedgerEvents()
+	if err != nil {
+		t.Fatalf("LedgerEvents error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 ledger events, got %d", len(events))
+	}
+	byID := make(map[string]*core.LedgerEvent)
+	for _, e := range events {
+		if e.Type != core.LedgerCEXTrade || e.Source != "Binance" {
+			t.Fatalf("wrong ledger event %+v", e)
+		}
+		byID[e.ID] = e
+	}
+	if e := byID["sell"]; e == nil || e.Stamp != 1001000 || e.Sent.AssetID != 42 || e.Sent.Amount != 1e8 || e.Received.Amount != 2e6 {
+		t.Fatalf("wrong sell event %+v", e)
+	}
+	if e := byID["buy"]; e == nil || e.Sent.AssetID != 60 || e.Sent.Amount != 5e6 || e.Received.Amount != 3e8 {
+		t.Fatalf("wrong buy event %+v", e)
+	}
+}
@@ -75,6 +75,8 @@ func (db *tEventLogDB) latestStoredEvent() *MarketMakingEvent {
 	}
 	return db.storedEvents[len(db.storedEvents)-1]
 }
+func (db *tEventLogDB) backup() ([]byte, error) { return nil, nil }
+func (db *tEventLogDB) restore(b []byte) error  { return nil }
 func (db *tEventLogDB) runs(n uint64, refStartTime *uint64, refMkt *MarketWithHost) ([]*MarketMakingRun, error) {
 	return nil, nil
 }
@@ -770,6 +770,8 @@ func (m *MarketMaker) botSubLogger(cfg *BotConfig) dex.Logger {
 		return m.log.SubLogger(fmt.Sprintf("ARB-%s", mktID))
 	case cfg.ArbMarketMakerConfig != nil:
 		return m.log.SubLogger(fmt.Sprintf("AMM-%s", mktID))
+	case cfg.ScriptConfig != nil:
+		return m.log.SubLogger(fmt.Sprintf("SCR-%s", mktID))
 	}
 	// This will error in the caller.
 	return m.log.SubLogger(fmt.Sprintf("Bot-%s", mktID))
@@ -794,6 +796,8 @@ func (m *MarketMaker) newBot(cfg *BotConfig, adaptorCfg *exchangeAdaptorCfg) (bo
 		return newBasicMarketMaker(cfg, adaptorCfg, m.oracle, m.log.SubLogger(fmt.Sprintf("MM-%s", mktID)))
 	case cfg.SimpleArbConfig != nil:
 		return newSimpleArbMarketMaker(cfg, adaptorCfg, m.log.SubLogger(fmt.Sprintf("ARB-%s", mktID)))
+	case cfg.ScriptConfig != nil:
+		return newScriptBot(cfg, adaptorCfg, m.log.SubLogger(fmt.Sprintf("SCR-%s", mktID)))
 	default:
 		return nil, fmt.Errorf("not bot config found")
 	}
@@ -1070,6 +1074,83 @@ func (m *MarketMaker) UpdateCEXConfig(updatedCfg *CEXConfig) error {
 	return nil
 }
 
+// StateBackup is the market making state included in an application state
+// backup.
+type StateBackup struct {
+	Config *MarketMakingConfig `json:"config"`
+	// EventLog is a copy of the event log database.
+	EventLog []byte `json:"eventLog,omitempty"`
+}
+
+// AppStateSection is the name of the market maker's section of an application
+// state backup.
+const AppStateSection = "mm"
+
+// ExportState returns the bot and CEX configurations and the event log for
+// inclusion in an application state backup. If excludeSecrets is true, the
+// CEX API credentials are omitted.
+func (m *MarketMaker) ExportState(excludeSecrets bool) (json.RawMessage, error) {
+	cfg := m.defaultConfig()
+	if excludeSecrets {
+		for i, cexCfg := range cfg.CexConfigs {
+			cfg.CexConfigs[i] = &CEXConfig{Name: cexCfg.Name}
+		}
+	}
+	state := &StateBackup{Config: cfg}
+	if m.eventLogDB != nil {
+		b, err := m.eventLogDB.backup()
+		if err != nil {
+			return nil, fmt.Errorf("error backing up event log: %w", err)
+		}
+		state.EventLog = b
+	}
+	return json.Marshal(state)
+}
+
+// RestoreState restores state exported with ExportState. Bot and CEX
+// configurations are only added for markets and exchanges that are not
+// already configured, and only runs that are not already in the event log
+// are added.
+func (m *MarketMaker) RestoreState(b json.RawMessage) error {
+	var state StateBackup
+	if err := json.Unmarshal(b, &state); err != nil {
+		return fmt.Errorf("error decoding market making state: %w", err)
+	}
+	if state.Config != nil {
+		cfg := m.defaultConfig()
+		haveBot := make(map[MarketWithHost]bool, len(cfg.BotConfigs))
+		for _, botCfg := range cfg.BotConfigs {
+			haveBot[MarketWithHost{botCfg.Host, botCfg.BaseID, botCfg.QuoteID}] = true
+		}
+		for _, botCfg := range state.Config.BotConfigs {
+			if !haveBot[MarketWithHost{botCfg.Host, botCfg.BaseID, botCfg.QuoteID}] {
+				cfg.BotConfigs = append(cfg.BotConfigs, botCfg)
+			}
+		}
+		haveCEX := make(map[string]bool, len(cfg.CexConfigs))
+		for _, cexCfg := range cfg.CexConfigs {
+			haveCEX[cexCfg.Name] = true
+		}
+		for _, cexCfg := range state.Config.CexConfigs {
+			if !haveCEX[cexCfg.Name] {
+				cfg.CexConfigs = append(cfg.CexConfigs, cexCfg)
+			}
+		}
+		if err := m.writeConfigFile(cfg); err != nil {
+			return err
+		}
+	}
+	if len(state.EventLog) > 0 {
+		if m.eventLogDB == nil {
+			return errors.New("event log not loaded")
+		}
+		if err := m.eventLogDB.restore(state.EventLog); err != nil {
+			return fmt.Errorf("error restoring event log: %w", err)
+		}
+	}
+	return nil
+}
+
 // RemoveConfig removes a bot config from the market making config.
 func (m *MarketMaker) RemoveBotConfig(host string, baseID, quoteID uint32) error {
 	cfg := m.defaultConfig()
@@ -1114,6 +1195,10 @@ func validRunningBotCfgUpdate(oldCfg, newCfg *BotConfig) error {
 		return fmt.Errorf("cannot change bot type for running bot")
 	}
 
+	if oldCfg.ScriptConfig == nil != (newCfg.ScriptConfig == nil) {
+		return fmt.Errorf("cannot change bot type for running bot")
+	}
+
 	return nil
 }
 
@@ -1268,6 +1353,51 @@ func (m *MarketMaker) RunOverview(startTime int64, mkt *MarketWithHost) (*Market
 	return m.eventLogDB.runOverview(startTime, mkt)
 }
 
+// LedgerEvents returns the completed CEX trades of all runs as ledger events,
+// for inclusion in the Core ledger.
+func (m *MarketMaker) LedgerEvents() ([]*core.LedgerEvent, error) {
+	runs, err := m.eventLogDB.runs(0, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var events []*core.LedgerEvent
+	for _, run := range runs {
+		overview, err := m.eventLogDB.runOverview(run.StartTime, run.Market)
+		if err != nil {
+			return nil, fmt.Errorf("error getting overview for %s run at %d: %w", run.Market, run.StartTime, err)
+		}
+		var cexName string
+		if len(overview.Cfgs) > 0 && overview.Cfgs[0].Cfg != nil {
+			cexName = overview.Cfgs[0].Cfg.CEXName
+		}
+		runEvents, err := m.eventLogDB.runEvents(run.StartTime, run.Market, 0, nil, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting events for %s run at %d: %w", run.Market, run.StartTime, err)
+		}
+		for _, e := range runEvents {
+			o := e.CEXOrderEvent
+			if o == nil || e.Pending || o.BaseFilled == 0 {
+				continue
+			}
+			base := &core.LedgerAmount{AssetID: run.Market.BaseID, Symbol: dex.BipIDSymbol(run.Market.BaseID), Amount: o.BaseFilled}
+			quote := &core.LedgerAmount{AssetID: run.Market.QuoteID, Symbol: dex.BipIDSymbol(run.Market.QuoteID), Amount: o.QuoteFilled}
+			sent, received := quote, base
+			if o.Sell {
+				sent, received = base, quote
+			}
+			events = append(events, &core.LedgerEvent{
+				Stamp:    uint64(e.TimeStamp) * 1000,
+				Type:     core.LedgerCEXTrade,
+				Source:   cexName,
+				ID:       o.ID,
+				Sent:     sent,
+				Received: received,
+			})
+		}
+	}
+	return events, nil
+}
+
 func (m *MarketMaker) updateDEXOrderEvent(mkt *MarketWithHost, event *MarketMakingEvent) (*MarketMakingEvent, error) {
 	orderEvent := event.DEXOrderEvent
 
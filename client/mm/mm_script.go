@@ -0,0 +1,723 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package mm
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/mm/libxc"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+	starjson "go.starlark.net/lib/json"
+	"go.starlark.net/lib/math"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+const (
+	// defaultScriptMaxSteps is the default limit on the Starlark execution
+	// steps of the script's top-level code or a single callback.
+	defaultScriptMaxSteps = 10_000_000
+
+	scriptEpochFunc    = "on_epoch"
+	scriptOrderFunc    = "on_order"
+	scriptCEXTradeFunc = "on_cex_trade"
+)
+
+// ScriptConfig is the configuration for a bot whose strategy is defined by a
+// user's Starlark script. Starlark is a Python dialect without access to the
+// file system, network or clock, so the script can only interact with the
+// market through the bot module, and is subject to the same allocation,
+// event logging and stop semantics as the built-in bots.
+//
+// The script must define on_epoch(epoch), which is called for every new
+// epoch on the DEX market. on_order(order) and on_cex_trade(trade) are called
+// for updates to the bot's DEX orders and CEX trades if they are defined. The
+// bot module provides the market data and trading functions. Rates are
+// message-rates and quantities are in atoms. The params global is the decoded
+// Params, and the state global is a dict that persists between calls. print
+// writes to the bot's log.
+type ScriptConfig struct {
+	// Script is the Starlark source. If empty, the source is read from
+	// ScriptPath when the bot starts.
+	Script string `json:"script,omitempty"`
+	// ScriptPath is the path of a file containing the Starlark source.
+	ScriptPath string `json:"scriptPath,omitempty"`
+	// Params is a JSON value available to the script as params, so that a
+	// strategy can be reused with different settings.
+	Params json.RawMessage `json:"params,omitempty"`
+	// MaxPlacements is the most orders the script places on each side of the
+	// book with MultiTrade. It is used to estimate funding fees. Default 1.
+	MaxPlacements uint32 `json:"maxPlacements,omitempty"`
+	// MaxSteps limits the execution steps of a single callback, so that a
+	// runaway loop cannot stall the bot. Default 10 million.
+	MaxSteps uint64 `json:"maxSteps,omitempty"`
+}
+
+func (c *ScriptConfig) copy() *ScriptConfig {
+	cfg := *c
+	if c.Params != nil {
+		cfg.Params = make(json.RawMessage, len(c.Params))
+		copy(cfg.Params, c.Params)
+	}
+	return &cfg
+}
+
+// source returns the Starlark source and a file name for error messages.
+func (c *ScriptConfig) source() (filename, src string, err error) {
+	if c.Script != "" {
+		return "script.star", c.Script, nil
+	}
+	if c.ScriptPath == "" {
+		return "", "", errors.New("no script or script path")
+	}
+	b, err := os.ReadFile(c.ScriptPath)
+	if err != nil {
+		return "", "", fmt.Errorf("error reading script: %w", err)
+	}
+	return c.ScriptPath, string(b), nil
+}
+
+func (c *ScriptConfig) maxSteps() uint64 {
+	if c.MaxSteps == 0 {
+		return defaultScriptMaxSteps
+	}
+	return c.MaxSteps
+}
+
+func (c *ScriptConfig) validate() error {
+	if c.Script != "" && c.ScriptPath != "" {
+		return errors.New("only one of script and script path can be set")
+	}
+	if len(c.Params) > 0 && !json.Valid(c.Params) {
+		return errors.New("params are not valid JSON")
+	}
+	filename, src, err := c.source()
+	if err != nil {
+		return err
+	}
+	f, _, err := starlark.SourceProgramOptions(scriptFileOptions, filename, src, scriptPredeclared.Has)
+	if err != nil {
+		return fmt.Errorf("error compiling script: %w", err)
+	}
+	for _, stmt := range f.Stmts {
+		if def, is := stmt.(*syntax.DefStmt); is && def.Name.Name == scriptEpochFunc {
+			return nil
+		}
+	}
+	return fmt.Errorf("script does not define %s", scriptEpochFunc)
+}
+
+// scriptFileOptions enables the Starlark language features that are off by
+// default.
+var scriptFileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+	Recursion:       true,
+}
+
+// scriptPredeclared are the predeclared names, used only for compilation.
+// The bot's values are created when the script is loaded.
+var scriptPredeclared = starlark.StringDict{
+	"bot":    starlark.None,
+	"params": starlark.None,
+	"state":  starlark.None,
+	"json":   starlark.None,
+	"math":   starlark.None,
+}
+
+type scriptBot struct {
+	*unifiedExchangeAdaptor
+	core botCoreAdaptor
+	cex  botCexAdaptor
+	book dexOrderBook
+
+	// scriptMtx serializes the callbacks, which share the script's state.
+	scriptMtx  sync.Mutex
+	globals    starlark.StringDict
+	epoch      uint64
+	buyReport  *OrderReport
+	sellReport *OrderReport
+}
+
+var _ bot = (*scriptBot)(nil)
+
+func (s *scriptBot) cfg() *ScriptConfig {
+	return s.botCfg().ScriptConfig
+}
+
+// load executes the script's top-level code.
+func (s *scriptBot) load() error {
+	cfg := s.cfg()
+	filename, src, err := cfg.source()
+	if err != nil {
+		return err
+	}
+	params := starlark.Value(starlark.None)
+	if len(cfg.Params) > 0 {
+		params, err = starlark.Call(&starlark.Thread{}, starjson.Module.Members["decode"],
+			starlark.Tuple{starlark.String(cfg.Params)}, nil)
+		if err != nil {
+			return fmt.Errorf("error decoding params: %w", err)
+		}
+	}
+	predeclared := starlark.StringDict{
+		"bot":    s.module(),
+		"params": params,
+		"state":  starlark.NewDict(0),
+		"json":   starjson.Module,
+		"math":   math.Module,
+	}
+	thread, stop := s.thread(s.ctx)
+	defer stop()
+	globals, err := starlark.ExecFileOptions(scriptFileOptions, thread, filename, src, predeclared)
+	if err != nil {
+		return fmt.Errorf("error executing script: %w", scriptError(err))
+	}
+	if _, is := globals[scriptEpochFunc].(starlark.Callable); !is {
+		return fmt.Errorf("script does not define %s", scriptEpochFunc)
+	}
+	s.globals = globals
+	return nil
+}
+
+// thread creates a Starlark thread limited to the configured steps, which is
+// cancelled if the context is cancelled before the returned stop function is
+// called.
+func (s *scriptBot) thread(ctx context.Context) (*starlark.Thread, func() bool) {
+	thread := &starlark.Thread{
+		Name: s.botID,
+		Print: func(_ *starlark.Thread, msg string) {
+			s.log.Infof("script: %s", msg)
+		},
+	}
+	thread.SetMaxExecutionSteps(s.cfg().maxSteps())
+	return thread, context.AfterFunc(ctx, func() { thread.Cancel("bot stopped") })
+}
+
+// scriptError includes the Starlark backtrace in the error message.
+func scriptError(err error) error {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		return errors.New(evalErr.Backtrace())
+	}
+	return err
+}
+
+// call calls the named script function if it is defined.
+func (s *scriptBot) call(name string, args ...starlark.Value) error {
+	s.scriptMtx.Lock()
+	defer s.scriptMtx.Unlock()
+	fn, is := s.globals[name].(starlark.Callable)
+	if !is {
+		return nil
+	}
+	thread, stop := s.thread(s.ctx)
+	defer stop()
+	if _, err := starlark.Call(thread, fn, args, nil); err != nil {
+		return fmt.Errorf("%s error: %w", name, scriptError(err))
+	}
+	return nil
+}
+
+func (s *scriptBot) rebalance(newEpoch uint64) {
+	s.log.Tracef("rebalance: epoch %d", newEpoch)
+
+	if !s.checkBotHealth(newEpoch) {
+		s.tryCancelOrders(s.ctx, &newEpoch, false)
+		return
+	}
+
+	s.scriptMtx.Lock()
+	s.epoch = newEpoch
+	s.buyReport, s.sellReport = nil, nil
+	s.scriptMtx.Unlock()
+
+	err := s.call(scriptEpochFunc, starlark.MakeUint64(newEpoch))
+	if err != nil {
+		s.log.Errorf("Script error: %v", err)
+	}
+
+	s.scriptMtx.Lock()
+	epochReport := &EpochReport{
+		BuysReport:  s.buyReport,
+		SellsReport: s.sellReport,
+		EpochNum:    newEpoch,
+	}
+	s.scriptMtx.Unlock()
+	epochReport.setPreOrderProblems(err)
+	s.updateEpochReport(epochReport)
+}
+
+func (s *scriptBot) botLoop(ctx context.Context) (*sync.WaitGroup, error) {
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	book, bookFeed, err := s.core.SyncBook(s.host, s.baseID, s.quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync book: %v", err)
+	}
+	s.book = book
+
+	var tradeUpdates <-chan *libxc.Trade
+	if s.CEX != nil {
+		if err := s.cex.SubscribeMarket(s.ctx, s.baseID, s.quoteID); err != nil {
+			bookFeed.Close()
+			return nil, fmt.Errorf("failed to subscribe to cex market: %v", err)
+		}
+		tradeUpdates = s.cex.SubscribeTradeUpdates()
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer bookFeed.Close()
+		for {
+			select {
+			case ni, ok := <-bookFeed.Next():
+				if !ok {
+					s.log.Error("Stopping bot due to nil book feed.")
+					s.kill()
+					return
+				}
+				switch epoch := ni.Payload.(type) {
+				case *core.ResolvedEpoch:
+					s.rebalance(epoch.Current)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		orderUpdates := s.core.SubscribeOrderUpdates()
+		for {
+			select {
+			case o := <-orderUpdates:
+				if err := s.call(scriptOrderFunc, scriptOrder(o)); err != nil {
+					s.log.Errorf("Script error: %v", err)
+				}
+			case trade := <-tradeUpdates:
+				if err := s.call(scriptCEXTradeFunc, scriptCEXTrade(trade)); err != nil {
+					s.log.Errorf("Script error: %v", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return &wg, nil
+}
+
+func scriptStruct(fields starlark.StringDict) *starlarkstruct.Struct {
+	return starlarkstruct.FromStringDict(starlarkstruct.Default, fields)
+}
+
+func scriptOrder(o *core.Order) starlark.Value {
+	return scriptStruct(starlark.StringDict{
+		"id":     starlark.String(o.ID.String()),
+		"sell":   starlark.Bool(o.Sell),
+		"rate":   starlark.MakeUint64(o.Rate),
+		"qty":    starlark.MakeUint64(o.Qty),
+		"filled": starlark.MakeUint64(o.Filled),
+		"status": starlark.String(o.Status.String()),
+		"active": starlark.Bool(o.Status <= order.OrderStatusBooked),
+	})
+}
+
+func scriptCEXTrade(t *libxc.Trade) starlark.Value {
+	return scriptStruct(starlark.StringDict{
+		"id":           starlark.String(t.ID),
+		"sell":         starlark.Bool(t.Sell),
+		"rate":         starlark.MakeUint64(t.Rate),
+		"qty":          starlark.MakeUint64(t.Qty),
+		"base_filled":  starlark.MakeUint64(t.BaseFilled),
+		"quote_filled": starlark.MakeUint64(t.QuoteFilled),
+		"complete":     starlark.Bool(t.Complete),
+	})
+}
+
+func scriptBalance(b *BotBalance) starlark.Value {
+	return scriptStruct(starlark.StringDict{
+		"available": starlark.MakeUint64(b.Available),
+		"locked":    starlark.MakeUint64(b.Locked),
+		"pending":   starlark.MakeUint64(b.Pending),
+		"reserved":  starlark.MakeUint64(b.Reserved),
+	})
+}
+
+type scriptBuiltin func(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error)
+
+// module creates the bot module exposed to the script.
+func (s *scriptBot) module() *starlarkstruct.Module {
+	fns := map[string]scriptBuiltin{
+		"lot_size":       s.scriptLotSize,
+		"rate_step":      s.scriptRateStep,
+		"mid_gap":        s.scriptMidGap,
+		"vwap":           s.scriptVWAP,
+		"dex_balance":    s.scriptDEXBalance,
+		"cex_balance":    s.scriptCEXBalance,
+		"fiat_rate":      s.scriptFiatRate,
+		"fiat_mid_gap":   s.scriptFiatMidGap,
+		"conv_rate":      s.scriptConvRate,
+		"msg_rate":       s.scriptMsgRate,
+		"stepped_rate":   s.scriptSteppedRate,
+		"fees_in_units":  s.scriptFeesInUnits,
+		"sufficient_dex": s.scriptSufficientDEX,
+		"multi_trade":    s.scriptMultiTrade,
+		"trade":          s.scriptTrade,
+		"cancel":         s.scriptCancel,
+		"cex_mid_gap":    s.scriptCEXMidGap,
+		"cex_vwap":       s.scriptCEXVWAP,
+		"cex_trade":      s.scriptCEXTrade,
+		"cex_cancel":     s.scriptCEXCancel,
+	}
+	members := starlark.StringDict{
+		"host":     starlark.String(s.host),
+		"base_id":  starlark.MakeUint64(uint64(s.baseID)),
+		"quote_id": starlark.MakeUint64(uint64(s.quoteID)),
+	}
+	for name, fn := range fns {
+		fn := fn
+		members[name] = starlark.NewBuiltin("bot."+name, func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			v, err := fn(args, kwargs)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", b.Name(), err)
+			}
+			return v, nil
+		})
+	}
+	return &starlarkstruct.Module{Name: "bot", Members: members}
+}
+
+func (s *scriptBot) scriptLotSize(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs("lot_size", args, kwargs); err != nil {
+		return nil, err
+	}
+	return starlark.MakeUint64(s.lotSize.Load()), nil
+}
+
+func (s *scriptBot) scriptRateStep(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs("rate_step", args, kwargs); err != nil {
+		return nil, err
+	}
+	return starlark.MakeUint64(s.rateStep.Load()), nil
+}
+
+// scriptMidGap returns the mid-gap rate of the DEX book, or None if the book
+// is empty.
+func (s *scriptBot) scriptMidGap(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs("mid_gap", args, kwargs); err != nil {
+		return nil, err
+	}
+	midGap, err := s.book.MidGap()
+	if err != nil {
+		return starlark.None, nil
+	}
+	return starlark.MakeUint64(midGap), nil
+}
+
+// scriptVWAP returns the (avg, extrema, filled) rates for filling the lots
+// on the DEX book.
+func (s *scriptBot) scriptVWAP(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var lots uint64
+	var sell bool
+	if err := starlark.UnpackArgs("vwap", args, kwargs, "lots", &lots, "sell", &sell); err != nil {
+		return nil, err
+	}
+	avg, extrema, filled, err := s.book.VWAP(lots, s.lotSize.Load(), sell)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Tuple{starlark.MakeUint64(avg), starlark.MakeUint64(extrema), starlark.Bool(filled)}, nil
+}
+
+func (s *scriptBot) scriptDEXBalance(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var assetID uint32
+	if err := starlark.UnpackArgs("dex_balance", args, kwargs, "asset_id", &assetID); err != nil {
+		return nil, err
+	}
+	return scriptBalance(s.DEXBalance(assetID)), nil
+}
+
+func (s *scriptBot) scriptCEXBalance(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var assetID uint32
+	if err := starlark.UnpackArgs("cex_balance", args, kwargs, "asset_id", &assetID); err != nil {
+		return nil, err
+	}
+	return scriptBalance(s.CEXBalance(assetID)), nil
+}
+
+func (s *scriptBot) scriptFiatRate(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var assetID uint32
+	if err := starlark.UnpackArgs("fiat_rate", args, kwargs, "asset_id", &assetID); err != nil {
+		return nil, err
+	}
+	return starlark.Float(s.fiatRate(assetID)), nil
+}
+
+// scriptFiatMidGap returns the market rate derived from the fiat exchange
+// rates, or 0 if unavailable.
+func (s *scriptBot) scriptFiatMidGap(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs("fiat_mid_gap", args, kwargs); err != nil {
+		return nil, err
+	}
+	return starlark.MakeUint64(s.core.ExchangeRateFromFiatSources()), nil
+}
+
+func (s *scriptBot) scriptConvRate(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var msgRate uint64
+	if err := starlark.UnpackArgs("conv_rate", args, kwargs, "rate", &msgRate); err != nil {
+		return nil, err
+	}
+	return starlark.Float(calc.ConventionalRate(msgRate, s.bui, s.qui)), nil
+}
+
+func (s *scriptBot) scriptMsgRate(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var convRate float64
+	if err := starlark.UnpackArgs("msg_rate", args, kwargs, "rate", &convRate); err != nil {
+		return nil, err
+	}
+	return starlark.MakeUint64(s.msgRate(convRate)), nil
+}
+
+func (s *scriptBot) scriptSteppedRate(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rate uint64
+	if err := starlark.UnpackArgs("stepped_rate", args, kwargs, "rate", &rate); err != nil {
+		return nil, err
+	}
+	return starlark.MakeUint64(steppedRate(rate, s.rateStep.Load())), nil
+}
+
+// scriptFeesInUnits returns the estimated fees for a lot in units of the base
+// or quote asset.
+func (s *scriptBot) scriptFeesInUnits(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var sell, base bool
+	var rate uint64
+	if err := starlark.UnpackArgs("fees_in_units", args, kwargs, "sell", &sell, "base", &base, "rate", &rate); err != nil {
+		return nil, err
+	}
+	fees, err := s.core.OrderFeesInUnits(sell, base, rate)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.MakeUint64(fees), nil
+}
+
+func (s *scriptBot) scriptSufficientDEX(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rate, qty uint64
+	var sell bool
+	if err := starlark.UnpackArgs("sufficient_dex", args, kwargs, "rate", &rate, "qty", &qty, "sell", &sell); err != nil {
+		return nil, err
+	}
+	ok, err := s.core.SufficientBalanceForDEXTrade(rate, qty, sell)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Bool(ok), nil
+}
+
+// parsePlacement parses a placement, which is either a (rate, lots) tuple or
+// a dict with rate, lots and optionally counter_trade_rate keys.
+func parsePlacement(v starlark.Value) (*TradePlacement, error) {
+	p := new(TradePlacement)
+	switch v := v.(type) {
+	case starlark.Tuple:
+		if len(v) != 2 {
+			return nil, fmt.Errorf("placement tuple should be (rate, lots), got %s", v)
+		}
+		if err := starlark.AsInt(v[0], &p.Rate); err != nil {
+			return nil, fmt.Errorf("invalid rate: %w", err)
+		}
+		if err := starlark.AsInt(v[1], &p.Lots); err != nil {
+			return nil, fmt.Errorf("invalid lots: %w", err)
+		}
+	case *starlark.Dict:
+		for key, ptr := range map[string]*uint64{"rate": &p.Rate, "lots": &p.Lots, "counter_trade_rate": &p.CounterTradeRate} {
+			val, found, err := v.Get(starlark.String(key))
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+			if err := starlark.AsInt(val, ptr); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("placement should be a tuple or dict, got %s", v.Type())
+	}
+	return p, nil
+}
+
+// scriptMultiTrade places the orders with MultiTrade. The placements are the
+// lots that should be on the book at each rate in priority order, and should
+// be the same length in each call. A rate of 0 cancels the placement's
+// orders. The return value is the number of lots ordered for each placement.
+func (s *scriptBot) scriptMultiTrade(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var list *starlark.List
+	var sell bool
+	var driftTolerance float64
+	if err := starlark.UnpackArgs("multi_trade", args, kwargs, "placements", &list, "sell", &sell, "drift_tolerance?", &driftTolerance); err != nil {
+		return nil, err
+	}
+	placements := make([]*TradePlacement, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		p, err := parsePlacement(list.Index(i))
+		if err != nil {
+			return nil, fmt.Errorf("placement %d: %w", i, err)
+		}
+		placements = append(placements, p)
+	}
+	_, report := s.multiTrade(placements, sell, driftTolerance, s.epoch)
+	if sell {
+		s.sellReport = report
+	} else {
+		s.buyReport = report
+	}
+	if report.Error != nil && report.Error.UnknownError != "" {
+		return nil, errors.New(report.Error.UnknownError)
+	}
+	ordered := make([]starlark.Value, 0, len(report.Placements))
+	for _, p := range report.Placements {
+		ordered = append(ordered, starlark.MakeUint64(p.OrderedLots))
+	}
+	return starlark.NewList(ordered), nil
+}
+
+// scriptTrade places a single DEX order and returns its ID.
+func (s *scriptBot) scriptTrade(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rate, qty uint64
+	var sell bool
+	if err := starlark.UnpackArgs("trade", args, kwargs, "rate", &rate, "qty", &qty, "sell", &sell); err != nil {
+		return nil, err
+	}
+	o, err := s.core.DEXTrade(rate, qty, sell)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.String(o.ID.String()), nil
+}
+
+// scriptCancel cancels a DEX order. Only orders placed by the bot may be
+// canceled.
+func (s *scriptBot) scriptCancel(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var oidStr string
+	if err := starlark.UnpackArgs("cancel", args, kwargs, "order_id", &oidStr); err != nil {
+		return nil, err
+	}
+	b, err := hex.DecodeString(oidStr)
+	if err != nil || len(b) != order.OrderIDSize {
+		return nil, fmt.Errorf("invalid order ID %q", oidStr)
+	}
+	var oid order.OrderID
+	copy(oid[:], b)
+	s.balancesMtx.RLock()
+	_, found := s.pendingDEXOrders[oid]
+	s.balancesMtx.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("order %s was not placed by this bot", oid)
+	}
+	return starlark.None, s.core.Cancel(oid[:])
+}
+
+var errNoCEX = errors.New("bot has no CEX")
+
+func (s *scriptBot) scriptCEXMidGap(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs("cex_mid_gap", args, kwargs); err != nil {
+		return nil, err
+	}
+	if s.CEX == nil {
+		return nil, errNoCEX
+	}
+	return starlark.MakeUint64(s.cex.MidGap(s.baseID, s.quoteID)), nil
+}
+
+// scriptCEXVWAP returns the (avg, extrema, filled) rates for filling the
+// quantity on the CEX book.
+func (s *scriptBot) scriptCEXVWAP(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var qty uint64
+	var sell bool
+	if err := starlark.UnpackArgs("cex_vwap", args, kwargs, "qty", &qty, "sell", &sell); err != nil {
+		return nil, err
+	}
+	if s.CEX == nil {
+		return nil, errNoCEX
+	}
+	avg, extrema, filled, err := s.CEX.VWAP(s.baseID, s.quoteID, sell, qty)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Tuple{starlark.MakeUint64(avg), starlark.MakeUint64(extrema), starlark.Bool(filled)}, nil
+}
+
+// scriptCEXTrade places a CEX order and returns its ID.
+func (s *scriptBot) scriptCEXTrade(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var rate, qty uint64
+	var sell bool
+	if err := starlark.UnpackArgs("cex_trade", args, kwargs, "rate", &rate, "qty", &qty, "sell", &sell); err != nil {
+		return nil, err
+	}
+	if s.CEX == nil {
+		return nil, errNoCEX
+	}
+	trade, err := s.cex.CEXTrade(s.ctx, s.baseID, s.quoteID, sell, rate, qty)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.String(trade.ID), nil
+}
+
+func (s *scriptBot) scriptCEXCancel(args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var tradeID string
+	if err := starlark.UnpackArgs("cex_cancel", args, kwargs, "trade_id", &tradeID); err != nil {
+		return nil, err
+	}
+	if s.CEX == nil {
+		return nil, errNoCEX
+	}
+	return starlark.None, s.cex.CancelTrade(s.ctx, s.baseID, s.quoteID, tradeID)
+}
+
+func newScriptBot(cfg *BotConfig, adaptorCfg *exchangeAdaptorCfg, log dex.Logger) (*scriptBot, error) {
+	if cfg.ScriptConfig == nil {
+		// implies bug in caller
+		return nil, errors.New("no script config provided")
+	}
+
+	if err := cfg.ScriptConfig.validate(); err != nil {
+		return nil, fmt.Errorf("invalid script config: %w", err)
+	}
+
+	adaptor, err := newUnifiedExchangeAdaptor(adaptorCfg)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing exchange adaptor: %w", err)
+	}
+
+	s := &scriptBot{
+		unifiedExchangeAdaptor: adaptor,
+		core:                   adaptor,
+		cex:                    adaptor,
+	}
+	adaptor.setBotLoop(s.botLoop)
+	return s, nil
+}
@@ -0,0 +1,201 @@
+package mm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+	"go.starlark.net/starlark"
+)
+
+const tScript = `
+def on_epoch(epoch):
+    mid = bot.mid_gap()
+    if mid == None:
+        return
+    spread = params["spread"] * bot.rate_step()
+    lots = params["lots"]
+    bot.multi_trade([{"rate": bot.stepped_rate(mid - spread), "lots": lots}], False)
+    bot.multi_trade([(bot.stepped_rate(mid + spread), lots)], True)
+`
+
+func TestScriptConfigValidate(t *testing.T) {
+	scriptPath := filepath.Join(t.TempDir(), "bot.star")
+	if err := os.WriteFile(scriptPath, []byte(tScript), 0600); err != nil {
+		t.Fatalf("error writing script: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *ScriptConfig
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			cfg:  &ScriptConfig{Script: tScript},
+		},
+		{
+			name: "ok path",
+			cfg:  &ScriptConfig{ScriptPath: scriptPath},
+		},
+		{
+			name:    "no script",
+			cfg:     &ScriptConfig{},
+			wantErr: true,
+		},
+		{
+			name:    "script and path",
+			cfg:     &ScriptConfig{Script: tScript, ScriptPath: scriptPath},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			cfg:     &ScriptConfig{ScriptPath: filepath.Join(t.TempDir(), "nope.star")},
+			wantErr: true,
+		},
+		{
+			name:    "syntax error",
+			cfg:     &ScriptConfig{Script: "def on_epoch(epoch)\n    pass\n"},
+			wantErr: true,
+		},
+		{
+			name:    "undefined name",
+			cfg:     &ScriptConfig{Script: "def on_epoch(epoch):\n    boat.mid_gap()\n"},
+			wantErr: true,
+		},
+		{
+			name:    "no on_epoch",
+			cfg:     &ScriptConfig{Script: "def on_order(o):\n    pass\n"},
+			wantErr: true,
+		},
+		{
+			name:    "bad params",
+			cfg:     &ScriptConfig{Script: tScript, Params: json.RawMessage(`{"lots":`)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wantErr = %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestScriptBotRebalance(t *testing.T) {
+	const lotSize = 5e9
+	const rateStep = 1e3
+	const midGap uint64 = 5e6
+	const baseID, quoteID = 42, 0
+
+	newBot := func(script string, params string) (*scriptBot, *tCore) {
+		t.Helper()
+		s := &scriptBot{
+			unifiedExchangeAdaptor: mustParseAdaptorFromMarket(&core.Market{
+				RateStep:   rateStep,
+				AtomToConv: 1,
+				LotSize:    lotSize,
+				BaseID:     baseID,
+				QuoteID:    quoteID,
+			}),
+			book: &tOrderBook{midGap: midGap},
+		}
+		s.core = s.unifiedExchangeAdaptor
+		tcore := newTCore()
+		tcore.setWalletsAndExchange(&core.Market{
+			BaseID:  baseID,
+			QuoteID: quoteID,
+		})
+		s.clientCore = tcore
+		s.fiatRates.Store(map[uint32]float64{baseID: 1, quoteID: 1})
+		s.buyFees = &OrderFees{
+			LotFeeRange: &LotFeeRange{
+				Max:       &LotFees{Swap: 2e5, Redeem: 1e5},
+				Estimated: &LotFees{},
+			},
+			BookingFeesPerLot: 2e5,
+		}
+		s.sellFees = &OrderFees{
+			LotFeeRange: &LotFeeRange{
+				Max:       &LotFees{Swap: 3e6, Redeem: 1e6},
+				Estimated: &LotFees{},
+			},
+			BookingFeesPerLot: 3e6,
+		}
+		s.baseDexBalances[baseID] = lotSize * 50
+		s.baseDexBalances[quoteID] = int64(calc.BaseToQuote(midGap, lotSize*50))
+		s.botCfgV.Store(&BotConfig{
+			ScriptConfig: &ScriptConfig{
+				Script:   script,
+				Params:   json.RawMessage(params),
+				MaxSteps: 10_000,
+			},
+		})
+		if err := s.load(); err != nil {
+			t.Fatalf("error loading script: %v", err)
+		}
+		return s, tcore
+	}
+
+	s, tcore := newBot(tScript, `{"spread": 10, "lots": 2}`)
+	s.rebalance(100)
+
+	if len(tcore.multiTradesPlaced) != 2 {
+		t.Fatalf("expected 2 multi-trades, got %d", len(tcore.multiTradesPlaced))
+	}
+	buys, sells := tcore.multiTradesPlaced[0], tcore.multiTradesPlaced[1]
+	if buys.Sell || !sells.Sell {
+		t.Fatalf("wrong sides")
+	}
+	if len(buys.Placements) != 1 || len(sells.Placements) != 1 {
+		t.Fatalf("expected 1 placement per side, got %d buys and %d sells", len(buys.Placements), len(sells.Placements))
+	}
+	if buys.Placements[0].Rate != midGap-10*rateStep || buys.Placements[0].Qty != 2*lotSize {
+		t.Fatalf("wrong buy placement %+v", buys.Placements[0])
+	}
+	if sells.Placements[0].Rate != midGap+10*rateStep || sells.Placements[0].Qty != 2*lotSize {
+		t.Fatalf("wrong sell placement %+v", sells.Placements[0])
+	}
+
+	// The state persists between epochs.
+	s, tcore = newBot("def on_epoch(epoch):\n    state['n'] = state.get('n', 0) + 1\n    if state['n'] == 2:\n        bot.multi_trade([(bot.mid_gap(), 1)], False)\n", "")
+	s.rebalance(100)
+	s.rebalance(101)
+	if len(tcore.multiTradesPlaced) != 1 {
+		t.Fatalf("expected 1 multi-trade, got %d", len(tcore.multiTradesPlaced))
+	}
+
+	// A runaway script is stopped and reported as an epoch problem.
+	s, tcore = newBot("def on_epoch(epoch):\n    while True:\n        pass\n", "")
+	s.rebalance(100)
+	if len(tcore.multiTradesPlaced) != 0 {
+		t.Fatalf("unexpected multi-trades")
+	}
+	report, _ := s.epochReport.Load().(*EpochReport)
+	if report == nil || report.PreOrderProblems == nil || !strings.Contains(report.PreOrderProblems.UnknownError, "too many steps") {
+		t.Fatalf("expected step limit error in epoch report, got %+v", report)
+	}
+
+	// Scripts can only cancel the bot's own orders.
+	s, tcore = newBot("def on_epoch(epoch):\n    pass\n", "")
+	var botOID, otherOID order.OrderID
+	botOID[0], otherOID[0] = 1, 2
+	s.pendingDEXOrders[botOID] = &pendingDEXOrder{}
+	if _, err := s.scriptCancel(starlark.Tuple{starlark.String(otherOID.String())}, nil); err == nil {
+		t.Fatalf("no error canceling an order not placed by the bot")
+	}
+	if _, err := s.scriptCancel(starlark.Tuple{starlark.String(botOID.String())}, nil); err != nil {
+		t.Fatalf("error canceling bot order: %v", err)
+	}
+	if len(tcore.cancelsPlaced) != 1 || tcore.cancelsPlaced[0] != botOID {
+		t.Fatalf("expected 1 cancel of the bot's order, got %v", tcore.cancelsPlaced)
+	}
+}
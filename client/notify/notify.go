@@ -0,0 +1,340 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package notify forwards Core notifications to outbound sinks, such as HTTP
+// webhooks, ntfy-style push endpoints, and SMTP email, so that the user learns
+// of failed matches, refunds, and bot problems when no UI is open.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+	"golang.org/x/time/rate"
+)
+
+// Sink types.
+const (
+	SinkWebhook = "webhook"
+	SinkNtfy    = "ntfy"
+	SinkEmail   = "email"
+)
+
+const (
+	defaultRetries      = 3
+	defaultRatePerMin   = 30
+	defaultQueueSize    = 100
+	defaultSendTimeout  = 20 * time.Second
+	defaultMinSeverity  = db.Success
+	maxRetryDelayFactor = 1 << 5
+)
+
+// retryDelay is the delay before the first retry of a failed delivery. The
+// delay doubles with each retry. It is a variable for testing.
+var retryDelay = 5 * time.Second
+
+// clientCore is the Core functionality used by the Dispatcher.
+type clientCore interface {
+	NotificationFeed() *core.NoteFeed
+}
+
+// Config is the notification sink configuration, typically read from a JSON
+// file.
+type Config struct {
+	Sinks []*SinkConfig `json:"sinks"`
+}
+
+// Filter selects the notifications forwarded to a sink. Empty lists match all
+// notifications.
+type Filter struct {
+	// Types are notification types, e.g. "order", "match", "bot".
+	Types []string `json:"types,omitempty"`
+	// Topics are notification topics, e.g. "MatchRevoked", "SwapRefunded".
+	Topics []string `json:"topics,omitempty"`
+	// MinSeverity is the lowest severity forwarded, one of "poke", "success",
+	// "warning", or "error". The default is "success".
+	MinSeverity string `json:"minSeverity,omitempty"`
+}
+
+// SinkConfig is the configuration for a single sink. Exactly one of Webhook,
+// Ntfy, or Email must be set, matching the Type.
+type SinkConfig struct {
+	// Name identifies the sink in logs. Defaults to the Type.
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Filter *Filter `json:"filter,omitempty"`
+	// Retries is the number of times a failed delivery is retried, with
+	// exponential backoff. Default 3. Use -1 for no retries.
+	Retries int `json:"retries,omitempty"`
+	// RatePerMinute limits the number of notifications sent. Notifications
+	// exceeding the limit are dropped. Default 30.
+	RatePerMinute int `json:"ratePerMinute,omitempty"`
+
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+	Ntfy    *NtfyConfig    `json:"ntfy,omitempty"`
+	Email   *EmailConfig   `json:"email,omitempty"`
+}
+
+// LoadConfig reads a Config from a JSON file.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading notification config file: %w", err)
+	}
+	cfg := new(Config)
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("error parsing notification config file: %w", err)
+	}
+	return cfg, nil
+}
+
+// Message is the notification data delivered to sinks. Webhooks receive the
+// JSON encoding.
+type Message struct {
+	ID       dex.Bytes `json:"id"`
+	Type     string    `json:"type"`
+	Topic    string    `json:"topic"`
+	Severity string    `json:"severity"`
+	Subject  string    `json:"subject"`
+	Details  string    `json:"details"`
+	// Stamp is the notification time, in milliseconds.
+	Stamp uint64 `json:"stamp"`
+
+	severity db.Severity
+}
+
+func newMessage(n core.Notification) *Message {
+	return &Message{
+		ID:       n.ID(),
+		Type:     n.Type(),
+		Topic:    string(n.Topic()),
+		Severity: n.Severity().String(),
+		Subject:  n.Subject(),
+		Details:  n.Details(),
+		Stamp:    n.Time(),
+		severity: n.Severity(),
+	}
+}
+
+// sender delivers a message to a sink endpoint.
+type sender interface {
+	send(ctx context.Context, msg *Message) error
+}
+
+// sink is a configured sink with its filter, queue and rate limiter.
+type sink struct {
+	name        string
+	sender      sender
+	types       map[string]bool
+	topics      map[string]bool
+	minSeverity db.Severity
+	retries     int
+	limiter     *rate.Limiter
+	queue       chan *Message
+}
+
+func parseSeverity(s string) (db.Severity, error) {
+	if s == "" {
+		return defaultMinSeverity, nil
+	}
+	for _, sev := range []db.Severity{db.Poke, db.Success, db.WarningLevel, db.ErrorLevel} {
+		if strings.EqualFold(s, sev.String()) {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", s)
+}
+
+func newSink(cfg *SinkConfig) (*sink, error) {
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Type
+	}
+	var snd sender
+	var err error
+	switch cfg.Type {
+	case SinkWebhook:
+		if cfg.Webhook == nil {
+			return nil, errors.New("no webhook configuration")
+		}
+		snd, err = newWebhookSender(cfg.Webhook)
+	case SinkNtfy:
+		if cfg.Ntfy == nil {
+			return nil, errors.New("no ntfy configuration")
+		}
+		snd, err = newNtfySender(cfg.Ntfy)
+	case SinkEmail:
+		if cfg.Email == nil {
+			return nil, errors.New("no email configuration")
+		}
+		snd, err = newEmailSender(cfg.Email)
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := &sink{
+		name:        name,
+		sender:      snd,
+		minSeverity: defaultMinSeverity,
+		retries:     cfg.Retries,
+		queue:       make(chan *Message, defaultQueueSize),
+	}
+	switch {
+	case s.retries == 0:
+		s.retries = defaultRetries
+	case s.retries < 0:
+		s.retries = 0
+	}
+	ratePerMin := cfg.RatePerMinute
+	if ratePerMin <= 0 {
+		ratePerMin = defaultRatePerMin
+	}
+	s.limiter = rate.NewLimiter(rate.Limit(float64(ratePerMin)/60), ratePerMin)
+	if f := cfg.Filter; f != nil {
+		if s.minSeverity, err = parseSeverity(f.MinSeverity); err != nil {
+			return nil, err
+		}
+		if len(f.Types) > 0 {
+			s.types = make(map[string]bool, len(f.Types))
+			for _, t := range f.Types {
+				s.types[t] = true
+			}
+		}
+		if len(f.Topics) > 0 {
+			s.topics = make(map[string]bool, len(f.Topics))
+			for _, t := range f.Topics {
+				s.topics[t] = true
+			}
+		}
+	}
+	return s, nil
+}
+
+// matches checks whether the message passes the sink's filter.
+func (s *sink) matches(msg *Message) bool {
+	if msg.severity < s.minSeverity {
+		return false
+	}
+	if s.types != nil && !s.types[msg.Type] {
+		return false
+	}
+	if s.topics != nil && !s.topics[msg.Topic] {
+		return false
+	}
+	return true
+}
+
+// deliver sends the message, retrying with exponential backoff.
+func (s *sink) deliver(ctx context.Context, msg *Message, log dex.Logger) {
+	delay := retryDelay
+	for i := 0; ; i++ {
+		sendCtx, cancel := context.WithTimeout(ctx, defaultSendTimeout)
+		err := s.sender.send(sendCtx, msg)
+		cancel()
+		if err == nil {
+			return
+		}
+		if i >= s.retries {
+			log.Errorf("Failed to deliver notification %q to %s sink after %d attempts: %v", msg.Subject, s.name, i+1, err)
+			return
+		}
+		log.Debugf("Error delivering notification to %s sink. Retrying in %s: %v", s.name, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		if delay < retryDelay*maxRetryDelayFactor {
+			delay *= 2
+		}
+	}
+}
+
+// Dispatcher forwards Core notifications to the configured sinks.
+type Dispatcher struct {
+	core  clientCore
+	log   dex.Logger
+	sinks []*sink
+}
+
+// NewDispatcher is the constructor for a Dispatcher.
+func NewDispatcher(c clientCore, cfg *Config, log dex.Logger) (*Dispatcher, error) {
+	d := &Dispatcher{
+		core: c,
+		log:  log,
+	}
+	for i, sinkCfg := range cfg.Sinks {
+		s, err := newSink(sinkCfg)
+		if err != nil {
+			return nil, fmt.Errorf("error configuring sink %d (%s): %w", i, sinkCfg.Name, err)
+		}
+		d.sinks = append(d.sinks, s)
+	}
+	return d, nil
+}
+
+// Connect starts forwarding notifications. Connect satisfies dex.Connector.
+func (d *Dispatcher) Connect(ctx context.Context) (*sync.WaitGroup, error) {
+	var wg sync.WaitGroup
+	for _, s := range d.sinks {
+		wg.Add(1)
+		go func(s *sink) {
+			defer wg.Done()
+			for {
+				select {
+				case msg := <-s.queue:
+					s.deliver(ctx, msg, d.log)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(s)
+	}
+
+	feed := d.core.NotificationFeed()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer feed.ReturnFeed()
+		for {
+			select {
+			case n := <-feed.C:
+				d.dispatch(n)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return &wg, nil
+}
+
+// dispatch queues the notification for delivery to matching sinks.
+func (d *Dispatcher) dispatch(n core.Notification) {
+	msg := newMessage(n)
+	for _, s := range d.sinks {
+		if !s.matches(msg) {
+			continue
+		}
+		if !s.limiter.Allow() {
+			d.log.Warnf("Rate limit exceeded for %s sink. Dropping notification %q", s.name, msg.Subject)
+			continue
+		}
+		select {
+		case s.queue <- msg:
+		default:
+			d.log.Warnf("Delivery queue full for %s sink. Dropping notification %q", s.name, msg.Subject)
+		}
+	}
+}
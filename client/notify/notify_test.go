@@ -0,0 +1,347 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
+	"decred.org/dcrdex/dex"
+)
+
+var tLogger = dex.StdOutLogger("NOTIFY_TEST", dex.LevelInfo)
+
+func init() {
+	retryDelay = time.Millisecond
+}
+
+func newNote(noteType string, topic db.Topic, severity db.Severity) core.Notification {
+	n := db.NewNotification(noteType, topic, "subject "+string(topic), "details\nline 2", severity)
+	return &n
+}
+
+type tCore struct {
+	noteC chan core.Notification
+}
+
+func (c *tCore) NotificationFeed() *core.NoteFeed {
+	return &core.NoteFeed{C: c.noteC}
+}
+
+// tServer is a stand-in HTTP endpoint that records requests and fails the
+// first failN of them.
+type tServer struct {
+	*httptest.Server
+	mtx     sync.Mutex
+	failN   int
+	reqs    []*http.Request
+	bodies  [][]byte
+	arrived chan struct{}
+}
+
+func newTServer(failN int) *tServer {
+	s := &tServer{failN: failN, arrived: make(chan struct{}, 100)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		if s.failN > 0 {
+			s.failN--
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		s.reqs = append(s.reqs, r)
+		s.bodies = append(s.bodies, b)
+		s.arrived <- struct{}{}
+	}))
+	return s
+}
+
+func (s *tServer) wait(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-s.arrived:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for request %d", i+1)
+		}
+	}
+}
+
+func TestSinkFilter(t *testing.T) {
+	s, err := newSink(&SinkConfig{
+		Type:    SinkWebhook,
+		Webhook: &WebhookConfig{URL: "http://localhost"},
+		Filter: &Filter{
+			Types:       []string{core.NoteTypeMatch, core.NoteTypeOrder},
+			Topics:      []string{"SwapRefunded", "MatchRevoked", "OrderLoaded"},
+			MinSeverity: "warning",
+		},
+	})
+	if err != nil {
+		t.Fatalf("newSink error: %v", err)
+	}
+	for _, tt := range []struct {
+		note core.Notification
+		exp  bool
+	}{
+		{newNote(core.NoteTypeMatch, "SwapRefunded", db.WarningLevel), true},
+		{newNote(core.NoteTypeMatch, "MatchRevoked", db.ErrorLevel), true},
+		{newNote(core.NoteTypeMatch, "SwapRefunded", db.Success), false},
+		{newNote(core.NoteTypeOrder, "OrderPlaced", db.ErrorLevel), false},
+		{newNote(core.NoteTypeBalance, "SwapRefunded", db.ErrorLevel), false},
+	} {
+		if s.matches(newMessage(tt.note)) != tt.exp {
+			t.Fatalf("wrong match result for %s", tt.note)
+		}
+	}
+
+	// The default filter passes Success and above.
+	s, _ = newSink(&SinkConfig{Type: SinkNtfy, Ntfy: &NtfyConfig{URL: "https://ntfy.sh/x"}})
+	if s.matches(newMessage(newNote(core.NoteTypeOrder, "x", db.Poke))) {
+		t.Fatalf("default filter passed a poke")
+	}
+	if !s.matches(newMessage(newNote(core.NoteTypeOrder, "x", db.Success))) {
+		t.Fatalf("default filter blocked a success note")
+	}
+
+	for name, cfg := range map[string]*SinkConfig{
+		"unknown type":     {Type: "pigeon"},
+		"missing config":   {Type: SinkEmail},
+		"bad url":          {Type: SinkWebhook, Webhook: &WebhookConfig{URL: "ftp://host"}},
+		"bad severity":     {Type: SinkNtfy, Ntfy: &NtfyConfig{URL: "https://ntfy.sh/x"}, Filter: &Filter{MinSeverity: "meh"}},
+		"no smtp port":     {Type: SinkEmail, Email: &EmailConfig{Host: "mail.com", From: "a@b.c", To: []string{"d@e.f"}}},
+		"no email recipts": {Type: SinkEmail, Email: &EmailConfig{Host: "mail.com:25", From: "a@b.c"}},
+	} {
+		if _, err := newSink(cfg); err == nil {
+			t.Fatalf("%s: no error", name)
+		}
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	srv := newTServer(2)
+	defer srv.Close()
+
+	s, err := newSink(&SinkConfig{
+		Type: SinkWebhook,
+		Webhook: &WebhookConfig{
+			URL:     srv.URL,
+			Secret:  "shh",
+			Headers: map[string]string{"X-Extra": "1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newSink error: %v", err)
+	}
+	note := newNote(core.NoteTypeMatch, "SwapRefunded", db.WarningLevel)
+	// The first two attempts fail.
+	s.deliver(context.Background(), newMessage(note), tLogger)
+	srv.wait(t, 1)
+
+	req, body := srv.reqs[0], srv.bodies[0]
+	if sig := req.Header.Get(SignatureHeader); sig != signBody("shh", body) {
+		t.Fatalf("wrong signature %q", sig)
+	}
+	if req.Header.Get("X-Extra") != "1" {
+		t.Fatalf("custom header not set")
+	}
+	var msg Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("error decoding webhook body: %v", err)
+	}
+	if msg.Topic != "SwapRefunded" || msg.Severity != "warning" || msg.Type != core.NoteTypeMatch || !msg.ID.Equal(note.ID()) {
+		t.Fatalf("wrong webhook message %+v", msg)
+	}
+
+	// Retries exhausted.
+	srv.failN = 10
+	s.retries = 1
+	s.deliver(context.Background(), newMessage(note), tLogger)
+	if len(srv.reqs) != 1 || srv.failN != 8 {
+		t.Fatalf("expected 2 failed attempts, %d remaining", srv.failN)
+	}
+}
+
+func TestNtfySink(t *testing.T) {
+	srv := newTServer(0)
+	defer srv.Close()
+
+	s, err := newSink(&SinkConfig{
+		Type: SinkNtfy,
+		Ntfy: &NtfyConfig{URL: srv.URL + "/bison", Token: "tk_abc"},
+	})
+	if err != nil {
+		t.Fatalf("newSink error: %v", err)
+	}
+	s.deliver(context.Background(), newMessage(newNote(core.NoteTypeBot, "BotProblem", db.ErrorLevel)), tLogger)
+	srv.wait(t, 1)
+	req := srv.reqs[0]
+	if req.URL.Path != "/bison" {
+		t.Fatalf("wrong path %q", req.URL.Path)
+	}
+	if req.Header.Get("Title") != "subject BotProblem" || req.Header.Get("Priority") != "5" ||
+		req.Header.Get("Authorization") != "Bearer tk_abc" {
+		t.Fatalf("wrong ntfy headers %v", req.Header)
+	}
+	if string(srv.bodies[0]) != "details\nline 2" {
+		t.Fatalf("wrong ntfy body %q", srv.bodies[0])
+	}
+}
+
+// runSMTPServer runs a minimal stand-in SMTP server that accepts a single
+// message and returns its envelope and data.
+func runSMTPServer(t *testing.T) (addr string, result chan []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	result = make(chan []string, 1)
+	go func() {
+		defer ln.Close()
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { fmt.Fprintf(conn, "%s\r\n", s) }
+		var lines []string
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL", "RCPT":
+				lines = append(lines, line)
+				reply("250 OK")
+			case "DATA":
+				reply("354 go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					l = strings.TrimRight(l, "\r\n")
+					if l == "." {
+						break
+					}
+					lines = append(lines, l)
+				}
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				result <- lines
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return ln.Addr().String(), result
+}
+
+func TestEmailSink(t *testing.T) {
+	addr, result := runSMTPServer(t)
+	s, err := newSink(&SinkConfig{
+		Type: SinkEmail,
+		Email: &EmailConfig{
+			Host: addr,
+			From: "bison@localhost",
+			To:   []string{"me@localhost", "you@localhost"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("newSink error: %v", err)
+	}
+	s.retries = 0
+	s.deliver(context.Background(), newMessage(newNote(core.NoteTypeMatch, "SwapRefunded", db.ErrorLevel)), tLogger)
+
+	select {
+	case lines := <-result:
+		msg := strings.Join(lines, "\n")
+		for _, want := range []string{
+			"MAIL FROM:<bison@localhost>",
+			"RCPT TO:<me@localhost>",
+			"RCPT TO:<you@localhost>",
+			"Subject: [Bison Wallet] subject SwapRefunded",
+			"details\nline 2",
+			"Topic: SwapRefunded",
+		} {
+			if !strings.Contains(msg, want) {
+				t.Fatalf("email missing %q:\n%s", want, msg)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for email")
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	srv := newTServer(0)
+	defer srv.Close()
+
+	c := &tCore{noteC: make(chan core.Notification, 10)}
+	d, err := NewDispatcher(c, &Config{Sinks: []*SinkConfig{{
+		Name:          "hook",
+		Type:          SinkWebhook,
+		Webhook:       &WebhookConfig{URL: srv.URL},
+		RatePerMinute: 2,
+		Filter:        &Filter{Types: []string{core.NoteTypeMatch}},
+	}}}, tLogger)
+	if err != nil {
+		t.Fatalf("NewDispatcher error: %v", err)
+	}
+	if _, err := NewDispatcher(c, &Config{Sinks: []*SinkConfig{{Type: "pigeon"}}}, tLogger); err == nil {
+		t.Fatalf("no error for bad sink")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg, err := d.Connect(ctx)
+	if err != nil {
+		t.Fatalf("Connect error: %v", err)
+	}
+	// Filtered out.
+	c.noteC <- newNote(core.NoteTypeOrder, "OrderPlaced", db.Success)
+	// The third match note exceeds the rate limit.
+	for i := 0; i < 3; i++ {
+		c.noteC <- newNote(core.NoteTypeMatch, db.Topic(fmt.Sprintf("Match%d", i)), db.Success)
+	}
+	srv.wait(t, 2)
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	if len(srv.bodies) != 2 {
+		t.Fatalf("expected 2 deliveries, got %d", len(srv.bodies))
+	}
+	for i, b := range srv.bodies {
+		var msg Message
+		if err := json.Unmarshal(b, &msg); err != nil {
+			t.Fatalf("error decoding message: %v", err)
+		}
+		if msg.Topic != fmt.Sprintf("Match%d", i) {
+			t.Fatalf("wrong message %d topic %q", i, msg.Topic)
+		}
+	}
+}
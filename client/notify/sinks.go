@@ -0,0 +1,210 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/db"
+)
+
+// SignatureHeader is the webhook request header holding the hex-encoded
+// HMAC-SHA256 of the request body, prefixed with "sha256=".
+const SignatureHeader = "X-Bison-Signature-256"
+
+// WebhookConfig is the configuration for an HTTP webhook sink. The Message is
+// POSTed as JSON.
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// Secret is the HMAC-SHA256 key used to sign the request body. The
+	// signature is in the X-Bison-Signature-256 header. Requests are not
+	// signed if Secret is empty.
+	Secret  string            `json:"secret,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// NtfyConfig is the configuration for an ntfy-style push sink. The details
+// are POSTed as the body, with the subject as the title.
+type NtfyConfig struct {
+	// URL is the topic URL, e.g. https://ntfy.sh/mytopic.
+	URL string `json:"url"`
+	// Token is an optional access token.
+	Token string `json:"token,omitempty"`
+}
+
+// EmailConfig is the configuration for an SMTP email sink.
+type EmailConfig struct {
+	// Host is the SMTP server address, with port.
+	Host string `json:"host"`
+	// Username and Password are for PLAIN authentication. Authentication is
+	// skipped if Username is empty.
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+func checkHTTPURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid url %q: %w", s, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url %q is not http or https", s)
+	}
+	return nil
+}
+
+// postRequest sends the request and checks the response status.
+func postRequest(req *http.Request) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	return nil
+}
+
+type webhookSender struct {
+	cfg *WebhookConfig
+}
+
+func newWebhookSender(cfg *WebhookConfig) (*webhookSender, error) {
+	if err := checkHTTPURL(cfg.URL); err != nil {
+		return nil, err
+	}
+	return &webhookSender{cfg: cfg}, nil
+}
+
+// signBody computes the webhook signature for the body.
+func signBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *webhookSender) send(ctx context.Context, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	if w.cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, signBody(w.cfg.Secret, body))
+	}
+	return postRequest(req)
+}
+
+type ntfySender struct {
+	cfg *NtfyConfig
+}
+
+func newNtfySender(cfg *NtfyConfig) (*ntfySender, error) {
+	if err := checkHTTPURL(cfg.URL); err != nil {
+		return nil, err
+	}
+	return &ntfySender{cfg: cfg}, nil
+}
+
+// ntfyPriority maps the severity to an ntfy priority, 1 - 5.
+func ntfyPriority(sev db.Severity) int {
+	switch sev {
+	case db.ErrorLevel:
+		return 5
+	case db.WarningLevel:
+		return 4
+	case db.Success:
+		return 3
+	}
+	return 2
+}
+
+func (n *ntfySender) send(ctx context.Context, msg *Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.URL, strings.NewReader(msg.Details))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", msg.Subject)
+	req.Header.Set("Priority", strconv.Itoa(ntfyPriority(msg.severity)))
+	req.Header.Set("Tags", msg.Severity+","+msg.Type)
+	if n.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.cfg.Token)
+	}
+	return postRequest(req)
+}
+
+type emailSender struct {
+	cfg *EmailConfig
+}
+
+func newEmailSender(cfg *EmailConfig) (*emailSender, error) {
+	if _, _, err := net.SplitHostPort(cfg.Host); err != nil {
+		return nil, fmt.Errorf("invalid SMTP host %q: %w", cfg.Host, err)
+	}
+	if cfg.From == "" || len(cfg.To) == 0 {
+		return nil, errors.New("email sender and recipients are required")
+	}
+	return &emailSender{cfg: cfg}, nil
+}
+
+// emailBody formats the message as an RFC 5322 email.
+func (e *emailSender) emailBody(msg *Message) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", e.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.cfg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "[Bison Wallet] "+msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.UnixMilli(int64(msg.Stamp)).Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Details, "\n", "\r\n"))
+	fmt.Fprintf(&b, "\r\n\r\nType: %s\r\nTopic: %s\r\nSeverity: %s\r\n", msg.Type, msg.Topic, msg.Severity)
+	return b.Bytes()
+}
+
+func (e *emailSender) send(ctx context.Context, msg *Message) error {
+	var auth smtp.Auth
+	if e.cfg.Username != "" {
+		host, _, _ := net.SplitHostPort(e.cfg.Host)
+		auth = smtp.PlainAuth("", e.cfg.Username, e.cfg.Password, host)
+	}
+	// smtp.SendMail does not take a context, so the send is abandoned, not
+	// canceled, when the context is done.
+	errC := make(chan error, 1)
+	go func() {
+		errC <- smtp.SendMail(e.cfg.Host, auth, e.cfg.From, e.cfg.To, e.emailBody(msg))
+	}()
+	select {
+	case err := <-errC:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
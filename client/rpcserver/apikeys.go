@@ -0,0 +1,230 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"decred.org/dcrdex/client/apikey"
+	"decred.org/dcrdex/dex/msgjson"
+)
+
+// wsRoute is the route recorded in the audit log for websocket connections.
+const wsRoute = "ws"
+
+// apiKeyManager manages the API keys and the audit log. It is satisfied by
+// *apikey.Store.
+type apiKeyManager interface {
+	Authenticate(name, secret string) (*apikey.Key, bool)
+	Create(form *apikey.KeyForm) (*apikey.Key, string, error)
+	Revoke(name string) error
+	Keys() []*apikey.Key
+	Audit(entry *apikey.AuditEntry) error
+	AuditLog(n int) ([]*apikey.AuditEntry, error)
+}
+
+var _ apiKeyManager = (*apikey.Store)(nil)
+
+// routePermissions is the permission an API key needs for each route. Routes
+// that are not listed, e.g. those that create wallets, reveal or restore the
+// seed or manage API keys, are only available with the RPC user and password.
+var routePermissions = map[string]apikey.Permission{
+	exchangesRoute:             apikey.PermRead,
+	helpRoute:                  apikey.PermRead,
+	myOrdersRoute:              apikey.PermRead,
+	orderBookRoute:             apikey.PermRead,
+	getDEXConfRoute:            apikey.PermRead,
+	bondAssetsRoute:            apikey.PermRead,
+	versionRoute:               apikey.PermRead,
+	walletsRoute:               apikey.PermRead,
+	walletPeersRoute:           apikey.PermRead,
+	notificationsRoute:         apikey.PermRead,
+	mmAvailableBalancesRoute:   apikey.PermRead,
+	mmStatusRoute:              apikey.PermRead,
+	stakeStatusRoute:           apikey.PermRead,
+	txHistoryRoute:             apikey.PermRead,
+	walletTxRoute:              apikey.PermRead,
+	checkBridgeApprovalRoute:   apikey.PermRead,
+	pendingBridgesRoute:        apikey.PermRead,
+	bridgeHistoryRoute:         apikey.PermRead,
+	sendMultiFeeRoute:          apikey.PermRead,
+	conditionalOrdersRoute:     apikey.PermRead,
+	aggregateBookRoute:         apikey.PermRead,
+	planRouteRoute:             apikey.PermRead,
+	routedOrdersRoute:          apikey.PermRead,
+	meshBondsRoute:             apikey.PermRead,
+	ledgerRoute:                apikey.PermRead,
+	alertsRoute:                apikey.PermRead,
+	profilesRoute:              apikey.PermRead,
+	wsRoute:                    apikey.PermRead,
+	cancelRoute:                apikey.PermTrade,
+	tradeRoute:                 apikey.PermTrade,
+	multiTradeRoute:            apikey.PermTrade,
+	conditionalOrderRoute:      apikey.PermTrade,
+	cancelConditionalRoute:     apikey.PermTrade,
+	routedTradeRoute:           apikey.PermTrade,
+	addAlertRoute:              apikey.PermTrade,
+	removeAlertRoute:           apikey.PermTrade,
+	startBotRoute:              apikey.PermBots,
+	stopBotRoute:               apikey.PermBots,
+	updateRunningBotCfgRoute:   apikey.PermBots,
+	updateRunningBotInvRoute:   apikey.PermBots,
+	withdrawRoute:              apikey.PermFunds,
+	sendRoute:                  apikey.PermFunds,
+	sendPSBTRoute:              apikey.PermFunds,
+	withdrawPSBTRoute:          apikey.PermFunds,
+	broadcastPSBTRoute:         apikey.PermFunds,
+	abandonPSBTRoute:           apikey.PermFunds,
+	refundBondPSBTRoute:        apikey.PermFunds,
+	sendMultiRoute:             apikey.PermFunds,
+	bridgeRoute:                apikey.PermFunds,
+	approveBridgeContractRoute: apikey.PermFunds,
+	postBondRoute:              apikey.PermFunds,
+	postBondPSBTRoute:          apikey.PermFunds,
+	postMeshBondRoute:          apikey.PermFunds,
+	purchaseTicketsRoute:       apikey.PermFunds,
+	withdrawBchSpvRoute:        apikey.PermFunds,
+}
+
+type ctxKey int
+
+const (
+	// ctxAPIKey is the request context key for the *apikey.Key used to
+	// authenticate. It is not set for requests authenticated with the RPC
+	// user and password.
+	ctxAPIKey ctxKey = iota
+	// ctxPeerAddr is the request context key for the address of the
+	// connection, before middleware.RealIP replaces it with a header value.
+	ctxPeerAddr
+)
+
+// peerAddr records the address of the connection in the request context.
+// IP allowlists are checked against this address, since the headers read by
+// middleware.RealIP are set by the client.
+func peerAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxPeerAddr, r.RemoteAddr)))
+	})
+}
+
+// requestAPIKey is the API key used to authenticate the request, or nil for
+// the RPC user.
+func requestAPIKey(r *http.Request) *apikey.Key {
+	k, _ := r.Context().Value(ctxAPIKey).(*apikey.Key)
+	return k
+}
+
+// withAPIKey sets the API key in the request context.
+func withAPIKey(r *http.Request, k *apikey.Key) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), ctxAPIKey, k))
+}
+
+// remoteAddr is the address of the connection, which unlike r.RemoteAddr is
+// not taken from the headers read by middleware.RealIP.
+func remoteAddr(r *http.Request) string {
+	if addr, ok := r.Context().Value(ctxPeerAddr).(string); ok {
+		return addr
+	}
+	return r.RemoteAddr
+}
+
+// remoteIP parses the IP address of the connection from the request.
+func remoteIP(r *http.Request) net.IP {
+	return apikey.ParseIP(remoteAddr(r))
+}
+
+// authorize returns an error payload if the API key is not permitted to make
+// the request. A nil key is the RPC user, which may make any request.
+func authorize(k *apikey.Key, req *msgjson.Message) *msgjson.ResponsePayload {
+	if k == nil {
+		return nil
+	}
+	err := k.Authorize(routePermissions, req.Route, func() ([]string, error) {
+		params := new(RawParams)
+		if err := req.Unmarshal(params); err != nil {
+			// handleRequest will fail too.
+			return nil, nil
+		}
+		return withdrawAddresses(req.Route, params)
+	})
+	if err != nil {
+		return &msgjson.ResponsePayload{Error: msgjson.NewError(msgjson.RPCPermissionError, "%v", err)}
+	}
+	return nil
+}
+
+// withdrawAddresses returns the addresses that a funds request pays. Requests
+// that do not pay an external address return no addresses. Arguments that
+// cannot be parsed return no addresses, since the handler rejects them.
+func withdrawAddresses(route string, params *RawParams) ([]string, error) {
+	switch route {
+	case sendRoute, withdrawRoute:
+		form, err := parseSendOrWithdrawArgs(params)
+		if err != nil {
+			return nil, nil
+		}
+		return []string{form.address}, nil
+	case sendPSBTRoute, withdrawPSBTRoute:
+		form, err := parseSendPSBTArgs(params)
+		if err != nil {
+			return nil, nil
+		}
+		return []string{form.address}, nil
+	case sendMultiRoute:
+		form, err := parseSendMultiArgs(params, 1)
+		if err != nil {
+			return nil, nil
+		}
+		addrs := make([]string, 0, len(form.payments))
+		for _, p := range form.payments {
+			addrs = append(addrs, p.Address)
+		}
+		return addrs, nil
+	case withdrawBchSpvRoute:
+		_, recipient, err := parseBchWithdrawArgs(params)
+		if err != nil {
+			return nil, nil
+		}
+		return []string{recipient}, nil
+	case broadcastPSBTRoute:
+		// The outputs of a signed PSBT are not checked.
+		return nil, errors.New("cannot broadcast a PSBT with a withdrawal address allowlist")
+	}
+	return nil, nil
+}
+
+// audit records an authenticated call in the audit log, if API keys are
+// managed.
+func (s *RPCServer) audit(k *apikey.Key, r *http.Request, route string, allowed bool, resErr *msgjson.Error) {
+	if s.apiKeys == nil {
+		return
+	}
+	entry := &apikey.AuditEntry{
+		Stamp:   time.Now(),
+		IP:      remoteAddr(r),
+		Route:   route,
+		Allowed: allowed,
+	}
+	if k != nil {
+		entry.Key = k.Name
+	}
+	if resErr != nil {
+		entry.Error = resErr.Message
+	}
+	if err := s.apiKeys.Audit(entry); err != nil {
+		log.Errorf("error writing audit log entry: %v", err)
+	}
+}
+
+// denyHTTP audits and rejects a request that is not tied to an RPC route.
+func (s *RPCServer) denyHTTP(w http.ResponseWriter, r *http.Request, k *apikey.Key, route, reason string) {
+	log.Warnf("api key %q denied %s from ip %s: %s", k.Name, route, remoteAddr(r), reason)
+	s.audit(k, r, route, false, msgjson.NewError(msgjson.RPCPermissionError, "%s", reason))
+	http.Error(w, fmt.Sprintf("%s: %s", http.StatusText(http.StatusForbidden), reason), http.StatusForbidden)
+}
@@ -4,6 +4,7 @@
 package rpcserver
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -38,6 +39,8 @@ const (
 	getDEXConfRoute            = "getdexconfig"
 	bondAssetsRoute            = "bondassets"
 	postBondRoute              = "postbond"
+	postBondPSBTRoute          = "postbondpsbt"
+	refundBondPSBTRoute        = "refundbondpsbt"
 	bondOptionsRoute           = "bondopts"
 	tradeRoute                 = "trade"
 	versionRoute               = "version"
@@ -70,6 +73,34 @@ const (
 	approveBridgeContractRoute = "approvebridgecontract"
 	pendingBridgesRoute        = "pendingbridges"
 	bridgeHistoryRoute         = "bridgehistory"
+	sendPSBTRoute              = "sendpsbt"
+	withdrawPSBTRoute          = "withdrawpsbt"
+	broadcastPSBTRoute         = "broadcastpsbt"
+	abandonPSBTRoute           = "abandonpsbt"
+	sendMultiRoute             = "sendmulti"
+	sendMultiFeeRoute          = "sendmultifee"
+	conditionalOrderRoute      = "conditionalorder"
+	conditionalOrdersRoute     = "conditionalorders"
+	cancelConditionalRoute     = "cancelconditionalorder"
+	aggregateBookRoute         = "aggregatebook"
+	planRouteRoute             = "planroute"
+	routedTradeRoute           = "routedtrade"
+	routedOrdersRoute          = "routedorders"
+	postMeshBondRoute          = "postmeshbond"
+	meshBondsRoute             = "meshbonds"
+	ledgerRoute                = "ledger"
+	profilesRoute              = "profiles"
+	switchProfileRoute         = "switchprofile"
+	createProfileRoute         = "createprofile"
+	addAlertRoute              = "addalert"
+	alertsRoute                = "alerts"
+	removeAlertRoute           = "removealert"
+	apiKeysRoute               = "apikeys"
+	newAPIKeyRoute             = "newapikey"
+	revokeAPIKeyRoute          = "revokeapikey"
+	apiAuditLogRoute           = "apiauditlog"
+	exportAppStateRoute        = "exportappstate"
+	restoreAppStateRoute       = "restoreappstate"
 )
 
 const (
@@ -78,10 +109,14 @@ const (
 	walletLockedStr   = "%s wallet locked"
 	walletUnlockedStr = "%s wallet unlocked"
 	canceledOrderStr  = "canceled order %s"
+	canceledCondStr   = "canceled conditional order %s"
 	logoutStr         = "goodbye"
 	walletStatusStr   = "%s wallet has been %s"
 	setVotePrefsStr   = "vote preferences set"
 	setVSPStr         = "vsp set to %s"
+	switchProfileStr  = "switching to profile %s. the server will restart"
+	removedAlertStr   = "removed market alert %s"
+	revokedAPIKeyStr  = "revoked api key %s"
 )
 
 // createResponse creates a msgjson response payload.
@@ -119,6 +154,8 @@ var routes = map[string]func(s *RPCServer, params *RawParams) *msgjson.ResponseP
 	orderBookRoute:             handleOrderBook,
 	getDEXConfRoute:            handleGetDEXConfig,
 	postBondRoute:              handlePostBond,
+	postBondPSBTRoute:          handlePostBondPSBT,
+	refundBondPSBTRoute:        handleRefundBondPSBT,
 	bondOptionsRoute:           handleBondOptions,
 	bondAssetsRoute:            handleBondAssets,
 	tradeRoute:                 handleTrade,
@@ -152,6 +189,34 @@ var routes = map[string]func(s *RPCServer, params *RawParams) *msgjson.ResponseP
 	approveBridgeContractRoute: handleApproveBridge,
 	pendingBridgesRoute:        handlePendingBridges,
 	bridgeHistoryRoute:         handleBridgeHistory,
+	sendPSBTRoute:              handleSendPSBT,
+	withdrawPSBTRoute:          handleWithdrawPSBT,
+	broadcastPSBTRoute:         handleBroadcastPSBT,
+	abandonPSBTRoute:           handleAbandonPSBT,
+	sendMultiRoute:             handleSendMulti,
+	sendMultiFeeRoute:          handleSendMultiFee,
+	conditionalOrderRoute:      handleConditionalOrder,
+	conditionalOrdersRoute:     handleConditionalOrders,
+	cancelConditionalRoute:     handleCancelConditionalOrder,
+	aggregateBookRoute:         handleAggregateBook,
+	planRouteRoute:             handlePlanRoute,
+	routedTradeRoute:           handleRoutedTrade,
+	routedOrdersRoute:          handleRoutedOrders,
+	postMeshBondRoute:          handlePostMeshBond,
+	meshBondsRoute:             handleMeshBonds,
+	ledgerRoute:                handleLedger,
+	profilesRoute:              handleProfiles,
+	switchProfileRoute:         handleSwitchProfile,
+	createProfileRoute:         handleCreateProfile,
+	addAlertRoute:              handleAddAlert,
+	alertsRoute:                handleAlerts,
+	removeAlertRoute:           handleRemoveAlert,
+	apiKeysRoute:               handleAPIKeys,
+	newAPIKeyRoute:             handleNewAPIKey,
+	revokeAPIKeyRoute:          handleRevokeAPIKey,
+	apiAuditLogRoute:           handleAPIAuditLog,
+	exportAppStateRoute:        handleExportAppState,
+	restoreAppStateRoute:       handleRestoreAppState,
 }
 
 // handleHelp handles requests for help. Returns general help for all commands
@@ -399,11 +464,23 @@ func handleBondOptions(s *RPCServer, params *RawParams) *msgjson.ResponsePayload
 // handlePostBond handles requests for postbond. *msgjson.ResponsePayload.Error
 // is empty if successful.
 func handlePostBond(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	return postBond(s, params, postBondRoute)
+}
+
+// handlePostBondPSBT handles requests for postbondpsbt. The bond's funding
+// inputs are left unsigned, and the result includes the base64-encoded PSBT.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handlePostBondPSBT(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	return postBond(s, params, postBondPSBTRoute)
+}
+
+func postBond(s *RPCServer, params *RawParams, route string) *msgjson.ResponsePayload {
 	form, err := parsePostBondArgs(params)
 	if err != nil {
-		return usage(postBondRoute, err)
+		return usage(route, err)
 	}
 	defer form.AppPass.Clear()
+	form.PSBT = route == postBondPSBTRoute
 	// Get the exchange config with Exchanges(), not GetDEXConfig, since we may
 	// already be connected and even with an existing account.
 	exchInf := s.core.Exchanges()
@@ -413,7 +490,7 @@ func handlePostBond(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
 		exchCfg, err = s.core.GetDEXConfig(form.Addr, form.Cert)
 		if err != nil {
 			resErr := &msgjson.Error{Code: msgjson.RPCGetDEXConfigError, Message: err.Error()}
-			return createResponse(postBondRoute, nil, resErr)
+			return createResponse(route, nil, resErr)
 		}
 	}
 	// Registration with different assets will be supported in the future, but
@@ -427,22 +504,22 @@ func handlePostBond(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
 	bondAsset, supported := exchCfg.BondAssets[symb]
 	if !supported {
 		resErr := msgjson.NewError(msgjson.RPCPostBondError, "DEX %s does not support registration with %s", form.Addr, symb)
-		return createResponse(postBondRoute, nil, resErr)
+		return createResponse(route, nil, resErr)
 	}
 	if bondAsset.Amt > form.Bond || form.Bond%bondAsset.Amt != 0 {
 		resErr := msgjson.NewError(msgjson.RPCPostBondError, "DEX at %s expects a bond amount in multiples of %d %s but %d was offered",
 			form.Addr, bondAsset.Amt, dex.BipIDSymbol(assetID), form.Bond)
-		return createResponse(postBondRoute, nil, resErr)
+		return createResponse(route, nil, resErr)
 	}
 	res, err := s.core.PostBond(form)
 	if err != nil {
 		resErr := &msgjson.Error{Code: msgjson.RPCPostBondError, Message: err.Error()}
-		return createResponse(postBondRoute, nil, resErr)
+		return createResponse(route, nil, resErr)
 	}
 	if res.BondID == "" {
-		return createResponse(postBondRoute, "existing account configured - no bond posted", nil)
+		return createResponse(route, "existing account configured - no bond posted", nil)
 	}
-	return createResponse(postBondRoute, res, nil)
+	return createResponse(route, res, nil)
 }
 
 // handleExchanges handles requests for exchanges. It takes no arguments and
@@ -569,6 +646,343 @@ func handleCancel(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
 	return createResponse(cancelRoute, &res, nil)
 }
 
+// handleConditionalOrder handles requests for conditionalorder. The result is
+// the created conditional orders. *msgjson.ResponsePayload.Error is empty if
+// successful.
+func handleConditionalOrder(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseConditionalOrderArgs(params)
+	if err != nil {
+		return usage(conditionalOrderRoute, err)
+	}
+	defer form.appPass.Clear()
+	ords, err := s.core.PlaceConditionalOrder(form.appPass, form.srvForm)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCConditionalOrderError, "unable to place conditional order: %v", err)
+		return createResponse(conditionalOrderRoute, nil, resErr)
+	}
+	return createResponse(conditionalOrderRoute, ords, nil)
+}
+
+// handleConditionalOrders handles requests for conditionalorders.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleConditionalOrders(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{0}); err != nil {
+		return usage(conditionalOrdersRoute, err)
+	}
+	ords, err := s.core.ConditionalOrders()
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCConditionalOrderError, "unable to retrieve conditional orders: %v", err)
+		return createResponse(conditionalOrdersRoute, nil, resErr)
+	}
+	return createResponse(conditionalOrdersRoute, ords, nil)
+}
+
+// handleCancelConditionalOrder handles requests for cancelconditionalorder.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleCancelConditionalOrder(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	id, err := parseCancelConditionalArgs(params)
+	if err != nil {
+		return usage(cancelConditionalRoute, err)
+	}
+	if err := s.core.CancelConditionalOrder(id); err != nil {
+		resErr := msgjson.NewError(msgjson.RPCConditionalOrderError, "unable to cancel conditional order %s: %v", id, err)
+		return createResponse(cancelConditionalRoute, nil, resErr)
+	}
+	res := fmt.Sprintf(canceledCondStr, id)
+	return createResponse(cancelConditionalRoute, &res, nil)
+}
+
+// handlePostMeshBond handles requests for postmeshbond.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handlePostMeshBond(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parsePostMeshBondArgs(params)
+	if err != nil {
+		return usage(postMeshBondRoute, err)
+	}
+	defer form.appPass.Clear()
+	bond, err := s.core.PostMeshBond(form.appPass, form.srvForm)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCMeshBondError, "unable to post mesh bond: %v", err)
+		return createResponse(postMeshBondRoute, nil, resErr)
+	}
+	return createResponse(postMeshBondRoute, bond, nil)
+}
+
+// handleMeshBonds handles requests for meshbonds.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleMeshBonds(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{0}); err != nil {
+		return usage(meshBondsRoute, err)
+	}
+	bonds, err := s.core.MeshBonds()
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCMeshBondError, "unable to retrieve mesh bonds: %v", err)
+		return createResponse(meshBondsRoute, nil, resErr)
+	}
+	return createResponse(meshBondsRoute, bonds, nil)
+}
+
+// handleAddAlert handles requests for addalert. The result is the created
+// market alert. *msgjson.ResponsePayload.Error is empty if successful.
+func handleAddAlert(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseAddAlertArgs(params)
+	if err != nil {
+		return usage(addAlertRoute, err)
+	}
+	alert, err := s.core.AddMarketAlert(form)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCMarketAlertError, "unable to add market alert: %v", err)
+		return createResponse(addAlertRoute, nil, resErr)
+	}
+	return createResponse(addAlertRoute, alert, nil)
+}
+
+// handleAlerts handles requests for alerts. *msgjson.ResponsePayload.Error is
+// empty if successful.
+func handleAlerts(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{0}); err != nil {
+		return usage(alertsRoute, err)
+	}
+	alerts, err := s.core.MarketAlerts()
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCMarketAlertError, "unable to retrieve market alerts: %v", err)
+		return createResponse(alertsRoute, nil, resErr)
+	}
+	return createResponse(alertsRoute, alerts, nil)
+}
+
+// handleRemoveAlert handles requests for removealert.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleRemoveAlert(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	id, err := parseRemoveAlertArgs(params)
+	if err != nil {
+		return usage(removeAlertRoute, err)
+	}
+	if err := s.core.RemoveMarketAlert(id); err != nil {
+		resErr := msgjson.NewError(msgjson.RPCMarketAlertError, "unable to remove market alert %s: %v", id, err)
+		return createResponse(removeAlertRoute, nil, resErr)
+	}
+	res := fmt.Sprintf(removedAlertStr, id)
+	return createResponse(removeAlertRoute, &res, nil)
+}
+
+// handleAggregateBook handles requests for aggregatebook.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleAggregateBook(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseAggregateBookArgs(params)
+	if err != nil {
+		return usage(aggregateBookRoute, err)
+	}
+	book, err := s.core.AggregatedBook(form.base, form.quote, form.hosts)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCRoutedOrderError, "unable to build aggregated book: %v", err)
+		return createResponse(aggregateBookRoute, nil, resErr)
+	}
+	return createResponse(aggregateBookRoute, book, nil)
+}
+
+// handlePlanRoute handles requests for planroute.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handlePlanRoute(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseRoutedTradeArgs(params, false)
+	if err != nil {
+		return usage(planRouteRoute, err)
+	}
+	plan, err := s.core.PlanRoute(form.srvForm)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCRoutedOrderError, "unable to plan route: %v", err)
+		return createResponse(planRouteRoute, nil, resErr)
+	}
+	return createResponse(planRouteRoute, plan, nil)
+}
+
+// handleRoutedTrade handles requests for routedtrade.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleRoutedTrade(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseRoutedTradeArgs(params, true)
+	if err != nil {
+		return usage(routedTradeRoute, err)
+	}
+	defer form.appPass.Clear()
+	ord, err := s.core.RoutedTrade(form.appPass, form.srvForm)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCRoutedOrderError, "unable to place routed trade: %v", err)
+		return createResponse(routedTradeRoute, nil, resErr)
+	}
+	return createResponse(routedTradeRoute, ord, nil)
+}
+
+// handleRoutedOrders handles requests for routedorders.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleRoutedOrders(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{0}); err != nil {
+		return usage(routedOrdersRoute, err)
+	}
+	ords, err := s.core.RoutedOrders()
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCRoutedOrderError, "unable to retrieve routed orders: %v", err)
+		return createResponse(routedOrdersRoute, nil, resErr)
+	}
+	return createResponse(routedOrdersRoute, ords, nil)
+}
+
+// handleLedger handles requests for ledger. *msgjson.ResponsePayload.Error is
+// empty if successful.
+func handleLedger(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseLedgerArgs(params)
+	if err != nil {
+		return usage(ledgerRoute, err)
+	}
+	if s.mm != nil {
+		cexEvents, err := s.mm.LedgerEvents()
+		if err != nil {
+			resErr := msgjson.NewError(msgjson.RPCLedgerError, "unable to retrieve market making events: %v", err)
+			return createResponse(ledgerRoute, nil, resErr)
+		}
+		form.srvForm.ExtraEvents = cexEvents
+	}
+	ledger, err := s.core.Ledger(form.srvForm)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCLedgerError, "unable to generate ledger: %v", err)
+		return createResponse(ledgerRoute, nil, resErr)
+	}
+	if form.format == "" {
+		return createResponse(ledgerRoute, ledger, nil)
+	}
+	var buf strings.Builder
+	if err := ledger.WriteCSV(&buf, form.format); err != nil {
+		resErr := msgjson.NewError(msgjson.RPCLedgerError, "unable to write ledger CSV: %v", err)
+		return createResponse(ledgerRoute, nil, resErr)
+	}
+	return createResponse(ledgerRoute, buf.String(), nil)
+}
+
+// handleProfiles handles requests for profiles. *msgjson.ResponsePayload.Error
+// is empty if successful.
+func handleProfiles(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{0}); err != nil {
+		return usage(profilesRoute, err)
+	}
+	res := &profilesResponse{Active: defaultProfile, Profiles: []string{defaultProfile}}
+	if s.profiles != nil {
+		var err error
+		res.Active, res.Profiles, err = s.profiles.Profiles()
+		if err != nil {
+			resErr := msgjson.NewError(msgjson.RPCProfileError, "unable to list profiles: %v", err)
+			return createResponse(profilesRoute, nil, resErr)
+		}
+	}
+	return createResponse(profilesRoute, res, nil)
+}
+
+// handleSwitchProfile handles requests for switchprofile.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleSwitchProfile(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{1}); err != nil {
+		return usage(switchProfileRoute, err)
+	}
+	if s.profiles == nil {
+		resErr := msgjson.NewError(msgjson.RPCProfileError, "profiles are not supported")
+		return createResponse(switchProfileRoute, nil, resErr)
+	}
+	profile := params.Args[0]
+	if err := s.profiles.SwitchProfile(profile); err != nil {
+		resErr := msgjson.NewError(msgjson.RPCProfileError, "unable to switch profile: %v", err)
+		return createResponse(switchProfileRoute, nil, resErr)
+	}
+	return createResponse(switchProfileRoute, fmt.Sprintf(switchProfileStr, profile), nil)
+}
+
+// handleCreateProfile handles requests for createprofile.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleCreateProfile(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{1}); err != nil {
+		return usage(createProfileRoute, err)
+	}
+	if s.profiles == nil {
+		resErr := msgjson.NewError(msgjson.RPCProfileError, "profiles are not supported")
+		return createResponse(createProfileRoute, nil, resErr)
+	}
+	profile := params.Args[0]
+	if err := s.profiles.CreateProfile(profile); err != nil {
+		resErr := msgjson.NewError(msgjson.RPCProfileError, "unable to create profile: %v", err)
+		return createResponse(createProfileRoute, nil, resErr)
+	}
+	return createResponse(createProfileRoute, fmt.Sprintf(switchProfileStr, profile), nil)
+}
+
+// apiKeysUnsupported is the error payload for API key requests when API keys
+// are not managed.
+func apiKeysUnsupported(route string) *msgjson.ResponsePayload {
+	resErr := msgjson.NewError(msgjson.RPCAPIKeyError, "api keys are not supported")
+	return createResponse(route, nil, resErr)
+}
+
+// handleAPIKeys handles requests for apikeys. *msgjson.ResponsePayload.Error
+// is empty if successful.
+func handleAPIKeys(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{0}); err != nil {
+		return usage(apiKeysRoute, err)
+	}
+	if s.apiKeys == nil {
+		return apiKeysUnsupported(apiKeysRoute)
+	}
+	return createResponse(apiKeysRoute, s.apiKeys.Keys(), nil)
+}
+
+// handleNewAPIKey handles requests for newapikey.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleNewAPIKey(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseNewAPIKeyArgs(params)
+	if err != nil {
+		return usage(newAPIKeyRoute, err)
+	}
+	if s.apiKeys == nil {
+		return apiKeysUnsupported(newAPIKeyRoute)
+	}
+	k, secret, err := s.apiKeys.Create(form)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCAPIKeyError, "unable to create api key: %v", err)
+		return createResponse(newAPIKeyRoute, nil, resErr)
+	}
+	return createResponse(newAPIKeyRoute, &newAPIKeyResponse{Key: k, Secret: secret}, nil)
+}
+
+// handleRevokeAPIKey handles requests for revokeapikey.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleRevokeAPIKey(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	if err := checkNArgs(params, []int{0}, []int{1}); err != nil {
+		return usage(revokeAPIKeyRoute, err)
+	}
+	if s.apiKeys == nil {
+		return apiKeysUnsupported(revokeAPIKeyRoute)
+	}
+	name := params.Args[0]
+	if err := s.apiKeys.Revoke(name); err != nil {
+		resErr := msgjson.NewError(msgjson.RPCAPIKeyError, "unable to revoke api key %s: %v", name, err)
+		return createResponse(revokeAPIKeyRoute, nil, resErr)
+	}
+	return createResponse(revokeAPIKeyRoute, fmt.Sprintf(revokedAPIKeyStr, name), nil)
+}
+
+// handleAPIAuditLog handles requests for apiauditlog.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleAPIAuditLog(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	n, err := parseAPIAuditLogArgs(params)
+	if err != nil {
+		return usage(apiAuditLogRoute, err)
+	}
+	if s.apiKeys == nil {
+		return apiKeysUnsupported(apiAuditLogRoute)
+	}
+	entries, err := s.apiKeys.AuditLog(n)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCAPIKeyError, "unable to read audit log: %v", err)
+		return createResponse(apiAuditLogRoute, nil, resErr)
+	}
+	return createResponse(apiAuditLogRoute, entries, nil)
+}
+
 // handleWithdraw handles requests for withdraw. *msgjson.ResponsePayload.Error
 // is empty if successful.
 func handleWithdraw(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
@@ -604,6 +1018,121 @@ func send(s *RPCServer, params *RawParams, route string) *msgjson.ResponsePayloa
 	return createResponse(route, &res, nil)
 }
 
+// handleSendPSBT handles the request for sendpsbt. The result is an unsigned
+// base64-encoded PSBT. *msgjson.ResponsePayload.Error is empty if successful.
+func handleSendPSBT(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	return sendPSBT(s, params, sendPSBTRoute)
+}
+
+// handleWithdrawPSBT handles the request for withdrawpsbt. The result is an
+// unsigned base64-encoded PSBT. *msgjson.ResponsePayload.Error is empty if
+// successful.
+func handleWithdrawPSBT(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	return sendPSBT(s, params, withdrawPSBTRoute)
+}
+
+func sendPSBT(s *RPCServer, params *RawParams, route string) *msgjson.ResponsePayload {
+	form, err := parseSendPSBTArgs(params)
+	if err != nil {
+		return usage(route, err)
+	}
+	subtract := route == withdrawPSBTRoute
+	b64, err := s.core.SendPSBT(form.assetID, form.value, form.address, subtract)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCPSBTError, "unable to create PSBT: %v", err)
+		return createResponse(route, nil, resErr)
+	}
+	return createResponse(route, &b64, nil)
+}
+
+// handleAbandonPSBT handles the request for abandonpsbt.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleAbandonPSBT(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	assetID, unsignedPSBT, err := parsePSBTArgs(params)
+	if err != nil {
+		return usage(abandonPSBTRoute, err)
+	}
+	txID, err := s.core.AbandonPSBT(assetID, unsignedPSBT)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCPSBTError, "unable to abandon PSBT: %v", err)
+		return createResponse(abandonPSBTRoute, nil, resErr)
+	}
+	return createResponse(abandonPSBTRoute, &txID, nil)
+}
+
+// handleRefundBondPSBT handles the request for refundbondpsbt. The result is
+// an unsigned base64-encoded PSBT. *msgjson.ResponsePayload.Error is empty if
+// successful.
+func handleRefundBondPSBT(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseRefundBondPSBTArgs(params)
+	if err != nil {
+		return usage(refundBondPSBTRoute, err)
+	}
+	b64, err := s.core.RefundBondPSBT(form.host, form.assetID, form.bondID)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCPSBTError, "unable to create bond refund PSBT: %v", err)
+		return createResponse(refundBondPSBTRoute, nil, resErr)
+	}
+	return createResponse(refundBondPSBTRoute, &b64, nil)
+}
+
+// handleBroadcastPSBT handles the request for broadcastpsbt.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleBroadcastPSBT(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	assetID, signedPSBT, err := parsePSBTArgs(params)
+	if err != nil {
+		return usage(broadcastPSBTRoute, err)
+	}
+	txID, err := s.core.BroadcastPSBT(assetID, signedPSBT)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCPSBTError, "unable to broadcast PSBT: %v", err)
+		return createResponse(broadcastPSBTRoute, nil, resErr)
+	}
+	return createResponse(broadcastPSBTRoute, &txID, nil)
+}
+
+// handleSendMulti handles the request for sendmulti. The result is a list of
+// the coin IDs created for the payments. *msgjson.ResponsePayload.Error is
+// empty if successful.
+func handleSendMulti(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseSendMultiArgs(params, 1)
+	if err != nil {
+		return usage(sendMultiRoute, err)
+	}
+	defer form.appPass.Clear()
+	if len(form.appPass) == 0 {
+		resErr := msgjson.NewError(msgjson.RPCFundTransferError, "empty pass")
+		return createResponse(sendMultiRoute, nil, resErr)
+	}
+	coins, err := s.core.SendMulti(form.appPass, form.assetID, form.payments)
+	res := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		res = append(res, coin.String())
+	}
+	if err != nil {
+		// Some payments may have been sent before the error.
+		resErr := msgjson.NewError(msgjson.RPCFundTransferError, "unable to send: %v (sent coins: %v)", err, res)
+		return createResponse(sendMultiRoute, nil, resErr)
+	}
+	return createResponse(sendMultiRoute, res, nil)
+}
+
+// handleSendMultiFee handles the request for sendmultifee. The result is the
+// estimated fees for a sendmulti request with the same payments.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleSendMultiFee(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseSendMultiArgs(params, 0)
+	if err != nil {
+		return usage(sendMultiFeeRoute, err)
+	}
+	fee, err := s.core.EstimateSendMultiTxFee(form.assetID, form.payments)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCFundTransferError, "unable to estimate fees: %v", err)
+		return createResponse(sendMultiFeeRoute, nil, resErr)
+	}
+	return createResponse(sendMultiFeeRoute, fee, nil)
+}
+
 // handleRescanWallet handles requests to rescan a wallet. This may trigger an
 // asynchronous resynchronization of wallet address activity, and the wallet
 // state should be consulted for status. *msgjson.ResponsePayload.Error is empty
@@ -787,6 +1316,59 @@ func handleAppSeed(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
 	return createResponse(appSeedRoute, seed, nil)
 }
 
+// handleExportAppState handles requests for exportappstate.
+// *msgjson.ResponsePayload.Error is empty if successful.
+func handleExportAppState(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseExportAppStateArgs(params)
+	if err != nil {
+		return usage(exportAppStateRoute, err)
+	}
+	defer form.appPass.Clear()
+	exportForm := &core.AppStateExportForm{
+		ExcludeSecrets: form.excludeSecrets,
+		Sections:       make(map[string]json.RawMessage),
+	}
+	if s.mm != nil {
+		mmState, err := s.mm.ExportState(form.excludeSecrets)
+		if err != nil {
+			resErr := msgjson.NewError(msgjson.RPCAppStateError, "unable to export market making state: %v", err)
+			return createResponse(exportAppStateRoute, nil, resErr)
+		}
+		exportForm.Sections[mm.AppStateSection] = mmState
+	}
+	backup, err := s.core.ExportAppState(form.appPass, exportForm)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCAppStateError, "unable to export app state: %v", err)
+		return createResponse(exportAppStateRoute, nil, resErr)
+	}
+	return createResponse(exportAppStateRoute, hex.EncodeToString(backup), nil)
+}
+
+// handleRestoreAppState handles requests for restoreappstate. The app state
+// can only be restored to an uninitialized client, which is logged in on
+// success. *msgjson.ResponsePayload.Error is empty if successful.
+func handleRestoreAppState(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
+	form, err := parseRestoreAppStateArgs(params)
+	if err != nil {
+		return usage(restoreAppStateRoute, err)
+	}
+	defer form.appPass.Clear()
+	res, err := s.core.RestoreAppState(form.appPass, form.backup)
+	if err != nil {
+		resErr := msgjson.NewError(msgjson.RPCAppStateError, "unable to restore app state: %v", err)
+		return createResponse(restoreAppStateRoute, nil, resErr)
+	}
+	if mmState := res.Sections[mm.AppStateSection]; len(mmState) > 0 {
+		if s.mm == nil {
+			res.Errors = append(res.Errors, "market making state not restored: market maker not available")
+		} else if err := s.mm.RestoreState(mmState); err != nil {
+			log.Errorf("Error restoring market making state: %v", err)
+			res.Errors = append(res.Errors, fmt.Sprintf("error restoring market making state: %v", err))
+		}
+	}
+	return createResponse(restoreAppStateRoute, &restoreAppStateResponse{Errors: res.Errors}, nil)
+}
+
 // handleDeleteArchivedRecords handles requests for deleting archived records.
 // *msgjson.ResponsePayload.Error is empty if successful.
 func handleDeleteArchivedRecords(s *RPCServer, params *RawParams) *msgjson.ResponsePayload {
@@ -1467,6 +2049,39 @@ var helpMsgs = map[string]helpMsg{
       "bondID" (string): The bond transactions's txid and output index.
       "reqConfirms" (int): The number of confirmations required to start trading.
     }`,
+	},
+	postBondPSBTRoute: {
+		pwArgsShort: `"appPass"`,
+		argsShort:   `"addr" bond assetID (maintain "cert")`,
+		cmdSummary: `Post new bond for DEX, leaving the bond transaction's funding inputs
+		unsigned for an external signer. Sign the returned PSBT and submit it with
+		broadcastpsbt, or drop the bond with abandonpsbt.`,
+		pwArgsLong: `Password Args:
+    appPass (string): The Bison Wallet password.`,
+		argsLong: `Args:
+    addr (string): The DEX address to post bond for for.
+    bond (int): The bond amount.
+    assetID (int): The asset ID with which to pay the fee.
+    maintain (bool): Optional. Whether to maintain the trading tier established by this bond. Only applicable when registering. (default is true)
+    cert (string): Optional. The TLS certificate path. Only applicable when registering.`,
+		returns: `Returns:
+    {
+      "bondID" (string): The bond transactions's txid and output index.
+      "reqConfirms" (int): The number of confirmations required to start trading.
+      "psbt" (string): The base64-encoded unsigned bond PSBT.
+    }`,
+	},
+	refundBondPSBTRoute: {
+		argsShort:  `"addr" assetID "bondID"`,
+		cmdSummary: `Create an unsigned PSBT that refunds a bond to the bond asset wallet.`,
+		argsLong: `Args:
+    addr (string): The DEX address the bond was posted to.
+    assetID (int): The bond's asset ID.
+    bondID (string): The bond transactions's txid and output index.`,
+		returns: `Returns:
+    string: The base64-encoded unsigned PSBT. The bond input must be signed
+      with the bond's private key before the PSBT is submitted with
+      broadcastpsbt. The refund is only valid after the bond's lock time.`,
 	},
 	bondOptionsRoute: {
 		argsShort:  `"addr" targetTier (maxBondedAmt bondAssetID penaltyComps)`,
@@ -1615,6 +2230,421 @@ var helpMsgs = map[string]helpMsg{
     orderID (string): The hex ID of the order to cancel`,
 		returns: `Returns:
     string: The message "` + fmt.Sprintf(canceledOrderStr, "[order ID]") + `"`,
+	},
+	conditionalOrderRoute: {
+		pwArgsShort: `"appPass"`,
+		argsShort:   `"order"`,
+		cmdSummary: `Create a stop-loss or take-profit order. The order is held by the
+    client until the trigger price is hit, at which point the trade is placed.
+    The client must be logged in with the trade's wallets unlocked for the trade
+    to be placed.`,
+		pwArgsLong: `Password Args:
+    appPass (string): The Bison Wallet password.`,
+		argsLong: `Args:
+    order (string): A JSON-encoded conditional order.
+    {
+      "kind" (string): "stoploss" or "takeprofit". A sell stop-loss or a buy
+        take-profit triggers when the price falls to the trigger price. A sell
+        take-profit or a buy stop-loss triggers when the price rises to the
+        trigger price.
+      "trade" (obj): The trade to place when triggered, with the fields of the
+        trade command. {"host", "isLimit", "sell", "base", "quote", "qty",
+        "rate", "tifnow", "options"}
+      "triggerSource" (string): "midgap" to trigger on the order book mid-gap
+        rate or "fiat" to trigger on the fiat exchange rate of the base asset.
+      "triggerRate" (int): The mid-gap rate, in atoms quote asset per unit base
+        asset, for a "midgap" trigger.
+      "triggerFiatRate" (float): The USD price of one unit of the base asset for
+        a "fiat" trigger.
+      "oco" (obj): Optional. A second conditional order on the same market.
+        When either is triggered or canceled, the other is canceled.
+    }`,
+		returns: `Returns:
+    array: The created conditional orders.`,
+	},
+	conditionalOrdersRoute: {
+		cmdSummary: `List conditional orders, newest first.`,
+		returns: `Returns:
+    array: The conditional orders.
+    [
+      {
+        "id" (string): The conditional order's hex ID.
+        "kind" (string): "stoploss" or "takeprofit".
+        "host", "isLimit", "sell", "base", "quote", "qty", "rate", "tifnow",
+          "options": The trade parameters.
+        "triggerSource" (string): "midgap" or "fiat".
+        "triggerRate" (int): The mid-gap trigger rate.
+        "triggerFiatRate" (float): The fiat trigger rate.
+        "oco" (string): The ID of the one-cancels-other partner, if any.
+        "status" (int): 0 = active, 1 = triggered, 2 = canceled, 3 = failed.
+        "stamp" (int): The creation time in milliseconds since 00:00:00 Jan 1 1970.
+        "orderID" (string): The ID of the order placed when triggered.
+        "error" (string): The reason the order could not be placed.
+      },...
+    ]`,
+	},
+	cancelConditionalRoute: {
+		argsShort:  `"id"`,
+		cmdSummary: `Cancel an active conditional order, along with its one-cancels-other partner.`,
+		argsLong: `Args:
+    id (string): The hex ID of the conditional order to cancel.`,
+		returns: `Returns:
+    string: The message "` + fmt.Sprintf(canceledCondStr, "[id]") + `"`,
+	},
+	postMeshBondRoute: {
+		pwArgsShort: `"appPass"`,
+		argsShort:   `"bond"`,
+		cmdSummary: `Post a bond to the Tatanka Mesh. The bond is refunded automatically
+    after its lock time.`,
+		pwArgsLong: `Password Args:
+    appPass (string): The Bison Wallet password.`,
+		argsLong: `Args:
+    bond (string): A JSON-encoded mesh bond.
+    {
+      "assetID" (int): The BIP-44 coin index of the bond asset.
+      "amount" (int): The bond amount, in atoms.
+      "strength" (int): The bond strength claimed.
+      "lockTime" (string): The RFC 3339 time at which the bond can be refunded.
+    }`,
+		returns: `Returns:
+    obj: The posted bond.
+    {
+      "peerID" (string): Our mesh peer ID.
+      "assetID" (int): The BIP-44 coin index of the bond asset.
+      "coinID" (string): The bond coin ID.
+      "strength" (int): The bond strength.
+      "expiration" (string): The bond lock time.
+      "maturation" (string): The time the bond was posted.
+    }`,
+	},
+	meshBondsRoute: {
+		cmdSummary: `List our active bonds on the Tatanka Mesh.`,
+		returns: `Returns:
+    array: The active bonds. See the postmeshbond command.`,
+	},
+	addAlertRoute: {
+		argsShort: `"alert"`,
+		cmdSummary: `Add a market alert. A notification is sent when the alert's condition
+    is met. Alerts are checked while the client is running.`,
+		argsLong: `Args:
+    alert (string): A JSON-encoded market alert.
+    {
+      "kind" (string): "price", "spread", "volume" or "fiat".
+      "host" (string): The DEX host. Not used for "fiat" alerts.
+      "base" (int): The BIP-44 coin index for the market's base asset. For
+        "fiat" alerts, the asset whose fiat rate is watched.
+      "quote" (int): The BIP-44 coin index for the market's quote asset. Not
+        used for "fiat" alerts.
+      "rate" (int): The mid-gap rate, in atoms quote asset per unit base asset,
+        for a "price" alert.
+      "above" (bool): For a "price" alert, alert when the rate rises to the
+        alert rate, rather than falling to it.
+      "spread" (float): For a "spread" alert, alert when the spread narrows to
+        this fraction of the mid-gap rate, e.g. 0.01 for 1%.
+      "percent" (float): For a "fiat" alert, alert when the USD rate moves this
+        many percent in either direction. For a "volume" alert, alert when the
+        24-hour volume rises this many percent above its average over the
+        last 6 hours. A "volume" alert needs an hour of volume history before
+        it can fire.
+      "repeat" (bool): Optional. Keep the alert active after it fires. A
+        repeating alert fires again once its condition has cleared. A
+        repeating "fiat" alert measures the next move from the rate at which
+        it fired.
+    }`,
+		returns: `Returns:
+    obj: The created market alert. See the alerts command.`,
+	},
+	alertsRoute: {
+		cmdSummary: `List market alerts, newest first.`,
+		returns: `Returns:
+    array: The market alerts.
+    [
+      {
+        "id" (string): The alert's hex ID.
+        "kind", "host", "base", "quote", "rate", "above", "spread", "percent",
+          "repeat": The alert parameters.
+        "refFiatRate" (float): The USD rate that "fiat" alert moves are
+          measured from.
+        "status" (int): 0 = active, 1 = triggered.
+        "stamp" (int): The creation time in milliseconds since 00:00:00 Jan 1 1970.
+        "firedStamp" (int): The time the alert last fired, if it has.
+      },...
+    ]`,
+	},
+	removeAlertRoute: {
+		argsShort:  `"id"`,
+		cmdSummary: `Remove a market alert.`,
+		argsLong: `Args:
+    id (string): The hex ID of the market alert to remove.`,
+		returns: `Returns:
+    string: The message "` + fmt.Sprintf(removedAlertStr, "[id]") + `"`,
+	},
+	apiKeysRoute: {
+		cmdSummary: `List the API keys. API keys authenticate like the RPC user and
+    password, with the key name as the user and the key secret as the
+    password, but may only use the routes their permissions allow.`,
+		returns: `Returns:
+    array: The API keys, by name.
+    [
+      {
+        "name" (string): The key name.
+        "permissions" (array): The granted permissions. "read", "trade",
+          "bots" or "funds".
+        "allowedIPs" (array): The IP addresses or CIDR ranges that may use the
+          key. Any address may use the key if empty.
+        "withdrawAddrs" (array): The only addresses funds may be sent to with
+          the key. Any address is allowed if empty.
+        "created" (string): The creation time.
+      },...
+    ]`,
+	},
+	newAPIKeyRoute: {
+		argsShort:  `"name" "permissions" ("allowedIPs" "withdrawAddrs")`,
+		cmdSummary: `Create an API key. The secret is only shown once.`,
+		argsLong: `Args:
+    name (string): The key name. 1 to 32 letters, numbers, underscores or
+      hyphens.
+    permissions (string): A comma-separated list of permissions. "read" to
+      view wallets, orders, books and bots, "trade" to place and cancel
+      orders, "bots" to start, stop and update bots, "funds" to send, withdraw
+      and post bonds.
+    allowedIPs (string): Optional. A comma-separated list of IP addresses or
+      CIDR ranges that may use the key.
+    withdrawAddrs (string): Optional. A comma-separated list of the only
+      addresses funds may be sent to with the key. PSBTs cannot be broadcast
+      with a withdrawal address allowlist.`,
+		returns: `Returns:
+    obj: The new key.
+    {
+      "key" (obj): The key, as returned by apikeys.
+      "secret" (string): The key secret.
+    }`,
+	},
+	revokeAPIKeyRoute: {
+		argsShort:  `"name"`,
+		cmdSummary: `Revoke an API key.`,
+		argsLong: `Args:
+    name (string): The key name.`,
+		returns: `Returns:
+    string: The message "` + fmt.Sprintf(revokedAPIKeyStr, "[name]") + `"`,
+	},
+	apiAuditLogRoute: {
+		argsShort:  `(n)`,
+		cmdSummary: `List the most recent authenticated RPC calls, newest first.`,
+		argsLong: `Args:
+    n (int): Optional. The number of entries. Default is 100. 0 lists all
+      entries.`,
+		returns: `Returns:
+    array: The audit log entries.
+    [
+      {
+        "stamp" (string): The time of the call.
+        "key" (string): The API key name. Empty for the RPC user.
+        "ip" (string): The client address.
+        "route" (string): The route, or "ws" for a websocket connection.
+        "allowed" (bool): Whether the call was permitted.
+        "error" (string): The error message, if the call failed.
+      },...
+    ]`,
+	},
+	aggregateBookRoute: {
+		argsShort:  `base quote ("hosts")`,
+		cmdSummary: `Retrieve the combined order book for a market on multiple DEX hosts.`,
+		argsLong: `Args:
+    base (int): The BIP-44 coin index for the market's base asset.
+    quote (int): The BIP-44 coin index for the market's quote asset.
+    hosts (string): Optional. A comma-separated list of DEX hosts. All
+      connected hosts listing the market are used if not specified.`,
+		returns: `Returns:
+    obj: The aggregated order book.
+    {
+      "base" (int): The base asset ID.
+      "quote" (int): The quote asset ID.
+      "hosts" (array): The hosts included.
+      "buys" (array): Buy orders, highest rate first.
+      "sells" (array): Sell orders, lowest rate first.
+        [
+          {
+            "host" (string): The DEX host of the order.
+            "qty" (float): The number of coins base asset being sold.
+            "qtyAtomic" (int): The number of atoms base asset being sold.
+            "rate" (float): The coins quote asset to accept per coin base asset.
+            "msgRate" (int): The atomic rate.
+            "sell" (bool): Always true for sells, false for buys.
+            "epoch" (int): The order's epoch, if it has not yet been booked.
+          },...
+        ]
+    }`,
+	},
+	planRouteRoute: {
+		argsShort: `"order"`,
+		cmdSummary: `Preview how a routed trade would be split across DEX hosts, without
+    placing any orders.`,
+		argsLong: `Args:
+    order (string): A JSON-encoded routed trade.
+    {
+      "hosts" (array): Optional. The DEX hosts to route across. All connected
+        hosts listing the market are used if not specified.
+      "sell" (bool): Whether the order is selling.
+      "base" (int): The BIP-44 coin index for the market's base asset.
+      "quote" (int): The BIP-44 coin index for the market's quote asset.
+      "qty" (int): The total number of units of the base asset to trade. Must
+        be divisible by the lot sizes of the hosts used.
+      "rate" (int): Optional. The worst acceptable rate, in atoms quote asset
+        per unit base asset. 0 for no limit.
+      "tifnow" (bool): Optional. Require immediate match. Do not book the
+        orders.
+      "options" (obj): Optional. Order options, as with the trade command.
+    }`,
+		returns: `Returns:
+    obj: The route plan.
+    {
+      "allocations" (array): The part of the trade assigned to each host.
+        [
+          {
+            "host" (string): The DEX host.
+            "lotSize" (int): The host's lot size.
+            "qty" (int): The quantity to trade on the host.
+            "rate" (int): The worst book rate needed to fill the quantity.
+            "quoteQty" (int): The quote asset value at book rates.
+            "fees" (int): The estimated fees, in units of the quote asset.
+          },...
+        ]
+      "qty" (int): The total quantity.
+      "quoteQty" (int): The total quote asset value at book rates.
+      "avgRate" (int): The average rate at book rates.
+      "fees" (int): The total estimated fees, in units of the quote asset.
+    }`,
+	},
+	routedTradeRoute: {
+		pwArgsShort: `"appPass"`,
+		argsShort:   `"order"`,
+		cmdSummary: `Split a trade across the same market on multiple DEX hosts to get the
+    best average price, given each host's lot size, fees and the user's trading
+    limits. A limit order is placed on each host at the worst book rate needed
+    to fill its part. The orders are tracked as one routed order.`,
+		pwArgsLong: `Password Args:
+    appPass (string): The Bison Wallet password.`,
+		argsLong: `Args:
+    order (string): A JSON-encoded routed trade.
+    {
+      "hosts" (array): Optional. The DEX hosts to route across. All connected
+        hosts listing the market are used if not specified.
+      "sell" (bool): Whether the order is selling.
+      "base" (int): The BIP-44 coin index for the market's base asset.
+      "quote" (int): The BIP-44 coin index for the market's quote asset.
+      "qty" (int): The total number of units of the base asset to trade. Must
+        be divisible by the lot sizes of the hosts used.
+      "rate" (int): Optional. The worst acceptable rate, in atoms quote asset
+        per unit base asset. 0 for no limit.
+      "tifnow" (bool): Optional. Require immediate match. Do not book the
+        orders.
+      "options" (obj): Optional. Order options, as with the trade command.
+    }`,
+		returns: `Returns:
+    obj: The routed order. See the routedorders command.`,
+	},
+	routedOrdersRoute: {
+		cmdSummary: `List routed orders, newest first.`,
+		returns: `Returns:
+    array: The routed orders.
+    [
+      {
+        "id" (string): The routed order's hex ID.
+        "sell", "base", "quote", "qty", "rate": The routed trade parameters.
+        "stamp" (int): The creation time in milliseconds since 00:00:00 Jan 1 1970.
+        "filled" (int): The total quantity filled by the child orders.
+        "active" (bool): Whether any of the child orders is active.
+        "children" (array): The orders placed on each host.
+          [
+            {
+              "host" (string): The DEX host.
+              "qty" (int): The quantity ordered on the host.
+              "rate" (int): The limit rate of the order.
+              "order" (obj): The order, as returned by the myorders command.
+              "error" (string): The reason the order could not be placed.
+            },...
+          ]
+      },...
+    ]`,
+	},
+	ledgerRoute: {
+		argsShort: `(start end "method" "format")`,
+		cmdSummary: `Generate a tax and accounting ledger of DEX trades, network fees, bonds,
+    sends, receives, bridge transfers and market making CEX trades. Each event is
+    valued in USD at the fiat rates recorded at the time, and gains are realized
+    with the chosen cost basis method. Fiat rates are only recorded while a
+    rate source is enabled, so earlier events have no value.`,
+		argsLong: `Args:
+    start (int): Optional. The start of the reporting period in milliseconds
+      since 00:00:00 Jan 1 1970. Earlier events still contribute to the cost
+      basis. Default is 0.
+    end (int): Optional. The end of the reporting period in milliseconds. 0
+      for now.
+    method (string): Optional. The cost basis method, one of "fifo", "lifo", or
+      "average". Default is "fifo".
+    format (string): Optional. Return CSV in one of the "generic", "koinly",
+      or "cointracker" formats instead of JSON.`,
+		returns: `Returns:
+    obj: The ledger, or a CSV string if a format was specified.
+    {
+      "method" (string): The cost basis method.
+      "currency" (string): The fiat currency of the values.
+      "start" (int): The start of the reporting period.
+      "end" (int): The end of the reporting period.
+      "events" (array): The events in the reporting period, oldest first.
+        [
+          {
+            "stamp" (int): The event time in milliseconds.
+            "type" (string): The event type.
+            "source" (string): The DEX host, CEX name or asset of the event.
+            "id" (string): The match ID, order ID or transaction ID.
+            "sent", "received", "fee" (obj): The assets exchanged.
+              {
+                "assetID" (int): The asset ID.
+                "symbol" (string): The asset symbol.
+                "amount" (int): The amount in atoms.
+                "value" (float): The fiat value.
+              }
+            "proceeds" (float): The fiat proceeds of disposals.
+            "costBasis" (float): The cost basis of the assets disposed of.
+            "gain" (float): The realized gain or loss.
+            "taxable" (bool): Whether a gain or loss was realized.
+          },...
+        ]
+      "assets" (array): The holdings, cost basis and realized gain per asset.
+      "realizedGain" (float): The total realized gain in the period.
+    }`,
+	},
+	profilesRoute: {
+		cmdSummary: `List the user profiles. Each profile has its own app password, seed,
+    accounts, wallets and bots. Requests made with a profile header (bwctl
+    --profile) are rejected unless that profile is active.`,
+		returns: `Returns:
+    obj: The profiles.
+    {
+      "active" (string): The active profile.
+      "profiles" (array): The names of all profiles, default first.
+    }`,
+	},
+	switchProfileRoute: {
+		argsShort: `"name"`,
+		cmdSummary: `Log out of the active profile and restart with another existing
+    profile. The switch fails if there are active orders or running bots.`,
+		argsLong: `Args:
+    name (string): The profile name.`,
+		returns: `Returns:
+    string: The message "` + fmt.Sprintf(switchProfileStr, "[name]") + `"`,
+	},
+	createProfileRoute: {
+		argsShort: `"name"`,
+		cmdSummary: `Create a profile, log out of the active profile and restart with the
+    new profile. The switch fails if there are active orders or running bots.`,
+		argsLong: `Args:
+    name (string): The profile name. 1 to 32 letters, numbers, underscores or
+      hyphens.`,
+		returns: `Returns:
+    string: The message "` + fmt.Sprintf(switchProfileStr, "[name]") + `"`,
 	},
 	rescanWalletRoute: {
 		argsShort: `assetID (force)`,
@@ -1665,6 +2695,81 @@ needed to complete a swap.`,
     address (string): The address to which funds are sent.`,
 		returns: `Returns:
     string: "[coin ID]"`,
+	},
+	sendPSBTRoute: {
+		argsShort:  `assetID value "address"`,
+		cmdSummary: `Create an unsigned PSBT that sends exact value from an exchange wallet to address.`,
+		argsLong: `Args:
+    assetID (int): The asset's BIP-44 registered coin index. Used to identify
+      which wallet to send from. e.g. 0 for BTC. See
+      https://github.com/satoshilabs/slips/blob/master/slip-0044.md
+    value (int): The amount to send in units of the asset's smallest
+      denomination (e.g. satoshis)
+    address (string): The address to which funds are sent.`,
+		returns: `Returns:
+    string: The base64-encoded unsigned PSBT. Sign it with an external signer
+      and submit it with broadcastpsbt.`,
+	},
+	withdrawPSBTRoute: {
+		argsShort:  `assetID value "address"`,
+		cmdSummary: `Create an unsigned PSBT that withdraws value from an exchange wallet to address. Network fees are subtracted from the value.`,
+		argsLong: `Args:
+    assetID (int): The asset's BIP-44 registered coin index. Used to identify
+      which wallet to withdraw from. e.g. 0 for BTC. See
+      https://github.com/satoshilabs/slips/blob/master/slip-0044.md
+    value (int): The amount to withdraw in units of the asset's smallest
+      denomination (e.g. satoshis)
+    address (string): The address to which funds are withdrawn.`,
+		returns: `Returns:
+    string: The base64-encoded unsigned PSBT. Sign it with an external signer
+      and submit it with broadcastpsbt.`,
+	},
+	broadcastPSBTRoute: {
+		argsShort:  `assetID "psbt"`,
+		cmdSummary: `Finalize and broadcast a signed PSBT.`,
+		argsLong: `Args:
+    assetID (int): The asset's BIP-44 registered coin index.
+    psbt (string): The base64-encoded signed PSBT, or the path to a file
+      containing it when using bwctl.`,
+		returns: `Returns:
+    string: The transaction ID.`,
+	},
+	abandonPSBTRoute: {
+		argsShort:  `assetID "psbt"`,
+		cmdSummary: `Unlock the wallet inputs of an unsigned PSBT that will not be broadcast. A pending bond funded by the PSBT is dropped.`,
+		argsLong: `Args:
+    assetID (int): The asset's BIP-44 registered coin index.
+    psbt (string): The base64-encoded unsigned PSBT, or the path to a file
+      containing it when using bwctl.`,
+		returns: `Returns:
+    string: The transaction ID.`,
+	},
+	sendMultiRoute: {
+		pwArgsShort: `"appPass"`,
+		argsShort:   `assetID "payments"`,
+		cmdSummary:  `Pay multiple addresses from an exchange wallet. UTXO-based wallets pay all addresses in a single transaction. Account-based wallets send a transaction for each payment.`,
+		pwArgsLong: `Password Args:
+    appPass (string): The Bison Wallet password.`,
+		argsLong: `Args:
+    assetID (int): The asset's BIP-44 registered coin index. Used to identify
+      which wallet to send from. e.g. 0 for BTC. See
+      https://github.com/satoshilabs/slips/blob/master/slip-0044.md
+    payments (string): A JSON-encoded list of payments, each with an address
+      and a value in units of the asset's smallest denomination.
+      e.g. '[{"address":"bc1q...","value":100000},{"address":"bc1q...","value":200000}]'`,
+		returns: `Returns:
+    []string: The coin IDs created for the payments.`,
+	},
+	sendMultiFeeRoute: {
+		argsShort:  `assetID "payments"`,
+		cmdSummary: `Estimate the network fees for a sendmulti request.`,
+		argsLong: `Args:
+    assetID (int): The asset's BIP-44 registered coin index.
+    payments (string): A JSON-encoded list of payments, each with an address
+      and a value in units of the asset's smallest denomination.
+      e.g. '[{"address":"bc1q...","value":100000},{"address":"bc1q...","value":200000}]'`,
+		returns: `Returns:
+    int: The estimated fees in units of the asset's smallest denomination.`,
 	},
 	logoutRoute: {
 		cmdSummary: `Logout of Bison Wallet.`,
@@ -1779,6 +2884,40 @@ needed to complete a swap.`,
     appPass (string): The Bison Wallet password.`,
 		returns: `Returns:
     string: The application's seed as hex.`,
+	},
+	exportAppStateRoute: {
+		pwArgsShort: `"appPass"`,
+		argsShort:   `(excludeSecrets)`,
+		cmdSummary: `Export a backup of the application state, including the app seed, DEX
+    accounts and bonds, wallet configurations, preferences, and market making
+    configurations and history. The backup is encrypted with the app password,
+    and can be restored to a new client with restoreappstate.`,
+		pwArgsLong: `Password Args:
+    appPass (string): The Bison Wallet password.`,
+		argsLong: `Args:
+    excludeSecrets (bool): Optional. Omit wallet passwords, wallet RPC
+      credentials and CEX API credentials. Default is false.`,
+		returns: `Returns:
+    string: The hex-encoded backup.`,
+	},
+	restoreAppStateRoute: {
+		pwArgsShort: `"appPass"`,
+		argsShort:   `"backup"`,
+		cmdSummary: `Initialize a new client from a backup created with exportappstate, and
+    log in. The password the backup was created with becomes the app password.
+    Wallets, accounts and preferences that cannot be restored are reported
+    rather than aborting the restore.`,
+		pwArgsLong: `Password Args:
+    appPass (string): The Bison Wallet password the backup was created with.`,
+		argsLong: `Args:
+    backup (string): The hex-encoded backup, or the path of a file containing
+      it when using bwctl.`,
+		returns: `Returns:
+    obj: The restore result.
+    {
+      "errors" ([]string): Errors restoring individual wallets, accounts and
+        preferences.
+    }`,
 	},
 	walletPeersRoute: {
 		cmdSummary: `Show the peers a wallet is connected to.`,
@@ -12,12 +12,15 @@ import (
 	"strings"
 	"testing"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/db"
 	"decred.org/dcrdex/client/websocket"
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/encode"
 	"decred.org/dcrdex/dex/msgjson"
+	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/davecgh/go-spew/spew"
 )
 
@@ -849,6 +852,806 @@ func TestHandleSendAndWithdraw(t *testing.T) {
 	}
 }
 
+func TestHandleSendAndWithdrawPSBT(t *testing.T) {
+	params := &RawParams{Args: []string{"0", "1000", "abc"}}
+	tests := []struct {
+		name        string
+		params      *RawParams
+		psbtErr     error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      params,
+		wantErrCode: -1,
+	}, {
+		name:        "SendPSBT error",
+		params:      params,
+		psbtErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCPSBTError,
+	}, {
+		name:        "bad params",
+		params:      &RawParams{Args: []string{"0", "abc"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, handler := range []func(*RPCServer, *RawParams) *msgjson.ResponsePayload{handleSendPSBT, handleWithdrawPSBT} {
+		for _, test := range tests {
+			tc := &TCore{
+				psbt:    "cHNidP8B",
+				psbtErr: test.psbtErr,
+			}
+			r := &RPCServer{core: tc}
+			payload := handler(r, test.params)
+			res := ""
+			if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			if test.wantErrCode == -1 && res != tc.psbt {
+				t.Fatalf("%s: wrong PSBT returned", test.name)
+			}
+		}
+	}
+}
+
+func TestHandleBroadcastPSBT(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      *RawParams
+		psbtErr     error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{Args: []string{"0", "cHNidP8B"}},
+		wantErrCode: -1,
+	}, {
+		name:        "BroadcastPSBT error",
+		params:      &RawParams{Args: []string{"0", "cHNidP8B"}},
+		psbtErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCPSBTError,
+	}, {
+		name:        "bad asset ID",
+		params:      &RawParams{Args: []string{"btc", "cHNidP8B"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, handler := range []func(*RPCServer, *RawParams) *msgjson.ResponsePayload{handleBroadcastPSBT, handleAbandonPSBT} {
+		for _, test := range tests {
+			tc := &TCore{psbtErr: test.psbtErr}
+			r := &RPCServer{core: tc}
+			payload := handler(r, test.params)
+			res := ""
+			if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+		}
+	}
+}
+
+func TestHandleRefundBondPSBT(t *testing.T) {
+	params := &RawParams{Args: []string{"dex.tld", "0", "abcd:0"}}
+	tests := []struct {
+		name        string
+		params      *RawParams
+		psbtErr     error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      params,
+		wantErrCode: -1,
+	}, {
+		name:        "RefundBondPSBT error",
+		params:      params,
+		psbtErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCPSBTError,
+	}, {
+		name:        "bad asset ID",
+		params:      &RawParams{Args: []string{"dex.tld", "btc", "abcd:0"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "missing bond ID",
+		params:      &RawParams{Args: []string{"dex.tld", "0"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{
+			psbt:    "cHNidP8B",
+			psbtErr: test.psbtErr,
+		}
+		r := &RPCServer{core: tc}
+		payload := handleRefundBondPSBT(r, test.params)
+		res := ""
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && res != tc.psbt {
+			t.Fatalf("%s: wrong PSBT returned", test.name)
+		}
+	}
+}
+
+func TestHandlePostBondPSBT(t *testing.T) {
+	pw := encode.PassBytes("password123")
+	params := &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"dex.tld", "1000", "0"}}
+	tc := &TCore{
+		exchanges: map[string]*core.Exchange{
+			"dex.tld": {BondAssets: map[string]*core.BondAsset{"btc": {Amt: 1000}}},
+		},
+		postBondResult: &core.PostBondResult{BondID: "abcd:0", PSBT: "cHNidP8B"},
+	}
+	r := &RPCServer{core: tc}
+	for _, test := range []struct {
+		handler func(*RPCServer, *RawParams) *msgjson.ResponsePayload
+		psbt    bool
+	}{{handlePostBond, false}, {handlePostBondPSBT, true}} {
+		payload := test.handler(r, params)
+		if err := verifyResponse(payload, new(core.PostBondResult), -1); err != nil {
+			t.Fatal(err)
+		}
+		if tc.postBondForm.PSBT != test.psbt {
+			t.Fatalf("wrong PSBT flag. wanted %t, got %t", test.psbt, tc.postBondForm.PSBT)
+		}
+	}
+
+	tc.postBondErr = errors.New("error")
+	payload := handlePostBondPSBT(r, params)
+	if err := verifyResponse(payload, new(core.PostBondResult), msgjson.RPCPostBondError); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleSendMulti(t *testing.T) {
+	pw := encode.PassBytes("password123")
+	payments := `[{"address":"abc","value":1000},{"address":"def","value":2000}]`
+	params := &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"42", payments}}
+	tests := []struct {
+		name         string
+		params       *RawParams
+		sendMultiErr error
+		wantErrCode  int
+	}{{
+		name:        "ok",
+		params:      params,
+		wantErrCode: -1,
+	}, {
+		name:         "SendMulti error",
+		params:       params,
+		sendMultiErr: errors.New("error"),
+		wantErrCode:  msgjson.RPCFundTransferError,
+	}, {
+		name:        "empty password",
+		params:      &RawParams{PWArgs: []encode.PassBytes{nil}, Args: []string{"42", payments}},
+		wantErrCode: msgjson.RPCFundTransferError,
+	}, {
+		name:        "bad payments",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"42", "abc"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no payments",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"42", "[]"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no password",
+		params:      &RawParams{Args: []string{"42", payments}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{
+			sendMultiCoins: []asset.Coin{tCoin{}, tCoin{}},
+			sendMultiErr:   test.sendMultiErr,
+		}
+		r := &RPCServer{core: tc}
+		payload := handleSendMulti(r, test.params)
+		var res []string
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && len(res) != 2 {
+			t.Fatalf("%s: expected 2 coins, got %d", test.name, len(res))
+		}
+	}
+}
+
+func TestHandleSendMultiFee(t *testing.T) {
+	payments := `[{"address":"abc","value":1000}]`
+	tests := []struct {
+		name         string
+		params       *RawParams
+		sendMultiErr error
+		wantErrCode  int
+	}{{
+		name:        "ok",
+		params:      &RawParams{Args: []string{"42", payments}},
+		wantErrCode: -1,
+	}, {
+		name:         "EstimateSendMultiTxFee error",
+		params:       &RawParams{Args: []string{"42", payments}},
+		sendMultiErr: errors.New("error"),
+		wantErrCode:  msgjson.RPCFundTransferError,
+	}, {
+		name:        "bad asset ID",
+		params:      &RawParams{Args: []string{"dcr", payments}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{sendMultiErr: test.sendMultiErr}
+		r := &RPCServer{core: tc}
+		payload := handleSendMultiFee(r, test.params)
+		var fee uint64
+		if err := verifyResponse(payload, &fee, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+	}
+}
+
+func TestHandleConditionalOrder(t *testing.T) {
+	pw := encode.PassBytes("password123")
+	order := `{"kind":"stoploss","trade":{"host":"dex.tld","sell":true,"base":42,"quote":0,"qty":1000000000},"triggerSource":"midgap","triggerRate":100000}`
+	tests := []struct {
+		name         string
+		params       *RawParams
+		condOrderErr error
+		wantErrCode  int
+	}{{
+		name:        "ok",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{order}},
+		wantErrCode: -1,
+	}, {
+		name:         "core error",
+		params:       &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{order}},
+		condOrderErr: errors.New("error"),
+		wantErrCode:  msgjson.RPCConditionalOrderError,
+	}, {
+		name:        "bad JSON",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"{"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no trade",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{`{"kind":"stoploss"}`}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no password",
+		params:      &RawParams{Args: []string{order}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{
+			condOrders:   []*db.ConditionalOrder{{ID: dex.Bytes{0x01}}},
+			condOrderErr: test.condOrderErr,
+		}
+		r := &RPCServer{core: tc}
+		payload := handleConditionalOrder(r, test.params)
+		var res []*db.ConditionalOrder
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && len(res) != 1 {
+			t.Fatalf("%s: expected 1 conditional order, got %d", test.name, len(res))
+		}
+	}
+}
+
+func TestHandleConditionalOrders(t *testing.T) {
+	tc := &TCore{condOrders: []*db.ConditionalOrder{{ID: dex.Bytes{0x01}}, {ID: dex.Bytes{0x02}}}}
+	r := &RPCServer{core: tc}
+	var res []*db.ConditionalOrder
+	if err := verifyResponse(handleConditionalOrders(r, &RawParams{}), &res, -1); err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("expected 2 conditional orders, got %d", len(res))
+	}
+	tc.condOrderErr = errors.New("error")
+	if err := verifyResponse(handleConditionalOrders(r, &RawParams{}), &res, msgjson.RPCConditionalOrderError); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleMarketAlerts(t *testing.T) {
+	alert := `{"kind":"price","host":"dex.tld","base":42,"quote":0,"rate":100000,"above":true}`
+	tests := []struct {
+		name        string
+		handler     func(*RPCServer, *RawParams) *msgjson.ResponsePayload
+		args        []string
+		coreErr     error
+		wantErrCode int
+	}{{
+		name:        "add ok",
+		handler:     handleAddAlert,
+		args:        []string{alert},
+		wantErrCode: -1,
+	}, {
+		name:        "add core error",
+		handler:     handleAddAlert,
+		args:        []string{alert},
+		coreErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCMarketAlertError,
+	}, {
+		name:        "add bad JSON",
+		handler:     handleAddAlert,
+		args:        []string{"{"},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "add no args",
+		handler:     handleAddAlert,
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "list ok",
+		handler:     handleAlerts,
+		wantErrCode: -1,
+	}, {
+		name:        "list core error",
+		handler:     handleAlerts,
+		coreErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCMarketAlertError,
+	}, {
+		name:        "remove ok",
+		handler:     handleRemoveAlert,
+		args:        []string{"01"},
+		wantErrCode: -1,
+	}, {
+		name:        "remove core error",
+		handler:     handleRemoveAlert,
+		args:        []string{"01"},
+		coreErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCMarketAlertError,
+	}, {
+		name:        "remove bad id",
+		handler:     handleRemoveAlert,
+		args:        []string{"zz"},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{
+			marketAlerts:   []*db.MarketAlert{{ID: dex.Bytes{0x01}}},
+			marketAlertErr: test.coreErr,
+		}
+		r := &RPCServer{core: tc}
+		payload := test.handler(r, &RawParams{Args: test.args})
+		if err := verifyResponse(payload, new(any), test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+	}
+}
+
+func TestHandleMeshBonds(t *testing.T) {
+	bond := `{"assetID":42,"amount":100000000,"strength":1,"lockTime":"2030-01-01T00:00:00Z"}`
+	pw := encode.PassBytes("abc")
+	tests := []struct {
+		name        string
+		handler     func(*RPCServer, *RawParams) *msgjson.ResponsePayload
+		params      *RawParams
+		coreErr     error
+		wantErrCode int
+	}{{
+		name:        "post ok",
+		handler:     handlePostMeshBond,
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{bond}},
+		wantErrCode: -1,
+	}, {
+		name:        "post core error",
+		handler:     handlePostMeshBond,
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{bond}},
+		coreErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCMeshBondError,
+	}, {
+		name:        "post bad JSON",
+		handler:     handlePostMeshBond,
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"{"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "post no password",
+		handler:     handlePostMeshBond,
+		params:      &RawParams{Args: []string{bond}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "list ok",
+		handler:     handleMeshBonds,
+		params:      &RawParams{},
+		wantErrCode: -1,
+	}, {
+		name:        "list core error",
+		handler:     handleMeshBonds,
+		params:      &RawParams{},
+		coreErr:     errors.New("error"),
+		wantErrCode: msgjson.RPCMeshBondError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{
+			meshBonds:   []*tanka.Bond{{AssetID: 42, Strength: 1}},
+			meshBondErr: test.coreErr,
+		}
+		r := &RPCServer{core: tc}
+		payload := test.handler(r, test.params)
+		if err := verifyResponse(payload, new(any), test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+	}
+}
+
+func TestHandleAggregateBook(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		routeErr    error
+		wantHosts   int
+		wantErrCode int
+	}{{
+		name:        "ok all hosts",
+		args:        []string{"42", "0"},
+		wantErrCode: -1,
+	}, {
+		name:        "ok hosts",
+		args:        []string{"42", "0", "dex1.tld, dex2.tld"},
+		wantHosts:   2,
+		wantErrCode: -1,
+	}, {
+		name:        "core error",
+		args:        []string{"42", "0"},
+		routeErr:    errors.New("error"),
+		wantErrCode: msgjson.RPCRoutedOrderError,
+	}, {
+		name:        "bad base",
+		args:        []string{"dcr", "0"},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "missing quote",
+		args:        []string{"42"},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		r := &RPCServer{core: &TCore{routeErr: test.routeErr}}
+		payload := handleAggregateBook(r, &RawParams{Args: test.args})
+		res := new(core.AggregatedBook)
+		if err := verifyResponse(payload, res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && len(res.Hosts) != test.wantHosts {
+			t.Fatalf("%s: expected %d hosts, got %d", test.name, test.wantHosts, len(res.Hosts))
+		}
+	}
+}
+
+func TestHandleRoutedTrade(t *testing.T) {
+	pw := encode.PassBytes("password123")
+	order := `{"sell":true,"base":42,"quote":0,"qty":1000000000}`
+	tests := []struct {
+		name        string
+		params      *RawParams
+		routeErr    error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{order}},
+		wantErrCode: -1,
+	}, {
+		name:        "core error",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{order}},
+		routeErr:    errors.New("error"),
+		wantErrCode: msgjson.RPCRoutedOrderError,
+	}, {
+		name:        "bad JSON",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"{"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "zero qty",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{`{"base":42,"quote":0}`}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no password",
+		params:      &RawParams{Args: []string{order}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		r := &RPCServer{core: &TCore{routeErr: test.routeErr}}
+		payload := handleRoutedTrade(r, test.params)
+		res := new(core.RoutedOrder)
+		if err := verifyResponse(payload, res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && res.Qty != 1e9 {
+			t.Fatalf("%s: wrong routed order quantity %d", test.name, res.Qty)
+		}
+	}
+
+	// planroute takes no password.
+	r := &RPCServer{core: &TCore{}}
+	plan := new(core.RoutePlan)
+	if err := verifyResponse(handlePlanRoute(r, &RawParams{Args: []string{order}}), plan, -1); err != nil {
+		t.Fatal(err)
+	}
+	payload := handlePlanRoute(r, &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{order}})
+	if err := verifyResponse(payload, plan, msgjson.RPCArgumentsError); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleRoutedOrders(t *testing.T) {
+	tc := &TCore{routedOrders: []*core.RoutedOrder{{ID: dex.Bytes{0x01}}, {ID: dex.Bytes{0x02}}}}
+	r := &RPCServer{core: tc}
+	var res []*core.RoutedOrder
+	if err := verifyResponse(handleRoutedOrders(r, &RawParams{}), &res, -1); err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Fatalf("expected 2 routed orders, got %d", len(res))
+	}
+	tc.routeErr = errors.New("error")
+	if err := verifyResponse(handleRoutedOrders(r, &RawParams{}), &res, msgjson.RPCRoutedOrderError); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleLedger(t *testing.T) {
+	ledger := &core.Ledger{
+		Method:   core.CostBasisFIFO,
+		Currency: core.LedgerCurrency,
+		Events:   []*core.LedgerEvent{{Stamp: 1000, Type: core.LedgerTrade, ID: "abc"}},
+	}
+	tests := []struct {
+		name        string
+		params      *RawParams
+		ledgerErr   error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{},
+		wantErrCode: -1,
+	}, {
+		name:        "ok with args",
+		params:      &RawParams{Args: []string{"1000", "2000", "lifo"}},
+		wantErrCode: -1,
+	}, {
+		name:        "csv",
+		params:      &RawParams{Args: []string{"", "", "", "koinly"}},
+		wantErrCode: -1,
+	}, {
+		name:        "bad csv format",
+		params:      &RawParams{Args: []string{"", "", "", "turbotax"}},
+		wantErrCode: msgjson.RPCLedgerError,
+	}, {
+		name:        "bad start",
+		params:      &RawParams{Args: []string{"abc"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "too many args",
+		params:      &RawParams{Args: []string{"1", "2", "fifo", "generic", "extra"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "core error",
+		params:      &RawParams{},
+		ledgerErr:   errors.New("error"),
+		wantErrCode: msgjson.RPCLedgerError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{ledger: ledger, ledgerErr: test.ledgerErr}
+		r := &RPCServer{core: tc}
+		payload := handleLedger(r, test.params)
+		var res any
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.name == "csv" {
+			var csv string
+			if err := json.Unmarshal(payload.Result, &csv); err != nil || !strings.Contains(csv, "Net Worth Amount") {
+				t.Fatalf("csv: wrong result %q, err = %v", csv, err)
+			}
+		}
+	}
+}
+
+type tProfiles struct {
+	active    string
+	all       []string
+	err       error
+	switchErr error
+	switched  string
+	created   string
+}
+
+func (p *tProfiles) Profiles() (string, []string, error) {
+	return p.active, p.all, p.err
+}
+
+func (p *tProfiles) CreateProfile(name string) error {
+	if p.switchErr != nil {
+		return p.switchErr
+	}
+	p.created = name
+	return nil
+}
+
+func (p *tProfiles) SwitchProfile(name string) error {
+	if p.switchErr != nil {
+		return p.switchErr
+	}
+	p.switched = name
+	return nil
+}
+
+func TestHandleProfiles(t *testing.T) {
+	// Without a profile manager, only the default profile exists.
+	r := &RPCServer{}
+	payload := handleProfiles(r, &RawParams{})
+	res := new(profilesResponse)
+	if err := verifyResponse(payload, res, -1); err != nil {
+		t.Fatal(err)
+	}
+	if res.Active != defaultProfile || len(res.Profiles) != 1 {
+		t.Fatalf("wrong default profiles %+v", res)
+	}
+
+	tests := []struct {
+		name        string
+		params      *RawParams
+		profilesErr error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{},
+		wantErrCode: -1,
+	}, {
+		name:        "too many args",
+		params:      &RawParams{Args: []string{"alice"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "profiles error",
+		params:      &RawParams{},
+		profilesErr: errors.New("error"),
+		wantErrCode: msgjson.RPCProfileError,
+	}}
+	for _, test := range tests {
+		r := &RPCServer{profiles: &tProfiles{active: "alice", all: []string{defaultProfile, "alice"}, err: test.profilesErr}}
+		payload := handleProfiles(r, test.params)
+		res := new(profilesResponse)
+		if err := verifyResponse(payload, res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && (res.Active != "alice" || len(res.Profiles) != 2) {
+			t.Fatalf("%s: wrong profiles %+v", test.name, res)
+		}
+	}
+}
+
+func TestHandleSwitchProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      *RawParams
+		noManager   bool
+		switchErr   error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{Args: []string{"alice"}},
+		wantErrCode: -1,
+	}, {
+		name:        "no args",
+		params:      &RawParams{},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no profile manager",
+		params:      &RawParams{Args: []string{"alice"}},
+		noManager:   true,
+		wantErrCode: msgjson.RPCProfileError,
+	}, {
+		name:        "switch error",
+		params:      &RawParams{Args: []string{"alice"}},
+		switchErr:   errors.New("active orders"),
+		wantErrCode: msgjson.RPCProfileError,
+	}}
+	for _, test := range tests {
+		profiles := &tProfiles{switchErr: test.switchErr}
+		r := &RPCServer{}
+		if !test.noManager {
+			r.profiles = profiles
+		}
+		payload := handleSwitchProfile(r, test.params)
+		var res string
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && profiles.switched != "alice" {
+			t.Fatalf("%s: profile not switched", test.name)
+		}
+	}
+}
+
+func TestHandleCreateProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      *RawParams
+		noManager   bool
+		createErr   error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{Args: []string{"alice"}},
+		wantErrCode: -1,
+	}, {
+		name:        "no args",
+		params:      &RawParams{},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no profile manager",
+		params:      &RawParams{Args: []string{"alice"}},
+		noManager:   true,
+		wantErrCode: msgjson.RPCProfileError,
+	}, {
+		name:        "create error",
+		params:      &RawParams{Args: []string{"alice"}},
+		createErr:   errors.New("already exists"),
+		wantErrCode: msgjson.RPCProfileError,
+	}}
+	for _, test := range tests {
+		profiles := &tProfiles{switchErr: test.createErr}
+		r := &RPCServer{}
+		if !test.noManager {
+			r.profiles = profiles
+		}
+		payload := handleCreateProfile(r, test.params)
+		var res string
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && (profiles.created != "alice" || profiles.switched != "") {
+			t.Fatalf("%s: profile not created", test.name)
+		}
+	}
+}
+
+func TestCheckProfile(t *testing.T) {
+	r := &RPCServer{}
+	if r.checkProfile("", walletsRoute) != nil || r.checkProfile(defaultProfile, walletsRoute) != nil {
+		t.Fatalf("default profile rejected")
+	}
+	if r.checkProfile("alice", walletsRoute) == nil {
+		t.Fatalf("inactive profile accepted without a profile manager")
+	}
+	r.profiles = &tProfiles{active: "alice"}
+	if r.checkProfile("alice", walletsRoute) != nil {
+		t.Fatalf("active profile rejected")
+	}
+	payload := r.checkProfile("bob", walletsRoute)
+	if payload == nil || payload.Error.Code != msgjson.RPCProfileError {
+		t.Fatalf("inactive profile accepted")
+	}
+	if r.checkProfile("bob", profilesRoute) != nil || r.checkProfile("bob", switchProfileRoute) != nil ||
+		r.checkProfile("bob", createProfileRoute) != nil {
+		t.Fatalf("profile routes rejected")
+	}
+}
+
+func TestHandleCancelConditionalOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       *RawParams
+		condOrderErr error
+		wantErrCode  int
+	}{{
+		name:        "ok",
+		params:      &RawParams{Args: []string{"0102030405060708"}},
+		wantErrCode: -1,
+	}, {
+		name:         "core error",
+		params:       &RawParams{Args: []string{"0102030405060708"}},
+		condOrderErr: errors.New("error"),
+		wantErrCode:  msgjson.RPCConditionalOrderError,
+	}, {
+		name:        "bad id",
+		params:      &RawParams{Args: []string{"zz"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no id",
+		params:      &RawParams{},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}}
+	for _, test := range tests {
+		r := &RPCServer{core: &TCore{condOrderErr: test.condOrderErr}}
+		payload := handleCancelConditionalOrder(r, test.params)
+		var res string
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+	}
+}
+
 func TestHandleLogout(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1152,6 +1955,91 @@ func TestHandleAppSeed(t *testing.T) {
 	}
 }
 
+func TestHandleExportAppState(t *testing.T) {
+	pw := encode.PassBytes("abc")
+	tests := []struct {
+		name        string
+		params      *RawParams
+		appStateErr error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}},
+		wantErrCode: -1,
+	}, {
+		name:        "ok exclude secrets",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"true"}},
+		wantErrCode: -1,
+	}, {
+		name:        "bad excludeSecrets",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"maybe"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no pass",
+		params:      &RawParams{},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "core.ExportAppState error",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}},
+		appStateErr: errors.New("error"),
+		wantErrCode: msgjson.RPCAppStateError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{appStateBackup: []byte{0x01, 0x02}, appStateErr: test.appStateErr}
+		r := &RPCServer{core: tc}
+		payload := handleExportAppState(r, test.params)
+		res := ""
+		if err := verifyResponse(payload, &res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && res != "0102" {
+			t.Fatalf("%s: expected 0102 but got %s", test.name, res)
+		}
+	}
+}
+
+func TestHandleRestoreAppState(t *testing.T) {
+	pw := encode.PassBytes("abc")
+	tests := []struct {
+		name        string
+		params      *RawParams
+		appStateErr error
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"0102"}},
+		wantErrCode: -1,
+	}, {
+		name:        "not hex",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"backup"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "no backup",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "core.RestoreAppState error",
+		params:      &RawParams{PWArgs: []encode.PassBytes{pw}, Args: []string{"0102"}},
+		appStateErr: errors.New("error"),
+		wantErrCode: msgjson.RPCAppStateError,
+	}}
+	for _, test := range tests {
+		tc := &TCore{
+			appStateRestoration: &core.AppStateRestoration{Errors: []string{"wallet error"}},
+			appStateErr:         test.appStateErr,
+		}
+		r := &RPCServer{core: tc}
+		payload := handleRestoreAppState(r, test.params)
+		res := new(restoreAppStateResponse)
+		if err := verifyResponse(payload, res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 && (len(res.Errors) != 1 || res.Errors[0] != "wallet error") {
+			t.Fatalf("%s: wrong errors %v", test.name, res.Errors)
+		}
+	}
+}
+
 func TestHandleDiscoverAcct(t *testing.T) {
 	pw := encode.PassBytes("password123")
 	params := &RawParams{
@@ -1382,3 +2270,85 @@ func TestHandleSetVotingPreferences(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleAPIKeyRoutes(t *testing.T) {
+	// Without a key manager, the routes are unsupported.
+	r := &RPCServer{}
+	payload := handleAPIKeys(r, &RawParams{})
+	if err := verifyResponse(payload, &[]*apikey.Key{}, msgjson.RPCAPIKeyError); err != nil {
+		t.Fatalf("no error without a key manager")
+	}
+
+	keys, err := apikey.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	r.apiKeys = keys
+
+	newKeyTests := []struct {
+		name        string
+		params      *RawParams
+		wantErrCode int
+	}{{
+		name:        "ok",
+		params:      &RawParams{Args: []string{"bot", "read, trade", "127.0.0.1", "addr1,addr2"}},
+		wantErrCode: -1,
+	}, {
+		name:        "no permissions arg",
+		params:      &RawParams{Args: []string{"bot2"}},
+		wantErrCode: msgjson.RPCArgumentsError,
+	}, {
+		name:        "duplicate name",
+		params:      &RawParams{Args: []string{"bot", "read"}},
+		wantErrCode: msgjson.RPCAPIKeyError,
+	}, {
+		name:        "unknown permission",
+		params:      &RawParams{Args: []string{"bot2", "root"}},
+		wantErrCode: msgjson.RPCAPIKeyError,
+	}}
+	for _, test := range newKeyTests {
+		payload := handleNewAPIKey(r, test.params)
+		res := new(newAPIKeyResponse)
+		if err := verifyResponse(payload, res, test.wantErrCode); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if test.wantErrCode == -1 {
+			if res.Secret == "" || len(res.Key.Permissions) != 2 || len(res.Key.WithdrawAddrs) != 2 {
+				t.Fatalf("%s: wrong new key %+v", test.name, res.Key)
+			}
+		}
+	}
+
+	payload = handleAPIKeys(r, &RawParams{})
+	var list []*apikey.Key
+	if err := verifyResponse(payload, &list, -1); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "bot" {
+		t.Fatalf("wrong keys listed")
+	}
+
+	keys.Audit(&apikey.AuditEntry{Key: "bot", Route: walletsRoute, Allowed: true})
+	payload = handleAPIAuditLog(r, &RawParams{Args: []string{"5"}})
+	var entries []*apikey.AuditEntry
+	if err := verifyResponse(payload, &entries, -1); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(entries))
+	}
+	payload = handleAPIAuditLog(r, &RawParams{Args: []string{"x"}})
+	if err := verifyResponse(payload, &entries, msgjson.RPCArgumentsError); err != nil {
+		t.Fatalf("bad n: %v", err)
+	}
+
+	payload = handleRevokeAPIKey(r, &RawParams{Args: []string{"bot"}})
+	var msg string
+	if err := verifyResponse(payload, &msg, -1); err != nil {
+		t.Fatal(err)
+	}
+	payload = handleRevokeAPIKey(r, &RawParams{Args: []string{"bot"}})
+	if err := verifyResponse(payload, &msg, msgjson.RPCAPIKeyError); err != nil {
+		t.Fatalf("revoking unknown key: %v", err)
+	}
+}
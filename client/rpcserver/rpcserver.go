@@ -6,7 +6,6 @@ package rpcserver
 
 import (
 	"context"
-	"crypto/elliptic"
 	"crypto/sha256"
 	"crypto/subtle"
 	"crypto/tls"
@@ -16,10 +15,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/db"
@@ -27,7 +26,7 @@ import (
 	"decred.org/dcrdex/client/websocket"
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/msgjson"
-	"github.com/decred/dcrd/certgen"
+	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -44,6 +43,13 @@ const (
 	// rpcTimeoutSeconds is the number of seconds a connection to the RPC server
 	// is allowed to stay open without authenticating before it is closed.
 	rpcTimeoutSeconds = 10
+
+	// ProfileHeader is the HTTP request header naming the user profile that a
+	// request is intended for. Requests naming an inactive profile are
+	// rejected.
+	ProfileHeader = "X-Bison-Profile"
+	// defaultProfile is the profile name used when profiles are not managed.
+	defaultProfile = "default"
 )
 
 var (
@@ -77,7 +83,28 @@ type clientCore interface {
 	WalletState(assetID uint32) *core.WalletState
 	RescanWallet(assetID uint32, force bool) error
 	Send(appPass []byte, assetID uint32, value uint64, addr string, subtract bool) (asset.Coin, error)
+	SendPSBT(assetID uint32, value uint64, addr string, subtract bool) (string, error)
+	BroadcastPSBT(assetID uint32, signedPSBT string) (string, error)
+	AbandonPSBT(assetID uint32, unsignedPSBT string) (string, error)
+	RefundBondPSBT(host string, assetID uint32, bondID string) (string, error)
+	SendMulti(pw []byte, assetID uint32, payments []*asset.Payment) ([]asset.Coin, error)
+	EstimateSendMultiTxFee(assetID uint32, payments []*asset.Payment) (uint64, error)
+	PlaceConditionalOrder(pw []byte, form *core.ConditionalOrderForm) ([]*db.ConditionalOrder, error)
+	ConditionalOrders() ([]*db.ConditionalOrder, error)
+	CancelConditionalOrder(id dex.Bytes) error
+	AddMarketAlert(form *core.MarketAlertForm) (*db.MarketAlert, error)
+	MarketAlerts() ([]*db.MarketAlert, error)
+	RemoveMarketAlert(id dex.Bytes) error
+	AggregatedBook(base, quote uint32, hosts []string) (*core.AggregatedBook, error)
+	PlanRoute(form *core.RoutedTradeForm) (*core.RoutePlan, error)
+	RoutedTrade(pw []byte, form *core.RoutedTradeForm) (*core.RoutedOrder, error)
+	RoutedOrders() ([]*core.RoutedOrder, error)
+	PostMeshBond(pw []byte, form *core.MeshBondForm) (*tanka.Bond, error)
+	MeshBonds() ([]*tanka.Bond, error)
+	Ledger(form *core.LedgerForm) (*core.Ledger, error)
 	ExportSeed(pw []byte) (string, error)
+	ExportAppState(pw []byte, form *core.AppStateExportForm) ([]byte, error)
+	RestoreAppState(pw, bundle []byte) (*core.AppStateRestoration, error)
 	DeleteArchivedRecords(olderThan *time.Time, matchesFileStr, ordersFileStr string) (int, error)
 	WalletPeers(assetID uint32) ([]*asset.WalletPeer, error)
 	AddWalletPeer(assetID uint32, host string) error
@@ -101,6 +128,19 @@ type clientCore interface {
 	GenerateBCHRecoveryTransaction(appPW []byte, recipient string) ([]byte, error)
 }
 
+// profileManager manages the user profiles. It is satisfied by
+// *app.Profiles.
+type profileManager interface {
+	// Profiles returns the active profile and all existing profiles.
+	Profiles() (active string, all []string, err error)
+	// SwitchProfile logs out of the active profile and requests a switch to
+	// the named profile, which must exist.
+	SwitchProfile(name string) error
+	// CreateProfile creates the named profile, logs out of the active
+	// profile and requests a switch to the new profile.
+	CreateProfile(name string) error
+}
+
 // RPCServer is a single-client http and websocket server enabling a JSON
 // interface to Bison Wallet.
 type RPCServer struct {
@@ -115,31 +155,8 @@ type RPCServer struct {
 	wg        sync.WaitGroup
 	bwVersion *SemVersion
 	ctx       context.Context
-}
-
-// genCertPair generates a key/cert pair to the paths provided.
-func genCertPair(certFile, keyFile string, hosts []string) error {
-	log.Infof("Generating TLS certificates...")
-
-	org := "dcrdex autogenerated cert"
-	validUntil := time.Now().Add(10 * 365 * 24 * time.Hour)
-	cert, key, err := certgen.NewTLSCertPair(elliptic.P521(), org,
-		validUntil, hosts)
-	if err != nil {
-		return err
-	}
-
-	// Write cert and key files.
-	if err = os.WriteFile(certFile, cert, 0644); err != nil {
-		return err
-	}
-	if err = os.WriteFile(keyFile, key, 0600); err != nil {
-		os.Remove(certFile)
-		return err
-	}
-
-	log.Infof("Done generating TLS certificates")
-	return nil
+	profiles  profileManager
+	apiKeys   apiKeyManager
 }
 
 // writeJSON marshals the provided interface and writes the bytes to the
@@ -190,7 +207,26 @@ func (s *RPCServer) handleJSON(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Responses not accepted", http.StatusMethodNotAllowed)
 		return
 	}
-	s.parseHTTPRequest(w, req)
+	s.parseHTTPRequest(w, r, req)
+}
+
+// checkProfile returns an error payload if the request specifies a profile
+// that is not active. Requests to list, switch and create profiles are always
+// allowed.
+func (s *RPCServer) checkProfile(profile, route string) *msgjson.ResponsePayload {
+	if profile == "" || route == profilesRoute || route == switchProfileRoute || route == createProfileRoute {
+		return nil
+	}
+	active := defaultProfile
+	if s.profiles != nil {
+		active, _, _ = s.profiles.Profiles()
+	}
+	if profile == active {
+		return nil
+	}
+	return &msgjson.ResponsePayload{
+		Error: msgjson.NewError(msgjson.RPCProfileError, "profile %q is not active. the active profile is %q", profile, active),
+	}
 }
 
 // Config holds variables needed to create a new RPC Server.
@@ -200,6 +236,12 @@ type Config struct {
 	Addr, User, Pass, Cert, Key string
 	BWVersion                   *SemVersion
 	CertHosts                   []string
+	// Profiles is optional. If not set, only the default profile is
+	// available.
+	Profiles profileManager
+	// APIKeys is optional. If not set, only the RPC user and password are
+	// accepted.
+	APIKeys apiKeyManager
 }
 
 // SetLogger sets the logger for the RPCServer package.
@@ -221,7 +263,7 @@ func New(cfg *Config) (*RPCServer, error) {
 		return nil, fmt.Errorf("missing cert pair file")
 	}
 	if !keyExists && !certExists {
-		err := genCertPair(cfg.Cert, cfg.Key, cfg.CertHosts)
+		err := apikey.GenCertPair(cfg.Cert, cfg.Key, cfg.CertHosts, log)
 		if err != nil {
 			return nil, err
 		}
@@ -255,6 +297,8 @@ func New(cfg *Config) (*RPCServer, error) {
 		tlsConfig: tlsConfig,
 		bwVersion: cfg.BWVersion,
 		wsServer:  websocket.New(cfg.Core, log.SubLogger("WS")),
+		profiles:  cfg.Profiles,
+		apiKeys:   cfg.APIKeys,
 	}
 
 	// Create authSHA to verify requests against.
@@ -265,6 +309,7 @@ func New(cfg *Config) (*RPCServer, error) {
 
 	// Middleware
 	mux.Use(middleware.Recoverer)
+	mux.Use(peerAddr)
 	mux.Use(middleware.RealIP)
 	mux.Use(s.authMiddleware)
 
@@ -302,6 +347,12 @@ func (s *RPCServer) Connect(ctx context.Context) (*sync.WaitGroup, error) {
 
 	// Configure the websocket handler before starting the server.
 	s.mux.Get("/ws", func(w http.ResponseWriter, r *http.Request) {
+		k := requestAPIKey(r)
+		if k != nil && !k.Allows(routePermissions[wsRoute]) {
+			s.denyHTTP(w, r, k, wsRoute, fmt.Sprintf("api key %q does not have %q permission", k.Name, routePermissions[wsRoute]))
+			return
+		}
+		s.audit(k, r, wsRoute, true, nil)
 		s.wsServer.HandleConnect(ctx, w, r)
 	})
 
@@ -349,9 +400,21 @@ func (s *RPCServer) handleRequest(req *msgjson.Message) *msgjson.ResponsePayload
 }
 
 // parseHTTPRequest parses the msgjson message in the request body, creates a
-// response message, and writes it to the http.ResponseWriter.
-func (s *RPCServer) parseHTTPRequest(w http.ResponseWriter, req *msgjson.Message) {
-	payload := s.handleRequest(req)
+// response message, and writes it to the http.ResponseWriter. Requests made
+// with an API key are only handled if the key permits them. If a profile is
+// specified, the request is only handled if the profile is active. The call is
+// recorded in the audit log.
+func (s *RPCServer) parseHTTPRequest(w http.ResponseWriter, r *http.Request, req *msgjson.Message) {
+	k := requestAPIKey(r)
+	payload := authorize(k, req)
+	allowed := payload == nil
+	if allowed {
+		payload = s.checkProfile(r.Header.Get(ProfileHeader), req.Route)
+	}
+	if payload == nil {
+		payload = s.handleRequest(req)
+	}
+	s.audit(k, r, req.Route, allowed, payload.Error)
 	resp, err := msgjson.NewResponse(req.ID, payload.Result, payload.Error)
 	if err != nil {
 		msg := fmt.Sprintf("error encoding response: %v", err)
@@ -376,11 +439,31 @@ func (s *RPCServer) authMiddleware(next http.Handler) http.Handler {
 			return
 		}
 		authSHA := sha256.Sum256([]byte(auth[0]))
-		if subtle.ConstantTimeCompare(s.authSHA[:], authSHA[:]) != 1 {
+		if subtle.ConstantTimeCompare(s.authSHA[:], authSHA[:]) == 1 {
+			log.Debugf("authenticated user with ip: %s", r.RemoteAddr)
+			next.ServeHTTP(w, r)
+			return
+		}
+		if s.apiKeys == nil {
 			fail()
 			return
 		}
-		log.Debugf("authenticated user with ip: %s", r.RemoteAddr)
-		next.ServeHTTP(w, r)
+		// The user is the API key name and the password is its secret.
+		name, secret, ok := apikey.ParseBasicAuth(auth[0])
+		if !ok {
+			fail()
+			return
+		}
+		k, ok := s.apiKeys.Authenticate(name, secret)
+		if !ok {
+			fail()
+			return
+		}
+		if !k.AllowsIP(remoteIP(r)) {
+			s.denyHTTP(w, r, k, r.URL.Path, "ip address not allowed")
+			return
+		}
+		log.Debugf("authenticated api key %q with ip: %s", k.Name, remoteAddr(r))
+		next.ServeHTTP(w, withAPIKey(r, k))
 	})
 }
@@ -13,17 +13,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/db"
 	"decred.org/dcrdex/client/mnemonic"
 	"decred.org/dcrdex/client/orderbook"
 	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
 	"decred.org/dcrdex/dex/msgjson"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
 func init() {
@@ -49,6 +54,7 @@ type TCore struct {
 	wallets                  []*core.WalletState
 	initializeClientErr      error
 	postBondResult           *core.PostBondResult
+	postBondForm             *core.PostBondForm
 	postBondErr              error
 	bondOptsErr              error
 	exchanges                map[string]*core.Exchange
@@ -71,6 +77,23 @@ type TCore struct {
 	stakeStatus              *asset.TicketStakingStatus
 	stakeStatusErr           error
 	setVotingPrefErr         error
+	psbt                     string
+	psbtErr                  error
+	sendMultiCoins           []asset.Coin
+	sendMultiErr             error
+	condOrders               []*db.ConditionalOrder
+	routedOrders             []*core.RoutedOrder
+	ledger                   *core.Ledger
+	ledgerErr                error
+	routeErr                 error
+	condOrderErr             error
+	marketAlerts             []*db.MarketAlert
+	marketAlertErr           error
+	meshBonds                []*tanka.Bond
+	meshBondErr              error
+	appStateBackup           []byte
+	appStateRestoration      *core.AppStateRestoration
+	appStateErr              error
 }
 
 func (c *TCore) Balance(uint32) (uint64, error) {
@@ -132,7 +155,8 @@ func (c *TCore) RescanWallet(assetID uint32, force bool) error {
 func (c *TCore) GetDEXConfig(dexAddr string, certI any) (*core.Exchange, error) {
 	return c.dexExchange, c.getDEXConfigErr
 }
-func (c *TCore) PostBond(*core.PostBondForm) (*core.PostBondResult, error) {
+func (c *TCore) PostBond(form *core.PostBondForm) (*core.PostBondResult, error) {
+	c.postBondForm = form
 	return c.postBondResult, c.postBondErr
 }
 func (c *TCore) UpdateBondOptions(form *core.BondOptionsForm) error {
@@ -156,6 +180,12 @@ func (c *TCore) Send(pw []byte, assetID uint32, value uint64, addr string, subtr
 func (c *TCore) ExportSeed(pw []byte) (string, error) {
 	return c.exportSeed, c.exportSeedErr
 }
+func (c *TCore) ExportAppState(pw []byte, form *core.AppStateExportForm) ([]byte, error) {
+	return c.appStateBackup, c.appStateErr
+}
+func (c *TCore) RestoreAppState(pw, bundle []byte) (*core.AppStateRestoration, error) {
+	return c.appStateRestoration, c.appStateErr
+}
 func (c *TCore) DiscoverAccount(dexAddr string, pass []byte, certI any) (*core.Exchange, bool, error) {
 	return c.dexExchange, false, c.discoverAcctErr
 }
@@ -198,6 +228,63 @@ func (c *TCore) TxHistory(assetID uint32, n int, refID *string, past bool) ([]*a
 func (c *TCore) WalletTransaction(assetID uint32, txID string) (*asset.WalletTransaction, error) {
 	return nil, nil
 }
+func (c *TCore) SendPSBT(assetID uint32, value uint64, addr string, subtract bool) (string, error) {
+	return c.psbt, c.psbtErr
+}
+func (c *TCore) BroadcastPSBT(assetID uint32, signedPSBT string) (string, error) {
+	return "txid", c.psbtErr
+}
+func (c *TCore) AbandonPSBT(assetID uint32, unsignedPSBT string) (string, error) {
+	return "txid", c.psbtErr
+}
+func (c *TCore) RefundBondPSBT(host string, assetID uint32, bondID string) (string, error) {
+	return c.psbt, c.psbtErr
+}
+func (c *TCore) SendMulti(pw []byte, assetID uint32, payments []*asset.Payment) ([]asset.Coin, error) {
+	return c.sendMultiCoins, c.sendMultiErr
+}
+func (c *TCore) EstimateSendMultiTxFee(assetID uint32, payments []*asset.Payment) (uint64, error) {
+	return 1000, c.sendMultiErr
+}
+func (c *TCore) PlaceConditionalOrder(pw []byte, form *core.ConditionalOrderForm) ([]*db.ConditionalOrder, error) {
+	return c.condOrders, c.condOrderErr
+}
+func (c *TCore) ConditionalOrders() ([]*db.ConditionalOrder, error) {
+	return c.condOrders, c.condOrderErr
+}
+func (c *TCore) CancelConditionalOrder(id dex.Bytes) error {
+	return c.condOrderErr
+}
+func (c *TCore) AddMarketAlert(form *core.MarketAlertForm) (*db.MarketAlert, error) {
+	return &db.MarketAlert{ID: dex.Bytes{0x01}, Kind: form.Kind}, c.marketAlertErr
+}
+func (c *TCore) MarketAlerts() ([]*db.MarketAlert, error) {
+	return c.marketAlerts, c.marketAlertErr
+}
+func (c *TCore) RemoveMarketAlert(id dex.Bytes) error {
+	return c.marketAlertErr
+}
+func (c *TCore) AggregatedBook(base, quote uint32, hosts []string) (*core.AggregatedBook, error) {
+	return &core.AggregatedBook{Base: base, Quote: quote, Hosts: hosts}, c.routeErr
+}
+func (c *TCore) PlanRoute(form *core.RoutedTradeForm) (*core.RoutePlan, error) {
+	return &core.RoutePlan{Qty: form.Qty}, c.routeErr
+}
+func (c *TCore) RoutedTrade(pw []byte, form *core.RoutedTradeForm) (*core.RoutedOrder, error) {
+	return &core.RoutedOrder{ID: dex.Bytes{0x01}, Qty: form.Qty}, c.routeErr
+}
+func (c *TCore) Ledger(form *core.LedgerForm) (*core.Ledger, error) {
+	return c.ledger, c.ledgerErr
+}
+func (c *TCore) RoutedOrders() ([]*core.RoutedOrder, error) {
+	return c.routedOrders, c.routeErr
+}
+func (c *TCore) PostMeshBond(pw []byte, form *core.MeshBondForm) (*tanka.Bond, error) {
+	return &tanka.Bond{AssetID: form.AssetID, Strength: form.Strength}, c.meshBondErr
+}
+func (c *TCore) MeshBonds() ([]*tanka.Bond, error) {
+	return c.meshBonds, c.meshBondErr
+}
 func (c *TCore) GenerateBCHRecoveryTransaction(appPW []byte, recipient string) ([]byte, error) {
 	return nil, nil
 }
@@ -541,3 +628,174 @@ func TestAuthMiddleware(t *testing.T) {
 		wantAuthError(test.name, test.wantErr)
 	}
 }
+
+func TestAPIKeys(t *testing.T) {
+	s, shutdown := newTServer(t, false, "user", "abc")
+	defer shutdown()
+	keys, err := apikey.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	s.apiKeys = keys
+	_, readSecret, _ := keys.Create(&apikey.KeyForm{Name: "reader", Permissions: []apikey.Permission{apikey.PermRead}})
+	_, localSecret, _ := keys.Create(&apikey.KeyForm{
+		Name:        "local",
+		Permissions: []apikey.Permission{apikey.PermRead},
+		AllowedIPs:  []string{"127.0.0.1"},
+	})
+	h := peerAddr(middleware.RealIP(s.authMiddleware(http.HandlerFunc(s.handleJSON))))
+
+	do := func(name, secret, ip, route string, args ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		msg, _ := msgjson.NewRequest(1, route, &RawParams{Args: args})
+		b, _ := json.Marshal(msg)
+		r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(b))
+		r.RemoteAddr = ip + ":1234"
+		r.SetBasicAuth(name, secret)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	errCode := func(w *httptest.ResponseRecorder) int {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected HTTP status %d", w.Code)
+		}
+		resp := new(msgjson.Message)
+		if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+			t.Fatalf("unable to unmarshal response: %v", err)
+		}
+		payload := new(msgjson.ResponsePayload)
+		if err := json.Unmarshal(resp.Payload, payload); err != nil {
+			t.Fatalf("unable to unmarshal payload: %v", err)
+		}
+		if payload.Error == nil {
+			return -1
+		}
+		return payload.Error.Code
+	}
+
+	// The RPC user can use any route.
+	if code := errCode(do("user", "abc", "10.0.0.1", apiKeysRoute)); code != -1 {
+		t.Fatalf("RPC user got error code %d", code)
+	}
+	if w := do("reader", "wrong", "10.0.0.1", walletsRoute); w.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong secret got HTTP status %d", w.Code)
+	}
+	if code := errCode(do("reader", readSecret, "10.0.0.1", walletsRoute)); code != -1 {
+		t.Fatalf("read route got error code %d", code)
+	}
+	for _, route := range []string{tradeRoute, appSeedRoute, exportAppStateRoute, restoreAppStateRoute, newAPIKeyRoute} {
+		if code := errCode(do("reader", readSecret, "10.0.0.1", route)); code != msgjson.RPCPermissionError {
+			t.Fatalf("%s got error code %d", route, code)
+		}
+	}
+	if w := do("local", localSecret, "10.0.0.1", walletsRoute); w.Code != http.StatusForbidden {
+		t.Fatalf("disallowed ip got HTTP status %d", w.Code)
+	}
+	if code := errCode(do("local", localSecret, "127.0.0.1", walletsRoute)); code != -1 {
+		t.Fatalf("allowed ip got error code %d", code)
+	}
+	// The allowlist is checked against the connection address, not the
+	// client-provided headers.
+	msg, _ := msgjson.NewRequest(1, walletsRoute, &RawParams{})
+	b, _ := json.Marshal(msg)
+	r, _ := http.NewRequest("POST", "/", bytes.NewBuffer(b))
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("X-Real-IP", "127.0.0.1")
+	r.SetBasicAuth("local", localSecret)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("spoofed ip got HTTP status %d", w.Code)
+	}
+
+	entries, err := keys.AuditLog(0)
+	if err != nil {
+		t.Fatalf("AuditLog error: %v", err)
+	}
+	if len(entries) != 10 {
+		t.Fatalf("expected 10 audit entries, got %d", len(entries))
+	}
+	if e := entries[9]; e.Key != "" || e.Route != apiKeysRoute || !e.Allowed {
+		t.Fatalf("wrong RPC user audit entry %+v", e)
+	}
+	if e := entries[3]; e.Key != "reader" || e.Route != newAPIKeyRoute || e.Allowed {
+		t.Fatalf("wrong denied audit entry %+v", e)
+	}
+	// The spoofed header is not recorded as the client address.
+	if e := entries[0]; e.Key != "local" || e.IP != "10.0.0.1:1234" || e.Allowed {
+		t.Fatalf("wrong spoofed ip audit entry %+v", e)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	k := &apikey.Key{
+		Name:          "k",
+		Permissions:   []apikey.Permission{apikey.PermRead, apikey.PermFunds},
+		WithdrawAddrs: []string{"addr1", "addr2"},
+	}
+	payments := `[{"address":"addr1","value":1},{"address":"%s","value":1}]`
+	tests := []struct {
+		name    string
+		route   string
+		params  *RawParams
+		wantErr bool
+	}{{
+		name:   "read",
+		route:  walletsRoute,
+		params: &RawParams{},
+	}, {
+		name:    "no trade permission",
+		route:   tradeRoute,
+		params:  &RawParams{},
+		wantErr: true,
+	}, {
+		name:   "send to allowed address",
+		route:  sendRoute,
+		params: &RawParams{PWArgs: []encode.PassBytes{encode.PassBytes("pw")}, Args: []string{"42", "1", "addr2"}},
+	}, {
+		name:    "send to other address",
+		route:   withdrawRoute,
+		params:  &RawParams{PWArgs: []encode.PassBytes{encode.PassBytes("pw")}, Args: []string{"42", "1", "addr3"}},
+		wantErr: true,
+	}, {
+		name:   "sendmulti to allowed addresses",
+		route:  sendMultiRoute,
+		params: &RawParams{PWArgs: []encode.PassBytes{encode.PassBytes("pw")}, Args: []string{"42", fmt.Sprintf(payments, "addr2")}},
+	}, {
+		name:    "sendmulti to other address",
+		route:   sendMultiRoute,
+		params:  &RawParams{PWArgs: []encode.PassBytes{encode.PassBytes("pw")}, Args: []string{"42", fmt.Sprintf(payments, "addr3")}},
+		wantErr: true,
+	}, {
+		name:    "psbt to other address",
+		route:   sendPSBTRoute,
+		params:  &RawParams{Args: []string{"42", "1", "addr3"}},
+		wantErr: true,
+	}, {
+		name:    "broadcast psbt",
+		route:   broadcastPSBTRoute,
+		params:  &RawParams{Args: []string{"0", "psbt"}},
+		wantErr: true,
+	}, {
+		name:   "post bond",
+		route:  postBondRoute,
+		params: &RawParams{},
+	}}
+	for _, test := range tests {
+		req, _ := msgjson.NewRequest(1, test.route, test.params)
+		payload := authorize(k, req)
+		if (payload != nil) != test.wantErr {
+			t.Fatalf("%s: wanted error = %t, got %v", test.name, test.wantErr, payload)
+		}
+		if payload != nil && payload.Error.Code != msgjson.RPCPermissionError {
+			t.Fatalf("%s: wrong error code %d", test.name, payload.Error.Code)
+		}
+	}
+	// The RPC user is not restricted.
+	req, _ := msgjson.NewRequest(1, appSeedRoute, &RawParams{})
+	if payload := authorize(nil, req); payload != nil {
+		t.Fatalf("RPC user not authorized: %v", payload.Error)
+	}
+}
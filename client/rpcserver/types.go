@@ -10,8 +10,11 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
+	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/mm"
 	"decred.org/dcrdex/dex"
@@ -56,6 +59,22 @@ type getBondAssetsResponse struct {
 	Assets map[string]*core.BondAsset `json:"assets"`
 }
 
+// profilesResponse is the profiles response payload.
+type profilesResponse struct {
+	Active   string   `json:"active"`
+	Profiles []string `json:"profiles"`
+}
+
+// newAPIKeyResponse is the newapikey response payload.
+type restoreAppStateResponse struct {
+	Errors []string `json:"errors"`
+}
+
+type newAPIKeyResponse struct {
+	Key    *apikey.Key `json:"key"`
+	Secret string      `json:"secret"`
+}
+
 // tradeResponse is used when responding to the trade route.
 type tradeResponse struct {
 	OrderID string `json:"orderID"`
@@ -156,6 +175,38 @@ type multiTradeForm struct {
 	srvForm *core.MultiTradeForm
 }
 
+// conditionalOrderForm combines the application password and the user's
+// conditional order details.
+type conditionalOrderForm struct {
+	appPass encode.PassBytes
+	srvForm *core.ConditionalOrderForm
+}
+
+// routedTradeForm combines the application password and the user's routed
+// trade details.
+type routedTradeForm struct {
+	appPass encode.PassBytes
+	srvForm *core.RoutedTradeForm
+}
+
+// postMeshBondForm is information necessary to post a bond to the mesh.
+type postMeshBondForm struct {
+	appPass encode.PassBytes
+	srvForm *core.MeshBondForm
+}
+
+// aggregateBookForm is information necessary to build an aggregated book.
+type aggregateBookForm struct {
+	base, quote uint32
+	hosts       []string
+}
+
+// ledgerForm is information necessary to generate a ledger.
+type ledgerForm struct {
+	srvForm *core.LedgerForm
+	format  string
+}
+
 // cancelForm is information necessary to cancel a trade.
 type cancelForm struct {
 	orderID dex.Bytes
@@ -169,6 +220,21 @@ type sendOrWithdrawForm struct {
 	address string
 }
 
+// sendPSBTForm is information necessary to create an unsigned PSBT for a send
+// or withdraw.
+type sendPSBTForm struct {
+	assetID uint32
+	value   uint64
+	address string
+}
+
+// sendMultiForm is information necessary to pay multiple addresses.
+type sendMultiForm struct {
+	appPass  encode.PassBytes
+	assetID  uint32
+	payments []*asset.Payment
+}
+
 // orderBookForm is information necessary to fetch an order book.
 type orderBookForm struct {
 	host    string
@@ -184,6 +250,16 @@ type myOrdersForm struct {
 	quote *uint32
 }
 
+type exportAppStateForm struct {
+	appPass        encode.PassBytes
+	excludeSecrets bool
+}
+
+type restoreAppStateForm struct {
+	appPass encode.PassBytes
+	backup  []byte
+}
+
 type deleteRecordsForm struct {
 	olderThan                     *time.Time
 	ordersFileStr, matchesFileStr string
@@ -684,6 +760,140 @@ func parseCancelArgs(params *RawParams) (*cancelForm, error) {
 	return &cancelForm{orderID: oidB}, nil
 }
 
+func parseConditionalOrderArgs(params *RawParams) (*conditionalOrderForm, error) {
+	if err := checkNArgs(params, []int{1}, []int{1}); err != nil {
+		return nil, err
+	}
+	srvForm := new(core.ConditionalOrderForm)
+	if err := json.Unmarshal([]byte(params.Args[0]), srvForm); err != nil {
+		return nil, fmt.Errorf("%w: unable to unmarshal conditional order: %v", errArgs, err)
+	}
+	if srvForm.Trade == nil {
+		return nil, fmt.Errorf("%w: no trade specified", errArgs)
+	}
+	return &conditionalOrderForm{
+		appPass: params.PWArgs[0],
+		srvForm: srvForm,
+	}, nil
+}
+
+func parseCancelConditionalArgs(params *RawParams) (dex.Bytes, error) {
+	if err := checkNArgs(params, []int{0}, []int{1}); err != nil {
+		return nil, err
+	}
+	id, err := hex.DecodeString(params.Args[0])
+	if err != nil || len(id) == 0 {
+		return nil, fmt.Errorf("%w: invalid conditional order id", errArgs)
+	}
+	return id, nil
+}
+
+func parsePostMeshBondArgs(params *RawParams) (*postMeshBondForm, error) {
+	if err := checkNArgs(params, []int{1}, []int{1}); err != nil {
+		return nil, err
+	}
+	srvForm := new(core.MeshBondForm)
+	if err := json.Unmarshal([]byte(params.Args[0]), srvForm); err != nil {
+		return nil, fmt.Errorf("%w: unable to unmarshal mesh bond: %v", errArgs, err)
+	}
+	return &postMeshBondForm{appPass: params.PWArgs[0], srvForm: srvForm}, nil
+}
+
+func parseAddAlertArgs(params *RawParams) (*core.MarketAlertForm, error) {
+	if err := checkNArgs(params, []int{0}, []int{1}); err != nil {
+		return nil, err
+	}
+	form := new(core.MarketAlertForm)
+	if err := json.Unmarshal([]byte(params.Args[0]), form); err != nil {
+		return nil, fmt.Errorf("%w: unable to unmarshal market alert: %v", errArgs, err)
+	}
+	return form, nil
+}
+
+func parseRemoveAlertArgs(params *RawParams) (dex.Bytes, error) {
+	if err := checkNArgs(params, []int{0}, []int{1}); err != nil {
+		return nil, err
+	}
+	id, err := hex.DecodeString(params.Args[0])
+	if err != nil || len(id) == 0 {
+		return nil, fmt.Errorf("%w: invalid market alert id", errArgs)
+	}
+	return id, nil
+}
+
+func parseAggregateBookArgs(params *RawParams) (*aggregateBookForm, error) {
+	if err := checkNArgs(params, []int{0}, []int{2, 3}); err != nil {
+		return nil, err
+	}
+	base, err := checkUIntArg(params.Args[0], "base", 32)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := checkUIntArg(params.Args[1], "quote", 32)
+	if err != nil {
+		return nil, err
+	}
+	form := &aggregateBookForm{base: uint32(base), quote: uint32(quote)}
+	if len(params.Args) > 2 && params.Args[2] != "" {
+		for _, host := range strings.Split(params.Args[2], ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				form.hosts = append(form.hosts, host)
+			}
+		}
+	}
+	return form, nil
+}
+
+func parseRoutedTradeArgs(params *RawParams, needPass bool) (*routedTradeForm, error) {
+	nPWArgs := []int{0}
+	if needPass {
+		nPWArgs = []int{1}
+	}
+	if err := checkNArgs(params, nPWArgs, []int{1}); err != nil {
+		return nil, err
+	}
+	srvForm := new(core.RoutedTradeForm)
+	if err := json.Unmarshal([]byte(params.Args[0]), srvForm); err != nil {
+		return nil, fmt.Errorf("%w: unable to unmarshal routed trade: %v", errArgs, err)
+	}
+	if srvForm.Qty == 0 {
+		return nil, fmt.Errorf("%w: zero quantity", errArgs)
+	}
+	form := &routedTradeForm{srvForm: srvForm}
+	if needPass {
+		form.appPass = params.PWArgs[0]
+	}
+	return form, nil
+}
+
+func parseLedgerArgs(params *RawParams) (*ledgerForm, error) {
+	if err := checkNArgs(params, []int{0}, []int{0, 4}); err != nil {
+		return nil, err
+	}
+	form := &ledgerForm{srvForm: new(core.LedgerForm)}
+	if len(params.Args) > 0 && params.Args[0] != "" {
+		start, err := checkUIntArg(params.Args[0], "start", 64)
+		if err != nil {
+			return nil, err
+		}
+		form.srvForm.Start = start
+	}
+	if len(params.Args) > 1 && params.Args[1] != "" {
+		end, err := checkUIntArg(params.Args[1], "end", 64)
+		if err != nil {
+			return nil, err
+		}
+		form.srvForm.End = end
+	}
+	if len(params.Args) > 2 {
+		form.srvForm.Method = params.Args[2]
+	}
+	if len(params.Args) > 3 {
+		form.format = params.Args[3]
+	}
+	return form, nil
+}
+
 func parseSendOrWithdrawArgs(params *RawParams) (*sendOrWithdrawForm, error) {
 	if err := checkNArgs(params, []int{1}, []int{3}); err != nil {
 		return nil, err
@@ -705,6 +915,88 @@ func parseSendOrWithdrawArgs(params *RawParams) (*sendOrWithdrawForm, error) {
 	return req, nil
 }
 
+func parseSendPSBTArgs(params *RawParams) (*sendPSBTForm, error) {
+	if err := checkNArgs(params, []int{0}, []int{3}); err != nil {
+		return nil, err
+	}
+	assetID, err := checkUIntArg(params.Args[0], "assetID", 32)
+	if err != nil {
+		return nil, err
+	}
+	value, err := checkUIntArg(params.Args[1], "value", 64)
+	if err != nil {
+		return nil, err
+	}
+	return &sendPSBTForm{
+		assetID: uint32(assetID),
+		value:   value,
+		address: params.Args[2],
+	}, nil
+}
+
+// parseSendMultiArgs parses the arguments for sendmulti and sendmultifee. nPW
+// is the number of password arguments expected, 1 for sendmulti and 0 for
+// sendmultifee.
+func parseSendMultiArgs(params *RawParams, nPW int) (*sendMultiForm, error) {
+	if err := checkNArgs(params, []int{nPW}, []int{2}); err != nil {
+		return nil, err
+	}
+	assetID, err := checkUIntArg(params.Args[0], "assetID", 32)
+	if err != nil {
+		return nil, err
+	}
+	var payments []*asset.Payment
+	if err := json.Unmarshal([]byte(params.Args[1]), &payments); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal payments: %w", err)
+	}
+	if len(payments) == 0 {
+		return nil, errors.New("no payments")
+	}
+	form := &sendMultiForm{
+		assetID:  uint32(assetID),
+		payments: payments,
+	}
+	if nPW > 0 {
+		form.appPass = params.PWArgs[0]
+	}
+	return form, nil
+}
+
+// parsePSBTArgs parses the asset ID and base64-encoded PSBT arguments of the
+// broadcastpsbt and abandonpsbt routes.
+func parsePSBTArgs(params *RawParams) (assetID uint32, b64PSBT string, _ error) {
+	if err := checkNArgs(params, []int{0}, []int{2}); err != nil {
+		return 0, "", err
+	}
+	id, err := checkUIntArg(params.Args[0], "assetID", 32)
+	if err != nil {
+		return 0, "", err
+	}
+	return uint32(id), params.Args[1], nil
+}
+
+// refundBondPSBTForm is information necessary to create a bond refund PSBT.
+type refundBondPSBTForm struct {
+	host    string
+	assetID uint32
+	bondID  string
+}
+
+func parseRefundBondPSBTArgs(params *RawParams) (*refundBondPSBTForm, error) {
+	if err := checkNArgs(params, []int{0}, []int{3}); err != nil {
+		return nil, err
+	}
+	assetID, err := checkUIntArg(params.Args[1], "assetID", 32)
+	if err != nil {
+		return nil, err
+	}
+	return &refundBondPSBTForm{
+		host:    params.Args[0],
+		assetID: uint32(assetID),
+		bondID:  params.Args[2],
+	}, nil
+}
+
 func parseBchWithdrawArgs(params *RawParams) (appPW encode.PassBytes, recipient string, _ error) {
 	if err := checkNArgs(params, []int{1}, []int{1}); err != nil {
 		return nil, "", err
@@ -797,6 +1089,35 @@ func parseAppSeedArgs(params *RawParams) (encode.PassBytes, error) {
 	return params.PWArgs[0], nil
 }
 
+func parseExportAppStateArgs(params *RawParams) (*exportAppStateForm, error) {
+	if err := checkNArgs(params, []int{1}, []int{0, 1}); err != nil {
+		return nil, err
+	}
+	form := &exportAppStateForm{appPass: params.PWArgs[0]}
+	if len(params.Args) > 0 {
+		excludeSecrets, err := checkBoolArg(params.Args[0], "excludeSecrets")
+		if err != nil {
+			return nil, err
+		}
+		form.excludeSecrets = excludeSecrets
+	}
+	return form, nil
+}
+
+func parseRestoreAppStateArgs(params *RawParams) (*restoreAppStateForm, error) {
+	if err := checkNArgs(params, []int{1}, []int{1}); err != nil {
+		return nil, err
+	}
+	if len(params.PWArgs[0]) == 0 {
+		return nil, fmt.Errorf("app password cannot be empty")
+	}
+	backup, err := hex.DecodeString(strings.TrimSpace(params.Args[0]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: backup must be hex-encoded: %v", errArgs, err)
+	}
+	return &restoreAppStateForm{appPass: params.PWArgs[0], backup: backup}, nil
+}
+
 func parseDeleteArchivedRecordsArgs(params *RawParams) (form *deleteRecordsForm, err error) {
 	if err = checkNArgs(params, []int{0}, []int{0, 3}); err != nil {
 		return nil, err
@@ -860,6 +1181,52 @@ func parseNotificationsArgs(params *RawParams) (int, error) {
 	return int(num), nil
 }
 
+// defaultAuditLogN is the number of audit log entries returned by apiauditlog
+// if not specified.
+const defaultAuditLogN = 100
+
+// splitList splits a comma-separated list, dropping empty elements.
+func splitList(s string) []string {
+	var list []string
+	for _, el := range strings.Split(s, ",") {
+		if el = strings.TrimSpace(el); el != "" {
+			list = append(list, el)
+		}
+	}
+	return list
+}
+
+func parseNewAPIKeyArgs(params *RawParams) (*apikey.KeyForm, error) {
+	if err := checkNArgs(params, []int{0}, []int{2, 4}); err != nil {
+		return nil, err
+	}
+	form := &apikey.KeyForm{Name: params.Args[0]}
+	for _, p := range splitList(params.Args[1]) {
+		form.Permissions = append(form.Permissions, apikey.Permission(p))
+	}
+	if len(params.Args) > 2 {
+		form.AllowedIPs = splitList(params.Args[2])
+	}
+	if len(params.Args) > 3 {
+		form.WithdrawAddrs = splitList(params.Args[3])
+	}
+	return form, nil
+}
+
+func parseAPIAuditLogArgs(params *RawParams) (int, error) {
+	if err := checkNArgs(params, []int{0}, []int{0, 1}); err != nil {
+		return 0, err
+	}
+	if len(params.Args) == 0 {
+		return defaultAuditLogN, nil
+	}
+	n, err := checkUIntArg(params.Args[0], "n", 32)
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
 func parseMktWithHost(host, baseID, quoteID string) (*mm.MarketWithHost, error) {
 	mkt := new(mm.MarketWithHost)
 	mkt.Host = host
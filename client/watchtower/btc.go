@@ -0,0 +1,258 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	bchtxscript "github.com/gcash/bchd/txscript"
+	bchwire "github.com/gcash/bchd/wire"
+)
+
+// maxScanBlocks limits the blocks scanned by a single FindSpend call, so that
+// a long-offline watchtower catches up over several checks.
+const maxScanBlocks = 100
+
+// BTCChain is a Chain backed by the RPC server of a bitcoind full node. It
+// also works for Bitcoin Cash, which shares the transaction and block
+// serialization, though not the signature hash algorithm. Use NewBCHChain for
+// Bitcoin Cash.
+type BTCChain struct {
+	client *rpcclient.Client
+	log    dex.Logger
+	// verifyInput executes the input's scripts against the previous output.
+	verifyInput func(tx *wire.MsgTx, idx int, pkScript []byte, value int64) error
+}
+
+var _ Chain = (*BTCChain)(nil)
+
+// NewBTCChain is the constructor for a BTCChain. host is the RPC address,
+// host:port.
+func NewBTCChain(host, user, pass string, log dex.Logger) (*BTCChain, error) {
+	return newBTCChain(host, user, pass, log, verifyBTCInput)
+}
+
+// NewBCHChain is the constructor for a BTCChain backed by a Bitcoin Cash
+// node. host is the RPC address, host:port.
+func NewBCHChain(host, user, pass string, log dex.Logger) (*BTCChain, error) {
+	return newBTCChain(host, user, pass, log, verifyBCHInput)
+}
+
+func newBTCChain(host, user, pass string, log dex.Logger,
+	verifyInput func(*wire.MsgTx, int, []byte, int64) error) (*BTCChain, error) {
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		HTTPPostMode: true,
+		DisableTLS:   true,
+		Host:         host,
+		User:         user,
+		Pass:         pass,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RPC client: %w", err)
+	}
+	return &BTCChain{client: client, log: log, verifyInput: verifyInput}, nil
+}
+
+// Shutdown shuts down the RPC client.
+func (c *BTCChain) Shutdown() {
+	c.client.Shutdown()
+}
+
+// btcCoinID is the 32-byte tx hash followed by the 4-byte big-endian output
+// index, matching client/asset/btc.
+func btcCoinID(op *wire.OutPoint) []byte {
+	b := make([]byte, chainhash.HashSize+4)
+	copy(b, op.Hash[:])
+	binary.BigEndian.PutUint32(b[chainhash.HashSize:], op.Index)
+	return b
+}
+
+func decodeBTCCoinID(coinID []byte) (*chainhash.Hash, uint32, error) {
+	if len(coinID) != chainhash.HashSize+4 {
+		return nil, 0, fmt.Errorf("coin ID wrong length. expected %d, got %d", chainhash.HashSize+4, len(coinID))
+	}
+	var txHash chainhash.Hash
+	copy(txHash[:], coinID[:chainhash.HashSize])
+	return &txHash, binary.BigEndian.Uint32(coinID[chainhash.HashSize:]), nil
+}
+
+func decodeBTCTx(b []byte) (*wire.MsgTx, error) {
+	msgTx := new(wire.MsgTx)
+	if err := msgTx.Deserialize(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	if len(msgTx.TxIn) == 0 {
+		return nil, errors.New("no inputs")
+	}
+	return msgTx, nil
+}
+
+// ParseTx decodes the transaction, returning the coin spent by the first
+// input and the lock time. Part of the Chain interface.
+func (c *BTCChain) ParseTx(b []byte) ([]byte, time.Time, error) {
+	msgTx, err := decodeBTCTx(b)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return btcCoinID(&msgTx.TxIn[0].PreviousOutPoint), time.Unix(int64(msgTx.LockTime), 0), nil
+}
+
+// VerifySpend checks that the first input of the transaction is a fully signed
+// spend of the unspent output it references, by executing the input's scripts
+// against the output's pubkey script and value. Part of the Chain interface.
+func (c *BTCChain) VerifySpend(_ context.Context, b []byte) error {
+	msgTx, err := decodeBTCTx(b)
+	if err != nil {
+		return err
+	}
+	op := &msgTx.TxIn[0].PreviousOutPoint
+	txOut, err := c.client.GetTxOut(&op.Hash, op.Index, true)
+	if err != nil {
+		return fmt.Errorf("error getting spent output: %w", err)
+	}
+	if txOut == nil {
+		return fmt.Errorf("output %s is spent or unknown", op)
+	}
+	pkScript, err := hex.DecodeString(txOut.ScriptPubKey.Hex)
+	if err != nil {
+		return fmt.Errorf("error decoding pubkey script: %w", err)
+	}
+	value, err := btcutil.NewAmount(txOut.Value)
+	if err != nil {
+		return fmt.Errorf("invalid output value: %w", err)
+	}
+	if err := c.verifyInput(msgTx, 0, pkScript, int64(value)); err != nil {
+		return fmt.Errorf("invalid spend of %s: %w", op, err)
+	}
+	return nil
+}
+
+func verifyBTCInput(tx *wire.MsgTx, idx int, pkScript []byte, value int64) error {
+	prevOuts := txscript.NewCannedPrevOutputFetcher(pkScript, value)
+	vm, err := txscript.NewEngine(pkScript, tx, idx, txscript.StandardVerifyFlags, nil,
+		txscript.NewTxSigHashes(tx, prevOuts), value, prevOuts)
+	if err != nil {
+		return err
+	}
+	return vm.Execute()
+}
+
+func verifyBCHInput(tx *wire.MsgTx, idx int, pkScript []byte, value int64) error {
+	var buf bytes.Buffer
+	if err := tx.SerializeNoWitness(&buf); err != nil {
+		return err
+	}
+	bchTx := new(bchwire.MsgTx)
+	if err := bchTx.Deserialize(&buf); err != nil {
+		return err
+	}
+	utxos := bchtxscript.NewUtxoCache()
+	utxos.AddEntry(idx, *bchwire.NewTxOut(value, pkScript))
+	vm, err := bchtxscript.NewEngine(pkScript, bchTx, idx, bchtxscript.StandardVerifyFlags, nil,
+		bchtxscript.NewTxSigHashes(bchTx), utxos, value)
+	if err != nil {
+		return err
+	}
+	return vm.Execute()
+}
+
+// Height is the best block height. Part of the Chain interface.
+func (c *BTCChain) Height(context.Context) (int64, error) {
+	return c.client.GetBlockCount()
+}
+
+// Unspent checks whether the output is unspent, including mempool spends.
+// Part of the Chain interface.
+func (c *BTCChain) Unspent(_ context.Context, coinID []byte) (bool, error) {
+	txHash, vout, err := decodeBTCCoinID(coinID)
+	if err != nil {
+		return false, err
+	}
+	txOut, err := c.client.GetTxOut(txHash, vout, true)
+	if err != nil {
+		return false, err
+	}
+	return txOut != nil, nil
+}
+
+// FindSpend scans blocks for an input spending the output. Part of the Chain
+// interface.
+func (c *BTCChain) FindSpend(ctx context.Context, coinID []byte, fromHeight int64) ([][]byte, int64, error) {
+	txHash, vout, err := decodeBTCCoinID(coinID)
+	if err != nil {
+		return nil, fromHeight, err
+	}
+	tip, err := c.client.GetBlockCount()
+	if err != nil {
+		return nil, fromHeight, err
+	}
+	if tip > fromHeight+maxScanBlocks {
+		tip = fromHeight + maxScanBlocks
+	}
+	scanned := fromHeight
+	for h := fromHeight; h <= tip; h++ {
+		if ctx.Err() != nil {
+			return nil, scanned, ctx.Err()
+		}
+		blockHash, err := c.client.GetBlockHash(h)
+		if err != nil {
+			return nil, scanned, err
+		}
+		block, err := c.client.GetBlock(blockHash)
+		if err != nil {
+			return nil, scanned, err
+		}
+		for _, tx := range block.Transactions {
+			for _, txIn := range tx.TxIn {
+				op := txIn.PreviousOutPoint
+				if op.Index != vout || op.Hash != *txHash {
+					continue
+				}
+				return inputPushes(txIn), h, nil
+			}
+		}
+		scanned = h
+	}
+	return nil, scanned, nil
+}
+
+// inputPushes returns the witness items or signature script data pushes of the
+// input.
+func inputPushes(txIn *wire.TxIn) [][]byte {
+	pushes := make([][]byte, 0, len(txIn.Witness)+5)
+	pushes = append(pushes, txIn.Witness...)
+	if len(txIn.SignatureScript) > 0 {
+		sigPushes, _ := txscript.PushedData(txIn.SignatureScript)
+		pushes = append(pushes, sigPushes...)
+	}
+	return pushes
+}
+
+// Broadcast sends the transaction to the network. Part of the Chain
+// interface.
+func (c *BTCChain) Broadcast(_ context.Context, b []byte) (string, error) {
+	msgTx, err := decodeBTCTx(b)
+	if err != nil {
+		return "", err
+	}
+	txHash, err := c.client.SendRawTransaction(msgTx, false)
+	if err != nil {
+		return "", err
+	}
+	c.log.Debugf("Broadcast tx %s", txHash)
+	return txHash.String(), nil
+}
@@ -0,0 +1,269 @@
+//go:build harness
+
+package watchtower
+
+// The harness tests run the watchtower against the BTC simnet harness, which
+// must be running. See dex/testing/btc/harness.sh.
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	alphaRPC  = "127.0.0.1:20556"
+	swapValue = 1e7
+	txFee     = 5000
+)
+
+var (
+	tLogger = dex.StdOutLogger("WT_HARNESS", dex.LevelDebug)
+	tParams = &chaincfg.RegressionNetParams
+)
+
+type harness struct {
+	t      *testing.T
+	chain  *BTCChain
+	wallet *rpcclient.Client
+}
+
+func newHarness(t *testing.T) *harness {
+	chain, err := NewBTCChain(alphaRPC, "user", "pass", tLogger)
+	if err != nil {
+		t.Fatalf("NewBTCChain error: %v", err)
+	}
+	wallet, err := rpcclient.New(&rpcclient.ConnConfig{
+		HTTPPostMode: true,
+		DisableTLS:   true,
+		Host:         alphaRPC + "/wallet/",
+		User:         "user",
+		Pass:         "pass",
+	}, nil)
+	if err != nil {
+		t.Fatalf("error creating wallet client: %v", err)
+	}
+	t.Cleanup(func() {
+		chain.Shutdown()
+		wallet.Shutdown()
+	})
+	return &harness{t: t, chain: chain, wallet: wallet}
+}
+
+func (h *harness) mine() {
+	h.t.Helper()
+	addr, err := h.wallet.GetNewAddress("")
+	if err != nil {
+		h.t.Fatalf("GetNewAddress error: %v", err)
+	}
+	if _, err := h.wallet.GenerateToAddress(1, addr, nil); err != nil {
+		h.t.Fatalf("GenerateToAddress error: %v", err)
+	}
+}
+
+type tKey struct {
+	priv *btcec.PrivateKey
+	addr btcutil.Address
+}
+
+func newKey(t *testing.T) *tKey {
+	priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		t.Fatalf("NewPrivateKey error: %v", err)
+	}
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(priv.PubKey().SerializeCompressed()), tParams)
+	if err != nil {
+		t.Fatalf("error creating address: %v", err)
+	}
+	return &tKey{priv: priv, addr: addr}
+}
+
+// swap funds a segwit swap contract from the harness wallet and mines it.
+func (h *harness) swap(sender, receiver *tKey, secretHash []byte, lockTime time.Time) (*wire.OutPoint, []byte) {
+	h.t.Helper()
+	contract, err := dexbtc.MakeContract(receiver.addr, sender.addr, secretHash, lockTime.Unix(), true, tParams)
+	if err != nil {
+		h.t.Fatalf("MakeContract error: %v", err)
+	}
+	scriptHash := sha256.Sum256(contract)
+	contractAddr, err := btcutil.NewAddressWitnessScriptHash(scriptHash[:], tParams)
+	if err != nil {
+		h.t.Fatalf("error creating contract address: %v", err)
+	}
+	txHash, err := h.wallet.SendToAddress(contractAddr, swapValue)
+	if err != nil {
+		h.t.Fatalf("SendToAddress error: %v", err)
+	}
+	tx, err := h.chain.client.GetRawTransaction(txHash)
+	if err != nil {
+		h.t.Fatalf("GetRawTransaction error: %v", err)
+	}
+	pkScript, _ := txscript.PayToAddrScript(contractAddr)
+	for vout, txOut := range tx.MsgTx().TxOut {
+		if bytes.Equal(txOut.PkScript, pkScript) {
+			h.mine()
+			return wire.NewOutPoint(txHash, uint32(vout)), contract
+		}
+	}
+	h.t.Fatalf("contract output not found")
+	return nil, nil
+}
+
+// spend creates a signed transaction spending the contract to the key's
+// address. A nil secret creates a refund.
+func spend(t *testing.T, op *wire.OutPoint, contract []byte, key *tKey, secret []byte, lockTime time.Time) *wire.MsgTx {
+	t.Helper()
+	tx := wire.NewMsgTx(wire.TxVersion)
+	txIn := wire.NewTxIn(op, nil, nil)
+	if secret == nil {
+		tx.LockTime = uint32(lockTime.Unix())
+		txIn.Sequence = wire.MaxTxInSequenceNum - 1
+	}
+	tx.AddTxIn(txIn)
+	pkScript, _ := txscript.PayToAddrScript(key.addr)
+	tx.AddTxOut(wire.NewTxOut(swapValue-txFee, pkScript))
+
+	scriptHash := sha256.Sum256(contract)
+	contractAddr, _ := btcutil.NewAddressWitnessScriptHash(scriptHash[:], tParams)
+	prevScript, _ := txscript.PayToAddrScript(contractAddr)
+	sigHashes := txscript.NewTxSigHashes(tx, txscript.NewCannedPrevOutputFetcher(prevScript, swapValue))
+	sig, err := txscript.RawTxInWitnessSignature(tx, sigHashes, 0, swapValue, contract, txscript.SigHashAll, key.priv)
+	if err != nil {
+		t.Fatalf("signing error: %v", err)
+	}
+	pubKey := key.priv.PubKey().SerializeCompressed()
+	if secret == nil {
+		txIn.Witness = dexbtc.RefundP2WSHContract(contract, sig, pubKey)
+	} else {
+		txIn.Witness = dexbtc.RedeemP2WSHContract(contract, sig, pubKey, secret)
+	}
+	return tx
+}
+
+func serialize(t *testing.T, tx *wire.MsgTx) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatalf("Serialize error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func newHarnessServer(t *testing.T, h *harness) (*Server, string) {
+	s, err := NewServer(&Config{
+		DBPath: filepath.Join(t.TempDir(), "wt.db"),
+		Chains: map[uint32]Chain{0: h.chain},
+		Logger: tLogger,
+	})
+	if err != nil {
+		t.Fatalf("NewServer error: %v", err)
+	}
+	srv := httptest.NewServer(s.handler())
+	t.Cleanup(func() {
+		srv.Close()
+		s.db.Close()
+	})
+	return s, srv.URL
+}
+
+func TestHarnessRefund(t *testing.T) {
+	h := newHarness(t)
+	s, url := newHarnessServer(t, h)
+	ctx := context.Background()
+
+	sender, receiver := newKey(t), newKey(t)
+	secretHash := sha256.Sum256(encode.RandomBytes(32))
+	// Expired well past the median time past.
+	lockTime := time.Now().Add(-3 * time.Hour)
+	op, contract := h.swap(sender, receiver, secretHash[:], lockTime)
+	reg := &Registration{
+		AssetID:    0,
+		SwapCoinID: btcCoinID(op),
+		Refund:     serialize(t, spend(t, op, contract, sender, nil, lockTime)),
+	}
+	if err := Register(ctx, url, reg); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+
+	s.checkAll(ctx)
+	st, err := GetStatus(ctx, url, reg.ID())
+	if err != nil {
+		t.Fatalf("GetStatus error: %v", err)
+	}
+	if st.RefundTxID == "" {
+		t.Fatalf("refund not broadcast")
+	}
+	h.mine()
+	s.checkAll(ctx)
+	if st, _ = GetStatus(ctx, url, reg.ID()); st.State != StateDone {
+		t.Fatalf("wrong state %s after refund mined", st.State)
+	}
+}
+
+func TestHarnessRedeem(t *testing.T) {
+	h := newHarness(t)
+	s, url := newHarnessServer(t, h)
+	ctx := context.Background()
+
+	maker, taker := newKey(t), newKey(t)
+	secret := encode.RandomBytes(32)
+	secretHash := sha256.Sum256(secret)
+	makerLockTime, takerLockTime := time.Now().Add(8*time.Hour), time.Now().Add(4*time.Hour)
+	makerSwap, makerContract := h.swap(maker, taker, secretHash[:], makerLockTime)
+	takerSwap, takerContract := h.swap(taker, maker, secretHash[:], takerLockTime)
+
+	// The taker goes offline with a redeem template for the maker's swap.
+	placeholder := encode.RandomBytes(32)
+	template := serialize(t, spend(t, makerSwap, makerContract, taker, placeholder, time.Time{}))
+	er, err := SealRedeem(0, template, bytes.Index(template, placeholder), secretHash[:])
+	if err != nil {
+		t.Fatalf("SealRedeem error: %v", err)
+	}
+	reg := &Registration{
+		AssetID:    0,
+		SwapCoinID: btcCoinID(takerSwap),
+		Refund:     serialize(t, spend(t, takerSwap, takerContract, taker, nil, takerLockTime)),
+		Redeem:     er,
+	}
+	if err := Register(ctx, url, reg); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+
+	// Not refundable yet.
+	s.checkAll(ctx)
+	if st, _ := GetStatus(ctx, url, reg.ID()); st.State != StateWatching || st.RefundTxID != "" {
+		t.Fatalf("wrong status before maker redeem %+v", st)
+	}
+
+	// The maker redeems the taker's swap, revealing the secret.
+	if _, err := h.chain.Broadcast(ctx, serialize(t, spend(t, takerSwap, takerContract, maker, secret, time.Time{}))); err != nil {
+		t.Fatalf("error broadcasting maker redeem: %v", err)
+	}
+	h.mine()
+	s.checkAll(ctx)
+	st, err := GetStatus(ctx, url, reg.ID())
+	if err != nil {
+		t.Fatalf("GetStatus error: %v", err)
+	}
+	if st.State != StateDone || st.RedeemTxID == "" {
+		t.Fatalf("wrong status after maker redeem %+v", st)
+	}
+	h.mine()
+	if unspent, err := h.chain.Unspent(ctx, btcCoinID(makerSwap)); err != nil || unspent {
+		t.Fatalf("maker's swap not redeemed (err = %v)", err)
+	}
+}
@@ -0,0 +1,475 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"github.com/go-chi/chi/v5"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	defaultCheckInterval = 30 * time.Second
+	defaultRetention     = 7 * 24 * time.Hour
+	defaultMaxActive     = 10_000
+	maxRegistrationSize  = 1 << 16
+)
+
+var registrationsBucket = []byte("registrations")
+
+// Chain is a blockchain backend for a single asset.
+type Chain interface {
+	// ParseTx decodes the serialized transaction, returning the coin ID of
+	// the output spent by its first input and its lock time.
+	ParseTx(tx []byte) (spends []byte, lockTime time.Time, err error)
+	// VerifySpend checks that the first input of the serialized transaction
+	// is a fully signed, valid spend of the unspent output it references.
+	VerifySpend(ctx context.Context, tx []byte) error
+	// Height is the best block height.
+	Height(ctx context.Context) (int64, error)
+	// Unspent checks whether the output is unspent, considering mempool
+	// spends.
+	Unspent(ctx context.Context, coinID []byte) (bool, error)
+	// FindSpend scans the blocks from fromHeight to the tip for an input
+	// spending the output, and returns the input's data pushes, or nil if
+	// the spend was not found. The last scanned height is returned.
+	FindSpend(ctx context.Context, coinID []byte, fromHeight int64) (pushes [][]byte, scanned int64, err error)
+	// Broadcast sends the serialized transaction to the network.
+	Broadcast(ctx context.Context, tx []byte) (txID string, err error)
+}
+
+// Config is the configuration for a Server.
+type Config struct {
+	// DBPath is the registration database file path.
+	DBPath string
+	// Addr is the HTTP listen address.
+	Addr   string
+	Chains map[uint32]Chain
+	Logger dex.Logger
+	// CheckInterval is the interval between checks of the watched swaps.
+	// Default 30 seconds.
+	CheckInterval time.Duration
+	// Retention is how long completed registrations are kept. Default 7
+	// days.
+	Retention time.Duration
+	// MaxActive is the maximum number of registrations being watched.
+	// Default 10,000.
+	MaxActive int
+}
+
+// record is a stored registration.
+type record struct {
+	Reg        *Registration `json:"reg"`
+	State      State         `json:"state"`
+	LockTime   int64         `json:"lockTime"`
+	ScanHeight int64         `json:"scanHeight"`
+	Secret     dex.Bytes     `json:"secret,omitempty"`
+	RefundTxID string        `json:"refundTxID,omitempty"`
+	RedeemTxID string        `json:"redeemTxID,omitempty"`
+	// Stamp is the time of the last state change, in unix seconds.
+	Stamp int64 `json:"stamp"`
+}
+
+func (r *record) status() *Status {
+	return &Status{
+		ID:         r.Reg.ID(),
+		AssetID:    r.Reg.AssetID,
+		State:      r.State,
+		LockTime:   r.LockTime,
+		RefundTxID: r.RefundTxID,
+		RedeemTxID: r.RedeemTxID,
+	}
+}
+
+// Server is a swap watchtower. Clients register swaps over HTTP, and the
+// Server broadcasts refunds and redeems on their behalf.
+type Server struct {
+	cfg    *Config
+	log    dex.Logger
+	chains map[uint32]Chain
+	db     *bbolt.DB
+	// mtx serializes registration against the check loop.
+	mtx sync.Mutex
+}
+
+// NewServer is the constructor for a Server.
+func NewServer(cfg *Config) (*Server, error) {
+	if len(cfg.Chains) == 0 {
+		return nil, errors.New("no chains configured")
+	}
+	db, err := bbolt.Open(cfg.DBPath, 0600, &bbolt.Options{Timeout: 3 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(registrationsBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	s := &Server{
+		cfg:    cfg,
+		log:    cfg.Logger,
+		chains: cfg.Chains,
+		db:     db,
+	}
+	if s.cfg.CheckInterval <= 0 {
+		s.cfg.CheckInterval = defaultCheckInterval
+	}
+	if s.cfg.Retention <= 0 {
+		s.cfg.Retention = defaultRetention
+	}
+	if s.cfg.MaxActive <= 0 {
+		s.cfg.MaxActive = defaultMaxActive
+	}
+	return s, nil
+}
+
+// Run runs the HTTP server and the check loop until the context is canceled.
+func (s *Server) Run(ctx context.Context) error {
+	defer s.db.Close()
+
+	ln, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", s.cfg.Addr, err)
+	}
+	srv := &http.Server{
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		srv.Close()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.checkLoop(ctx)
+	}()
+	s.log.Infof("Watchtower listening on %s", ln.Addr())
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		s.log.Errorf("HTTP server error: %v", err)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (s *Server) handler() http.Handler {
+	mux := chi.NewRouter()
+	mux.Post(RegisterRoute, s.handleRegister)
+	mux.Get(StatusRoute+"{id}", s.handleStatus)
+	return mux
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	reg := new(Registration)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRegistrationSize)).Decode(reg); err != nil {
+		http.Error(w, "invalid registration: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	st, err := s.register(r.Context(), reg)
+	if err != nil {
+		s.log.Debugf("Rejected registration from %s: %v", r.RemoteAddr, err)
+		code := http.StatusBadRequest
+		if errors.Is(err, ErrAlreadyRegistered) {
+			code = http.StatusConflict
+		}
+		http.Error(w, err.Error(), code)
+		return
+	}
+	writeJSON(w, st)
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	rec, err := s.record(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "unknown registration", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, rec.status())
+}
+
+func writeJSON(w http.ResponseWriter, thing any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(thing); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// register validates and stores the registration. The refund must be a valid,
+// fully signed spend of the swap, so only the owner of the swap's refund key
+// can register it. A registration cannot be replaced, since anyone who has
+// seen it could otherwise replace it with one that strips the redeem
+// template. Re-sending an identical registration is not an error.
+func (s *Server) register(ctx context.Context, reg *Registration) (*Status, error) {
+	chain, found := s.chains[reg.AssetID]
+	if !found {
+		return nil, fmt.Errorf("asset %d not supported", reg.AssetID)
+	}
+	if reg.Redeem != nil {
+		if _, found := s.chains[reg.Redeem.AssetID]; !found {
+			return nil, fmt.Errorf("redeem asset %d not supported", reg.Redeem.AssetID)
+		}
+	}
+	spends, lockTime, err := chain.ParseTx(reg.Refund)
+	if err != nil {
+		return nil, fmt.Errorf("invalid refund tx: %w", err)
+	}
+	if string(spends) != string(reg.SwapCoinID) {
+		return nil, errors.New("refund tx does not spend the swap")
+	}
+	unspent, err := chain.Unspent(ctx, reg.SwapCoinID)
+	if err != nil {
+		return nil, fmt.Errorf("error checking swap output: %w", err)
+	}
+	if !unspent {
+		return nil, errors.New("swap output is spent or unknown")
+	}
+	if err := chain.VerifySpend(ctx, reg.Refund); err != nil {
+		return nil, fmt.Errorf("invalid refund tx: %w", err)
+	}
+	height, err := chain.Height(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting chain height: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	id := reg.ID()
+	if old, err := s.record(id); err == nil {
+		if old.State == StateWatching && old.Reg.equal(reg) {
+			return old.status(), nil
+		}
+		return nil, fmt.Errorf("%w: %s is %s", ErrAlreadyRegistered, id, old.State)
+	}
+	if n, err := s.activeCount(); err != nil {
+		return nil, err
+	} else if n >= s.cfg.MaxActive {
+		return nil, errors.New("watchtower is at capacity")
+	}
+	rec := &record{
+		Reg:        reg,
+		State:      StateWatching,
+		LockTime:   lockTime.Unix(),
+		ScanHeight: height,
+		Stamp:      time.Now().Unix(),
+	}
+	if err := s.storeRecord(rec); err != nil {
+		return nil, err
+	}
+	s.log.Infof("Registered swap %s for asset %d, lock time %s, redeem = %t",
+		id, reg.AssetID, lockTime, reg.Redeem != nil)
+	return rec.status(), nil
+}
+
+func (s *Server) record(id string) (*record, error) {
+	rec := new(record)
+	return rec, s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(registrationsBucket).Get([]byte(id))
+		if b == nil {
+			return fmt.Errorf("registration %s not found", id)
+		}
+		return json.Unmarshal(b, rec)
+	})
+}
+
+func (s *Server) storeRecord(rec *record) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(registrationsBucket).Put([]byte(rec.Reg.ID()), b)
+	})
+}
+
+func (s *Server) activeCount() (n int, err error) {
+	return n, s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(registrationsBucket).ForEach(func(_, v []byte) error {
+			var rec record
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+			if rec.State != StateDone {
+				n++
+			}
+			return nil
+		})
+	})
+}
+
+func (s *Server) checkLoop(ctx context.Context) {
+	tick := time.NewTicker(s.cfg.CheckInterval)
+	defer tick.Stop()
+	for {
+		s.checkAll(ctx)
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkAll checks every active registration and prunes expired ones.
+func (s *Server) checkAll(ctx context.Context) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var recs []*record
+	var expired [][]byte
+	pruneStamp := time.Now().Add(-s.cfg.Retention).Unix()
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(registrationsBucket).ForEach(func(k, v []byte) error {
+			rec := new(record)
+			if err := json.Unmarshal(v, rec); err != nil {
+				s.log.Errorf("Error decoding registration %s: %v", k, err)
+				return nil
+			}
+			if rec.State != StateDone {
+				recs = append(recs, rec)
+			} else if rec.Stamp < pruneStamp {
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			return nil
+		})
+	}); err != nil {
+		s.log.Errorf("Error reading registrations: %v", err)
+		return
+	}
+	for _, rec := range recs {
+		if ctx.Err() != nil {
+			return
+		}
+		if !s.check(ctx, rec) {
+			continue
+		}
+		rec.Stamp = time.Now().Unix()
+		if err := s.storeRecord(rec); err != nil {
+			s.log.Errorf("Error storing registration %s: %v", rec.Reg.ID(), err)
+		}
+	}
+	if len(expired) > 0 {
+		if err := s.db.Update(func(tx *bbolt.Tx) error {
+			bkt := tx.Bucket(registrationsBucket)
+			for _, k := range expired {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			s.log.Errorf("Error pruning registrations: %v", err)
+		} else {
+			s.log.Debugf("Pruned %d completed registrations", len(expired))
+		}
+	}
+}
+
+// check advances the registration, returning true if it changed.
+func (s *Server) check(ctx context.Context, rec *record) bool {
+	id, reg := rec.Reg.ID(), rec.Reg
+	if rec.State == StateRedeeming {
+		return s.redeem(ctx, rec)
+	}
+
+	chain := s.chains[reg.AssetID]
+	if chain == nil {
+		return false
+	}
+	unspent, err := chain.Unspent(ctx, reg.SwapCoinID)
+	if err != nil {
+		s.log.Errorf("Error checking swap %s: %v", id, err)
+		return false
+	}
+	if unspent {
+		if time.Now().Unix() < rec.LockTime {
+			return false
+		}
+		txID, err := chain.Broadcast(ctx, reg.Refund)
+		if err != nil {
+			// Likely not final yet, since the median time past lags.
+			s.log.Debugf("Error broadcasting refund for %s: %v", id, err)
+			return false
+		}
+		if rec.RefundTxID == txID {
+			return false
+		}
+		s.log.Infof("Broadcast refund %s for swap %s", txID, id)
+		rec.RefundTxID = txID
+		return true
+	}
+
+	pushes, scanned, err := chain.FindSpend(ctx, reg.SwapCoinID, rec.ScanHeight)
+	if err != nil {
+		s.log.Errorf("Error finding spend of swap %s: %v", id, err)
+		return false
+	}
+	changed := scanned != rec.ScanHeight
+	rec.ScanHeight = scanned
+	if pushes == nil {
+		// Spent in mempool.
+		return changed
+	}
+	if reg.Redeem != nil {
+		if secret, _ := reg.Redeem.findSecret(pushes); secret != nil {
+			s.log.Infof("Found secret for swap %s", id)
+			rec.Secret = secret
+			rec.State = StateRedeeming
+			s.redeem(ctx, rec)
+			return true
+		}
+	}
+	// Refunded, or redeemed with no redeem of our own to do.
+	s.log.Infof("Swap %s is spent", id)
+	rec.State = StateDone
+	return true
+}
+
+// redeem broadcasts the completed redeem tx. The registration is done when
+// the redeem is accepted or the counterparty's swap is spent.
+func (s *Server) redeem(ctx context.Context, rec *record) bool {
+	id, er := rec.Reg.ID(), rec.Reg.Redeem
+	chain := s.chains[er.AssetID]
+	if chain == nil {
+		return false
+	}
+	tx, err := er.open(rec.Secret)
+	if err != nil {
+		s.log.Errorf("Error opening redeem for %s: %v", id, err)
+		rec.State = StateDone
+		return true
+	}
+	txID, err := chain.Broadcast(ctx, tx)
+	if err == nil {
+		s.log.Infof("Broadcast redeem %s for swap %s", txID, id)
+		rec.RedeemTxID = txID
+		rec.State = StateDone
+		return true
+	}
+	s.log.Errorf("Error broadcasting redeem for %s: %v", id, err)
+	counterSwap, _, err := chain.ParseTx(tx)
+	if err != nil {
+		rec.State = StateDone
+		return true
+	}
+	if unspent, err := chain.Unspent(ctx, counterSwap); err == nil && !unspent {
+		s.log.Infof("Counterparty swap for %s is already spent", id)
+		rec.State = StateDone
+		return true
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+// Package watchtower is a swap watchtower service and the client-side
+// registration protocol. A client that may go offline during a swap registers
+// each active match with one or more watchtowers. A registration carries a
+// pre-signed refund transaction for the client's swap and, for a taker, a
+// redeem transaction template for the counterparty's swap with a placeholder
+// in place of the secret. The watchtower broadcasts the refund once the swap's
+// lock time has passed, or, when the counterparty redeems the client's swap and
+// so reveals the secret on chain, fills the secret into the template and
+// broadcasts the redeem.
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"decred.org/dcrdex/dex"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// RegisterRoute is the HTTP path for POSTing a Registration.
+	RegisterRoute = "/register"
+	// StatusRoute is the HTTP path prefix for GETting a registration's Status.
+	// The registration ID follows the prefix.
+	StatusRoute = "/status/"
+
+	// SecretSize is the size of a swap secret.
+	SecretSize = 32
+
+	redeemKeyTag = "dcrdex watchtower redeem"
+)
+
+// ErrAlreadyRegistered is returned by Register when the watchtower already has
+// a different registration for the swap.
+var ErrAlreadyRegistered = errors.New("swap already registered")
+
+// Registration is a request for the watchtower to watch a swap.
+type Registration struct {
+	// AssetID is the asset of the client's swap and refund.
+	AssetID uint32 `json:"assetID"`
+	// SwapCoinID is the client's swap contract output.
+	SwapCoinID dex.Bytes `json:"swapCoinID"`
+	// Refund is the signed refund transaction, which will not be accepted by
+	// the network until the swap's lock time.
+	Refund dex.Bytes `json:"refund"`
+	// Redeem is the encrypted redeem template for the counterparty's swap.
+	// Only a taker, who learns the secret from the maker's redeem, needs
+	// this.
+	Redeem *EncryptedRedeem `json:"redeem,omitempty"`
+}
+
+// equal checks whether the registrations are identical.
+func (r *Registration) equal(r2 *Registration) bool {
+	if r.AssetID != r2.AssetID || !bytes.Equal(r.SwapCoinID, r2.SwapCoinID) || !bytes.Equal(r.Refund, r2.Refund) {
+		return false
+	}
+	if r.Redeem == nil || r2.Redeem == nil {
+		return r.Redeem == r2.Redeem
+	}
+	return r.Redeem.AssetID == r2.Redeem.AssetID && bytes.Equal(r.Redeem.Ciphertext, r2.Redeem.Ciphertext)
+}
+
+// ID is the registration ID, the hex-encoded sha256 hash of the asset ID and
+// swap coin ID.
+func (r *Registration) ID() string {
+	return registrationID(r.AssetID, r.SwapCoinID)
+}
+
+func registrationID(assetID uint32, coinID []byte) string {
+	b := make([]byte, 4+len(coinID))
+	binary.BigEndian.PutUint32(b, assetID)
+	copy(b[4:], coinID)
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// EncryptedRedeem is a redeem transaction template sealed with a key derived
+// from the swap's secret hash. The key is known to the taker, and becomes
+// known to the watchtower once the secret is revealed in a spend of the
+// taker's swap. It keeps the redeem details out of the watchtower's storage
+// until they are needed, though a watchtower that decodes the contract in the
+// refund transaction could derive the key sooner. The template is harmless in
+// any case, since it pays the client and is useless without the secret.
+type EncryptedRedeem struct {
+	// AssetID is the asset of the counterparty's swap.
+	AssetID uint32 `json:"assetID"`
+	// Ciphertext is the nonce-prefixed XChaCha20-Poly1305 encryption of the
+	// 4-byte big-endian secret offset followed by the serialized template.
+	Ciphertext dex.Bytes `json:"ciphertext"`
+}
+
+// Status is a registration's status.
+type Status struct {
+	ID      string `json:"id"`
+	AssetID uint32 `json:"assetID"`
+	// State is one of the State constants.
+	State State `json:"state"`
+	// LockTime is the swap's lock time, in unix seconds.
+	LockTime int64 `json:"lockTime"`
+	// RefundTxID and RedeemTxID are set when the watchtower broadcasts the
+	// refund or redeem.
+	RefundTxID string `json:"refundTxID,omitempty"`
+	RedeemTxID string `json:"redeemTxID,omitempty"`
+}
+
+// State is the state of a registration.
+type State string
+
+const (
+	// StateWatching means the swap is unspent, or a refund has been broadcast
+	// but not mined.
+	StateWatching State = "watching"
+	// StateRedeeming means the secret was found, and the redeem is being
+	// broadcast.
+	StateRedeeming State = "redeeming"
+	// StateDone means the swap was spent, and any required redeem was
+	// broadcast, or the counterparty's swap was already spent.
+	StateDone State = "done"
+)
+
+func redeemKey(secretHash []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(redeemKeyTag))
+	h.Write(secretHash)
+	return h.Sum(nil)
+}
+
+// SealRedeem encrypts the redeem template for a Registration. The secretHash
+// is the swap's secret hash, and secretOffset is the position of the secret
+// placeholder in the serialized tx.
+func SealRedeem(assetID uint32, tx []byte, secretOffset int, secretHash []byte) (*EncryptedRedeem, error) {
+	if secretOffset < 0 || secretOffset+SecretSize > len(tx) {
+		return nil, fmt.Errorf("secret offset %d out of range for %d byte tx", secretOffset, len(tx))
+	}
+	aead, err := chacha20poly1305.NewX(redeemKey(secretHash))
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, 4+len(tx))
+	binary.BigEndian.PutUint32(plaintext, uint32(secretOffset))
+	copy(plaintext[4:], tx)
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &EncryptedRedeem{
+		AssetID:    assetID,
+		Ciphertext: aead.Seal(nonce, nonce, plaintext, nil),
+	}, nil
+}
+
+// open decrypts the redeem template with the candidate secret, and returns
+// the completed redeem transaction. An error is returned if the candidate is
+// not the secret.
+func (er *EncryptedRedeem) open(secret []byte) ([]byte, error) {
+	if len(secret) != SecretSize {
+		return nil, errors.New("wrong secret size")
+	}
+	secretHash := sha256.Sum256(secret)
+	aead, err := chacha20poly1305.NewX(redeemKey(secretHash[:]))
+	if err != nil {
+		return nil, err
+	}
+	if len(er.Ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := er.Ciphertext[:aead.NonceSize()], er.Ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(plaintext) < 4 {
+		return nil, errors.New("plaintext too short")
+	}
+	offset := int(binary.BigEndian.Uint32(plaintext))
+	tx := plaintext[4:]
+	if offset+SecretSize > len(tx) {
+		return nil, fmt.Errorf("secret offset %d out of range for %d byte tx", offset, len(tx))
+	}
+	copy(tx[offset:], secret)
+	return tx, nil
+}
+
+// findSecret checks the data pushes of a swap's spending input for a secret
+// that opens the redeem template. The completed redeem tx is returned, or nil
+// if no push is the secret, as for a refund.
+func (er *EncryptedRedeem) findSecret(pushes [][]byte) (secret, tx []byte) {
+	for _, push := range pushes {
+		if len(push) != SecretSize {
+			continue
+		}
+		if tx, err := er.open(push); err == nil {
+			return push, tx
+		}
+	}
+	return nil, nil
+}
+
+// Register sends the registration to the watchtower at url.
+func Register(ctx context.Context, url string, reg *Registration) error {
+	b, err := json.Marshal(reg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(url, "/")+RegisterRoute, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		return ErrAlreadyRegistered
+	}
+	b, _ = io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+}
+
+// GetStatus retrieves the status of a registration from the watchtower at
+// url.
+func GetStatus(ctx context.Context, url, id string) (*Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+StatusRoute+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	st := new(Status)
+	return st, json.NewDecoder(resp.Body).Decode(st)
+}
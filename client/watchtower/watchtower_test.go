@@ -0,0 +1,314 @@
+//go:build !harness
+
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+)
+
+var tLogger = dex.StdOutLogger("WT_TEST", dex.LevelInfo)
+
+// tChain is a Chain whose txs are coin ID (8 bytes) || lock time (8 bytes) ||
+// payload.
+type tChain struct {
+	mtx       sync.Mutex
+	height    int64
+	spent     map[string][][]byte // coin ID -> pushes, nil pushes for a mempool spend
+	sent      [][]byte
+	sendErr   error
+	findCalls int
+}
+
+func newTChain() *tChain {
+	return &tChain{height: 100, spent: make(map[string][][]byte)}
+}
+
+func tTx(coinID []byte, lockTime int64, payload []byte) []byte {
+	b := make([]byte, 16, 16+len(payload))
+	copy(b, coinID)
+	binary.BigEndian.PutUint64(b[8:], uint64(lockTime))
+	return append(b, payload...)
+}
+
+func (c *tChain) ParseTx(tx []byte) ([]byte, time.Time, error) {
+	if len(tx) < 16 {
+		return nil, time.Time{}, errors.New("short tx")
+	}
+	return tx[:8], time.Unix(int64(binary.BigEndian.Uint64(tx[8:16])), 0), nil
+}
+
+func (c *tChain) VerifySpend(_ context.Context, tx []byte) error {
+	if bytes.HasSuffix(tx, []byte("unsigned")) {
+		return errors.New("bad signature")
+	}
+	return nil
+}
+
+func (c *tChain) Height(context.Context) (int64, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.height, nil
+}
+
+func (c *tChain) Unspent(_ context.Context, coinID []byte) (bool, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, spent := c.spent[string(coinID)]
+	return !spent, nil
+}
+
+func (c *tChain) FindSpend(_ context.Context, coinID []byte, fromHeight int64) ([][]byte, int64, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.findCalls++
+	return c.spent[string(coinID)], c.height, nil
+}
+
+func (c *tChain) Broadcast(_ context.Context, tx []byte) (string, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.sendErr != nil {
+		return "", c.sendErr
+	}
+	c.sent = append(c.sent, tx)
+	return "txid", nil
+}
+
+func (c *tChain) spend(coinID []byte, pushes [][]byte) {
+	c.mtx.Lock()
+	c.spent[string(coinID)] = pushes
+	c.mtx.Unlock()
+}
+
+func newTServer(t *testing.T, chains map[uint32]Chain) *Server {
+	t.Helper()
+	s, err := NewServer(&Config{
+		DBPath: filepath.Join(t.TempDir(), "wt.db"),
+		Chains: chains,
+		Logger: tLogger,
+	})
+	if err != nil {
+		t.Fatalf("NewServer error: %v", err)
+	}
+	t.Cleanup(func() { s.db.Close() })
+	return s
+}
+
+func TestSealRedeem(t *testing.T) {
+	secret := encode.RandomBytes(32)
+	secretHash := sha256.Sum256(secret)
+	tx := encode.RandomBytes(200)
+	er, err := SealRedeem(0, tx, 50, secretHash[:])
+	if err != nil {
+		t.Fatalf("SealRedeem error: %v", err)
+	}
+	if bytes.Contains(er.Ciphertext, tx[:50]) {
+		t.Fatalf("template not encrypted")
+	}
+	foundSecret, redeemTx := er.findSecret([][]byte{{0x01}, encode.RandomBytes(32), secret, encode.RandomBytes(33)})
+	if !bytes.Equal(foundSecret, secret) {
+		t.Fatalf("secret not found")
+	}
+	if !bytes.Equal(redeemTx[50:82], secret) || !bytes.Equal(redeemTx[:50], tx[:50]) || !bytes.Equal(redeemTx[82:], tx[82:]) {
+		t.Fatalf("wrong redeem tx")
+	}
+	if s, _ := er.findSecret([][]byte{encode.RandomBytes(32)}); s != nil {
+		t.Fatalf("found a secret that isn't")
+	}
+	if _, err := SealRedeem(0, tx, 180, secretHash[:]); err == nil {
+		t.Fatalf("no error for out of range offset")
+	}
+}
+
+func TestRefund(t *testing.T) {
+	chain := newTChain()
+	s := newTServer(t, map[uint32]Chain{0: chain})
+	ctx := context.Background()
+	swapCoin := encode.RandomBytes(8)
+	refund := tTx(swapCoin, time.Now().Add(time.Hour).Unix(), []byte("refund"))
+	reg := &Registration{AssetID: 0, SwapCoinID: swapCoin, Refund: refund}
+
+	// Bad registrations.
+	for name, bad := range map[string]*Registration{
+		"unknown asset":        {AssetID: 1, SwapCoinID: swapCoin, Refund: refund},
+		"unknown redeem asset": {AssetID: 0, SwapCoinID: swapCoin, Refund: refund, Redeem: &EncryptedRedeem{AssetID: 1}},
+		"bad refund":           {AssetID: 0, SwapCoinID: swapCoin, Refund: []byte{1}},
+		"wrong coin":           {AssetID: 0, SwapCoinID: encode.RandomBytes(8), Refund: refund},
+		"unsigned refund":      {AssetID: 0, SwapCoinID: swapCoin, Refund: tTx(swapCoin, 0, []byte("unsigned"))},
+	} {
+		if _, err := s.register(ctx, bad); err == nil {
+			t.Fatalf("%s: no error", name)
+		}
+	}
+
+	st, err := s.register(ctx, reg)
+	if err != nil {
+		t.Fatalf("register error: %v", err)
+	}
+	if st.State != StateWatching || st.ID != reg.ID() {
+		t.Fatalf("wrong status %+v", st)
+	}
+
+	// Not yet expired.
+	s.checkAll(ctx)
+	if len(chain.sent) != 0 {
+		t.Fatalf("refund sent before lock time")
+	}
+
+	// An identical registration is accepted. A different one is not.
+	if _, err := s.register(ctx, reg); err != nil {
+		t.Fatalf("identical re-register error: %v", err)
+	}
+	replacement := &Registration{AssetID: 0, SwapCoinID: swapCoin, Refund: tTx(swapCoin, 0, []byte("refund"))}
+	if _, err := s.register(ctx, replacement); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Fatalf("wrong error for replacement: %v", err)
+	}
+
+	// Expired.
+	swapCoin = encode.RandomBytes(8)
+	reg = &Registration{AssetID: 0, SwapCoinID: swapCoin, Refund: tTx(swapCoin, time.Now().Add(-time.Minute).Unix(), []byte("refund"))}
+	if _, err := s.register(ctx, reg); err != nil {
+		t.Fatalf("register error: %v", err)
+	}
+	chain.sendErr = errors.New("non-final")
+	s.checkAll(ctx)
+	if len(chain.sent) != 0 {
+		t.Fatalf("refund sent with send error")
+	}
+	chain.sendErr = nil
+	s.checkAll(ctx)
+	if len(chain.sent) != 1 || !bytes.Equal(chain.sent[0], reg.Refund) {
+		t.Fatalf("refund not sent")
+	}
+	rec, _ := s.record(reg.ID())
+	if rec.RefundTxID != "txid" || rec.State != StateWatching {
+		t.Fatalf("wrong record after refund %+v", rec)
+	}
+
+	// Spent in mempool only. Keep watching.
+	chain.spend(swapCoin, nil)
+	s.checkAll(ctx)
+	if rec, _ = s.record(reg.ID()); rec.State != StateWatching {
+		t.Fatalf("done before spend was mined")
+	}
+	// Mined.
+	chain.spend(swapCoin, [][]byte{{0x01}})
+	s.checkAll(ctx)
+	if rec, _ = s.record(reg.ID()); rec.State != StateDone {
+		t.Fatalf("not done after refund mined")
+	}
+	if _, err := s.register(ctx, reg); err == nil {
+		t.Fatalf("no error re-registering a completed swap")
+	}
+
+	// Completed registrations are pruned.
+	calls := chain.findCalls
+	s.cfg.Retention = -time.Hour
+	s.checkAll(ctx)
+	if _, err := s.record(reg.ID()); err == nil {
+		t.Fatalf("registration not pruned")
+	}
+	if chain.findCalls != calls {
+		t.Fatalf("completed registration checked")
+	}
+}
+
+func TestRedeem(t *testing.T) {
+	btc, ltc := newTChain(), newTChain()
+	s := newTServer(t, map[uint32]Chain{0: btc, 2: ltc})
+	ctx := context.Background()
+
+	secret := encode.RandomBytes(32)
+	secretHash := sha256.Sum256(secret)
+	swapCoin, counterSwap := encode.RandomBytes(8), encode.RandomBytes(8)
+	template := tTx(counterSwap, 0, make([]byte, 40))
+	er, err := SealRedeem(2, template, 20, secretHash[:])
+	if err != nil {
+		t.Fatalf("SealRedeem error: %v", err)
+	}
+	reg := &Registration{
+		AssetID:    0,
+		SwapCoinID: swapCoin,
+		Refund:     tTx(swapCoin, time.Now().Add(time.Hour).Unix(), nil),
+		Redeem:     er,
+	}
+
+	// Register over HTTP.
+	srv := httptest.NewServer(s.handler())
+	defer srv.Close()
+	if err := Register(ctx, srv.URL, reg); err != nil {
+		t.Fatalf("Register error: %v", err)
+	}
+	if err := Register(ctx, srv.URL, &Registration{AssetID: 5}); err == nil {
+		t.Fatalf("no error for bad registration")
+	}
+	// A second registration cannot overwrite the first to strip the redeem
+	// template.
+	overwrite := &Registration{AssetID: 0, SwapCoinID: swapCoin, Refund: reg.Refund}
+	if err := Register(ctx, srv.URL, overwrite); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Fatalf("wrong error for overwrite: %v", err)
+	}
+	if rec, _ := s.record(reg.ID()); rec.Reg.Redeem == nil {
+		t.Fatalf("redeem template stripped")
+	}
+	if _, err := GetStatus(ctx, srv.URL, "abc"); err == nil {
+		t.Fatalf("no error for unknown registration")
+	}
+
+	// The counterparty redeems the swap, revealing the secret. The first
+	// redeem attempt fails.
+	btc.spend(swapCoin, [][]byte{encode.RandomBytes(72), encode.RandomBytes(33), secret, {0x01}})
+	ltc.sendErr = errors.New("try again")
+	s.checkAll(ctx)
+	st, err := GetStatus(ctx, srv.URL, reg.ID())
+	if err != nil {
+		t.Fatalf("GetStatus error: %v", err)
+	}
+	if st.State != StateRedeeming {
+		t.Fatalf("wrong state %s after failed redeem", st.State)
+	}
+	ltc.sendErr = nil
+	s.checkAll(ctx)
+	if len(ltc.sent) != 1 {
+		t.Fatalf("redeem not sent")
+	}
+	exp := append([]byte(nil), template...)
+	copy(exp[20:], secret)
+	if !bytes.Equal(ltc.sent[0], exp) {
+		t.Fatalf("wrong redeem tx")
+	}
+	if st, _ = GetStatus(ctx, srv.URL, reg.ID()); st.State != StateDone || st.RedeemTxID != "txid" {
+		t.Fatalf("wrong final status %+v", st)
+	}
+
+	// A failing redeem is abandoned once the counterparty's swap is spent.
+	swapCoin2 := encode.RandomBytes(8)
+	reg2 := &Registration{AssetID: 0, SwapCoinID: swapCoin2, Refund: tTx(swapCoin2, 0, nil), Redeem: er}
+	btc.sendErr = errors.New("not accepted")
+	if _, err := s.register(ctx, reg2); err != nil {
+		t.Fatalf("register error: %v", err)
+	}
+	btc.spend(swapCoin2, [][]byte{secret})
+	ltc.sendErr = errors.New("missing inputs")
+	s.checkAll(ctx)
+	if rec, _ := s.record(reg2.ID()); rec.State != StateRedeeming {
+		t.Fatalf("wrong state %s", rec.State)
+	}
+	ltc.spend(counterSwap, [][]byte{secret})
+	s.checkAll(ctx)
+	if rec, _ := s.record(reg2.ID()); rec.State != StateDone || rec.RedeemTxID != "" {
+		t.Fatalf("wrong final record %+v", rec)
+	}
+}
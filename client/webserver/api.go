@@ -14,6 +14,7 @@ import (
 	"os"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/db"
@@ -22,6 +23,7 @@ import (
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/config"
 	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/tatanka/tanka"
 )
 
 var zero = encode.ClearBytes
@@ -771,6 +773,119 @@ func (s *WebServer) apiExportSeed(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// apiExportAppState is the handler for the '/exportappstate' API request.
+func (s *WebServer) apiExportAppState(w http.ResponseWriter, r *http.Request) {
+	form := new(exportAppStateForm)
+	defer form.Pass.Clear()
+	if !readPost(w, r, form) {
+		return
+	}
+	r.Close = true
+	pass, err := s.resolvePass(form.Pass, r)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("password error: %w", err))
+		return
+	}
+	defer zero(pass)
+	exportForm := &core.AppStateExportForm{
+		ExcludeSecrets: form.ExcludeSecrets,
+		Sections:       make(map[string]json.RawMessage),
+	}
+	if s.mm != nil {
+		mmState, err := s.mm.ExportState(form.ExcludeSecrets)
+		if err != nil {
+			s.writeAPIError(w, fmt.Errorf("error exporting market making state: %w", err))
+			return
+		}
+		exportForm.Sections[mm.AppStateSection] = mmState
+	}
+	backup, err := s.core.ExportAppState(pass, exportForm)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error exporting app state: %w", err))
+		return
+	}
+	w.Header().Set("Connection", "close")
+	writeJSON(w, &struct {
+		OK     bool      `json:"ok"`
+		Backup dex.Bytes `json:"backup"`
+	}{
+		OK:     true,
+		Backup: backup,
+	})
+}
+
+// ledger generates the Core ledger, including the market maker's CEX trades.
+func (s *WebServer) ledger(form *core.LedgerForm) (*core.Ledger, error) {
+	if s.mm != nil {
+		cexEvents, err := s.mm.LedgerEvents()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving market making events: %w", err)
+		}
+		form.ExtraEvents = cexEvents
+	}
+	return s.core.Ledger(form)
+}
+
+// apiLedger is the handler for the '/ledger' API request.
+func (s *WebServer) apiLedger(w http.ResponseWriter, r *http.Request) {
+	form := new(ledgerForm)
+	if !readPost(w, r, form) {
+		return
+	}
+	ledger, err := s.ledger(&core.LedgerForm{
+		Start:  form.Start,
+		End:    form.End,
+		Method: form.Method,
+	})
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error generating ledger: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK     bool         `json:"ok"`
+		Ledger *core.Ledger `json:"ledger"`
+	}{
+		OK:     true,
+		Ledger: ledger,
+	})
+}
+
+// apiRestoreAppState is the handler for the '/restoreappstate' API request.
+// The app state can only be restored to an uninitialized client, which is
+// logged in on success.
+func (s *WebServer) apiRestoreAppState(w http.ResponseWriter, r *http.Request) {
+	form := new(restoreAppStateForm)
+	defer form.Pass.Clear()
+	if !readPost(w, r, form) {
+		return
+	}
+	r.Close = true
+	res, err := s.core.RestoreAppState(form.Pass, form.Backup)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error restoring app state: %w", err))
+		return
+	}
+	if mmState := res.Sections[mm.AppStateSection]; len(mmState) > 0 {
+		if s.mm == nil {
+			res.Errors = append(res.Errors, "market making state not restored: market maker not available")
+		} else if err := s.mm.RestoreState(mmState); err != nil {
+			log.Errorf("Error restoring market making state: %v", err)
+			res.Errors = append(res.Errors, fmt.Sprintf("error restoring market making state: %v", err))
+		}
+	}
+	if err := s.actuallyLogin(w, r, &loginForm{Pass: form.Pass}); err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, &struct {
+		OK     bool     `json:"ok"`
+		Errors []string `json:"errors"`
+	}{
+		OK:     true,
+		Errors: res.Errors,
+	})
+}
+
 // apiAccountImport is the handler for the '/importaccount' API request.
 func (s *WebServer) apiAccountImport(w http.ResponseWriter, r *http.Request) {
 	form := new(accountImportForm)
@@ -918,6 +1033,257 @@ func (s *WebServer) apiCancel(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, simpleAck())
 }
 
+// apiConditionalOrder is the handler for the '/conditionalorder' API request.
+func (s *WebServer) apiConditionalOrder(w http.ResponseWriter, r *http.Request) {
+	form := new(conditionalOrderForm)
+	defer form.Pass.Clear()
+	if !readPost(w, r, form) {
+		return
+	}
+	pass, err := s.resolvePass(form.Pass, r)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("password error: %w", err))
+		return
+	}
+	defer zero(pass)
+	if form.Order == nil {
+		s.writeAPIError(w, errors.New("order missing"))
+		return
+	}
+	ords, err := s.core.PlaceConditionalOrder(pass, form.Order)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error placing conditional order: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK     bool                   `json:"ok"`
+		Orders []*db.ConditionalOrder `json:"orders"`
+	}{
+		OK:     true,
+		Orders: ords,
+	})
+}
+
+// apiConditionalOrders is the handler for the '/conditionalorders' API
+// request.
+func (s *WebServer) apiConditionalOrders(w http.ResponseWriter, r *http.Request) {
+	ords, err := s.core.ConditionalOrders()
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error retrieving conditional orders: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK     bool                   `json:"ok"`
+		Orders []*db.ConditionalOrder `json:"orders"`
+	}{
+		OK:     true,
+		Orders: ords,
+	})
+}
+
+// apiCancelConditionalOrder is the handler for the '/cancelconditionalorder'
+// API request.
+func (s *WebServer) apiCancelConditionalOrder(w http.ResponseWriter, r *http.Request) {
+	form := &struct {
+		ID dex.Bytes `json:"id"`
+	}{}
+	if !readPost(w, r, form) {
+		return
+	}
+	if err := s.core.CancelConditionalOrder(form.ID); err != nil {
+		s.writeAPIError(w, fmt.Errorf("error canceling conditional order %s: %w", form.ID, err))
+		return
+	}
+	writeJSON(w, simpleAck())
+}
+
+// apiAddMarketAlert is the handler for the '/addalert' API request.
+func (s *WebServer) apiAddMarketAlert(w http.ResponseWriter, r *http.Request) {
+	form := new(core.MarketAlertForm)
+	if !readPost(w, r, form) {
+		return
+	}
+	alert, err := s.core.AddMarketAlert(form)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error adding market alert: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK    bool            `json:"ok"`
+		Alert *db.MarketAlert `json:"alert"`
+	}{
+		OK:    true,
+		Alert: alert,
+	})
+}
+
+// apiMarketAlerts is the handler for the '/alerts' API request.
+func (s *WebServer) apiMarketAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := s.core.MarketAlerts()
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error retrieving market alerts: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK     bool              `json:"ok"`
+		Alerts []*db.MarketAlert `json:"alerts"`
+	}{
+		OK:     true,
+		Alerts: alerts,
+	})
+}
+
+// apiPostMeshBond is the handler for the '/postmeshbond' API request.
+func (s *WebServer) apiPostMeshBond(w http.ResponseWriter, r *http.Request) {
+	form := new(meshBondForm)
+	defer form.Pass.Clear()
+	if !readPost(w, r, form) {
+		return
+	}
+	pass, err := s.resolvePass(form.Pass, r)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("password error: %w", err))
+		return
+	}
+	defer zero(pass)
+	if form.Bond == nil {
+		s.writeAPIError(w, errors.New("bond missing"))
+		return
+	}
+	bond, err := s.core.PostMeshBond(pass, form.Bond)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error posting mesh bond: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK   bool        `json:"ok"`
+		Bond *tanka.Bond `json:"bond"`
+	}{
+		OK:   true,
+		Bond: bond,
+	})
+}
+
+// apiMeshBonds is the handler for the '/meshbonds' API request.
+func (s *WebServer) apiMeshBonds(w http.ResponseWriter, r *http.Request) {
+	bonds, err := s.core.MeshBonds()
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error retrieving mesh bonds: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK    bool          `json:"ok"`
+		Bonds []*tanka.Bond `json:"bonds"`
+	}{
+		OK:    true,
+		Bonds: bonds,
+	})
+}
+
+// apiRemoveMarketAlert is the handler for the '/removealert' API request.
+func (s *WebServer) apiRemoveMarketAlert(w http.ResponseWriter, r *http.Request) {
+	form := &struct {
+		ID dex.Bytes `json:"id"`
+	}{}
+	if !readPost(w, r, form) {
+		return
+	}
+	if err := s.core.RemoveMarketAlert(form.ID); err != nil {
+		s.writeAPIError(w, fmt.Errorf("error removing market alert %s: %w", form.ID, err))
+		return
+	}
+	writeJSON(w, simpleAck())
+}
+
+// errAPIKeysUnsupported is the error for API key requests when API keys are not
+// managed.
+var errAPIKeysUnsupported = errors.New("api keys are not supported")
+
+// auditLogLimit is the number of audit log entries returned by the
+// '/apiauditlog' API request.
+const auditLogLimit = 100
+
+// apiAPIKeys is the handler for the '/apikeys' API request.
+func (s *WebServer) apiAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if s.apiKeys == nil {
+		s.writeAPIError(w, errAPIKeysUnsupported)
+		return
+	}
+	writeJSON(w, &struct {
+		OK   bool          `json:"ok"`
+		Keys []*apikey.Key `json:"keys"`
+	}{
+		OK:   true,
+		Keys: s.apiKeys.Keys(),
+	})
+}
+
+// apiNewAPIKey is the handler for the '/newapikey' API request. The key's
+// secret is only returned here.
+func (s *WebServer) apiNewAPIKey(w http.ResponseWriter, r *http.Request) {
+	if s.apiKeys == nil {
+		s.writeAPIError(w, errAPIKeysUnsupported)
+		return
+	}
+	form := new(apikey.KeyForm)
+	if !readPost(w, r, form) {
+		return
+	}
+	k, secret, err := s.apiKeys.Create(form)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error creating api key: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK     bool        `json:"ok"`
+		Key    *apikey.Key `json:"key"`
+		Secret string      `json:"secret"`
+	}{
+		OK:     true,
+		Key:    k,
+		Secret: secret,
+	})
+}
+
+// apiRevokeAPIKey is the handler for the '/revokeapikey' API request.
+func (s *WebServer) apiRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if s.apiKeys == nil {
+		s.writeAPIError(w, errAPIKeysUnsupported)
+		return
+	}
+	form := &struct {
+		Name string `json:"name"`
+	}{}
+	if !readPost(w, r, form) {
+		return
+	}
+	if err := s.apiKeys.Revoke(form.Name); err != nil {
+		s.writeAPIError(w, fmt.Errorf("error revoking api key %s: %w", form.Name, err))
+		return
+	}
+	writeJSON(w, simpleAck())
+}
+
+// apiAPIAuditLog is the handler for the '/apiauditlog' API request.
+func (s *WebServer) apiAPIAuditLog(w http.ResponseWriter, r *http.Request) {
+	if s.apiKeys == nil {
+		s.writeAPIError(w, errAPIKeysUnsupported)
+		return
+	}
+	entries, err := s.apiKeys.AuditLog(auditLogLimit)
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error reading audit log: %w", err))
+		return
+	}
+	writeJSON(w, &struct {
+		OK      bool                 `json:"ok"`
+		Entries []*apikey.AuditEntry `json:"entries"`
+	}{
+		OK:      true,
+		Entries: entries,
+	})
+}
+
 // apiCloseWallet is the handler for the '/closewallet' API request.
 func (s *WebServer) apiCloseWallet(w http.ResponseWriter, r *http.Request) {
 	form := &struct {
@@ -1033,6 +1399,70 @@ func (s *WebServer) apiBuildInfo(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp)
 }
 
+// apiProfiles handles the 'profiles' API request. The profile names are
+// available without logging in so that a profile can be chosen from the login
+// page.
+func (s *WebServer) apiProfiles(w http.ResponseWriter, r *http.Request) {
+	if s.profiles == nil {
+		s.writeAPIError(w, errors.New("profiles are not supported"))
+		return
+	}
+	active, all, err := s.profiles.Profiles()
+	if err != nil {
+		s.writeAPIError(w, fmt.Errorf("error listing profiles: %w", err))
+		return
+	}
+	writeJSON(w, &profilesResponse{
+		OK:       true,
+		Active:   active,
+		Profiles: all,
+	})
+}
+
+// apiSwitchProfile handles the 'switchprofile' API request. The active profile
+// is logged out and the application restarts with the requested profile, which
+// must exist. The request must be from an authorized session unless the app is
+// logged out, e.g. from the login page.
+func (s *WebServer) apiSwitchProfile(w http.ResponseWriter, r *http.Request) {
+	if s.profiles == nil {
+		s.writeAPIError(w, errors.New("profiles are not supported"))
+		return
+	}
+	if s.core.LoggedIn() && !s.isAuthed(r) {
+		http.Error(w, "not authorized - login first", http.StatusUnauthorized)
+		return
+	}
+	s.switchProfile(w, r, s.profiles.SwitchProfile)
+}
+
+// apiCreateProfile handles the 'createprofile' API request. The new profile is
+// created, the active profile is logged out, and the application restarts with
+// the new profile.
+func (s *WebServer) apiCreateProfile(w http.ResponseWriter, r *http.Request) {
+	if s.profiles == nil {
+		s.writeAPIError(w, errors.New("profiles are not supported"))
+		return
+	}
+	s.switchProfile(w, r, s.profiles.CreateProfile)
+}
+
+// switchProfile reads the profile form and switches profiles with the
+// profileManager method, then ends the web sessions.
+func (s *WebServer) switchProfile(w http.ResponseWriter, r *http.Request, switchTo func(string) error) {
+	form := new(switchProfileForm)
+	if !readPost(w, r, form) {
+		return
+	}
+	if err := switchTo(form.Name); err != nil {
+		s.writeAPIError(w, fmt.Errorf("error switching profile: %w", err))
+		return
+	}
+	s.deauth()
+	clearCookie(authCK, w)
+	clearCookie(pwKeyCK, w)
+	writeJSON(w, simpleAck())
+}
+
 // apiLogin handles the 'login' API request.
 func (s *WebServer) apiLogin(w http.ResponseWriter, r *http.Request) {
 	login := new(loginForm)
@@ -1468,6 +1898,59 @@ func (s *WebServer) apiSend(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, resp)
 }
 
+// apiSendMulti handles the 'sendmulti' API request.
+func (s *WebServer) apiSendMulti(w http.ResponseWriter, r *http.Request) {
+	form := new(sendMultiForm)
+	defer form.Pass.Clear()
+	if !readPost(w, r, form) {
+		return
+	}
+	if len(form.Pass) == 0 {
+		s.writeAPIError(w, fmt.Errorf("empty password"))
+		return
+	}
+	coins, err := s.core.SendMulti(form.Pass, form.AssetID, form.Payments)
+	coinStrs := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		coinStrs = append(coinStrs, coin.String())
+	}
+	if err != nil {
+		// Some payments may have been sent before the error.
+		s.writeAPIError(w, fmt.Errorf("send error: %w (sent coins: %v)", err, coinStrs))
+		return
+	}
+	resp := struct {
+		OK    bool     `json:"ok"`
+		Coins []string `json:"coins"`
+	}{
+		OK:    true,
+		Coins: coinStrs,
+	}
+	writeJSON(w, resp)
+}
+
+// apiEstimateSendMultiTxFee is the handler for the '/sendmultifee' API
+// request.
+func (s *WebServer) apiEstimateSendMultiTxFee(w http.ResponseWriter, r *http.Request) {
+	form := new(sendMultiFeeForm)
+	if !readPost(w, r, form) {
+		return
+	}
+	txFee, err := s.core.EstimateSendMultiTxFee(form.AssetID, form.Payments)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+	resp := struct {
+		OK    bool   `json:"ok"`
+		TxFee uint64 `json:"txfee"`
+	}{
+		OK:    true,
+		TxFee: txFee,
+	}
+	writeJSON(w, resp)
+}
+
 // apiMaxBuy handles the 'maxbuy' API request.
 func (s *WebServer) apiMaxBuy(w http.ResponseWriter, r *http.Request) {
 	form := &struct {
@@ -4,6 +4,7 @@
 package webserver
 
 import (
+	"bytes"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -22,20 +23,21 @@ import (
 )
 
 const (
-	homeRoute        = "/"
-	registerRoute    = "/register"
-	initRoute        = "/init"
-	loginRoute       = "/login"
-	marketsRoute     = "/markets"
-	walletsRoute     = "/wallets"
-	walletLogRoute   = "/wallets/logfile"
-	settingsRoute    = "/settings"
-	ordersRoute      = "/orders"
-	exportOrderRoute = "/orders/export"
-	marketMakerRoute = "/mm"
-	mmSettingsRoute  = "/mmsettings"
-	mmArchivesRoute  = "/mmarchives"
-	mmLogsRoute      = "/mmlogs"
+	homeRoute         = "/"
+	registerRoute     = "/register"
+	initRoute         = "/init"
+	loginRoute        = "/login"
+	marketsRoute      = "/markets"
+	walletsRoute      = "/wallets"
+	walletLogRoute    = "/wallets/logfile"
+	settingsRoute     = "/settings"
+	ordersRoute       = "/orders"
+	exportOrderRoute  = "/orders/export"
+	exportLedgerRoute = "/ledger/export"
+	marketMakerRoute  = "/mm"
+	mmSettingsRoute   = "/mmsettings"
+	mmArchivesRoute   = "/mmarchives"
+	mmLogsRoute       = "/mmlogs"
 )
 
 // sendTemplate processes the template and sends the result.
@@ -497,6 +499,55 @@ func (s *WebServer) handleExportOrders(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleExportLedger is the handler for the /ledger/export request. The
+// reporting period, cost basis method and CSV format are specified with the
+// start, end, method and format query parameters.
+func (s *WebServer) handleExportLedger(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		log.Errorf("error parsing form for ledger export: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	form := &core.LedgerForm{Method: r.Form.Get("method")}
+	for _, p := range []struct {
+		name string
+		v    *uint64
+	}{{"start", &form.Start}, {"end", &form.End}} {
+		if str := r.Form.Get(p.name); str != "" {
+			v, err := strconv.ParseUint(str, 10, 64)
+			if err != nil {
+				log.Errorf("error parsing ledger %s: %v", p.name, err)
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			*p.v = v
+		}
+	}
+	format := r.Form.Get("format")
+	if format == "" {
+		format = core.LedgerCSVGeneric
+	}
+
+	ledger, err := s.ledger(form)
+	if err != nil {
+		log.Errorf("error generating ledger: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	var buf bytes.Buffer
+	if err := ledger.WriteCSV(&buf, format); err != nil {
+		log.Errorf("error writing ledger CSV: %v", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=ledger-%s.csv", format))
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.Errorf("error writing ledger CSV: %v", err)
+	}
+}
+
 type orderTmplData struct {
 	CommonArguments
 	Order *core.OrderReader
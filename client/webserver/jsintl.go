@@ -221,6 +221,10 @@ const (
 	idCausesSelfMatch                = "CAUSES_SELF_MATCH"
 	idCexNotConnected                = "CEX_NOT_CONNECTED"
 	idDeleteBot                      = "DELETE_BOT"
+	idSwitchingProfile               = "SWITCHING_PROFILE"
+	idRPCUser                        = "RPC_USER"
+	idAPICallDenied                  = "API_CALL_DENIED"
+	idRevokeAPIKey                   = "REVOKE_API_KEY"
 )
 
 var enUS = map[string]*intl.Translation{
@@ -441,6 +445,10 @@ var enUS = map[string]*intl.Translation{
 	idCausesSelfMatch:                {T: "This order would cause a self-match"},
 	idCexNotConnected:                {T: "{{ cexName }} not connected"},
 	idDeleteBot:                      {T: "Are you sure you want to delete this bot for the {{ baseTicker }}-{{ quoteTicker }} market on {{ host }}?"},
+	idSwitchingProfile:               {T: "Switching to profile {{ profile }}. The page will reload when it is ready."},
+	idRPCUser:                        {T: "RPC user"},
+	idAPICallDenied:                  {T: "denied"},
+	idRevokeAPIKey:                   {T: "Revoke API key {{ name }}? Scripts using it will stop working."},
 }
 
 var ptBR = map[string]*intl.Translation{
@@ -45,6 +45,7 @@ import (
 	dexbtc "decred.org/dcrdex/dex/networks/btc"
 	"decred.org/dcrdex/dex/order"
 	ordertest "decred.org/dcrdex/dex/order/test"
+	"decred.org/dcrdex/tatanka/tanka"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -619,8 +620,56 @@ func (c *TCore) ValidateAddress(address string, assetID uint32) (bool, error) {
 func (c *TCore) EstimateSendTxFee(addr string, assetID uint32, value uint64, subtract, maxWithdraw bool) (fee uint64, isValidAddress bool, err error) {
 	return uint64(float64(value) * 0.01), len(addr) > 10, nil
 }
+func (c *TCore) SendMulti(pw []byte, assetID uint32, payments []*asset.Payment) ([]asset.Coin, error) {
+	coins := make([]asset.Coin, 0, len(payments))
+	for range payments {
+		coins = append(coins, &tCoin{id: encode.RandomBytes(36)})
+	}
+	return coins, nil
+}
+func (c *TCore) EstimateSendMultiTxFee(assetID uint32, payments []*asset.Payment) (uint64, error) {
+	var total uint64
+	for _, p := range payments {
+		total += p.Value
+	}
+	return uint64(float64(total) * 0.01), nil
+}
+func (c *TCore) PlaceConditionalOrder(pw []byte, form *core.ConditionalOrderForm) ([]*db.ConditionalOrder, error) {
+	return nil, fmt.Errorf("conditional orders not implemented")
+}
+func (c *TCore) ConditionalOrders() ([]*db.ConditionalOrder, error) {
+	return nil, nil
+}
+func (c *TCore) CancelConditionalOrder(id dex.Bytes) error {
+	return fmt.Errorf("no conditional orders")
+}
+func (c *TCore) AddMarketAlert(form *core.MarketAlertForm) (*db.MarketAlert, error) {
+	return nil, fmt.Errorf("market alerts not implemented")
+}
+func (c *TCore) MarketAlerts() ([]*db.MarketAlert, error) {
+	return nil, nil
+}
+func (c *TCore) RemoveMarketAlert(id dex.Bytes) error {
+	return fmt.Errorf("no market alerts")
+}
+func (c *TCore) PostMeshBond(pw []byte, form *core.MeshBondForm) (*tanka.Bond, error) {
+	return nil, fmt.Errorf("not connected to the mesh")
+}
+func (c *TCore) MeshBonds() ([]*tanka.Bond, error) {
+	return nil, fmt.Errorf("not connected to the mesh")
+}
+func (c *TCore) ExportAppState(pw []byte, form *core.AppStateExportForm) ([]byte, error) {
+	return nil, fmt.Errorf("app state export not supported")
+}
+func (c *TCore) RestoreAppState(pw, bundle []byte) (*core.AppStateRestoration, error) {
+	return nil, fmt.Errorf("app state restore not supported")
+}
+func (c *TCore) Ledger(form *core.LedgerForm) (*core.Ledger, error) {
+	return nil, fmt.Errorf("ledger not supported")
+}
 func (c *TCore) Login([]byte) error  { return nil }
 func (c *TCore) IsInitialized() bool { return c.inited }
+func (c *TCore) LoggedIn() bool      { return c.inited }
 func (c *TCore) Logout() error       { return nil }
 func (c *TCore) Notifications(n int) (notes, pokes []*db.Notification, _ error) {
 	return []*db.Notification{}, []*db.Notification{}, nil
@@ -2271,6 +2320,18 @@ func (m *TMarketMaker) UpdateBotConfig(updatedCfg *mm.BotConfig) error {
 	return nil
 }
 
+func (m *TMarketMaker) ExportState(excludeSecrets bool) (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+
+func (m *TMarketMaker) RestoreState(b json.RawMessage) error {
+	return nil
+}
+
+func (m *TMarketMaker) LedgerEvents() ([]*core.LedgerEvent, error) {
+	return nil, nil
+}
+
 func (m *TMarketMaker) RemoveBotConfig(host string, baseID, quoteID uint32) error {
 	for i := 0; i < len(m.cfg.BotConfigs); i++ {
 		botCfg := m.cfg.BotConfigs[i]
@@ -338,6 +338,8 @@ var EnUS = map[string]*intl.Translation{
 	"Fee unavailable":             {T: "Fee unavailable"},
 	"fiat_exchange_rate_msg":      {T: "Sources may provide fiat exchange rates for different subsets of assets. You should select all sources that are acceptable to get fiat exchange rates for the most assets. Assets with fiat rates from multiple sources will use the average from all of those sources. Note: dcrdate provides fiat exchange rates for only BTC and DCR."},
 	"delete_archived_records":     {T: "Delete Archived Records"},
+	"export_tax_ledger":           {T: "Export Tax Ledger"},
+	"average_cost":                {T: "Average Cost"},
 	"date_time":                   {T: "Date and Time"},
 	"delete_all_archived_records": {T: "Leave unchecked to delete all archived records."},
 	"show_archived_date_msg":      {T: "Specify date of latest records to keep"},
@@ -700,4 +702,29 @@ var EnUS = map[string]*intl.Translation{
 	"Total Profit":                {T: "Total Profit"},
 	"total_profit_tooltip":        {T: "Total profit only includes runs that have completed"},
 	"Number of Runs":              {T: "Number of Runs"},
+	"Profile":                     {T: "Profile"},
+	"New Profile":                 {T: "New Profile"},
+	"Switch":                      {T: "Switch"},
+	"api_keys_msg":                {T: "API keys give scripts and bots limited access to the RPC server. Use the key name as the RPC user and the secret as the RPC password."},
+	"New API Key":                 {T: "New API Key"},
+	"Audit Log":                   {T: "Audit Log"},
+	"Revoke":                      {T: "Revoke"},
+	"Name":                        {T: "Name"},
+	"Permissions":                 {T: "Permissions"},
+	"api_perm_read":               {T: "Read wallets, orders, books and bots"},
+	"api_perm_trade":              {T: "Place and cancel orders"},
+	"api_perm_bots":               {T: "Start, stop and update bots"},
+	"api_perm_funds":              {T: "Send, withdraw and post bonds"},
+	"Allowed IPs":                 {T: "Allowed IPs"},
+	"Withdrawal Addresses":        {T: "Withdrawal Addresses"},
+	"api_key_lists_msg":           {T: "Comma-separated. Leave empty to allow any."},
+	"api_key_secret_msg":          {T: "Copy the secret now. It will not be shown again."},
+	"app_state_backup_msg":        {T: "Save your app seed, DEX accounts, wallet settings and bots to a backup file, encrypted with your app password. The backup can be restored when setting up Bison Wallet on a new device."},
+	"Export App State":            {T: "Export App State"},
+	"app_state_exclude_secrets":   {T: "Exclude wallet and exchange passwords"},
+	"Export":                      {T: "Export"},
+	"Restore from Backup":         {T: "Restore from Backup"},
+	"app_state_restore_msg":       {T: "Select a backup file exported from the settings view and enter the password it was exported with. The password will be your app password."},
+	"Backup File":                 {T: "Backup File"},
+	"app_state_restore_errors":    {T: "Some parts of the backup could not be restored:"},
 }
@@ -1,5 +1,5 @@
 import Doc, { Animation } from './doc'
-import { postJSON } from './http'
+import { postJSON, getJSON } from './http'
 import State from './state'
 import * as intl from './locales'
 import { Wave } from './charts'
@@ -1855,6 +1855,77 @@ export class LoginForm {
   }
 }
 
+/*
+ * ProfileForm lists the user profiles and switches to another profile. If
+ * canCreate, which requires a logged in session, a new profile can be created.
+ * The server restarts with the new profile, so the page is reloaded once the
+ * switch is complete.
+ */
+export class ProfileForm {
+  form: HTMLElement
+  page: Record<string, PageElement>
+  canCreate: boolean
+
+  constructor (form: HTMLElement, canCreate = false) {
+    this.form = form
+    this.canCreate = canCreate
+    const page = this.page = Doc.parseTemplate(form)
+    Doc.setVis(canCreate, page.newProfileBox)
+    bind(form, page.submit, () => { this.submit() })
+    Doc.bind(page.newProfile, 'keyup', (e: KeyboardEvent) => {
+      if (e.key === 'Enter') this.submit()
+    })
+  }
+
+  /*
+   * refresh fetches the profiles. The form remains hidden if profiles are not
+   * supported.
+   */
+  async refresh () {
+    const page = this.page
+    const res = await getJSON('/api/profiles')
+    if (!app().checkResponse(res)) return
+    Doc.empty(page.profileSelect)
+    for (const name of res.profiles as string[]) {
+      const opt = document.createElement('option')
+      opt.value = name
+      opt.textContent = name
+      opt.selected = name === res.active
+      page.profileSelect.appendChild(opt)
+    }
+    page.newProfile.value = ''
+    Doc.hide(page.errMsg, page.switchMsg)
+    Doc.show(this.form)
+  }
+
+  async submit () {
+    const page = this.page
+    Doc.hide(page.errMsg)
+    const newName = this.canCreate ? page.newProfile.value?.trim() || '' : ''
+    const name = newName || page.profileSelect.value || ''
+    if (name === '') return
+    const loaded = app().loading(this.form)
+    const route = newName ? '/api/createprofile' : '/api/switchprofile'
+    const res = await postJSON(route, { name })
+    if (!app().checkResponse(res)) {
+      loaded()
+      Doc.showFormError(page.errMsg, res.msg)
+      return
+    }
+    page.switchMsg.textContent = intl.prep(intl.ID_SWITCHING_PROFILE, { profile: name })
+    Doc.show(page.switchMsg)
+    // The server is restarting. Wait for the new profile to become active.
+    for (;;) {
+      await new Promise(resolve => setTimeout(resolve, 1000))
+      try {
+        const res = await getJSON('/api/profiles')
+        if (res.ok && res.active === name) break
+      } catch (e) { /* server is still restarting */ }
+    }
+    window.location.href = '/'
+  }
+}
+
 const traitNewAddresser = 1 << 1
 
 /*
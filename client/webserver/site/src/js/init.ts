@@ -4,7 +4,8 @@ import { postJSON } from './http'
 import * as intl from './locales'
 import {
   bind as bindForm,
-  slideSwap
+  slideSwap,
+  ProfileForm
 } from './forms'
 import { Wave } from './charts'
 import {
@@ -23,6 +24,10 @@ interface InitResponse extends APIResponse {
   mnemonic?: string
 }
 
+interface RestoreResponse extends APIResponse {
+  errors: string[]
+}
+
 /*
  * InitPage is the page handler for the /init view. InitPage is essentially a
  * form handler. There are no non-form elements on /init. InitPage additionally
@@ -48,6 +53,8 @@ export default class InitPage extends BasePage {
   initForm: AppInitForm
   quickConfigForm: QuickConfigForm
   seedBackupForm: SeedBackupForm
+  profileForm: ProfileForm
+  restoreForm: RestoreForm
   mnemonic?: string
 
   constructor (body: HTMLElement) {
@@ -57,6 +64,16 @@ export default class InitPage extends BasePage {
     this.initForm = new AppInitForm(page.appPWForm, (pw: string, hosts: string[], mnemonic?: string) => { this.appInited(pw, hosts, mnemonic) })
     this.quickConfigForm = new QuickConfigForm(page.quickConfigForm, () => this.quickConfigDone())
     this.seedBackupForm = new SeedBackupForm(page.seedBackupForm, () => this.seedBackedUp())
+    this.profileForm = new ProfileForm(page.profileForm)
+    this.profileForm.refresh()
+    this.restoreForm = new RestoreForm(page.restoreForm, () => {
+      slideSwap(page.restoreForm, page.appPWForm)
+      Doc.show(page.profileForm)
+    })
+    Doc.bind(page.showRestoreForm, 'click', () => {
+      Doc.hide(page.profileForm)
+      slideSwap(page.appPWForm, page.restoreForm)
+    })
   }
 
   async appInited (pw: string, hosts: string[], mnemonic?: string) {
@@ -64,6 +81,7 @@ export default class InitPage extends BasePage {
     const page = this.page
     await this.quickConfigForm.update(pw, hosts)
     if (mnemonic) this.seedBackupForm.update(mnemonic)
+    Doc.hide(page.profileForm)
     slideSwap(page.appPWForm, page.quickConfigForm)
   }
 
@@ -140,6 +158,62 @@ class AppInitForm {
   }
 }
 
+/*
+ * RestoreForm handles the form that initializes the app from an app state
+ * backup file exported from the settings view.
+ */
+class RestoreForm {
+  form: PageElement
+  page: Record<string, PageElement>
+
+  constructor (form: PageElement, back: () => void) {
+    this.form = form
+    const page = this.page = Doc.idDescendants(form)
+    bindForm(form, page.restoreSubmit, () => this.submit())
+    Doc.bind(page.restoreBack, 'click', () => back())
+    Doc.bind(page.restoreErrAck, 'click', () => app().loadPage('wallets'))
+  }
+
+  async submit () {
+    const page = this.page
+    Doc.hide(page.restoreErrMsg)
+    const files = page.restoreFile.files
+    if (!files || !files.length) {
+      page.restoreErrMsg.textContent = intl.prep(intl.ID_NONE_SELECTED)
+      Doc.show(page.restoreErrMsg)
+      return
+    }
+    const pw = page.restorePW.value || ''
+    if (pw === '') {
+      page.restoreErrMsg.textContent = intl.prep(intl.ID_NO_PASS_ERROR_MSG)
+      Doc.show(page.restoreErrMsg)
+      return
+    }
+    const backup = (await files[0].text()).trim()
+    page.restorePW.value = ''
+    const loaded = app().loading(this.form)
+    const res: RestoreResponse = await postJSON('/api/restoreappstate', { pw, backup })
+    loaded()
+    if (!app().checkResponse(res)) {
+      page.restoreErrMsg.textContent = res.msg
+      Doc.show(page.restoreErrMsg)
+      return
+    }
+    await app().fetchUser()
+    app().loggedIn([], [])
+    if (!res.errors || !res.errors.length) {
+      app().loadPage('wallets')
+      return
+    }
+    Doc.hide(page.restoreInputs)
+    Doc.show(page.restoreErrors)
+    for (const err of res.errors) {
+      page.restoreErrorList.appendChild(document.createTextNode(err))
+      page.restoreErrorList.appendChild(document.createElement('br'))
+    }
+  }
+}
+
 // HostConfigRow is used by the QuickConfigForm to track the user's choices.
 interface HostConfigRow {
   host: string
@@ -221,6 +221,10 @@ export const ID_CEX_BALANCES = 'CEX_BALANCES'
 export const ID_CAUSES_SELF_MATCH = 'CAUSES_SELF_MATCH'
 export const ID_CEX_NOT_CONNECTED = 'CEX_NOT_CONNECTED'
 export const ID_DELETE_BOT = 'DELETE_BOT'
+export const ID_SWITCHING_PROFILE = 'SWITCHING_PROFILE'
+export const ID_RPC_USER = 'RPC_USER'
+export const ID_API_CALL_DENIED = 'API_CALL_DENIED'
+export const ID_REVOKE_API_KEY = 'REVOKE_API_KEY'
 
 let locale: Locale
 
@@ -1,7 +1,7 @@
 import { PageElement, app } from './registry'
 import Doc from './doc'
 import BasePage from './basepage'
-import { AppPassResetForm, LoginForm, slideSwap } from './forms'
+import { AppPassResetForm, LoginForm, ProfileForm, slideSwap } from './forms'
 
 /*
   LoginPage holds the form for login and password reset.
@@ -10,23 +10,27 @@ export default class LoginPage extends BasePage {
   loginForm: LoginForm
   page: Record<string, PageElement>
   appPassResetForm: AppPassResetForm
+  profileForm: ProfileForm
 
   constructor (body: HTMLElement) {
     super()
     const page = this.page = Doc.idDescendants(body)
     this.loginForm = new LoginForm(page.loginForm, () => { this.loggedIn() })
+    this.profileForm = new ProfileForm(page.profileForm)
 
     const prepAndDisplayLoginForm = () => {
       Doc.hide(page.resetAppPWForm)
       this.loginForm.refresh()
       Doc.show(page.loginForm)
       this.loginForm.focus()
+      this.profileForm.refresh()
     }
     prepAndDisplayLoginForm()
 
     this.appPassResetForm = new AppPassResetForm(page.resetAppPWForm, () => { prepAndDisplayLoginForm() })
     Doc.bind(page.forgotPassBtn, 'click', () => {
       this.appPassResetForm.refresh()
+      Doc.hide(page.profileForm)
       slideSwap(page.loginForm, page.resetAppPWForm)
     })
     Doc.bind(page.resetPassFormCloser, 'click', () => { prepAndDisplayLoginForm() })
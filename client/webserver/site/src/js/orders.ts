@@ -107,6 +107,10 @@ export default class OrdersPage extends BasePage {
       this.exportOrders()
     })
 
+    Doc.bind(page.exportLedger, 'click', () => {
+      this.exportLedger()
+    })
+
     page.showArchivedDateField.addEventListener('change', () => {
       if (page.showArchivedDateField.checked) Doc.show(page.archivedDateField)
       else Doc.hide(page.archivedDateField, page.deleteArchivedRecordsErr)
@@ -259,6 +263,20 @@ export default class OrdersPage extends BasePage {
     window.open(url.toString())
   }
 
+  /* exportLedger downloads a csv tax ledger with the selected cost basis method
+   * and format.
+   */
+  exportLedger () {
+    const page = this.page
+    const url = new URL(window.location.href)
+    url.search = new URLSearchParams({
+      method: page.ledgerMethod.value,
+      format: page.ledgerFormat.value
+    }).toString()
+    url.pathname = '/ledger/export'
+    window.open(url.toString())
+  }
+
   /* deleteArchivedRecords removes the user's archived orders and matches
    * created before user specified date time in millisecond. Deleted archived
    * records are saved to a CSV file if the user specify so.
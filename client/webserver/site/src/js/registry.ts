@@ -790,6 +790,14 @@ export interface SimpleArbConfig {
   numEpochsLeaveOpen: number
 }
 
+export interface ScriptConfig {
+  script?: string
+  scriptPath?: string
+  params?: any
+  maxPlacements?: number
+  maxSteps?: number
+}
+
 export interface BotCEXCfg {
   name: string
   autoRebalance?: AutoRebalanceConfig
@@ -834,6 +842,7 @@ export interface BotConfig {
   basicMarketMakingConfig?: BasicMarketMakingConfig
   arbMarketMakingConfig?: ArbMarketMakingConfig
   simpleArbConfig?: SimpleArbConfig
+  scriptConfig?: ScriptConfig
 }
 
 export interface CEXConfig {
@@ -1,7 +1,7 @@
 import Doc from './doc'
 import BasePage from './basepage'
 import State from './state'
-import { postJSON } from './http'
+import { postJSON, getJSON } from './http'
 import * as forms from './forms'
 import * as intl from './locales'
 import { setCoinHref } from './coinexplorers'
@@ -21,6 +21,23 @@ import {
 
 const animationLength = 300
 
+interface APIKey {
+  name: string
+  permissions: string[]
+  allowedIPs?: string[]
+  withdrawAddrs?: string[]
+  created: string
+}
+
+interface AuditEntry {
+  stamp: string
+  key?: string
+  ip: string
+  route: string
+  allowed: boolean
+  error?: string
+}
+
 export default class SettingsPage extends BasePage {
   body: HTMLElement
   currentDEX: Exchange
@@ -33,7 +50,9 @@ export default class SettingsPage extends BasePage {
   walletWaitForm: forms.WalletWaitForm
   dexAddrForm: forms.DEXAddressForm
   appPassResetForm: forms.AppPassResetForm
+  profileForm: forms.ProfileForm
   currentForm: PageElement
+  revokingKey: string
   keyup: (e: KeyboardEvent) => void
 
   constructor (body: HTMLElement) {
@@ -172,16 +191,45 @@ export default class SettingsPage extends BasePage {
     })
     forms.bind(page.exportSeedAuth, page.exportSeedSubmit, () => this.submitExportSeedReq())
 
+    Doc.bind(page.exportAppState, 'click', () => {
+      Doc.hide(page.exportAppStateErr)
+      this.showForm(page.exportAppStateForm)
+    })
+    forms.bind(page.exportAppStateForm, page.exportAppStateSubmit, () => this.submitExportAppState())
+
     Doc.bind(page.exportLogs, 'click', () => this.exportLogs())
 
     Doc.bind(page.gameCodeLink, 'click', () => this.showForm(page.gameCodeForm))
     Doc.bind(page.gameCodeSubmit, 'click', () => this.submitGameCode())
 
+    Doc.cleanTemplates(page.apiKeyTmpl, page.auditEntryTmpl)
+    Doc.bind(page.newAPIKey, 'click', () => {
+      page.apiKeyName.value = ''
+      page.apiKeyIPs.value = ''
+      page.apiKeyAddrs.value = ''
+      page.apiKeySecret.textContent = ''
+      Doc.hide(page.newAPIKeyErr, page.newAPIKeySecret)
+      Doc.show(page.newAPIKeyInputs)
+      this.showForm(page.newAPIKeyForm)
+    })
+    Doc.bind(page.newAPIKeySubmit, 'click', () => this.submitNewAPIKey())
+    Doc.bind(page.revokeAPIKeySubmit, 'click', () => this.revokeAPIKey())
+    Doc.bind(page.viewAuditLog, 'click', () => this.showAuditLog())
+
+    this.profileForm = new forms.ProfileForm(page.profileForm, true)
+    Doc.bind(page.manageProfiles, 'click', async () => {
+      await this.profileForm.refresh()
+      this.showForm(page.profileForm)
+    })
+    if (app().authed) this.fetchAPIKeys()
+
     const closePopups = () => {
       Doc.hide(page.forms)
       page.exportSeedPW.value = ''
+      page.exportAppStatePW.value = ''
       page.legacySeed.textContent = ''
       page.mnemonic.textContent = ''
+      page.apiKeySecret.textContent = ''
     }
 
     Doc.bind(page.forms, 'mousedown', (e: MouseEvent) => {
@@ -382,6 +430,27 @@ export default class SettingsPage extends BasePage {
     this.showForm(page.authorizeSeedDisplay)
   }
 
+  /* submitExportAppState exports the app state and downloads the backup. */
+  async submitExportAppState () {
+    const page = this.page
+    const loaded = app().loading(this.body)
+    const res = await postJSON('/api/exportappstate', {
+      pw: page.exportAppStatePW.value,
+      excludeSecrets: page.exportAppStateExclude.checked
+    })
+    loaded()
+    if (!app().checkResponse(res)) {
+      Doc.showFormError(page.exportAppStateErr, res.msg)
+      return
+    }
+    page.exportAppStatePW.value = ''
+    const a = document.createElement('a')
+    a.setAttribute('download', `bisonw-appstate-${new Date().toISOString().slice(0, 10)}.hex`)
+    a.setAttribute('href', 'data:text/plain,' + res.backup)
+    a.click()
+    Doc.hide(page.forms)
+  }
+
   /* showForm shows a modal form with a little animation. */
   async showForm (form: HTMLElement) {
     const page = this.page
@@ -486,6 +555,90 @@ export default class SettingsPage extends BasePage {
     }
   }
 
+  /* fetchAPIKeys lists the RPC API keys. */
+  async fetchAPIKeys () {
+    const page = this.page
+    const res = await getJSON('/api/apikeys')
+    // API keys are not managed if the RPC server is off.
+    if (!app().checkResponse(res)) return
+    Doc.empty(page.apiKeys)
+    for (const k of res.keys as APIKey[]) {
+      const row = page.apiKeyTmpl.cloneNode(true) as PageElement
+      const tmpl = Doc.parseTemplate(row)
+      tmpl.name.textContent = k.name
+      tmpl.permissions.textContent = k.permissions.join(', ')
+      Doc.bind(tmpl.revoke, 'click', () => {
+        this.revokingKey = k.name
+        page.revokeAPIKeyMsg.textContent = intl.prep(intl.ID_REVOKE_API_KEY, { name: k.name })
+        Doc.hide(page.revokeAPIKeyErr)
+        this.showForm(page.revokeAPIKeyForm)
+      })
+      page.apiKeys.appendChild(row)
+    }
+  }
+
+  async submitNewAPIKey () {
+    const page = this.page
+    Doc.hide(page.newAPIKeyErr)
+    const splitList = (s: string) => s.split(',').map(el => el.trim()).filter(el => el !== '')
+    const permissions = Doc.applySelector(page.apiKeyPerms, 'input[type=checkbox]')
+      .filter(cb => cb.checked).map(cb => cb.value)
+    const loaded = app().loading(page.newAPIKeyForm)
+    const res = await postJSON('/api/newapikey', {
+      name: page.apiKeyName.value,
+      permissions,
+      allowedIPs: splitList(page.apiKeyIPs.value || ''),
+      withdrawAddrs: splitList(page.apiKeyAddrs.value || '')
+    })
+    loaded()
+    if (!app().checkResponse(res)) {
+      Doc.showFormError(page.newAPIKeyErr, res.msg)
+      return
+    }
+    page.apiKeySecret.textContent = res.secret
+    Doc.hide(page.newAPIKeyInputs)
+    Doc.show(page.newAPIKeySecret)
+    this.fetchAPIKeys()
+  }
+
+  async revokeAPIKey () {
+    const page = this.page
+    const loaded = app().loading(page.revokeAPIKeyForm)
+    const res = await postJSON('/api/revokeapikey', { name: this.revokingKey })
+    loaded()
+    if (!app().checkResponse(res)) {
+      Doc.showFormError(page.revokeAPIKeyErr, res.msg)
+      return
+    }
+    Doc.hide(page.forms)
+    this.fetchAPIKeys()
+  }
+
+  /* showAuditLog shows the most recent authenticated RPC calls. */
+  async showAuditLog () {
+    const page = this.page
+    Doc.hide(page.auditLogErr)
+    Doc.empty(page.auditLog)
+    this.showForm(page.auditLogForm)
+    const res = await getJSON('/api/apiauditlog')
+    if (!app().checkResponse(res)) {
+      Doc.showFormError(page.auditLogErr, res.msg)
+      return
+    }
+    for (const e of res.entries as AuditEntry[]) {
+      const row = page.auditEntryTmpl.cloneNode(true) as PageElement
+      const tmpl = Doc.parseTemplate(row)
+      tmpl.stamp.textContent = new Date(e.stamp).toLocaleString()
+      tmpl.key.textContent = e.key || intl.prep(intl.ID_RPC_USER)
+      tmpl.ip.textContent = e.ip
+      tmpl.route.textContent = e.route
+      if (!e.allowed) tmpl.status.textContent = intl.prep(intl.ID_API_CALL_DENIED)
+      else if (e.error) tmpl.status.textContent = e.error
+      tmpl.status.classList.toggle('text-danger', !e.allowed || Boolean(e.error))
+      page.auditLog.appendChild(row)
+    }
+  }
+
   async submitGameCode () {
     const page = this.page
     Doc.hide(page.gameCodeErr)
@@ -4,6 +4,7 @@
 package webserver
 
 import (
+	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/db"
 	"decred.org/dcrdex/dex"
@@ -24,6 +25,19 @@ func simpleAck() *standardResponse {
 	}
 }
 
+// switchProfileForm is sent by the client to switch to or create a user
+// profile.
+type switchProfileForm struct {
+	Name string `json:"name"`
+}
+
+// profilesResponse is the response to the profiles API request.
+type profilesResponse struct {
+	OK       bool     `json:"ok"`
+	Active   string   `json:"active"`
+	Profiles []string `json:"profiles"`
+}
+
 // The loginForm is sent by the client to log in to a DEX.
 type loginForm struct {
 	Pass encode.PassBytes `json:"pass"`
@@ -109,6 +123,16 @@ type tradeForm struct {
 	Order *core.TradeForm  `json:"order"`
 }
 
+type conditionalOrderForm struct {
+	Pass  encode.PassBytes           `json:"pw"`
+	Order *core.ConditionalOrderForm `json:"order"`
+}
+
+type meshBondForm struct {
+	Pass encode.PassBytes   `json:"pw"`
+	Bond *core.MeshBondForm `json:"bond"`
+}
+
 type cancelForm struct {
 	OrderID dex.Bytes `json:"orderID"`
 }
@@ -122,11 +146,39 @@ type sendForm struct {
 	Pass     encode.PassBytes `json:"pw"`
 }
 
+// sendMultiForm is sent to pay multiple addresses.
+type sendMultiForm struct {
+	AssetID  uint32           `json:"assetID"`
+	Payments []*asset.Payment `json:"payments"`
+	Pass     encode.PassBytes `json:"pw"`
+}
+
+type sendMultiFeeForm struct {
+	AssetID  uint32           `json:"assetID"`
+	Payments []*asset.Payment `json:"payments"`
+}
+
 type accountExportForm struct {
 	Pass encode.PassBytes `json:"pw"`
 	Host string           `json:"host"`
 }
 
+type exportAppStateForm struct {
+	Pass           encode.PassBytes `json:"pw"`
+	ExcludeSecrets bool             `json:"excludeSecrets"`
+}
+
+type ledgerForm struct {
+	Start  uint64 `json:"start"`
+	End    uint64 `json:"end"`
+	Method string `json:"method"`
+}
+
+type restoreAppStateForm struct {
+	Pass   encode.PassBytes `json:"pw"`
+	Backup dex.Bytes        `json:"backup"`
+}
+
 type accountImportForm struct {
 	Pass    encode.PassBytes `json:"pw"`
 	Account *core.Account    `json:"account"`
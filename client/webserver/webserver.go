@@ -28,6 +28,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/db"
@@ -40,6 +41,7 @@ import (
 	"decred.org/dcrdex/dex/encode"
 	"decred.org/dcrdex/dex/encrypt"
 	"decred.org/dcrdex/dex/version"
+	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/decred/dcrd/certgen"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -138,6 +140,7 @@ type clientCore interface {
 	AccountImport(pw []byte, account *core.Account, bonds []*db.Bond) error
 	ToggleAccountStatus(pw []byte, host string, disable bool) error
 	IsInitialized() bool
+	LoggedIn() bool
 	ExportSeed(pw []byte) (string, error)
 	PreOrder(*core.TradeForm) (*core.OrderEstimate, error)
 	WalletLogFilePath(assetID uint32) (string, error)
@@ -151,6 +154,19 @@ type clientCore interface {
 	ToggleRateSourceStatus(src string, disable bool) error
 	FiatRateSources() map[string]bool
 	EstimateSendTxFee(address string, assetID uint32, value uint64, subtract, maxWithdraw bool) (fee uint64, isValidAddress bool, err error)
+	SendMulti(pw []byte, assetID uint32, payments []*asset.Payment) ([]asset.Coin, error)
+	EstimateSendMultiTxFee(assetID uint32, payments []*asset.Payment) (uint64, error)
+	PlaceConditionalOrder(pw []byte, form *core.ConditionalOrderForm) ([]*db.ConditionalOrder, error)
+	ConditionalOrders() ([]*db.ConditionalOrder, error)
+	CancelConditionalOrder(id dex.Bytes) error
+	AddMarketAlert(form *core.MarketAlertForm) (*db.MarketAlert, error)
+	MarketAlerts() ([]*db.MarketAlert, error)
+	RemoveMarketAlert(id dex.Bytes) error
+	PostMeshBond(pw []byte, form *core.MeshBondForm) (*tanka.Bond, error)
+	MeshBonds() ([]*tanka.Bond, error)
+	ExportAppState(pw []byte, form *core.AppStateExportForm) ([]byte, error)
+	RestoreAppState(pw, bundle []byte) (*core.AppStateRestoration, error)
+	Ledger(form *core.LedgerForm) (*core.Ledger, error)
 	ValidateAddress(address string, assetID uint32) (bool, error)
 	DeleteArchivedRecordsWithBackup(olderThan *time.Time, saveMatchesToFile, saveOrdersToFile bool) (string, int, error)
 	WalletPeers(assetID uint32) ([]*asset.WalletPeer, error)
@@ -192,6 +208,9 @@ type MMCore interface {
 	UpdateRunningBotCfg(cfg *mm.BotConfig, balanceDiffs *mm.BotInventoryDiffs, autoRebalanceCfg *mm.AutoRebalanceConfig, saveUpdate bool) error
 	AvailableBalances(mkt *mm.MarketWithHost, cexName *string) (dexBalances, cexBalances map[uint32]uint64, _ error)
 	MaxFundingFees(mkt *mm.MarketWithHost, maxBuyPlacements, maxSellPlacements uint32, baseOptions, quoteOptions map[string]string) (buyFees, sellFees uint64, err error)
+	ExportState(excludeSecrets bool) (json.RawMessage, error)
+	RestoreState(b json.RawMessage) error
+	LedgerEvents() ([]*core.LedgerEvent, error)
 }
 
 // genCertPair generates a key/cert pair to the paths provided.
@@ -250,6 +269,12 @@ type Config struct {
 	HttpProf        bool
 	Tor             bool
 	MainLogFilePath string
+	// Profiles is optional. If not set, profiles cannot be listed, switched
+	// or created.
+	Profiles profileManager
+	// APIKeys is optional. If not set, RPC API keys cannot be managed from
+	// the settings page.
+	APIKeys apiKeyManager
 }
 
 type valStamp struct {
@@ -257,6 +282,28 @@ type valStamp struct {
 	stamp time.Time
 }
 
+// profileManager manages the user profiles. It is satisfied by
+// *app.Profiles.
+type profileManager interface {
+	// Profiles returns the active profile and all existing profiles.
+	Profiles() (active string, all []string, err error)
+	// SwitchProfile logs out of the active profile and requests a switch to
+	// the named profile, which must exist.
+	SwitchProfile(name string) error
+	// CreateProfile creates the named profile, logs out of the active
+	// profile and requests a switch to the new profile.
+	CreateProfile(name string) error
+}
+
+// apiKeyManager manages the RPC server's API keys. It is satisfied by
+// *apikey.Store.
+type apiKeyManager interface {
+	Create(form *apikey.KeyForm) (*apikey.Key, string, error)
+	Revoke(name string) error
+	Keys() []*apikey.Key
+	AuditLog(n int) ([]*apikey.AuditEntry, error)
+}
+
 // WebServer is a single-client http and websocket server enabling a browser
 // interface to Bison Wallet.
 type WebServer struct {
@@ -287,6 +334,8 @@ type WebServer struct {
 
 	useDEXBranding  bool
 	mainLogFilePath string
+	profiles        profileManager
+	apiKeys         apiKeyManager
 }
 
 // New is the constructor for a new WebServer. CustomSiteDir in the Config can
@@ -415,6 +464,8 @@ func New(cfg *Config) (*WebServer, error) {
 		appVersion:      userAppVersion(cfg.AppVersion, false),
 		useDEXBranding:  useDEXBranding,
 		mainLogFilePath: cfg.MainLogFilePath,
+		profiles:        cfg.Profiles,
+		apiKeys:         cfg.APIKeys,
 	}
 	s.lang.Store(lang)
 
@@ -494,6 +545,7 @@ func New(cfg *Config) (*WebServer, error) {
 					webAuth.Get(homeRoute, s.handleHome)
 					webAuth.Get(walletsRoute, s.handleWallets)
 					webAuth.Get(walletLogRoute, s.handleWalletLogFile)
+					webAuth.Get(exportLedgerRoute, s.handleExportLedger)
 				})
 			})
 
@@ -518,12 +570,15 @@ func New(cfg *Config) (*WebServer, error) {
 	mux.Route("/api", func(r chi.Router) {
 		r.Use(middleware.AllowContentType("application/json"))
 		r.Post("/init", s.apiInit)
+		r.Post("/restoreappstate", s.apiRestoreAppState)
 		r.Get("/isinitialized", s.apiIsInitialized)
 		r.Post("/resetapppassword", s.apiResetAppPassword)
 		r.Get("/user", s.apiUser)
 		r.Post("/locale", s.apiLocale)
 		r.Post("/setlocale", s.apiSetLocale)
 		r.Get("/buildinfo", s.apiBuildInfo)
+		r.Get("/profiles", s.apiProfiles)
+		r.Post("/switchprofile", s.apiSwitchProfile)
 
 		r.Group(func(apiInit chi.Router) {
 			apiInit.Use(s.rejectUninited)
@@ -552,6 +607,19 @@ func New(cfg *Config) (*WebServer, error) {
 			apiAuth.Post("/trade", s.apiTrade)
 			apiAuth.Post("/tradeasync", s.apiTradeAsync)
 			apiAuth.Post("/cancel", s.apiCancel)
+			apiAuth.Post("/conditionalorder", s.apiConditionalOrder)
+			apiAuth.Get("/conditionalorders", s.apiConditionalOrders)
+			apiAuth.Post("/cancelconditionalorder", s.apiCancelConditionalOrder)
+			apiAuth.Post("/addalert", s.apiAddMarketAlert)
+			apiAuth.Get("/alerts", s.apiMarketAlerts)
+			apiAuth.Post("/removealert", s.apiRemoveMarketAlert)
+			apiAuth.Post("/postmeshbond", s.apiPostMeshBond)
+			apiAuth.Get("/meshbonds", s.apiMeshBonds)
+			apiAuth.Get("/apikeys", s.apiAPIKeys)
+			apiAuth.Post("/newapikey", s.apiNewAPIKey)
+			apiAuth.Post("/revokeapikey", s.apiRevokeAPIKey)
+			apiAuth.Get("/apiauditlog", s.apiAPIAuditLog)
+			apiAuth.Post("/createprofile", s.apiCreateProfile)
 			apiAuth.Post("/logout", s.apiLogout)
 			apiAuth.Post("/balance", s.apiGetBalance)
 			apiAuth.Post("/parseconfig", s.apiParseConfig)
@@ -562,11 +630,15 @@ func New(cfg *Config) (*WebServer, error) {
 			apiAuth.Post("/orders", s.apiOrders)
 			apiAuth.Post("/order", s.apiOrder)
 			apiAuth.Post("/send", s.apiSend)
+			apiAuth.Post("/sendmulti", s.apiSendMulti)
+			apiAuth.Post("/sendmultifee", s.apiEstimateSendMultiTxFee)
 			apiAuth.Post("/maxbuy", s.apiMaxBuy)
 			apiAuth.Post("/maxsell", s.apiMaxSell)
 			apiAuth.Post("/preorder", s.apiPreOrder)
 			apiAuth.Post("/exportaccount", s.apiAccountExport)
 			apiAuth.Post("/exportseed", s.apiExportSeed)
+			apiAuth.Post("/exportappstate", s.apiExportAppState)
+			apiAuth.Post("/ledger", s.apiLedger)
 			apiAuth.Post("/importaccount", s.apiAccountImport)
 			apiAuth.Post("/toggleaccountstatus", s.apiToggleAccountStatus)
 			apiAuth.Post("/accelerateorder", s.apiAccelerateOrder)
@@ -13,11 +13,13 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"decred.org/dcrdex/client/apikey"
 	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/db"
@@ -26,6 +28,7 @@ import (
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/encode"
 	"decred.org/dcrdex/dex/order"
+	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -72,6 +75,7 @@ type TCore struct {
 	logoutErr        error
 	initErr          error
 	isInited         bool
+	loggedIn         bool
 	getDEXConfigErr  error
 	createWalletErr  error
 	openWalletErr    error
@@ -92,6 +96,14 @@ type TCore struct {
 	tradeErr         error
 	notes            []*db.Notification
 	notesErr         error
+	condOrders       []*db.ConditionalOrder
+	condOrderErr     error
+	marketAlerts     []*db.MarketAlert
+	marketAlertErr   error
+	meshBonds        []*tanka.Bond
+	meshBondErr      error
+	appStateErr      error
+	ledgerErr        error
 }
 
 func (c *TCore) Network() dex.Network                         { return dex.Mainnet }
@@ -134,6 +146,7 @@ func (c *TCore) InitializeClient(pw []byte, seed *string) (string, error) {
 }
 func (c *TCore) Login(pw []byte) error { return c.loginErr }
 func (c *TCore) IsInitialized() bool   { return c.isInited }
+func (c *TCore) LoggedIn() bool        { return c.loggedIn }
 func (c *TCore) SyncBook(dex string, base, quote uint32) (*orderbook.OrderBook, core.BookFeed, error) {
 	return nil, c.syncFeed, c.syncErr
 }
@@ -185,6 +198,63 @@ func (c *TCore) SupportedAssets() map[uint32]*core.SupportedAsset {
 func (c *TCore) Send(pw []byte, assetID uint32, value uint64, address string, subtract bool) (asset.Coin, error) {
 	return &tCoin{id: []byte{0xde, 0xc7, 0xed}}, c.sendErr
 }
+func (c *TCore) SendMulti(pw []byte, assetID uint32, payments []*asset.Payment) ([]asset.Coin, error) {
+	coins := make([]asset.Coin, 0, len(payments))
+	for i := range payments {
+		coins = append(coins, &tCoin{id: []byte{byte(i)}})
+	}
+	return coins, c.sendErr
+}
+func (c *TCore) EstimateSendMultiTxFee(assetID uint32, payments []*asset.Payment) (uint64, error) {
+	return c.estFee, c.estFeeErr
+}
+func (c *TCore) PlaceConditionalOrder(pw []byte, form *core.ConditionalOrderForm) ([]*db.ConditionalOrder, error) {
+	return c.condOrders, c.condOrderErr
+}
+func (c *TCore) ConditionalOrders() ([]*db.ConditionalOrder, error) {
+	return c.condOrders, c.condOrderErr
+}
+func (c *TCore) CancelConditionalOrder(id dex.Bytes) error {
+	return c.condOrderErr
+}
+func (c *TCore) AddMarketAlert(form *core.MarketAlertForm) (*db.MarketAlert, error) {
+	if c.marketAlertErr != nil {
+		return nil, c.marketAlertErr
+	}
+	return c.marketAlerts[0], nil
+}
+func (c *TCore) MarketAlerts() ([]*db.MarketAlert, error) {
+	return c.marketAlerts, c.marketAlertErr
+}
+func (c *TCore) RemoveMarketAlert(id dex.Bytes) error {
+	return c.marketAlertErr
+}
+func (c *TCore) PostMeshBond(pw []byte, form *core.MeshBondForm) (*tanka.Bond, error) {
+	if c.meshBondErr != nil {
+		return nil, c.meshBondErr
+	}
+	return c.meshBonds[0], nil
+}
+func (c *TCore) MeshBonds() ([]*tanka.Bond, error) {
+	return c.meshBonds, c.meshBondErr
+}
+func (c *TCore) ExportAppState(pw []byte, form *core.AppStateExportForm) ([]byte, error) {
+	return []byte{0x01}, c.appStateErr
+}
+func (c *TCore) RestoreAppState(pw, bundle []byte) (*core.AppStateRestoration, error) {
+	return &core.AppStateRestoration{Errors: []string{}}, c.appStateErr
+}
+func (c *TCore) Ledger(form *core.LedgerForm) (*core.Ledger, error) {
+	if c.ledgerErr != nil {
+		return nil, c.ledgerErr
+	}
+	return &core.Ledger{
+		Method:   form.Method,
+		Currency: core.LedgerCurrency,
+		Events:   []*core.LedgerEvent{},
+		Assets:   []*core.LedgerAssetSummary{},
+	}, nil
+}
 func (c *TCore) ValidateAddress(address string, assetID uint32) (bool, error) {
 	return c.validAddr, nil
 }
@@ -602,6 +672,129 @@ func TestSend(t *testing.T) {
 	}
 }
 
+func TestAPISendMulti(t *testing.T) {
+	s, tCore, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	writer := new(TWriter)
+	reader := new(TReader)
+	body := &sendMultiForm{
+		AssetID:  42,
+		Payments: []*asset.Payment{{Address: "addr1", Value: 1e8}, {Address: "addr2", Value: 2e8}},
+		Pass:     encode.PassBytes("dummyAppPass"),
+	}
+
+	want := `{"ok":true,"coins":["00","01"]}`
+	ensureResponse(t, s.apiSendMulti, want, reader, writer, body, nil)
+
+	want = fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	tCore.sendErr = tErr
+	ensureResponse(t, s.apiSendMulti, want, reader, writer, body, nil)
+	tCore.sendErr = nil
+
+	feeBody := &sendMultiFeeForm{
+		AssetID:  body.AssetID,
+		Payments: body.Payments,
+	}
+	want = `{"ok":true,"txfee":10000}`
+	tCore.estFee = 10000
+	ensureResponse(t, s.apiEstimateSendMultiTxFee, want, reader, writer, feeBody, nil)
+
+	want = fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	tCore.estFeeErr = tErr
+	ensureResponse(t, s.apiEstimateSendMultiTxFee, want, reader, writer, feeBody, nil)
+}
+
+func TestAPIConditionalOrders(t *testing.T) {
+	s, tCore, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	writer := new(TWriter)
+	reader := new(TReader)
+	tCore.condOrders = []*db.ConditionalOrder{{ID: dex.Bytes{0x01}, Kind: db.StopLoss}}
+	body := &conditionalOrderForm{
+		Pass: encode.PassBytes("dummyAppPass"),
+		Order: &core.ConditionalOrderForm{
+			Kind:          db.StopLoss,
+			Trade:         &core.TradeForm{Host: "dex.tld", Sell: true, Base: 42, Quote: 0, Qty: 1e8},
+			TriggerSource: db.TriggerMidGap,
+			TriggerRate:   1e6,
+		},
+	}
+	ordJSON := `{"id":"01","kind":"stoploss","host":"","isLimit":false,"sell":false,"base":0,"quote":0,"qty":0,"rate":0,"tifnow":false,"options":null,"triggerSource":"","status":0,"stamp":0}`
+	want := `{"ok":true,"orders":[` + ordJSON + `]}`
+	ensureResponse(t, s.apiConditionalOrder, want, reader, writer, body, nil)
+
+	want = `{"ok":false,"msg":"order missing"}`
+	ensureResponse(t, s.apiConditionalOrder, want, reader, writer, &conditionalOrderForm{Pass: body.Pass}, nil)
+
+	want = fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	tCore.condOrderErr = tErr
+	ensureResponse(t, s.apiConditionalOrder, want, reader, writer, body, nil)
+	tCore.condOrderErr = nil
+
+	want = `{"ok":true}`
+	ensureResponse(t, s.apiCancelConditionalOrder, want, reader, writer, map[string]string{"id": "01"}, nil)
+
+	want = fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	tCore.condOrderErr = tErr
+	ensureResponse(t, s.apiCancelConditionalOrder, want, reader, writer, map[string]string{"id": "01"}, nil)
+}
+
+func TestAPIMarketAlerts(t *testing.T) {
+	s, tCore, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	writer := new(TWriter)
+	reader := new(TReader)
+	tCore.marketAlerts = []*db.MarketAlert{{ID: dex.Bytes{0x01}, Kind: db.AlertVolume, Host: "dex.tld", Base: 42, Percent: 50}}
+	body := &core.MarketAlertForm{Kind: db.AlertVolume, Host: "dex.tld", Base: 42, Percent: 50}
+	alertJSON := `{"id":"01","kind":"volume","host":"dex.tld","base":42,"quote":0,"percent":50,"repeat":false,"status":0,"stamp":0}`
+	want := `{"ok":true,"alert":` + alertJSON + `}`
+	ensureResponse(t, s.apiAddMarketAlert, want, reader, writer, body, nil)
+
+	want = `{"ok":true,"alerts":[` + alertJSON + `]}`
+	ensureResponse(t, s.apiMarketAlerts, want, reader, writer, nil, nil)
+
+	want = `{"ok":true}`
+	ensureResponse(t, s.apiRemoveMarketAlert, want, reader, writer, map[string]string{"id": "01"}, nil)
+
+	tCore.marketAlertErr = tErr
+	want = fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	ensureResponse(t, s.apiAddMarketAlert, want, reader, writer, body, nil)
+	want = fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	ensureResponse(t, s.apiRemoveMarketAlert, want, reader, writer, map[string]string{"id": "01"}, nil)
+}
+
+func TestAPIMeshBonds(t *testing.T) {
+	s, tCore, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	writer := new(TWriter)
+	reader := new(TReader)
+	lockTime := time.Unix(1700000000, 0).UTC()
+	tCore.meshBonds = []*tanka.Bond{{AssetID: 42, CoinID: dex.Bytes{0x01}, Strength: 1, Expiration: lockTime, Maturation: lockTime}}
+	body := &meshBondForm{
+		Pass: encode.PassBytes("dummyAppPass"),
+		Bond: &core.MeshBondForm{AssetID: 42, Amount: 1e8, Strength: 1, LockTime: lockTime},
+	}
+	bondJSON := `{"peerID":[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0],"assetID":42,"coinID":"01","strength":1,` +
+		`"expiration":"2023-11-14T22:13:20Z","maturation":"2023-11-14T22:13:20Z"}`
+	want := `{"ok":true,"bond":` + bondJSON + `}`
+	ensureResponse(t, s.apiPostMeshBond, want, reader, writer, body, nil)
+
+	want = `{"ok":false,"msg":"bond missing"}`
+	ensureResponse(t, s.apiPostMeshBond, want, reader, writer, &meshBondForm{Pass: body.Pass}, nil)
+
+	want = `{"ok":true,"bonds":[` + bondJSON + `]}`
+	ensureResponse(t, s.apiMeshBonds, want, reader, writer, nil, nil)
+
+	tCore.meshBondErr = tErr
+	want = fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	ensureResponse(t, s.apiPostMeshBond, want, reader, writer, body, nil)
+	ensureResponse(t, s.apiMeshBonds, want, reader, writer, nil, nil)
+}
+
 func TestAPIInit(t *testing.T) {
 	writer := new(TWriter)
 	var body any
@@ -629,6 +822,63 @@ func TestAPIInit(t *testing.T) {
 	tCore.initErr = nil
 }
 
+func TestAPIAppState(t *testing.T) {
+	s, tCore, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	writer := new(TWriter)
+	reader := new(TReader)
+	exportForm := &exportAppStateForm{Pass: encode.PassBytes("dummyAppPass")}
+	ensureResponse(t, s.apiExportAppState, `{"ok":true,"backup":"01"}`, reader, writer, exportForm, nil)
+
+	restoreForm := &restoreAppStateForm{Pass: encode.PassBytes("dummyAppPass"), Backup: []byte{0x01}}
+	ensureResponse(t, s.apiRestoreAppState, `{"ok":true,"errors":[]}`, reader, writer, restoreForm, nil)
+
+	want := fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr)
+	tCore.appStateErr = tErr
+	ensureResponse(t, s.apiExportAppState, want, reader, writer, exportForm, nil)
+	ensureResponse(t, s.apiRestoreAppState, want, reader, writer, restoreForm, nil)
+}
+
+func TestLedger(t *testing.T) {
+	s, tCore, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	writer := new(TWriter)
+	reader := new(TReader)
+	form := &ledgerForm{Method: core.CostBasisLIFO}
+	want := `{"ok":true,"ledger":{"method":"lifo","currency":"USD","start":0,"end":0,"events":[],"assets":[],"realizedGain":0}}`
+	ensureResponse(t, s.apiLedger, want, reader, writer, form, nil)
+
+	exportLedger := func(query string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		s.handleExportLedger(rec, httptest.NewRequest(http.MethodGet, exportLedgerRoute+query, nil))
+		return rec
+	}
+	rec := exportLedger("?start=1000&format=koinly")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ledger export failed with status %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv" {
+		t.Fatalf("wrong content type %q", ct)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "Date,Sent Amount") {
+		t.Fatalf("wrong koinly CSV %q", rec.Body.String())
+	}
+	for _, query := range []string{"?start=abc", "?format=turbotax"} {
+		if rec := exportLedger(query); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected bad request, got %d", query, rec.Code)
+		}
+	}
+
+	tCore.ledgerErr = tErr
+	ensureResponse(t, s.apiLedger, fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr), reader, writer, form, nil)
+	if rec := exportLedger(""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected bad request for core error, got %d", rec.Code)
+	}
+}
+
 // TODO: TestAPIGetDEXInfo
 
 func TestAPINewWallet(t *testing.T) {
@@ -677,6 +927,128 @@ func TestAPILogout(t *testing.T) {
 	tCore.logoutErr = nil
 }
 
+type tProfiles struct {
+	active    string
+	all       []string
+	switchErr error
+	switched  string
+	created   string
+}
+
+func (p *tProfiles) Profiles() (string, []string, error) {
+	return p.active, p.all, nil
+}
+
+func (p *tProfiles) SwitchProfile(name string) error {
+	if p.switchErr != nil {
+		return p.switchErr
+	}
+	p.switched = name
+	return nil
+}
+
+func (p *tProfiles) CreateProfile(name string) error {
+	if p.switchErr != nil {
+		return p.switchErr
+	}
+	p.created = name
+	return nil
+}
+
+func TestAPIProfiles(t *testing.T) {
+	writer := new(TWriter)
+	reader := new(TReader)
+	s, _, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	ensureResponse(t, s.apiProfiles, `{"ok":false,"msg":"profiles are not supported"}`, reader, writer, nil, nil)
+	s.profiles = &tProfiles{active: "alice", all: []string{"default", "alice"}}
+	ensureResponse(t, s.apiProfiles, `{"ok":true,"active":"alice","profiles":["default","alice"]}`, reader, writer, nil, nil)
+}
+
+func TestAPISwitchProfile(t *testing.T) {
+	writer := new(TWriter)
+	reader := new(TReader)
+	s, tCore, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	body := &switchProfileForm{Name: "bob"}
+	ensure := func(handler func(http.ResponseWriter, *http.Request), want string, cookies map[string]string) {
+		t.Helper()
+		ensureResponse(t, handler, want, reader, writer, body, cookies)
+	}
+	ensure(s.apiSwitchProfile, `{"ok":false,"msg":"profiles are not supported"}`, nil)
+	ensure(s.apiCreateProfile, `{"ok":false,"msg":"profiles are not supported"}`, nil)
+
+	// Switching is allowed without a session while logged out.
+	profiles := &tProfiles{active: "alice"}
+	s.profiles = profiles
+	ensure(s.apiSwitchProfile, `{"ok":true}`, nil)
+	if profiles.switched != "bob" || profiles.created != "" {
+		t.Fatalf("profile not switched")
+	}
+
+	profiles.switchErr = tErr
+	ensure(s.apiSwitchProfile, fmt.Sprintf(`{"ok":false,"msg":"%s"}`, tErr), nil)
+	profiles.switchErr = nil
+
+	// Once logged in, only an authorized session can switch.
+	tCore.loggedIn = true
+	ensure(s.apiSwitchProfile, "not authorized - login first", nil)
+	token := s.authorize()
+	ensure(s.apiSwitchProfile, `{"ok":true}`, map[string]string{authCK: token})
+	if s.isAuthed(&http.Request{Header: http.Header{"Cookie": []string{authCK + "=" + token}}}) {
+		t.Fatalf("still authorized after switching profiles")
+	}
+
+	// Creating a profile is an authorized route.
+	ensure(s.apiCreateProfile, `{"ok":true}`, nil)
+	if profiles.created != "bob" {
+		t.Fatalf("profile not created")
+	}
+}
+
+func TestAPIKeys(t *testing.T) {
+	writer := new(TWriter)
+	reader := new(TReader)
+	s, _, shutdown := newTServer(t, false)
+	defer shutdown()
+
+	ensureResponse(t, s.apiAPIKeys, `{"ok":false,"msg":"api keys are not supported"}`, reader, writer, nil, nil)
+	keys, err := apikey.NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	s.apiKeys = keys
+	ensureResponse(t, s.apiAPIKeys, `{"ok":true,"keys":[]}`, reader, writer, nil, nil)
+
+	form := &apikey.KeyForm{Name: "bot", Permissions: []apikey.Permission{apikey.PermTrade}}
+	ensureResponse(t, s.apiNewAPIKey, `{"ok":false,"msg":"invalid key name \"bad name\". use 1 to 32 letters, numbers, underscores or hyphens"}`,
+		reader, writer, &apikey.KeyForm{Name: "bad name", Permissions: form.Permissions}, nil)
+	reader.msg, _ = json.Marshal(form)
+	req, _ := http.NewRequest("POST", "/", reader)
+	s.apiNewAPIKey(writer, req)
+	res := new(struct {
+		OK     bool        `json:"ok"`
+		Key    *apikey.Key `json:"key"`
+		Secret string      `json:"secret"`
+	})
+	if err := json.Unmarshal(writer.b, res); err != nil {
+		t.Fatalf("error decoding new key response: %v", err)
+	}
+	writer.b = nil
+	if !res.OK || res.Key.Name != "bot" {
+		t.Fatalf("wrong new key response")
+	}
+	if _, ok := keys.Authenticate("bot", res.Secret); !ok {
+		t.Fatalf("new key secret not accepted")
+	}
+
+	ensureResponse(t, s.apiAPIAuditLog, `{"ok":true,"entries":[]}`, reader, writer, nil, nil)
+	ensureResponse(t, s.apiRevokeAPIKey, `{"ok":true}`, reader, writer, map[string]string{"name": "bot"}, nil)
+	ensureResponse(t, s.apiRevokeAPIKey, `{"ok":false,"msg":"unknown api key"}`, reader, writer, map[string]string{"name": "bot"}, nil)
+}
+
 func TestApiGetBalance(t *testing.T) {
 	writer := new(TWriter)
 	reader := new(TReader)
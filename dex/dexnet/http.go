@@ -49,7 +49,7 @@ func WithErrorParsing(thing interface{}) *RequestOption {
 // be JSON-unmarshaled into thing.
 func Post(ctx context.Context, uri string, thing interface{}, body []byte, opts ...*RequestOption) error {
 	var r io.Reader
-	if len(body) == 1 {
+	if len(body) > 0 {
 		r = bytes.NewReader(body)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, r)
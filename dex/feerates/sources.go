@@ -10,18 +10,24 @@ import (
 	"sync"
 	"time"
 
-	"decred.org/dcrdex/client/asset/bch"
-	"decred.org/dcrdex/client/asset/btc"
-	"decred.org/dcrdex/client/asset/dcr"
-	"decred.org/dcrdex/client/asset/doge"
-	"decred.org/dcrdex/client/asset/eth"
-	"decred.org/dcrdex/client/asset/ltc"
-	"decred.org/dcrdex/client/asset/zec"
 	"decred.org/dcrdex/dex"
-	"decred.org/dcrdex/server/asset/dash"
+	dexeth "decred.org/dcrdex/dex/networks/eth"
 	"github.com/decred/dcrd/dcrutil/v4"
 )
 
+// BIP IDs of the supported chains. The asset packages aren't imported so that
+// importing feerates doesn't register the asset drivers.
+const (
+	btcID  = 0
+	ltcID  = 2
+	dogeID = 3
+	dashID = 5
+	dcrID  = 42
+	ethID  = 60
+	zecID  = 133
+	bchID  = 145
+)
+
 const (
 	defaultFeeRefreshInterval = 5 * time.Minute
 	FeeRateEstimateExpiry     = 30 * time.Minute
@@ -75,10 +81,10 @@ var (
 	// fee rate estimate for at the time of writing. Fortunately, we can request all
 	// 4 simultaneously without issues as requests are capped at 5 per second.
 	blockDaemonChainsSupported = map[uint32]string{
-		btc.BipID: "bitcoin",
-		bch.BipID: "bitcoincash",
-		eth.BipID: "ethereum",
-		ltc.BipID: "litecoin",
+		btcID: "bitcoin",
+		bchID: "bitcoincash",
+		ethID: "ethereum",
+		ltcID: "litecoin",
 	}
 
 	// tatumChainsSupported is a map of bipIDs to string value used in fetching
@@ -86,22 +92,22 @@ var (
 	// rate estimate for at the time of writing. Unfortunately, we can only make
 	// a maximum of 3 request per seconds.
 	tatumChainsSupported = map[uint32]string{
-		btc.BipID:  "BTC",
-		eth.BipID:  "ETH",
-		doge.BipID: "DOGE",
-		ltc.BipID:  "LTC",
+		btcID:  "BTC",
+		ethID:  "ETH",
+		dogeID: "DOGE",
+		ltcID:  "LTC",
 	}
 
 	// blockchairChainsSupported is a map of bipIDs to string value used in
 	// identifying fee rate estimate data retrieved from the blockchair API.
 	blockchairChainsSupported = map[uint32]string{
-		btc.BipID:  "bitcoin",
-		zec.BipID:  "zcash",
-		eth.BipID:  "ethereum",
-		bch.BipID:  "bitcoin-cash",
-		ltc.BipID:  "litecoin",
-		doge.BipID: "dogecoin",
-		dash.BipID: "dash",
+		btcID:  "bitcoin",
+		zecID:  "zcash",
+		ethID:  "ethereum",
+		bchID:  "bitcoin-cash",
+		ltcID:  "litecoin",
+		dogeID: "dogecoin",
+		dashID: "dash",
 	}
 
 	// blockCypherChainsSupported is a map of bipIDs to string value used in
@@ -110,11 +116,11 @@ var (
 	// is returned from the blockCypher API but is yet to be included in their
 	// doc.
 	blockCypherChainsSupported = map[uint32]string{
-		btc.BipID:  "btc",
-		eth.BipID:  "eth",
-		ltc.BipID:  "litecoin",
-		doge.BipID: "doge",
-		dash.BipID: "dash",
+		btcID:  "btc",
+		ethID:  "eth",
+		ltcID:  "litecoin",
+		dogeID: "doge",
+		dashID: "dash",
 	}
 
 	// Expected API errors
@@ -188,7 +194,7 @@ func feeRateEstimateSources(net dex.Network, cfg Config) []*feeRateEstimateSourc
 
 					var feeRateEstimate uint64
 					var err error
-					if chainID == eth.BipID {
+					if chainID == ethID {
 						feeRateEstimate, err = fetchBlockDaemonEthFeeRateEstimate(ctx, net, authHeader)
 					} else {
 						var resp struct {
@@ -227,7 +233,7 @@ func feeRateEstimateSources(net dex.Network, cfg Config) []*feeRateEstimateSourc
 
 				feeRateEstimates := make(map[uint32]uint64, 1)
 				for _, chainID := range chainsIDs {
-					if chainID != dcr.BipID {
+					if chainID != dcrID {
 						continue
 					}
 
@@ -327,7 +333,7 @@ func feeRateEstimateSources(net dex.Network, cfg Config) []*feeRateEstimateSourc
 
 					feeRateEstimate := feeInfo.SuggestedTransactionFeePerBytePerSat
 					if feeInfo.SuggestedFeeGweiOptions.Fast > 0 {
-						feeRateEstimate = feeInfo.SuggestedFeeGweiOptions.Fast * eth.WalletInfo.UnitInfo.Conventional.ConversionFactor
+						feeRateEstimate = feeInfo.SuggestedFeeGweiOptions.Fast * dexeth.UnitInfo.Conventional.ConversionFactor
 					}
 					feeRateEstimates[chainID] = feeRateEstimate
 				}
@@ -38,23 +38,29 @@ func (db *DB) Table(name string) (*Table, error) {
 
 // GetRaw retrieves a value from the Table as raw bytes.
 func (t *Table) GetRaw(k KV) (b []byte, err error) {
+	err = t.View(func(txn *badger.Txn) error {
+		b, err = t.GetRawTxn(txn, k)
+		return err
+	})
+	return
+}
+
+// GetRawTxn is like GetRaw, but reads the value as part of the provided
+// transaction.
+func (t *Table) GetRawTxn(txn *badger.Txn, k KV) ([]byte, error) {
 	kB, err := parseKV(k)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling key: %w", err)
 	}
-	err = t.View(func(txn *badger.Txn) error {
-		dbID, err := t.keyID(txn, kB, true)
-		if err != nil {
-			return convertError(err)
-		}
-		d, err := t.get(txn, dbID)
-		if err != nil {
-			return err
-		}
-		b = d.v
-		return nil
-	})
-	return
+	dbID, err := t.keyID(txn, kB, true)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	d, err := t.get(txn, dbID)
+	if err != nil {
+		return nil, err
+	}
+	return d.v, nil
 }
 
 // Get retrieves a value from the Table.
@@ -115,6 +121,15 @@ func (t *Table) UseDefaultSetOptions(setOpts ...SetOption) {
 
 // Set inserts a new value for the key, and creates index entries.
 func (t *Table) Set(k, v KV, setOpts ...SetOption) error {
+	return t.Update(func(txn *badger.Txn) error {
+		return t.SetTxn(txn, k, v, setOpts...)
+	})
+}
+
+// SetTxn is like Set, but inserts the value as part of the provided
+// read-write transaction, so that updates to multiple Tables can be made
+// atomically with DB.Update.
+func (t *Table) SetTxn(txn *badger.Txn, k, v KV, setOpts ...SetOption) error {
 	kB, err := parseKV(k)
 	if err != nil {
 		return fmt.Errorf("error marshaling key: %w", err)
@@ -133,69 +148,67 @@ func (t *Table) Set(k, v KV, setOpts ...SetOption) error {
 		setOpts[i](&opts)
 	}
 	d := &datum{v: vB, indexes: make([][]byte, 0, len(t.indexes))}
-	return t.Update(func(txn *badger.Txn) error {
-		dbID, err := t.keyID(txn, kB, false)
+	dbID, err := t.keyID(txn, kB, false)
+	if err != nil {
+		return convertError(err)
+	}
+	// See if an entry already exists
+	oldDatum, err := t.get(txn, dbID)
+	if !errors.Is(err, ErrKeyNotFound) {
 		if err != nil {
-			return convertError(err)
+			return fmt.Errorf("error looking for existing entry: %w", err)
 		}
-		// See if an entry already exists
-		oldDatum, err := t.get(txn, dbID)
-		if !errors.Is(err, ErrKeyNotFound) {
-			if err != nil {
-				return fmt.Errorf("error looking for existing entry: %w", err)
-			}
-			// We found an old entry
-			if !opts.replace {
-				return errors.New("attempted to replace an entry without specifying WithReplace")
-			}
-			// Delete any old indexes
-			for _, k := range oldDatum.indexes {
-				if err := txn.Delete(k); err != nil {
-					return fmt.Errorf("error deleting replaced datum's index entry; %w", err)
-				}
+		// We found an old entry
+		if !opts.replace {
+			return errors.New("attempted to replace an entry without specifying WithReplace")
+		}
+		// Delete any old indexes
+		for _, k := range oldDatum.indexes {
+			if err := txn.Delete(k); err != nil {
+				return fmt.Errorf("error deleting replaced datum's index entry; %w", err)
 			}
 		}
+	}
 
-		// Add to indexes
-		for _, idx := range t.indexes {
-			var indexEntry []byte
-			if indexEntry, err = idx.add(txn, k, v, dbID); err != nil {
-				// Handle unique index conflicts
-				var indexConflictError uniqueIndexConflictError
-				switch {
-				case errors.As(err, &indexConflictError):
-					// If the index is unique and we're not replacing, return an error
-					if !opts.replace {
-						return fmt.Errorf("index uniqueness violation on %q", indexConflictError.indexName)
-					}
-
-					// If we're replacing, delete the old entry
-					if err := t.removeTableEntry(txn, indexConflictError.conflictDBID); err != nil {
-						return fmt.Errorf("error deleting conflicting entry from index %q: %w", indexConflictError.indexName, err)
-					}
-
-					// Try again
-					indexEntry, err = idx.add(txn, k, v, dbID)
-					if err != nil {
-						return fmt.Errorf("error adding entry to index after deleting conflicting entry: %w", err)
-					}
-				default:
-					return fmt.Errorf("error adding entry to index: %w", err)
+	// Add to indexes
+	for _, idx := range t.indexes {
+		var indexEntry []byte
+		if indexEntry, err = idx.add(txn, k, v, dbID); err != nil {
+			// Handle unique index conflicts
+			var indexConflictError uniqueIndexConflictError
+			switch {
+			case errors.As(err, &indexConflictError):
+				// If the index is unique and we're not replacing, return an error
+				if !opts.replace {
+					return fmt.Errorf("index uniqueness violation on %q", indexConflictError.indexName)
+				}
+
+				// If we're replacing, delete the old entry
+				if err := t.removeTableEntry(txn, indexConflictError.conflictDBID); err != nil {
+					return fmt.Errorf("error deleting conflicting entry from index %q: %w", indexConflictError.indexName, err)
 				}
-			}
 
-			if indexEntry != nil {
-				d.indexes = append(d.indexes, indexEntry)
+				// Try again
+				indexEntry, err = idx.add(txn, k, v, dbID)
+				if err != nil {
+					return fmt.Errorf("error adding entry to index after deleting conflicting entry: %w", err)
+				}
+			default:
+				return fmt.Errorf("error adding entry to index: %w", err)
 			}
 		}
 
-		dB, err := d.bytes()
-		if err != nil {
-			return fmt.Errorf("error encoding datum: %w", err)
+		if indexEntry != nil {
+			d.indexes = append(d.indexes, indexEntry)
 		}
+	}
 
-		return txn.Set(prefixedKey(t.prefix, dbID[:]), dB)
-	})
+	dB, err := d.bytes()
+	if err != nil {
+		return fmt.Errorf("error encoding datum: %w", err)
+	}
+
+	return txn.Set(prefixedKey(t.prefix, dbID[:]), dB)
 }
 
 // Delete deletes the data associated with the key, including any index entries
@@ -99,6 +99,16 @@ const (
 	RPCUpdateRunningBotInvError          // 81
 	RPCMMStatusError                     // 82
 	RPCBridgeError                       // 83
+	RPCPSBTError                         // 84
+	RPCConditionalOrderError             // 85
+	RPCRoutedOrderError                  // 86
+	RPCLedgerError                       // 87
+	RPCProfileError                      // 88
+	RPCMarketAlertError                  // 89
+	RPCPermissionError                   // 90
+	RPCAPIKeyError                       // 91
+	RPCMeshBondError                     // 92
+	RPCAppStateError                     // 93
 )
 
 // Routes are destinations for a "payload" of data. The type of data being
@@ -0,0 +1,350 @@
+// SPDX-License-Identifier: BlueOak-1.0.0
+// pragma should be as specific as possible to allow easier validation.
+pragma solidity = 0.8.21;
+
+// ETHSwap creates a contract to be deployed on an ethereum network. After
+// deployed, it keeps a record of the state of a contract and enables
+// redemption and refund of the contract when conditions are met.
+//
+// ETHSwap accomplishes this by holding funds sent to ETHSwap until certain
+// conditions are met. An initiator sends a tx with the Vector(s) to fund and
+// the requisite value to transfer to ETHSwap. At
+// this point the funds belong to the contract, and cannot be accessed by
+// anyone else, not even the contract's deployer. The swap Vector specifies
+// the conditions necessary for refund and redeem.
+//
+// ETHSwap has no limits on gas used for any transactions.
+//
+// ETHSwap cannot be used by other contracts or multisig wallets. The only
+// third party that can mediate a swap is a relayer submitting a redemption
+// that the participant has authorized with a signature. See
+// redeemWithSignature.
+//
+// This code should be verifiable as resulting in a certain on-chain contract
+// by compiling with the correct version of solidity and comparing the
+// resulting byte code to the data in the original transaction.
+contract ETHSwap {
+    bytes4 private constant TRANSFER_FROM_SELECTOR = bytes4(keccak256("transferFrom(address,address,uint256)"));
+    bytes4 private constant TRANSFER_SELECTOR = bytes4(keccak256("transfer(address,uint256)"));
+    // Step is a type that hold's a contract's current step. Empty is the
+    // uninitiated or null value.
+    enum Step { Empty, Filled, Redeemed, Refunded }
+
+    struct Status {
+        Step step;
+        bytes32 secret;
+        uint256 blockNumber;
+    }
+
+    bytes32 constant RefundRecord = 0xFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF;
+    bytes32 constant RefundRecordHash = 0xAF9613760F72635FBDB44A5A0A63C39F12AF30F950A6EE5C971BE188E89C4051;
+
+    // swaps is a map of contract hashes to the "swap record". The swap record
+    // has the following interpretation.
+    //   if (record == bytes32(0x00)): contract is uninitiated
+    //   else if (uint256(record) < block.number && sha256(record) != contract.secretHash):
+    //      contract is initiated and redeemable by the participant with the secret.
+    //   else if (sha256(record) == contract.secretHash): contract has been redeemed
+    //   else if (record == RefundRecord): contract has been refunded
+    //   else: invalid record. Should be impossible by construction
+    mapping(bytes32 => bytes32) public swaps;
+
+    // Vector is the information necessary for initialization and redemption
+    // or refund. The Vector itself is not stored on-chain. Instead, a key
+    // unique to the Vector is generated from the Vector data and keys
+    // the swap record.
+    struct Vector {
+        bytes32 secretHash;
+        uint256 value;
+        address initiator;
+        uint64 refundTimestamp;
+        address participant;
+    }
+
+    // contractKey generates a key hash which commits to the contract data. The
+    // generated hash is used as a key in the swaps map.
+    function contractKey(address token, Vector calldata v) public pure returns (bytes32) {
+        return sha256(
+            bytes.concat(
+                v.secretHash,
+                bytes20(v.initiator),
+                bytes20(v.participant),
+                bytes32(v.value),
+                bytes8(v.refundTimestamp),
+                bytes20(token)
+            )
+        );
+    }
+
+    // Redemption is the information necessary to redeem a Vector. Since we
+    // don't store the Vector itself, it must be provided as part of the
+    // redemption.
+    struct Redemption {
+        Vector v;
+        bytes32 secret;
+    }
+
+    function secretValidates(bytes32 secret, bytes32 secretHash) public pure returns (bool) {
+        return sha256(bytes.concat(secret)) == secretHash;
+    }
+
+    // constructor is empty. This contract has no connection to the original
+    // sender after deployed. It can only be interacted with by users
+    // initiating, redeeming, and refunding swaps.
+    constructor() {}
+
+    // senderIsOrigin ensures that this contract cannot be used by other
+    // contracts, which reduces possible attack vectors.
+    modifier senderIsOrigin() {
+        require(tx.origin == msg.sender, "sender != origin");
+        _;
+    }
+
+    // retrieveStatus retrieves the current swap record for the contract.
+    function retrieveStatus(address token, Vector calldata v)
+        private view returns (bytes32, bytes32, uint256)
+    {
+        bytes32 k = contractKey(token, v);
+        bytes32 record = swaps[k];
+        return (k, record, uint256(record));
+    }
+
+    // status returns the current state of the swap.
+    function status(address token, Vector calldata v)
+        public view returns(Status memory)
+    {
+        (, bytes32 record, uint256 blockNum) = retrieveStatus(token, v);
+        Status memory r;
+        if (blockNum == 0) {
+            r.step = Step.Empty;
+        } else if (record == RefundRecord) {
+            r.step = Step.Refunded;
+        } else if (secretValidates(record, v.secretHash)) {
+            r.step = Step.Redeemed;
+            r.secret = record;
+        } else {
+            r.step = Step.Filled;
+            r.blockNumber =  blockNum;
+        }
+        return r;
+    }
+
+    // initiate initiates an array of Vectors.
+    function initiate(address token, Vector[] calldata contracts)
+        public
+        payable
+        senderIsOrigin()
+    {
+        uint initVal = 0;
+        for (uint i = 0; i < contracts.length; i++) {
+            Vector calldata v = contracts[i];
+
+            require(v.value > 0, "0 val");
+            require(v.refundTimestamp > 0, "0 refundTimestamp");
+            require(v.secretHash != RefundRecordHash, "illegal secret hash (refund record hash)");
+
+            bytes32 k = contractKey(token, v);
+            bytes32 record = swaps[k];
+            require(record == bytes32(0), "swap not empty");
+
+            record = bytes32(block.number);
+            require(!secretValidates(record, v.secretHash), "hash collision");
+
+            swaps[k] = record;
+
+            initVal += v.value;
+        }
+
+        if (token == address(0)) {
+            require(initVal == msg.value, "bad val");
+        } else {
+            bool success;
+            bytes memory data;
+            (success, data) = token.call(abi.encodeWithSelector(TRANSFER_FROM_SELECTOR, msg.sender, address(this), initVal));
+            require(success && (data.length == 0 || abi.decode(data, (bool))), 'transfer from failed');
+        }
+    }
+
+    // isRedeemable returns whether or not a swap identified by vector
+    // can be redeemed using secret. isRedeemable DOES NOT check if the caller
+    // is the participant in the vector.
+    function isRedeemable(address token, Vector calldata v)
+        public
+        view
+        returns (bool)
+    {
+        (, bytes32 record, uint256 blockNum) = retrieveStatus(token, v);
+        return blockNum != 0 && !secretValidates(record, v.secretHash);
+    }
+
+    // redeem redeems a Vector. It checks that the sender is not a contract,
+    // and that the secret hashes to secretHash. msg.value is tranfered
+    // from ETHSwap to the sender.
+    //
+    // To prevent reentry attack, it is very important to check the state of the
+    // contract first, and change the state before proceeding to send. That way,
+    // the nested attacking function will throw upon trying to call redeem a
+    // second time. Currently, reentry is also not possible because contracts
+    // cannot use this contract.
+    function redeem(address token, Redemption[] calldata redemptions)
+        public
+        senderIsOrigin()
+    {
+        uint amountToRedeem = 0;
+        for (uint i = 0; i < redemptions.length; i++) {
+            Redemption calldata r = redemptions[i];
+
+            require(r.v.participant == msg.sender, "not authed");
+
+            (bytes32 k, bytes32 record, uint256 blockNum) = retrieveStatus(token, r.v);
+
+            // To be redeemable, the record needs to represent a valid block
+            // number.
+            require(blockNum > 0 && blockNum < block.number, "unfilled swap");
+
+            // Can't already be redeemed.
+            require(!secretValidates(record, r.v.secretHash), "already redeemed");
+
+            // Are they presenting the correct secret?
+            require(secretValidates(r.secret, r.v.secretHash), "invalid secret");
+
+            swaps[k] = r.secret;
+            amountToRedeem += r.v.value;
+        }
+
+         if (token == address(0)) {
+            (bool ok, ) = payable(msg.sender).call{value: amountToRedeem}("");
+            require(ok == true, "transfer failed");
+         } else {
+            bool success;
+            bytes memory data;
+            (success, data) = token.call(abi.encodeWithSelector(TRANSFER_SELECTOR, msg.sender, amountToRedeem));
+            require(success && (data.length == 0 || abi.decode(data, (bool))), 'transfer failed');
+         }
+    }
+
+    // redeemWithSignature redeems Vectors on behalf of their participant,
+    // enabling a participant with no native asset to pay for gas to redeem.
+    // The caller (the relayer) submits the redemptions along with the
+    // participant's signature, which commits to the chain, this contract, the
+    // token, the contract keys of the redeemed Vectors, the relayer's fee and
+    // a deadline. The relayer is paid relayerFee from the redeemed amount and
+    // the rest is transferred to the participant. Each Vector can only be
+    // redeemed once, so the signature cannot be replayed.
+    function redeemWithSignature(
+        address token,
+        Redemption[] calldata redemptions,
+        uint256 relayerFee,
+        uint64 deadline,
+        uint8 sigV,
+        bytes32 sigR,
+        bytes32 sigS
+    )
+        public
+        senderIsOrigin()
+    {
+        require(block.timestamp <= deadline, "authorization expired");
+
+        (address participant, bytes32 keysHash, uint amountToRedeem) = recordRedemptions(token, redemptions);
+
+        require(relayerFee < amountToRedeem, "fee exceeds redeemed amount");
+
+        bytes32 digest = keccak256(abi.encode(
+            block.chainid,
+            address(this),
+            token,
+            keysHash,
+            relayerFee,
+            deadline
+        ));
+        address signer = ecrecover(digest, sigV, sigR, sigS);
+        require(signer != address(0) && signer == participant, "not authed");
+
+        transferOut(token, participant, amountToRedeem - relayerFee);
+        if (relayerFee > 0) {
+            transferOut(token, msg.sender, relayerFee);
+        }
+    }
+
+    // recordRedemptions checks that the redemptions are of initiated, unredeemed
+    // Vectors of a single participant, and records their secrets. It returns
+    // the participant, the hash of the packed contract keys that the
+    // participant's signature commits to, and the total redeemed value.
+    function recordRedemptions(address token, Redemption[] calldata redemptions)
+        private
+        returns (address participant, bytes32 keysHash, uint amountToRedeem)
+    {
+        require(redemptions.length > 0, "no redemptions");
+
+        participant = redemptions[0].v.participant;
+        bytes32[] memory keys = new bytes32[](redemptions.length);
+        for (uint i = 0; i < redemptions.length; i++) {
+            Redemption calldata r = redemptions[i];
+
+            require(r.v.participant == participant, "multiple participants");
+
+            (bytes32 k, bytes32 record, uint256 blockNum) = retrieveStatus(token, r.v);
+
+            require(blockNum > 0 && blockNum < block.number, "unfilled swap");
+            require(!secretValidates(record, r.v.secretHash), "already redeemed");
+            require(secretValidates(r.secret, r.v.secretHash), "invalid secret");
+
+            swaps[k] = r.secret;
+            keys[i] = k;
+            amountToRedeem += r.v.value;
+        }
+        keysHash = keccak256(abi.encodePacked(keys));
+    }
+
+    // transferOut transfers value of the asset, which is ether if token is the
+    // zero address, from the contract to the recipient.
+    function transferOut(address token, address recipient, uint value) private {
+        if (token == address(0)) {
+            (bool ok, ) = payable(recipient).call{value: value}("");
+            require(ok == true, "transfer failed");
+        } else {
+            bool success;
+            bytes memory data;
+            (success, data) = token.call(abi.encodeWithSelector(TRANSFER_SELECTOR, recipient, value));
+            require(success && (data.length == 0 || abi.decode(data, (bool))), 'transfer failed');
+        }
+    }
+
+    // refund refunds a Vector. It checks that the sender is not a contract
+    // and that the refund time has passed. msg.value is transfered from the
+    // contract to the sender = Vector.participant.
+    //
+    // It is important to note that this also uses call.value which comes with
+    // no restrictions on gas used. See redeem for more info.
+    function refund(address token, Vector calldata v)
+        public
+        senderIsOrigin()
+    {
+        // Is this contract even in a refundable state?
+        require(block.timestamp >= v.refundTimestamp, "locktime not expired");
+
+        // Retrieve the record.
+        (bytes32 k, bytes32 record, uint256 blockNum) = retrieveStatus(token, v);
+
+        // Is this swap initialized?
+        // This check also guarantees that the swap has not already been
+        // refunded i.e. record != RefundRecord, since RefundRecord is certainly
+        // greater than block.number.
+        require(blockNum > 0 && blockNum <= block.number, "swap not active");
+
+        // Is it already redeemed?
+        require(!secretValidates(record, v.secretHash), "swap already redeemed");
+
+        swaps[k] = RefundRecord;
+
+        if (token == address(0)) {
+            (bool ok, ) = payable(v.initiator).call{value: v.value}("");
+            require(ok == true, "transfer failed");
+        } else {
+            bool success;
+            bytes memory data;
+            (success, data) = token.call(abi.encodeWithSelector(TRANSFER_SELECTOR, msg.sender, v.value));
+            require(success && (data.length == 0 || abi.decode(data, (bool))), 'transfer failed');
+        }
+    }
+}
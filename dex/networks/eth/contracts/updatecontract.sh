@@ -25,7 +25,7 @@ fi
 mkdir temp
 mkdir -p ${PKG_NAME}
 
-solc --abi --bin --bin-runtime --overwrite --optimize ${SOLIDITY_FILE} -o ./temp/
+solc --abi --bin --bin-runtime --overwrite --optimize --evm-version paris ${SOLIDITY_FILE} -o ./temp/
 abigen --abi ./temp/${CONTRACT_NAME}.abi --bin ./temp/${CONTRACT_NAME}.bin --pkg ${PKG_NAME} \
  --type ${CONTRACT_NAME} --out ./${PKG_NAME}/contract.go
 
@@ -68,6 +68,7 @@ var (
 	VersionedGases = map[uint32]*Gases{
 		0: v0Gases,
 		1: v1Gases,
+		2: v2Gases,
 	}
 
 	ContractAddresses = map[uint32]map[dex.Network]common.Address{
@@ -81,6 +82,11 @@ var (
 			dex.Testnet: common.HexToAddress("0x9CDe3c347021F0AA63E2780dAD867B5949c5E083"), // tx 0x90f18e70121598a48fc49a5d5b0328358eb34441e2c5dee439dda2dfc7bf3dd8
 			dex.Simnet:  common.HexToAddress("0x2f68e723b8989ba1c6a9f03e42f33cb7dc9d606f"),
 		},
+		// Version 2 is not deployed to mainnet or testnet. The simnet address
+		// is read from the file written by the harness.
+		2: {
+			dex.Simnet: {},
+		},
 	}
 
 	MultiBalanceAddresses = map[dex.Network]common.Address{
@@ -115,6 +121,10 @@ var v1Gases = &Gases{
 	Refund: 52_507,
 }
 
+// v2Gases are the same as v1Gases. Version 2 only adds redeemWithSignature,
+// and its swap, redeem, and refund methods are unchanged.
+var v2Gases = v1Gases
+
 // LoadGenesisFile loads a Genesis config from a json file.
 func LoadGenesisFile(genesisFile string) (*core.Genesis, error) {
 	fid, err := os.Open(genesisFile)
@@ -166,7 +176,7 @@ func DecodeContractData(data []byte) (contractVersion uint32, locator []byte, er
 			err = fmt.Errorf("v0 locator is too small. expected %d, got %d", SecretHashSize, len(locator))
 			return
 		}
-	case 1:
+	case 1, RelayContractVersion: // version 2 uses version 1 locators
 		if len(locator) != LocatorV1Length {
 			err = fmt.Errorf("v1 locator is too small. expected %d, got %d", LocatorV1Length, len(locator))
 			return
@@ -472,6 +482,7 @@ type ProtocolVersion uint32
 const (
 	ProtocolVersionZero ProtocolVersion = iota
 	ProtocolVersionV1Contracts
+	ProtocolVersionV2Contracts
 )
 
 func (v ProtocolVersion) ContractVersion() uint32 {
@@ -480,6 +491,8 @@ func (v ProtocolVersion) ContractVersion() uint32 {
 		return 0
 	case ProtocolVersionV1Contracts:
 		return 1
+	case ProtocolVersionV2Contracts:
+		return 2
 	default:
 		return ContractVersionUnknown
 	}
@@ -70,6 +70,12 @@ func TestVersionedGases(t *testing.T) {
 		},
 		{
 			ver:            2,
+			expInitGases:   []uint64{0, v2Gases.Swap, v2Gases.Swap + v2Gases.SwapAdd},
+			expRedeemGases: []uint64{0, v2Gases.Redeem, v2Gases.Redeem + v2Gases.RedeemAdd},
+			expRefundGas:   v2Gases.Refund,
+		},
+		{
+			ver:            3,
 			expInitGases:   []uint64{0, math.MaxUint64},
 			expRedeemGases: []uint64{0, math.MaxUint64},
 			expRefundGas:   math.MaxUint64,
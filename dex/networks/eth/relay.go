@@ -0,0 +1,199 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package eth
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"decred.org/dcrdex/dex"
+	swapv1 "decred.org/dcrdex/dex/networks/eth/contracts/v1"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RelayContractVersion is the first swap contract version that supports
+// relayed redemptions.
+const RelayContractVersion = 2
+
+// RelayedRedeemMethodName is the name of the version 2 swap contract method
+// that a relayer calls to redeem on behalf of a participant.
+const RelayedRedeemMethodName = "redeemWithSignature"
+
+// RelayedRedeemGasOverhead is the gas used by redeemWithSignature beyond the
+// gas used by redeem for the same redemptions, covering the signature
+// recovery, hashing of the contract keys, and the transfer of the relayer's
+// fee.
+const RelayedRedeemGasOverhead = 25_000
+
+// RelayGasLimit is the gas limit that a relayer submits a relayed redemption
+// with, given its gas estimate for the transaction.
+func RelayGasLimit(gasEstimate uint64) uint64 {
+	return gasEstimate * 5 / 4
+}
+
+// RelayedRedeemABI is the ABI of the version 2 swap contract, which has the
+// redeemWithSignature method. Its Redemption tuple is identical to the version
+// 1 contract's.
+var RelayedRedeemABI = ABIs[RelayContractVersion]
+
+// ContractKey is the key that the swap contract uses to store the swap record
+// of the vector. It must match the contract's contractKey method.
+func (v *SwapVector) ContractKey(token common.Address) [32]byte {
+	b := make([]byte, 0, 32+20+20+32+8+20)
+	b = append(b, v.SecretHash[:]...)
+	b = append(b, v.From[:]...)
+	b = append(b, v.To[:]...)
+	b = append(b, common.LeftPadBytes(v.Value.Bytes(), 32)...)
+	b = binary.BigEndian.AppendUint64(b, v.LockTime)
+	b = append(b, token[:]...)
+	return sha256.Sum256(b)
+}
+
+// RelayedRedemption is a participant's authorization for a relayer to redeem
+// a batch of swaps on their behalf in exchange for a fee that is deducted from
+// the redeemed amount. It is the body of a request to a relayer.
+type RelayedRedemption struct {
+	ChainID      int64           `json:"chainID"`
+	ContractAddr common.Address  `json:"contract"`
+	Token        common.Address  `json:"token"`
+	Redemptions  []*RedemptionV1 `json:"redemptions"`
+	RelayerFee   *big.Int        `json:"relayerFee"`
+	Deadline     uint64          `json:"deadline"`
+	Signature    dex.Bytes       `json:"sig"`
+}
+
+// RelayResponse is a relayer's response to a RelayedRedemption.
+type RelayResponse struct {
+	TxHash common.Hash `json:"txHash"`
+}
+
+// RelayFeeQuote is a relayer's response to a fee request. For the base chain
+// asset, the relayer requires a fee of at least GasFeeCap times the gas limit
+// of the relayed transaction. For tokens, the relayer requires MinTokenFee.
+type RelayFeeQuote struct {
+	// GasFeeCap is the gas fee cap, in wei, that the relayer will submit the
+	// transaction with.
+	GasFeeCap *big.Int `json:"gasFeeCap"`
+	// MinTokenFee is the minimum relayer fee, in the token's smallest unit,
+	// for token redemptions.
+	MinTokenFee *big.Int `json:"minTokenFee"`
+}
+
+// Message is the message signed by the participant. The signature is over the
+// Keccak-256 hash of the message, i.e. the Digest.
+func (r *RelayedRedemption) Message() []byte {
+	keys := make([][32]byte, 0, len(r.Redemptions))
+	for _, redeem := range r.Redemptions {
+		keys = append(keys, redeem.Contract.ContractKey(r.Token))
+	}
+	return RelayedRedemptionMessage(big.NewInt(r.ChainID), r.ContractAddr, r.Token, keys, r.RelayerFee, r.Deadline)
+}
+
+// Digest is the hash signed by the participant.
+func (r *RelayedRedemption) Digest() [32]byte {
+	return crypto.Keccak256Hash(r.Message())
+}
+
+// Value is the total value of the redeemed swaps.
+func (r *RelayedRedemption) Value() *big.Int {
+	v := new(big.Int)
+	for _, redeem := range r.Redemptions {
+		v.Add(v, redeem.Contract.Value)
+	}
+	return v
+}
+
+// Participant is the participant of the redeemed swaps. An error is returned
+// if there are no redemptions or the redemptions are for multiple
+// participants.
+func (r *RelayedRedemption) Participant() (common.Address, error) {
+	if len(r.Redemptions) == 0 {
+		return common.Address{}, errors.New("no redemptions")
+	}
+	participant := r.Redemptions[0].Contract.To
+	for _, redeem := range r.Redemptions[1:] {
+		if redeem.Contract.To != participant {
+			return common.Address{}, errors.New("multiple participants")
+		}
+	}
+	return participant, nil
+}
+
+// Validate checks that the redemptions are well-formed, the fee is less than
+// the redeemed amount, and the signature was made by the participant.
+func (r *RelayedRedemption) Validate() error {
+	participant, err := r.Participant()
+	if err != nil {
+		return err
+	}
+	for _, redeem := range r.Redemptions {
+		if sha256.Sum256(redeem.Secret[:]) != redeem.Contract.SecretHash {
+			return fmt.Errorf("invalid secret for secret hash %x", redeem.Contract.SecretHash)
+		}
+	}
+	if r.RelayerFee == nil || r.RelayerFee.Sign() < 0 {
+		return errors.New("invalid relayer fee")
+	}
+	if r.RelayerFee.Cmp(r.Value()) >= 0 {
+		return fmt.Errorf("relayer fee %s exceeds redeemed amount %s", r.RelayerFee, r.Value())
+	}
+	if len(r.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("wrong signature length %d", len(r.Signature))
+	}
+	digest := r.Digest()
+	pubKey, err := crypto.SigToPub(digest[:], r.Signature)
+	if err != nil {
+		return fmt.Errorf("error recovering signer: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != participant {
+		return fmt.Errorf("signer %s is not the participant %s", signer, participant)
+	}
+	return nil
+}
+
+// RelayedRedemptionMessage builds the message that a participant signs to
+// authorize a relayed redemption. Its Keccak-256 hash must match the digest
+// computed by the version 2 swap contract's redeemWithSignature method, i.e.
+//
+//	keccak256(abi.encode(chainid, contract, token, keccak256(abi.encodePacked(keys)), relayerFee, deadline))
+func RelayedRedemptionMessage(chainID *big.Int, contractAddr, token common.Address, keys [][32]byte,
+	relayerFee *big.Int, deadline uint64) []byte {
+
+	packedKeys := make([]byte, 0, len(keys)*32)
+	for _, k := range keys {
+		packedKeys = append(packedKeys, k[:]...)
+	}
+	b := make([]byte, 0, 6*32)
+	b = append(b, common.LeftPadBytes(chainID.Bytes(), 32)...)
+	b = append(b, common.LeftPadBytes(contractAddr[:], 32)...)
+	b = append(b, common.LeftPadBytes(token[:], 32)...)
+	b = append(b, crypto.Keccak256(packedKeys)...)
+	b = append(b, common.LeftPadBytes(relayerFee.Bytes(), 32)...)
+	b = append(b, common.LeftPadBytes(new(big.Int).SetUint64(deadline).Bytes(), 32)...)
+	return b
+}
+
+// PackRelayedRedeem packs the calldata for the version 2 swap contract's
+// redeemWithSignature method.
+func PackRelayedRedeem(r *RelayedRedemption) ([]byte, error) {
+	if len(r.Signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("wrong signature length %d", len(r.Signature))
+	}
+	redemptions := make([]swapv1.ETHSwapRedemption, 0, len(r.Redemptions))
+	for _, redeem := range r.Redemptions {
+		redemptions = append(redemptions, swapv1.ETHSwapRedemption{
+			V:      SwapVectorToAbigen(redeem.Contract),
+			Secret: redeem.Secret,
+		})
+	}
+	var sigR, sigS [32]byte
+	copy(sigR[:], r.Signature[:32])
+	copy(sigS[:], r.Signature[32:64])
+	sigV := r.Signature[64] + 27
+	return RelayedRedeemABI.Pack(RelayedRedeemMethodName, r.Token, redemptions, r.RelayerFee, r.Deadline, sigV, sigR, sigS)
+}
@@ -0,0 +1,103 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package eth
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRelayedRedemption(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey error: %v", err)
+	}
+	participant := crypto.PubkeyToAddress(priv.PublicKey)
+
+	newRedemption := func(secret byte, to common.Address) *RedemptionV1 {
+		r := &RedemptionV1{
+			Secret: [32]byte{secret},
+			Contract: &SwapVector{
+				From:     common.HexToAddress("0x345853e21b1d475582E71cC269124eD5e2dD3422"),
+				To:       to,
+				Value:    big.NewInt(1e9),
+				LockTime: uint64(time.Now().Unix()),
+			},
+		}
+		r.Contract.SecretHash = sha256.Sum256(r.Secret[:])
+		return r
+	}
+
+	newRelayedRedemption := func() *RelayedRedemption {
+		r := &RelayedRedemption{
+			ChainID:      1337,
+			ContractAddr: common.HexToAddress("0x2f68e723b8989ba1c6a9f03e42f33cb7dc9d606f"),
+			Redemptions:  []*RedemptionV1{newRedemption(1, participant), newRedemption(2, participant)},
+			RelayerFee:   big.NewInt(1e7),
+			Deadline:     uint64(time.Now().Add(time.Hour).Unix()),
+		}
+		digest := r.Digest()
+		r.Signature, err = crypto.Sign(digest[:], priv)
+		if err != nil {
+			t.Fatalf("Sign error: %v", err)
+		}
+		return r
+	}
+
+	r := newRelayedRedemption()
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	calldata, err := PackRelayedRedeem(r)
+	if err != nil {
+		t.Fatalf("PackRelayedRedeem error: %v", err)
+	}
+	decoded, err := ParseCallData(calldata, RelayedRedeemABI)
+	if err != nil {
+		t.Fatalf("ParseCallData error: %v", err)
+	}
+	if decoded.Name != RelayedRedeemMethodName {
+		t.Fatalf("wrong method name %s", decoded.Name)
+	}
+
+	// The signature commits to the fee.
+	r.RelayerFee = big.NewInt(2e7)
+	if err := r.Validate(); err == nil {
+		t.Fatalf("no error for modified fee")
+	}
+
+	// The signature commits to the chain.
+	r = newRelayedRedemption()
+	r.ChainID = 1
+	if err := r.Validate(); err == nil {
+		t.Fatalf("no error for modified chain ID")
+	}
+
+	// Fee can't exceed the redeemed amount.
+	r = newRelayedRedemption()
+	r.RelayerFee = big.NewInt(2e9)
+	if err := r.Validate(); err == nil {
+		t.Fatalf("no error for fee exceeding redeemed amount")
+	}
+
+	// Bad secret.
+	r = newRelayedRedemption()
+	r.Redemptions[1].Secret = [32]byte{3}
+	if err := r.Validate(); err == nil {
+		t.Fatalf("no error for invalid secret")
+	}
+
+	// All redemptions must be for the signer.
+	r = newRelayedRedemption()
+	r.Redemptions[1] = newRedemption(2, common.HexToAddress("0x1"))
+	if err := r.Validate(); err == nil {
+		t.Fatalf("no error for multiple participants")
+	}
+}
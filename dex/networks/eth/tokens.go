@@ -497,6 +497,7 @@ func MaybeReadSimnetAddrsDir(
 
 	ethSwapContractAddrFileV0 := filepath.Join(harnessDir, "eth_swap_contract_address.txt")
 	ethSwapContractAddrFileV1 := filepath.Join(harnessDir, "eth_swap_contract_address_v1.txt")
+	ethSwapContractAddrFileV2 := filepath.Join(harnessDir, "eth_swap_contract_address_v2.txt")
 	testUSDCSwapContractAddrFileV0 := filepath.Join(harnessDir, "usdc_swap_contract_address.txt")
 	testUSDCContractAddrFile := filepath.Join(harnessDir, "test_usdc_contract_address.txt")
 	testUSDTSwapContractAddrFileV0 := filepath.Join(harnessDir, "usdt_swap_contract_address.txt")
@@ -505,6 +506,9 @@ func MaybeReadSimnetAddrsDir(
 
 	contractAddrs[0][dex.Simnet] = maybeGetContractAddrFromFile(ethSwapContractAddrFileV0)
 	contractAddrs[1][dex.Simnet] = maybeGetContractAddrFromFile(ethSwapContractAddrFileV1)
+	if _, found := contractAddrs[2]; found {
+		contractAddrs[2][dex.Simnet] = maybeGetContractAddrFromFile(ethSwapContractAddrFileV2)
+	}
 	multiBalandAddresses[dex.Simnet] = maybeGetContractAddrFromFile(multiBalanceContractAddrFile)
 
 	usdcToken.SwapContracts[0].Address = maybeGetContractAddrFromFile(testUSDCSwapContractAddrFileV0)
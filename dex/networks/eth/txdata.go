@@ -11,6 +11,7 @@ import (
 
 	swapv0 "decred.org/dcrdex/dex/networks/eth/contracts/v0"
 	swapv1 "decred.org/dcrdex/dex/networks/eth/contracts/v1"
+	swapv2 "decred.org/dcrdex/dex/networks/eth/contracts/v2"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -35,9 +36,15 @@ func initAbis() map[uint32]*abi.ABI {
 		panic(fmt.Sprintf("failed to parse v1 abi: %v", err))
 	}
 
+	v2ABI, err := swapv2.ETHSwapMetaData.GetAbi()
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse v2 abi: %v", err))
+	}
+
 	return map[uint32]*abi.ABI{
 		0: &v0ABI,
 		1: &v1ABI,
+		2: v2ABI,
 	}
 }
 
@@ -21,6 +21,7 @@ ERC20_SWAP_V0=$(fileToHex "../../networks/erc20/contracts/v0/swap_contract.bin")
 TEST_TOKEN=$(fileToHex "../../networks/erc20/contracts/v0/token_contract.bin")
 MULTIBALANCE_BIN=$(fileToHex "../../networks/eth/contracts/multibalance/contract.bin")
 ETH_SWAP_V1=$(fileToHex "../../networks/eth/contracts/v1/contract.bin")
+ETH_SWAP_V2=$(fileToHex "../../networks/eth/contracts/v2/contract.bin")
 
 export NODES_ROOT=~/dextest/eth
 
@@ -129,6 +130,9 @@ ETH_SWAP_CONTRACT_HASH_V0=$("${NODES_ROOT}/harness-ctl/alpha" "attach --preload
 echo "Deploying ETHSwap1 contract."
 ETH_SWAP_CONTRACT_HASH_V1=$("${NODES_ROOT}/harness-ctl/alpha" "attach --preload ${NODES_ROOT}/harness-ctl/deploy.js --exec deploy(\"${ETH_SWAP_V1}\")" | sed 's/"//g')
 
+echo "Deploying ETHSwapV2 contract."
+ETH_SWAP_CONTRACT_HASH_V2=$("${NODES_ROOT}/harness-ctl/alpha" "attach --preload ${NODES_ROOT}/harness-ctl/deploy.js --exec deploy(\"${ETH_SWAP_V2}\")" | sed 's/"//g')
+
 echo "Deploying USDC contract."
 TEST_USDC_CONTRACT_HASH=$("${NODES_ROOT}/harness-ctl/alpha" "attach --preload ${NODES_ROOT}/harness-ctl/deploy.js --exec deployERC20(\"${TEST_TOKEN}\",6)" | sed 's/"//g')
 
@@ -164,6 +168,12 @@ cat > "${NODES_ROOT}/eth_swap_contract_address_v1.txt" <<EOF
 ${ETH_SWAP_CONTRACT_ADDR_V1}
 EOF
 
+ETH_SWAP_CONTRACT_ADDR_V2=$("${NODES_ROOT}/harness-ctl/alpha" "attach --preload ${NODES_ROOT}/harness-ctl/contractAddress.js --exec contractAddress(\"${ETH_SWAP_CONTRACT_HASH_V2}\")" | sed 's/"//g')
+echo "ETH SWAP V2 contract address is ${ETH_SWAP_CONTRACT_ADDR_V2}. Saving to ${NODES_ROOT}/eth_swap_contract_address_v2.txt"
+cat > "${NODES_ROOT}/eth_swap_contract_address_v2.txt" <<EOF
+${ETH_SWAP_CONTRACT_ADDR_V2}
+EOF
+
 TEST_USDC_CONTRACT_ADDR=$("${NODES_ROOT}/harness-ctl/alpha" "attach --preload ${NODES_ROOT}/harness-ctl/contractAddress.js --exec contractAddress(\"${TEST_USDC_CONTRACT_HASH}\")" | sed 's/"//g')
 echo "Test USDC contract address is ${TEST_USDC_CONTRACT_ADDR}. Saving to ${NODES_ROOT}/test_usdc_contract_address.txt"
 cat > "${NODES_ROOT}/test_usdc_contract_address.txt" <<EOF
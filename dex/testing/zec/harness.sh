@@ -16,6 +16,7 @@ ALPHA_RPC_PORT="33768"
 BETA_RPC_PORT="33769"
 DELTA_RPC_PORT="33770"
 GAMMA_RPC_PORT="33771"
+LIGHTWALLETD_PORT="33772"
 
 set -ex
 NODES_ROOT=~/dextest/${SYMBOL}
@@ -79,6 +80,9 @@ port=${ALPHA_LISTEN_PORT}
 regtest=1
 rpcport=${ALPHA_RPC_PORT}
 exportdir=${SOURCE_DIR}
+# Serve the lightwalletd light wallet backend.
+experimentalfeatures=1
+lightwalletd=1
 # Activate all the things.
 nuparams=5ba81b19:1
 nuparams=76b809bb:1
@@ -295,6 +299,7 @@ tmux send-keys -t $SESSION:0 C-c
 tmux send-keys -t $SESSION:1 C-c
 tmux send-keys -t $SESSION:2 C-c
 tmux send-keys -t $SESSION:3 C-c
+tmux send-keys -t $SESSION:6 C-c 2>/dev/null || true
 tmux wait-for alpha${SYMBOL}
 tmux wait-for beta${SYMBOL}
 tmux wait-for delta${SYMBOL}
@@ -363,6 +368,22 @@ tmux new-window -t $SESSION:5 -n 'miner' $SHELL
 tmux send-keys -t $SESSION:5 "cd ${HARNESS_DIR}" C-m
 tmux send-keys -t $SESSION:5 "watch -n 15 ./mine-alpha 1" C-m
 
+################################################################################
+# Start lightwalletd for the light wallet, if installed.
+################################################################################
+
+if command -v lightwalletd > /dev/null; then
+  LIGHTWALLETD_DIR="${NODES_ROOT}/lightwalletd"
+  mkdir -p "${LIGHTWALLETD_DIR}"
+  echo "Starting lightwalletd on 127.0.0.1:${LIGHTWALLETD_PORT}"
+  tmux new-window -t $SESSION:6 -n 'lightwalletd' $SHELL
+  tmux send-keys -t $SESSION:6 "cd ${LIGHTWALLETD_DIR}" C-m
+  tmux send-keys -t $SESSION:6 "lightwalletd --no-tls-very-insecure \
+    --grpc-bind-addr 127.0.0.1:${LIGHTWALLETD_PORT} --http-bind-addr 127.0.0.1:0 \
+    --zcash-conf-path ${ALPHA_DIR}/alpha.conf --data-dir ${LIGHTWALLETD_DIR} \
+    --log-file /dev/stdout" C-m
+fi
+
 # Reenable history and attach to the control session.
 tmux select-window -t $SESSION:4
 tmux send-keys -t $SESSION:4 "set -o history" C-m
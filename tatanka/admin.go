@@ -0,0 +1,293 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package tatanka
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"decred.org/dcrdex/dex/msgjson"
+	"decred.org/dcrdex/tatanka/mj"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+// ErrUnknownPeer is returned from operator actions targeting a peer that is
+// not connected.
+var ErrUnknownPeer = errors.New("unknown peer")
+
+// PeerInfo is the reputation and bonding status of a connected peer.
+type PeerInfo struct {
+	ID         string `json:"id"`
+	BondTier   uint64 `json:"bondTier"`
+	Score      int64  `json:"score"`
+	ScoreDepth uint64 `json:"scoreDepth"`
+	Tier       int64  `json:"tier"`
+}
+
+// ClientInfo describes a locally-connected client.
+type ClientInfo struct {
+	PeerInfo
+	Topics []tanka.Topic `json:"topics"`
+}
+
+// TatankaInfo describes a connected remote tatanka node.
+type TatankaInfo struct {
+	PeerInfo
+	Whitelisted bool `json:"whitelisted"`
+	// Configured will be false until the remote node has sent its
+	// configuration. A node that stays unconfigured is likely unhealthy.
+	Configured  bool      `json:"configured"`
+	Version     uint32    `json:"version"`
+	Chains      []uint32  `json:"chains"`
+	LastMessage time.Time `json:"lastMessage"`
+}
+
+// TopicInfo is the subscription status of a topic.
+type TopicInfo struct {
+	Topic       tanka.Topic `json:"topic"`
+	Subscribers int         `json:"subscribers"`
+	// Subjects is the number of subscribers for each subject.
+	Subjects map[tanka.Subject]int `json:"subjects"`
+}
+
+// BondTotal is the sum of unexpired bonds for an asset.
+type BondTotal struct {
+	AssetID  uint32 `json:"assetID"`
+	Bonds    int    `json:"bonds"`
+	Bonders  int    `json:"bonders"`
+	Strength uint64 `json:"strength"`
+}
+
+// RouteStats are message counters for a route.
+type RouteStats struct {
+	Received uint64 `json:"received"`
+	Errors   uint64 `json:"errors"`
+}
+
+// BlacklistEntry is a peer blacklisted by the operator.
+type BlacklistEntry struct {
+	ID    string    `json:"id"`
+	Stamp time.Time `json:"stamp"`
+}
+
+func (p *peer) info() PeerInfo {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	rep := p.Reputation
+	if rep == nil {
+		rep = new(tanka.Reputation)
+	}
+	bondTier := p.BondTier()
+	return PeerInfo{
+		ID:         p.ID.String(),
+		BondTier:   bondTier,
+		Score:      rep.Score,
+		ScoreDepth: rep.Depth,
+		Tier:       calcTier(rep, bondTier),
+	}
+}
+
+// countMessage increments the counters for the route.
+func (t *Tatanka) countMessage(route string, msgErr *msgjson.Error) {
+	t.routeStatsMtx.Lock()
+	defer t.routeStatsMtx.Unlock()
+	if t.routeStats == nil {
+		t.routeStats = make(map[string]*RouteStats)
+	}
+	stats, found := t.routeStats[route]
+	if !found {
+		stats = new(RouteStats)
+		t.routeStats[route] = stats
+	}
+	stats.Received++
+	if msgErr != nil {
+		stats.Errors++
+	}
+}
+
+// RouteStats is a snapshot of the message counters for every route that has
+// received a message.
+func (t *Tatanka) RouteStats() map[string]*RouteStats {
+	t.routeStatsMtx.Lock()
+	defer t.routeStatsMtx.Unlock()
+	stats := make(map[string]*RouteStats, len(t.routeStats))
+	for route, s := range t.routeStats {
+		stats[route] = &RouteStats{Received: s.Received, Errors: s.Errors}
+	}
+	return stats
+}
+
+// Clients describes the locally-connected clients.
+func (t *Tatanka) Clients() []*ClientInfo {
+	t.clientMtx.RLock()
+	clients := make([]*client, 0, len(t.clients))
+	for _, c := range t.clients {
+		clients = append(clients, c)
+	}
+	subs := make(map[tanka.PeerID][]tanka.Topic)
+	for topicID, topic := range t.topics {
+		for peerID := range topic.subscribers {
+			subs[peerID] = append(subs[peerID], topicID)
+		}
+	}
+	t.clientMtx.RUnlock()
+
+	infos := make([]*ClientInfo, 0, len(clients))
+	for _, c := range clients {
+		topics := subs[c.ID]
+		sort.Slice(topics, func(i, j int) bool { return topics[i] < topics[j] })
+		infos = append(infos, &ClientInfo{PeerInfo: c.info(), Topics: topics})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// Client describes a locally-connected client.
+func (t *Tatanka) Client(peerID tanka.PeerID) (*ClientInfo, error) {
+	c := t.clientNode(peerID)
+	if c == nil {
+		return nil, ErrUnknownPeer
+	}
+	var topics []tanka.Topic
+	t.clientMtx.RLock()
+	for topicID, topic := range t.topics {
+		if _, found := topic.subscribers[peerID]; found {
+			topics = append(topics, topicID)
+		}
+	}
+	t.clientMtx.RUnlock()
+	sort.Slice(topics, func(i, j int) bool { return topics[i] < topics[j] })
+	return &ClientInfo{PeerInfo: c.info(), Topics: topics}, nil
+}
+
+// Topics is the subscription status of every topic.
+func (t *Tatanka) Topics() []*TopicInfo {
+	t.clientMtx.RLock()
+	infos := make([]*TopicInfo, 0, len(t.topics))
+	for topicID, topic := range t.topics {
+		subjects := make(map[tanka.Subject]int, len(topic.subjects))
+		for subject, subs := range topic.subjects {
+			subjects[subject] = len(subs)
+		}
+		infos = append(infos, &TopicInfo{
+			Topic:       topicID,
+			Subscribers: len(topic.subscribers),
+			Subjects:    subjects,
+		})
+	}
+	t.clientMtx.RUnlock()
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Topic < infos[j].Topic })
+	return infos
+}
+
+// Tatankas describes the connected remote tatanka nodes.
+func (t *Tatanka) Tatankas() []*TatankaInfo {
+	nodes := t.tatankaNodes()
+	infos := make([]*TatankaInfo, 0, len(nodes))
+	for _, tt := range nodes {
+		_, whitelisted := t.whitelist[tt.ID]
+		info := &TatankaInfo{
+			PeerInfo:    tt.info(),
+			Whitelisted: whitelisted,
+		}
+		if cfg, _ := tt.cfg.Load().(*mj.TatankaConfig); cfg != nil {
+			info.Configured = true
+			info.Version = cfg.Version
+			info.Chains = cfg.Chains
+		}
+		if stamp := tt.lastMessage.Load(); stamp > 0 {
+			info.LastMessage = time.UnixMilli(stamp)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// BondTotals sums the unexpired bonds in the database by asset.
+func (t *Tatanka) BondTotals() ([]*BondTotal, error) {
+	bonds, err := t.db.AllBonds()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving bonds: %w", err)
+	}
+	totals := make(map[uint32]*BondTotal)
+	bonders := make(map[uint32]map[tanka.PeerID]struct{})
+	for _, b := range bonds {
+		total, found := totals[b.AssetID]
+		if !found {
+			total = &BondTotal{AssetID: b.AssetID}
+			totals[b.AssetID] = total
+			bonders[b.AssetID] = make(map[tanka.PeerID]struct{})
+		}
+		total.Bonds++
+		total.Strength += b.Strength
+		bonders[b.AssetID][b.PeerID] = struct{}{}
+	}
+	sums := make([]*BondTotal, 0, len(totals))
+	for assetID, total := range totals {
+		total.Bonders = len(bonders[assetID])
+		sums = append(sums, total)
+	}
+	sort.Slice(sums, func(i, j int) bool { return sums[i].AssetID < sums[j].AssetID })
+	return sums, nil
+}
+
+// DisconnectPeer disconnects a client or remote tatanka node. Whitelisted
+// tatanka nodes will be reconnected by the whitelist loop.
+func (t *Tatanka) DisconnectPeer(peerID tanka.PeerID) error {
+	if c := t.clientNode(peerID); c != nil {
+		t.log.Infof("Operator disconnecting client %s", peerID)
+		c.Disconnect()
+		t.clientDisconnected(peerID)
+		return nil
+	}
+	t.tatankasMtx.Lock()
+	tt := t.tatankas[peerID]
+	delete(t.tatankas, peerID)
+	t.tatankasMtx.Unlock()
+	if tt == nil {
+		return ErrUnknownPeer
+	}
+	t.log.Infof("Operator disconnecting tatanka node %s", peerID)
+	tt.Disconnect()
+	return nil
+}
+
+// BlacklistPeer blacklists and disconnects the peer. Blacklisted peers are
+// refused connections until they are removed from the blacklist.
+func (t *Tatanka) BlacklistPeer(peerID tanka.PeerID) error {
+	if err := t.db.Blacklist(peerID); err != nil {
+		return fmt.Errorf("error blacklisting peer: %w", err)
+	}
+	t.log.Infof("Operator blacklisted peer %s", peerID)
+	if err := t.DisconnectPeer(peerID); err != nil && !errors.Is(err, ErrUnknownPeer) {
+		return err
+	}
+	return nil
+}
+
+// UnblacklistPeer removes the peer from the blacklist.
+func (t *Tatanka) UnblacklistPeer(peerID tanka.PeerID) error {
+	if err := t.db.Unblacklist(peerID); err != nil {
+		return fmt.Errorf("error removing peer from blacklist: %w", err)
+	}
+	t.log.Infof("Operator removed peer %s from the blacklist", peerID)
+	return nil
+}
+
+// Blacklist is the operator's blacklist.
+func (t *Tatanka) Blacklist() ([]*BlacklistEntry, error) {
+	peers, err := t.db.Blacklisted()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving blacklist: %w", err)
+	}
+	entries := make([]*BlacklistEntry, 0, len(peers))
+	for peerID, stamp := range peers {
+		entries = append(entries, &BlacklistEntry{ID: peerID.String(), Stamp: stamp})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Stamp.Before(entries[j].Stamp) })
+	return entries, nil
+}
@@ -0,0 +1,181 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package admin
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"decred.org/dcrdex/tatanka"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/go-chi/chi/v5"
+)
+
+const pongStr = "pong"
+
+// writeJSON marshals the provided interface and writes the bytes to the
+// ResponseWriter. The response code is assumed to be StatusOK.
+func writeJSON(w http.ResponseWriter, thing any) {
+	writeJSONWithStatus(w, thing, http.StatusOK)
+}
+
+// writeJSONWithStatus marshals the provided interface and writes the bytes to the
+// ResponseWriter with the specified response code.
+func writeJSONWithStatus(w http.ResponseWriter, thing any, code int) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	b, err := json.MarshalIndent(thing, "", "    ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Errorf("JSON encode error: %v", err)
+		return
+	}
+	w.WriteHeader(code)
+	_, err = w.Write(append(b, byte('\n')))
+	if err != nil {
+		log.Errorf("Write error: %v", err)
+	}
+}
+
+// decodePeerID parses a hex-encoded peer ID.
+func decodePeerID(s string) (peerID tanka.PeerID, err error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return peerID, fmt.Errorf("could not decode peer ID %q: %w", s, err)
+	}
+	if len(b) != tanka.PeerIDLength {
+		return peerID, fmt.Errorf("peer ID %q has wrong length %d", s, len(b))
+	}
+	copy(peerID[:], b)
+	return peerID, nil
+}
+
+// peerActionError writes the error for a failed peer action.
+func peerActionError(w http.ResponseWriter, err error) {
+	if errors.Is(err, tatanka.ErrUnknownPeer) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// apiPing is the handler for the '/ping' API request.
+func apiPing(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, pongStr)
+}
+
+// apiClients is the handler for the '/clients' API request.
+func (s *Server) apiClients(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.core.Clients())
+}
+
+// apiClient is the handler for the '/peer/{peerIDKey}' API request.
+func (s *Server) apiClient(w http.ResponseWriter, r *http.Request) {
+	peerID, err := decodePeerID(chi.URLParam(r, peerIDKey))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	info, err := s.core.Client(peerID)
+	if err != nil {
+		peerActionError(w, err)
+		return
+	}
+	writeJSON(w, info)
+}
+
+// apiTopics is the handler for the '/topics' API request.
+func (s *Server) apiTopics(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.core.Topics())
+}
+
+// apiBonds is the handler for the '/bonds' API request.
+func (s *Server) apiBonds(w http.ResponseWriter, _ *http.Request) {
+	totals, err := s.core.BondTotals()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, totals)
+}
+
+// apiReputationOutliers is the handler for the '/reputation' API request. The
+// connected clients with a reputation score below the optional "below" query
+// parameter, default zero, are listed from the lowest score.
+func (s *Server) apiReputationOutliers(w http.ResponseWriter, r *http.Request) {
+	var below int64
+	if belowStr := r.URL.Query().Get(belowKey); belowStr != "" {
+		var err error
+		below, err = strconv.ParseInt(belowStr, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	outliers := make([]*tatanka.ClientInfo, 0)
+	for _, c := range s.core.Clients() {
+		if c.Score < below {
+			outliers = append(outliers, c)
+		}
+	}
+	sort.Slice(outliers, func(i, j int) bool { return outliers[i].Score < outliers[j].Score })
+	writeJSON(w, outliers)
+}
+
+// apiTatankas is the handler for the '/tatankas' API request.
+func (s *Server) apiTatankas(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.core.Tatankas())
+}
+
+// apiMetrics is the handler for the '/metrics' API request.
+func (s *Server) apiMetrics(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, s.core.RouteStats())
+}
+
+// apiBlacklist is the handler for the '/blacklist' API request.
+func (s *Server) apiBlacklist(w http.ResponseWriter, _ *http.Request) {
+	entries, err := s.core.Blacklist()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, entries)
+}
+
+// peerAction handles a request for an action on the peer in the URL.
+func (s *Server) peerAction(w http.ResponseWriter, r *http.Request, action func(tanka.PeerID) error, msg string) {
+	peerIDStr := chi.URLParam(r, peerIDKey)
+	peerID, err := decodePeerID(peerIDStr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := action(peerID); err != nil {
+		peerActionError(w, err)
+		return
+	}
+	writeJSON(w, fmt.Sprintf("%s %s", msg, peerIDStr))
+}
+
+// apiDisconnect is the handler for the '/peer/{peerIDKey}/disconnect' API
+// request.
+func (s *Server) apiDisconnect(w http.ResponseWriter, r *http.Request) {
+	s.peerAction(w, r, s.core.DisconnectPeer, "disconnected")
+}
+
+// apiBlacklistPeer is the handler for the '/peer/{peerIDKey}/blacklist' API
+// request.
+func (s *Server) apiBlacklistPeer(w http.ResponseWriter, r *http.Request) {
+	s.peerAction(w, r, s.core.BlacklistPeer, "blacklisted")
+}
+
+// apiUnblacklistPeer is the handler for the '/peer/{peerIDKey}/unblacklist'
+// API request.
+func (s *Server) apiUnblacklistPeer(w http.ResponseWriter, r *http.Request) {
+	s.peerAction(w, r, s.core.UnblacklistPeer, "removed from blacklist:")
+}
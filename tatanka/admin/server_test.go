@@ -0,0 +1,173 @@
+package admin
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"decred.org/dcrdex/tatanka"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+const tPass = "abc"
+
+type TCore struct {
+	clients      []*tatanka.ClientInfo
+	bondTotals   []*tatanka.BondTotal
+	stats        map[string]*tatanka.RouteStats
+	actionErr    error
+	disconnected []tanka.PeerID
+	blacklisted  []tanka.PeerID
+}
+
+func (c *TCore) Clients() []*tatanka.ClientInfo { return c.clients }
+func (c *TCore) Client(peerID tanka.PeerID) (*tatanka.ClientInfo, error) {
+	for _, cl := range c.clients {
+		if cl.ID == peerID.String() {
+			return cl, nil
+		}
+	}
+	return nil, tatanka.ErrUnknownPeer
+}
+func (c *TCore) Topics() []*tatanka.TopicInfo                  { return nil }
+func (c *TCore) Tatankas() []*tatanka.TatankaInfo              { return nil }
+func (c *TCore) BondTotals() ([]*tatanka.BondTotal, error)     { return c.bondTotals, nil }
+func (c *TCore) RouteStats() map[string]*tatanka.RouteStats    { return c.stats }
+func (c *TCore) Blacklist() ([]*tatanka.BlacklistEntry, error) { return nil, nil }
+func (c *TCore) DisconnectPeer(peerID tanka.PeerID) error {
+	if c.actionErr != nil {
+		return c.actionErr
+	}
+	c.disconnected = append(c.disconnected, peerID)
+	return nil
+}
+func (c *TCore) BlacklistPeer(peerID tanka.PeerID) error {
+	c.blacklisted = append(c.blacklisted, peerID)
+	return nil
+}
+func (c *TCore) UnblacklistPeer(peerID tanka.PeerID) error { return nil }
+
+func newTServer(t *testing.T) (*Server, *TCore) {
+	core := new(TCore)
+	s, err := NewServer(&SrvConfig{
+		Core:    core,
+		Addr:    "localhost:0",
+		AuthSHA: sha256.Sum256([]byte(tPass)),
+		NoTLS:   true,
+	})
+	if err != nil {
+		t.Fatalf("error creating Server: %v", err)
+	}
+	return s, core
+}
+
+func tRequest(s *Server, path, pass string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r, _ := http.NewRequest(http.MethodGet, "http://localhost"+path, nil)
+	r.RemoteAddr = "localhost"
+	r.SetBasicAuth("", pass)
+	s.srv.Handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestAuth(t *testing.T) {
+	s, _ := newTServer(t)
+	if w := tRequest(s, "/api/ping", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Fatalf("wrong password returned code %d", w.Code)
+	}
+	w := tRequest(s, "/api/ping", tPass)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ping returned code %d", w.Code)
+	}
+	if body := w.Body.String(); body != `"`+pongStr+`"`+"\n" {
+		t.Fatalf("wrong ping response %q", body)
+	}
+}
+
+func TestReputationOutliers(t *testing.T) {
+	s, core := newTServer(t)
+	for i, score := range []int64{5, -10, -50, 0} {
+		core.clients = append(core.clients, &tatanka.ClientInfo{PeerInfo: tatanka.PeerInfo{
+			ID:    tanka.PeerID{byte(i)}.String(),
+			Score: score,
+		}})
+	}
+
+	check := func(path string, expScores ...int64) {
+		t.Helper()
+		w := tRequest(s, path, tPass)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s returned code %d", path, w.Code)
+		}
+		var outliers []*tatanka.ClientInfo
+		if err := json.Unmarshal(w.Body.Bytes(), &outliers); err != nil {
+			t.Fatalf("error decoding response: %v", err)
+		}
+		if len(outliers) != len(expScores) {
+			t.Fatalf("expected %d outliers, got %d", len(expScores), len(outliers))
+		}
+		for i, c := range outliers {
+			if c.Score != expScores[i] {
+				t.Fatalf("expected score %d at index %d, got %d", expScores[i], i, c.Score)
+			}
+		}
+	}
+	check("/api/reputation", -50, -10)
+	check("/api/reputation?below=-20", -50)
+	check("/api/reputation?below=10", -50, -10, 0, 5)
+	if w := tRequest(s, "/api/reputation?below=x", tPass); w.Code != http.StatusBadRequest {
+		t.Fatalf("bad threshold returned code %d", w.Code)
+	}
+}
+
+func TestPeerActions(t *testing.T) {
+	s, core := newTServer(t)
+	peerID := tanka.PeerID{0x02, 0x01}
+	core.clients = []*tatanka.ClientInfo{{PeerInfo: tatanka.PeerInfo{ID: peerID.String()}}}
+
+	if w := tRequest(s, "/api/peer/"+peerID.String(), tPass); w.Code != http.StatusOK {
+		t.Fatalf("client info returned code %d", w.Code)
+	}
+	if w := tRequest(s, "/api/peer/"+tanka.PeerID{0x03}.String(), tPass); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown client info returned code %d", w.Code)
+	}
+	if w := tRequest(s, "/api/peer/abcd/disconnect", tPass); w.Code != http.StatusBadRequest {
+		t.Fatalf("short peer ID returned code %d", w.Code)
+	}
+
+	if w := tRequest(s, "/api/peer/"+peerID.String()+"/disconnect", tPass); w.Code != http.StatusOK {
+		t.Fatalf("disconnect returned code %d", w.Code)
+	}
+	if len(core.disconnected) != 1 || core.disconnected[0] != peerID {
+		t.Fatalf("peer not disconnected")
+	}
+	core.actionErr = tatanka.ErrUnknownPeer
+	if w := tRequest(s, "/api/peer/"+peerID.String()+"/disconnect", tPass); w.Code != http.StatusNotFound {
+		t.Fatalf("disconnecting unknown peer returned code %d", w.Code)
+	}
+
+	if w := tRequest(s, "/api/peer/"+peerID.String()+"/blacklist", tPass); w.Code != http.StatusOK {
+		t.Fatalf("blacklist returned code %d", w.Code)
+	}
+	if len(core.blacklisted) != 1 || core.blacklisted[0] != peerID {
+		t.Fatalf("peer not blacklisted")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s, core := newTServer(t)
+	core.stats = map[string]*tatanka.RouteStats{"connect": {Received: 3, Errors: 1}}
+	w := tRequest(s, "/api/metrics", tPass)
+	if w.Code != http.StatusOK {
+		t.Fatalf("metrics returned code %d", w.Code)
+	}
+	var stats map[string]*tatanka.RouteStats
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if s := stats["connect"]; s == nil || s.Received != 3 || s.Errors != 1 {
+		t.Fatalf("wrong metrics")
+	}
+}
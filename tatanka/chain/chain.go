@@ -35,8 +35,10 @@ type Chain interface {
 	// Query(context.Context, Query) (Result, error)
 	Connected() bool
 	CheckBond(*tanka.Bond) error
-	// AuditHTLC will be needed if Tatanka nodes are the auditors.
-	// AuditHTLC(*tanka.HTLCAudit) (bool, error)
+	// AuditHTLC looks up the swap contract on-chain and reports its terms and
+	// whether it has been redeemed or refunded. An error is returned if the
+	// contract is not found or has not been mined.
+	AuditHTLC(*tanka.HTLCAudit) (*tanka.HTLCStatus, error)
 }
 
 // FeeRater is an optional interface that should be implemented by backends for
@@ -57,6 +59,23 @@ func RegisterChainConstructor(chainID uint32, c ChainConstructor) {
 	chains[chainID] = c
 }
 
+var tokenParents = make(map[uint32]uint32)
+
+// RegisterToken is called by chain backends to register a token whose bonds
+// are handled by the parent chain's backend.
+func RegisterToken(tokenID, parentID uint32) {
+	tokenParents[tokenID] = parentID
+}
+
+// ChainID is the ID of the chain backend that handles the asset. For tokens,
+// this is the parent chain's ID.
+func ChainID(assetID uint32) uint32 {
+	if parentID, found := tokenParents[assetID]; found {
+		return parentID
+	}
+	return assetID
+}
+
 // New is used by the caller to construct a new Chain.
 func New(chainID uint32, cfg json.RawMessage, log dex.Logger, net dex.Network) (Chain, error) {
 	c, found := chains[chainID]
@@ -0,0 +1,455 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	dexeth "decred.org/dcrdex/dex/networks/eth"
+	swapv1 "decred.org/dcrdex/dex/networks/eth/contracts/v1"
+	dexpolygon "decred.org/dcrdex/dex/networks/polygon"
+	"decred.org/dcrdex/tatanka/chain"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+/*
+	An EVM bond is an initiation of the version 1 swap contract. The
+	participant is BondParticipant, an address derived from the bonding peer's
+	ID for which no private key is known, so the swap can never be redeemed.
+	The refund time is the bond expiration, and the contract only refunds to
+	the initiator after the refund time, so the bonded funds are locked until
+	the bond expires. The bond is active while the swap remains in the
+	initiated state.
+*/
+
+const (
+	// bondContractVersion is the swap contract version used for bonds.
+	bondContractVersion = 1
+	// defaultBondConfs is the number of confirmations required for a bond
+	// if not configured.
+	defaultBondConfs = 1
+	// requestTimeout is the timeout for requests made during bond checks.
+	requestTimeout = time.Second * 10
+	// bondParticipantTag is hashed with the peer ID to derive the bond
+	// participant address.
+	bondParticipantTag = "tatanka bond participant"
+)
+
+var feeMonitorTick = time.Second * 10
+
+func init() {
+	chain.RegisterChainConstructor(dexeth.EthBipID, NewEthereum)
+	chain.RegisterChainConstructor(dexpolygon.PolygonBipID, NewPolygon)
+	for tokenID, tkn := range dexeth.Tokens {
+		chain.RegisterToken(tokenID, tkn.ParentID)
+	}
+	for tokenID, tkn := range dexpolygon.Tokens {
+		chain.RegisterToken(tokenID, tkn.ParentID)
+	}
+}
+
+type ConfigFile struct {
+	// RPCAddr is the http(s), ws(s) or IPC endpoint of the node.
+	RPCAddr string `json:"rpcaddr"`
+	// BondConfs is the number of confirmations required for a bond.
+	// Default 1.
+	BondConfs uint32 `json:"bondConfs"`
+	// BondAmounts is the bond value, in atomic units, per unit of bond
+	// strength, keyed by asset symbol, e.g. "eth" or "usdc.eth". Bonds are
+	// only accepted for configured assets.
+	BondAmounts map[string]uint64 `json:"bondAmounts"`
+}
+
+// ethClient is the subset of *ethclient.Client methods used by evmChain.
+type ethClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	Close()
+}
+
+// swapContract is the subset of *swapv1.ETHSwapCaller methods used by
+// evmChain.
+type swapContract interface {
+	Status(opts *bind.CallOpts, token common.Address, v swapv1.ETHSwapVector) (swapv1.ETHSwapStatus, error)
+}
+
+type evmChain struct {
+	cfg          *ConfigFile
+	net          dex.Network
+	log          dex.Logger
+	fees         chan uint64
+	name         string
+	assetID      uint32
+	evmChainID   int64
+	contractAddr common.Address
+	tokens       map[uint32]*dexeth.Token
+
+	ctx       context.Context
+	ec        ethClient
+	contract  swapContract
+	connected atomic.Bool
+}
+
+// NewEthereum is the ChainConstructor for Ethereum.
+func NewEthereum(rawConfig json.RawMessage, log dex.Logger, net dex.Network) (chain.Chain, error) {
+	return newEVMChain(rawConfig, log, net, "Ethereum", dexeth.EthBipID, dexeth.ChainIDs,
+		dexeth.ContractAddresses, dexeth.Tokens)
+}
+
+// NewPolygon is the ChainConstructor for Polygon.
+func NewPolygon(rawConfig json.RawMessage, log dex.Logger, net dex.Network) (chain.Chain, error) {
+	return newEVMChain(rawConfig, log, net, "Polygon", dexpolygon.PolygonBipID, dexpolygon.ChainIDs,
+		dexpolygon.ContractAddresses, dexpolygon.Tokens)
+}
+
+func newEVMChain(
+	rawConfig json.RawMessage,
+	log dex.Logger,
+	net dex.Network,
+	name string,
+	assetID uint32,
+	chainIDs map[dex.Network]int64,
+	contractAddrs map[uint32]map[dex.Network]common.Address,
+	tokens map[uint32]*dexeth.Token,
+) (chain.Chain, error) {
+	var cfg ConfigFile
+	if err := json.Unmarshal(rawConfig, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing configuration: %w", err)
+	}
+	if cfg.RPCAddr == "" {
+		return nil, errors.New("no rpcaddr configured")
+	}
+	if cfg.BondConfs == 0 {
+		cfg.BondConfs = defaultBondConfs
+	}
+	for symbol := range cfg.BondAmounts {
+		if id, found := dex.BipSymbolID(symbol); !found || (id != assetID && tokens[id] == nil) {
+			return nil, fmt.Errorf("bond amount configured for unknown %s asset %q", name, symbol)
+		}
+	}
+	evmChainID, found := chainIDs[net]
+	if !found {
+		return nil, fmt.Errorf("no %s chain ID for network %s", name, net)
+	}
+	contractAddr := contractAddrs[bondContractVersion][net]
+	if contractAddr == (common.Address{}) {
+		return nil, fmt.Errorf("no %s version %d contract address for network %s", name, bondContractVersion, net)
+	}
+	return &evmChain{
+		cfg:          &cfg,
+		net:          net,
+		log:          log,
+		fees:         make(chan uint64, 1),
+		name:         name,
+		assetID:      assetID,
+		evmChainID:   evmChainID,
+		contractAddr: contractAddr,
+		tokens:       tokens,
+		ctx:          context.Background(),
+	}, nil
+}
+
+func (c *evmChain) Connect(ctx context.Context) (*sync.WaitGroup, error) {
+	ec, err := ethclient.DialContext(ctx, c.cfg.RPCAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s node: %w", c.name, err)
+	}
+	c.ec = ec
+	chainID, err := c.ec.ChainID(ctx)
+	if err != nil {
+		c.ec.Close()
+		return nil, fmt.Errorf("error getting %s chain ID: %w", c.name, err)
+	}
+	if chainID.Int64() != c.evmChainID {
+		c.ec.Close()
+		return nil, fmt.Errorf("wrong %s chain ID %d. expected %d", c.name, chainID, c.evmChainID)
+	}
+	if c.contract, err = swapv1.NewETHSwapCaller(c.contractAddr, ec); err != nil {
+		c.ec.Close()
+		return nil, fmt.Errorf("error constructing contract caller: %w", err)
+	}
+	c.ctx = ctx
+	c.connected.Store(true)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer c.ec.Close()
+		defer c.connected.Store(false)
+		c.monitorFees(ctx)
+	}()
+
+	return &wg, nil
+}
+
+func (c *evmChain) Connected() bool {
+	return c.connected.Load()
+}
+
+func (c *evmChain) FeeChannel() <-chan uint64 {
+	return c.fees
+}
+
+// monitorFees sends the recommended max fee rate, in gwei/gas, for each new
+// block. The recommendation is the standard base fee * 2 + tip.
+func (c *evmChain) monitorFees(ctx context.Context) {
+	tick := time.NewTicker(feeMonitorTick)
+	defer tick.Stop()
+	var tip common.Hash
+	for {
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+			return
+		}
+
+		hdr, err := c.ec.HeaderByNumber(ctx, nil)
+		if err != nil {
+			c.connected.Store(false)
+			c.log.Errorf("%s is not connected: %v", c.name, err)
+			continue
+		}
+		c.connected.Store(true)
+		if hdr.Hash() == tip {
+			continue
+		}
+		tip = hdr.Hash()
+		if hdr.BaseFee == nil {
+			c.log.Errorf("%s block %s has no base fee", c.name, tip)
+			continue
+		}
+		tipCap, err := c.ec.SuggestGasTipCap(ctx)
+		if err != nil {
+			c.log.Errorf("error getting %s tip cap suggestion: %v", c.name, err)
+			continue
+		}
+		if minTip := dexeth.GweiToWei(dexeth.MinGasTipCap); tipCap.Cmp(minTip) < 0 {
+			tipCap = minTip
+		}
+		maxFeeRate := new(big.Int).Add(tipCap, new(big.Int).Mul(hdr.BaseFee, big.NewInt(2)))
+		select {
+		case c.fees <- dexeth.WeiToGweiCeil(maxFeeRate):
+		case <-time.After(time.Second * 5):
+			c.log.Errorf("fee channel is blocking")
+		}
+	}
+}
+
+// atomicValue converts a contract value to atomic units.
+func (c *evmChain) atomicValue(assetID uint32, v *big.Int) uint64 {
+	if assetID == c.assetID {
+		return dexeth.WeiToGwei(v)
+	}
+	return c.tokens[assetID].EVMToAtomic(v)
+}
+
+// tokenAddress is the address passed to the swap contract for the asset. The
+// zero address indicates the native asset.
+func (c *evmChain) tokenAddress(assetID uint32) (common.Address, error) {
+	if assetID == c.assetID {
+		return common.Address{}, nil
+	}
+	tkn, found := c.tokens[assetID]
+	if !found {
+		return common.Address{}, fmt.Errorf("asset %d is not a %s token", assetID, c.name)
+	}
+	netToken, found := tkn.NetTokens[c.net]
+	if !found {
+		return common.Address{}, fmt.Errorf("no %s token address for network %s", tkn.Name, c.net)
+	}
+	return netToken.Address, nil
+}
+
+// BondParticipant is the swap participant address for the peer's bonds. The
+// address is the last 20 bytes of a hash of the peer ID, so nobody has the
+// private key needed to redeem the swap.
+func BondParticipant(peerID tanka.PeerID) common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte(bondParticipantTag), peerID[:]))
+}
+
+// CheckBond checks that the bond transaction initiated swap contracts with the
+// peer's bond participant that are still locked until at least the bond
+// expiration, with enough value for the bond strength.
+func (c *evmChain) CheckBond(b *tanka.Bond) error {
+	symbol := dex.BipIDSymbol(b.AssetID)
+	bondAmt := c.cfg.BondAmounts[symbol]
+	if bondAmt == 0 {
+		return fmt.Errorf("%s bonds not accepted", symbol)
+	}
+	if b.Strength == 0 {
+		return errors.New("zero bond strength")
+	}
+	tokenAddr, err := c.tokenAddress(b.AssetID)
+	if err != nil {
+		return err
+	}
+	participant := BondParticipant(b.PeerID)
+	txHash, err := dexeth.DecodeCoinID(b.CoinID)
+	if err != nil {
+		return fmt.Errorf("invalid coin ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, requestTimeout)
+	defer cancel()
+
+	tx, pending, err := c.ec.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("error getting bond transaction %s: %w", txHash, err)
+	}
+	if pending {
+		return fmt.Errorf("bond transaction %s is not mined", txHash)
+	}
+	if tx.To() == nil || *tx.To() != c.contractAddr {
+		return fmt.Errorf("bond transaction %s is not to the swap contract", txHash)
+	}
+	receipt, err := c.ec.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("error getting bond transaction %s receipt: %w", txHash, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("bond transaction %s failed", txHash)
+	}
+	tipHeight, err := c.ec.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting tip height: %w", err)
+	}
+	if confs := int64(tipHeight) - receipt.BlockNumber.Int64() + 1; confs < int64(c.cfg.BondConfs) {
+		return fmt.Errorf("bond transaction %s has %d confirmations. %d required", txHash, confs, c.cfg.BondConfs)
+	}
+
+	txTokenAddr, vectors, err := dexeth.ParseInitiateDataV1(tx.Data())
+	if err != nil {
+		return fmt.Errorf("error parsing bond transaction %s: %w", txHash, err)
+	}
+	if txTokenAddr != tokenAddr {
+		return fmt.Errorf("bond transaction %s is for token %s, not %s", txHash, txTokenAddr, symbol)
+	}
+	var total uint64
+	for _, v := range vectors {
+		if v.To != participant {
+			continue
+		}
+		if lockTime := time.Unix(int64(v.LockTime), 0); lockTime.Before(b.Expiration) {
+			return fmt.Errorf("bond contract locked until %s, before expiration %s", lockTime, b.Expiration)
+		}
+		status, err := c.contract.Status(&bind.CallOpts{Context: ctx}, tokenAddr, dexeth.SwapVectorToAbigen(v))
+		if err != nil {
+			return fmt.Errorf("error getting bond contract status: %w", err)
+		}
+		if step := dexeth.SwapStep(status.Step); step != dexeth.SSInitiated {
+			return fmt.Errorf("bond contract is %s", step)
+		}
+		total += c.atomicValue(b.AssetID, v.Value)
+	}
+	if total == 0 {
+		return fmt.Errorf("bond transaction %s has no contracts for peer %s", txHash, b.PeerID)
+	}
+	if b.Strength > math.MaxUint64/bondAmt {
+		return fmt.Errorf("bond strength %d is too large", b.Strength)
+	}
+	if req := b.Strength * bondAmt; total < req {
+		return fmt.Errorf("bond value %d is less than %d required for strength %d", total, req, b.Strength)
+	}
+	return nil
+}
+
+// AuditHTLC checks the status of a version 1 swap contract. The contract is
+// the contract data encoding of the swap's locator, and the coin ID is the
+// hash of the initiation transaction.
+func (c *evmChain) AuditHTLC(audit *tanka.HTLCAudit) (*tanka.HTLCStatus, error) {
+	ver, locator, err := dexeth.DecodeContractData(audit.Contract)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding contract: %w", err)
+	}
+	if ver != bondContractVersion {
+		return nil, fmt.Errorf("unsupported contract version %d", ver)
+	}
+	v, err := dexeth.ParseV1Locator(locator)
+	if err != nil {
+		return nil, err
+	}
+	tokenAddr, err := c.tokenAddress(audit.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	txHash, err := dexeth.DecodeCoinID(audit.CoinID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid coin ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, requestTimeout)
+	defer cancel()
+
+	// The block time comes from the initiation transaction, so make sure the
+	// transaction actually initiated the contract.
+	tx, pending, err := c.ec.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract transaction %s: %w", txHash, err)
+	}
+	if pending {
+		return nil, fmt.Errorf("contract transaction %s is not mined", txHash)
+	}
+	if tx.To() == nil || *tx.To() != c.contractAddr {
+		return nil, fmt.Errorf("contract transaction %s is not to the swap contract", txHash)
+	}
+	txTokenAddr, vectors, err := dexeth.ParseInitiateDataV1(tx.Data())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing contract transaction %s: %w", txHash, err)
+	}
+	if txVector, found := vectors[v.SecretHash]; !found || txTokenAddr != tokenAddr || !dexeth.CompareVectors(v, txVector) {
+		return nil, fmt.Errorf("contract transaction %s did not initiate the contract", txHash)
+	}
+	receipt, err := c.ec.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract transaction %s receipt: %w", txHash, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("contract transaction %s failed", txHash)
+	}
+	hdr, err := c.ec.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract block header: %w", err)
+	}
+
+	status, err := c.contract.Status(&bind.CallOpts{Context: ctx}, tokenAddr, dexeth.SwapVectorToAbigen(v))
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract status: %w", err)
+	}
+	step := dexeth.SwapStep(status.Step)
+	if step == dexeth.SSNone {
+		return nil, errors.New("contract not found")
+	}
+	htlc := &tanka.HTLCStatus{
+		Recipient:  v.To.Hex(),
+		Value:      c.atomicValue(audit.AssetID, v.Value),
+		SecretHash: v.SecretHash[:],
+		LockTime:   time.Unix(int64(v.LockTime), 0),
+		BlockTime:  time.Unix(int64(hdr.Time), 0),
+		Redeemed:   step == dexeth.SSRedeemed,
+		Refunded:   step == dexeth.SSRefunded,
+	}
+	if htlc.Redeemed {
+		htlc.Secret = status.Secret[:]
+	}
+	return htlc, nil
+}
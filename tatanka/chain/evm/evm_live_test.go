@@ -0,0 +1,86 @@
+//go:build harness
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/user"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	dexeth "decred.org/dcrdex/dex/networks/eth"
+	"decred.org/dcrdex/tatanka/chain"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+func TestSimnetETH(t *testing.T) {
+	usr, _ := user.Current()
+	cfg := &ConfigFile{
+		RPCAddr:     filepath.Join(usr.HomeDir, "dextest", "eth", "alpha", "node", "geth.ipc"),
+		BondAmounts: map[string]uint64{"eth": 1e8},
+	}
+	rawCfg, _ := json.Marshal(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := prepareSimnetChain(t, ctx, NewEthereum, rawCfg)
+
+	priv, _ := secp256k1.GeneratePrivateKey()
+	var peerID tanka.PeerID
+	copy(peerID[:], priv.PubKey().SerializeCompressed())
+	bond := &tanka.Bond{
+		PeerID:     peerID,
+		AssetID:    dexeth.EthBipID,
+		CoinID:     encode.RandomBytes(32),
+		Strength:   1,
+		Expiration: time.Now().Add(time.Hour),
+	}
+	if err := c.CheckBond(bond); err == nil {
+		t.Fatalf("no error for unknown bond transaction")
+	}
+	usdcID, _ := dex.BipSymbolID("usdc.eth")
+	bond.AssetID = usdcID
+	if err := c.CheckBond(bond); err == nil {
+		t.Fatalf("no error for unconfigured bond asset")
+	}
+}
+
+func TestSimnetPolygon(t *testing.T) {
+	usr, _ := user.Current()
+	cfg := &ConfigFile{
+		RPCAddr: filepath.Join(usr.HomeDir, "dextest", "polygon", "alpha", "bor", "bor.ipc"),
+	}
+	rawCfg, _ := json.Marshal(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	prepareSimnetChain(t, ctx, NewPolygon, rawCfg)
+}
+
+func prepareSimnetChain(t *testing.T, ctx context.Context, newChain chain.ChainConstructor, rawCfg json.RawMessage) chain.Chain {
+	feeMonitorTick = time.Second
+
+	c, err := newChain(rawCfg, dex.StdOutLogger("T", dex.LevelTrace), dex.Simnet)
+	if err != nil {
+		t.Fatalf("chain constructor error: %v", err)
+	}
+
+	cm := dex.NewConnectionMaster(c)
+	if err := cm.ConnectOnce(ctx); err != nil {
+		t.Fatalf("Connect error: %v", err)
+	}
+
+	fmt.Println("Waiting for fee update")
+	select {
+	case fees := <-c.(chain.FeeRater).FeeChannel():
+		fmt.Println("Fees received over fee channel =", fees)
+	case <-time.After(time.Second * 30):
+		t.Fatalf("No fee update seen. Is the miner running?")
+	}
+
+	return c
+}
@@ -0,0 +1,260 @@
+//go:build !harness
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	dexeth "decred.org/dcrdex/dex/networks/eth"
+	swapv1 "decred.org/dcrdex/dex/networks/eth/contracts/v1"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var tLogger = dex.StdOutLogger("EVM_TEST", dex.LevelInfo)
+
+type tClient struct {
+	tip       uint64
+	tx        *types.Transaction
+	pending   bool
+	txErr     error
+	receipt   *types.Receipt
+	hdr       *types.Header
+	hdrErr    error
+	tipCap    *big.Int
+	tipCapErr error
+}
+
+func (c *tClient) ChainID(context.Context) (*big.Int, error) {
+	return big.NewInt(dexeth.SimnetChainID), nil
+}
+
+func (c *tClient) BlockNumber(context.Context) (uint64, error) {
+	return c.tip, nil
+}
+
+func (c *tClient) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return c.hdr, c.hdrErr
+}
+
+func (c *tClient) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return c.tipCap, c.tipCapErr
+}
+
+func (c *tClient) TransactionByHash(context.Context, common.Hash) (*types.Transaction, bool, error) {
+	return c.tx, c.pending, c.txErr
+}
+
+func (c *tClient) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return c.receipt, nil
+}
+
+func (c *tClient) Close() {}
+
+type tContract struct {
+	step dexeth.SwapStep
+	err  error
+}
+
+func (c *tContract) Status(*bind.CallOpts, common.Address, swapv1.ETHSwapVector) (swapv1.ETHSwapStatus, error) {
+	return swapv1.ETHSwapStatus{Step: uint8(c.step)}, c.err
+}
+
+func newTChain(t *testing.T) (*evmChain, *tClient, *tContract) {
+	t.Helper()
+	rawCfg, _ := json.Marshal(&ConfigFile{
+		RPCAddr:     "127.0.0.1:8545",
+		BondConfs:   2,
+		BondAmounts: map[string]uint64{"eth": 1e8},
+	})
+	ch, err := NewEthereum(rawCfg, tLogger, dex.Simnet)
+	if err != nil {
+		t.Fatalf("NewEthereum error: %v", err)
+	}
+	c := ch.(*evmChain)
+	cl := &tClient{tip: 100}
+	contract := &tContract{step: dexeth.SSInitiated}
+	c.ec, c.contract = cl, contract
+	return c, cl, contract
+}
+
+func initiateTx(t *testing.T, to common.Address, vectors ...swapv1.ETHSwapVector) *types.Transaction {
+	t.Helper()
+	data, err := dexeth.ABIs[1].Pack("initiate", common.Address{}, vectors)
+	if err != nil {
+		t.Fatalf("error packing initiate data: %v", err)
+	}
+	return types.NewTx(&types.DynamicFeeTx{To: &to, Data: data})
+}
+
+func TestCheckBond(t *testing.T) {
+	c, cl, contract := newTChain(t)
+
+	var peerID tanka.PeerID
+	copy(peerID[:], encode.RandomBytes(tanka.PeerIDLength))
+	expiration := time.Now().Add(time.Hour * 24).Truncate(time.Second)
+	bond := &tanka.Bond{
+		PeerID:     peerID,
+		AssetID:    dexeth.EthBipID,
+		CoinID:     encode.RandomBytes(32),
+		Strength:   2,
+		Expiration: expiration,
+	}
+	initiator := common.BytesToAddress(encode.RandomBytes(20))
+	vector := func(participant common.Address, value uint64, lockTime time.Time) swapv1.ETHSwapVector {
+		var secretHash [32]byte
+		copy(secretHash[:], encode.RandomBytes(32))
+		return swapv1.ETHSwapVector{
+			SecretHash:      secretHash,
+			Value:           dexeth.GweiToWei(value),
+			Initiator:       initiator,
+			RefundTimestamp: uint64(lockTime.Unix()),
+			Participant:     participant,
+		}
+	}
+	participant := BondParticipant(peerID)
+	// A bond paying the peer's own mesh key address could be redeemed by the
+	// peer at any time.
+	pubKey, _ := crypto.GenerateKey()
+	peerAddr := crypto.PubkeyToAddress(pubKey.PublicKey)
+
+	reset := func() {
+		cl.tx = initiateTx(t, c.contractAddr, vector(participant, 1e8, expiration), vector(participant, 1e8, expiration))
+		cl.pending, cl.txErr = false, nil
+		cl.receipt = &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(99)}
+		contract.step, contract.err = dexeth.SSInitiated, nil
+		bond.AssetID, bond.Strength = dexeth.EthBipID, 2
+	}
+
+	tests := []struct {
+		name    string
+		prep    func()
+		wantErr string
+	}{{
+		name: "ok",
+	}, {
+		name:    "asset not accepted",
+		prep:    func() { bond.AssetID = 0 },
+		wantErr: "not accepted",
+	}, {
+		name:    "zero strength",
+		prep:    func() { bond.Strength = 0 },
+		wantErr: "zero bond strength",
+	}, {
+		name:    "tx error",
+		prep:    func() { cl.txErr = errors.New("test error") },
+		wantErr: "test error",
+	}, {
+		name:    "pending",
+		prep:    func() { cl.pending = true },
+		wantErr: "not mined",
+	}, {
+		name:    "not to contract",
+		prep:    func() { cl.tx = initiateTx(t, initiator, vector(participant, 2e8, expiration)) },
+		wantErr: "not to the swap contract",
+	}, {
+		name:    "failed tx",
+		prep:    func() { cl.receipt.Status = types.ReceiptStatusFailed },
+		wantErr: "failed",
+	}, {
+		name:    "not enough confirmations",
+		prep:    func() { cl.receipt.BlockNumber = big.NewInt(100) },
+		wantErr: "confirmations",
+	}, {
+		name:    "redeemable by peer",
+		prep:    func() { cl.tx = initiateTx(t, c.contractAddr, vector(peerAddr, 2e8, expiration)) },
+		wantErr: "no contracts for peer",
+	}, {
+		name:    "lock time before expiration",
+		prep:    func() { cl.tx = initiateTx(t, c.contractAddr, vector(participant, 2e8, expiration.Add(-time.Second))) },
+		wantErr: "before expiration",
+	}, {
+		name:    "refunded",
+		prep:    func() { contract.step = dexeth.SSRefunded },
+		wantErr: "bond contract is",
+	}, {
+		name:    "insufficient value",
+		prep:    func() { bond.Strength = 3 },
+		wantErr: "less than",
+	}}
+	for _, tt := range tests {
+		reset()
+		if tt.prep != nil {
+			tt.prep()
+		}
+		err := c.CheckBond(bond)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Fatalf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestBondParticipant(t *testing.T) {
+	var peerID tanka.PeerID
+	copy(peerID[:], encode.RandomBytes(tanka.PeerIDLength))
+	if BondParticipant(peerID) != BondParticipant(peerID) {
+		t.Fatalf("bond participant not deterministic")
+	}
+	var peerID2 tanka.PeerID
+	copy(peerID2[:], encode.RandomBytes(tanka.PeerIDLength))
+	if BondParticipant(peerID) == BondParticipant(peerID2) {
+		t.Fatalf("same bond participant for different peers")
+	}
+}
+
+func TestMonitorFees(t *testing.T) {
+	c, cl, _ := newTChain(t)
+	defer func(d time.Duration) { feeMonitorTick = d }(feeMonitorTick)
+	feeMonitorTick = time.Millisecond
+
+	baseFee := dexeth.GweiToWei(30)
+	cl.hdr = &types.Header{Number: big.NewInt(100), BaseFee: baseFee}
+	cl.tipCap = dexeth.GweiToWei(1) // below the minimum
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.monitorFees(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	select {
+	case feeRate := <-c.FeeChannel():
+		if exp := uint64(30*2 + dexeth.MinGasTipCap); feeRate != exp {
+			t.Fatalf("wrong fee rate. expected %d, got %d", exp, feeRate)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("no fee rate sent")
+	}
+	if !c.Connected() {
+		t.Fatalf("not connected after fee rate sent")
+	}
+
+	// No new fee rate is sent for the same block.
+	select {
+	case <-c.FeeChannel():
+		t.Fatalf("fee rate sent for the same block")
+	case <-time.After(time.Millisecond * 50):
+	}
+}
@@ -4,7 +4,9 @@
 package utxo
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +22,7 @@ import (
 	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 	"github.com/decred/dcrd/rpcclient/v8"
@@ -40,12 +43,14 @@ type BitcoinConfigFile struct {
 }
 
 type bitcoinChain struct {
-	cfg  *BitcoinConfigFile
-	net  dex.Network
-	log  dex.Logger
-	fees chan uint64
-	name string
-
+	cfg    *BitcoinConfigFile
+	net    dex.Network
+	params *chaincfg.Params
+	log    dex.Logger
+	fees   chan uint64
+	name   string
+
+	ctx       context.Context
 	cl        *rpcclient.Client
 	connected atomic.Bool
 }
@@ -63,16 +68,30 @@ func NewBitcoin(rawConfig json.RawMessage, log dex.Logger, net dex.Network) (cha
 		return nil, fmt.Errorf("error validating RPC configuration: %v", err)
 	}
 
+	var params *chaincfg.Params
+	switch net {
+	case dex.Mainnet:
+		params = &chaincfg.MainNetParams
+	case dex.Testnet:
+		params = &chaincfg.TestNet3Params
+	case dex.Regtest: // dex.Simnet
+		params = &chaincfg.RegressionNetParams
+	default:
+		return nil, fmt.Errorf("unknown network %d", net)
+	}
+
 	return &bitcoinChain{
-		cfg:  &cfg,
-		net:  net,
-		log:  log,
-		name: "Bitcoin",
-		fees: make(chan uint64, 1),
+		cfg:    &cfg,
+		net:    net,
+		params: params,
+		log:    log,
+		name:   "Bitcoin",
+		fees:   make(chan uint64, 1),
 	}, nil
 }
 
 func (c *bitcoinChain) Connect(ctx context.Context) (_ *sync.WaitGroup, err error) {
+	c.ctx = ctx
 	cfg := c.cfg
 	host := cfg.RPCBind
 	if cfg.NodeRelay != "" {
@@ -315,11 +334,103 @@ func (c *bitcoinChain) CheckBond(b *tanka.Bond) error {
 	return nil
 }
 
-func (c *bitcoinChain) AuditHTLC(*tanka.HTLCAudit) (bool, error) {
+// AuditHTLC checks that the contract is the output's script, and whether the
+// output has been spent by a redemption or a refund. Spends are searched for
+// in blocks starting at the contract's block.
+func (c *bitcoinChain) AuditHTLC(audit *tanka.HTLCAudit) (*tanka.HTLCStatus, error) {
+	txHash, vout, err := decodeCoinID(audit.CoinID)
+	if err != nil {
+		return nil, err
+	}
+	txid := chainhash.Hash(txHash).String()
 
-	// TODO: Perform the audit
+	ctx, cancel := context.WithTimeout(c.ctx, auditTimeout)
+	defer cancel()
 
-	return true, nil
+	var tx btcjson.TxRawResult
+	if err := c.call(ctx, "getrawtransaction", []any{txid, true}, &tx); err != nil {
+		return nil, fmt.Errorf("error getting contract transaction %s: %w", txid, err)
+	}
+	if tx.BlockHash == "" {
+		return nil, fmt.Errorf("contract transaction %s is not mined", txid)
+	}
+	if int(vout) >= len(tx.Vout) {
+		return nil, fmt.Errorf("contract transaction %s has no output %d", txid, vout)
+	}
+	out := tx.Vout[vout]
+	pkScript, err := hex.DecodeString(out.ScriptPubKey.Hex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding output script: %w", err)
+	}
+	scriptHash := dexbtc.ExtractScriptHash(pkScript)
+	var segwit bool
+	switch {
+	case len(scriptHash) == 32 && bytes.Equal(scriptHash, sha256Hash(audit.Contract)):
+		segwit = true
+	case len(scriptHash) == 20 && bytes.Equal(scriptHash, btcutil.Hash160(audit.Contract)):
+	default:
+		return nil, fmt.Errorf("output %s:%d does not pay to the contract", txid, vout)
+	}
+	_, receiver, lockTime, secretHash, err := dexbtc.ExtractSwapDetails(audit.Contract, segwit, c.params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing contract: %w", err)
+	}
+	value, err := btcutil.NewAmount(out.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing output value: %w", err)
+	}
+	status := &tanka.HTLCStatus{
+		Recipient:  receiver.EncodeAddress(),
+		Value:      uint64(value),
+		SecretHash: secretHash,
+		LockTime:   time.Unix(int64(lockTime), 0),
+		BlockTime:  time.Unix(tx.Blocktime, 0),
+	}
+
+	// gettxout returns null for a spent output.
+	var txOut *btcjson.GetTxOutResult
+	if err := c.call(ctx, "gettxout", []any{txid, vout, true}, &txOut); err != nil {
+		return nil, fmt.Errorf("error getting contract output: %w", err)
+	}
+	if txOut != nil {
+		return status, nil
+	}
+
+	for blockHash := tx.BlockHash; blockHash != ""; {
+		var blk struct {
+			Tx       []btcjson.TxRawResult `json:"tx"`
+			NextHash string                `json:"nextblockhash"`
+		}
+		if err := c.call(ctx, "getblock", []any{blockHash, 2}, &blk); err != nil {
+			return nil, fmt.Errorf("error getting block %s: %w", blockHash, err)
+		}
+		for i := range blk.Tx {
+			for _, in := range blk.Tx[i].Vin {
+				if in.Txid != txid || in.Vout != vout {
+					continue
+				}
+				witness := make([][]byte, len(in.Witness))
+				for j, w := range in.Witness {
+					if witness[j], err = hex.DecodeString(w); err != nil {
+						return nil, fmt.Errorf("error decoding witness: %w", err)
+					}
+				}
+				var sigScript []byte
+				if in.ScriptSig != nil {
+					if sigScript, err = hex.DecodeString(in.ScriptSig.Hex); err != nil {
+						return nil, fmt.Errorf("error decoding signature script: %w", err)
+					}
+				}
+				setSpender(status, func() ([]byte, error) {
+					return dexbtc.FindKeyPush(witness, sigScript, scriptHash, segwit, c.params)
+				})
+				return status, nil
+			}
+		}
+		blockHash = blk.NextHash
+	}
+	// Spent in mempool.
+	return status, nil
 }
 
 // isMethodNotFoundErr will return true if the error indicates that the RPC
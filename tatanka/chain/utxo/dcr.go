@@ -4,7 +4,9 @@
 package utxo
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,9 +17,11 @@ import (
 	"time"
 
 	"decred.org/dcrdex/dex"
+	dexdcr "decred.org/dcrdex/dex/networks/dcr"
 	"decred.org/dcrdex/tatanka/chain"
 	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
 	"github.com/decred/dcrd/dcrutil/v4"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 	"github.com/decred/dcrd/rpcclient/v8"
@@ -48,11 +52,13 @@ type DecredConfigFile struct {
 }
 
 type decredChain struct {
-	cfg  *DecredConfigFile
-	net  dex.Network
-	log  dex.Logger
-	fees chan uint64
+	cfg    *DecredConfigFile
+	net    dex.Network
+	params *chaincfg.Params
+	log    dex.Logger
+	fees   chan uint64
 
+	ctx       context.Context
 	cl        *rpcclient.Client
 	connected atomic.Bool
 }
@@ -62,15 +68,28 @@ func NewDecred(rawConfig json.RawMessage, log dex.Logger, net dex.Network) (chai
 	if err := json.Unmarshal(rawConfig, &cfg); err != nil {
 		return nil, fmt.Errorf("error parsing configuration: %w", err)
 	}
+	var params *chaincfg.Params
+	switch net {
+	case dex.Mainnet:
+		params = chaincfg.MainNetParams()
+	case dex.Testnet:
+		params = chaincfg.TestNet3Params()
+	case dex.Regtest: // dex.Simnet
+		params = chaincfg.SimNetParams()
+	default:
+		return nil, fmt.Errorf("unknown network %d", net)
+	}
 	return &decredChain{
-		cfg:  &cfg,
-		net:  net,
-		log:  log,
-		fees: make(chan uint64, 1),
+		cfg:    &cfg,
+		net:    net,
+		params: params,
+		log:    log,
+		fees:   make(chan uint64, 1),
 	}, nil
 }
 
 func (c *decredChain) Connect(ctx context.Context) (_ *sync.WaitGroup, err error) {
+	c.ctx = ctx
 	cfg := c.cfg
 	if cfg.NodeRelay == "" {
 		c.cl, err = connectNodeRPC(cfg.RPCListen, cfg.RPCUser, cfg.RPCPass, cfg.RPCCert)
@@ -250,11 +269,90 @@ func (c *decredChain) CheckBond(b *tanka.Bond) error {
 	return nil
 }
 
-func (c *decredChain) AuditHTLC(*tanka.HTLCAudit) (bool, error) {
+// AuditHTLC checks that the contract is the output's script, and whether the
+// output has been spent by a redemption or a refund. Spends are searched for
+// in blocks starting at the contract's block.
+func (c *decredChain) AuditHTLC(audit *tanka.HTLCAudit) (*tanka.HTLCStatus, error) {
+	txHashB, vout, err := decodeCoinID(audit.CoinID)
+	if err != nil {
+		return nil, err
+	}
+	txHash := chainhash.Hash(txHashB)
+
+	ctx, cancel := context.WithTimeout(c.ctx, auditTimeout)
+	defer cancel()
+
+	tx, err := c.cl.GetRawTransactionVerbose(ctx, &txHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract transaction %s: %w", txHash, err)
+	}
+	if tx.BlockHash == "" {
+		return nil, fmt.Errorf("contract transaction %s is not mined", txHash)
+	}
+	if int(vout) >= len(tx.Vout) {
+		return nil, fmt.Errorf("contract transaction %s has no output %d", txHash, vout)
+	}
+	out := tx.Vout[vout]
+	pkScript, err := hex.DecodeString(out.ScriptPubKey.Hex)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding output script: %w", err)
+	}
+	scriptHash := dexdcr.ExtractScriptHash(out.ScriptPubKey.Version, pkScript)
+	if !bytes.Equal(scriptHash, dcrutil.Hash160(audit.Contract)) {
+		return nil, fmt.Errorf("output %s:%d does not pay to the contract", txHash, vout)
+	}
+	_, receiver, lockTime, secretHash, err := dexdcr.ExtractSwapDetails(audit.Contract, c.params)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing contract: %w", err)
+	}
+	value, err := dcrutil.NewAmount(out.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing output value: %w", err)
+	}
+	status := &tanka.HTLCStatus{
+		Recipient:  receiver.String(),
+		Value:      uint64(value),
+		SecretHash: secretHash,
+		LockTime:   time.Unix(int64(lockTime), 0),
+		BlockTime:  time.Unix(tx.Blocktime, 0),
+	}
 
-	// TODO: Perform the audit
+	// gettxout returns null for a spent output.
+	txOut, err := c.cl.GetTxOut(ctx, &txHash, vout, wire.TxTreeRegular, true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting contract output: %w", err)
+	}
+	if txOut != nil {
+		return status, nil
+	}
 
-	return true, nil
+	tipHeight, err := c.cl.GetBlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tip height: %w", err)
+	}
+	for height := tx.BlockHeight; height <= tipHeight; height++ {
+		blockHash, err := c.cl.GetBlockHash(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("error getting block hash at height %d: %w", height, err)
+		}
+		blk, err := c.cl.GetBlock(ctx, blockHash)
+		if err != nil {
+			return nil, fmt.Errorf("error getting block %s: %w", blockHash, err)
+		}
+		for _, spender := range blk.Transactions {
+			for _, in := range spender.TxIn {
+				if in.PreviousOutPoint.Hash != txHash || in.PreviousOutPoint.Index != vout {
+					continue
+				}
+				setSpender(status, func() ([]byte, error) {
+					return dexdcr.FindKeyPush(0, in.SignatureScript, scriptHash, c.params)
+				})
+				return status, nil
+			}
+		}
+	}
+	// Spent in mempool.
+	return status, nil
 }
 
 // connectNodeRPC attempts to create a new websocket connection to a dcrd node
@@ -0,0 +1,42 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package utxo
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+// auditTimeout is the timeout for an HTLC audit, which may require scanning
+// blocks for a spending transaction.
+const auditTimeout = time.Minute
+
+// decodeCoinID decodes a coin ID of the form transaction hash || output index.
+func decodeCoinID(coinID []byte) (txHash [32]byte, vout uint32, err error) {
+	if len(coinID) != 36 {
+		return txHash, 0, fmt.Errorf("coin ID wrong length. expected 36, got %d", len(coinID))
+	}
+	copy(txHash[:], coinID[:32])
+	return txHash, binary.BigEndian.Uint32(coinID[32:]), nil
+}
+
+func sha256Hash(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+// setSpender sets the status for a spent contract. If the spending input
+// reveals the secret, the contract was redeemed, otherwise it was refunded.
+func setSpender(status *tanka.HTLCStatus, findKeyPush func() ([]byte, error)) {
+	if secret, err := findKeyPush(); err == nil {
+		status.Redeemed = true
+		status.Secret = secret
+		return
+	}
+	status.Refunded = true
+}
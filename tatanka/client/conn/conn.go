@@ -20,7 +20,6 @@ import (
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/dexnet"
 	"decred.org/dcrdex/dex/msgjson"
-	"decred.org/dcrdex/tatanka"
 	"decred.org/dcrdex/tatanka/mj"
 	"decred.org/dcrdex/tatanka/tanka"
 	"decred.org/dcrdex/tatanka/tcp"
@@ -32,6 +31,7 @@ const (
 	ErrPeerNeedsReconnect = dex.ErrorKind("peer needs reconnect")
 	ErrTankaError         = dex.ErrorKind("tanka error")
 	ErrBadPeerResponse    = dex.ErrorKind("bad peer response")
+	ErrNotConnected       = dex.ErrorKind("not connected to peer")
 )
 
 // NetworkBackend represents a peer's communication protocol.
@@ -375,12 +375,13 @@ func (c *MeshConn) handleTankagram(tankagram *msgjson.Message, sendResponse func
 		return
 	}
 
-	// If the payload cannot be decrypted with the ephemeral shared key,
-	// let the peer know that a shared key must be established before
-	// we can communicate.
+	// If the payload cannot be decrypted with the ephemeral shared key, the
+	// peer may have restarted and be establishing a new shared key. If not,
+	// handleNewPeerTankagram will let the peer know that a shared key must be
+	// established before we can communicate.
 	decryptedPayload, err := decryptTankagramPayload(p.ephemeralSharedKey, gram.EncryptedPayload)
 	if err != nil {
-		sendError(mj.TEDecryptionFailed, p.ephemeralSharedKey)
+		c.handleNewPeerTankagram(&gram, tankagram.ID, sendResponse)
 		return
 	}
 
@@ -472,8 +473,8 @@ func (c *MeshConn) runNodeMaintenance(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
-func (c *MeshConn) getNodeInfo(ctx context.Context, url string) (*tatanka.NodeInfoResponse, error) {
-	var resp tatanka.NodeInfoResponse
+func (c *MeshConn) getNodeInfo(ctx context.Context, url string) (*mj.NodeInfoResponse, error) {
+	var resp mj.NodeInfoResponse
 	if err := dexnet.Get(ctx, fmt.Sprintf("%s/%s", url, mj.RouteNodeInfo), &resp); err != nil {
 		return nil, fmt.Errorf("error getting node info from node: %w", err)
 	}
@@ -899,6 +900,18 @@ func (c *MeshConn) RequestMesh(msg *msgjson.Message, thing any, opts ...RequestO
 	return nil
 }
 
+// NotifyMesh sends a notification to the primary tatanka node.
+func (c *MeshConn) NotifyMesh(msg *msgjson.Message) error {
+	c.nodesMtx.RLock()
+	primaryNode := c.primaryNode
+	c.nodesMtx.RUnlock()
+	if primaryNode == nil {
+		return errors.New("not connected to any tatanka nodes")
+	}
+	mj.SignMessage(c.priv, msg)
+	return primaryNode.Send(msg)
+}
+
 func (c *MeshConn) requestTT(tt NetworkBackend, msg *msgjson.Message, thing any, timeout time.Duration) (err error) {
 	errChan := make(chan error)
 	if err := tt.Request(msg, func(msg *msgjson.Message) {
@@ -1017,7 +1030,7 @@ func (c *MeshConn) RequestPeer(peerID tanka.PeerID, msg *msgjson.Message, thing
 	p, known := c.peers[peerID]
 	c.peersMtx.RUnlock()
 	if !known {
-		return fmt.Errorf("not connected to peer %s", peerID)
+		return fmt.Errorf("%w %s", ErrNotConnected, peerID)
 	}
 
 	payload, err := json.Marshal(msg)
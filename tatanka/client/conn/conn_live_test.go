@@ -59,11 +59,11 @@ var (
 
 // ### Helper Functions
 
-func newBootNode(addr string, peerID []byte) tatanka.BootNode {
+func newBootNode(addr string, peerID []byte) mj.BootNode {
 	tcpCfg, _ := json.Marshal(&tcp.RemoteNodeConfig{
 		URL: "ws://" + addr,
 	})
-	return tatanka.BootNode{
+	return mj.BootNode{
 		Protocol: "ws",
 		Config:   tcpCfg,
 		PeerID:   peerID,
@@ -146,7 +146,7 @@ func runServer(t *testing.T, ctx context.Context, thisNode *tTatankaNodeInfo, ot
 	os.MkdirAll(dir, 0755)
 	os.WriteFile(filepath.Join(dir, "priv.key"), thisNode.priv.Serialize(), 0644)
 
-	whiteList := make([]tatanka.BootNode, 0, len(otherNodes))
+	whiteList := make([]mj.BootNode, 0, len(otherNodes))
 	for _, node := range otherNodes {
 		whiteList = append(whiteList, newBootNode(node.addr.String(), node.peerID[:]))
 	}
@@ -12,12 +12,14 @@ import (
 	"sync"
 	"time"
 
+	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/feerates"
 	"decred.org/dcrdex/dex/fiatrates"
 	"decred.org/dcrdex/dex/lexi"
 	"decred.org/dcrdex/dex/msgjson"
 	"decred.org/dcrdex/tatanka/client/conn"
+	"decred.org/dcrdex/tatanka/client/orderbook"
 	"decred.org/dcrdex/tatanka/mj"
 	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -29,6 +31,17 @@ type Config struct {
 	PrivateKey *secp256k1.PrivateKey
 	Logger     dex.Logger
 	EntryNode  *TatankaCredentials
+	// Net is used to determine swap contract lock times.
+	Net dex.Network
+	// Wallets provides the wallets used for swaps. Matches are not accepted
+	// or proposed if Wallets is nil.
+	Wallets func(assetID uint32) (asset.Wallet, error)
+	// SwapConfs is the number of confirmations required on a counterparty's
+	// swap contract. Default DefaultSwapConfs.
+	SwapConfs uint32
+	// PeerTimeout is how long to wait for a counterparty to act when none of
+	// our funds are locked in a swap contract. Default DefaultPeerTimeout.
+	PeerTimeout time.Duration
 }
 
 // Mesh is a manager for operations on the Tatanka Mesh Network.
@@ -46,6 +59,7 @@ type Mesh struct {
 	db        *lexi.DB
 	dbCM      *dex.ConnectionMaster
 	bondTable *lexi.Table
+	swapper   *swapper
 
 	marketsMtx sync.RWMutex
 	markets    map[string]*market
@@ -75,12 +89,29 @@ func New(cfg *Config) (*Mesh, error) {
 		payloads:  make(chan interface{}, 128),
 		markets:   make(map[string]*market),
 		fiatRates: make(map[string]*fiatrates.FiatRateInfo),
+
+		feeRateEstimates: make(map[uint32]*feerates.Estimate),
 	}
 
 	if err := mesh.initializeDB(); err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
+	var err error
+	mesh.swapper, err = newSwapper(&swapperConfig{
+		log:         cfg.Logger.SubLogger("SWAP"),
+		priv:        cfg.PrivateKey,
+		db:          mesh.db,
+		net:         cfg.Net,
+		wallets:     cfg.Wallets,
+		feeRate:     mesh.FeeRateEstimate,
+		swapConfs:   cfg.SwapConfs,
+		peerTimeout: cfg.PeerTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize swapper: %w", err)
+	}
+
 	return mesh, nil
 }
 
@@ -111,6 +142,19 @@ func (m *Mesh) Connect(ctx context.Context) (*sync.WaitGroup, error) {
 	}
 
 	m.conn = &meshConn{mesh, meshCM}
+	m.swapper.comms = m.conn
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.swapper.run(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.runOrderMaintenance(ctx)
+	}()
 
 	wg.Add(1)
 	go func() {
@@ -179,6 +223,8 @@ func (m *Mesh) handlePeerRequest(peerID tanka.PeerID, route string, payload json
 	case mj.RouteNegotiate:
 		// TODO: Reputation check.
 		m.handleNegotiate(peerID, payload, respond)
+	case mj.RouteSwapAddress, mj.RouteSwapContract, mj.RouteSwapRedeem:
+		m.swapper.handlePeerMessage(peerID, route, payload, respond)
 	default:
 		m.log.Debugf("Received a peer request for an unknown route %q", route)
 	}
@@ -257,12 +303,101 @@ func (m *Mesh) SubscribeMarket(baseID, quoteID uint32) error {
 		baseID:  baseID,
 		quoteID: quoteID,
 		conn:    m.conn,
+		swapper: m.swapper,
 		ords:    make(map[tanka.ID40]*order),
+		book:    orderbook.New(),
+		feeRate: m.FeeRateEstimate,
+		broadcast: func(msgType mj.BroadcastMessageType, thing any) error {
+			return m.Broadcast(mj.TopicMarket, tanka.Subject(mktName), msgType, thing)
+		},
 	}
 
 	return nil
 }
 
+// PlaceOrder proposes matches with compatible orders on the book, and
+// broadcasts the order to the market. Accepted matches are swapped. If the
+// order has no expiration, one is set. The order is refreshed until it is
+// filled, or until the mesh oracle fee rate rises above the order's max fee
+// rate.
+func (m *Mesh) PlaceOrder(ord *tanka.Order) error {
+	if ord.From != m.peerID {
+		return fmt.Errorf("order is from %s, not us", ord.From)
+	}
+	if ord.Expiration.IsZero() {
+		ord.Expiration = time.Now().Add(orderLifetime)
+	}
+	mkt, mktName, err := m.market(ord.BaseID, ord.QuoteID)
+	if err != nil {
+		return err
+	}
+	if err := mkt.checkOrder(ord, time.Now()); err != nil {
+		return fmt.Errorf("invalid order: %w", err)
+	}
+	mkt.addOwnOrder(ord)
+	return m.Broadcast(mj.TopicMarket, tanka.Subject(mktName), mj.MessageTypeNewOrder, ord)
+}
+
+// CancelOrder unbooks one of our orders on a subscribed market. Matches that
+// were already accepted are still swapped.
+func (m *Mesh) CancelOrder(baseID, quoteID uint32, oid tanka.ID40) error {
+	mkt, _, err := m.market(baseID, quoteID)
+	if err != nil {
+		return err
+	}
+	return mkt.cancelOrder(oid, time.Now())
+}
+
+// market returns a subscribed market and its name.
+func (m *Mesh) market(baseID, quoteID uint32) (*market, string, error) {
+	mktName, err := dex.MarketName(baseID, quoteID)
+	if err != nil {
+		return nil, "", fmt.Errorf("error constructing market name: %w", err)
+	}
+	m.marketsMtx.RLock()
+	mkt, found := m.markets[mktName]
+	m.marketsMtx.RUnlock()
+	if !found {
+		return nil, "", fmt.Errorf("not subscribed to market %s", mktName)
+	}
+	return mkt, mktName, nil
+}
+
+// Book returns the other peers' orders on a subscribed market, best first.
+func (m *Mesh) Book(baseID, quoteID uint32) (buys, sells []*tanka.Order, err error) {
+	mkt, _, err := m.market(baseID, quoteID)
+	if err != nil {
+		return nil, nil, err
+	}
+	buys, sells = mkt.bookOrders()
+	return buys, sells, nil
+}
+
+// OrderStatus is the status of one of our orders.
+type OrderStatus struct {
+	*tanka.Order
+	// Remaining is the quantity that hasn't been matched.
+	Remaining uint64
+	// Booked is false once the order is filled, or unbooked because its max
+	// fee rate is too low.
+	Booked bool
+}
+
+// Orders returns our orders on a subscribed market. Orders are forgotten a
+// while after they are unbooked.
+func (m *Mesh) Orders(baseID, quoteID uint32) ([]*OrderStatus, error) {
+	mkt, _, err := m.market(baseID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	return mkt.ownOrders(), nil
+}
+
+// Swaps returns all swaps, active and completed.
+func (m *Mesh) Swaps() ([]*Swap, error) {
+	return m.swapper.swapRecords()
+}
+
 func (m *Mesh) handleBroadcast(payload json.RawMessage) {
 	var bcast mj.Broadcast
 	if err := json.Unmarshal(payload, &bcast); err != nil {
@@ -278,8 +413,8 @@ func (m *Mesh) handleBroadcast(payload json.RawMessage) {
 }
 
 func (m *Mesh) handleNegotiate(peerID tanka.PeerID, payload json.RawMessage, respond func(any, mj.TankagramError)) {
-	var match *tanka.Match
-	if err := json.Unmarshal(payload, match); err != nil {
+	var match tanka.Match
+	if err := json.Unmarshal(payload, &match); err != nil {
 		m.log.Debugf("handleNegotiate: unable to unmarshal match from peer %v: %v", peerID, err)
 		respond(false, mj.TEEBadRequest)
 		return
@@ -298,7 +433,7 @@ func (m *Mesh) handleNegotiate(peerID tanka.PeerID, payload json.RawMessage, res
 		respond(false, mj.TEEPeerError)
 		return
 	}
-	respond(market.handleNegotiate(match), mj.TEErrNone)
+	respond(market.handleNegotiate(peerID, &match), mj.TEErrNone)
 }
 
 func (m *Mesh) handleRates(payload json.RawMessage) {
@@ -0,0 +1,1002 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package mesh
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/lexi"
+	"decred.org/dcrdex/dex/msgjson"
+	dexorder "decred.org/dcrdex/dex/order"
+	"decred.org/dcrdex/tatanka/client/conn"
+	"decred.org/dcrdex/tatanka/mj"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+const (
+	// swapTick is how often the active swaps are checked for actions in the
+	// absence of messages from the counterparty.
+	swapTick = 15 * time.Second
+	// DefaultSwapConfs is the number of confirmations required on a
+	// counterparty's swap contract before acting on it, if not configured.
+	DefaultSwapConfs = 1
+	// DefaultPeerTimeout is how long to wait for a counterparty to act when
+	// none of our funds are locked in a contract, if not configured.
+	DefaultPeerTimeout = time.Hour
+
+	// Scores self-reported to the mesh for swap outcomes.
+	scoreSwapSuccess int8 = 1
+	scoreSwapFailure int8 = -1
+)
+
+// SwapContract is a swap contract broadcast by one of the parties.
+type SwapContract struct {
+	CoinID   dex.Bytes `json:"coinID"`
+	Contract dex.Bytes `json:"contract"`
+	// Expiration is the contract's lock time expiration. For the
+	// counterparty's contract, it is set when the contract is audited.
+	Expiration time.Time `json:"expiration,omitempty"`
+}
+
+// Swap is the state of an atomic swap with a mesh peer. The party whose order
+// was matched initiates the swap as the maker. The party that proposed the
+// match participates as the taker. Status progresses through the
+// dexorder.MatchStatus values from NewlyMatched to MakerRedeemed for the maker,
+// and to MatchComplete for the taker.
+type Swap struct {
+	Match *tanka.Match `json:"match"`
+	// OrderID is the ID of our order.
+	OrderID tanka.ID40         `json:"orderID"`
+	Peer    tanka.PeerID       `json:"peer"`
+	Side    dexorder.MatchSide `json:"side"`
+	// Sell is true if we are selling the base asset.
+	Sell   bool                 `json:"sell"`
+	Rate   uint64               `json:"rate"`
+	Status dexorder.MatchStatus `json:"status"`
+	// Secret is generated by the maker, and learned by the taker when the
+	// maker redeems.
+	Secret     dex.Bytes `json:"secret,omitempty"`
+	SecretHash dex.Bytes `json:"secretHash,omitempty"`
+	// Address is where we receive the counterparty's swap. PeerAddress is
+	// where we send ours.
+	Address      string        `json:"address"`
+	PeerAddress  string        `json:"peerAddress,omitempty"`
+	Contract     *SwapContract `json:"contract,omitempty"`
+	PeerContract *SwapContract `json:"peerContract,omitempty"`
+	// PeerCommitment is the counterparty's signed commitment, which is
+	// submitted to the mesh in a dispute if the swap fails.
+	PeerCommitment *mj.SwapCommitment `json:"peerCommitment,omitempty"`
+	// PeerAudited is true when the counterparty's contract has passed audit.
+	PeerAudited bool      `json:"peerAudited"`
+	RedeemCoin  dex.Bytes `json:"redeemCoin,omitempty"`
+	RefundCoin  dex.Bytes `json:"refundCoin,omitempty"`
+	// Notified is true when the counterparty has acknowledged the message for
+	// our last action.
+	Notified bool `json:"notified"`
+	// Revoked is set if the swap is abandoned before any of our funds were
+	// locked.
+	Revoked  bool   `json:"revoked"`
+	Refunded bool   `json:"refunded"`
+	Reported bool   `json:"reported"`
+	Error    string `json:"error,omitempty"`
+	// LastAction is the time of the last status change.
+	LastAction time.Time `json:"lastAction"`
+}
+
+// ID is the match ID.
+func (s *Swap) ID() tanka.ID32 {
+	return s.Match.ID()
+}
+
+// Active is true if the swap requires further action.
+func (s *Swap) Active() bool {
+	switch {
+	case s.Revoked, s.Refunded:
+		return false
+	case s.Side == dexorder.Maker:
+		return s.Status < dexorder.MakerRedeemed || !s.Notified
+	default:
+		return s.Status < dexorder.MatchComplete
+	}
+}
+
+func (s *Swap) fromAsset() uint32 {
+	if s.Sell {
+		return s.Match.BaseID
+	}
+	return s.Match.QuoteID
+}
+
+func (s *Swap) toAsset() uint32 {
+	if s.Sell {
+		return s.Match.QuoteID
+	}
+	return s.Match.BaseID
+}
+
+// swapValue is the value of our contract.
+func (s *Swap) swapValue() uint64 {
+	if s.Sell {
+		return s.Match.Qty
+	}
+	return calc.BaseToQuote(s.Rate, s.Match.Qty)
+}
+
+// peerValue is the value of the counterparty's contract.
+func (s *Swap) peerValue() uint64 {
+	if s.Sell {
+		return calc.BaseToQuote(s.Rate, s.Match.Qty)
+	}
+	return s.Match.Qty
+}
+
+func (s *Swap) setStatus(status dexorder.MatchStatus) {
+	s.Status = status
+	s.Notified = false
+	s.LastAction = time.Now()
+}
+
+// swapComms is the messaging needed to carry out swaps. *meshConn satisfies
+// swapComms.
+type swapComms interface {
+	ConnectPeer(peerID tanka.PeerID) error
+	RequestPeer(peerID tanka.PeerID, msg *msgjson.Message, thing interface{}) error
+	NotifyMesh(msg *msgjson.Message) error
+}
+
+// swapTracker wraps a Swap with a mutex. The mutex is not held while messaging
+// the counterparty.
+type swapTracker struct {
+	mtx sync.Mutex
+	*Swap
+	findingRedemption bool
+}
+
+// swapper carries out swaps with mesh peers, driving the asset wallets through
+// the HTLC lifecycle.
+type swapper struct {
+	log         dex.Logger
+	priv        *secp256k1.PrivateKey
+	id          tanka.PeerID
+	comms       swapComms
+	table       *lexi.Table
+	wallets     func(assetID uint32) (asset.Wallet, error)
+	feeRate     func(chainID uint32) uint64
+	swapConfs   uint32
+	peerTimeout time.Duration
+	lockTimes   map[dexorder.MatchSide]time.Duration
+
+	ctx   context.Context
+	wg    sync.WaitGroup
+	check chan struct{}
+
+	mtx   sync.RWMutex
+	swaps map[tanka.ID32]*swapTracker
+}
+
+type swapperConfig struct {
+	log         dex.Logger
+	priv        *secp256k1.PrivateKey
+	comms       swapComms
+	db          *lexi.DB
+	net         dex.Network
+	wallets     func(assetID uint32) (asset.Wallet, error)
+	feeRate     func(chainID uint32) uint64
+	swapConfs   uint32
+	peerTimeout time.Duration
+}
+
+func newSwapper(cfg *swapperConfig) (*swapper, error) {
+	table, err := cfg.db.Table("swaps")
+	if err != nil {
+		return nil, err
+	}
+	swapConfs := cfg.swapConfs
+	if swapConfs == 0 {
+		swapConfs = DefaultSwapConfs
+	}
+	peerTimeout := cfg.peerTimeout
+	if peerTimeout == 0 {
+		peerTimeout = DefaultPeerTimeout
+	}
+	var id tanka.PeerID
+	copy(id[:], cfg.priv.PubKey().SerializeCompressed())
+	return &swapper{
+		log:         cfg.log,
+		priv:        cfg.priv,
+		id:          id,
+		comms:       cfg.comms,
+		table:       table,
+		wallets:     cfg.wallets,
+		feeRate:     cfg.feeRate,
+		swapConfs:   swapConfs,
+		peerTimeout: peerTimeout,
+		lockTimes: map[dexorder.MatchSide]time.Duration{
+			dexorder.Maker: dex.LockTimeMaker(cfg.net),
+			dexorder.Taker: dex.LockTimeTaker(cfg.net),
+		},
+		ctx:   context.Background(),
+		check: make(chan struct{}, 1),
+		swaps: make(map[tanka.ID32]*swapTracker),
+	}, nil
+}
+
+// run loads the active swaps from the database and processes them until the
+// context is canceled.
+func (s *swapper) run(ctx context.Context) {
+	s.mtx.Lock()
+	s.ctx = ctx
+	s.mtx.Unlock()
+
+	if err := s.table.Iterate(nil, func(it *lexi.Iter) error {
+		sw := new(Swap)
+		if err := it.V(func(vB []byte) error {
+			return json.Unmarshal(vB, sw)
+		}); err != nil {
+			return err
+		}
+		if sw.Active() {
+			s.mtx.Lock()
+			s.swaps[sw.ID()] = &swapTracker{Swap: sw}
+			s.mtx.Unlock()
+		}
+		return nil
+	}); err != nil {
+		s.log.Errorf("Error loading swaps: %v", err)
+	}
+
+	tick := time.NewTicker(swapTick)
+	defer tick.Stop()
+	for {
+		s.processSwaps(ctx)
+		select {
+		case <-tick.C:
+		case <-s.check:
+		case <-ctx.Done():
+			s.wg.Wait()
+			return
+		}
+	}
+}
+
+func (s *swapper) recheck() {
+	select {
+	case s.check <- struct{}{}:
+	default:
+	}
+}
+
+func (s *swapper) runContext() context.Context {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.ctx
+}
+
+func (s *swapper) store(sw *Swap) error {
+	id := sw.ID()
+	return s.table.Set(id[:], lexi.JSON(sw), lexi.WithReplace())
+}
+
+// newSwap starts tracking a negotiated match. For the maker, the secret is
+// generated here. For the taker, the swap_address message is sent on the next
+// check.
+func (s *swapper) newSwap(match *tanka.Match, peer tanka.PeerID, oid tanka.ID40, side dexorder.MatchSide, sell bool, rate uint64) error {
+	if s.wallets == nil {
+		return errors.New("no wallets configured")
+	}
+	sw := &Swap{
+		Match:      match,
+		OrderID:    oid,
+		Peer:       peer,
+		Side:       side,
+		Sell:       sell,
+		Rate:       rate,
+		Status:     dexorder.NewlyMatched,
+		LastAction: time.Now(),
+	}
+	for _, assetID := range []uint32{sw.fromAsset(), sw.toAsset()} {
+		if _, err := s.wallets(assetID); err != nil {
+			return fmt.Errorf("no %s wallet: %w", dex.BipIDSymbol(assetID), err)
+		}
+	}
+	toWallet, _ := s.wallets(sw.toAsset())
+	addr, err := toWallet.RedemptionAddress()
+	if err != nil {
+		return fmt.Errorf("error getting %s redemption address: %w", dex.BipIDSymbol(sw.toAsset()), err)
+	}
+	sw.Address = addr
+	if side == dexorder.Maker {
+		sw.Secret = encode.RandomBytes(32)
+		secretHash := sha256.Sum256(sw.Secret)
+		sw.SecretHash = secretHash[:]
+	}
+	if err := s.store(sw); err != nil {
+		return fmt.Errorf("error storing swap: %w", err)
+	}
+	s.mtx.Lock()
+	s.swaps[sw.ID()] = &swapTracker{Swap: sw}
+	s.mtx.Unlock()
+	s.recheck()
+	return nil
+}
+
+func (s *swapper) processSwaps(ctx context.Context) {
+	s.mtx.RLock()
+	trackers := make([]*swapTracker, 0, len(s.swaps))
+	for _, t := range s.swaps {
+		trackers = append(trackers, t)
+	}
+	s.mtx.RUnlock()
+
+	for _, t := range trackers {
+		if ctx.Err() != nil {
+			return
+		}
+		if active := s.processSwap(ctx, t); !active {
+			s.mtx.Lock()
+			delete(s.swaps, t.ID())
+			s.mtx.Unlock()
+		}
+	}
+}
+
+// processSwap takes any actions required for the swap, and sends the
+// counterparty the message for our last action if it has not been
+// acknowledged.
+func (s *swapper) processSwap(ctx context.Context, t *swapTracker) (active bool) {
+	t.mtx.Lock()
+	if err := s.advance(ctx, t); err != nil {
+		s.log.Errorf("Error processing swap %s: %v", t.ID(), err)
+		t.Error = err.Error()
+	}
+	msg := s.swapMessage(t.Swap)
+	status, peer, matchID := t.Status, t.Peer, t.ID()
+	if err := s.store(t.Swap); err != nil {
+		s.log.Errorf("Error storing swap %s: %v", matchID, err)
+	}
+	t.mtx.Unlock()
+
+	if msg != nil {
+		var ok bool
+		if err := s.requestPeer(peer, msg, &ok); err != nil || !ok {
+			s.log.Warnf("Error sending %s for swap %s to %s: ok = %t, err = %v", msg.Route, matchID, peer, ok, err)
+		} else {
+			t.mtx.Lock()
+			if t.Status == status {
+				t.Notified = true
+				if err := s.store(t.Swap); err != nil {
+					s.log.Errorf("Error storing swap %s: %v", matchID, err)
+				}
+			}
+			t.mtx.Unlock()
+		}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return t.Active()
+}
+
+// swapMessage is the message for our last action, if the counterparty has not
+// acknowledged it yet.
+func (s *swapper) swapMessage(sw *Swap) *msgjson.Message {
+	if sw.Notified || sw.Revoked || sw.Refunded {
+		return nil
+	}
+	matchID := sw.ID()
+	switch {
+	case sw.Side == dexorder.Taker && sw.Status == dexorder.NewlyMatched:
+		return mj.MustRequest(mj.RouteSwapAddress, &mj.SwapAddress{
+			MatchID:    matchID,
+			Address:    sw.Address,
+			Commitment: s.commitment(sw),
+		})
+	case sw.Side == dexorder.Maker && sw.Status == dexorder.MakerSwapCast:
+		return mj.MustRequest(mj.RouteSwapContract, &mj.SwapContract{
+			MatchID:    matchID,
+			CoinID:     sw.Contract.CoinID,
+			Contract:   sw.Contract.Contract,
+			Address:    sw.Address,
+			Commitment: s.commitment(sw),
+		})
+	case sw.Side == dexorder.Taker && sw.Status == dexorder.TakerSwapCast:
+		return mj.MustRequest(mj.RouteSwapContract, &mj.SwapContract{
+			MatchID:  matchID,
+			CoinID:   sw.Contract.CoinID,
+			Contract: sw.Contract.Contract,
+		})
+	case sw.Side == dexorder.Maker && sw.Status == dexorder.MakerRedeemed:
+		return mj.MustRequest(mj.RouteSwapRedeem, &mj.SwapRedeem{
+			MatchID: matchID,
+			CoinID:  sw.RedeemCoin,
+			Secret:  sw.Secret,
+		})
+	}
+	return nil
+}
+
+// commitment is our signed commitment to the counterparty. The taker commits
+// before the maker's swap, so the taker's lock time is the maker's required
+// lock time, and the deadline is when the taker may revoke the swap. The
+// maker commits with its own swap.
+func (s *swapper) commitment(sw *Swap) *mj.SwapCommitment {
+	c := &mj.SwapCommitment{
+		MatchID:      sw.ID(),
+		Signer:       s.id,
+		Counterparty: sw.Peer,
+		Maker:        sw.Side == dexorder.Maker,
+		AssetID:      sw.toAsset(),
+		Address:      sw.Address,
+		Value:        sw.peerValue(),
+	}
+	if c.Maker {
+		c.SecretHash = sw.SecretHash
+		c.LockTime = sw.Contract.Expiration
+	} else {
+		c.LockTime = sw.Match.Stamp.Add(s.lockTimes[dexorder.Maker])
+		c.Deadline = sw.Match.Stamp.Add(s.peerTimeout)
+	}
+	c.Sign(s.priv)
+	return c
+}
+
+// checkCommitment checks the counterparty's commitment, which must be
+// consistent with the swap and the address they sent.
+func (s *swapper) checkCommitment(sw *Swap, c *mj.SwapCommitment, addr string) error {
+	switch {
+	case c == nil:
+		return errors.New("no commitment")
+	case c.MatchID != sw.ID():
+		return errors.New("commitment is for the wrong match")
+	case c.Signer != sw.Peer || c.Counterparty != s.id:
+		return errors.New("commitment is for the wrong parties")
+	case c.Maker != (sw.Side == dexorder.Taker):
+		return errors.New("commitment is for the wrong side")
+	case c.AssetID != sw.fromAsset() || c.Address != addr:
+		return errors.New("commitment is for the wrong address")
+	case c.Value > sw.swapValue():
+		return fmt.Errorf("commitment value %d is more than our swap value %d", c.Value, sw.swapValue())
+	}
+	if c.Maker {
+		if len(c.SecretHash) != sha256.Size {
+			return fmt.Errorf("invalid commitment secret hash length %d", len(c.SecretHash))
+		}
+	} else if c.LockTime.After(sw.Match.Stamp.Add(s.lockTimes[dexorder.Maker])) {
+		return fmt.Errorf("commitment lock time %s is too late", c.LockTime)
+	}
+	return c.Verify()
+}
+
+// dispute submits the counterparty's commitment and the contract that they
+// failed to act on to the mesh, which audits the contract on-chain.
+func (s *swapper) dispute(sw *Swap, c *SwapContract, assetID uint32) {
+	if sw.PeerCommitment == nil {
+		return
+	}
+	note := mj.MustNotification(mj.RouteDispute, &mj.Dispute{
+		Commitment: sw.PeerCommitment,
+		Contract: &tanka.HTLCAudit{
+			AssetID:  assetID,
+			CoinID:   c.CoinID,
+			Contract: c.Contract,
+		},
+	})
+	if err := s.comms.NotifyMesh(note); err != nil {
+		s.log.Errorf("Error disputing swap %s: %v", sw.ID(), err)
+	}
+}
+
+// requestPeer sends a request to the peer, connecting first if we are not
+// connected or the peer has lost our encryption key.
+func (s *swapper) requestPeer(peer tanka.PeerID, msg *msgjson.Message, thing any) error {
+	err := s.comms.RequestPeer(peer, msg, thing)
+	if errors.Is(err, conn.ErrNotConnected) || errors.Is(err, conn.ErrPeerNeedsReconnect) {
+		if err := s.comms.ConnectPeer(peer); err != nil {
+			return err
+		}
+		err = s.comms.RequestPeer(peer, msg, thing)
+	}
+	return err
+}
+
+// advance takes the next action in the swap, if one is required. The
+// swapTracker mutex must be held.
+func (s *swapper) advance(ctx context.Context, t *swapTracker) error {
+	sw := t.Swap
+	if !sw.Active() {
+		return nil
+	}
+	fromWallet, err := s.wallets(sw.fromAsset())
+	if err != nil {
+		return err
+	}
+	toWallet, err := s.wallets(sw.toAsset())
+	if err != nil {
+		return err
+	}
+	timedOut := time.Since(sw.LastAction) > s.peerTimeout
+
+	if sw.Side == dexorder.Maker {
+		switch sw.Status {
+		case dexorder.NewlyMatched:
+			if sw.PeerAddress == "" {
+				if timedOut {
+					s.revoke(sw, "no address from counterparty")
+				}
+				return nil
+			}
+			// The taker can abandon the swap after its deadline, so don't
+			// lock funds that the taker won't be held responsible for.
+			if time.Now().After(sw.PeerCommitment.Deadline) {
+				s.log.Infof("Abandoning swap %s with %s: counterparty's deadline passed", sw.ID(), sw.Peer)
+				sw.Revoked = true
+				sw.Error = "counterparty's deadline passed"
+				return nil
+			}
+			return s.swap(sw, fromWallet, sw.Match.Stamp.Add(s.lockTimes[dexorder.Maker]), dexorder.MakerSwapCast)
+		case dexorder.MakerSwapCast:
+			if sw.PeerContract != nil {
+				if err := s.audit(sw, toWallet); err != nil {
+					s.log.Warnf("Audit of contract from %s for swap %s failed: %v", sw.Peer, sw.ID(), err)
+				} else {
+					sw.setStatus(dexorder.TakerSwapCast)
+					return s.redeemIfConfirmed(ctx, sw, toWallet)
+				}
+			}
+			return s.refundIfExpired(ctx, sw, fromWallet)
+		case dexorder.TakerSwapCast:
+			// If the taker refunds before we redeem, we refund too.
+			err := s.redeemIfConfirmed(ctx, sw, toWallet)
+			if sw.Status != dexorder.TakerSwapCast {
+				return err
+			}
+			if err != nil {
+				s.log.Warnf("Unable to redeem swap %s: %v", sw.ID(), err)
+			}
+			return s.refundIfExpired(ctx, sw, fromWallet)
+		case dexorder.MakerRedeemed:
+			// The taker can find the secret on-chain, so stop trying to send
+			// it eventually.
+			if timedOut {
+				sw.Notified = true
+			}
+		}
+		return nil
+	}
+
+	switch sw.Status {
+	case dexorder.NewlyMatched:
+		if sw.PeerContract != nil {
+			err = s.audit(sw, toWallet)
+			if err == nil {
+				sw.setStatus(dexorder.MakerSwapCast)
+				err = s.participateIfConfirmed(ctx, sw, fromWallet, toWallet)
+				break
+			}
+			s.log.Warnf("Audit of contract from %s for swap %s failed: %v", sw.Peer, sw.ID(), err)
+			err = nil
+		}
+		if timedOut {
+			s.revoke(sw, "no valid contract from counterparty")
+		}
+	case dexorder.MakerSwapCast:
+		err = s.participateIfConfirmed(ctx, sw, fromWallet, toWallet)
+	case dexorder.TakerSwapCast:
+		err = s.refundIfExpired(ctx, sw, fromWallet)
+	case dexorder.MakerRedeemed:
+		return s.redeem(sw, toWallet)
+	}
+	if sw.Status == dexorder.TakerSwapCast && !sw.Refunded {
+		s.findRedemption(t, fromWallet)
+	}
+	return err
+}
+
+// revoke abandons a swap in which none of our funds are locked, and reports
+// the counterparty's failure.
+func (s *swapper) revoke(sw *Swap, reason string) {
+	s.log.Infof("Revoking swap %s with %s: %s", sw.ID(), sw.Peer, reason)
+	sw.Revoked = true
+	sw.Error = reason
+	s.report(sw, scoreSwapFailure)
+}
+
+// report self-reports the swap outcome to the mesh.
+func (s *swapper) report(sw *Swap, score int8) {
+	if sw.Reported {
+		return
+	}
+	note := mj.MustNotification(mj.RouteSetScore, &mj.ScoreReport{PeerID: sw.Peer, Score: score})
+	if err := s.comms.NotifyMesh(note); err != nil {
+		s.log.Errorf("Error reporting outcome of swap %s: %v", sw.ID(), err)
+		return
+	}
+	sw.Reported = true
+}
+
+// feeChainID is the ID of the chain on which fees are paid for the asset.
+func feeChainID(assetID uint32) uint32 {
+	if tkn := asset.TokenInfo(assetID); tkn != nil {
+		return tkn.ParentID
+	}
+	return assetID
+}
+
+func (s *swapper) swapFeeRate(assetID uint32, w asset.Wallet) uint64 {
+	if s.feeRate != nil {
+		if r := s.feeRate(feeChainID(assetID)); r > 0 {
+			return r
+		}
+	}
+	if fr, ok := w.(asset.FeeRater); ok {
+		return fr.FeeRate()
+	}
+	return 0
+}
+
+func assetVersion(w asset.Wallet) uint32 {
+	var v uint32
+	for _, sv := range w.Info().SupportedVersions {
+		if sv > v {
+			v = sv
+		}
+	}
+	return v
+}
+
+// swap funds and broadcasts our swap contract.
+func (s *swapper) swap(sw *Swap, w asset.Wallet, lockTime time.Time, status dexorder.MatchStatus) error {
+	assetID := sw.fromAsset()
+	feeRate := s.swapFeeRate(assetID, w)
+	if feeRate == 0 {
+		return fmt.Errorf("no %s fee rate available", dex.BipIDSymbol(assetID))
+	}
+	val := sw.swapValue()
+	ver := assetVersion(w)
+	coins, _, _, err := w.FundOrder(&asset.Order{
+		AssetVersion:  ver,
+		Value:         val,
+		MaxSwapCount:  1,
+		MaxFeeRate:    feeRate,
+		Immediate:     true,
+		FeeSuggestion: feeRate,
+	})
+	if err != nil {
+		return fmt.Errorf("error funding %s swap: %w", dex.BipIDSymbol(assetID), err)
+	}
+	receipts, _, fees, err := w.Swap(&asset.Swaps{
+		AssetVersion: ver,
+		Inputs:       coins,
+		Contracts: []*asset.Contract{{
+			Address:    sw.PeerAddress,
+			Value:      val,
+			SecretHash: sw.SecretHash,
+			LockTime:   uint64(lockTime.Unix()),
+		}},
+		FeeRate: feeRate,
+	})
+	if err != nil {
+		if err := w.ReturnCoins(coins); err != nil {
+			s.log.Errorf("Error returning coins for failed swap %s: %v", sw.ID(), err)
+		}
+		return fmt.Errorf("error broadcasting %s swap: %w", dex.BipIDSymbol(assetID), err)
+	}
+	if len(receipts) != 1 {
+		return fmt.Errorf("expected 1 swap receipt, got %d", len(receipts))
+	}
+	r := receipts[0]
+	sw.Contract = &SwapContract{
+		CoinID:     r.Coin().ID(),
+		Contract:   r.Contract(),
+		Expiration: r.Expiration(),
+	}
+	sw.setStatus(status)
+	s.log.Infof("Broadcast %s swap contract %s for swap %s with %s. fees = %d",
+		dex.BipIDSymbol(assetID), r.Coin(), sw.ID(), sw.Peer, fees)
+	return nil
+}
+
+// audit checks the counterparty's contract.
+func (s *swapper) audit(sw *Swap, w asset.Wallet) error {
+	if sw.PeerAudited {
+		return nil
+	}
+	c := sw.PeerContract
+	ai, err := w.AuditContract(c.CoinID, c.Contract, nil, true)
+	if err != nil {
+		return err
+	}
+	if ai.Recipient != sw.Address {
+		return fmt.Errorf("contract recipient %s is not our address %s", ai.Recipient, sw.Address)
+	}
+	if v, req := ai.Coin.Value(), sw.peerValue(); v < req {
+		return fmt.Errorf("contract value %d is less than the required %d", v, req)
+	}
+	if sw.Side == dexorder.Maker {
+		if !bytes.Equal(ai.SecretHash, sw.SecretHash) {
+			return fmt.Errorf("contract secret hash %x is not our secret hash %x", ai.SecretHash, sw.SecretHash)
+		}
+		// The taker's contract must expire before ours, so we have time to
+		// refund after the taker's secret-less window closes.
+		if !ai.Expiration.Before(sw.Contract.Expiration) {
+			return fmt.Errorf("contract expiration %s is not before ours %s", ai.Expiration, sw.Contract.Expiration)
+		}
+	} else {
+		if len(ai.SecretHash) != sha256.Size {
+			return fmt.Errorf("invalid secret hash length %d", len(ai.SecretHash))
+		}
+		if minExp := time.Now().Add(s.lockTimes[dexorder.Taker]); !ai.Expiration.After(minExp) {
+			return fmt.Errorf("contract expiration %s is too soon", ai.Expiration)
+		}
+		// Our contract must expire before the maker's committed lock time for
+		// the maker to be held responsible for redeeming it.
+		if pc := sw.PeerCommitment; !bytes.Equal(ai.SecretHash, pc.SecretHash) || ai.Expiration.Unix() > pc.LockTime.Unix() {
+			return errors.New("contract does not match the counterparty's commitment")
+		}
+		sw.SecretHash = ai.SecretHash
+	}
+	c.Expiration = ai.Expiration
+	sw.PeerAudited = true
+	return nil
+}
+
+func (s *swapper) peerConfirmed(ctx context.Context, sw *Swap, w asset.Wallet) (bool, error) {
+	c := sw.PeerContract
+	confs, spent, err := w.SwapConfirmations(ctx, c.CoinID, c.Contract, sw.Match.Stamp)
+	if err != nil {
+		return false, fmt.Errorf("error checking confirmations: %w", err)
+	}
+	if spent {
+		return false, errors.New("counterparty's contract is already spent")
+	}
+	return confs >= s.swapConfs, nil
+}
+
+// participateIfConfirmed broadcasts the taker's contract once the maker's
+// contract has the required confirmations.
+func (s *swapper) participateIfConfirmed(ctx context.Context, sw *Swap, fromWallet, toWallet asset.Wallet) error {
+	confirmed, err := s.peerConfirmed(ctx, sw, toWallet)
+	if err != nil || !confirmed {
+		return err
+	}
+	lockTime := time.Now().Add(s.lockTimes[dexorder.Taker])
+	if !lockTime.Before(sw.PeerContract.Expiration) {
+		s.revoke(sw, "counterparty's contract expires too soon")
+		return nil
+	}
+	return s.swap(sw, fromWallet, lockTime, dexorder.TakerSwapCast)
+}
+
+// redeemIfConfirmed redeems the taker's contract once it has the required
+// confirmations.
+func (s *swapper) redeemIfConfirmed(ctx context.Context, sw *Swap, w asset.Wallet) error {
+	confirmed, err := s.peerConfirmed(ctx, sw, w)
+	if err != nil || !confirmed {
+		return err
+	}
+	return s.redeem(sw, w)
+}
+
+// redeem redeems the counterparty's contract.
+func (s *swapper) redeem(sw *Swap, w asset.Wallet) error {
+	assetID := sw.toAsset()
+	c := sw.PeerContract
+	ai, err := w.AuditContract(c.CoinID, c.Contract, nil, false)
+	if err != nil {
+		return fmt.Errorf("error auditing contract for redemption: %w", err)
+	}
+	_, out, fees, err := w.Redeem(&asset.RedeemForm{
+		Redemptions:   []*asset.Redemption{{Spends: ai, Secret: sw.Secret}},
+		FeeSuggestion: s.swapFeeRate(assetID, w),
+	})
+	if err != nil {
+		return fmt.Errorf("error redeeming %s contract: %w", dex.BipIDSymbol(assetID), err)
+	}
+	sw.RedeemCoin = out.ID()
+	if sw.Side == dexorder.Maker {
+		sw.setStatus(dexorder.MakerRedeemed)
+	} else {
+		sw.setStatus(dexorder.MatchComplete)
+	}
+	s.log.Infof("Redeemed %s contract for swap %s with %s in %s. fees = %d",
+		dex.BipIDSymbol(assetID), sw.ID(), sw.Peer, out, fees)
+	s.report(sw, scoreSwapSuccess)
+	return nil
+}
+
+// refundIfExpired refunds our contract once its lock time has expired.
+func (s *swapper) refundIfExpired(ctx context.Context, sw *Swap, w asset.Wallet) error {
+	if sw.Contract == nil {
+		return nil
+	}
+	expired, _, err := w.ContractLockTimeExpired(ctx, sw.Contract.Contract)
+	if err != nil {
+		return fmt.Errorf("error checking contract expiration: %w", err)
+	}
+	if !expired {
+		return nil
+	}
+	assetID := sw.fromAsset()
+	refundCoin, err := w.Refund(sw.Contract.CoinID, sw.Contract.Contract, s.swapFeeRate(assetID, w))
+	if err != nil {
+		return fmt.Errorf("error refunding %s contract: %w", dex.BipIDSymbol(assetID), err)
+	}
+	sw.RefundCoin = refundCoin
+	sw.Refunded = true
+	sw.LastAction = time.Now()
+	s.log.Infof("Refunded %s contract for swap %s with %s", dex.BipIDSymbol(assetID), sw.ID(), sw.Peer)
+	s.report(sw, scoreSwapFailure)
+	s.dispute(sw, sw.Contract, assetID)
+	return nil
+}
+
+// findRedemption watches for the maker's redemption of our contract, in case
+// the maker does not send the secret. The swapTracker mutex must be held.
+func (s *swapper) findRedemption(t *swapTracker, w asset.Wallet) {
+	if t.findingRedemption {
+		return
+	}
+	t.findingRedemption = true
+	ctx := s.runContext()
+	coinID, contract := t.Contract.CoinID, t.Contract.Contract
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		_, secret, err := w.FindRedemption(ctx, coinID, contract)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Errorf("Error finding redemption for swap %s: %v", t.ID(), err)
+			}
+			t.mtx.Lock()
+			t.findingRedemption = false
+			t.mtx.Unlock()
+			return
+		}
+		t.mtx.Lock()
+		if t.Status == dexorder.TakerSwapCast && bytes.Equal(t.SecretHash, sha256Hash(secret)) {
+			t.Secret = secret
+			t.setStatus(dexorder.MakerRedeemed)
+		}
+		t.mtx.Unlock()
+		s.recheck()
+	}()
+}
+
+func sha256Hash(b []byte) []byte {
+	h := sha256.Sum256(b)
+	return h[:]
+}
+
+// handlePeerMessage handles swap messages from the counterparty.
+func (s *swapper) handlePeerMessage(peer tanka.PeerID, route string, payload json.RawMessage, respond func(any, mj.TankagramError)) {
+	var matchID tanka.ID32
+	var update func(sw *Swap) error
+	switch route {
+	case mj.RouteSwapAddress:
+		var msg mj.SwapAddress
+		if err := json.Unmarshal(payload, &msg); err != nil || msg.Address == "" {
+			respond(false, mj.TEEBadRequest)
+			return
+		}
+		matchID = msg.MatchID
+		update = func(sw *Swap) error {
+			if sw.Side != dexorder.Maker {
+				return errors.New("swap address sent to taker")
+			}
+			if sw.PeerAddress == "" {
+				if err := s.checkCommitment(sw, msg.Commitment, msg.Address); err != nil {
+					return err
+				}
+				sw.PeerAddress = msg.Address
+				sw.PeerCommitment = msg.Commitment
+				sw.LastAction = time.Now()
+			}
+			return nil
+		}
+	case mj.RouteSwapContract:
+		var msg mj.SwapContract
+		if err := json.Unmarshal(payload, &msg); err != nil || len(msg.CoinID) == 0 || len(msg.Contract) == 0 {
+			respond(false, mj.TEEBadRequest)
+			return
+		}
+		matchID = msg.MatchID
+		update = func(sw *Swap) error {
+			if sw.PeerContract != nil {
+				// Already received.
+				return nil
+			}
+			if sw.Side == dexorder.Taker {
+				if msg.Address == "" {
+					return errors.New("no address")
+				}
+				if err := s.checkCommitment(sw, msg.Commitment, msg.Address); err != nil {
+					return err
+				}
+				sw.PeerAddress = msg.Address
+				sw.PeerCommitment = msg.Commitment
+			} else if sw.Status != dexorder.MakerSwapCast {
+				return errors.New("contract received before ours was sent")
+			}
+			sw.PeerContract = &SwapContract{CoinID: msg.CoinID, Contract: msg.Contract}
+			sw.LastAction = time.Now()
+			return nil
+		}
+	case mj.RouteSwapRedeem:
+		var msg mj.SwapRedeem
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			respond(false, mj.TEEBadRequest)
+			return
+		}
+		matchID = msg.MatchID
+		update = func(sw *Swap) error {
+			if sw.Side != dexorder.Taker {
+				return errors.New("redemption sent to maker")
+			}
+			if sw.Status >= dexorder.MakerRedeemed {
+				return nil
+			}
+			if sw.Status != dexorder.TakerSwapCast {
+				return fmt.Errorf("redemption received in status %s", sw.Status)
+			}
+			if !bytes.Equal(sha256Hash(msg.Secret), sw.SecretHash) {
+				return errors.New("invalid secret")
+			}
+			sw.Secret = msg.Secret
+			sw.setStatus(dexorder.MakerRedeemed)
+			return nil
+		}
+	default:
+		respond(false, mj.TEEBadRequest)
+		return
+	}
+
+	s.mtx.RLock()
+	t, found := s.swaps[matchID]
+	s.mtx.RUnlock()
+	if !found || t.Peer != peer {
+		s.log.Debugf("Received %s from %s for unknown swap %s", route, peer, matchID)
+		respond(false, mj.TEEBadRequest)
+		return
+	}
+	t.mtx.Lock()
+	err := update(t.Swap)
+	if err == nil {
+		err = s.store(t.Swap)
+	}
+	t.mtx.Unlock()
+	if err != nil {
+		s.log.Errorf("Error handling %s from %s for swap %s: %v", route, peer, matchID, err)
+		respond(false, mj.TEEBadRequest)
+		return
+	}
+	respond(true, mj.TEErrNone)
+	s.recheck()
+}
+
+// swapRecords returns all stored swaps.
+func (s *swapper) swapRecords() ([]*Swap, error) {
+	swaps := make([]*Swap, 0)
+	return swaps, s.table.Iterate(nil, func(it *lexi.Iter) error {
+		sw := new(Swap)
+		if err := it.V(func(vB []byte) error {
+			return json.Unmarshal(vB, sw)
+		}); err != nil {
+			return err
+		}
+		swaps = append(swaps, sw)
+		return nil
+	})
+}
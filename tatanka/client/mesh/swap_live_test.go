@@ -0,0 +1,259 @@
+//go:build live
+
+// Run a swap between two mesh clients connected to a local tatanka node. The
+// dcr and btc simnet harnesses must be running.
+//
+//   go test -v -tags live -run TestLiveSwap
+
+package mesh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/asset/btc"
+	"decred.org/dcrdex/client/asset/dcr"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/config"
+	dexbtc "decred.org/dcrdex/dex/networks/btc"
+	dexorder "decred.org/dcrdex/dex/order"
+	"decred.org/dcrdex/server/comms"
+	"decred.org/dcrdex/tatanka"
+	"decred.org/dcrdex/tatanka/chain/utxo"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+var (
+	usr, _     = user.Current()
+	dextestDir = filepath.Join(usr.HomeDir, "dextest")
+	tPW        = []byte("abc")
+)
+
+func mustEncode(thing any) json.RawMessage {
+	b, err := json.Marshal(thing)
+	if err != nil {
+		panic("mustEncode: " + err.Error())
+	}
+	return b
+}
+
+func runLiveNode(t *testing.T, ctx context.Context, logMaker *dex.LoggerMaker) *TatankaCredentials {
+	t.Helper()
+	priv, _ := secp256k1.GeneratePrivateKey()
+	var peerID tanka.PeerID
+	copy(peerID[:], priv.PubKey().SerializeCompressed())
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error getting a port: %v", err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "priv.key"), priv.Serialize(), 0644)
+	cfgPath := filepath.Join(dir, "config.json")
+	rawCfg, _ := json.Marshal(&tatanka.ConfigFile{Chains: []tatanka.ChainConfig{
+		{
+			Symbol: "dcr",
+			Config: mustEncode(&utxo.DecredConfigFile{
+				RPCUser:   "user",
+				RPCPass:   "pass",
+				RPCListen: "127.0.0.1:19561",
+				RPCCert:   filepath.Join(dextestDir, "dcr", "alpha", "rpc.cert"),
+			}),
+		},
+		{
+			Symbol: "btc",
+			Config: mustEncode(&utxo.BitcoinConfigFile{
+				RPCConfig: dexbtc.RPCConfig{
+					RPCUser: "user",
+					RPCPass: "pass",
+					RPCBind: "127.0.0.1",
+					RPCPort: 20556,
+				},
+			}),
+		},
+	}})
+	if err := os.WriteFile(cfgPath, rawCfg, 0644); err != nil {
+		t.Fatalf("error writing tatanka config: %v", err)
+	}
+
+	cfg := &tatanka.Config{
+		Net:     dex.Simnet,
+		DataDir: dir,
+		Logger:  logMaker.Logger("SRV"),
+		RPC: comms.RPCConfig{
+			ListenAddrs: []string{addr},
+			NoTLS:       true,
+		},
+		ConfigPath: cfgPath,
+		MaxClients: 100,
+	}
+	cfg.FiatOracleConfig.DisabledFiatSources = "Messari"
+	tt, err := tatanka.New(cfg)
+	if err != nil {
+		t.Fatalf("error creating tatanka node: %v", err)
+	}
+	if err := dex.NewConnectionMaster(tt).ConnectOnce(ctx); err != nil {
+		t.Fatalf("error starting tatanka node: %v", err)
+	}
+	return &TatankaCredentials{PeerID: peerID, Addr: addr, NoTLS: true}
+}
+
+type liveWalletName struct {
+	node, name string
+}
+
+func liveWallet(t *testing.T, ctx context.Context, assetID uint32, wn liveWalletName, logger dex.Logger) asset.Wallet {
+	t.Helper()
+	symbol := dex.BipIDSymbol(assetID)
+	settings, err := config.Parse(filepath.Join(dextestDir, symbol, wn.node, wn.node+".conf"))
+	if err != nil {
+		t.Fatalf("error reading %s config: %v", symbol, err)
+	}
+	if wn.name != "" {
+		settings["walletname"] = wn.name
+	}
+	walletCfg := &asset.WalletConfig{
+		Settings:    settings,
+		Emit:        asset.NewWalletEmitter(make(chan asset.WalletNotification, 128), assetID, logger),
+		PeersChange: func(uint32, error) {},
+		DataDir:     t.TempDir(),
+	}
+	var w asset.Wallet
+	switch assetID {
+	case 42:
+		w, err = dcr.NewWallet(walletCfg, logger, dex.Simnet)
+	default:
+		w, err = btc.NewWallet(walletCfg, logger, dex.Simnet)
+	}
+	if err != nil {
+		t.Fatalf("error creating %s wallet: %v", symbol, err)
+	}
+	if err := dex.NewConnectionMaster(w).ConnectOnce(ctx); err != nil {
+		t.Fatalf("error connecting %s wallet: %v", symbol, err)
+	}
+	if err := w.(asset.Authenticator).Unlock(tPW); err != nil {
+		t.Fatalf("error unlocking %s wallet: %v", symbol, err)
+	}
+	return w
+}
+
+func mine(symbol string) {
+	exec.Command("tmux", "send-keys", "-t", symbol+"-harness:0", "./mine-alpha 1", "C-m").Run()
+}
+
+func TestLiveSwap(t *testing.T) {
+	const baseID, quoteID = 42, 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logMaker, err := dex.NewLoggerMaker(os.Stdout, dex.LevelDebug.String())
+	if err != nil {
+		t.Fatalf("error creating logger: %v", err)
+	}
+	entryNode := runLiveNode(t, ctx, logMaker)
+
+	newMesh := func(name string, dcrWallet, btcWallet liveWalletName) *Mesh {
+		log := logMaker.Logger(name)
+		wallets := map[uint32]asset.Wallet{
+			baseID:  liveWallet(t, ctx, baseID, dcrWallet, log.SubLogger("DCR")),
+			quoteID: liveWallet(t, ctx, quoteID, btcWallet, log.SubLogger("BTC")),
+		}
+		priv, _ := secp256k1.GeneratePrivateKey()
+		m, err := New(&Config{
+			DataDir:    t.TempDir(),
+			PrivateKey: priv,
+			Logger:     log,
+			EntryNode:  entryNode,
+			Net:        dex.Simnet,
+			Wallets: func(assetID uint32) (asset.Wallet, error) {
+				if w, found := wallets[assetID]; found {
+					return w, nil
+				}
+				return nil, fmt.Errorf("no %s wallet", dex.BipIDSymbol(assetID))
+			},
+		})
+		if err != nil {
+			t.Fatalf("error creating %s mesh client: %v", name, err)
+		}
+		if _, err := m.Connect(ctx); err != nil {
+			t.Fatalf("error connecting %s mesh client: %v", name, err)
+		}
+		if err := m.SubscribeMarket(baseID, quoteID); err != nil {
+			t.Fatalf("error subscribing %s to market: %v", name, err)
+		}
+		return m
+	}
+	maker := newMesh("MAKER", liveWalletName{node: "alpha"}, liveWalletName{node: "alpha"})
+	taker := newMesh("TAKER", liveWalletName{node: "beta"}, liveWalletName{node: "alpha", name: "gamma"})
+
+	const lotSize = 1 << 26
+	newOrder := func(m *Mesh, sell bool) *tanka.Order {
+		return &tanka.Order{
+			From:    m.ID(),
+			BaseID:  baseID,
+			QuoteID: quoteID,
+			Sell:    sell,
+			Qty:     lotSize * 2,
+			Rate:    1e6,
+			LotSize: lotSize,
+			Nonce:   uint64(time.Now().UnixNano()),
+			Stamp:   time.Now(),
+			// High enough for simnet.
+			MaxFeeRate: 1000,
+		}
+	}
+	if err := maker.PlaceOrder(newOrder(maker, true)); err != nil {
+		t.Fatalf("maker PlaceOrder error: %v", err)
+	}
+	time.Sleep(time.Second * 2)
+	if err := taker.PlaceOrder(newOrder(taker, false)); err != nil {
+		t.Fatalf("taker PlaceOrder error: %v", err)
+	}
+
+	swapStatus := func(m *Mesh) (*Swap, bool) {
+		swaps, err := m.Swaps()
+		if err != nil {
+			t.Fatalf("Swaps error: %v", err)
+		}
+		if len(swaps) != 1 {
+			return nil, false
+		}
+		return swaps[0], !swaps[0].Active()
+	}
+
+	// Mine blocks until both swaps are complete.
+	timeout := time.After(time.Minute * 5)
+	for {
+		makerSwap, makerDone := swapStatus(maker)
+		takerSwap, takerDone := swapStatus(taker)
+		if makerDone && takerDone {
+			if makerSwap.Status != dexorder.MakerRedeemed || takerSwap.Status != dexorder.MatchComplete {
+				t.Fatalf("swaps completed in statuses %s and %s. errors = %q, %q",
+					makerSwap.Status, takerSwap.Status, makerSwap.Error, takerSwap.Error)
+			}
+			return
+		}
+		select {
+		case <-time.After(time.Second * 5):
+			mine("dcr")
+			mine("btc")
+			maker.swapper.recheck()
+			taker.swapper.recheck()
+		case <-timeout:
+			t.Fatalf("swaps not completed")
+		}
+	}
+}
@@ -0,0 +1,621 @@
+package mesh
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/lexi"
+	"decred.org/dcrdex/dex/msgjson"
+	dexorder "decred.org/dcrdex/dex/order"
+	"decred.org/dcrdex/tatanka/mj"
+	"decred.org/dcrdex/tatanka/tanka"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+const (
+	tBaseID  = 42
+	tQuoteID = 0
+	tQty     = 1e8
+	tRate    = 1e7
+)
+
+type tCoin struct {
+	id  dex.Bytes
+	val uint64
+}
+
+func (c *tCoin) ID() dex.Bytes    { return c.id }
+func (c *tCoin) String() string   { return c.id.String() }
+func (c *tCoin) Value() uint64    { return c.val }
+func (c *tCoin) TxID() string     { return c.id.String() }
+func (c *tCoin) ValueStr() string { return fmt.Sprint(c.val) }
+
+type tReceipt struct {
+	coin *tCoin
+	exp  time.Time
+}
+
+func (r *tReceipt) Expiration() time.Time   { return r.exp }
+func (r *tReceipt) Coin() asset.Coin        { return r.coin }
+func (r *tReceipt) Contract() dex.Bytes     { return r.coin.id }
+func (r *tReceipt) String() string          { return r.coin.String() }
+func (r *tReceipt) SignedRefund() dex.Bytes { return nil }
+
+type tContract struct {
+	recipient  string
+	value      uint64
+	secretHash []byte
+	expiration time.Time
+	secret     []byte
+	refunded   bool
+}
+
+// tChain is a fake blockchain shared by both parties' wallets for an asset.
+type tChain struct {
+	mtx       sync.Mutex
+	contracts map[string]*tContract
+	confs     uint32
+	expired   bool
+}
+
+func newTChain() *tChain {
+	return &tChain{contracts: make(map[string]*tContract)}
+}
+
+func (c *tChain) contract(coinID []byte) (*tContract, error) {
+	ct, found := c.contracts[string(coinID)]
+	if !found {
+		return nil, errors.New("contract not found")
+	}
+	return ct, nil
+}
+
+type tWallet struct {
+	asset.Wallet
+	chain *tChain
+	addr  string
+}
+
+func (w *tWallet) Info() *asset.WalletInfo {
+	return &asset.WalletInfo{SupportedVersions: []uint32{0}}
+}
+
+func (w *tWallet) FeeRate() uint64 { return 10 }
+
+func (w *tWallet) FundOrder(ord *asset.Order) (asset.Coins, []dex.Bytes, uint64, error) {
+	return asset.Coins{&tCoin{id: encode.RandomBytes(32), val: ord.Value}}, nil, 0, nil
+}
+
+func (w *tWallet) ReturnCoins(asset.Coins) error { return nil }
+
+func (w *tWallet) Swap(swaps *asset.Swaps) ([]asset.Receipt, asset.Coin, uint64, error) {
+	w.chain.mtx.Lock()
+	defer w.chain.mtx.Unlock()
+	receipts := make([]asset.Receipt, 0, len(swaps.Contracts))
+	for _, c := range swaps.Contracts {
+		coin := &tCoin{id: encode.RandomBytes(32), val: c.Value}
+		exp := time.Unix(int64(c.LockTime), 0)
+		w.chain.contracts[string(coin.id)] = &tContract{
+			recipient:  c.Address,
+			value:      c.Value,
+			secretHash: c.SecretHash,
+			expiration: exp,
+		}
+		receipts = append(receipts, &tReceipt{coin: coin, exp: exp})
+	}
+	return receipts, nil, 1, nil
+}
+
+func (w *tWallet) AuditContract(coinID, _, _ dex.Bytes, _ bool) (*asset.AuditInfo, error) {
+	w.chain.mtx.Lock()
+	defer w.chain.mtx.Unlock()
+	c, err := w.chain.contract(coinID)
+	if err != nil {
+		return nil, err
+	}
+	return &asset.AuditInfo{
+		Recipient:  c.recipient,
+		Expiration: c.expiration,
+		Coin:       &tCoin{id: coinID, val: c.value},
+		Contract:   coinID,
+		SecretHash: c.secretHash,
+	}, nil
+}
+
+func (w *tWallet) Redeem(form *asset.RedeemForm) ([]dex.Bytes, asset.Coin, uint64, error) {
+	w.chain.mtx.Lock()
+	defer w.chain.mtx.Unlock()
+	for _, r := range form.Redemptions {
+		c, err := w.chain.contract(r.Spends.Coin.ID())
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if c.secret != nil || c.refunded {
+			return nil, nil, 0, errors.New("already spent")
+		}
+		if !bytes.Equal(sha256Hash(r.Secret), c.secretHash) {
+			return nil, nil, 0, errors.New("wrong secret")
+		}
+		c.secret = r.Secret
+	}
+	return nil, &tCoin{id: encode.RandomBytes(32)}, 1, nil
+}
+
+func (w *tWallet) Refund(coinID, _ dex.Bytes, _ uint64) (dex.Bytes, error) {
+	w.chain.mtx.Lock()
+	defer w.chain.mtx.Unlock()
+	c, err := w.chain.contract(coinID)
+	if err != nil {
+		return nil, err
+	}
+	if c.secret != nil || c.refunded {
+		return nil, errors.New("already spent")
+	}
+	c.refunded = true
+	return encode.RandomBytes(32), nil
+}
+
+func (w *tWallet) SwapConfirmations(_ context.Context, coinID, _ dex.Bytes, _ time.Time) (uint32, bool, error) {
+	w.chain.mtx.Lock()
+	defer w.chain.mtx.Unlock()
+	c, err := w.chain.contract(coinID)
+	if err != nil {
+		return 0, false, err
+	}
+	return w.chain.confs, c.secret != nil || c.refunded, nil
+}
+
+func (w *tWallet) FindRedemption(ctx context.Context, coinID, _ dex.Bytes) (dex.Bytes, dex.Bytes, error) {
+	for {
+		w.chain.mtx.Lock()
+		c, err := w.chain.contract(coinID)
+		var secret []byte
+		if err == nil {
+			secret = c.secret
+		}
+		w.chain.mtx.Unlock()
+		if err != nil {
+			return nil, nil, err
+		}
+		if secret != nil {
+			return encode.RandomBytes(32), secret, nil
+		}
+		select {
+		case <-time.After(time.Millisecond * 10):
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+}
+
+func (w *tWallet) ContractLockTimeExpired(context.Context, dex.Bytes) (bool, time.Time, error) {
+	w.chain.mtx.Lock()
+	defer w.chain.mtx.Unlock()
+	return w.chain.expired, time.Time{}, nil
+}
+
+func (w *tWallet) RedemptionAddress() (string, error) {
+	return w.addr, nil
+}
+
+// tComms routes peer requests directly to the counterparty's swapper.
+type tComms struct {
+	peerID tanka.PeerID
+	peers  map[tanka.PeerID]*swapper
+
+	mtx      sync.Mutex
+	reports  []*mj.ScoreReport
+	disputes []*mj.Dispute
+	offline  bool
+}
+
+func (c *tComms) ConnectPeer(tanka.PeerID) error { return nil }
+
+func (c *tComms) RequestPeer(peerID tanka.PeerID, msg *msgjson.Message, thing any) error {
+	c.mtx.Lock()
+	offline := c.offline
+	c.mtx.Unlock()
+	s, found := c.peers[peerID]
+	if !found || offline {
+		return fmt.Errorf("peer %s unreachable", peerID)
+	}
+	var err error
+	s.handlePeerMessage(c.peerID, msg.Route, msg.Payload, func(res any, teErr mj.TankagramError) {
+		if teErr != mj.TEErrNone {
+			err = fmt.Errorf("tankagram error %q", teErr)
+			return
+		}
+		b, _ := json.Marshal(res)
+		err = json.Unmarshal(b, thing)
+	})
+	return err
+}
+
+func (c *tComms) NotifyMesh(msg *msgjson.Message) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	switch msg.Route {
+	case mj.RouteSetScore:
+		var report mj.ScoreReport
+		if err := msg.Unmarshal(&report); err != nil {
+			return err
+		}
+		c.reports = append(c.reports, &report)
+	case mj.RouteDispute:
+		var d mj.Dispute
+		if err := msg.Unmarshal(&d); err != nil {
+			return err
+		}
+		c.disputes = append(c.disputes, &d)
+	default:
+		return fmt.Errorf("unexpected route %s", msg.Route)
+	}
+	return nil
+}
+
+func (c *tComms) lastReport() *mj.ScoreReport {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(c.reports) == 0 {
+		return nil
+	}
+	return c.reports[len(c.reports)-1]
+}
+
+type tParty struct {
+	peerID tanka.PeerID
+	priv   *secp256k1.PrivateKey
+	comms  *tComms
+	s      *swapper
+}
+
+type tSwapRig struct {
+	chains       map[uint32]*tChain
+	maker, taker *tParty
+	match        *tanka.Match
+}
+
+func newTSwapRig(t *testing.T) *tSwapRig {
+	t.Helper()
+	chains := map[uint32]*tChain{
+		tBaseID:  newTChain(),
+		tQuoteID: newTChain(),
+	}
+	peers := make(map[tanka.PeerID]*swapper)
+	newParty := func(name string) *tParty {
+		priv, _ := secp256k1.GeneratePrivateKey()
+		var peerID tanka.PeerID
+		copy(peerID[:], priv.PubKey().SerializeCompressed())
+		db, err := lexi.New(&lexi.Config{
+			Path: filepath.Join(t.TempDir(), name),
+			Log:  dex.StdOutLogger("DB", dex.LevelOff),
+		})
+		if err != nil {
+			t.Fatalf("error constructing %s db: %v", name, err)
+		}
+		comms := &tComms{peerID: peerID, peers: peers}
+		s, err := newSwapper(&swapperConfig{
+			log:   dex.StdOutLogger(name, dex.LevelInfo),
+			priv:  priv,
+			comms: comms,
+			db:    db,
+			net:   dex.Simnet,
+			wallets: func(assetID uint32) (asset.Wallet, error) {
+				chain, found := chains[assetID]
+				if !found {
+					return nil, errors.New("no wallet")
+				}
+				return &tWallet{chain: chain, addr: fmt.Sprintf("%s-%d", name, assetID)}, nil
+			},
+			peerTimeout: time.Minute,
+		})
+		if err != nil {
+			t.Fatalf("error constructing %s swapper: %v", name, err)
+		}
+		peers[peerID] = s
+		return &tParty{peerID: peerID, priv: priv, comms: comms, s: s}
+	}
+	maker, taker := newParty("maker"), newParty("taker")
+	return &tSwapRig{
+		chains: chains,
+		maker:  maker,
+		taker:  taker,
+		match: &tanka.Match{
+			From:    taker.peerID,
+			Qty:     tQty,
+			BaseID:  tBaseID,
+			QuoteID: tQuoteID,
+			Stamp:   time.Now(),
+		},
+	}
+}
+
+// start creates the swaps. The maker sells the base asset.
+func (rig *tSwapRig) start(t *testing.T) {
+	t.Helper()
+	var makerOID, takerOID tanka.ID40
+	makerOID[0], takerOID[0] = 1, 2
+	if err := rig.maker.s.newSwap(rig.match, rig.taker.peerID, makerOID, dexorder.Maker, true, tRate); err != nil {
+		t.Fatalf("maker newSwap error: %v", err)
+	}
+	if err := rig.taker.s.newSwap(rig.match, rig.maker.peerID, takerOID, dexorder.Taker, false, tRate); err != nil {
+		t.Fatalf("taker newSwap error: %v", err)
+	}
+}
+
+func (rig *tSwapRig) swap(p *tParty) *Swap {
+	p.s.mtx.RLock()
+	defer p.s.mtx.RUnlock()
+	t := p.s.swaps[rig.match.ID()]
+	if t == nil {
+		return nil
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	sw := *t.Swap
+	return &sw
+}
+
+func (rig *tSwapRig) process(p *tParty) {
+	p.s.processSwaps(context.Background())
+}
+
+func checkStatus(t *testing.T, sw *Swap, status dexorder.MatchStatus) {
+	t.Helper()
+	if sw == nil {
+		t.Fatalf("swap not found")
+	}
+	if sw.Status != status {
+		t.Fatalf("expected %s swap status %s, got %s. error = %q", sw.Side, status, sw.Status, sw.Error)
+	}
+}
+
+func TestSwapSuccess(t *testing.T) {
+	rig := newTSwapRig(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		rig.taker.s.wg.Wait()
+	}()
+	rig.taker.s.ctx = ctx
+	rig.start(t)
+
+	// The maker waits for the taker's address.
+	rig.process(rig.maker)
+	checkStatus(t, rig.swap(rig.maker), dexorder.NewlyMatched)
+
+	// The taker sends its address, and the maker swaps.
+	rig.process(rig.taker)
+	if addr := rig.swap(rig.maker).PeerAddress; addr != "taker-42" {
+		t.Fatalf("wrong taker address %q", addr)
+	}
+	rig.process(rig.maker)
+	makerSwap := rig.swap(rig.maker)
+	checkStatus(t, makerSwap, dexorder.MakerSwapCast)
+	if !makerSwap.Notified {
+		t.Fatalf("taker did not acknowledge maker's contract")
+	}
+	if c := rig.chains[tBaseID].contracts[string(makerSwap.Contract.CoinID)]; c.value != tQty {
+		t.Fatalf("wrong maker contract value %d", c.value)
+	}
+
+	// The taker audits, but waits for confirmations.
+	rig.process(rig.taker)
+	takerSwap := rig.swap(rig.taker)
+	checkStatus(t, takerSwap, dexorder.MakerSwapCast)
+	if !takerSwap.PeerAudited || !bytes.Equal(takerSwap.SecretHash, makerSwap.SecretHash) {
+		t.Fatalf("maker's contract not audited")
+	}
+	rig.chains[tBaseID].confs = 1
+	rig.process(rig.taker)
+	takerSwap = rig.swap(rig.taker)
+	checkStatus(t, takerSwap, dexorder.TakerSwapCast)
+	if !takerSwap.Contract.Expiration.Before(makerSwap.Contract.Expiration) {
+		t.Fatalf("taker's contract does not expire before the maker's")
+	}
+	if c := rig.chains[tQuoteID].contracts[string(takerSwap.Contract.CoinID)]; c.value != tQty/10 {
+		t.Fatalf("wrong taker contract value %d", c.value)
+	}
+
+	// The maker audits and redeems once confirmed.
+	rig.process(rig.maker)
+	checkStatus(t, rig.swap(rig.maker), dexorder.TakerSwapCast)
+	rig.chains[tQuoteID].confs = 1
+	rig.process(rig.maker)
+	if sw := rig.swap(rig.maker); sw != nil {
+		t.Fatalf("maker swap still active in status %s", sw.Status)
+	}
+	if r := rig.maker.comms.lastReport(); r == nil || r.PeerID != rig.taker.peerID || r.Score != scoreSwapSuccess {
+		t.Fatalf("maker did not report success")
+	}
+
+	// The taker learned the secret from the maker, and redeems.
+	checkStatus(t, rig.swap(rig.taker), dexorder.MakerRedeemed)
+	rig.process(rig.taker)
+	if sw := rig.swap(rig.taker); sw != nil {
+		t.Fatalf("taker swap still active in status %s", sw.Status)
+	}
+	if r := rig.taker.comms.lastReport(); r == nil || r.PeerID != rig.maker.peerID || r.Score != scoreSwapSuccess {
+		t.Fatalf("taker did not report success")
+	}
+
+	// Completed swaps are stored.
+	for _, p := range []*tParty{rig.maker, rig.taker} {
+		swaps, err := p.s.swapRecords()
+		if err != nil {
+			t.Fatalf("swapRecords error: %v", err)
+		}
+		if len(swaps) != 1 || swaps[0].Active() || swaps[0].RedeemCoin == nil {
+			t.Fatalf("completed swap not stored")
+		}
+	}
+}
+
+func TestSwapFindRedemption(t *testing.T) {
+	rig := newTSwapRig(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		rig.taker.s.wg.Wait()
+	}()
+	rig.taker.s.ctx = ctx
+	rig.chains[tBaseID].confs = 1
+	rig.chains[tQuoteID].confs = 1
+	rig.start(t)
+
+	rig.process(rig.taker)
+	rig.process(rig.maker)
+	rig.process(rig.taker)
+	checkStatus(t, rig.swap(rig.taker), dexorder.TakerSwapCast)
+
+	// The maker redeems, but the taker doesn't get the message.
+	rig.taker.comms.offline = true
+	rig.maker.comms.offline = true
+	rig.process(rig.maker)
+	checkStatus(t, rig.swap(rig.maker), dexorder.MakerRedeemed)
+
+	// The taker finds the secret on-chain.
+	deadline := time.Now().Add(time.Second)
+	for rig.swap(rig.taker).Status != dexorder.MakerRedeemed {
+		if time.Now().After(deadline) {
+			t.Fatalf("taker did not find redemption")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	rig.process(rig.taker)
+	if sw := rig.swap(rig.taker); sw != nil {
+		t.Fatalf("taker swap still active in status %s", sw.Status)
+	}
+}
+
+func TestSwapRefund(t *testing.T) {
+	rig := newTSwapRig(t)
+	rig.start(t)
+
+	rig.process(rig.taker)
+	rig.process(rig.maker)
+	checkStatus(t, rig.swap(rig.maker), dexorder.MakerSwapCast)
+
+	// The taker disappears. The maker refunds after the lock time.
+	rig.maker.comms.offline = true
+	rig.process(rig.maker)
+	if sw := rig.swap(rig.maker); sw == nil || sw.Refunded {
+		t.Fatalf("maker refunded before expiration")
+	}
+	rig.chains[tBaseID].expired = true
+	rig.process(rig.maker)
+	if sw := rig.swap(rig.maker); sw != nil {
+		t.Fatalf("maker swap still active after refund")
+	}
+	swaps, err := rig.maker.s.swapRecords()
+	if err != nil {
+		t.Fatalf("swapRecords error: %v", err)
+	}
+	if len(swaps) != 1 || !swaps[0].Refunded || swaps[0].RefundCoin == nil {
+		t.Fatalf("refund not stored")
+	}
+	if r := rig.maker.comms.lastReport(); r == nil || r.Score != scoreSwapFailure {
+		t.Fatalf("maker did not report failure")
+	}
+
+	// The maker disputes the swap with the taker's commitment.
+	if len(rig.maker.comms.disputes) != 1 {
+		t.Fatalf("expected 1 dispute, got %d", len(rig.maker.comms.disputes))
+	}
+	d := rig.maker.comms.disputes[0]
+	if c := d.Commitment; c.Signer != rig.taker.peerID || c.Counterparty != rig.maker.peerID || c.Maker || c.Address != "taker-42" {
+		t.Fatalf("wrong commitment in dispute")
+	}
+	if err := d.Commitment.Verify(); err != nil {
+		t.Fatalf("commitment signature error: %v", err)
+	}
+	if d.Contract.AssetID != tBaseID || !bytes.Equal(d.Contract.CoinID, swaps[0].Contract.CoinID) {
+		t.Fatalf("wrong contract in dispute")
+	}
+}
+
+func TestSwapRevoke(t *testing.T) {
+	rig := newTSwapRig(t)
+	rig.start(t)
+
+	// The taker never sends an address.
+	rig.taker.comms.offline = true
+	rig.process(rig.taker)
+	rig.process(rig.maker)
+	checkStatus(t, rig.swap(rig.maker), dexorder.NewlyMatched)
+
+	rig.maker.s.peerTimeout = 0
+	rig.process(rig.maker)
+	if sw := rig.swap(rig.maker); sw != nil {
+		t.Fatalf("maker swap not revoked")
+	}
+	if r := rig.maker.comms.lastReport(); r == nil || r.PeerID != rig.taker.peerID || r.Score != scoreSwapFailure {
+		t.Fatalf("maker did not report failure")
+	}
+}
+
+func TestSwapBadMessages(t *testing.T) {
+	rig := newTSwapRig(t)
+	rig.start(t)
+	matchID := rig.match.ID()
+
+	send := func(from *tParty, to *tParty, route string, payload any) bool {
+		t.Helper()
+		b, _ := json.Marshal(payload)
+		var ok bool
+		to.s.handlePeerMessage(from.peerID, route, b, func(res any, _ mj.TankagramError) {
+			ok = res.(bool)
+		})
+		return ok
+	}
+
+	// Only the counterparty can send messages for the swap.
+	var stranger tParty
+	stranger.peerID[0] = 3
+	if send(&stranger, rig.maker, mj.RouteSwapAddress, &mj.SwapAddress{MatchID: matchID, Address: "addr"}) {
+		t.Fatalf("stranger's address accepted")
+	}
+	// A taker does not receive addresses.
+	if send(rig.maker, rig.taker, mj.RouteSwapAddress, &mj.SwapAddress{MatchID: matchID, Address: "addr"}) {
+		t.Fatalf("address accepted by taker")
+	}
+	// The address must match the taker's signed commitment.
+	c := rig.taker.s.commitment(rig.swap(rig.taker))
+	if send(rig.taker, rig.maker, mj.RouteSwapAddress, &mj.SwapAddress{MatchID: matchID, Address: "addr", Commitment: c}) {
+		t.Fatalf("address not matching commitment accepted")
+	}
+	c.Address = "addr"
+	if send(rig.taker, rig.maker, mj.RouteSwapAddress, &mj.SwapAddress{MatchID: matchID, Address: "addr", Commitment: c}) {
+		t.Fatalf("commitment with bad signature accepted")
+	}
+	if send(rig.taker, rig.maker, mj.RouteSwapAddress, &mj.SwapAddress{MatchID: matchID, Address: "addr"}) {
+		t.Fatalf("address without commitment accepted")
+	}
+	// The maker doesn't accept a contract before sending its own.
+	if send(rig.taker, rig.maker, mj.RouteSwapContract, &mj.SwapContract{MatchID: matchID, CoinID: []byte{1}, Contract: []byte{1}}) {
+		t.Fatalf("early contract accepted by maker")
+	}
+	// The taker checks the secret.
+	rig.process(rig.taker)
+	rig.process(rig.maker)
+	rig.chains[tBaseID].confs = 1
+	rig.process(rig.taker)
+	checkStatus(t, rig.swap(rig.taker), dexorder.TakerSwapCast)
+	if send(rig.maker, rig.taker, mj.RouteSwapRedeem, &mj.SwapRedeem{MatchID: matchID, Secret: encode.RandomBytes(32)}) {
+		t.Fatalf("wrong secret accepted")
+	}
+	if sha256.Size != len(rig.swap(rig.taker).SecretHash) {
+		t.Fatalf("taker secret hash not set")
+	}
+}
@@ -1,6 +1,7 @@
 package mesh
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -9,12 +10,24 @@ import (
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/fiatrates"
 	"decred.org/dcrdex/dex/msgjson"
+	dexorder "decred.org/dcrdex/dex/order"
 	"decred.org/dcrdex/tatanka/client/orderbook"
 	"decred.org/dcrdex/tatanka/client/trade"
 	"decred.org/dcrdex/tatanka/mj"
 	"decred.org/dcrdex/tatanka/tanka"
 )
 
+const (
+	// orderLifetime is the expiration set on our orders when they are placed
+	// or refreshed.
+	orderLifetime = tanka.MaxOrderLifetime / 2
+	// orderRefreshThreshold is how close to expiration our orders are
+	// refreshed.
+	orderRefreshThreshold = orderLifetime / 2
+	// orderMaintenanceInterval is how often orders are pruned and refreshed.
+	orderMaintenanceInterval = tanka.EpochLength
+)
+
 type order struct {
 	*tanka.Order
 	oid tanka.ID40
@@ -24,12 +37,17 @@ type order struct {
 	matchesMtx sync.RWMutex
 	matches    map[tanka.ID32]*tanka.Match
 	remain     uint64
+	// booked is false once the order is filled, or unbooked because the max
+	// fee rate is too low. Unbooked orders don't match. The order's
+	// Expiration is also protected by matchesMtx.
+	booked bool
 }
 
 type market struct {
 	log     dex.Logger
 	peerID  tanka.PeerID
 	conn    *meshConn
+	swapper *swapper
 	baseID  uint32
 	quoteID uint32
 
@@ -39,21 +57,29 @@ type market struct {
 
 	// book is known market orders minus ours.
 	book *orderbook.Book
+
+	// feeRate is the mesh oracle's fee rate for a chain.
+	feeRate func(chainID uint32) uint64
+	// broadcast sends a broadcast to the market's subscribers.
+	broadcast func(msgType mj.BroadcastMessageType, thing any) error
 }
 
 func (m *market) addOwnOrder(ord *tanka.Order) {
 	oid := ord.ID()
 	m.ordsMtx.RLock()
-	if _, exists := m.ords[oid]; exists {
+	_, exists := m.ords[oid]
+	m.ordsMtx.RUnlock()
+	if exists {
 		// ignore it then
 		return
 	}
-	m.ordsMtx.RUnlock()
 	o := &order{
 		Order:   ord,
 		oid:     oid,
 		matches: make(map[tanka.ID32]*tanka.Match),
+		booked:  true,
 	}
+	m.pruneBook(time.Now())
 	desire := &trade.DesiredTrade{
 		Qty:  ord.Qty,
 		Rate: ord.Rate,
@@ -63,34 +89,30 @@ func (m *market) addOwnOrder(ord *tanka.Order) {
 	// TODO: Do asyncronously.
 	o.remain = ord.Qty
 	for _, match := range matches {
-		now := time.Now()
+		// The match references the book order, so that both sides agree on
+		// the match ID.
 		matchThem := &tanka.Match{
 			From:    m.peerID,
-			OrderID: ord.ID(),
+			OrderID: match.Order.ID(),
 			Qty:     match.Qty,
 			BaseID:  m.baseID,
 			QuoteID: m.quoteID,
-			Stamp:   now,
+			Stamp:   time.Now(),
 		}
-		ok, err := m.negotiate(ord.From, matchThem)
+		ok, err := m.negotiate(match.Order.From, matchThem)
 		if err != nil {
-			m.log.Errorf("unable to negotiate match with peer %s: %v", ord.From, err)
+			m.log.Errorf("unable to negotiate match with peer %s: %v", match.Order.From, err)
 			continue
 		}
 		if !ok {
 			continue
 		}
-		// They agree.
-		matchUs := &tanka.Match{
-			From:    match.Order.From,
-			OrderID: match.Order.ID(),
-			Qty:     match.Qty,
-			BaseID:  m.baseID,
-			QuoteID: m.quoteID,
-			Stamp:   now,
-		}
-		o.matches[matchUs.ID()] = matchUs
+		// They agree. We are the taker at the book order's rate.
+		o.matches[matchThem.ID()] = matchThem
 		o.remain -= match.Qty
+		if err := m.swapper.newSwap(matchThem, match.Order.From, oid, dexorder.Taker, ord.Sell, match.Order.Rate); err != nil {
+			m.log.Errorf("error starting swap for match %s: %v", matchThem.ID(), err)
+		}
 	}
 	// TODO: Retry with orders from the book if some were not accepted but
 	// more compatible orders exist. We need a way to mark tried orders
@@ -110,12 +132,19 @@ func (m *market) feeParams() *trade.FeeParameters {
 }
 
 func (m *market) addOrder(ord *tanka.Order) {
-	// TODO: Validate order.
+	// Our own orders are matched when placed.
+	if ord.From == m.peerID {
+		return
+	}
+	if err := m.checkOrder(ord, time.Now()); err != nil {
+		m.log.Debugf("ignoring order %s from %s: %v", ord.ID(), ord.From, err)
+		return
+	}
 	m.book.Add(ord)
 	check := func(o *order) {
 		o.matchesMtx.Lock()
 		defer o.matchesMtx.Unlock()
-		if o.remain == 0 {
+		if o.remain == 0 || !o.booked {
 			return
 		}
 		if ord.Sell == o.Order.Sell {
@@ -137,15 +166,16 @@ func (m *market) addOrder(ord *tanka.Order) {
 		}
 		lots := maxQty / ord.LotSize
 		qty := lots * ord.LotSize
-		o.remain -= qty
-		now := time.Now()
+		if qty == 0 {
+			return
+		}
 		matchThem := &tanka.Match{
 			From:    m.peerID,
-			OrderID: o.Order.ID(),
+			OrderID: ord.ID(),
 			Qty:     qty,
 			BaseID:  m.baseID,
 			QuoteID: m.quoteID,
-			Stamp:   now,
+			Stamp:   time.Now(),
 		}
 		ok, err := m.negotiate(ord.From, matchThem)
 		if err != nil {
@@ -155,16 +185,12 @@ func (m *market) addOrder(ord *tanka.Order) {
 		if !ok {
 			return
 		}
-		// They agree.
-		matchUs := &tanka.Match{
-			From:    ord.From,
-			OrderID: ord.ID(),
-			Qty:     qty,
-			BaseID:  m.baseID,
-			QuoteID: m.quoteID,
-			Stamp:   now,
+		// They agree. We are the taker at their rate.
+		o.remain -= qty
+		o.matches[matchThem.ID()] = matchThem
+		if err := m.swapper.newSwap(matchThem, ord.From, o.oid, dexorder.Taker, o.Sell, ord.Rate); err != nil {
+			m.log.Errorf("error starting swap for match %s: %v", matchThem.ID(), err)
 		}
-		o.matches[matchUs.ID()] = matchUs
 	}
 	m.ordsMtx.RLock()
 	defer m.ordsMtx.RUnlock()
@@ -173,6 +199,169 @@ func (m *market) addOrder(ord *tanka.Order) {
 	}
 }
 
+// feeRateTooLow checks whether the order's max fee rate is below the mesh
+// oracle's fee rate for the chain that the order's owner swaps on.
+func (m *market) feeRateTooLow(ord *tanka.Order) bool {
+	if m.feeRate == nil {
+		return false
+	}
+	r := m.feeRate(feeChainID(ord.SwapAssetID()))
+	return r > 0 && ord.MaxFeeRate < r
+}
+
+// checkOrder checks that an order is valid and can be booked.
+func (m *market) checkOrder(ord *tanka.Order, now time.Time) error {
+	if ord.BaseID != m.baseID || ord.QuoteID != m.quoteID {
+		return fmt.Errorf("wrong market %d-%d", ord.BaseID, ord.QuoteID)
+	}
+	if err := ord.Valid(); err != nil {
+		return err
+	}
+	if err := ord.CheckExpiration(now); err != nil {
+		return err
+	}
+	if m.feeRateTooLow(ord) {
+		return fmt.Errorf("max fee rate %d is below the oracle fee rate", ord.MaxFeeRate)
+	}
+	return nil
+}
+
+// updateOrder applies a peer's update to their booked order.
+func (m *market) updateOrder(ou *tanka.OrderUpdate) {
+	if ou.From == m.peerID {
+		return
+	}
+	if err := ou.CheckExpiration(time.Now()); err != nil {
+		m.log.Debugf("ignoring update for order %s: %v", ou.ID(), err)
+		return
+	}
+	if err := m.book.Update(ou); err != nil {
+		m.log.Debugf("error updating order %s: %v", ou.ID(), err)
+	}
+}
+
+// pruneBook removes expired orders, and orders with a max fee rate below the
+// oracle fee rate, from the book.
+func (m *market) pruneBook(now time.Time) {
+	pruned := m.book.Prune(func(o *tanka.Order) bool {
+		return !o.Expiration.After(now) || m.feeRateTooLow(o)
+	})
+	if len(pruned) > 0 {
+		m.log.Debugf("Pruned %d orders from the book", len(pruned))
+	}
+}
+
+// refreshOrders extends the expiration of our booked orders. Orders that are
+// filled or have a max fee rate below the oracle fee rate are unbooked, and
+// forgotten after they expire. An unbooked order is not booked again, even if
+// the oracle fee rate drops.
+func (m *market) refreshOrders(now time.Time) {
+	type broadcast struct {
+		msgType mj.BroadcastMessageType
+		thing   any
+	}
+	var bcasts []*broadcast
+	m.ordsMtx.Lock()
+	for oid, o := range m.ords {
+		o.matchesMtx.Lock()
+		switch {
+		case !o.booked:
+			if now.Sub(o.Expiration) > maxMatchStampOffset {
+				delete(m.ords, oid)
+			}
+		case o.remain == 0 || m.feeRateTooLow(o.Order):
+			o.booked = false
+			bcasts = append(bcasts, &broadcast{mj.MessageTypeOrderUpdate, &tanka.OrderUpdate{
+				From:  o.From,
+				Nonce: o.Nonce,
+				Stamp: now,
+			}})
+		case !o.Expiration.After(now):
+			// Our refreshes didn't make it out in time, and the order was
+			// pruned from the other books. Book it again.
+			o.Expiration = now.Add(orderLifetime)
+			ord := *o.Order
+			ord.Qty = o.remain
+			ord.Stamp = now
+			bcasts = append(bcasts, &broadcast{mj.MessageTypeNewOrder, &ord})
+		case o.Expiration.Sub(now) < orderRefreshThreshold:
+			o.Expiration = now.Add(orderLifetime)
+			bcasts = append(bcasts, &broadcast{mj.MessageTypeOrderUpdate, &tanka.OrderUpdate{
+				From:       o.From,
+				Nonce:      o.Nonce,
+				Qty:        o.remain,
+				Stamp:      now,
+				Expiration: o.Expiration,
+			}})
+		}
+		o.matchesMtx.Unlock()
+	}
+	m.ordsMtx.Unlock()
+	for _, b := range bcasts {
+		if err := m.broadcast(b.msgType, b.thing); err != nil {
+			m.log.Errorf("error broadcasting %s: %v", b.msgType, err)
+		}
+	}
+}
+
+// cancelOrder unbooks one of our orders and broadcasts the unbooking. Matches
+// that were already accepted are still swapped.
+func (m *market) cancelOrder(oid tanka.ID40, now time.Time) error {
+	m.ordsMtx.RLock()
+	o, found := m.ords[oid]
+	m.ordsMtx.RUnlock()
+	if !found {
+		return fmt.Errorf("order %s not found", oid)
+	}
+	o.matchesMtx.Lock()
+	if !o.booked {
+		o.matchesMtx.Unlock()
+		return fmt.Errorf("order %s is not booked", oid)
+	}
+	o.booked = false
+	ou := &tanka.OrderUpdate{
+		From:  o.From,
+		Nonce: o.Nonce,
+		Stamp: now,
+	}
+	o.matchesMtx.Unlock()
+	return m.broadcast(mj.MessageTypeOrderUpdate, ou)
+}
+
+// bookOrders returns copies of the book orders, best first.
+func (m *market) bookOrders() (buys, sells []*tanka.Order) {
+	m.book.Find(&orderbook.Filter{
+		Check: func(o *tanka.Order) bool {
+			ord := *o
+			if ord.Sell {
+				sells = append(sells, &ord)
+			} else {
+				buys = append(buys, &ord)
+			}
+			return false
+		},
+	})
+	return buys, sells
+}
+
+// ownOrders returns the status of our orders.
+func (m *market) ownOrders() []*OrderStatus {
+	m.ordsMtx.RLock()
+	defer m.ordsMtx.RUnlock()
+	ords := make([]*OrderStatus, 0, len(m.ords))
+	for _, o := range m.ords {
+		o.matchesMtx.RLock()
+		ord := *o.Order
+		ords = append(ords, &OrderStatus{
+			Order:     &ord,
+			Remaining: o.remain,
+			Booked:    o.booked,
+		})
+		o.matchesMtx.RUnlock()
+	}
+	return ords
+}
+
 func (m *market) negotiate(to tanka.PeerID, match *tanka.Match) (bool, error) {
 	b, err := json.Marshal(*match)
 	if err != nil {
@@ -190,7 +379,19 @@ func (m *market) negotiate(to tanka.PeerID, match *tanka.Match) (bool, error) {
 	return ok, m.conn.RequestPeer(to, msg, &ok)
 }
 
-func (m *market) handleNegotiate(match *tanka.Match) bool {
+// maxMatchStampOffset is how far a proposed match's stamp can be from our
+// clock. The stamp sets the maker's swap lock time.
+const maxMatchStampOffset = time.Minute
+
+func (m *market) handleNegotiate(peerID tanka.PeerID, match *tanka.Match) bool {
+	if match.From != peerID {
+		m.log.Errorf("match proposal from %s claims to be from %s", peerID, match.From)
+		return false
+	}
+	if offset := time.Since(match.Stamp); offset > maxMatchStampOffset || offset < -maxMatchStampOffset {
+		m.log.Debugf("ignoring match proposal from %s with stamp %s", peerID, match.Stamp)
+		return false
+	}
 	mid := match.ID()
 	m.ordsMtx.RLock()
 	ord, found := m.ords[match.OrderID]
@@ -206,9 +407,20 @@ func (m *market) handleNegotiate(match *tanka.Match) bool {
 	if ord.matches[mid] != nil {
 		return true
 	}
+	if !ord.booked {
+		return false
+	}
 	// We no longer have the quantity necessary.
 	// TODO: negotiate a lower quantity.
-	if ord.remain < match.Qty {
+	if match.BaseID != ord.BaseID || match.QuoteID != ord.QuoteID {
+		return false
+	}
+	if ord.remain < match.Qty || match.Qty == 0 || match.Qty%ord.LotSize != 0 {
+		return false
+	}
+	// We are the maker at our rate.
+	if err := m.swapper.newSwap(match, peerID, ord.oid, dexorder.Maker, ord.Sell, ord.Rate); err != nil {
+		m.log.Errorf("error starting swap for match %s: %v", mid, err)
 		return false
 	}
 	ord.matches[mid] = match
@@ -239,7 +451,22 @@ func (m *Mesh) handleMarketBroadcast(bcast *mj.Broadcast) {
 			m.log.Errorf("error unmarshaling new order: %v", err)
 			return
 		}
+		if ord.From != bcast.PeerID {
+			m.log.Errorf("order from %s broadcast by %s", ord.From, bcast.PeerID)
+			return
+		}
 		mkt.addOrder(&ord)
+	case mj.MessageTypeOrderUpdate:
+		var ou tanka.OrderUpdate
+		if err := json.Unmarshal(bcast.Payload, &ou); err != nil {
+			m.log.Errorf("error unmarshaling order update: %v", err)
+			return
+		}
+		if ou.From != bcast.PeerID {
+			m.log.Errorf("order update from %s broadcast by %s", ou.From, bcast.PeerID)
+			return
+		}
+		mkt.updateOrder(&ou)
 	case mj.MessageTypeNewSubscriber:
 		var ns mj.NewSubscriber
 		if err := json.Unmarshal(bcast.Payload, &ns); err != nil {
@@ -264,3 +491,27 @@ func (m *Mesh) FiatRate(assetID uint32) float64 {
 	}
 	return 0
 }
+
+// runOrderMaintenance periodically prunes stale orders from the books, and
+// refreshes our own orders.
+func (m *Mesh) runOrderMaintenance(ctx context.Context) {
+	tick := time.NewTicker(orderMaintenanceInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case now := <-tick.C:
+			m.marketsMtx.RLock()
+			mkts := make([]*market, 0, len(m.markets))
+			for _, mkt := range m.markets {
+				mkts = append(mkts, mkt)
+			}
+			m.marketsMtx.RUnlock()
+			for _, mkt := range mkts {
+				mkt.pruneBook(now)
+				mkt.refreshOrders(now)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package mesh
+
+import (
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/tatanka/client/orderbook"
+	"decred.org/dcrdex/tatanka/mj"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+const tOrderQty = 1 << 22
+
+type tBroadcast struct {
+	msgType mj.BroadcastMessageType
+	thing   any
+}
+
+func tNewMarket() (*market, *uint64, *[]*tBroadcast) {
+	var feeRate uint64 = 10
+	var bcasts []*tBroadcast
+	return &market{
+		log:     dex.StdOutLogger("T", dex.LevelInfo),
+		peerID:  tanka.PeerID{0x01},
+		baseID:  tBaseID,
+		quoteID: tQuoteID,
+		ords:    make(map[tanka.ID40]*order),
+		book:    orderbook.New(),
+		feeRate: func(uint32) uint64 { return feeRate },
+		broadcast: func(msgType mj.BroadcastMessageType, thing any) error {
+			bcasts = append(bcasts, &tBroadcast{msgType, thing})
+			return nil
+		},
+	}, &feeRate, &bcasts
+}
+
+func tNewOrder(from tanka.PeerID, nonce uint64, now time.Time) *tanka.Order {
+	return &tanka.Order{
+		From:       from,
+		BaseID:     tBaseID,
+		QuoteID:    tQuoteID,
+		Sell:       true,
+		Qty:        tOrderQty,
+		Rate:       tRate,
+		LotSize:    tOrderQty / 4,
+		Nonce:      nonce,
+		Stamp:      now,
+		Expiration: now.Add(orderLifetime),
+		MaxFeeRate: 20,
+	}
+}
+
+func TestBookLifecycle(t *testing.T) {
+	mkt, feeRate, _ := tNewMarket()
+	now := time.Now()
+	peer := tanka.PeerID{0x02}
+
+	for _, tt := range []struct {
+		name   string
+		mod    func(*tanka.Order)
+		booked bool
+	}{
+		{name: "ok", booked: true},
+		{name: "expired", mod: func(o *tanka.Order) { o.Expiration = now.Add(-time.Second) }},
+		{name: "expiration too far", mod: func(o *tanka.Order) { o.Expiration = now.Add(tanka.MaxOrderLifetime * 2) }},
+		{name: "max fee rate too low", mod: func(o *tanka.Order) { o.MaxFeeRate = *feeRate - 1 }},
+		{name: "wrong market", mod: func(o *tanka.Order) { o.QuoteID = tBaseID + 1 }},
+		{name: "invalid", mod: func(o *tanka.Order) { o.MaxFeeRate = 0 }},
+	} {
+		ord := tNewOrder(peer, 1, now)
+		if tt.mod != nil {
+			tt.mod(ord)
+		}
+		mkt.addOrder(ord)
+		booked := mkt.book.Order(ord.ID()) != nil
+		if booked != tt.booked {
+			t.Fatalf("%s: expected booked = %t, got %t", tt.name, tt.booked, booked)
+		}
+		if booked {
+			mkt.book.Delete(ord.ID())
+		}
+	}
+
+	// A refreshed order survives past its original expiration.
+	ord := tNewOrder(peer, 1, now)
+	mkt.addOrder(ord)
+	exp := ord.Expiration
+	ou := &tanka.OrderUpdate{
+		From:       peer,
+		Nonce:      1,
+		Qty:        tOrderQty,
+		Stamp:      now.Add(time.Second),
+		Expiration: now.Add(tanka.MaxOrderLifetime),
+	}
+	mkt.updateOrder(ou)
+	mkt.pruneBook(exp)
+	if mkt.book.Order(ord.ID()) == nil {
+		t.Fatalf("refreshed order pruned")
+	}
+	// An update too far in the future is ignored.
+	mkt.updateOrder(&tanka.OrderUpdate{
+		From:       peer,
+		Nonce:      1,
+		Qty:        tOrderQty,
+		Stamp:      now.Add(time.Second * 2),
+		Expiration: now.Add(tanka.MaxOrderLifetime * 2),
+	})
+	mkt.pruneBook(ou.Expiration)
+	if mkt.book.Order(ord.ID()) != nil {
+		t.Fatalf("expired order not pruned")
+	}
+
+	// Orders are unbooked when the oracle fee rate rises above their max fee
+	// rate.
+	ord = tNewOrder(peer, 2, now)
+	mkt.addOrder(ord)
+	*feeRate = ord.MaxFeeRate + 1
+	mkt.pruneBook(now)
+	if mkt.book.Order(ord.ID()) != nil {
+		t.Fatalf("order with low max fee rate not pruned")
+	}
+
+	// A zero-quantity update unbooks the order.
+	*feeRate = 10
+	ord = tNewOrder(peer, 3, now)
+	mkt.addOrder(ord)
+	mkt.updateOrder(&tanka.OrderUpdate{From: peer, Nonce: 3, Stamp: now.Add(time.Second)})
+	if mkt.book.Order(ord.ID()) != nil {
+		t.Fatalf("order not unbooked")
+	}
+}
+
+func TestRefreshOrders(t *testing.T) {
+	mkt, feeRate, bcasts := tNewMarket()
+	now := time.Now()
+
+	lastBroadcast := func() *tBroadcast {
+		t.Helper()
+		if len(*bcasts) != 1 {
+			t.Fatalf("expected 1 broadcast, got %d", len(*bcasts))
+		}
+		b := (*bcasts)[0]
+		*bcasts = nil
+		return b
+	}
+
+	ord := tNewOrder(mkt.peerID, 1, now)
+	mkt.addOwnOrder(ord)
+	o := mkt.ords[ord.ID()]
+
+	// Not close enough to expiration to refresh.
+	mkt.refreshOrders(now)
+	if len(*bcasts) != 0 {
+		t.Fatalf("order refreshed early")
+	}
+
+	// Refreshed.
+	refreshTime := ord.Expiration.Add(-orderRefreshThreshold / 2)
+	mkt.refreshOrders(refreshTime)
+	b := lastBroadcast()
+	ou, is := b.thing.(*tanka.OrderUpdate)
+	if b.msgType != mj.MessageTypeOrderUpdate || !is {
+		t.Fatalf("wrong broadcast %s", b.msgType)
+	}
+	if !ou.Expiration.Equal(refreshTime.Add(orderLifetime)) || ou.Qty != tOrderQty {
+		t.Fatalf("wrong update, qty = %d, expiration = %s", ou.Qty, ou.Expiration)
+	}
+	if err := ou.CheckExpiration(refreshTime); err != nil {
+		t.Fatalf("invalid refresh: %v", err)
+	}
+
+	// Rebooked after a missed refresh.
+	missedTime := o.Expiration.Add(time.Second)
+	mkt.refreshOrders(missedTime)
+	b = lastBroadcast()
+	rebooked, is := b.thing.(*tanka.Order)
+	if b.msgType != mj.MessageTypeNewOrder || !is {
+		t.Fatalf("wrong broadcast %s", b.msgType)
+	}
+	if rebooked.ID() != ord.ID() || !rebooked.Expiration.Equal(missedTime.Add(orderLifetime)) {
+		t.Fatalf("wrong rebooked order")
+	}
+
+	// Unbooked when filled, and then forgotten after expiration.
+	o.remain = 0
+	mkt.refreshOrders(missedTime)
+	b = lastBroadcast()
+	if ou, is = b.thing.(*tanka.OrderUpdate); !is || ou.Qty != 0 {
+		t.Fatalf("wrong unbooking broadcast")
+	}
+	if o.booked {
+		t.Fatalf("filled order still booked")
+	}
+	mkt.refreshOrders(missedTime)
+	if len(*bcasts) != 0 || mkt.ords[ord.ID()] == nil {
+		t.Fatalf("unbooked order forgotten before expiration")
+	}
+	mkt.refreshOrders(o.Expiration.Add(maxMatchStampOffset * 2))
+	if mkt.ords[ord.ID()] != nil {
+		t.Fatalf("unbooked order not forgotten")
+	}
+
+	// Unbooked when the oracle fee rate rises above the max fee rate.
+	ord = tNewOrder(mkt.peerID, 2, now)
+	mkt.addOwnOrder(ord)
+	*feeRate = ord.MaxFeeRate + 1
+	mkt.refreshOrders(now)
+	b = lastBroadcast()
+	if ou, is = b.thing.(*tanka.OrderUpdate); !is || ou.Qty != 0 {
+		t.Fatalf("wrong unbooking broadcast")
+	}
+	// An unbooked order doesn't accept matches.
+	match := &tanka.Match{
+		From:    tanka.PeerID{0x02},
+		OrderID: ord.ID(),
+		Qty:     tOrderQty,
+		BaseID:  tBaseID,
+		QuoteID: tQuoteID,
+		Stamp:   now,
+	}
+	if mkt.handleNegotiate(match.From, match) {
+		t.Fatalf("unbooked order accepted match")
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	mkt, _, bcasts := tNewMarket()
+	now := time.Now()
+
+	ord := tNewOrder(mkt.peerID, 1, now)
+	if err := mkt.cancelOrder(ord.ID(), now); err == nil {
+		t.Fatalf("no error canceling unknown order")
+	}
+	mkt.addOwnOrder(ord)
+	if err := mkt.cancelOrder(ord.ID(), now); err != nil {
+		t.Fatalf("cancelOrder error: %v", err)
+	}
+	if len(*bcasts) != 1 {
+		t.Fatalf("expected 1 broadcast, got %d", len(*bcasts))
+	}
+	b := (*bcasts)[0]
+	if ou, is := b.thing.(*tanka.OrderUpdate); b.msgType != mj.MessageTypeOrderUpdate || !is || ou.Qty != 0 || ou.Nonce != ord.Nonce {
+		t.Fatalf("wrong unbooking broadcast")
+	}
+	if mkt.ownOrders()[0].Booked {
+		t.Fatalf("canceled order still booked")
+	}
+	if err := mkt.cancelOrder(ord.ID(), now); err == nil {
+		t.Fatalf("no error canceling unbooked order")
+	}
+}
+
+func TestMarketSnapshots(t *testing.T) {
+	mkt, _, _ := tNewMarket()
+	now := time.Now()
+
+	sell := tNewOrder(tanka.PeerID{0x02}, 1, now)
+	buy := tNewOrder(tanka.PeerID{0x03}, 1, now)
+	buy.Sell = false
+	mkt.addOrder(sell)
+	mkt.addOrder(buy)
+	buys, sells := mkt.bookOrders()
+	if len(buys) != 1 || len(sells) != 1 || buys[0].ID() != buy.ID() || sells[0].ID() != sell.ID() {
+		t.Fatalf("wrong book orders")
+	}
+	// The snapshot is a copy.
+	sells[0].Qty = 0
+	if mkt.book.Order(sell.ID()).Qty != tOrderQty {
+		t.Fatalf("book order modified")
+	}
+
+	ord := tNewOrder(mkt.peerID, 1, now)
+	mkt.ords[ord.ID()] = &order{Order: ord, oid: ord.ID(), remain: tOrderQty / 2, booked: true}
+	ords := mkt.ownOrders()
+	if len(ords) != 1 || ords[0].ID() != ord.ID() || ords[0].Remaining != tOrderQty/2 || !ords[0].Booked {
+		t.Fatalf("wrong own orders")
+	}
+}
@@ -19,6 +19,7 @@ type Booker interface {
 	Add(*tanka.Order)
 	Update(ou *tanka.OrderUpdate) error
 	Delete(id tanka.ID40)
+	Prune(remove func(*tanka.Order) bool) []*tanka.Order
 }
 
 // Filter is used when searching for orders.
@@ -132,7 +133,8 @@ func (ob *Book) Add(o *tanka.Order) {
 	ob.addOrderAndSort(o)
 }
 
-// Update updates an order.
+// Update updates an order. Updates older than the order's current stamp are
+// rejected. An update with zero quantity deletes the order.
 func (ob *Book) Update(ou *tanka.OrderUpdate) error {
 	ob.mtx.Lock()
 	defer ob.mtx.Unlock()
@@ -141,8 +143,19 @@ func (ob *Book) Update(ou *tanka.OrderUpdate) error {
 	if !has {
 		return fmt.Errorf("order %x not found", id)
 	}
+	if ou.Stamp.Before(o.Stamp) {
+		return fmt.Errorf("update stamp %s is older than order %x stamp %s", ou.Stamp, id, o.Stamp)
+	}
+	if ou.Qty == 0 {
+		delete(ob.book, id)
+		ob.deleteSortedOrder(o)
+		return nil
+	}
 	o.Qty = ou.Qty
 	o.Stamp = ou.Stamp
+	if !ou.Expiration.IsZero() {
+		o.Expiration = ou.Expiration
+	}
 	return nil
 }
 
@@ -156,3 +169,19 @@ func (ob *Book) Delete(id tanka.ID40) {
 		ob.deleteSortedOrder(o)
 	}
 }
+
+// Prune deletes the orders for which remove returns true, and returns the
+// deleted orders.
+func (ob *Book) Prune(remove func(*tanka.Order) bool) []*tanka.Order {
+	ob.mtx.Lock()
+	defer ob.mtx.Unlock()
+	var pruned []*tanka.Order
+	for id, o := range ob.book {
+		if remove(o) {
+			delete(ob.book, id)
+			ob.deleteSortedOrder(o)
+			pruned = append(pruned, o)
+		}
+	}
+	return pruned
+}
@@ -193,6 +193,7 @@ func TestUpdate(t *testing.T) {
 		name    string
 		update  *tanka.OrderUpdate
 		wantErr bool
+		deleted bool
 	}{{
 		name: "ok",
 		update: &tanka.OrderUpdate{
@@ -210,6 +211,32 @@ func TestUpdate(t *testing.T) {
 			Stamp: updateTime,
 		},
 		wantErr: true,
+	}, {
+		name: "stale update",
+		update: &tanka.OrderUpdate{
+			From:  o.From,
+			Nonce: o.Nonce,
+			Qty:   6,
+			Stamp: updateTime.Add(-time.Second),
+		},
+		wantErr: true,
+	}, {
+		name: "refresh expiration",
+		update: &tanka.OrderUpdate{
+			From:       o.From,
+			Nonce:      o.Nonce,
+			Qty:        6,
+			Stamp:      updateTime.Add(time.Second),
+			Expiration: updateTime.Add(time.Minute),
+		},
+	}, {
+		name: "zero quantity deletes",
+		update: &tanka.OrderUpdate{
+			From:  o.From,
+			Nonce: o.Nonce,
+			Stamp: updateTime.Add(time.Second * 2),
+		},
+		deleted: true,
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -224,12 +251,21 @@ func TestUpdate(t *testing.T) {
 				t.Fatalf("unexpected error %v", err)
 			}
 			ord := ob.Order(test.update.ID())
+			if test.deleted {
+				if ord != nil || len(ob.buys) != 1 {
+					t.Fatal("order not deleted")
+				}
+				return
+			}
 			if ord.Qty != test.update.Qty {
 				t.Fatalf("expected qty %d but got %d", test.update.Qty, ord.Qty)
 			}
 			if ord.Stamp != test.update.Stamp {
 				t.Fatalf("expected stamp %s but got %s", test.update.Stamp, ord.Stamp)
 			}
+			if !test.update.Expiration.IsZero() && !ord.Expiration.Equal(test.update.Expiration) {
+				t.Fatalf("expected expiration %s but got %s", test.update.Expiration, ord.Expiration)
+			}
 		})
 	}
 }
@@ -260,3 +296,26 @@ func TestDeleteOrder(t *testing.T) {
 		t.Fatalf("wanted 1 but got %d orders", len(oids))
 	}
 }
+
+func TestPrune(t *testing.T) {
+	ob := New()
+	ords := testOrders()
+	for _, o := range ords {
+		ob.Add(o)
+	}
+	pruned := ob.Prune(func(o *tanka.Order) bool {
+		return o.LotSize > 2
+	})
+	if len(pruned) != 2 {
+		t.Fatalf("wanted 2 but got %d pruned orders", len(pruned))
+	}
+	if ob.Order(ords[0].ID()) != nil || ob.Order(ords[3].ID()) != nil {
+		t.Fatal("incorrect order pruned")
+	}
+	if len(ob.buys) != 1 || len(ob.sells) != 1 {
+		t.Fatalf("wanted 1 buy and 1 sell but got %d and %d", len(ob.buys), len(ob.sells))
+	}
+	if pruned = ob.Prune(func(*tanka.Order) bool { return false }); len(pruned) != 0 {
+		t.Fatalf("wanted no pruned orders but got %d", len(pruned))
+	}
+}
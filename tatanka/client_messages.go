@@ -12,6 +12,7 @@ import (
 	"decred.org/dcrdex/dex"
 	"decred.org/dcrdex/dex/msgjson"
 	"decred.org/dcrdex/dex/utils"
+	"decred.org/dcrdex/tatanka/chain"
 	"decred.org/dcrdex/tatanka/mj"
 	"decred.org/dcrdex/tatanka/tanka"
 	"decred.org/dcrdex/tatanka/tcp"
@@ -132,6 +133,12 @@ func (t *Tatanka) handleClientConnect(cl tanka.Sender, msg *msgjson.Message) *ms
 		return msgjson.NewError(mj.ErrAuth, "signature error: %v", err)
 	}
 
+	if banned, err := t.db.IsBlacklisted(conn.ID); err != nil {
+		return msgjson.NewError(mj.ErrInternal, "error checking blacklist for peer %q: %v", conn.ID, err)
+	} else if banned {
+		return msgjson.NewError(mj.ErrBanned, "you have been blacklisted by the node operator")
+	}
+
 	cl.SetPeerID(p.ID)
 
 	pp := &peer{Peer: p, Sender: cl, rrs: make(map[tanka.PeerID]*tanka.Reputation)}
@@ -259,7 +266,7 @@ func (t *Tatanka) handlePostBond(cl tanka.Sender, msg *msgjson.Message) *msgjson
 		}
 
 		t.chainMtx.RLock()
-		ch := t.chains[b.AssetID]
+		ch := t.chains[chain.ChainID(b.AssetID)]
 		t.chainMtx.RUnlock()
 		if ch == nil {
 			t.log.Errorf("Bond-posting client %q sent a bond for an unknown chain %d", peerID, b.AssetID)
@@ -271,21 +278,16 @@ func (t *Tatanka) handlePostBond(cl tanka.Sender, msg *msgjson.Message) *msgjson
 			return msgjson.NewError(mj.ErrBadRequest, "failed validation")
 		}
 
-		if err := t.db.StoreBond(b); err != nil {
+		// Storing the bond also updates the client's bonds and announces the
+		// bond to the other tatanka nodes.
+		stored, err := t.gossipEvent(nil, nil, b)
+		if err != nil {
 			t.log.Errorf("Error storing bond for client %s in db: %v", peerID, err)
 			return msgjson.NewError(mj.ErrInternal, "internal error")
 		}
-	}
-
-	liveBonds, err := t.db.GetBonds(peerID)
-	if err != nil {
-		t.log.Errorf("Error retrieving bonds for client %s in db: %v", peerID, err)
-		msgjson.NewError(mj.ErrInternal, "internal error")
-	}
-
-	if len(liveBonds) > 0 { // Probably no way to get here with empty liveBonds, but checking anyway.
-		if c := t.clientNode(peerID); c != nil {
-			c.updateBonds(liveBonds)
+		if !stored {
+			t.log.Errorf("Bond-posting client %q sent bond %s, which is already claimed by another peer", peerID, b.CoinID)
+			return msgjson.NewError(mj.ErrBadRequest, "bond already claimed")
 		}
 	}
 
@@ -294,13 +296,6 @@ func (t *Tatanka) handlePostBond(cl tanka.Sender, msg *msgjson.Message) *msgjson
 	return nil
 }
 
-// NodeInfoResponse is the response to a NodeInfo request.
-type NodeInfoResponse struct {
-	Capacity  uint64      `json:"capacity"`
-	Chains    []uint32    `json:"chains"`
-	Whitelist []*BootNode `json:"whitelist"`
-}
-
 func (t *Tatanka) handleNodeInfo(any) (any, error) {
 	t.clientMtx.RLock()
 	numClients := len(t.clients)
@@ -318,7 +313,7 @@ func (t *Tatanka) handleNodeInfo(any) (any, error) {
 	}
 	t.chainMtx.RUnlock()
 
-	whitelist := make([]*BootNode, 0, len(t.whitelist))
+	whitelist := make([]*mj.BootNode, 0, len(t.whitelist))
 
 	for peerID, node := range t.whitelist {
 		var n tcp.RemoteNodeConfig
@@ -326,14 +321,18 @@ func (t *Tatanka) handleNodeInfo(any) (any, error) {
 			t.log.Errorf("error reading boot node configuration: %w", err)
 			continue
 		}
-		whitelist = append(whitelist, &BootNode{
+		whitelist = append(whitelist, &mj.BootNode{
 			PeerID:   peerID[:],
 			Config:   node.cfg,
 			Protocol: node.protocol,
 		})
 	}
 
-	return NodeInfoResponse{remainingCapacity, chains, whitelist}, nil
+	return mj.NodeInfoResponse{
+		Capacity:  remainingCapacity,
+		Chains:    chains,
+		Whitelist: whitelist,
+	}, nil
 }
 
 func (t *Tatanka) notifySubscribersOfNewSubscriber(
@@ -783,33 +782,12 @@ func (t *Tatanka) handleSetScore(c *client, msg *msgjson.Message) {
 		t.log.Errorf("error unmarshaling set_score from %s: %v", scorer, err)
 		return
 	}
-	if err := t.db.SetScore(score.PeerID, scorer, score.Score, time.Now()); err != nil {
-		t.log.Errorf("error adding score from %s for %s to db: %v", scorer, score.PeerID, err)
-	}
-	rep, err := t.db.Reputation(score.PeerID)
-	if err != nil {
-		t.log.Errorf("error getting reputation after score update from %s for %s: %v", score.PeerID, scorer, err)
-		return
-	}
-	t.clientMtx.RLock()
-	c, found := t.clients[score.PeerID]
-	t.clientMtx.RUnlock()
-	if found {
-		c.mtx.Lock()
-		c.Reputation = rep
-		c.mtx.Unlock()
-	}
-
-	note := mj.MustNotification(mj.RouteShareScore, &mj.SharedScore{
-		Scorer:     scorer,
-		Scored:     score.PeerID,
-		Score:      score.Score,
-		Reputation: rep,
-	})
-	for _, tt := range t.tatankaNodes() {
-		if err := t.send(tt, note); err != nil {
-			t.log.Errorf("error notifying %s of new score: %v", tt.ID, err)
-		}
+	if _, err := t.gossipEvent(&tanka.ScoreEvent{
+		Scorer: scorer,
+		Scored: score.PeerID,
+		Score:  score.Score,
+	}, nil, nil); err != nil {
+		t.log.Errorf("error adding score from %s for %s: %v", scorer, score.PeerID, err)
 	}
 }
 
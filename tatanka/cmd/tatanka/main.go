@@ -5,6 +5,7 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"net"
@@ -13,12 +14,17 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 
 	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/encode"
 	"decred.org/dcrdex/dex/feerates"
 	"decred.org/dcrdex/dex/fiatrates"
 	"decred.org/dcrdex/server/comms"
 	"decred.org/dcrdex/tatanka"
+	"decred.org/dcrdex/tatanka/admin"
+	_ "decred.org/dcrdex/tatanka/chain/evm"
+	_ "decred.org/dcrdex/tatanka/chain/utxo"
 	"github.com/jessevdk/go-flags"
 	"github.com/jrick/logrotate/rotator"
 )
@@ -33,12 +39,14 @@ const (
 	defaultHSPort         = "7252"
 	defaultLogLevel       = "debug"
 	defaultMaxClients     = 1000
+	defaultAdminSrvAddr   = "127.0.0.1:7242"
 )
 
 var (
 	log              = dex.Disabled
 	subsystemLoggers = map[string]dex.Logger{
 		"MAIN": dex.Disabled,
+		"ADMN": dex.Disabled,
 	}
 )
 
@@ -101,7 +109,40 @@ func mainErr() (err error) {
 		return fmt.Errorf("ConnectOnce error: %w", err)
 	}
 
+	var wg sync.WaitGroup
+	if cfg.AdminSrvOn {
+		if cfg.AdminSrvPassword == "" {
+			return errors.New("an admin server password must be set with --adminsrvpass")
+		}
+		pw := []byte(cfg.AdminSrvPassword)
+		authSHA := sha256.Sum256(pw)
+		encode.ClearBytes(pw)
+		adminSrvAddr := defaultAdminSrvAddr
+		if cfg.AdminSrvAddr != "" {
+			adminSrvAddr = cfg.AdminSrvAddr
+		}
+		adminServer, err := admin.NewServer(&admin.SrvConfig{
+			Core:    t,
+			Addr:    adminSrvAddr,
+			AuthSHA: authSHA,
+			Cert:    cfg.CertPath,
+			Key:     cfg.KeyPath,
+			NoTLS:   cfg.AdminSrvNoTLS,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot set up admin server: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			adminServer.Run(ctx)
+			wg.Done()
+		}()
+	}
+
 	tc.Wait()
+	// Wait for the admin server to finish.
+	cancel()
+	wg.Wait()
 	return nil
 }
 
@@ -126,6 +167,11 @@ type Config struct {
 	WebAddr    string `long:"webaddr" description:"The public facing address by which peers should connect."`
 	MaxClients int    `long:"maxclients" description:"The maximum number of clients that can connect to this node."`
 
+	AdminSrvOn       bool   `long:"adminsrvon" description:"Turn on the admin server."`
+	AdminSrvAddr     string `long:"adminsrvaddr" description:"Administration HTTPS server address (default: 127.0.0.1:7242)."`
+	AdminSrvPassword string `long:"adminsrvpass" description:"Admin server password."`
+	AdminSrvNoTLS    bool   `long:"adminsrvnotls" description:"Run admin server without TLS. Only use this option if you are using a securely configured reverse proxy."`
+
 	FiatOracleConfig fiatrates.Config `group:"Fiat Oracle Config"`
 }
 
@@ -265,6 +311,7 @@ func parseAndSetDebugLevels(debugLevel string, UTC bool) (*dex.LoggerMaker, erro
 
 	// Set main's Logger.
 	log = subsystemLoggers["MAIN"]
+	admin.UseLogger(subsystemLoggers["ADMN"])
 
 	return lm, nil
 }
@@ -0,0 +1,64 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/lexi"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+// Blacklist adds the peer to the operator's blacklist. Blacklisted peers are
+// refused connections regardless of their bonds and reputation.
+func (d *DB) Blacklist(peerID tanka.PeerID) error {
+	stamp := encode.Uint64Bytes(uint64(time.Now().UnixMilli()))
+	return d.blacklist.Set(peerID[:], stamp, lexi.WithReplace())
+}
+
+// Unblacklist removes the peer from the blacklist. It is not an error if the
+// peer is not blacklisted.
+func (d *DB) Unblacklist(peerID tanka.PeerID) error {
+	if err := d.blacklist.Delete(peerID[:]); err != nil && !errors.Is(err, lexi.ErrKeyNotFound) {
+		return err
+	}
+	return nil
+}
+
+// IsBlacklisted checks whether the peer is blacklisted.
+func (d *DB) IsBlacklisted(peerID tanka.PeerID) (bool, error) {
+	if _, err := d.blacklist.GetRaw(peerID[:]); err != nil {
+		if errors.Is(err, lexi.ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Blacklisted is every blacklisted peer and the time they were blacklisted.
+func (d *DB) Blacklisted() (map[tanka.PeerID]time.Time, error) {
+	peers := make(map[tanka.PeerID]time.Time)
+	return peers, d.blacklist.Iterate(nil, func(it *lexi.Iter) error {
+		k, err := it.K()
+		if err != nil {
+			return err
+		}
+		if len(k) != tanka.PeerIDLength {
+			return fmt.Errorf("invalid blacklist key length %d", len(k))
+		}
+		var peerID tanka.PeerID
+		copy(peerID[:], k)
+		return it.V(func(vB []byte) error {
+			if len(vB) != 8 {
+				return fmt.Errorf("invalid blacklist stamp length %d", len(vB))
+			}
+			peers[peerID] = time.UnixMilli(int64(encode.BytesToUint64(vB)))
+			return nil
+		})
+	})
+}
@@ -71,3 +71,22 @@ func (d *DB) GetBonds(peerID tanka.PeerID) ([]*tanka.Bond, error) {
 		})
 	})
 }
+
+// AllBonds retrieves every unexpired bond.
+func (d *DB) AllBonds() ([]*tanka.Bond, error) {
+	var bonds []*tanka.Bond
+	now := time.Now()
+	return bonds, d.bonds.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			var bond dbBond
+			if err := bond.UnmarshalBinary(vB); err != nil {
+				return fmt.Errorf("error unmarshaling bond: %w", err)
+			}
+			if bond.Expiration.Before(now) {
+				return nil
+			}
+			bonds = append(bonds, bond.Bond)
+			return nil
+		})
+	})
+}
@@ -32,6 +32,19 @@ type DB struct {
 	bonds        *lexi.Table
 	bonderIdx    *lexi.Index
 	bondStampIdx *lexi.Index
+	verdicts     *lexi.Table
+	// verdictMtx serializes verdict updates, which depend on the previous
+	// verdict for the match.
+	verdictMtx sync.Mutex
+	// gossip stores the current gossip event for each subject.
+	gossip        *lexi.Table
+	gossipSeqIdx  *lexi.Index
+	gossipCursors *lexi.Table
+	// gossipMtx serializes gossip updates and guards gossipSeq.
+	gossipMtx sync.Mutex
+	gossipSeq uint64
+	// blacklist holds operator-banned peers. Keyed on peer ID.
+	blacklist *lexi.Table
 }
 
 func New(dir string, log dex.Logger) (*DB, error) {
@@ -120,15 +133,59 @@ func New(dir string, log dex.Logger) (*DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error initializing bond stamp index: %w", err)
 	}
-	return &DB{
-		DB:           db,
-		scores:       scoreTable,
-		scoredIdx:    scoredIdx,
-		log:          log,
-		bonds:        bondsTable,
-		bonderIdx:    bonderIdx,
-		bondStampIdx: bondStampIdx,
-	}, nil
+
+	// Dispute verdicts. Keyed on match ID.
+	verdictsTable, err := db.Table("verdicts")
+	if err != nil {
+		return nil, fmt.Errorf("error initializing verdicts table: %w", err)
+	}
+
+	// Gossip events. Keyed on the event subject.
+	gossipTable, err := db.Table("gossip")
+	if err != nil {
+		return nil, fmt.Errorf("error initializing gossip table: %w", err)
+	}
+	// Sequence index for syncing.
+	gossipSeqIdx, err := gossipTable.AddIndex("gossip-seq", func(_, v lexi.KV) ([]byte, error) {
+		g, is := v.(*dbGossip)
+		if !is {
+			return nil, fmt.Errorf("wrong type %T", v)
+		}
+		return encode.Uint64Bytes(g.seq), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error initializing gossip sequence index: %w", err)
+	}
+	// Sync cursors for remote tatanka nodes. Keyed on peer ID.
+	gossipCursors, err := db.Table("gossip-cursors")
+	if err != nil {
+		return nil, fmt.Errorf("error initializing gossip cursors table: %w", err)
+	}
+
+	// Operator blacklist. Keyed on peer ID.
+	blacklistTable, err := db.Table("blacklist")
+	if err != nil {
+		return nil, fmt.Errorf("error initializing blacklist table: %w", err)
+	}
+
+	d := &DB{
+		DB:            db,
+		scores:        scoreTable,
+		scoredIdx:     scoredIdx,
+		log:           log,
+		bonds:         bondsTable,
+		bonderIdx:     bonderIdx,
+		bondStampIdx:  bondStampIdx,
+		verdicts:      verdictsTable,
+		gossip:        gossipTable,
+		gossipSeqIdx:  gossipSeqIdx,
+		gossipCursors: gossipCursors,
+		blacklist:     blacklistTable,
+	}
+	if d.gossipSeq, err = d.lastGossipSeq(); err != nil {
+		return nil, fmt.Errorf("error retrieving gossip sequence: %w", err)
+	}
+	return d, nil
 }
 
 func (db *DB) Connect(ctx context.Context) (*sync.WaitGroup, error) {
@@ -139,6 +196,7 @@ func (db *DB) Connect(ctx context.Context) (*sync.WaitGroup, error) {
 			case <-time.After(time.Hour):
 				wg.Add(1)
 				db.pruneOldBonds()
+				db.pruneOldGossip()
 				wg.Done()
 			case <-ctx.Done():
 				return
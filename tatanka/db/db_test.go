@@ -152,3 +152,255 @@ func TestReputation(t *testing.T) {
 		t.Fatalf("Wrong number of remaining entries. Expected %d, got %d", tanka.MaxReputationEntries, n)
 	}
 }
+
+func TestVerdicts(t *testing.T) {
+	db, shutdown := tNewDB()
+	defer shutdown()
+
+	maker, taker := tanka.PeerID{0x01}, tanka.PeerID{0x02}
+	matchID := tanka.ID32{0x03}
+
+	checkRep := func(peer tanka.PeerID, expScore int64) {
+		t.Helper()
+		rep, err := db.Reputation(peer)
+		if err != nil {
+			t.Fatalf("Reputation error: %v", err)
+		}
+		if rep.Score != expScore {
+			t.Fatalf("Expected score %d, got %d", expScore, rep.Score)
+		}
+	}
+
+	// Both parties self-report a failure.
+	db.SetScore(taker, maker, -1, time.Now())
+	db.SetScore(maker, taker, -1, time.Now())
+
+	v, err := db.Verdict(matchID)
+	if err != nil || v != nil {
+		t.Fatalf("Expected no verdict, got %v, %v", v, err)
+	}
+
+	// The taker is found at fault, and the taker's report is neutralized.
+	takerFault := &tanka.Verdict{MatchID: matchID, AtFault: taker, Innocent: maker, Stamp: time.Now()}
+	if stored, err := db.StoreVerdict(takerFault); err != nil || !stored {
+		t.Fatalf("StoreVerdict error: stored = %t, err = %v", stored, err)
+	}
+	checkRep(taker, -1+int64(scoreVerdict))
+	checkRep(maker, 0)
+	v, err = db.Verdict(matchID)
+	if err != nil {
+		t.Fatalf("Verdict error: %v", err)
+	}
+	if v.AtFault != taker || v.Innocent != maker || v.Maker || v.Stamp.UnixMilli() != takerFault.Stamp.UnixMilli() {
+		t.Fatalf("Wrong verdict retrieved")
+	}
+
+	// Repeat verdicts are not stored.
+	if stored, _ := db.StoreVerdict(takerFault); stored {
+		t.Fatalf("Repeat verdict stored")
+	}
+
+	// The maker is then found at fault, which reverses the taker's verdict.
+	makerFault := &tanka.Verdict{MatchID: matchID, AtFault: maker, Innocent: taker, Maker: true, Stamp: time.Now()}
+	if stored, err := db.StoreVerdict(makerFault); err != nil || !stored {
+		t.Fatalf("StoreVerdict error: stored = %t, err = %v", stored, err)
+	}
+	checkRep(taker, 0)
+	checkRep(maker, int64(scoreVerdict))
+
+	// A verdict against the maker is final.
+	if stored, _ := db.StoreVerdict(takerFault); stored {
+		t.Fatalf("Verdict against taker overrode verdict against maker")
+	}
+}
+
+func TestGossip(t *testing.T) {
+	db, shutdown := tNewDB()
+	defer shutdown()
+
+	origin, scorer, scored := tanka.PeerID{0x01}, tanka.PeerID{0x02}, tanka.PeerID{0x03}
+	now := time.UnixMilli(time.Now().UnixMilli())
+	newScore := func(score int8, stamp time.Time) *tanka.GossipEvent {
+		return &tanka.GossipEvent{
+			Type:   tanka.GossipScore,
+			Origin: origin,
+			Stamp:  stamp,
+			Score:  &tanka.ScoreEvent{Scorer: scorer, Scored: scored, Score: score},
+		}
+	}
+	store := func(ev *tanka.GossipEvent, expStored bool) {
+		t.Helper()
+		stored, err := db.StoreGossip(ev)
+		if err != nil {
+			t.Fatalf("StoreGossip error: %v", err)
+		}
+		if stored != expStored {
+			t.Fatalf("Expected stored = %t, got %t", expStored, stored)
+		}
+	}
+	checkScore := func(exp int64) {
+		t.Helper()
+		rep, err := db.Reputation(scored)
+		if err != nil {
+			t.Fatalf("Reputation error: %v", err)
+		}
+		if rep.Score != exp || rep.Depth != 1 {
+			t.Fatalf("Expected score %d with depth 1, got score %d with depth %d", exp, rep.Score, rep.Depth)
+		}
+	}
+
+	ev := newScore(1, now.Add(-time.Minute))
+	store(ev, true)
+	checkScore(1)
+	// Duplicate.
+	store(ev, false)
+	// An older score doesn't override.
+	store(newScore(2, now.Add(-time.Minute*2)), false)
+	checkScore(1)
+	// A newer one does.
+	store(newScore(3, now), true)
+	checkScore(3)
+	// A tie is broken by ID, in either order.
+	evA, evB := newScore(4, now.Add(time.Second)), newScore(5, now.Add(time.Second))
+	idA, idB := evA.Digest(), evB.Digest()
+	if bytes.Compare(idA[:], idB[:]) > 0 {
+		evA, evB = evB, evA
+	}
+	store(evB, true)
+	store(evA, false)
+	checkScore(int64(evB.Score.Score))
+	// Too old.
+	store(newScore(6, now.Add(-gossipRetention-time.Minute)), false)
+
+	// A bond belongs to the first peer it's announced for.
+	newBondEvent := func(peer tanka.PeerID, stamp time.Time) *tanka.GossipEvent {
+		return &tanka.GossipEvent{
+			Type:   tanka.GossipBond,
+			Origin: origin,
+			Stamp:  stamp,
+			Bond:   newBond(peer, 1),
+		}
+	}
+	store(newBondEvent(scorer, now), true)
+	store(newBondEvent(scored, now.Add(time.Second)), false)
+	if bs, err := db.GetBonds(scored); err != nil || len(bs) != 0 {
+		t.Fatalf("Expected no bonds for the second claimant, got %d, err = %v", len(bs), err)
+	}
+	store(newBondEvent(scorer, now.Add(time.Second)), true)
+	if bs, err := db.GetBonds(scorer); err != nil || len(bs) != 1 {
+		t.Fatalf("Expected 1 bond for the first claimant, got %d, err = %v", len(bs), err)
+	}
+
+	// Verdicts follow the StoreVerdict rules.
+	newVerdictEvent := func(atFault, innocent tanka.PeerID, maker bool) *tanka.GossipEvent {
+		return &tanka.GossipEvent{
+			Type:   tanka.GossipVerdict,
+			Origin: origin,
+			Stamp:  now,
+			Verdict: &tanka.Verdict{
+				MatchID:  tanka.ID32{0x01},
+				AtFault:  atFault,
+				Innocent: innocent,
+				Maker:    maker,
+				Stamp:    now,
+			},
+		}
+	}
+	store(newVerdictEvent(scorer, scored, true), true)
+	store(newVerdictEvent(scored, scorer, false), false)
+
+	// The current events are synced in the order they were stored. There
+	// should be one score event, one bond event, and one verdict event.
+	evs, cursor, err := db.GossipSince(0, 2)
+	if err != nil {
+		t.Fatalf("GossipSince error: %v", err)
+	}
+	if len(evs) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(evs))
+	}
+	if evs[0].Type != tanka.GossipScore || evs[1].Type != tanka.GossipBond {
+		t.Fatalf("Wrong event types %s, %s", evs[0].Type, evs[1].Type)
+	}
+	if evs[0].Digest() != evB.Digest() {
+		t.Fatalf("Wrong score event synced")
+	}
+	evs, cursor, err = db.GossipSince(cursor, 2)
+	if err != nil {
+		t.Fatalf("GossipSince error: %v", err)
+	}
+	if len(evs) != 1 || evs[0].Type != tanka.GossipVerdict {
+		t.Fatalf("Expected 1 verdict event, got %d events", len(evs))
+	}
+	evs, reCursor, err := db.GossipSince(cursor, 2)
+	if err != nil {
+		t.Fatalf("GossipSince error: %v", err)
+	}
+	if len(evs) != 0 || reCursor != cursor {
+		t.Fatalf("Expected no events and an unchanged cursor, got %d events, cursor %d != %d", len(evs), reCursor, cursor)
+	}
+
+	// Cursors are stored for remote nodes.
+	if c, err := db.GossipCursor(origin); err != nil || c != 0 {
+		t.Fatalf("Expected zero cursor for unsynced node, got %d, err = %v", c, err)
+	}
+	if err := db.SetGossipCursor(origin, cursor); err != nil {
+		t.Fatalf("SetGossipCursor error: %v", err)
+	}
+	if c, err := db.GossipCursor(origin); err != nil || c != cursor {
+		t.Fatalf("Expected cursor %d, got %d, err = %v", cursor, c, err)
+	}
+
+	// The sequence is resumed after a restart.
+	if seq, err := db.lastGossipSeq(); err != nil || seq != db.gossipSeq {
+		t.Fatalf("Expected last sequence %d, got %d, err = %v", db.gossipSeq, seq, err)
+	}
+}
+
+func TestBlacklist(t *testing.T) {
+	db, shutdown := tNewDB()
+	defer shutdown()
+
+	a, b := tanka.PeerID{0x01}, tanka.PeerID{0x02}
+	if err := db.Blacklist(a); err != nil {
+		t.Fatalf("Blacklist error: %v", err)
+	}
+	if banned, err := db.IsBlacklisted(a); err != nil || !banned {
+		t.Fatalf("peer not blacklisted. err = %v", err)
+	}
+	if banned, err := db.IsBlacklisted(b); err != nil || banned {
+		t.Fatalf("wrong peer blacklisted. err = %v", err)
+	}
+	peers, err := db.Blacklisted()
+	if err != nil {
+		t.Fatalf("Blacklisted error: %v", err)
+	}
+	if stamp, found := peers[a]; len(peers) != 1 || !found || time.Since(stamp) > time.Minute {
+		t.Fatalf("wrong blacklist %v", peers)
+	}
+	if err := db.Unblacklist(a); err != nil {
+		t.Fatalf("Unblacklist error: %v", err)
+	}
+	if banned, _ := db.IsBlacklisted(a); banned {
+		t.Fatalf("peer still blacklisted")
+	}
+	// Not an error.
+	if err := db.Unblacklist(b); err != nil {
+		t.Fatalf("Unblacklist error for unlisted peer: %v", err)
+	}
+}
+
+func TestAllBonds(t *testing.T) {
+	db, shutdown := tNewDB()
+	defer shutdown()
+
+	db.StoreBond(newBond(tanka.PeerID{0x01}, 1))
+	db.StoreBond(newBond(tanka.PeerID{0x02}, 2))
+	db.StoreBond(newBond(tanka.PeerID{0x02}, 3, -time.Second))
+	bonds, err := db.AllBonds()
+	if err != nil {
+		t.Fatalf("AllBonds error: %v", err)
+	}
+	if len(bonds) != 2 {
+		t.Fatalf("expected 2 bonds, got %d", len(bonds))
+	}
+}
@@ -0,0 +1,203 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/lexi"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+// gossipRetention is how long a gossip event is kept for syncing to other
+// nodes. The effects of the event on reputation and bonds are not pruned.
+const gossipRetention = time.Hour * 24 * 30
+
+// dbGossip is the current gossip event for a subject, along with the sequence
+// number assigned when it was stored. Sequence numbers are used as sync
+// cursors by other nodes.
+type dbGossip struct {
+	*tanka.GossipEvent
+	seq      uint64
+	received time.Time
+}
+
+func (g *dbGossip) MarshalBinary() ([]byte, error) {
+	const gossipVer = 0
+	evB, err := json.Marshal(g.GossipEvent)
+	if err != nil {
+		return nil, err
+	}
+	var b encode.BuildyBytes = make([]byte, 1, 1+8+8+len(evB)+5)
+	b[0] = gossipVer
+	b = b.AddData(encode.Uint64Bytes(g.seq)).
+		AddData(encode.Uint64Bytes(uint64(g.received.UnixMilli()))).
+		AddData(evB)
+	return b, nil
+}
+
+func (g *dbGossip) UnmarshalBinary(b []byte) error {
+	const gossipVer = 0
+	ver, pushes, err := encode.DecodeBlob(b, 3)
+	if err != nil {
+		return fmt.Errorf("error decoding gossip blob: %w", err)
+	}
+	if ver != gossipVer {
+		return fmt.Errorf("unknown gossip version %d", ver)
+	}
+	if len(pushes) != 3 {
+		return fmt.Errorf("unknown number of gossip blob pushes %d", len(pushes))
+	}
+	g.seq = encode.BytesToUint64(pushes[0])
+	g.received = time.UnixMilli(int64(encode.BytesToUint64(pushes[1])))
+	g.GossipEvent = new(tanka.GossipEvent)
+	return json.Unmarshal(pushes[2], g.GossipEvent)
+}
+
+// supersedes checks whether the event should replace the current event for
+// the subject. Score events are ordered by stamp, with ties broken by event ID
+// so that every node makes the same choice. A bond belongs to the first peer
+// it is announced for, but a later announcement for the same peer updates it.
+// Verdicts are resolved by StoreVerdict.
+func supersedes(ev, cur *tanka.GossipEvent) bool {
+	evID, curID := ev.Digest(), cur.Digest()
+	if evID == curID {
+		return false
+	}
+	switch ev.Type {
+	case tanka.GossipVerdict:
+		return true
+	case tanka.GossipBond:
+		if ev.Bond.PeerID != cur.Bond.PeerID {
+			return false
+		}
+	}
+	if ev.Stamp.Equal(cur.Stamp) {
+		return bytes.Compare(evID[:], curID[:]) > 0
+	}
+	return ev.Stamp.After(cur.Stamp)
+}
+
+// StoreGossip applies the gossip event to the reputation and bond data, and
+// stores it for syncing to other nodes. The returned bool is false if the
+// event was a duplicate or lost a conflict with an existing event, in which
+// case it should not be relayed.
+func (d *DB) StoreGossip(ev *tanka.GossipEvent) (bool, error) {
+	d.gossipMtx.Lock()
+	defer d.gossipMtx.Unlock()
+	if time.Since(ev.Stamp) > gossipRetention {
+		// The current event for the subject may have been pruned.
+		return false, nil
+	}
+	k := ev.Subject()
+	var cur dbGossip
+	err := d.gossip.Get(k, &cur)
+	switch {
+	case err == nil:
+		if !supersedes(ev, cur.GossipEvent) {
+			return false, nil
+		}
+	case !errors.Is(err, lexi.ErrKeyNotFound):
+		return false, fmt.Errorf("error retrieving current gossip event: %w", err)
+	}
+	switch ev.Type {
+	case tanka.GossipScore:
+		if err := d.SetScore(ev.Score.Scored, ev.Score.Scorer, ev.Score.Score, ev.Stamp); err != nil {
+			return false, err
+		}
+	case tanka.GossipVerdict:
+		stored, err := d.StoreVerdict(ev.Verdict)
+		if err != nil || !stored {
+			return false, err
+		}
+	case tanka.GossipBond:
+		if err := d.StoreBond(ev.Bond); err != nil {
+			return false, err
+		}
+	default:
+		return false, fmt.Errorf("unknown gossip type %d", ev.Type)
+	}
+	d.gossipSeq++
+	return true, d.gossip.Set(k, &dbGossip{GossipEvent: ev, seq: d.gossipSeq, received: time.Now()}, lexi.WithReplace())
+}
+
+// GossipSince retrieves up to n stored events with a sequence number of at
+// least cursor, in the order they were stored. The returned cursor is the
+// cursor for the next call.
+func (d *DB) GossipSince(cursor uint64, n int) ([]*tanka.GossipEvent, uint64, error) {
+	evs := make([]*tanka.GossipEvent, 0)
+	err := d.gossipSeqIdx.Iterate(nil, func(it *lexi.Iter) error {
+		if len(evs) >= n {
+			return lexi.ErrEndIteration
+		}
+		return it.V(func(vB []byte) error {
+			var g dbGossip
+			if err := g.UnmarshalBinary(vB); err != nil {
+				return fmt.Errorf("error unmarshaling gossip event: %w", err)
+			}
+			evs = append(evs, g.GossipEvent)
+			cursor = g.seq + 1
+			return nil
+		})
+	}, lexi.WithSeek(encode.Uint64Bytes(cursor)))
+	return evs, cursor, err
+}
+
+// GossipCursor is the sync cursor for the node's gossip events. The cursor is
+// zero if the node has never been synced.
+func (d *DB) GossipCursor(peerID tanka.PeerID) (uint64, error) {
+	b, err := d.gossipCursors.GetRaw(peerID[:])
+	if err != nil {
+		if errors.Is(err, lexi.ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid cursor length %d", len(b))
+	}
+	return encode.BytesToUint64(b), nil
+}
+
+// SetGossipCursor stores the sync cursor for the node's gossip events.
+func (d *DB) SetGossipCursor(peerID tanka.PeerID, cursor uint64) error {
+	return d.gossipCursors.Set(peerID[:], encode.Uint64Bytes(cursor), lexi.WithReplace())
+}
+
+// lastGossipSeq is the highest stored sequence number.
+func (d *DB) lastGossipSeq() (seq uint64, err error) {
+	err = d.gossipSeqIdx.Iterate(nil, func(it *lexi.Iter) error {
+		if err := it.Entry(func(idxB []byte) error {
+			seq = encode.BytesToUint64(idxB)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return lexi.ErrEndIteration
+	}, lexi.WithReverse())
+	return seq, err
+}
+
+func (d *DB) pruneOldGossip() {
+	cutoff := time.Now().Add(-gossipRetention)
+	if err := d.gossip.Iterate(nil, func(it *lexi.Iter) error {
+		return it.V(func(vB []byte) error {
+			var g dbGossip
+			if err := g.UnmarshalBinary(vB); err != nil {
+				return fmt.Errorf("error unmarshaling gossip event: %w", err)
+			}
+			if g.received.Before(cutoff) {
+				return it.Delete()
+			}
+			return nil
+		})
+	}, lexi.WithUpdate()); err != nil {
+		d.log.Errorf("Error pruning gossip events: %v", err)
+	}
+}
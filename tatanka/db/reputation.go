@@ -44,7 +44,7 @@ func (d *DB) Reputation(scored tanka.PeerID) (*tanka.Reputation, error) {
 			if len(vB) != 1 {
 				return fmt.Errorf("score not a single byte. length = %d", len(vB))
 			}
-			agg.Score += int64(vB[0])
+			agg.Score += int64(int8(vB[0]))
 			return nil
 		}); err != nil {
 			return err
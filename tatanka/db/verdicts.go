@@ -0,0 +1,113 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"decred.org/dcrdex/dex/encode"
+	"decred.org/dcrdex/dex/lexi"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+// scoreVerdict is the score recorded against the at-fault party of a dispute.
+const scoreVerdict int8 = math.MinInt8
+
+// verdictScorer is the pseudo-scorer under which a verdict is recorded in the
+// scores table. The leading zero byte is not a valid public key prefix, so
+// there is no collision with a real peer.
+func verdictScorer(matchID tanka.ID32) (scorer tanka.PeerID) {
+	copy(scorer[1:], matchID[:])
+	return
+}
+
+type dbVerdict struct {
+	*tanka.Verdict
+}
+
+func (v *dbVerdict) MarshalBinary() ([]byte, error) {
+	const verdictVer = 0
+	var maker byte
+	if v.Maker {
+		maker = 1
+	}
+	var b encode.BuildyBytes = make([]byte, 1, 1+32+tanka.PeerIDLength*2+1+8+5)
+	b[0] = verdictVer
+	b = b.AddData(v.MatchID[:]).
+		AddData(v.AtFault[:]).
+		AddData(v.Innocent[:]).
+		AddData([]byte{maker}).
+		AddData(encode.Uint64Bytes(uint64(v.Stamp.UnixMilli())))
+	return b, nil
+}
+
+func (v *dbVerdict) UnmarshalBinary(b []byte) error {
+	const verdictVer = 0
+	v.Verdict = new(tanka.Verdict)
+	ver, pushes, err := encode.DecodeBlob(b, 5)
+	if err != nil {
+		return fmt.Errorf("error decoding verdict blob: %w", err)
+	}
+	if ver != verdictVer {
+		return fmt.Errorf("unknown verdict version %d", ver)
+	}
+	if len(pushes) != 5 {
+		return fmt.Errorf("unknown number of verdict blob pushes %d", len(pushes))
+	}
+	copy(v.MatchID[:], pushes[0])
+	copy(v.AtFault[:], pushes[1])
+	copy(v.Innocent[:], pushes[2])
+	v.Maker = len(pushes[3]) == 1 && pushes[3][0] == 1
+	v.Stamp = time.UnixMilli(int64(encode.BytesToUint64(pushes[4])))
+	return nil
+}
+
+// Verdict retrieves the verdict for the match. A nil verdict is returned if
+// the match has not been disputed.
+func (d *DB) Verdict(matchID tanka.ID32) (*tanka.Verdict, error) {
+	var v dbVerdict
+	if err := d.verdicts.Get(matchID[:], &v); err != nil {
+		if errors.Is(err, lexi.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return v.Verdict, nil
+}
+
+// StoreVerdict stores the verdict for a dispute and records it in the at-fault
+// peer's reputation. The at-fault peer's own score of the innocent party is
+// neutralized. A verdict against the maker takes precedence over a verdict
+// against the taker, because a maker that fails to redeem also leaves the
+// maker's own swap unredeemed. A prior verdict against the taker for the same
+// match is reversed. The returned bool is false if the verdict was not stored
+// because of an existing verdict.
+func (d *DB) StoreVerdict(v *tanka.Verdict) (bool, error) {
+	d.verdictMtx.Lock()
+	defer d.verdictMtx.Unlock()
+	prev, err := d.Verdict(v.MatchID)
+	if err != nil {
+		return false, fmt.Errorf("error retrieving previous verdict: %w", err)
+	}
+	scorer := verdictScorer(v.MatchID)
+	if prev != nil {
+		if prev.AtFault == v.AtFault || prev.Maker {
+			return false, nil
+		}
+		// The score may have already been pruned.
+		if err := d.scores.Delete(append(prev.AtFault[:], scorer[:]...)); err != nil && !errors.Is(err, lexi.ErrKeyNotFound) {
+			return false, fmt.Errorf("error reversing previous verdict: %w", err)
+		}
+	}
+	if err := d.verdicts.Set(v.MatchID[:], &dbVerdict{v}, lexi.WithReplace()); err != nil {
+		return false, err
+	}
+	if err := d.SetScore(v.AtFault, scorer, scoreVerdict, v.Stamp); err != nil {
+		return false, err
+	}
+	return true, d.SetScore(v.Innocent, v.AtFault, 0, v.Stamp)
+}
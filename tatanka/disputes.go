@@ -0,0 +1,209 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package tatanka
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/msgjson"
+	"decred.org/dcrdex/tatanka/chain"
+	"decred.org/dcrdex/tatanka/mj"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+/*
+	Swap outcomes are self-reported by the parties, so a peer could falsely
+	report a counterparty's failure. A party that can prove the failure
+	on-chain files a dispute instead. The dispute includes the accused
+	counterparty's signed SwapCommitment and the swap contract that the
+	accused failed to act on. Every tatanka node audits the contract through
+	its own chain backend, and only the at-fault party is penalized.
+
+	An accused maker is at fault if the taker's swap paid the maker's
+	committed address with the maker's secret hash, and expired without the
+	maker redeeming it. An accused taker is at fault if the maker's swap paid
+	the taker's committed address, and expired without the taker redeeming
+	it. A taker that participated but was never redeemed can file its own
+	dispute against the maker, which reverses the verdict against the taker.
+*/
+
+// disputeResponseWindow is the minimum time between a contract being mined
+// and its expiration for the accused to be held responsible for acting on it.
+func disputeResponseWindow(net dex.Network) time.Duration {
+	return dex.LockTimeTaker(net) / 2
+}
+
+// checkDispute performs the checks on a dispute that don't require an audit.
+func checkDispute(reporter tanka.PeerID, d *mj.Dispute) error {
+	if d.Commitment == nil || d.Contract == nil {
+		return errors.New("incomplete dispute")
+	}
+	c := d.Commitment
+	if c.Counterparty != reporter {
+		return errors.New("commitment was not made to the reporter")
+	}
+	if c.Signer == reporter {
+		return errors.New("self-dispute")
+	}
+	if c.AssetID != d.Contract.AssetID {
+		return fmt.Errorf("contract asset %d is not the committed asset %d", d.Contract.AssetID, c.AssetID)
+	}
+	if c.Maker && len(c.SecretHash) != sha256.Size {
+		return fmt.Errorf("invalid secret hash length %d", len(c.SecretHash))
+	}
+	if err := c.Verify(); err != nil {
+		return fmt.Errorf("invalid commitment signature: %w", err)
+	}
+	return nil
+}
+
+// judgeDispute checks whether the accused party is at fault for the audited
+// contract not being redeemed. A nil error indicates the accused is at fault.
+func judgeDispute(c *mj.SwapCommitment, htlc *tanka.HTLCStatus, window time.Duration, now time.Time) error {
+	if htlc.Recipient != c.Address {
+		return fmt.Errorf("contract recipient %s is not the committed address %s", htlc.Recipient, c.Address)
+	}
+	if htlc.Value < c.Value {
+		return fmt.Errorf("contract value %d is less than the committed value %d", htlc.Value, c.Value)
+	}
+	if htlc.Redeemed {
+		return errors.New("contract was redeemed")
+	}
+	if now.Before(htlc.LockTime) {
+		return fmt.Errorf("contract does not expire until %s", htlc.LockTime)
+	}
+	if htlc.LockTime.Sub(htlc.BlockTime) < window {
+		return fmt.Errorf("contract mined at %s, too close to expiration at %s", htlc.BlockTime, htlc.LockTime)
+	}
+	if c.Maker {
+		if !bytes.Equal(htlc.SecretHash, c.SecretHash) {
+			return fmt.Errorf("contract secret hash %x is not the committed secret hash %x", htlc.SecretHash, c.SecretHash)
+		}
+		if htlc.LockTime.Unix() >= c.LockTime.Unix() {
+			return fmt.Errorf("contract expiration %s is not before the maker's expiration %s", htlc.LockTime, c.LockTime)
+		}
+		return nil
+	}
+	if htlc.LockTime.Unix() < c.LockTime.Unix() {
+		return fmt.Errorf("contract expiration %s is before the committed minimum %s", htlc.LockTime, c.LockTime)
+	}
+	if htlc.BlockTime.Unix() > c.Deadline.Unix() {
+		return fmt.Errorf("contract mined at %s, after the deadline %s", htlc.BlockTime, c.Deadline)
+	}
+	return nil
+}
+
+// handleDispute handles a dispute from a locally-connected client. The dispute
+// is shared with the other tatanka nodes, which audit it independently.
+func (t *Tatanka) handleDispute(c *client, msg *msgjson.Message) {
+	reporter := c.peer.ID
+	var d mj.Dispute
+	if err := msg.Unmarshal(&d); err != nil {
+		t.log.Errorf("error unmarshaling dispute from %s: %v", reporter, err)
+		return
+	}
+	if err := checkDispute(reporter, &d); err != nil {
+		t.log.Errorf("Invalid dispute from %s: %v", reporter, err)
+		return
+	}
+
+	note := mj.MustNotification(mj.RouteShareDispute, &mj.SharedDispute{
+		Reporter: reporter,
+		Dispute:  &d,
+	})
+	for _, tt := range t.tatankaNodes() {
+		if err := t.send(tt, note); err != nil {
+			t.log.Errorf("error sharing dispute with %s: %v", tt.ID, err)
+		}
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.resolveDispute(reporter, &d)
+	}()
+}
+
+// handleShareDispute handles a dispute shared by another tatanka node.
+func (t *Tatanka) handleShareDispute(tt *remoteTatanka, msg *msgjson.Message) {
+	var sd mj.SharedDispute
+	if err := msg.Unmarshal(&sd); err != nil || sd.Dispute == nil {
+		t.log.Errorf("error unmarshaling shared dispute from %s: %v", tt.ID, err)
+		return
+	}
+	if err := checkDispute(sd.Reporter, sd.Dispute); err != nil {
+		t.log.Errorf("Invalid dispute shared by %s: %v", tt.ID, err)
+		return
+	}
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.resolveDispute(sd.Reporter, sd.Dispute)
+	}()
+}
+
+// resolveDispute audits the disputed contract and records a verdict if the
+// accused is at fault.
+func (t *Tatanka) resolveDispute(reporter tanka.PeerID, d *mj.Dispute) {
+	c := d.Commitment
+	t.chainMtx.RLock()
+	ch := t.chains[chain.ChainID(d.Contract.AssetID)]
+	t.chainMtx.RUnlock()
+	if ch == nil {
+		t.log.Warnf("Cannot audit dispute of match %s for unknown chain %d", c.MatchID, d.Contract.AssetID)
+		return
+	}
+	htlc, err := ch.AuditHTLC(d.Contract)
+	if err != nil {
+		t.log.Infof("Audit failed for dispute of match %s by %s against %s: %v", c.MatchID, reporter, c.Signer, err)
+		return
+	}
+	if err := judgeDispute(c, htlc, disputeResponseWindow(t.net), time.Now()); err != nil {
+		t.log.Infof("Rejected dispute of match %s by %s against %s: %v", c.MatchID, reporter, c.Signer, err)
+		return
+	}
+	// Storing the verdict also updates the reputations of any locally-connected
+	// parties, and shares the verdict with nodes that might have missed the
+	// dispute.
+	stored, err := t.gossipEvent(nil, &tanka.Verdict{
+		MatchID:  c.MatchID,
+		AtFault:  c.Signer,
+		Innocent: reporter,
+		Maker:    c.Maker,
+		Stamp:    time.UnixMilli(time.Now().UnixMilli()),
+	}, nil)
+	if err != nil {
+		t.log.Errorf("Error storing verdict for match %s: %v", c.MatchID, err)
+		return
+	}
+	if !stored {
+		t.log.Debugf("Verdict for match %s against %s not stored because of an existing verdict", c.MatchID, c.Signer)
+		return
+	}
+	t.log.Infof("%s found at fault for match %s disputed by %s", c.Signer, c.MatchID, reporter)
+}
+
+// refreshReputation updates the reputation of a locally-connected client from
+// the database.
+func (t *Tatanka) refreshReputation(peerID tanka.PeerID) {
+	t.clientMtx.RLock()
+	c, found := t.clients[peerID]
+	t.clientMtx.RUnlock()
+	if !found {
+		return
+	}
+	rep, err := t.db.Reputation(peerID)
+	if err != nil {
+		t.log.Errorf("error getting reputation for %s: %v", peerID, err)
+		return
+	}
+	c.mtx.Lock()
+	c.Reputation = rep
+	c.mtx.Unlock()
+}
@@ -0,0 +1,206 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package tatanka
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/dex/msgjson"
+	"decred.org/dcrdex/tatanka/chain"
+	"decred.org/dcrdex/tatanka/mj"
+	"decred.org/dcrdex/tatanka/tanka"
+)
+
+/*
+	Each tatanka node keeps its own database of scores, verdicts, and bonds.
+	To give every node a mesh-wide view of a peer, the node that accepts a
+	score, verdict, or bond creates a signed gossip event and sends it to the
+	other tatanka nodes. A node receiving a new event relays it to the nodes
+	other than the sender, so events reach nodes that aren't directly
+	connected to the origin. Events that are duplicates, or that lose a
+	conflict with the node's current event for the same subject, are not
+	relayed, which ends the flood.
+
+	A node that was offline catches up by requesting the events stored by a
+	remote node since the last sync, each time it connects to the remote
+	node.
+*/
+
+// gossipSyncBatch is the maximum number of events in a GossipSyncResult.
+const gossipSyncBatch = 500
+
+// gossipEvent creates a gossip event originating with this node, applies it,
+// and shares it with the other tatanka nodes. The returned bool is false if
+// the event was not applied because of an existing event.
+func (t *Tatanka) gossipEvent(score *tanka.ScoreEvent, verdict *tanka.Verdict, bond *tanka.Bond) (bool, error) {
+	ev, err := tanka.NewGossipEvent(t.priv, score, verdict, bond)
+	if err != nil {
+		return false, err
+	}
+	return t.processGossip(ev, t.id)
+}
+
+// checkGossip checks that an event received from another tatanka node is
+// valid.
+func (t *Tatanka) checkGossip(ev *tanka.GossipEvent) error {
+	if err := ev.Verify(); err != nil {
+		return err
+	}
+	if _, found := t.whitelist[ev.Origin]; !found && ev.Origin != t.id {
+		return fmt.Errorf("unknown origin %s", ev.Origin)
+	}
+	if time.Until(ev.Stamp) > tanka.EpochLength {
+		return fmt.Errorf("stamp %s is in the future", ev.Stamp)
+	}
+	if ev.Type != tanka.GossipBond {
+		return nil
+	}
+	b := ev.Bond
+	if b.Expiration.Before(time.Now()) {
+		return errors.New("bond expired")
+	}
+	// Bonds are checked again if we have the chain.
+	t.chainMtx.RLock()
+	ch := t.chains[chain.ChainID(b.AssetID)]
+	t.chainMtx.RUnlock()
+	if ch == nil {
+		return nil
+	}
+	if err := ch.CheckBond(b); err != nil {
+		return fmt.Errorf("bond %s for %s didn't pass validation for chain %d: %w", b.CoinID, b.PeerID, b.AssetID, err)
+	}
+	return nil
+}
+
+// processGossip applies the event, updating any affected local clients. A new
+// event is relayed to the other tatanka nodes, except the node it was received
+// from and the origin.
+func (t *Tatanka) processGossip(ev *tanka.GossipEvent, from tanka.PeerID) (bool, error) {
+	stored, err := t.db.StoreGossip(ev)
+	if err != nil || !stored {
+		return false, err
+	}
+
+	switch ev.Type {
+	case tanka.GossipScore:
+		t.refreshReputation(ev.Score.Scored)
+	case tanka.GossipVerdict:
+		t.refreshReputation(ev.Verdict.AtFault)
+		t.refreshReputation(ev.Verdict.Innocent)
+	case tanka.GossipBond:
+		t.refreshBonds(ev.Bond.PeerID)
+	}
+
+	note := mj.MustNotification(mj.RouteGossip, ev)
+	for _, tt := range t.tatankaNodes() {
+		if tt.ID == from || tt.ID == ev.Origin {
+			continue
+		}
+		if err := t.send(tt, note); err != nil {
+			t.log.Errorf("error sending %s gossip to %s: %v", ev.Type, tt.ID, err)
+		}
+	}
+	return true, nil
+}
+
+// refreshBonds updates the bonds of a locally-connected client from the
+// database.
+func (t *Tatanka) refreshBonds(peerID tanka.PeerID) {
+	c := t.clientNode(peerID)
+	if c == nil {
+		return
+	}
+	bonds, err := t.db.GetBonds(peerID)
+	if err != nil {
+		t.log.Errorf("error getting bonds for %s: %v", peerID, err)
+		return
+	}
+	c.updateBonds(bonds)
+}
+
+// handleGossip handles a gossip event sent or relayed by another tatanka node.
+func (t *Tatanka) handleGossip(tt *remoteTatanka, msg *msgjson.Message) {
+	var ev tanka.GossipEvent
+	if err := msg.Unmarshal(&ev); err != nil {
+		t.log.Errorf("error unmarshaling gossip from %s: %v", tt.ID, err)
+		return
+	}
+	if err := t.checkGossip(&ev); err != nil {
+		t.log.Errorf("Invalid %s gossip from %s: %v", ev.Type, tt.ID, err)
+		return
+	}
+	if _, err := t.processGossip(&ev, tt.ID); err != nil {
+		t.log.Errorf("Error processing %s gossip from %s: %v", ev.Type, tt.ID, err)
+	}
+}
+
+// handleGossipSync responds with the gossip events stored since the requested
+// cursor.
+func (t *Tatanka) handleGossipSync(tt *remoteTatanka, msg *msgjson.Message) *msgjson.Error {
+	var req mj.GossipSync
+	if err := msg.Unmarshal(&req); err != nil {
+		return msgjson.NewError(mj.ErrBadRequest, "unmarshal error: %v", err)
+	}
+	evs, cursor, err := t.db.GossipSince(req.Cursor, gossipSyncBatch)
+	if err != nil {
+		t.log.Errorf("Error retrieving gossip since %d for %s: %v", req.Cursor, tt.ID, err)
+		return msgjson.NewError(mj.ErrInternal, "internal error")
+	}
+	t.sendResult(tt, msg.ID, &mj.GossipSyncResult{
+		Events: evs,
+		Cursor: cursor,
+		More:   len(evs) == gossipSyncBatch,
+	})
+	return nil
+}
+
+// syncGossip requests the gossip events that the remote tatanka node has
+// stored since the last sync.
+func (t *Tatanka) syncGossip(tt *remoteTatanka) {
+	cursor, err := t.db.GossipCursor(tt.ID)
+	if err != nil {
+		t.log.Errorf("Error retrieving gossip cursor for %s: %v", tt.ID, err)
+		return
+	}
+	var n int
+	for {
+		var res mj.GossipSyncResult
+		req := mj.MustRequest(mj.RouteGossipSync, &mj.GossipSync{Cursor: cursor})
+		sent, resErr, err := t.requestAnyOne([]tanka.Sender{tt}, req, &res)
+		if resErr != nil {
+			err = resErr
+		}
+		if !sent {
+			if err != nil {
+				t.log.Errorf("Error syncing gossip from %s: %v", tt.ID, err)
+			}
+			return
+		}
+		for _, ev := range res.Events {
+			if err := t.checkGossip(ev); err != nil {
+				t.log.Warnf("Invalid %s gossip synced from %s: %v", ev.Type, tt.ID, err)
+				continue
+			}
+			stored, err := t.processGossip(ev, tt.ID)
+			if err != nil {
+				t.log.Errorf("Error processing %s gossip synced from %s: %v", ev.Type, tt.ID, err)
+				return
+			}
+			if stored {
+				n++
+			}
+		}
+		cursor = res.Cursor
+		if err := t.db.SetGossipCursor(tt.ID, cursor); err != nil {
+			t.log.Errorf("Error storing gossip cursor for %s: %v", tt.ID, err)
+			return
+		}
+		if !res.More || len(res.Events) == 0 {
+			break
+		}
+	}
+	t.log.Debugf("Synced %d new gossip events from %s", n, tt.ID)
+}
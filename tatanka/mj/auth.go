@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"decred.org/dcrdex/dex/msgjson"
+	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
@@ -42,3 +43,53 @@ func CheckSig(msg *msgjson.Message, pubKey *secp256k1.PublicKey) error {
 	}
 	return nil
 }
+
+// Digest is the hash of the serialized commitment, without the signature.
+func (c *SwapCommitment) Digest() [32]byte {
+	b := make([]byte, 0, 32+2*tanka.PeerIDLength+1+4+1+len(c.Address)+8+1+len(c.SecretHash)+16)
+	b = append(b, c.MatchID[:]...)
+	b = append(b, c.Signer[:]...)
+	b = append(b, c.Counterparty[:]...)
+	if c.Maker {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	b = binary.BigEndian.AppendUint32(b, c.AssetID)
+	// Variable-length fields are length-prefixed.
+	b = append(b, byte(len(c.Address)))
+	b = append(b, []byte(c.Address)...)
+	b = binary.BigEndian.AppendUint64(b, c.Value)
+	b = append(b, byte(len(c.SecretHash)))
+	b = append(b, c.SecretHash...)
+	b = binary.BigEndian.AppendUint64(b, uint64(c.LockTime.Unix()))
+	var deadline uint64
+	if !c.Deadline.IsZero() {
+		deadline = uint64(c.Deadline.Unix())
+	}
+	b = binary.BigEndian.AppendUint64(b, deadline)
+	return sha256.Sum256(b)
+}
+
+// Sign signs the commitment.
+func (c *SwapCommitment) Sign(priv *secp256k1.PrivateKey) {
+	h := c.Digest()
+	c.Sig = ecdsa.Sign(priv, h[:]).Serialize()
+}
+
+// Verify checks that the commitment was signed by the Signer.
+func (c *SwapCommitment) Verify() error {
+	pubKey, err := secp256k1.ParsePubKey(c.Signer[:])
+	if err != nil {
+		return fmt.Errorf("error parsing signer's public key: %w", err)
+	}
+	signature, err := ecdsa.ParseDERSignature(c.Sig)
+	if err != nil {
+		return fmt.Errorf("error decoding secp256k1 Signature from bytes: %w", err)
+	}
+	h := c.Digest()
+	if !signature.Verify(h[:], pubKey) {
+		return fmt.Errorf("secp256k1 signature verification failed")
+	}
+	return nil
+}
@@ -38,7 +38,9 @@ const (
 	RouteRelayBroadcast   = "relay_broadcast"
 	RouteRelayTankagram   = "relay_tankagram"
 	RoutePathInquiry      = "path_inquiry"
-	RouteShareScore       = "share_score"
+	RouteShareDispute     = "share_dispute"
+	RouteGossip           = "gossip"
+	RouteGossipSync       = "gossip_sync"
 
 	// tatanka <=> client
 	RouteConnect             = "connect"
@@ -49,6 +51,7 @@ const (
 	RouteRates               = "rates"
 	RouteSetScore            = "set_score"
 	RouteFeeRateEstimate     = "fee_rate_estimate"
+	RouteDispute             = "dispute"
 
 	// client1 <=> tatankanode <=> client2
 	RouteTankagram     = "tankagram"
@@ -56,6 +59,9 @@ const (
 	RouteNegotiate     = "negotiate"
 	RouteBroadcast     = "broadcast"
 	RouteNewSubscriber = "new_subscriber"
+	RouteSwapAddress   = "swap_address"
+	RouteSwapContract  = "swap_contract"
+	RouteSwapRedeem    = "swap_redeem"
 
 	// HTTP Requests, used before client established WS connection
 	RouteNodeInfo = "node_info"
@@ -72,6 +78,7 @@ type BroadcastMessageType string
 const (
 	MessageTypeTrollBox      BroadcastMessageType = "troll_box"
 	MessageTypeNewOrder      BroadcastMessageType = "new_order"
+	MessageTypeOrderUpdate   BroadcastMessageType = "order_update"
 	MessageTypeNewSubscriber BroadcastMessageType = "new_subscriber"
 	MessageTypeUnsubTopic    BroadcastMessageType = "unsub_topic"
 	MessageTypeUnsubSubject  BroadcastMessageType = "unsub_subject"
@@ -91,6 +98,25 @@ type TatankaConfig struct {
 	BondTier uint64 `json:"bondTier"`
 }
 
+// BootNode represents a configured boot node. Tatanka is whitelist only, and
+// node operators are responsible for keeping their whitelist up to date.
+type BootNode struct {
+	// Protocol is one of ("ws", "wss"), though other tatanka comms protocols
+	// may be implemented later. Or we may end up using e.g. go-libp2p.
+	Protocol string    `json:"protocol"`
+	PeerID   dex.Bytes `json:"peer_id"`
+	// Config can take different forms depending on the comms protocol, but is
+	// probably a tcp.RemoteNodeConfig.
+	Config json.RawMessage `json:"config"`
+}
+
+// NodeInfoResponse is the response to a NodeInfo request.
+type NodeInfoResponse struct {
+	Capacity  uint64      `json:"capacity"`
+	Chains    []uint32    `json:"chains"`
+	Whitelist []*BootNode `json:"whitelist"`
+}
+
 type Connect struct {
 	ID          tanka.PeerID                    `json:"id"`
 	InitialSubs map[tanka.Topic][]tanka.Subject `json:"initialSubs"`
@@ -221,13 +247,98 @@ type ScoreReport struct {
 	Score  int8         `json:"score"`
 }
 
-// SharedScore is a scorer-scored tuple shared between tatanka nodes, along
-// with the sending tatanka's new view of the scored peer's reputation.
-type SharedScore struct {
-	Scorer     tanka.PeerID      `json:"scorer"`
-	Scored     tanka.PeerID      `json:"scored"`
-	Score      int8              `json:"score"`
-	Reputation *tanka.Reputation `json:"rep"`
+// SwapAddress is sent by the participant in a mesh swap to tell the initiator
+// where to send the initiator's contract.
+type SwapAddress struct {
+	MatchID    tanka.ID32      `json:"matchID"`
+	Address    string          `json:"address"`
+	Commitment *SwapCommitment `json:"commitment"`
+}
+
+// SwapContract is sent by either party in a mesh swap after broadcasting
+// their swap contract. The initiator includes the address where the
+// participant should send the participant's contract.
+type SwapContract struct {
+	MatchID  tanka.ID32 `json:"matchID"`
+	CoinID   dex.Bytes  `json:"coinID"`
+	Contract dex.Bytes  `json:"contract"`
+	Address  string     `json:"address,omitempty"`
+	// Commitment is the initiator's commitment. The participant's commitment
+	// was sent with the SwapAddress.
+	Commitment *SwapCommitment `json:"commitment,omitempty"`
+}
+
+// SwapRedeem is sent by the initiator in a mesh swap after redeeming the
+// participant's contract, revealing the secret.
+type SwapRedeem struct {
+	MatchID tanka.ID32 `json:"matchID"`
+	CoinID  dex.Bytes  `json:"coinID"`
+	Secret  dex.Bytes  `json:"secret"`
+}
+
+// SwapCommitment is a swap party's signed statement of the terms under which
+// it will act in a swap. The parties exchange commitments so that either can
+// prove the other's obligations to Tatanka nodes in a Dispute.
+type SwapCommitment struct {
+	MatchID tanka.ID32 `json:"matchID"`
+	// Signer is the peer making the commitment, and Counterparty is the peer
+	// to whom it is made.
+	Signer       tanka.PeerID `json:"signer"`
+	Counterparty tanka.PeerID `json:"counterparty"`
+	// Maker is true if the signer is the maker (initiator).
+	Maker bool `json:"maker"`
+	// AssetID and Address are where the signer receives the counterparty's
+	// swap, and Value is the minimum value of that swap.
+	AssetID uint32 `json:"assetID"`
+	Address string `json:"address"`
+	Value   uint64 `json:"value"`
+	// SecretHash is the maker's secret hash. Empty for the taker.
+	SecretHash dex.Bytes `json:"secretHash,omitempty"`
+	// LockTime is, for the maker, the lock time of the maker's swap, before
+	// which the taker's swap must expire. For the taker, it is the earliest
+	// lock time accepted for the maker's swap.
+	LockTime time.Time `json:"lockTime"`
+	// Deadline is the time by which the maker's swap must be mined, after
+	// which the taker may abandon the swap. Zero for the maker.
+	Deadline time.Time `json:"deadline,omitempty"`
+	Sig      dex.Bytes `json:"sig"`
+}
+
+// Dispute is sent by a swap party to its Tatanka node when the counterparty
+// fails to complete a swap. The Tatanka nodes audit the Contract on-chain
+// against the accused counterparty's Commitment.
+type Dispute struct {
+	// Commitment is the accused counterparty's commitment, made to the
+	// reporter. The Signer is the accused.
+	Commitment *SwapCommitment `json:"commitment"`
+	// Contract is the swap contract that the accused failed to act on. For an
+	// accused maker, it is the taker's swap, which the maker did not redeem.
+	// For an accused taker, it is the maker's swap, which the taker did not
+	// redeem, or which was left to expire without the taker participating.
+	Contract *tanka.HTLCAudit `json:"contract"`
+}
+
+// SharedDispute is a Dispute forwarded to the other tatanka nodes, which audit
+// the contract independently.
+type SharedDispute struct {
+	Reporter tanka.PeerID `json:"reporter"`
+	Dispute  *Dispute     `json:"dispute"`
+}
+
+// GossipSync is a request for the gossip events that a tatanka node has stored
+// since the cursor. The cursor is opaque to the requester, and is the Cursor
+// returned in the last GossipSyncResult from the node.
+type GossipSync struct {
+	Cursor uint64 `json:"cursor"`
+}
+
+// GossipSyncResult is the response to a GossipSync request.
+type GossipSyncResult struct {
+	Events []*tanka.GossipEvent `json:"events"`
+	Cursor uint64               `json:"cursor"`
+	// More indicates that the response was truncated, and another request
+	// should be made with the new Cursor.
+	More bool `json:"more"`
 }
 
 func MustRequest(route string, payload any) *msgjson.Message {
@@ -22,7 +22,8 @@ type peer struct {
 func (p *peer) banned() bool {
 	p.mtx.RLock()
 	defer p.mtx.RUnlock()
-	// NEED TO CONSIDER *RemoteReputations....
+	// Reputation and Bonds include the events gossiped by other tatanka
+	// nodes, so this is the mesh-wide view.
 	tier := calcTier(p.Reputation, p.BondTier())
 	return tier <= 0
 }
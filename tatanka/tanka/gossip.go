@@ -0,0 +1,199 @@
+// This code is available on the terms of the project LICENSE.md file,
+// also available online at https://blueoakcouncil.org/license/1.0.0.
+
+package tanka
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"decred.org/dcrdex/dex"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// GossipType is the type of a GossipEvent.
+type GossipType uint8
+
+const (
+	GossipScore GossipType = iota + 1
+	GossipVerdict
+	GossipBond
+)
+
+func (t GossipType) String() string {
+	switch t {
+	case GossipScore:
+		return "score"
+	case GossipVerdict:
+		return "verdict"
+	case GossipBond:
+		return "bond"
+	}
+	return fmt.Sprintf("unknown gossip type %d", uint8(t))
+}
+
+// ScoreEvent is a client's score of a peer.
+type ScoreEvent struct {
+	Scorer PeerID `json:"scorer"`
+	Scored PeerID `json:"scored"`
+	Score  int8   `json:"score"`
+}
+
+// GossipEvent is a reputation event or bond announcement that is shared
+// between Tatanka nodes, so that every node has a mesh-wide view of a peer's
+// reputation and bonds. The event is signed by the originating node, so it can
+// be relayed by any node.
+type GossipEvent struct {
+	Type   GossipType `json:"type"`
+	Origin PeerID     `json:"origin"`
+	Stamp  time.Time  `json:"stamp"`
+	// Only the field for the Type is populated.
+	Score   *ScoreEvent `json:"score,omitempty"`
+	Verdict *Verdict    `json:"verdict,omitempty"`
+	Bond    *Bond       `json:"bond,omitempty"`
+	Sig     dex.Bytes   `json:"sig"`
+}
+
+// NewGossipEvent creates a signed event. Exactly one of the score, verdict, or
+// bond should be non-nil.
+func NewGossipEvent(priv *secp256k1.PrivateKey, score *ScoreEvent, verdict *Verdict, bond *Bond) (*GossipEvent, error) {
+	var origin PeerID
+	copy(origin[:], priv.PubKey().SerializeCompressed())
+	ev := &GossipEvent{
+		Origin:  origin,
+		Stamp:   time.UnixMilli(time.Now().UnixMilli()),
+		Score:   score,
+		Verdict: verdict,
+		Bond:    bond,
+	}
+	switch {
+	case score != nil:
+		ev.Type = GossipScore
+	case verdict != nil:
+		ev.Type = GossipVerdict
+	case bond != nil:
+		ev.Type = GossipBond
+	}
+	if err := ev.check(); err != nil {
+		return nil, err
+	}
+	h := ev.Digest()
+	ev.Sig = ecdsa.Sign(priv, h[:]).Serialize()
+	return ev, nil
+}
+
+// check checks that the populated fields match the Type.
+func (ev *GossipEvent) check() error {
+	var n int
+	for _, populated := range []bool{ev.Score != nil, ev.Verdict != nil, ev.Bond != nil} {
+		if populated {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("%d gossip event payloads", n)
+	}
+	switch ev.Type {
+	case GossipScore:
+		if ev.Score == nil {
+			return errors.New("no score")
+		}
+	case GossipVerdict:
+		if ev.Verdict == nil {
+			return errors.New("no verdict")
+		}
+	case GossipBond:
+		if ev.Bond == nil {
+			return errors.New("no bond")
+		}
+		if len(ev.Bond.CoinID) == 0 || len(ev.Bond.CoinID) > 255 {
+			return fmt.Errorf("invalid bond coin ID length %d", len(ev.Bond.CoinID))
+		}
+	default:
+		return errors.New(ev.Type.String())
+	}
+	return nil
+}
+
+// Subject identifies what the event is about. For a given subject, only one
+// event is current.
+func (ev *GossipEvent) Subject() []byte {
+	b := []byte{byte(ev.Type)}
+	switch ev.Type {
+	case GossipScore:
+		b = append(b, ev.Score.Scored[:]...)
+		return append(b, ev.Score.Scorer[:]...)
+	case GossipVerdict:
+		return append(b, ev.Verdict.MatchID[:]...)
+	case GossipBond:
+		b = binary.BigEndian.AppendUint32(b, ev.Bond.AssetID)
+		return append(b, ev.Bond.CoinID...)
+	}
+	return b
+}
+
+// Digest is the hash of the serialized event, without the signature. The
+// Digest also serves as the event ID.
+func (ev *GossipEvent) Digest() [32]byte {
+	unixOrZero := func(t time.Time) uint64 {
+		if t.IsZero() {
+			return 0
+		}
+		return uint64(t.Unix())
+	}
+	b := make([]byte, 0, 1+PeerIDLength+8+128)
+	b = append(b, byte(ev.Type))
+	b = append(b, ev.Origin[:]...)
+	b = binary.BigEndian.AppendUint64(b, uint64(ev.Stamp.UnixMilli()))
+	switch ev.Type {
+	case GossipScore:
+		b = append(b, ev.Score.Scorer[:]...)
+		b = append(b, ev.Score.Scored[:]...)
+		b = append(b, byte(ev.Score.Score))
+	case GossipVerdict:
+		v := ev.Verdict
+		b = append(b, v.MatchID[:]...)
+		b = append(b, v.AtFault[:]...)
+		b = append(b, v.Innocent[:]...)
+		if v.Maker {
+			b = append(b, 1)
+		} else {
+			b = append(b, 0)
+		}
+		b = binary.BigEndian.AppendUint64(b, uint64(v.Stamp.UnixMilli()))
+	case GossipBond:
+		bond := ev.Bond
+		b = append(b, bond.PeerID[:]...)
+		b = binary.BigEndian.AppendUint32(b, bond.AssetID)
+		b = append(b, byte(len(bond.CoinID)))
+		b = append(b, bond.CoinID...)
+		b = binary.BigEndian.AppendUint64(b, bond.Strength)
+		b = binary.BigEndian.AppendUint64(b, unixOrZero(bond.Expiration))
+		b = binary.BigEndian.AppendUint64(b, unixOrZero(bond.Maturation))
+	}
+	return sha256.Sum256(b)
+}
+
+// Verify checks that the event is well-formed and was signed by the Origin.
+func (ev *GossipEvent) Verify() error {
+	if err := ev.check(); err != nil {
+		return fmt.Errorf("invalid gossip event: %w", err)
+	}
+	pubKey, err := ev.Origin.PublicKey()
+	if err != nil {
+		return fmt.Errorf("error parsing origin's public key: %w", err)
+	}
+	signature, err := ecdsa.ParseDERSignature(ev.Sig)
+	if err != nil {
+		return fmt.Errorf("error decoding secp256k1 Signature from bytes: %w", err)
+	}
+	h := ev.Digest()
+	if !signature.Verify(h[:], pubKey) {
+		return fmt.Errorf("secp256k1 signature verification failed")
+	}
+	return nil
+}
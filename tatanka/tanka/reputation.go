@@ -43,4 +43,36 @@ func (bond *Bond) ID() ID32 {
 
 }
 
-type HTLCAudit struct{}
+// HTLCAudit identifies a swap contract to be audited on-chain by a Tatanka
+// node.
+type HTLCAudit struct {
+	AssetID  uint32    `json:"assetID"`
+	CoinID   dex.Bytes `json:"coinID"`
+	Contract dex.Bytes `json:"contract"`
+}
+
+// HTLCStatus is the on-chain state of an audited swap contract.
+type HTLCStatus struct {
+	Recipient  string    `json:"recipient"`
+	Value      uint64    `json:"value"`
+	SecretHash dex.Bytes `json:"secretHash"`
+	LockTime   time.Time `json:"lockTime"`
+	// BlockTime is the time of the block in which the contract was mined.
+	BlockTime time.Time `json:"blockTime"`
+	Redeemed  bool      `json:"redeemed"`
+	Refunded  bool      `json:"refunded"`
+	// Secret is revealed by a redemption.
+	Secret dex.Bytes `json:"secret,omitempty"`
+}
+
+// Verdict is the result of a dispute over a failed swap. Only the at-fault
+// party is penalized.
+type Verdict struct {
+	MatchID ID32   `json:"matchID"`
+	AtFault PeerID `json:"atFault"`
+	// Innocent is the counterparty of the at-fault peer.
+	Innocent PeerID `json:"innocent"`
+	// Maker is true if the at-fault party was the maker.
+	Maker bool      `json:"maker"`
+	Stamp time.Time `json:"stamp"`
+}
@@ -13,6 +13,26 @@ import (
 	"github.com/decred/dcrd/crypto/blake256"
 )
 
+// MaxOrderLifetime is how far in the future an order's expiration can be.
+// Orders are kept on the book by refreshing the expiration with an
+// OrderUpdate. Expired orders are pruned.
+const MaxOrderLifetime = time.Minute * 10
+
+// checkExpiration checks that an order's expiration is in the future, but not
+// further than MaxOrderLifetime. Some clock skew is tolerated.
+func checkExpiration(expiration, now time.Time) error {
+	if expiration.IsZero() {
+		return errors.New("no expiration")
+	}
+	if !expiration.After(now) {
+		return fmt.Errorf("expired at %s", expiration)
+	}
+	if expiration.Sub(now) > MaxOrderLifetime+EpochLength {
+		return fmt.Errorf("expiration %s is too far in the future", expiration)
+	}
+	return nil
+}
+
 type Order struct {
 	From    PeerID `json:"from"`
 	BaseID  uint32 `json:"baseID"`
@@ -28,6 +48,13 @@ type Order struct {
 	LotSize uint64    `json:"lotSize"`
 	Nonce   uint64    `json:"nonce"`
 	Stamp   time.Time `json:"stamp"`
+	// Expiration is when the order is pruned from the book unless refreshed.
+	Expiration time.Time `json:"expiration"`
+	// MaxFeeRate is the highest fee rate that the order's owner will pay for
+	// their swap transaction, on the chain of the asset they are sending.
+	// Orders are unbooked while the mesh fee rate oracle reports a higher
+	// rate.
+	MaxFeeRate uint64 `json:"maxFeeRate"`
 }
 
 func (ord *Order) ID() ID40 {
@@ -38,6 +65,9 @@ func (ord *Order) ID() ID40 {
 }
 
 func (ord *Order) Valid() error {
+	if ord.LotSize == 0 {
+		return errors.New("lot size is zero")
+	}
 	// Check whether the lot size is a power of 2, using binary jiu-jitsu.
 	if ord.LotSize&(ord.LotSize-1) != 0 {
 		return fmt.Errorf("lot size %d is not a power of 2", ord.LotSize)
@@ -54,9 +84,26 @@ func (ord *Order) Valid() error {
 	if ord.Rate == 0 {
 		return errors.New("order rate is zero")
 	}
+	if ord.MaxFeeRate == 0 {
+		return errors.New("max fee rate is zero")
+	}
 	return nil
 }
 
+// CheckExpiration checks that the order is not expired, and that the
+// expiration is not too far in the future.
+func (ord *Order) CheckExpiration(now time.Time) error {
+	return checkExpiration(ord.Expiration, now)
+}
+
+// SwapAssetID is the ID of the asset that the order's owner sends.
+func (ord *Order) SwapAssetID() uint32 {
+	if ord.Sell {
+		return ord.BaseID
+	}
+	return ord.QuoteID
+}
+
 type ID32 [32]byte
 
 func (i ID32) String() string {
@@ -79,13 +126,16 @@ type Match struct {
 }
 
 func (m *Match) ID() ID32 {
-	const msgLen = 32 + 40 + 4 + 4 + 8
+	// The stamp distinguishes repeat matches of the same quantity between
+	// the same order and peer.
+	const msgLen = 32 + 40 + 4 + 4 + 8 + 8
 	b := make([]byte, msgLen)
 	copy(b[:32], m.From[:])
 	copy(b[32:72], m.OrderID[:])
 	binary.BigEndian.PutUint32(b[72:76], m.BaseID)
 	binary.BigEndian.PutUint32(b[76:80], m.QuoteID)
 	binary.BigEndian.PutUint64(b[80:88], m.Qty)
+	binary.BigEndian.PutUint64(b[88:96], uint64(m.Stamp.UnixMilli()))
 	return blake256.Sum256(b)
 }
 
@@ -99,11 +149,14 @@ type MarketParameters struct {
 	QuoteID uint32 `json:"quoteID"`
 }
 
+// OrderUpdate updates the remaining quantity and expiration of a booked
+// order. An update with zero quantity unbooks the order.
 type OrderUpdate struct {
-	From  PeerID    `json:"from"`
-	Nonce uint64    `json:"nonce"`
-	Qty   uint64    `json:"qty"`
-	Stamp time.Time `json:"stamp"`
+	From       PeerID    `json:"from"`
+	Nonce      uint64    `json:"nonce"`
+	Qty        uint64    `json:"qty"`
+	Stamp      time.Time `json:"stamp"`
+	Expiration time.Time `json:"expiration"`
 }
 
 func (ou *OrderUpdate) ID() ID40 {
@@ -112,3 +165,13 @@ func (ou *OrderUpdate) ID() ID40 {
 	binary.BigEndian.PutUint64(b[32:], ou.Nonce)
 	return b
 }
+
+// CheckExpiration checks that the updated expiration is not in the past, and
+// is not too far in the future. An update that unbooks the order doesn't need
+// an expiration.
+func (ou *OrderUpdate) CheckExpiration(now time.Time) error {
+	if ou.Qty == 0 {
+		return nil
+	}
+	return checkExpiration(ou.Expiration, now)
+}
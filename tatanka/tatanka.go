@@ -42,6 +42,9 @@ const (
 type remoteTatanka struct {
 	*peer
 	cfg atomic.Value // mj.TatankaConfig
+	// lastMessage is the time of the last valid message from the node, in
+	// unix milliseconds.
+	lastMessage atomic.Int64
 }
 
 type Topic struct {
@@ -62,18 +65,6 @@ func (topic *Topic) unsubUser(peerID tanka.PeerID) {
 	delete(topic.subscribers, peerID)
 }
 
-// BootNode represents a configured boot node. Tatanka is whitelist only, and
-// node operators are responsible for keeping their whitelist up to date.
-type BootNode struct {
-	// Protocol is one of ("ws", "wss"), though other tatanka comms protocols
-	// may be implemented later. Or we may end up using e.g. go-libp2p.
-	Protocol string    `json:"protocol"`
-	PeerID   dex.Bytes `json:"peer_id"`
-	// Config can take different forms depending on the comms protocol, but is
-	// probably a tcp.RemoteNodeConfig.
-	Config json.RawMessage `json:"config"`
-}
-
 // Draft note: Why do we need a parsedBootNode? Why not just make BootNode
 // have a tanka.PeerID instead of a dex.Bytes? Also, instead of having a
 // TatankaCredentials in client/conn, can we use BootNode as well?
@@ -135,6 +126,9 @@ type Tatanka struct {
 
 	feeRatesOracle       *feerates.Oracle
 	feeRateEstimatesChan chan map[uint32]*feerates.Estimate
+
+	routeStatsMtx sync.Mutex
+	routeStats    map[string]*RouteStats
 }
 
 // Config is the configuration of the Tatanka.
@@ -148,7 +142,7 @@ type Config struct {
 
 	feeRatesOracleCfg feerates.Config
 
-	WhiteList []BootNode
+	WhiteList []mj.BootNode
 
 	FiatOracleConfig fiatrates.Config
 }
@@ -301,7 +295,9 @@ func (t *Tatanka) prepareHandlers() {
 		mj.RouteClientDisconnect: t.handleRemoteClientDisconnect,
 		mj.RouteRelayTankagram:   t.handleRelayedTankagram,
 		mj.RoutePathInquiry:      t.handlePathInquiry,
-		mj.RouteShareScore:       t.handleShareScore,
+		mj.RouteShareDispute:     t.handleShareDispute,
+		mj.RouteGossip:           t.handleGossip,
+		mj.RouteGossipSync:       t.handleGossipSync,
 	} {
 		registerTatankaHandler(route, handler)
 	}
@@ -321,6 +317,7 @@ func (t *Tatanka) prepareHandlers() {
 		mj.RouteBroadcast: t.handleBroadcast,
 		mj.RouteTankagram: t.handleTankagram,
 		mj.RouteSetScore:  t.handleSetScore,
+		mj.RouteDispute:   t.handleDispute,
 	} {
 		registerClientHandler(route, handler)
 	}
@@ -493,6 +490,13 @@ func (t *Tatanka) runWhitelistLoop(ctx context.Context) {
 				continue
 			}
 
+			if banned, err := t.db.IsBlacklisted(n.peerID); err != nil || banned {
+				if err != nil {
+					t.log.Errorf("error checking blacklist for boot node at %q (proto %q): %v", string(n.cfg), proto, err)
+				}
+				continue
+			}
+
 			p, err := t.db.Peer(n.peerID)
 			if err != nil {
 				t.log.Errorf("error getting peer info for boot node at %q (proto %q): %v", string(n.cfg), proto, err)
@@ -513,7 +517,7 @@ func (t *Tatanka) runWhitelistLoop(ctx context.Context) {
 			}
 
 			handleMessage := func(cl tanka.Sender, msg *msgjson.Message) {
-				t.handleTatankaMessage(cl, msg)
+				t.countMessage(msg.Route, t.handleTatankaMessage(cl, msg))
 			}
 
 			var cl tanka.Sender
@@ -540,7 +544,17 @@ func (t *Tatanka) runWhitelistLoop(ctx context.Context) {
 
 			cfgMsg := mj.MustRequest(mj.RouteTatankaConnect, t.generateConfig(bondTier))
 			if err := t.request(cl, cfgMsg, func(msg *msgjson.Message) {
-				// Nothing to do. The only non-error result is payload = true.
+				// The only non-error result is payload = true.
+				var connected bool
+				if err := msg.UnmarshalResult(&connected); err != nil || !connected {
+					t.log.Errorf("Error connecting to boot node %s: %v", p.ID, err)
+					return
+				}
+				t.wg.Add(1)
+				go func() {
+					defer t.wg.Done()
+					t.syncGossip(tt)
+				}()
 			}); err != nil {
 				t.log.Errorf("Error sending connect message: %w", err)
 				cl.Disconnect()
@@ -680,19 +694,19 @@ func (t *tcpCore) HandleMessage(cl tanka.Sender, msg *msgjson.Message) *msgjson.
 		t.log.Tracef("Tatanka node handling message. route = %s, payload = %s", msg.Route, string(msg.Payload))
 	}
 
+	var msgErr *msgjson.Error
 	if _, found := t.clientHandlers[msg.Route]; found {
-		return t.handleClientMessage(cl, msg)
-	}
-	if _, found := t.tatankaHandlers[msg.Route]; found {
-		return t.handleTatankaMessage(cl, msg)
-	}
-	if handle, found := t.specialHandlers[msg.Route]; found {
-		return handle(cl, msg)
-	}
-
-	fmt.Println("no route found", msg.Route)
-
-	return msgjson.NewError(mj.ErrBadRequest, "route %q not known", msg.Route)
+		msgErr = t.handleClientMessage(cl, msg)
+	} else if _, found := t.tatankaHandlers[msg.Route]; found {
+		msgErr = t.handleTatankaMessage(cl, msg)
+	} else if handle, found := t.specialHandlers[msg.Route]; found {
+		msgErr = handle(cl, msg)
+	} else {
+		t.log.Debugf("no route found for %q message", msg.Route)
+		return msgjson.NewError(mj.ErrBadRequest, "route %q not known", msg.Route)
+	}
+	t.countMessage(msg.Route, msgErr)
+	return msgErr
 }
 
 func (t *tcpCore) HTTPRoutes() []string {
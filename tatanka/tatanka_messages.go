@@ -32,6 +32,12 @@ func (t *Tatanka) handleInboundTatankaConnect(cl tanka.Sender, msg *msgjson.Mess
 		return msgjson.NewError(mj.ErrAuth, "bad sig")
 	}
 
+	if banned, err := t.db.IsBlacklisted(cfg.ID); err != nil {
+		return msgjson.NewError(mj.ErrInternal, "error checking blacklist: %v", err)
+	} else if banned {
+		return msgjson.NewError(mj.ErrBanned, "blacklisted")
+	}
+
 	// if calcTier(rep, p.BondTier()) <= 0 {
 	// 	return msgjson.NewError(mj.ErrAuth, "denying inbound banned tatanka node %q", p.ID)
 	// }
@@ -90,6 +96,12 @@ func (t *Tatanka) handleInboundTatankaConnect(cl tanka.Sender, msg *msgjson.Mess
 
 	t.sendResult(cl, msg.ID, true)
 
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.syncGossip(tt)
+	}()
+
 	return nil
 }
 
@@ -117,6 +129,7 @@ func (t *Tatanka) handleTatankaMessage(cl tanka.Sender, msg *msgjson.Message) *m
 		t.log.Errorf("Signature error for %q message from %q: %v", msg.Route, tt.ID, err)
 		return msgjson.NewError(mj.ErrSig, "signature doesn't check")
 	}
+	tt.lastMessage.Store(time.Now().UnixMilli())
 
 	// The first message received after mj.RouteTatankaConnect must be the
 	// config.
@@ -283,31 +296,3 @@ func (t *Tatanka) handleRelayBroadcast(tt *remoteTatanka, msg *msgjson.Message)
 		return
 	}
 }
-
-func (t *Tatanka) handleShareScore(tt *remoteTatanka, msg *msgjson.Message) {
-	var ss mj.SharedScore
-	if err := msg.Unmarshal(&ss); err != nil {
-		t.log.Errorf("error unmarshaling shared score: %v", err)
-		return
-	}
-	if err := t.db.SetScore(ss.Scored, ss.Scorer, ss.Score, time.Now()); err != nil {
-		return
-	}
-	t.clientMtx.RLock()
-	c, found := t.clients[ss.Scored]
-	t.clientMtx.RUnlock()
-	if !found {
-		return
-	}
-
-	rep, err := t.db.Reputation(ss.Scored)
-	if err != nil {
-		t.log.Errorf("error updating reputation after shared score update: %v", err)
-		return
-	}
-
-	c.mtx.Lock()
-	c.Reputation = rep
-	c.rrs[tt.ID] = ss.Reputation
-	c.mtx.Unlock()
-}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"decred.org/dcrdex/dex/encode"
 	"decred.org/dcrdex/dex/msgjson"
 	"decred.org/dcrdex/server/comms"
+	"decred.org/dcrdex/tatanka/db"
 	"decred.org/dcrdex/tatanka/mj"
 	"decred.org/dcrdex/tatanka/tanka"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -223,3 +226,449 @@ func TestPrepareHandlers(t *testing.T) {
 	// Incorrect call signatures will cause a panic in prepareHandlers.
 	tNewTatanka().prepareHandlers()
 }
+
+func TestJudgeDispute(t *testing.T) {
+	reporter, _ := tNewClient(1)
+	priv, _ := secp256k1.GeneratePrivateKey()
+	var accused tanka.PeerID
+	copy(accused[:], priv.PubKey().SerializeCompressed())
+
+	const window = time.Hour
+	now := time.Now()
+	secretHash := encode.RandomBytes(32)
+	newCase := func(maker bool) (*mj.Dispute, *tanka.HTLCStatus) {
+		c := &mj.SwapCommitment{
+			Signer:       accused,
+			Counterparty: reporter.ID,
+			Maker:        maker,
+			AssetID:      42,
+			Address:      "addr",
+			Value:        100,
+		}
+		htlc := &tanka.HTLCStatus{
+			Recipient: "addr",
+			Value:     100,
+			LockTime:  now.Add(-time.Minute),
+			BlockTime: now.Add(-window * 2),
+		}
+		if maker {
+			c.SecretHash = secretHash
+			c.LockTime = now.Add(window)
+			htlc.SecretHash = secretHash
+		} else {
+			c.LockTime = htlc.LockTime
+			c.Deadline = htlc.BlockTime.Add(time.Minute)
+		}
+		c.Sign(priv)
+		return &mj.Dispute{Commitment: c, Contract: &tanka.HTLCAudit{AssetID: 42}}, htlc
+	}
+
+	for _, tt := range []struct {
+		name  string
+		maker bool
+		mod   func(*mj.Dispute, *tanka.HTLCStatus)
+		// badDispute is true if checkDispute should fail. Otherwise, atFault
+		// is the expected judgement.
+		badDispute bool
+		atFault    bool
+	}{
+		{name: "maker at fault", maker: true, atFault: true},
+		{name: "taker at fault", atFault: true},
+		{name: "not made to reporter", mod: func(d *mj.Dispute, _ *tanka.HTLCStatus) {
+			d.Commitment.Counterparty = tanka.PeerID{0x02}
+		}, badDispute: true},
+		{name: "wrong asset", mod: func(d *mj.Dispute, _ *tanka.HTLCStatus) {
+			d.Contract.AssetID = 0
+		}, badDispute: true},
+		{name: "bad signature", mod: func(d *mj.Dispute, _ *tanka.HTLCStatus) {
+			d.Commitment.Value = 1
+		}, badDispute: true},
+		{name: "wrong recipient", mod: func(_ *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.Recipient = "other"
+		}},
+		{name: "low value", mod: func(_ *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.Value = 99
+		}},
+		{name: "redeemed", maker: true, mod: func(_ *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.Redeemed = true
+		}},
+		{name: "not expired", mod: func(_ *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.LockTime = now.Add(time.Minute)
+		}},
+		{name: "mined too late", maker: true, mod: func(_ *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.BlockTime = htlc.LockTime.Add(-window / 2)
+		}},
+		{name: "wrong secret hash", maker: true, mod: func(_ *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.SecretHash = encode.RandomBytes(32)
+		}},
+		{name: "taker's swap expires after maker's", maker: true, mod: func(d *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.LockTime = d.Commitment.LockTime
+		}},
+		{name: "maker's swap expires too soon", mod: func(d *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.LockTime = d.Commitment.LockTime.Add(-time.Second)
+		}},
+		{name: "maker's swap after deadline", mod: func(d *mj.Dispute, htlc *tanka.HTLCStatus) {
+			htlc.BlockTime = d.Commitment.Deadline.Add(time.Second)
+		}},
+	} {
+		d, htlc := newCase(tt.maker)
+		if tt.mod != nil {
+			tt.mod(d, htlc)
+		}
+		if err := checkDispute(reporter.ID, d); err != nil {
+			if !tt.badDispute {
+				t.Fatalf("%s: unexpected checkDispute error: %v", tt.name, err)
+			}
+			continue
+		}
+		if tt.badDispute {
+			t.Fatalf("%s: no checkDispute error", tt.name)
+		}
+		err := judgeDispute(d.Commitment, htlc, window, now)
+		if atFault := err == nil; atFault != tt.atFault {
+			t.Fatalf("%s: expected at fault = %t, got %t, err = %v", tt.name, tt.atFault, atFault, err)
+		}
+	}
+}
+
+// tLink is a tanka.Sender that delivers messages directly to another
+// in-process Tatanka node.
+type tLink struct {
+	to      *Tatanka
+	reverse *tLink
+	gossips atomic.Uint32
+
+	mtx          sync.Mutex
+	respHandlers map[uint64]func(*msgjson.Message)
+}
+
+func (l *tLink) deliver(msg *msgjson.Message) {
+	if msg.Route == mj.RouteGossip {
+		l.gossips.Add(1)
+	}
+	if msgErr := l.to.handleTatankaMessage(l.reverse, msg); msgErr != nil && msg.Type == msgjson.Request {
+		resp, _ := msgjson.NewResponse(msg.ID, nil, msgErr)
+		l.reverse.Send(resp)
+	}
+}
+
+func (l *tLink) Send(msg *msgjson.Message) error {
+	if msg.Type == msgjson.Response {
+		l.reverse.mtx.Lock()
+		respHandler := l.reverse.respHandlers[msg.ID]
+		delete(l.reverse.respHandlers, msg.ID)
+		l.reverse.mtx.Unlock()
+		if respHandler != nil {
+			go respHandler(msg)
+		}
+		return nil
+	}
+	l.deliver(msg)
+	return nil
+}
+
+func (l *tLink) SendRaw(rawMsg []byte) error {
+	msg, err := msgjson.DecodeMessage(rawMsg)
+	if err != nil {
+		return err
+	}
+	return l.Send(msg)
+}
+
+func (l *tLink) Request(msg *msgjson.Message, respHandler func(*msgjson.Message)) error {
+	l.mtx.Lock()
+	l.respHandlers[msg.ID] = respHandler
+	l.mtx.Unlock()
+	l.deliver(msg)
+	return nil
+}
+
+func (l *tLink) RequestRaw(msgID uint64, rawMsg []byte, respHandler func(*msgjson.Message)) error {
+	msg, err := msgjson.DecodeMessage(rawMsg)
+	if err != nil {
+		return err
+	}
+	return l.Request(msg, respHandler)
+}
+
+func (l *tLink) SetPeerID(tanka.PeerID) {}
+
+func (l *tLink) PeerID() tanka.PeerID {
+	return l.to.id
+}
+
+func (l *tLink) Disconnect() {}
+
+// tNewGossipTatanka is a Tatanka with a database, for testing interactions
+// between nodes.
+func tNewGossipTatanka(t *testing.T) *Tatanka {
+	tt := tNewTatanka()
+	var err error
+	if tt.db, err = db.New(t.TempDir(), dex.StdOutLogger("DB", dex.LevelInfo)); err != nil {
+		t.Fatalf("Error creating db: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	tt.ctx = ctx
+	tt.wg = new(sync.WaitGroup)
+	tt.whitelist = make(map[tanka.PeerID]*parsedBootNode)
+	tt.prepareHandlers()
+	t.Cleanup(func() {
+		cancel()
+		tt.wg.Wait()
+		tt.db.Close()
+	})
+	return tt
+}
+
+// tConnectTatankas connects the nodes, returning the link from
+// a to b and the link from b to a.
+func tConnectTatankas(a, b *Tatanka) (*tLink, *tLink) {
+	ab := &tLink{to: b, respHandlers: make(map[uint64]func(*msgjson.Message))}
+	ba := &tLink{to: a, reverse: ab, respHandlers: make(map[uint64]func(*msgjson.Message))}
+	ab.reverse = ba
+	addRemote := func(n, remote *Tatanka, l *tLink) {
+		p := &tanka.Peer{ID: remote.id, PubKey: remote.priv.PubKey()}
+		tt := &remoteTatanka{peer: &peer{Peer: p, Sender: l, rrs: make(map[tanka.PeerID]*tanka.Reputation)}}
+		tt.cfg.Store(&mj.TatankaConfig{ID: remote.id})
+		n.tatankasMtx.Lock()
+		n.tatankas[remote.id] = tt
+		n.tatankasMtx.Unlock()
+	}
+	addRemote(a, b, ab)
+	addRemote(b, a, ba)
+	return ab, ba
+}
+
+func tNewPeerID() (*secp256k1.PrivateKey, tanka.PeerID) {
+	priv, _ := secp256k1.GeneratePrivateKey()
+	var peerID tanka.PeerID
+	copy(peerID[:], priv.PubKey().SerializeCompressed())
+	return priv, peerID
+}
+
+func TestGossip(t *testing.T) {
+	// The nodes are connected in a line, a - b - c. d is offline.
+	a, b, c, d := tNewGossipTatanka(t), tNewGossipTatanka(t), tNewGossipTatanka(t), tNewGossipTatanka(t)
+	nodes := []*Tatanka{a, b, c, d}
+	for _, n := range nodes {
+		for _, remote := range nodes {
+			n.whitelist[remote.id] = &parsedBootNode{peerID: remote.id}
+		}
+	}
+	abLink, baLink := tConnectTatankas(a, b)
+	bcLink, cbLink := tConnectTatankas(b, c)
+
+	_, scorer := tNewPeerID()
+	_, scored := tNewPeerID()
+
+	checkPeer := func(n *Tatanka, expTier int64) {
+		t.Helper()
+		p, err := n.db.Peer(scored)
+		if err != nil {
+			t.Fatalf("Error retrieving peer: %v", err)
+		}
+		if tier := calcTier(p.Reputation, p.BondTier()); tier != expTier {
+			t.Fatalf("Expected tier %d, got %d", expTier, tier)
+		}
+	}
+
+	// A bond accepted by a reaches c through b.
+	bond := &tanka.Bond{
+		PeerID:     scored,
+		AssetID:    42,
+		CoinID:     encode.RandomBytes(32),
+		Strength:   1,
+		Expiration: time.Now().Add(time.Hour),
+	}
+	if stored, err := a.gossipEvent(nil, nil, bond); err != nil || !stored {
+		t.Fatalf("Bond not stored. err = %v", err)
+	}
+	checkPeer(c, 1)
+
+	// The scored client is connected to c, and sees the score set at a.
+	p, err := c.db.Peer(scored)
+	if err != nil {
+		t.Fatalf("Error retrieving peer: %v", err)
+	}
+	cl := &client{peer: &peer{Peer: p, Sender: tNewSender(scored), rrs: make(map[tanka.PeerID]*tanka.Reputation)}}
+	c.clients[scored] = cl
+	scorerClient := &client{peer: &peer{Peer: &tanka.Peer{ID: scorer}}}
+	setScore := func(score int8) {
+		a.handleSetScore(scorerClient, mj.MustNotification(mj.RouteSetScore, &mj.ScoreReport{PeerID: scored, Score: score}))
+	}
+	setScore(100)
+	checkPeer(c, 1+100/tanka.TierIncrement)
+	if cl.banned() {
+		t.Fatalf("Client banned")
+	}
+
+	// Events are only relayed forward.
+	if n := abLink.gossips.Load(); n != 2 {
+		t.Fatalf("Expected 2 events from a to b, got %d", n)
+	}
+	if n := bcLink.gossips.Load(); n != 2 {
+		t.Fatalf("Expected 2 events from b to c, got %d", n)
+	}
+	if baLink.gossips.Load() != 0 || cbLink.gossips.Load() != 0 {
+		t.Fatalf("Events relayed backwards")
+	}
+
+	// A duplicate isn't relayed again.
+	ev, _ := tanka.NewGossipEvent(a.priv, &tanka.ScoreEvent{Scorer: scorer, Scored: scored, Score: 110}, nil, nil)
+	note := mj.MustNotification(mj.RouteGossip, ev)
+	mj.SignMessage(a.priv, note)
+	abLink.deliver(note)
+	abLink.deliver(note)
+	if n := bcLink.gossips.Load(); n != 3 {
+		t.Fatalf("Expected 3 events from b to c, got %d", n)
+	}
+	checkPeer(c, 1+110/tanka.TierIncrement)
+
+	// A conflicting bond claim is rejected.
+	ev, _ = tanka.NewGossipEvent(a.priv, nil, nil, &tanka.Bond{
+		PeerID:     scorer,
+		AssetID:    bond.AssetID,
+		CoinID:     bond.CoinID,
+		Strength:   1,
+		Expiration: bond.Expiration,
+	})
+	if err := c.checkGossip(ev); err != nil {
+		t.Fatalf("checkGossip error: %v", err)
+	}
+	if stored, err := c.processGossip(ev, b.id); err != nil || stored {
+		t.Fatalf("Conflicting bond stored. err = %v", err)
+	}
+
+	// Events from unknown origins or with bad signatures are rejected.
+	rogue, _ := tNewPeerID()
+	ev, _ = tanka.NewGossipEvent(rogue, &tanka.ScoreEvent{Scorer: scorer, Scored: scored, Score: -128}, nil, nil)
+	if err := c.checkGossip(ev); err == nil {
+		t.Fatalf("No error for unknown origin")
+	}
+	ev, _ = tanka.NewGossipEvent(a.priv, &tanka.ScoreEvent{Scorer: scorer, Scored: scored, Score: 1}, nil, nil)
+	ev.Score.Score = -128
+	if err := c.checkGossip(ev); err == nil {
+		t.Fatalf("No error for bad signature")
+	}
+
+	// d comes online and catches up.
+	tConnectTatankas(c, d)
+	d.syncGossip(d.tatankaNode(c.id))
+	checkPeer(d, 1+110/tanka.TierIncrement)
+	cursor, err := d.db.GossipCursor(c.id)
+	if err != nil || cursor == 0 {
+		t.Fatalf("Cursor not stored. cursor = %d, err = %v", cursor, err)
+	}
+	// Nothing new on a second sync.
+	d.syncGossip(d.tatankaNode(c.id))
+	if reCursor, _ := d.db.GossipCursor(c.id); reCursor != cursor {
+		t.Fatalf("Cursor changed from %d to %d with no new events", cursor, reCursor)
+	}
+
+	// New events now reach d, and the client on c is banned.
+	setScore(-128)
+	checkPeer(d, 1-128/tanka.TierIncrement)
+	if !cl.banned() {
+		t.Fatalf("Client not banned")
+	}
+}
+
+func TestOperatorActions(t *testing.T) {
+	n, remote := tNewGossipTatanka(t), tNewGossipTatanka(t)
+	n.maxClients = 10
+	n.nets.Store([]uint32{42})
+	n.whitelist[remote.id] = &parsedBootNode{peerID: remote.id}
+	tConnectTatankas(n, remote)
+	// The remote node registers clients announced by n.
+	go remote.runRemoteClientsLoop(remote.ctx)
+
+	cl, clSender := tNewClient(0x01)
+	n.clients[cl.ID] = cl
+	const topic, subject tanka.Topic = "market", "dcr_btc"
+	n.topics[topic] = &Topic{
+		subjects:    map[tanka.Subject]map[tanka.PeerID]struct{}{tanka.Subject(subject): {cl.ID: {}}},
+		subscribers: map[tanka.PeerID]struct{}{cl.ID: {}},
+	}
+
+	clients := n.Clients()
+	if len(clients) != 1 || clients[0].ID != cl.ID.String() || len(clients[0].Topics) != 1 || clients[0].Topics[0] != topic {
+		t.Fatalf("wrong clients")
+	}
+	topics := n.Topics()
+	if len(topics) != 1 || topics[0].Subscribers != 1 || topics[0].Subjects[tanka.Subject(subject)] != 1 {
+		t.Fatalf("wrong topics")
+	}
+
+	// Remote tatankas report their configuration and last message.
+	tts := n.Tatankas()
+	if len(tts) != 1 || !tts[0].Whitelisted || !tts[0].Configured || !tts[0].LastMessage.IsZero() {
+		t.Fatalf("wrong tatankas")
+	}
+	note := mj.MustNotification(mj.RouteGossip, nil)
+	mj.SignMessage(remote.priv, note)
+	n.handleTatankaMessage(n.tatankaNode(remote.id), note)
+	if n.Tatankas()[0].LastMessage.IsZero() {
+		t.Fatalf("last message not recorded")
+	}
+
+	for i := byte(1); i <= 3; i++ {
+		n.db.StoreBond(&tanka.Bond{
+			PeerID:     tanka.PeerID{i % 2},
+			AssetID:    42,
+			CoinID:     []byte{i},
+			Strength:   uint64(i),
+			Expiration: time.Now().Add(time.Hour),
+		})
+	}
+	totals, err := n.BondTotals()
+	if err != nil {
+		t.Fatalf("BondTotals error: %v", err)
+	}
+	if len(totals) != 1 || totals[0].Bonds != 3 || totals[0].Bonders != 2 || totals[0].Strength != 6 {
+		t.Fatalf("wrong bond totals")
+	}
+
+	n.countMessage(mj.RouteConnect, nil)
+	n.countMessage(mj.RouteConnect, msgjson.NewError(mj.ErrAuth, "bad"))
+	if stats := n.RouteStats()[mj.RouteConnect]; stats == nil || stats.Received != 2 || stats.Errors != 1 {
+		t.Fatalf("wrong route stats")
+	}
+
+	// Disconnecting a client unsubscribes it.
+	if err := n.DisconnectPeer(cl.ID); err != nil {
+		t.Fatalf("DisconnectPeer error: %v", err)
+	}
+	if !clSender.disconnected || n.clientNode(cl.ID) != nil || n.Topics()[0].Subscribers != 0 {
+		t.Fatalf("client not disconnected")
+	}
+	if err := n.DisconnectPeer(cl.ID); !errors.Is(err, ErrUnknownPeer) {
+		t.Fatalf("wrong error for unknown peer: %v", err)
+	}
+
+	// A blacklisted peer is disconnected and can't reconnect.
+	priv, peerID := tNewPeerID()
+	n.clients[peerID] = &client{peer: &peer{Peer: &tanka.Peer{ID: peerID}, Sender: tNewSender(peerID)}}
+	if err := n.BlacklistPeer(peerID); err != nil {
+		t.Fatalf("BlacklistPeer error: %v", err)
+	}
+	if n.clientNode(peerID) != nil {
+		t.Fatalf("blacklisted client not disconnected")
+	}
+	if bl, err := n.Blacklist(); err != nil || len(bl) != 1 || bl[0].ID != peerID.String() {
+		t.Fatalf("wrong blacklist. err = %v", err)
+	}
+	connect := func() *msgjson.Error {
+		msg := mj.MustRequest(mj.RouteConnect, &mj.Connect{ID: peerID})
+		mj.SignMessage(priv, msg)
+		return n.handleClientConnect(tNewSender(peerID), msg)
+	}
+	if msgErr := connect(); msgErr == nil || msgErr.Code != mj.ErrBanned {
+		t.Fatalf("blacklisted client connected. err = %v", msgErr)
+	}
+	if err := n.UnblacklistPeer(peerID); err != nil {
+		t.Fatalf("UnblacklistPeer error: %v", err)
+	}
+	if msgErr := connect(); msgErr != nil {
+		t.Fatalf("connect error after removal from blacklist: %v", msgErr)
+	}
+}